@@ -31,6 +31,7 @@ import (
 
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
 	"github.com/m3db/m3/src/aggregator/client"
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
 	"github.com/m3db/m3/src/aggregator/sharding"
 	"github.com/m3db/m3/src/cluster/placement"
 	"github.com/m3db/m3/src/cluster/shard"
@@ -81,6 +82,20 @@ type Aggregator interface {
 	// Status returns the run-time status of the aggregator.
 	Status() RuntimeStatus
 
+	// Entries returns the snapshots of up to limit entries in the given shard,
+	// the limit is capped server-side and the cap is used if not positive.
+	Entries(shard uint32, limit int) ([]EntrySnapshot, error)
+
+	// MetricEntries returns the snapshots of the entries for the given metric id.
+	MetricEntries(id id.RawID) ([]EntrySnapshot, error)
+
+	// FlushTimes returns the latest flush times of the shard set.
+	FlushTimes() (*schema.ShardSetFlushTimes, error)
+
+	// Placement returns the currently active placement of the active
+	// staged placement.
+	Placement() (placement.Placement, error)
+
 	// Close closes the aggregator.
 	Close() error
 }
@@ -247,6 +262,39 @@ func (agg *aggregator) Status() RuntimeStatus {
 	}
 }
 
+func (agg *aggregator) Entries(shard uint32, limit int) ([]EntrySnapshot, error) {
+	agg.RLock()
+	if agg.state != aggregatorOpen {
+		agg.RUnlock()
+		return nil, errAggregatorNotOpenOrClosed
+	}
+	if int(shard) >= len(agg.shards) || agg.shards[shard] == nil {
+		agg.RUnlock()
+		return nil, errShardNotOwned
+	}
+	aggShard := agg.shards[shard]
+	agg.RUnlock()
+
+	return aggShard.Snapshot(limit), nil
+}
+
+func (agg *aggregator) MetricEntries(id id.RawID) ([]EntrySnapshot, error) {
+	shard, err := agg.shardFor(id)
+	if err != nil {
+		return nil, err
+	}
+	return shard.SnapshotFor(id), nil
+}
+
+func (agg *aggregator) FlushTimes() (*schema.ShardSetFlushTimes, error) {
+	return agg.flushTimesManager.Get()
+}
+
+func (agg *aggregator) Placement() (placement.Placement, error) {
+	_, placement, err := agg.placementManager.Placement()
+	return placement, err
+}
+
 func (agg *aggregator) Close() error {
 	agg.Lock()
 	defer agg.Unlock()
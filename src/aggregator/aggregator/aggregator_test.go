@@ -679,6 +679,104 @@ func TestAggregatorStatus(t *testing.T) {
 	require.Equal(t, RuntimeStatus{FlushStatus: flushStatus}, agg.Status())
 }
 
+func TestAggregatorEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg, _ := testAggregator(t, ctrl)
+	_, err := agg.Entries(1, 0)
+	require.Equal(t, errAggregatorNotOpenOrClosed, err)
+
+	require.NoError(t, agg.Open())
+	agg.shardFn = func([]byte, uint32) uint32 { return 1 }
+	require.NoError(t, agg.AddUntimed(testUntimedMetric, testStagedMetadatas))
+	bar := testUntimedMetric
+	bar.ID = []byte("bar")
+	require.NoError(t, agg.AddUntimed(bar, testStagedMetadatas))
+
+	_, err = agg.Entries(testNumShards, 0)
+	require.Equal(t, errShardNotOwned, err)
+
+	entries, err := agg.Entries(1, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(entries))
+	require.Equal(t, "foo", entries[0].ID)
+	require.Equal(t, "bar", entries[1].ID)
+
+	entries, err = agg.Entries(1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	require.Equal(t, "foo", entries[0].ID)
+}
+
+func TestAggregatorMetricEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg, _ := testAggregator(t, ctrl)
+	require.NoError(t, agg.Open())
+	agg.shardFn = func([]byte, uint32) uint32 { return 1 }
+	require.NoError(t, agg.AddUntimed(testUntimedMetric, testStagedMetadatas))
+
+	entries, err := agg.MetricEntries(testUntimedMetric.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(entries))
+	entry := entries[0]
+	require.Equal(t, "foo", entry.ID)
+	require.Equal(t, "untimed", entry.Category)
+	require.Equal(t, "counter", entry.Type)
+	require.NotEmpty(t, entry.Elems)
+	for _, elem := range entry.Elems {
+		require.Equal(t, "foo", elem.ID)
+		require.Equal(t, "counter", elem.Type)
+		require.Equal(t, []string{"Sum"}, elem.AggregationTypes)
+		require.False(t, elem.Tombstoned)
+		require.Equal(t, 1, len(elem.Values))
+		require.Equal(t, map[string]float64{"Sum": 1234}, elem.Values[0].Values)
+	}
+
+	entries, err = agg.MetricEntries([]byte("bar"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	agg.shardFn = func([]byte, uint32) uint32 { return testNumShards }
+	_, err = agg.MetricEntries(testUntimedMetric.ID)
+	require.Equal(t, errShardNotOwned, err)
+}
+
+func TestAggregatorFlushTimes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	flushTimes := &schema.ShardSetFlushTimes{
+		ByShard: map[uint32]*schema.ShardFlushTimes{
+			1: {
+				StandardByResolution: map[int64]int64{int64(time.Second): 1000},
+			},
+		},
+	}
+	flushTimesManager := NewMockFlushTimesManager(ctrl)
+	flushTimesManager.EXPECT().Get().Return(flushTimes, nil)
+	agg, _ := testAggregator(t, ctrl)
+	agg.flushTimesManager = flushTimesManager
+	res, err := agg.FlushTimes()
+	require.NoError(t, err)
+	require.Equal(t, flushTimes, res)
+}
+
+func TestAggregatorPlacement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	agg, _ := testAggregator(t, ctrl)
+	require.NoError(t, agg.Open())
+	placement, err := agg.Placement()
+	require.NoError(t, err)
+	require.Equal(t, testNumShards, placement.NumShards())
+	_, ok := placement.Instance(testInstanceID)
+	require.True(t, ok)
+}
+
 func TestAggregatorCloseAlreadyClosed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
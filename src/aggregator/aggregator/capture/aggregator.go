@@ -21,10 +21,13 @@
 package capture
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
 	aggr "github.com/m3db/m3/src/aggregator/aggregator"
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/cluster/placement"
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
@@ -33,6 +36,8 @@ import (
 	"github.com/m3db/m3/src/metrics/policy"
 )
 
+var errNoPlacement = errors.New("capturing aggregator has no placement")
+
 // aggregator is an aggregator that simply captures metrics coming
 // into the aggregator without actually performing aggregations.
 // It is useful for testing purposes.
@@ -135,6 +140,17 @@ func (agg *aggregator) Resign() error              { return nil }
 func (agg *aggregator) Status() aggr.RuntimeStatus { return aggr.RuntimeStatus{} }
 func (agg *aggregator) Close() error               { return nil }
 
+func (agg *aggregator) Entries(uint32, int) ([]aggr.EntrySnapshot, error)    { return nil, nil }
+func (agg *aggregator) MetricEntries(id.RawID) ([]aggr.EntrySnapshot, error) { return nil, nil }
+
+func (agg *aggregator) FlushTimes() (*schema.ShardSetFlushTimes, error) {
+	return &schema.ShardSetFlushTimes{}, nil
+}
+
+func (agg *aggregator) Placement() (placement.Placement, error) {
+	return nil, errNoPlacement
+}
+
 func (agg *aggregator) NumMetricsAdded() int {
 	agg.RLock()
 	numMetricsAdded := agg.numMetricsAdded
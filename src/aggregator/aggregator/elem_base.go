@@ -143,6 +143,9 @@ type metricElem interface {
 	// will be deleted once its aggregated values have been flushed.
 	MarkAsTombstoned()
 
+	// Snapshot returns a snapshot of the element and its pending aggregations.
+	Snapshot() ElemSnapshot
+
 	// Close closes the element.
 	Close()
 }
@@ -248,6 +251,26 @@ func (e *elemBase) MarkAsTombstoned() {
 	e.Unlock()
 }
 
+// snapshotWithLock returns a snapshot of the element states shared
+// by all element types.
+func (e *elemBase) snapshotWithLock() ElemSnapshot {
+	aggTypes := make([]string, 0, len(e.aggTypes))
+	for _, aggType := range e.aggTypes {
+		aggTypes = append(aggTypes, aggType.String())
+	}
+	snapshot := ElemSnapshot{
+		ID:                string(e.id),
+		StoragePolicy:     e.sp.String(),
+		AggregationTypes:  aggTypes,
+		NumForwardedTimes: e.numForwardedTimes,
+		Tombstoned:        e.tombstoned,
+	}
+	if forwardedID, ok := e.ForwardedID(); ok {
+		snapshot.ForwardedID = string(forwardedID)
+	}
+	return snapshot
+}
+
 type counterElemBase struct{}
 
 func (e counterElemBase) Type() metric.Type { return metric.CounterType }
@@ -1625,6 +1625,222 @@ func TestGaugeElemConsumeCustomAggregationCustomPipeline(t *testing.T) {
 	require.Equal(t, 0, len(e.values))
 }
 
+func TestGaugeElemConsumeIncreaseResetPipeline(t *testing.T) {
+	alignedstartAtNanos := []int64{
+		time.Unix(210, 0).UnixNano(),
+		time.Unix(220, 0).UnixNano(),
+		time.Unix(230, 0).UnixNano(),
+		time.Unix(240, 0).UnixNano(),
+	}
+	gaugeVals := []float64{10.0, 15.0, 5.0}
+	aggregationTypes := maggregation.Types{maggregation.Last}
+	isEarlierThanFn := isStandardMetricEarlierThan
+	timestampNanosFn := standardMetricTimestampNanos
+	opts := NewOptions().SetDiscardNaNAggregatedValues(false)
+	rollupOp := applied.RollupOp{
+		ID:            []byte("foo.bar"),
+		AggregationID: maggregation.MustCompressTypes(maggregation.Sum),
+	}
+	p := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Increase},
+		},
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Reset},
+		},
+		{
+			Type:   pipeline.RollupOpType,
+			Rollup: rollupOp,
+		},
+	})
+	e := testGaugeElem(alignedstartAtNanos[:3], gaugeVals, aggregationTypes, p, opts)
+
+	aggKey := aggregationKey{
+		aggregationID:     rollupOp.AggregationID,
+		storagePolicy:     testStoragePolicy,
+		pipeline:          applied.NewPipeline([]applied.OpUnion{}),
+		numForwardedTimes: testNumForwardedTimes + 1,
+	}
+
+	// Consume all values, each value is followed by an explicit reset halfway
+	// through to the next value.
+	expectedForwardedRes := []testForwardedMetricWithMetadata{
+		{aggregationKey: aggKey, timeNanos: time.Unix(220, 0).UnixNano(), value: nan},
+		{aggregationKey: aggKey, timeNanos: time.Unix(225, 0).UnixNano(), value: 0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(230, 0).UnixNano(), value: 5.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(235, 0).UnixNano(), value: 0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(240, 0).UnixNano(), value: 5.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(245, 0).UnixNano(), value: 0},
+	}
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(alignedstartAtNanos[3], isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	verifyForwardedMetrics(t, expectedForwardedRes, *forwardRes)
+	require.Equal(t, 0, len(*localRes))
+	require.Equal(t, 0, len(e.values))
+	require.Equal(t, []float64{5.0}, e.lastConsumedValues)
+}
+
+func TestGaugeElemConsumeResetPipelineDistinctTimestamps(t *testing.T) {
+	alignedstartAtNanos := []int64{
+		time.Unix(210, 0).UnixNano(),
+		time.Unix(220, 0).UnixNano(),
+		time.Unix(230, 0).UnixNano(),
+		time.Unix(240, 0).UnixNano(),
+	}
+	gaugeVals := []float64{10.0, 15.0, 5.0}
+	aggregationTypes := maggregation.Types{maggregation.Last}
+	isEarlierThanFn := isStandardMetricEarlierThan
+	timestampNanosFn := standardMetricTimestampNanos
+	rollupOp := applied.RollupOp{
+		ID:            []byte("foo.bar"),
+		AggregationID: maggregation.MustCompressTypes(maggregation.Sum),
+	}
+	p := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Reset},
+		},
+		{
+			Type:   pipeline.RollupOpType,
+			Rollup: rollupOp,
+		},
+	})
+	e := testGaugeElem(alignedstartAtNanos[:3], gaugeVals, aggregationTypes, p, NewOptions())
+
+	// Consume all values, each reset is flushed strictly in between two values
+	// so that it neither overwrites nor is summed into the next value.
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(alignedstartAtNanos[3], isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	require.Equal(t, 0, len(*localRes))
+
+	var (
+		expectedTimes  = []int64{220, 225, 230, 235, 240, 245}
+		expectedValues = []float64{10.0, 0, 15.0, 0, 5.0, 0}
+	)
+	require.Equal(t, len(expectedTimes), len(*forwardRes))
+	for i, res := range *forwardRes {
+		require.Equal(t, time.Unix(expectedTimes[i], 0).UnixNano(), res.timeNanos)
+		require.Equal(t, expectedValues[i], res.value)
+		if i > 0 {
+			require.True(t, res.timeNanos > (*forwardRes)[i-1].timeNanos)
+		}
+	}
+}
+
+func TestGaugeElemConsumeResetAddPipeline(t *testing.T) {
+	alignedstartAtNanos := []int64{
+		time.Unix(210, 0).UnixNano(),
+		time.Unix(220, 0).UnixNano(),
+		time.Unix(230, 0).UnixNano(),
+		time.Unix(240, 0).UnixNano(),
+	}
+	gaugeVals := []float64{10.0, 15.0, 5.0}
+	aggregationTypes := maggregation.Types{maggregation.Last}
+	isEarlierThanFn := isStandardMetricEarlierThan
+	timestampNanosFn := standardMetricTimestampNanos
+	rollupOp := applied.RollupOp{
+		ID:            []byte("foo.bar"),
+		AggregationID: maggregation.MustCompressTypes(maggregation.Sum),
+	}
+	p := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Reset},
+		},
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Add},
+		},
+		{
+			Type:   pipeline.RollupOpType,
+			Rollup: rollupOp,
+		},
+	})
+	e := testGaugeElem(alignedstartAtNanos[:3], gaugeVals, aggregationTypes, p, NewOptions())
+
+	aggKey := aggregationKey{
+		aggregationID:     rollupOp.AggregationID,
+		storagePolicy:     testStoragePolicy,
+		pipeline:          applied.NewPipeline([]applied.OpUnion{}),
+		numForwardedTimes: testNumForwardedTimes + 1,
+	}
+
+	// Consume all values, the resets go through the add transformation so
+	// they carry the running total rather than zero.
+	expectedForwardedRes := []testForwardedMetricWithMetadata{
+		{aggregationKey: aggKey, timeNanos: time.Unix(220, 0).UnixNano(), value: 10.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(225, 0).UnixNano(), value: 10.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(230, 0).UnixNano(), value: 25.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(235, 0).UnixNano(), value: 25.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(240, 0).UnixNano(), value: 30.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(245, 0).UnixNano(), value: 30.0},
+	}
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(alignedstartAtNanos[3], isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	verifyForwardedMetrics(t, expectedForwardedRes, *forwardRes)
+	require.Equal(t, 0, len(*localRes))
+	require.Equal(t, 0, len(e.values))
+	require.Equal(t, []float64{30.0}, e.lastConsumedValues)
+}
+
+func TestGaugeElemConsumeAddPipeline(t *testing.T) {
+	alignedstartAtNanos := []int64{
+		time.Unix(210, 0).UnixNano(),
+		time.Unix(220, 0).UnixNano(),
+		time.Unix(230, 0).UnixNano(),
+		time.Unix(240, 0).UnixNano(),
+	}
+	gaugeVals := []float64{10.0, 15.0, 5.0}
+	aggregationTypes := maggregation.Types{maggregation.Last}
+	isEarlierThanFn := isStandardMetricEarlierThan
+	timestampNanosFn := standardMetricTimestampNanos
+	rollupOp := applied.RollupOp{
+		ID:            []byte("foo.bar"),
+		AggregationID: maggregation.MustCompressTypes(maggregation.Sum),
+	}
+	p := applied.NewPipeline([]applied.OpUnion{
+		{
+			Type:           pipeline.TransformationOpType,
+			Transformation: pipeline.TransformationOp{Type: transformation.Add},
+		},
+		{
+			Type:   pipeline.RollupOpType,
+			Rollup: rollupOp,
+		},
+	})
+	e := testGaugeElem(alignedstartAtNanos[:3], gaugeVals, aggregationTypes, p, NewOptions())
+
+	aggKey := aggregationKey{
+		aggregationID:     rollupOp.AggregationID,
+		storagePolicy:     testStoragePolicy,
+		pipeline:          applied.NewPipeline([]applied.OpUnion{}),
+		numForwardedTimes: testNumForwardedTimes + 1,
+	}
+
+	// Consume all values, each value is added to the previous result.
+	expectedForwardedRes := []testForwardedMetricWithMetadata{
+		{aggregationKey: aggKey, timeNanos: time.Unix(220, 0).UnixNano(), value: 10.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(230, 0).UnixNano(), value: 25.0},
+		{aggregationKey: aggKey, timeNanos: time.Unix(240, 0).UnixNano(), value: 30.0},
+	}
+	localFn, localRes := testFlushLocalMetricFn()
+	forwardFn, forwardRes := testFlushForwardedMetricFn()
+	onForwardedFlushedFn, _ := testOnForwardedFlushedFn()
+	require.False(t, e.Consume(alignedstartAtNanos[3], isEarlierThanFn, timestampNanosFn, localFn, forwardFn, onForwardedFlushedFn))
+	verifyForwardedMetrics(t, expectedForwardedRes, *forwardRes)
+	require.Equal(t, 0, len(*localRes))
+	require.Equal(t, 0, len(e.values))
+	require.Equal(t, []float64{30.0}, e.lastConsumedValues)
+}
+
 func TestGaugeElemClose(t *testing.T) {
 	e := testGaugeElem(testAlignedStarts[:len(testAlignedStarts)-1], testGaugeVals, maggregation.DefaultTypes, applied.DefaultPipeline, NewOptions())
 	require.False(t, e.closed)
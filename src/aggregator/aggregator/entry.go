@@ -280,6 +280,29 @@ func (e *Entry) ShouldExpire(now time.Time) bool {
 	return e.shouldExpire(now)
 }
 
+// Snapshot returns a snapshot of the entry and its elements.
+func (e *Entry) Snapshot() EntrySnapshot {
+	e.RLock()
+	defer e.RUnlock()
+
+	snapshot := EntrySnapshot{
+		CutoverNanos:    e.cutoverNanos,
+		LastAccessNanos: atomic.LoadInt64(&e.lastAccessNanos),
+		Elems:           make([]ElemSnapshot, 0, len(e.aggregations)),
+	}
+	if e.closed {
+		return snapshot
+	}
+	for _, val := range e.aggregations {
+		elemSnapshot := val.elem.Value.(metricElem).Snapshot()
+		if !val.key.pipeline.IsEmpty() {
+			elemSnapshot.Pipeline = val.key.pipeline.String()
+		}
+		snapshot.Elems = append(snapshot.Elems, elemSnapshot)
+	}
+	return snapshot
+}
+
 // TryExpire attempts to expire the entry, returning true
 // if the entry is expired, and false otherwise.
 func (e *Entry) TryExpire(now time.Time) bool {
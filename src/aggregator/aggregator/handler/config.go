@@ -25,6 +25,7 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/filter"
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/kafka"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
 	"github.com/m3db/m3/src/aggregator/sharding"
 	"github.com/m3db/m3/src/cluster/client"
@@ -42,6 +43,7 @@ var (
 	errNoHandlerConfiguration                   = errors.New("no handler configuration")
 	errNoDynamicOrStaticBackendConfiguration    = errors.New("neither dynamic nor static backend was configured")
 	errBothDynamicAndStaticBackendConfiguration = errors.New("both dynamic and static backend were configured")
+	errNoKafkaConfiguration                     = errors.New("no kafka configuration for kafka backend")
 )
 
 // FlushHandlerConfiguration configures flush handlers.
@@ -125,6 +127,8 @@ func (c flushHandlerConfiguration) newHandler(
 		return NewBlackholeHandler(), nil
 	case loggingType:
 		return NewLoggingHandler(instrumentOpts.Logger()), nil
+	case kafkaType:
+		return c.StaticBackend.newKafkaHandler(instrumentOpts)
 	default:
 		return nil, fmt.Errorf("unknown backend type %v", c.StaticBackend.Type)
 	}
@@ -218,4 +222,26 @@ type staticBackendConfiguration struct {
 
 	// Name of the backend.
 	Name string `yaml:"name"`
+
+	// Kafka configs the kafka backend.
+	Kafka *kafka.Configuration `yaml:"kafka"`
+}
+
+func (c *staticBackendConfiguration) newKafkaHandler(
+	instrumentOpts instrument.Options,
+) (Handler, error) {
+	if c.Kafka == nil {
+		return nil, errNoKafkaConfiguration
+	}
+	scope := instrumentOpts.MetricsScope().Tagged(map[string]string{
+		"backend":   c.Name,
+		"component": "kafka",
+	})
+	h, err := NewKafkaHandler(c.Kafka.NewOptions(instrumentOpts.SetMetricsScope(scope)))
+	if err != nil {
+		return nil, err
+	}
+	instrumentOpts.Logger().Info("created kafka flush handler",
+		zap.String("name", c.Name), zap.String("topic", c.Kafka.Topic))
+	return h, nil
 }
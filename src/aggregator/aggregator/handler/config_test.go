@@ -21,8 +21,12 @@
 package handler
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/kafka"
+	"github.com/m3db/m3/src/x/instrument"
+
 	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v2"
 )
@@ -67,3 +71,40 @@ dynamicBackend:
 	require.Error(t, err)
 	require.Equal(t, errBothDynamicAndStaticBackendConfiguration, err)
 }
+
+func TestKafkaStaticBackend(t *testing.T) {
+	broker, err := kafka.NewFakeBroker("metrics", 2)
+	require.NoError(t, err)
+	defer broker.Close()
+
+	str := fmt.Sprintf(`
+staticBackend:
+  type: kafka
+  name: test
+  kafka:
+    brokers:
+      - %s
+    topic: metrics
+`, broker.Addr())
+
+	var cfg flushHandlerConfiguration
+	require.NoError(t, yaml.UnmarshalStrict([]byte(str), &cfg))
+	require.Equal(t, kafkaType, cfg.StaticBackend.Type)
+	require.Equal(t, "metrics", cfg.StaticBackend.Kafka.Topic)
+
+	h, err := cfg.newHandler(nil, instrument.NewOptions())
+	require.NoError(t, err)
+	require.Equal(t, 1, broker.NumMetadataRequests())
+	h.Close()
+}
+
+func TestKafkaStaticBackendNoConfiguration(t *testing.T) {
+	str := `
+staticBackend:
+  type: kafka
+`
+	var cfg flushHandlerConfiguration
+	require.NoError(t, yaml.UnmarshalStrict([]byte(str), &cfg))
+	_, err := cfg.newHandler(nil, instrument.NewOptions())
+	require.Equal(t, errNoKafkaConfiguration, err)
+}
@@ -34,6 +34,7 @@ func TestTypeUnmarshalYAML(t *testing.T) {
 	}{
 		{str: "blackhole", expected: blackholeType},
 		{str: "logging", expected: loggingType},
+		{str: "kafka", expected: kafkaType},
 	}
 	for _, input := range inputs {
 		var typ Type
@@ -46,5 +47,5 @@ func TestTypeUnmarshalYAMLErrors(t *testing.T) {
 	var typ Type
 	err := yaml.Unmarshal([]byte("huh"), &typ)
 	require.Error(t, err)
-	require.Equal(t, "invalid handler type 'huh' valid types are: blackhole, logging", err.Error())
+	require.Equal(t, "invalid handler type 'huh' valid types are: blackhole, logging, kafka", err.Error())
 }
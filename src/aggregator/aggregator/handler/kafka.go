@@ -0,0 +1,59 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package handler
+
+import (
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/kafka"
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
+
+	"github.com/uber-go/tally"
+)
+
+type kafkaHandler struct {
+	p    kafka.Producer
+	opts kafka.Options
+}
+
+// NewKafkaHandler creates a new handler that publishes metrics to a Kafka
+// topic, initializing the producer with the topic metadata.
+func NewKafkaHandler(opts kafka.Options) (Handler, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	p := kafka.NewProducer(opts)
+	if err := p.Init(); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return kafkaHandler{
+		p:    p,
+		opts: opts,
+	}, nil
+}
+
+func (h kafkaHandler) NewWriter(scope tally.Scope) (writer.Writer, error) {
+	iOpts := h.opts.InstrumentOptions()
+	return kafka.NewWriter(h.p, h.opts.SetInstrumentOptions(iOpts.SetMetricsScope(scope)))
+}
+
+func (h kafkaHandler) Close() {
+	h.p.Close()
+}
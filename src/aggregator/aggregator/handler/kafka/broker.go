@@ -0,0 +1,161 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// maxResponseSize guards against allocating huge buffers when reading a
+	// corrupt response size.
+	maxResponseSize = 64 * 1024 * 1024
+)
+
+// brokerConn is a connection to a single broker. Requests are serialized
+// on the connection, which is established lazily and re-established after
+// any error.
+type brokerConn struct {
+	sync.Mutex
+
+	addr           string
+	clientID       string
+	dialTimeout    time.Duration
+	requestTimeout time.Duration
+	nowFn          func() time.Time
+
+	conn          net.Conn
+	versions      map[int16]apiVersionRange
+	correlationID int32
+	closed        bool
+}
+
+func newBrokerConn(addr string, opts Options) *brokerConn {
+	return &brokerConn{
+		addr:           addr,
+		clientID:       opts.ClientID(),
+		dialTimeout:    opts.DialTimeout(),
+		requestTimeout: opts.RequestTimeout(),
+		nowFn:          opts.ClockOptions().NowFn(),
+	}
+}
+
+// roundTrip sends the request and decodes the response into resp. If resp
+// is nil, no response is expected from the broker.
+func (c *brokerConn) roundTrip(req request, resp response) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.closed {
+		return errProducerClosed
+	}
+	if err := c.roundTripWithLock(req, resp); err != nil {
+		c.closeConnWithLock()
+		return err
+	}
+	return nil
+}
+
+func (c *brokerConn) roundTripWithLock(req request, resp response) error {
+	if c.conn == nil {
+		if err := c.connectWithLock(); err != nil {
+			return err
+		}
+	}
+	version, err := negotiateVersion(req, c.versions)
+	if err != nil {
+		return err
+	}
+	return c.sendWithLock(req, version, resp)
+}
+
+// connectWithLock connects to the broker and fetches the versions of the
+// requests it supports, the broker may have been upgraded or downgraded
+// since the last connection.
+func (c *brokerConn) connectWithLock() error {
+	conn, err := net.DialTimeout("tcp", c.addr, c.dialTimeout)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	var resp apiVersionsResponse
+	if err := c.sendWithLock(&apiVersionsRequest{}, apiVersionsVersion, &resp); err != nil {
+		return err
+	}
+	if resp.err != ErrNoError {
+		return resp.err
+	}
+	c.versions = resp.versions
+	return nil
+}
+
+func (c *brokerConn) sendWithLock(req request, version int16, resp response) error {
+	c.correlationID++
+	correlationID := c.correlationID
+	if err := c.conn.SetDeadline(c.nowFn().Add(c.requestTimeout)); err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(encodeRequest(correlationID, c.clientID, req, version)); err != nil {
+		return err
+	}
+	if resp == nil {
+		return nil
+	}
+
+	var sizeBuf [4]byte
+	if _, err := io.ReadFull(c.conn, sizeBuf[:]); err != nil {
+		return err
+	}
+	size := int32(binary.BigEndian.Uint32(sizeBuf[:]))
+	if size < 4 || size > maxResponseSize {
+		return errMalformedResponse
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(c.conn, buf); err != nil {
+		return err
+	}
+	d := &decoder{buf: buf}
+	if id := d.int32(); id != correlationID {
+		return fmt.Errorf("kafka response correlation id %d does not match request %d", id, correlationID)
+	}
+	return resp.decode(d, version)
+}
+
+func (c *brokerConn) closeConnWithLock() {
+	if c.conn == nil {
+		return
+	}
+	c.conn.Close()
+	c.conn = nil
+	c.versions = nil
+}
+
+func (c *brokerConn) Close() {
+	c.Lock()
+	c.closed = true
+	c.closeConnWithLock()
+	c.Unlock()
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/sharding"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/pool"
+)
+
+// Configuration configures publishing metrics to Kafka.
+type Configuration struct {
+	// Brokers are the addresses of the brokers used to bootstrap the topic metadata.
+	Brokers []string `yaml:"brokers" validate:"nonzero"`
+
+	// Topic is the topic the metrics are published to.
+	Topic string `yaml:"topic" validate:"nonzero"`
+
+	// ClientID is the client id sent with every request.
+	ClientID string `yaml:"clientID"`
+
+	// Encoding is the encoding of the metrics, either json or protobuf.
+	Encoding *Encoding `yaml:"encoding"`
+
+	// HashType is the hash type used to compute the shard of a metric id.
+	HashType *sharding.HashType `yaml:"hashType"`
+
+	// NumShards is the number of shards metric ids are hashed to before being
+	// mapped to partitions, defaults to the number of partitions of the topic.
+	NumShards uint32 `yaml:"numShards"`
+
+	// RequiredAcks is the number of acknowledgements required for a produce
+	// request, 0 for none, 1 for the leader only and -1 for all in-sync replicas.
+	RequiredAcks *int16 `yaml:"requiredAcks"`
+
+	// DialTimeout is the timeout for connecting to a broker.
+	DialTimeout time.Duration `yaml:"dialTimeout"`
+
+	// RequestTimeout is the timeout for a request to a broker.
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+
+	// MaxRetries is the max number of retries for a produce request.
+	MaxRetries *int `yaml:"maxRetries"`
+
+	// RetryBackoff is the backoff between retries.
+	RetryBackoff time.Duration `yaml:"retryBackoff"`
+
+	// MaxBatchSize is the max number of messages buffered by a writer before
+	// they are produced.
+	MaxBatchSize int `yaml:"maxBatchSize"`
+
+	// MaxBufferSize is the max number of messages buffered by the producer
+	// while they are produced in the background.
+	MaxBufferSize int `yaml:"maxBufferSize"`
+
+	// BytesPool configures the pool of bytes used by the protobuf encoder.
+	BytesPool *pool.BucketizedPoolConfiguration `yaml:"bytesPool"`
+}
+
+// NewOptions creates a new set of Kafka options.
+func (c Configuration) NewOptions(instrumentOpts instrument.Options) Options {
+	opts := NewOptions().
+		SetInstrumentOptions(instrumentOpts).
+		SetBrokers(c.Brokers).
+		SetTopic(c.Topic).
+		SetNumShards(c.NumShards)
+	if c.ClientID != "" {
+		opts = opts.SetClientID(c.ClientID)
+	}
+	if c.Encoding != nil {
+		opts = opts.SetEncoding(*c.Encoding)
+	}
+	if c.HashType != nil {
+		opts = opts.SetHashType(*c.HashType)
+	}
+	if c.RequiredAcks != nil {
+		opts = opts.SetRequiredAcks(*c.RequiredAcks)
+	}
+	if c.DialTimeout != 0 {
+		opts = opts.SetDialTimeout(c.DialTimeout)
+	}
+	if c.RequestTimeout != 0 {
+		opts = opts.SetRequestTimeout(c.RequestTimeout)
+	}
+	if c.MaxRetries != nil {
+		opts = opts.SetMaxRetries(*c.MaxRetries)
+	}
+	if c.RetryBackoff != 0 {
+		opts = opts.SetRetryBackoff(c.RetryBackoff)
+	}
+	if c.MaxBatchSize != 0 {
+		opts = opts.SetMaxBatchSize(c.MaxBatchSize)
+	}
+	if c.MaxBufferSize != 0 {
+		opts = opts.SetMaxBufferSize(c.MaxBufferSize)
+	}
+	if c.BytesPool != nil {
+		scope := instrumentOpts.MetricsScope().Tagged(map[string]string{"pool": "kafka-bytes-pool"})
+		bytesPool := pool.NewBytesPool(c.BytesPool.NewBuckets(),
+			c.BytesPool.NewObjectPoolOptions(instrumentOpts.SetMetricsScope(scope)))
+		bytesPool.Init()
+		opts = opts.SetBytesPool(bytesPool)
+	}
+	return opts
+}
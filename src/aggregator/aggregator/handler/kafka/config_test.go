@@ -0,0 +1,129 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/sharding"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestConfiguration(t *testing.T) {
+	str := `
+brokers:
+  - 127.0.0.1:9092
+  - 127.0.0.2:9092
+topic: metrics
+clientID: test
+encoding: protobuf
+hashType: murmur32
+numShards: 1024
+requiredAcks: -1
+dialTimeout: 1s
+requestTimeout: 2s
+maxRetries: 5
+retryBackoff: 3s
+maxBatchSize: 100
+maxBufferSize: 1000
+`
+	var cfg Configuration
+	require.NoError(t, yaml.UnmarshalStrict([]byte(str), &cfg))
+
+	opts := cfg.NewOptions(instrument.NewOptions())
+	require.NoError(t, opts.Validate())
+	require.Equal(t, []string{"127.0.0.1:9092", "127.0.0.2:9092"}, opts.Brokers())
+	require.Equal(t, "metrics", opts.Topic())
+	require.Equal(t, "test", opts.ClientID())
+	require.Equal(t, ProtobufEncoding, opts.Encoding())
+	require.Equal(t, sharding.Murmur32Hash, opts.HashType())
+	require.Equal(t, uint32(1024), opts.NumShards())
+	require.Equal(t, int16(-1), opts.RequiredAcks())
+	require.Equal(t, time.Second, opts.DialTimeout())
+	require.Equal(t, 2*time.Second, opts.RequestTimeout())
+	require.Equal(t, 5, opts.MaxRetries())
+	require.Equal(t, 3*time.Second, opts.RetryBackoff())
+	require.Equal(t, 100, opts.MaxBatchSize())
+	require.Equal(t, 1000, opts.MaxBufferSize())
+}
+
+func TestConfigurationDefaults(t *testing.T) {
+	str := `
+brokers:
+  - 127.0.0.1:9092
+topic: metrics
+`
+	var cfg Configuration
+	require.NoError(t, yaml.UnmarshalStrict([]byte(str), &cfg))
+
+	opts := cfg.NewOptions(instrument.NewOptions())
+	require.NoError(t, opts.Validate())
+	require.Equal(t, defaultClientID, opts.ClientID())
+	require.Equal(t, JSONEncoding, opts.Encoding())
+	require.Equal(t, sharding.DefaultHash, opts.HashType())
+	require.Equal(t, uint32(0), opts.NumShards())
+	require.Equal(t, defaultRequiredAcks, opts.RequiredAcks())
+	require.Equal(t, defaultMaxRetries, opts.MaxRetries())
+	require.Equal(t, defaultMaxBatchSize, opts.MaxBatchSize())
+	require.Equal(t, defaultMaxBufferSize, opts.MaxBufferSize())
+}
+
+func TestEncodingUnmarshalYAMLError(t *testing.T) {
+	var encoding Encoding
+	err := yaml.Unmarshal([]byte("xml"), &encoding)
+	require.Error(t, err)
+	require.Equal(t, "invalid encoding 'xml' valid encodings are: json, protobuf", err.Error())
+}
+
+func TestOptionsValidate(t *testing.T) {
+	opts := NewOptions()
+	require.Equal(t, errNoBrokers, opts.Validate())
+
+	opts = opts.SetBrokers([]string{"127.0.0.1:9092"})
+	require.Equal(t, errNoTopic, opts.Validate())
+
+	opts = opts.SetTopic("metrics").SetRequiredAcks(2)
+	require.Equal(t, errInvalidRequiredAcks, opts.Validate())
+
+	opts = opts.SetRequiredAcks(1).SetRequestTimeout(0)
+	require.Equal(t, errInvalidRequestTimeout, opts.Validate())
+
+	opts = opts.SetRequestTimeout(time.Second).SetMaxRetries(-1)
+	require.Equal(t, errNegativeMaxRetries, opts.Validate())
+
+	opts = opts.SetMaxRetries(0).SetMaxBatchSize(0)
+	require.Equal(t, errInvalidMaxBatchSize, opts.Validate())
+
+	opts = opts.SetMaxBatchSize(1).SetMaxBufferSize(0)
+	require.Equal(t, errInvalidMaxBufferSize, opts.Validate())
+
+	opts = opts.SetMaxBufferSize(1).SetEncoding("xml")
+	require.Error(t, opts.Validate())
+
+	opts = opts.SetEncoding(JSONEncoding).SetHashType("unknown")
+	require.Error(t, opts.Validate())
+
+	require.NoError(t, opts.SetHashType(sharding.Murmur32Hash).Validate())
+}
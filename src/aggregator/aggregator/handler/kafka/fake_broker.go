@@ -0,0 +1,437 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	fakeBrokerNodeID = int32(1)
+)
+
+var (
+	errInvalidRecordBatchCRC   = errors.New("invalid kafka record batch crc")
+	errInvalidRecordBatchMagic = errors.New("invalid kafka record batch magic")
+)
+
+// FakeBroker is an in-process broker serving a single topic that implements
+// the subset of the Kafka wire protocol used by the producer, for testing.
+type FakeBroker struct {
+	sync.Mutex
+
+	listener      net.Listener
+	host          string
+	port          int32
+	topic         string
+	numPartitions int32
+
+	versions            map[int16]apiVersionRange
+	messages            map[int32][]Message
+	produceErrs         []KError
+	produceVersion      int16
+	numMetadataRequests int
+	numProduceRequests  int
+	conns               map[net.Conn]struct{}
+	closed              bool
+	wg                  sync.WaitGroup
+}
+
+// NewFakeBroker creates a new fake broker listening on a local port.
+func NewFakeBroker(topic string, numPartitions int32) (*FakeBroker, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	host, portStr, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	b := &FakeBroker{
+		listener:      listener,
+		host:          host,
+		port:          int32(port),
+		topic:         topic,
+		numPartitions: numPartitions,
+		messages:      make(map[int32][]Message),
+		conns:         make(map[net.Conn]struct{}),
+		versions: map[int16]apiVersionRange{
+			produceAPIKey:     {min: minProduceVersion, max: maxProduceVersion},
+			metadataAPIKey:    {min: minMetadataVersion, max: maxMetadataVersion},
+			apiVersionsAPIKey: {min: apiVersionsVersion, max: apiVersionsVersion},
+		},
+	}
+	b.wg.Add(1)
+	go b.serve()
+	return b, nil
+}
+
+// Addr returns the address of the broker.
+func (b *FakeBroker) Addr() string {
+	return b.listener.Addr().String()
+}
+
+// Messages returns the messages produced to the given partition.
+func (b *FakeBroker) Messages(partition int32) []Message {
+	b.Lock()
+	msgs := append([]Message(nil), b.messages[partition]...)
+	b.Unlock()
+	return msgs
+}
+
+// FailProduce makes the subsequent produce requests fail with the given
+// errors, one error per request.
+func (b *FakeBroker) FailProduce(errs ...KError) {
+	b.Lock()
+	b.produceErrs = append(b.produceErrs, errs...)
+	b.Unlock()
+}
+
+// setAPIVersions sets the range of versions of a request advertised by the
+// broker, the broker closes the connection on requests of other versions.
+func (b *FakeBroker) setAPIVersions(apiKey int16, min, max int16) {
+	b.Lock()
+	b.versions[apiKey] = apiVersionRange{min: min, max: max}
+	b.Unlock()
+}
+
+// lastProduceVersion returns the version of the last produce request.
+func (b *FakeBroker) lastProduceVersion() int16 {
+	b.Lock()
+	v := b.produceVersion
+	b.Unlock()
+	return v
+}
+
+// NumMetadataRequests returns the number of metadata requests received.
+func (b *FakeBroker) NumMetadataRequests() int {
+	b.Lock()
+	n := b.numMetadataRequests
+	b.Unlock()
+	return n
+}
+
+// NumProduceRequests returns the number of produce requests received.
+func (b *FakeBroker) NumProduceRequests() int {
+	b.Lock()
+	n := b.numProduceRequests
+	b.Unlock()
+	return n
+}
+
+// Close closes the broker and all its connections.
+func (b *FakeBroker) Close() {
+	b.Lock()
+	if b.closed {
+		b.Unlock()
+		return
+	}
+	b.closed = true
+	b.listener.Close()
+	for conn := range b.conns {
+		conn.Close()
+	}
+	b.Unlock()
+	b.wg.Wait()
+}
+
+func (b *FakeBroker) serve() {
+	defer b.wg.Done()
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		b.Lock()
+		if b.closed {
+			b.Unlock()
+			conn.Close()
+			return
+		}
+		b.conns[conn] = struct{}{}
+		b.wg.Add(1)
+		b.Unlock()
+		go b.serveConn(conn)
+	}
+}
+
+func (b *FakeBroker) serveConn(conn net.Conn) {
+	defer func() {
+		b.Lock()
+		delete(b.conns, conn)
+		b.Unlock()
+		conn.Close()
+		b.wg.Done()
+	}()
+	for {
+		var sizeBuf [4]byte
+		if _, err := io.ReadFull(conn, sizeBuf[:]); err != nil {
+			return
+		}
+		buf := make([]byte, binary.BigEndian.Uint32(sizeBuf[:]))
+		if _, err := io.ReadFull(conn, buf); err != nil {
+			return
+		}
+		d := &decoder{buf: buf}
+		var (
+			apiKey        = d.int16()
+			version       = d.int16()
+			correlationID = d.int32()
+			_             = d.string()
+			e             = &encoder{}
+		)
+		if !b.supportsVersion(apiKey, version) {
+			return
+		}
+		e.putInt32(0)
+		e.putInt32(correlationID)
+		var respond bool
+		switch apiKey {
+		case apiVersionsAPIKey:
+			respond = b.handleAPIVersions(e)
+		case metadataAPIKey:
+			respond = b.handleMetadata(d, e, version)
+		case produceAPIKey:
+			respond = b.handleProduce(d, e, version)
+		}
+		if d.err != nil {
+			return
+		}
+		if !respond {
+			continue
+		}
+		e.putInt32At(0, int32(len(e.buf)-4))
+		if _, err := conn.Write(e.buf); err != nil {
+			return
+		}
+	}
+}
+
+func (b *FakeBroker) supportsVersion(apiKey int16, version int16) bool {
+	b.Lock()
+	r, ok := b.versions[apiKey]
+	b.Unlock()
+	return ok && version >= r.min && version <= r.max
+}
+
+func (b *FakeBroker) handleAPIVersions(e *encoder) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	apiKeys := make([]int, 0, len(b.versions))
+	for apiKey := range b.versions {
+		apiKeys = append(apiKeys, int(apiKey))
+	}
+	sort.Ints(apiKeys)
+	e.putInt16(int16(ErrNoError))
+	e.putArrayLength(len(apiKeys))
+	for _, apiKey := range apiKeys {
+		r := b.versions[int16(apiKey)]
+		e.putInt16(int16(apiKey))
+		e.putInt16(r.min)
+		e.putInt16(r.max)
+	}
+	return true
+}
+
+func (b *FakeBroker) handleMetadata(d *decoder, e *encoder, version int16) bool {
+	topics := make([]string, d.arrayLength())
+	for i := range topics {
+		topics[i] = d.string()
+	}
+	if version >= 4 {
+		// Whether to create the topic, topics are never created.
+		d.bool()
+	}
+	b.Lock()
+	b.numMetadataRequests++
+	b.Unlock()
+
+	if version >= 3 {
+		// The throttle time.
+		e.putInt32(0)
+	}
+	e.putArrayLength(1)
+	e.putInt32(fakeBrokerNodeID)
+	e.putString(b.host)
+	e.putInt32(b.port)
+	if version >= 1 {
+		// No rack.
+		e.putNullableString(nil)
+	}
+	if version >= 2 {
+		// No cluster id.
+		e.putNullableString(nil)
+	}
+	if version >= 1 {
+		// The controller id.
+		e.putInt32(fakeBrokerNodeID)
+	}
+	e.putArrayLength(len(topics))
+	for _, topic := range topics {
+		if topic != b.topic {
+			e.putInt16(int16(ErrUnknownTopicOrPartition))
+			e.putString(topic)
+			if version >= 1 {
+				e.putBool(false)
+			}
+			e.putArrayLength(0)
+			continue
+		}
+		e.putInt16(int16(ErrNoError))
+		e.putString(topic)
+		if version >= 1 {
+			e.putBool(false)
+		}
+		e.putArrayLength(int(b.numPartitions))
+		for partition := int32(0); partition < b.numPartitions; partition++ {
+			e.putInt16(int16(ErrNoError))
+			e.putInt32(partition)
+			e.putInt32(fakeBrokerNodeID)
+			e.putArrayLength(1)
+			e.putInt32(fakeBrokerNodeID)
+			e.putArrayLength(1)
+			e.putInt32(fakeBrokerNodeID)
+		}
+	}
+	return true
+}
+
+func (b *FakeBroker) handleProduce(d *decoder, e *encoder, version int16) bool {
+	// The transactional id.
+	d.nullableString()
+	requiredAcks := d.int16()
+	d.int32()
+
+	b.Lock()
+	defer b.Unlock()
+
+	b.numProduceRequests++
+	b.produceVersion = version
+	var produceErr KError
+	if len(b.produceErrs) > 0 {
+		produceErr = b.produceErrs[0]
+		b.produceErrs = b.produceErrs[1:]
+	}
+	numTopics := d.arrayLength()
+	e.putArrayLength(numTopics)
+	for i := 0; i < numTopics; i++ {
+		topic := d.string()
+		e.putString(topic)
+		numPartitions := d.arrayLength()
+		e.putArrayLength(numPartitions)
+		for j := 0; j < numPartitions; j++ {
+			partition := d.int32()
+			msgs, err := decodeRecordBatches(d.bytes())
+			partitionErr := produceErr
+			switch {
+			case err != nil:
+				partitionErr = ErrCorruptMessage
+			case topic != b.topic || partition < 0 || partition >= b.numPartitions:
+				partitionErr = ErrUnknownTopicOrPartition
+			}
+			baseOffset := int64(len(b.messages[partition]))
+			if partitionErr == ErrNoError {
+				b.messages[partition] = append(b.messages[partition], msgs...)
+			}
+			e.putInt32(partition)
+			e.putInt16(int16(partitionErr))
+			e.putInt64(baseOffset)
+			e.putInt64(-1)
+			if version >= 5 {
+				// The log start offset.
+				e.putInt64(0)
+			}
+		}
+	}
+	e.putInt32(0)
+	return requiredAcks != 0
+}
+
+func decodeRecordBatches(buf []byte) ([]Message, error) {
+	var (
+		d    = &decoder{buf: buf}
+		msgs []Message
+	)
+	for d.off < len(d.buf) && d.err == nil {
+		d.int64()
+		batchBuf := d.bytes()
+		if d.err != nil {
+			break
+		}
+		bd := &decoder{buf: batchBuf}
+		bd.int32()
+		if magic := bd.int8(); bd.err == nil && magic != recordBatchMagic {
+			return nil, errInvalidRecordBatchMagic
+		}
+		crc := uint32(bd.int32())
+		if bd.err == nil && crc != crc32.Checksum(batchBuf[bd.off:], crc32cTable) {
+			return nil, errInvalidRecordBatchCRC
+		}
+		bd.int16()
+		bd.int32()
+		firstTimestamp := bd.int64()
+		bd.int64()
+		bd.int64()
+		bd.int16()
+		bd.int32()
+		for i, n := 0, bd.arrayLength(); i < n; i++ {
+			rd := &decoder{buf: bd.varintBytes()}
+			if bd.err != nil {
+				break
+			}
+			rd.int8()
+			timestampDelta := rd.varint()
+			rd.varint()
+			msg := Message{
+				Key:            append([]byte(nil), rd.varintBytes()...),
+				Value:          append([]byte(nil), rd.varintBytes()...),
+				TimestampNanos: (firstTimestamp + timestampDelta) * int64(time.Millisecond),
+			}
+			if rd.varint() != 0 {
+				// Headers are never produced.
+				return nil, errMalformedResponse
+			}
+			if rd.err != nil {
+				return nil, rd.err
+			}
+			msgs = append(msgs, msg)
+		}
+		if bd.err != nil {
+			return nil, bd.err
+		}
+	}
+	return msgs, d.err
+}
@@ -0,0 +1,396 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/sharding"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/pool"
+)
+
+const (
+	defaultClientID       = "m3aggregator"
+	defaultRequiredAcks   = int16(1)
+	defaultDialTimeout    = 5 * time.Second
+	defaultRequestTimeout = 10 * time.Second
+	defaultMaxRetries     = 3
+	defaultRetryBackoff   = 100 * time.Millisecond
+	defaultMaxBatchSize   = 1024
+	defaultMaxBufferSize  = 64 * 1024
+)
+
+var (
+	errNoBrokers             = errors.New("no kafka brokers")
+	errNoTopic               = errors.New("no kafka topic")
+	errInvalidRequiredAcks   = errors.New("invalid required acks, must be one of -1, 0 or 1")
+	errInvalidRequestTimeout = errors.New("invalid request timeout, must be positive")
+	errNegativeMaxRetries    = errors.New("negative max retries")
+	errInvalidMaxBatchSize   = errors.New("invalid max batch size, must be positive")
+	errInvalidMaxBufferSize  = errors.New("invalid max buffer size, must be positive")
+)
+
+// Options provide a set of options for the Kafka producer and writer.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetBrokers sets the addresses of the brokers used to bootstrap the
+	// topic metadata.
+	SetBrokers(value []string) Options
+
+	// Brokers returns the addresses of the brokers used to bootstrap the
+	// topic metadata.
+	Brokers() []string
+
+	// SetTopic sets the topic.
+	SetTopic(value string) Options
+
+	// Topic returns the topic.
+	Topic() string
+
+	// SetClientID sets the client id sent with every request.
+	SetClientID(value string) Options
+
+	// ClientID returns the client id sent with every request.
+	ClientID() string
+
+	// SetRequiredAcks sets the number of acknowledgements the leader needs to
+	// receive before responding, 0 for no response, 1 for the leader only and
+	// -1 for all in-sync replicas.
+	SetRequiredAcks(value int16) Options
+
+	// RequiredAcks returns the number of acknowledgements the leader needs to
+	// receive before responding, 0 for no response, 1 for the leader only and
+	// -1 for all in-sync replicas.
+	RequiredAcks() int16
+
+	// SetDialTimeout sets the timeout for connecting to a broker.
+	SetDialTimeout(value time.Duration) Options
+
+	// DialTimeout returns the timeout for connecting to a broker.
+	DialTimeout() time.Duration
+
+	// SetRequestTimeout sets the timeout for a request to a broker.
+	SetRequestTimeout(value time.Duration) Options
+
+	// RequestTimeout returns the timeout for a request to a broker.
+	RequestTimeout() time.Duration
+
+	// SetMaxRetries sets the max number of retries for a produce request.
+	SetMaxRetries(value int) Options
+
+	// MaxRetries returns the max number of retries for a produce request.
+	MaxRetries() int
+
+	// SetRetryBackoff sets the backoff between retries.
+	SetRetryBackoff(value time.Duration) Options
+
+	// RetryBackoff returns the backoff between retries.
+	RetryBackoff() time.Duration
+
+	// SetEncoding sets the encoding of the metrics.
+	SetEncoding(value Encoding) Options
+
+	// Encoding returns the encoding of the metrics.
+	Encoding() Encoding
+
+	// SetHashType sets the hash type used to compute the shard of a metric id.
+	SetHashType(value sharding.HashType) Options
+
+	// HashType returns the hash type used to compute the shard of a metric id.
+	HashType() sharding.HashType
+
+	// SetNumShards sets the number of shards metric ids are hashed to before
+	// being mapped to partitions. A value of 0 uses the number of partitions
+	// of the topic as the number of shards.
+	SetNumShards(value uint32) Options
+
+	// NumShards returns the number of shards metric ids are hashed to before
+	// being mapped to partitions. A value of 0 uses the number of partitions
+	// of the topic as the number of shards.
+	NumShards() uint32
+
+	// SetMaxBatchSize sets the max number of messages buffered by a writer
+	// before they are produced.
+	SetMaxBatchSize(value int) Options
+
+	// MaxBatchSize returns the max number of messages buffered by a writer
+	// before they are produced.
+	MaxBatchSize() int
+
+	// SetMaxBufferSize sets the max number of messages buffered by the
+	// producer while they are produced in the background, messages are
+	// rejected once the buffer is full.
+	SetMaxBufferSize(value int) Options
+
+	// MaxBufferSize returns the max number of messages buffered by the
+	// producer while they are produced in the background, messages are
+	// rejected once the buffer is full.
+	MaxBufferSize() int
+
+	// SetBytesPool sets the bytes pool used by the protobuf encoder.
+	SetBytesPool(value pool.BytesPool) Options
+
+	// BytesPool returns the bytes pool used by the protobuf encoder.
+	BytesPool() pool.BytesPool
+}
+
+type options struct {
+	clockOpts      clock.Options
+	instrumentOpts instrument.Options
+	brokers        []string
+	topic          string
+	clientID       string
+	requiredAcks   int16
+	dialTimeout    time.Duration
+	requestTimeout time.Duration
+	maxRetries     int
+	retryBackoff   time.Duration
+	encoding       Encoding
+	hashType       sharding.HashType
+	numShards      uint32
+	maxBatchSize   int
+	maxBufferSize  int
+	bytesPool      pool.BytesPool
+}
+
+// NewOptions creates a new set of Kafka options.
+func NewOptions() Options {
+	return &options{
+		clockOpts:      clock.NewOptions(),
+		instrumentOpts: instrument.NewOptions(),
+		clientID:       defaultClientID,
+		requiredAcks:   defaultRequiredAcks,
+		dialTimeout:    defaultDialTimeout,
+		requestTimeout: defaultRequestTimeout,
+		maxRetries:     defaultMaxRetries,
+		retryBackoff:   defaultRetryBackoff,
+		encoding:       defaultEncoding,
+		hashType:       sharding.DefaultHash,
+		maxBatchSize:   defaultMaxBatchSize,
+		maxBufferSize:  defaultMaxBufferSize,
+	}
+}
+
+func (o *options) Validate() error {
+	if len(o.brokers) == 0 {
+		return errNoBrokers
+	}
+	if o.topic == "" {
+		return errNoTopic
+	}
+	if o.requiredAcks < -1 || o.requiredAcks > 1 {
+		return errInvalidRequiredAcks
+	}
+	if o.requestTimeout <= 0 {
+		return errInvalidRequestTimeout
+	}
+	if o.maxRetries < 0 {
+		return errNegativeMaxRetries
+	}
+	if o.maxBatchSize <= 0 {
+		return errInvalidMaxBatchSize
+	}
+	if o.maxBufferSize <= 0 {
+		return errInvalidMaxBufferSize
+	}
+	if err := o.encoding.validate(); err != nil {
+		return err
+	}
+	_, err := o.hashType.ShardFn()
+	return err
+}
+
+func (o *options) SetClockOptions(value clock.Options) Options {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *options) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
+
+func (o *options) SetBrokers(value []string) Options {
+	opts := *o
+	opts.brokers = value
+	return &opts
+}
+
+func (o *options) Brokers() []string {
+	return o.brokers
+}
+
+func (o *options) SetTopic(value string) Options {
+	opts := *o
+	opts.topic = value
+	return &opts
+}
+
+func (o *options) Topic() string {
+	return o.topic
+}
+
+func (o *options) SetClientID(value string) Options {
+	opts := *o
+	opts.clientID = value
+	return &opts
+}
+
+func (o *options) ClientID() string {
+	return o.clientID
+}
+
+func (o *options) SetRequiredAcks(value int16) Options {
+	opts := *o
+	opts.requiredAcks = value
+	return &opts
+}
+
+func (o *options) RequiredAcks() int16 {
+	return o.requiredAcks
+}
+
+func (o *options) SetDialTimeout(value time.Duration) Options {
+	opts := *o
+	opts.dialTimeout = value
+	return &opts
+}
+
+func (o *options) DialTimeout() time.Duration {
+	return o.dialTimeout
+}
+
+func (o *options) SetRequestTimeout(value time.Duration) Options {
+	opts := *o
+	opts.requestTimeout = value
+	return &opts
+}
+
+func (o *options) RequestTimeout() time.Duration {
+	return o.requestTimeout
+}
+
+func (o *options) SetMaxRetries(value int) Options {
+	opts := *o
+	opts.maxRetries = value
+	return &opts
+}
+
+func (o *options) MaxRetries() int {
+	return o.maxRetries
+}
+
+func (o *options) SetRetryBackoff(value time.Duration) Options {
+	opts := *o
+	opts.retryBackoff = value
+	return &opts
+}
+
+func (o *options) RetryBackoff() time.Duration {
+	return o.retryBackoff
+}
+
+func (o *options) SetEncoding(value Encoding) Options {
+	opts := *o
+	opts.encoding = value
+	return &opts
+}
+
+func (o *options) Encoding() Encoding {
+	return o.encoding
+}
+
+func (o *options) SetHashType(value sharding.HashType) Options {
+	opts := *o
+	opts.hashType = value
+	return &opts
+}
+
+func (o *options) HashType() sharding.HashType {
+	return o.hashType
+}
+
+func (o *options) SetNumShards(value uint32) Options {
+	opts := *o
+	opts.numShards = value
+	return &opts
+}
+
+func (o *options) NumShards() uint32 {
+	return o.numShards
+}
+
+func (o *options) SetMaxBatchSize(value int) Options {
+	opts := *o
+	opts.maxBatchSize = value
+	return &opts
+}
+
+func (o *options) MaxBatchSize() int {
+	return o.maxBatchSize
+}
+
+func (o *options) SetMaxBufferSize(value int) Options {
+	opts := *o
+	opts.maxBufferSize = value
+	return &opts
+}
+
+func (o *options) MaxBufferSize() int {
+	return o.maxBufferSize
+}
+
+func (o *options) SetBytesPool(value pool.BytesPool) Options {
+	opts := *o
+	opts.bytesPool = value
+	return &opts
+}
+
+func (o *options) BytesPool() pool.BytesPool {
+	return o.bytesPool
+}
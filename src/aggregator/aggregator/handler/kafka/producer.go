@@ -0,0 +1,438 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	xerrors "github.com/m3db/m3/src/x/errors"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+var (
+	errProducerClosed     = errors.New("kafka producer is closed")
+	errProducerBufferFull = errors.New("kafka producer buffer is full")
+)
+
+type producerMetrics struct {
+	produceSuccess   tally.Counter
+	produceErrors    tally.Counter
+	produceRetries   tally.Counter
+	messagesProduced tally.Counter
+	messagesDropped  tally.Counter
+	bufferFull       tally.Counter
+	metadataSuccess  tally.Counter
+	metadataErrors   tally.Counter
+}
+
+func newProducerMetrics(scope tally.Scope) producerMetrics {
+	produceScope := scope.SubScope("produce")
+	metadataScope := scope.SubScope("metadata")
+	return producerMetrics{
+		produceSuccess:   produceScope.Counter("success"),
+		produceErrors:    produceScope.Counter("errors"),
+		produceRetries:   produceScope.Counter("retries"),
+		messagesProduced: produceScope.Counter("messages"),
+		messagesDropped:  produceScope.Counter("dropped"),
+		bufferFull:       produceScope.Counter("buffer-full"),
+		metadataSuccess:  metadataScope.Counter("success"),
+		metadataErrors:   metadataScope.Counter("errors"),
+	}
+}
+
+// partitionQueue buffers the batches of messages to be produced to a
+// partition.
+type partitionQueue struct {
+	sync.Mutex
+
+	batches  [][]Message
+	notifyCh chan struct{}
+}
+
+func newPartitionQueue() *partitionQueue {
+	return &partitionQueue{notifyCh: make(chan struct{}, 1)}
+}
+
+func (q *partitionQueue) push(msgs []Message) {
+	q.Lock()
+	q.batches = append(q.batches, msgs)
+	q.Unlock()
+	select {
+	case q.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (q *partitionQueue) pop() ([]Message, bool) {
+	q.Lock()
+	defer q.Unlock()
+
+	if len(q.batches) == 0 {
+		return nil, false
+	}
+	msgs := q.batches[0]
+	q.batches[0] = nil
+	q.batches = q.batches[1:]
+	return msgs, true
+}
+
+type producer struct {
+	sync.RWMutex
+
+	opts          Options
+	topic         string
+	maxBufferSize int64
+	logger        *zap.Logger
+	metrics       producerMetrics
+
+	// numBuffered is the number of messages buffered, accessed atomically.
+	numBuffered int64
+	brokers     map[int32]*brokerConn
+	leaders     []int32
+	queues      []*partitionQueue
+	closed      bool
+	doneCh      chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewProducer creates a new Kafka producer.
+func NewProducer(opts Options) Producer {
+	instrumentOpts := opts.InstrumentOptions()
+	return &producer{
+		opts:          opts,
+		topic:         opts.Topic(),
+		maxBufferSize: int64(opts.MaxBufferSize()),
+		logger:        instrumentOpts.Logger(),
+		metrics:       newProducerMetrics(instrumentOpts.MetricsScope()),
+		brokers:       make(map[int32]*brokerConn),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+func (p *producer) Init() error {
+	if err := p.refreshMetadata(); err != nil {
+		return err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.closed {
+		return errProducerClosed
+	}
+	if p.queues != nil {
+		return nil
+	}
+	// The partitions are fixed once initialized, partitions added to the
+	// topic later on are not produced to until the producer is recreated.
+	p.queues = make([]*partitionQueue, len(p.leaders))
+	for i := range p.queues {
+		p.queues[i] = newPartitionQueue()
+		p.wg.Add(1)
+		go p.produceLoop(int32(i), p.queues[i])
+	}
+	return nil
+}
+
+func (p *producer) NumPartitions() int32 {
+	p.RLock()
+	numPartitions := int32(len(p.queues))
+	p.RUnlock()
+	return numPartitions
+}
+
+func (p *producer) Produce(partition int32, msgs []Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	p.RLock()
+	defer p.RUnlock()
+
+	if p.closed {
+		return errProducerClosed
+	}
+	if partition < 0 || int(partition) >= len(p.queues) {
+		return fmt.Errorf("unknown partition %d for kafka topic %s", partition, p.topic)
+	}
+	n := int64(len(msgs))
+	if atomic.AddInt64(&p.numBuffered, n) > p.maxBufferSize {
+		atomic.AddInt64(&p.numBuffered, -n)
+		p.metrics.bufferFull.Inc(1)
+		return errProducerBufferFull
+	}
+	p.queues[partition].push(msgs)
+	return nil
+}
+
+// produceLoop produces the messages buffered for the partition until the
+// producer is closed, the messages still buffered then are produced before
+// returning.
+func (p *producer) produceLoop(partition int32, q *partitionQueue) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-q.notifyCh:
+			p.produceBuffered(partition, q)
+		case <-p.doneCh:
+			p.produceBuffered(partition, q)
+			return
+		}
+	}
+}
+
+func (p *producer) produceBuffered(partition int32, q *partitionQueue) {
+	for {
+		msgs, ok := q.pop()
+		if !ok {
+			return
+		}
+		p.produceWithRetries(partition, msgs)
+		atomic.AddInt64(&p.numBuffered, -int64(len(msgs)))
+	}
+}
+
+// produceWithRetries produces the messages, retrying retriable errors
+// unless the producer is closed. The messages are dropped if they still
+// could not be produced.
+func (p *producer) produceWithRetries(partition int32, msgs []Message) {
+	var err error
+	for attempt := 0; ; attempt++ {
+		if err = p.produce(partition, msgs); err == nil {
+			p.metrics.produceSuccess.Inc(1)
+			p.metrics.messagesProduced.Inc(int64(len(msgs)))
+			return
+		}
+		if attempt >= p.opts.MaxRetries() || !isRetriable(err) || !p.backoff() {
+			break
+		}
+		p.metrics.produceRetries.Inc(1)
+		// The leader may have moved, refresh the metadata before retrying.
+		if mErr := p.refreshMetadata(); mErr != nil {
+			p.logger.Warn("could not refresh kafka metadata",
+				zap.String("topic", p.topic), zap.Error(mErr))
+		}
+	}
+	p.metrics.produceErrors.Inc(1)
+	p.metrics.messagesDropped.Inc(int64(len(msgs)))
+}
+
+// backoff waits before a retry, returning false if the producer was closed
+// in the meantime.
+func (p *producer) backoff() bool {
+	timer := time.NewTimer(p.opts.RetryBackoff())
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-p.doneCh:
+		return false
+	}
+}
+
+func (p *producer) produce(partition int32, msgs []Message) error {
+	p.RLock()
+	if partition < 0 || int(partition) >= len(p.leaders) {
+		p.RUnlock()
+		return fmt.Errorf("unknown partition %d for kafka topic %s", partition, p.topic)
+	}
+	leader, ok := p.brokers[p.leaders[partition]]
+	p.RUnlock()
+	if !ok {
+		return ErrLeaderNotAvailable
+	}
+
+	req := &produceRequest{
+		requiredAcks: p.opts.RequiredAcks(),
+		timeout:      p.opts.RequestTimeout(),
+		topic:        p.topic,
+		partition:    partition,
+		msgs:         msgs,
+	}
+	if req.requiredAcks == 0 {
+		// The broker does not respond when no acks are required.
+		return leader.roundTrip(req, nil)
+	}
+	var resp produceResponse
+	if err := leader.roundTrip(req, &resp); err != nil {
+		return err
+	}
+	for _, pr := range resp.partitions {
+		if pr.partition != partition {
+			continue
+		}
+		if pr.err != ErrNoError {
+			return pr.err
+		}
+		return nil
+	}
+	return errMalformedResponse
+}
+
+func (p *producer) refreshMetadata() error {
+	var (
+		req      = &metadataRequest{topics: []string{p.topic}}
+		multiErr xerrors.MultiError
+	)
+	for _, addr := range p.metadataAddrs() {
+		// Use a dedicated connection so metadata requests do not queue
+		// behind produce requests to an unresponsive broker.
+		conn := newBrokerConn(addr, p.opts)
+		var resp metadataResponse
+		err := conn.roundTrip(req, &resp)
+		conn.Close()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		if err := p.updateMetadata(resp); err != nil {
+			p.metrics.metadataErrors.Inc(1)
+			return err
+		}
+		p.metrics.metadataSuccess.Inc(1)
+		return nil
+	}
+	p.metrics.metadataErrors.Inc(1)
+	return fmt.Errorf("could not fetch metadata for kafka topic %s: %v", p.topic, multiErr.FinalError())
+}
+
+// metadataAddrs returns the configured brokers followed by the other
+// known brokers.
+func (p *producer) metadataAddrs() []string {
+	addrs := append([]string(nil), p.opts.Brokers()...)
+	seen := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		seen[addr] = struct{}{}
+	}
+	p.RLock()
+	for _, b := range p.brokers {
+		if _, ok := seen[b.addr]; !ok {
+			seen[b.addr] = struct{}{}
+			addrs = append(addrs, b.addr)
+		}
+	}
+	p.RUnlock()
+	return addrs
+}
+
+func (p *producer) updateMetadata(resp metadataResponse) error {
+	var topic *topicMetadata
+	for i := range resp.topics {
+		if resp.topics[i].name == p.topic {
+			topic = &resp.topics[i]
+			break
+		}
+	}
+	if topic == nil {
+		return fmt.Errorf("kafka topic %s not found in metadata", p.topic)
+	}
+	if topic.err != ErrNoError {
+		return topic.err
+	}
+	if len(topic.partitions) == 0 {
+		return fmt.Errorf("kafka topic %s has no partitions", p.topic)
+	}
+	leaders := make([]int32, len(topic.partitions))
+	for i := range leaders {
+		leaders[i] = -1
+	}
+	for _, partition := range topic.partitions {
+		if partition.partition < 0 || int(partition.partition) >= len(leaders) {
+			return errMalformedResponse
+		}
+		leaders[partition.partition] = partition.leader
+	}
+
+	p.Lock()
+	if p.closed {
+		p.Unlock()
+		return errProducerClosed
+	}
+	var (
+		brokers  = make(map[int32]*brokerConn, len(resp.brokers))
+		toClose  []*brokerConn
+		oldConns = p.brokers
+	)
+	for _, b := range resp.brokers {
+		addr := b.addr()
+		if conn, ok := oldConns[b.nodeID]; ok && conn.addr == addr {
+			brokers[b.nodeID] = conn
+			continue
+		}
+		brokers[b.nodeID] = newBrokerConn(addr, p.opts)
+	}
+	for nodeID, conn := range oldConns {
+		if brokers[nodeID] != conn {
+			toClose = append(toClose, conn)
+		}
+	}
+	p.brokers = brokers
+	p.leaders = leaders
+	p.Unlock()
+
+	for _, conn := range toClose {
+		conn.Close()
+	}
+	return nil
+}
+
+func (p *producer) Close() error {
+	p.Lock()
+	if p.closed {
+		p.Unlock()
+		return errProducerClosed
+	}
+	p.closed = true
+	p.Unlock()
+
+	// Produce the buffered messages before closing the connections.
+	close(p.doneCh)
+	p.wg.Wait()
+
+	p.Lock()
+	for _, conn := range p.brokers {
+		conn.Close()
+	}
+	p.Unlock()
+	return nil
+}
+
+func isRetriable(err error) bool {
+	if err == errProducerClosed {
+		return false
+	}
+	if _, ok := err.(unsupportedVersionError); ok {
+		return false
+	}
+	if kErr, ok := err.(KError); ok {
+		return kErr.Retriable()
+	}
+	// Network and protocol errors are retried on a new connection.
+	return true
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+const (
+	testTopic = "test-topic"
+)
+
+func TestProducerProduce(t *testing.T) {
+	broker := newTestBroker(t, 3)
+	defer broker.Close()
+
+	p := newTestProducer(t, testOptions(broker))
+	defer p.Close()
+	require.Equal(t, int32(3), p.NumPartitions())
+
+	now := time.Unix(0, 1234*int64(time.Millisecond))
+	msgs := []Message{
+		{Key: []byte("foo"), Value: []byte("bar"), TimestampNanos: now.UnixNano()},
+		{Key: nil, Value: []byte("baz"), TimestampNanos: now.Add(time.Second).UnixNano()},
+	}
+	require.NoError(t, p.Produce(1, msgs))
+	require.NoError(t, p.Produce(2, nil))
+	require.True(t, waitFor(func() bool { return len(broker.Messages(1)) == 2 }))
+	require.Equal(t, msgs, broker.Messages(1))
+	require.Empty(t, broker.Messages(0))
+	require.Empty(t, broker.Messages(2))
+	require.Equal(t, 1, broker.NumProduceRequests())
+	require.Equal(t, maxProduceVersion, broker.lastProduceVersion())
+
+	require.Error(t, p.Produce(3, msgs))
+}
+
+func TestProducerNegotiateVersions(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+	broker.setAPIVersions(metadataAPIKey, 0, 0)
+	broker.setAPIVersions(produceAPIKey, 0, 4)
+
+	p := newTestProducer(t, testOptions(broker))
+	defer p.Close()
+
+	msgs := []Message{{Key: []byte("foo"), Value: []byte("bar")}}
+	require.NoError(t, p.Produce(0, msgs))
+	require.True(t, waitFor(func() bool { return len(broker.Messages(0)) == 1 }))
+	require.Equal(t, msgs, broker.Messages(0))
+	require.Equal(t, int16(4), broker.lastProduceVersion())
+}
+
+func TestProducerUnsupportedVersion(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	broker.setAPIVersions(metadataAPIKey, 5, 9)
+	p := NewProducer(testOptions(broker))
+	require.Error(t, p.Init())
+
+	broker.setAPIVersions(metadataAPIKey, minMetadataVersion, maxMetadataVersion)
+	broker.setAPIVersions(produceAPIKey, 0, 2)
+	scope := tally.NewTestScope("", nil)
+	p = newTestProducer(t, testOptions(broker).
+		SetInstrumentOptions(instrument.NewOptions().SetMetricsScope(scope)))
+	defer p.Close()
+
+	// The unsupported version is not retried.
+	require.NoError(t, p.Produce(0, []Message{{Key: []byte("foo"), Value: []byte("bar")}}))
+	require.True(t, waitFor(func() bool { return counterValue(scope, "produce.dropped") == 1 }))
+	require.Equal(t, int64(0), counterValue(scope, "produce.retries"))
+	require.Equal(t, 0, broker.NumProduceRequests())
+}
+
+func TestProducerNoRequiredAcks(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	p := newTestProducer(t, testOptions(broker).SetRequiredAcks(0))
+	defer p.Close()
+
+	msgs := []Message{{Key: []byte("foo"), Value: []byte("bar")}}
+	require.NoError(t, p.Produce(0, msgs))
+	require.True(t, waitFor(func() bool { return len(broker.Messages(0)) == 1 }))
+}
+
+func TestProducerRetryRetriableError(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	scope := tally.NewTestScope("", nil)
+	p := newTestProducer(t, testOptions(broker).
+		SetInstrumentOptions(instrument.NewOptions().SetMetricsScope(scope)))
+	defer p.Close()
+	require.Equal(t, 1, broker.NumMetadataRequests())
+
+	broker.FailProduce(ErrNotLeaderForPartition)
+	msgs := []Message{{Key: []byte("foo"), Value: []byte("bar")}}
+	require.NoError(t, p.Produce(0, msgs))
+	require.True(t, waitFor(func() bool { return len(broker.Messages(0)) == 1 }))
+	require.Equal(t, msgs, broker.Messages(0))
+	require.Equal(t, 2, broker.NumProduceRequests())
+	// The metadata is refreshed before retrying.
+	require.Equal(t, 2, broker.NumMetadataRequests())
+
+	broker.FailProduce(ErrNotLeaderForPartition, ErrNotLeaderForPartition)
+	require.NoError(t, p.Produce(0, msgs))
+	require.True(t, waitFor(func() bool { return counterValue(scope, "produce.dropped") == 1 }))
+	require.Equal(t, 4, broker.NumProduceRequests())
+	require.Equal(t, 1, len(broker.Messages(0)))
+}
+
+func TestProducerNonRetriableError(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	scope := tally.NewTestScope("", nil)
+	p := newTestProducer(t, testOptions(broker).
+		SetInstrumentOptions(instrument.NewOptions().SetMetricsScope(scope)))
+	defer p.Close()
+
+	broker.FailProduce(KError(10))
+	msgs := []Message{{Key: []byte("foo"), Value: []byte("bar")}}
+	require.NoError(t, p.Produce(0, msgs))
+	require.True(t, waitFor(func() bool { return counterValue(scope, "produce.dropped") == 1 }))
+	require.Equal(t, 1, broker.NumProduceRequests())
+	require.Empty(t, broker.Messages(0))
+}
+
+func TestProducerReconnect(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	scope := tally.NewTestScope("", nil)
+	p := newTestProducer(t, testOptions(broker).
+		SetInstrumentOptions(instrument.NewOptions().SetMetricsScope(scope)))
+	defer p.Close()
+
+	msgs := []Message{{Key: []byte("foo"), Value: []byte("bar")}}
+	require.NoError(t, p.Produce(0, msgs))
+	require.True(t, waitFor(func() bool { return len(broker.Messages(0)) == 1 }))
+	broker.Close()
+	require.NoError(t, p.Produce(0, msgs))
+	require.True(t, waitFor(func() bool { return counterValue(scope, "produce.dropped") == 1 }))
+}
+
+func TestProducerBufferFull(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	scope := tally.NewTestScope("", nil)
+	p := newTestProducer(t, testOptions(broker).
+		SetInstrumentOptions(instrument.NewOptions().SetMetricsScope(scope)).
+		SetMaxBufferSize(2).
+		SetRetryBackoff(time.Hour))
+
+	msg := Message{Key: []byte("foo"), Value: []byte("bar")}
+	require.Equal(t, errProducerBufferFull, p.Produce(0, []Message{msg, msg, msg}))
+
+	// The messages stay buffered while the produce request is backing off.
+	broker.FailProduce(ErrNotLeaderForPartition)
+	require.NoError(t, p.Produce(0, []Message{msg, msg}))
+	require.Equal(t, errProducerBufferFull, p.Produce(0, []Message{msg}))
+	require.Equal(t, int64(2), counterValue(scope, "produce.buffer-full"))
+
+	// Closing the producer interrupts the backoff and drops the messages.
+	require.True(t, waitFor(func() bool { return broker.NumProduceRequests() == 1 }))
+	require.NoError(t, p.Close())
+	require.Equal(t, int64(2), counterValue(scope, "produce.dropped"))
+	require.Empty(t, broker.Messages(0))
+}
+
+func TestProducerUnknownTopic(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	p := NewProducer(testOptions(broker).SetTopic("unknown"))
+	require.Equal(t, ErrUnknownTopicOrPartition, p.Init())
+	require.Equal(t, int32(0), p.NumPartitions())
+	require.NoError(t, p.Close())
+}
+
+func TestProducerNoBrokerAvailable(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	opts := testOptions(broker)
+	broker.Close()
+
+	p := NewProducer(opts)
+	require.Error(t, p.Init())
+}
+
+func TestProducerClose(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	p := newTestProducer(t, testOptions(broker))
+	msgs := []Message{{Key: []byte("foo"), Value: []byte("bar")}}
+	require.NoError(t, p.Produce(0, msgs))
+
+	// The buffered messages are produced before closing.
+	require.NoError(t, p.Close())
+	require.Equal(t, msgs, broker.Messages(0))
+	require.Equal(t, errProducerClosed, p.Close())
+	require.Equal(t, errProducerClosed, p.Produce(0, msgs))
+}
+
+func newTestBroker(t *testing.T, numPartitions int32) *FakeBroker {
+	broker, err := NewFakeBroker(testTopic, numPartitions)
+	require.NoError(t, err)
+	return broker
+}
+
+func testOptions(broker *FakeBroker) Options {
+	return NewOptions().
+		SetBrokers([]string{broker.Addr()}).
+		SetTopic(testTopic).
+		SetMaxRetries(1).
+		SetRetryBackoff(time.Millisecond).
+		SetRequestTimeout(time.Second)
+}
+
+func newTestProducer(t *testing.T, opts Options) Producer {
+	require.NoError(t, opts.Validate())
+	p := NewProducer(opts)
+	require.NoError(t, p.Init())
+	return p
+}
+
+func waitFor(fn func() bool) bool {
+	for i := 0; i < 100; i++ {
+		if fn() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func counterValue(scope tally.TestScope, name string) int64 {
+	c, ok := scope.Snapshot().Counters()[name+"+"]
+	if !ok {
+		return 0
+	}
+	return c.Value()
+}
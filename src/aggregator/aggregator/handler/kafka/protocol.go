@@ -0,0 +1,599 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"time"
+)
+
+// The writer speaks the subset of the Kafka wire protocol needed to publish
+// messages: ApiVersions to negotiate the versions of the other requests with
+// every broker, Metadata v0 to v4 to discover the partition leaders and
+// Produce v3 to v7 with record batches (message format v2), which are
+// supported by brokers since Kafka 0.11 including Kafka 4.0.
+
+const (
+	produceAPIKey     int16 = 0
+	metadataAPIKey    int16 = 3
+	apiVersionsAPIKey int16 = 18
+
+	minProduceVersion  int16 = 3
+	maxProduceVersion  int16 = 7
+	minMetadataVersion int16 = 0
+	maxMetadataVersion int16 = 4
+	apiVersionsVersion int16 = 0
+
+	recordBatchMagic = int8(2)
+)
+
+var (
+	errMalformedResponse = errors.New("malformed kafka response")
+
+	crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// KError is an error code returned by a Kafka broker.
+type KError int16
+
+// A list of error codes handled by the producer.
+const (
+	ErrNoError                 KError = 0
+	ErrCorruptMessage          KError = 2
+	ErrUnknownTopicOrPartition KError = 3
+	ErrLeaderNotAvailable      KError = 5
+	ErrNotLeaderForPartition   KError = 6
+	ErrRequestTimedOut         KError = 7
+	ErrBrokerNotAvailable      KError = 8
+	ErrReplicaNotAvailable     KError = 9
+	ErrNetworkException        KError = 13
+	ErrNotEnoughReplicas       KError = 19
+	ErrNotEnoughReplicasAfter  KError = 20
+	ErrUnsupportedVersion      KError = 35
+)
+
+func (e KError) Error() string {
+	return fmt.Sprintf("kafka error code %d", int16(e))
+}
+
+// Retriable returns whether the request may succeed if retried, generally
+// after refreshing the topic metadata.
+func (e KError) Retriable() bool {
+	switch e {
+	case ErrUnknownTopicOrPartition,
+		ErrLeaderNotAvailable,
+		ErrNotLeaderForPartition,
+		ErrRequestTimedOut,
+		ErrBrokerNotAvailable,
+		ErrReplicaNotAvailable,
+		ErrNetworkException,
+		ErrNotEnoughReplicas,
+		ErrNotEnoughReplicasAfter:
+		return true
+	default:
+		return false
+	}
+}
+
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) putInt8(v int8) {
+	e.buf = append(e.buf, byte(v))
+}
+
+func (e *encoder) putInt16(v int16) {
+	var b [2]byte
+	binary.BigEndian.PutUint16(b[:], uint16(v))
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *encoder) putInt32(v int32) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *encoder) putInt64(v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *encoder) putBool(v bool) {
+	if v {
+		e.putInt8(1)
+		return
+	}
+	e.putInt8(0)
+}
+
+func (e *encoder) putVarint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], v)
+	e.buf = append(e.buf, b[:n]...)
+}
+
+func (e *encoder) putString(v string) {
+	e.putInt16(int16(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) putNullableString(v *string) {
+	if v == nil {
+		e.putInt16(-1)
+		return
+	}
+	e.putString(*v)
+}
+
+// putVarintBytes encodes the bytes with a varint length prefix as used by
+// the records of a record batch.
+func (e *encoder) putVarintBytes(v []byte) {
+	if v == nil {
+		e.putVarint(-1)
+		return
+	}
+	e.putVarint(int64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) putArrayLength(n int) {
+	e.putInt32(int32(n))
+}
+
+// putInt32At overwrites the int32 at the given position, used to fill in
+// size prefixes once the size is known.
+func (e *encoder) putInt32At(pos int, v int32) {
+	binary.BigEndian.PutUint32(e.buf[pos:], uint32(v))
+}
+
+type decoder struct {
+	buf []byte
+	off int
+	err error
+}
+
+func (d *decoder) ensure(n int) bool {
+	if d.err != nil {
+		return false
+	}
+	if n < 0 || len(d.buf)-d.off < n {
+		d.err = errMalformedResponse
+		return false
+	}
+	return true
+}
+
+func (d *decoder) int8() int8 {
+	if !d.ensure(1) {
+		return 0
+	}
+	v := int8(d.buf[d.off])
+	d.off++
+	return v
+}
+
+func (d *decoder) int16() int16 {
+	if !d.ensure(2) {
+		return 0
+	}
+	v := int16(binary.BigEndian.Uint16(d.buf[d.off:]))
+	d.off += 2
+	return v
+}
+
+func (d *decoder) int32() int32 {
+	if !d.ensure(4) {
+		return 0
+	}
+	v := int32(binary.BigEndian.Uint32(d.buf[d.off:]))
+	d.off += 4
+	return v
+}
+
+func (d *decoder) int64() int64 {
+	if !d.ensure(8) {
+		return 0
+	}
+	v := int64(binary.BigEndian.Uint64(d.buf[d.off:]))
+	d.off += 8
+	return v
+}
+
+func (d *decoder) bool() bool {
+	return d.int8() != 0
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf[d.off:])
+	if n <= 0 {
+		d.err = errMalformedResponse
+		return 0
+	}
+	d.off += n
+	return v
+}
+
+func (d *decoder) string() string {
+	n := int(d.int16())
+	if n < 0 || !d.ensure(n) {
+		return ""
+	}
+	v := string(d.buf[d.off : d.off+n])
+	d.off += n
+	return v
+}
+
+func (d *decoder) nullableString() *string {
+	n := int(d.int16())
+	if n < 0 || !d.ensure(n) {
+		return nil
+	}
+	v := string(d.buf[d.off : d.off+n])
+	d.off += n
+	return &v
+}
+
+func (d *decoder) varintBytes() []byte {
+	n := int(d.varint())
+	if n < 0 || !d.ensure(n) {
+		return nil
+	}
+	v := d.buf[d.off : d.off+n]
+	d.off += n
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	n := int(d.int32())
+	if n < 0 || !d.ensure(n) {
+		return nil
+	}
+	v := d.buf[d.off : d.off+n]
+	d.off += n
+	return v
+}
+
+func (d *decoder) arrayLength() int {
+	n := int(d.int32())
+	if n < 0 {
+		return 0
+	}
+	// Every array element takes at least one byte.
+	if !d.ensure(n) {
+		return 0
+	}
+	return n
+}
+
+type request interface {
+	apiKey() int16
+	// versions returns the range of versions of the request supported by the
+	// producer.
+	versions() (min int16, max int16)
+	encode(e *encoder, version int16)
+}
+
+type response interface {
+	decode(d *decoder, version int16) error
+}
+
+// encodeRequest encodes the request with its size prefix and header.
+func encodeRequest(
+	correlationID int32,
+	clientID string,
+	req request,
+	version int16,
+) []byte {
+	e := &encoder{}
+	e.putInt32(0)
+	e.putInt16(req.apiKey())
+	e.putInt16(version)
+	e.putInt32(correlationID)
+	e.putString(clientID)
+	req.encode(e, version)
+	e.putInt32At(0, int32(len(e.buf)-4))
+	return e.buf
+}
+
+type apiVersionsRequest struct{}
+
+func (r *apiVersionsRequest) apiKey() int16 { return apiVersionsAPIKey }
+
+func (r *apiVersionsRequest) versions() (int16, int16) {
+	return apiVersionsVersion, apiVersionsVersion
+}
+
+func (r *apiVersionsRequest) encode(e *encoder, version int16) {}
+
+type apiVersionRange struct {
+	min int16
+	max int16
+}
+
+type apiVersionsResponse struct {
+	err      KError
+	versions map[int16]apiVersionRange
+}
+
+func (r *apiVersionsResponse) decode(d *decoder, version int16) error {
+	r.err = KError(d.int16())
+	n := d.arrayLength()
+	r.versions = make(map[int16]apiVersionRange, n)
+	for i := 0; i < n; i++ {
+		apiKey := d.int16()
+		r.versions[apiKey] = apiVersionRange{
+			min: d.int16(),
+			max: d.int16(),
+		}
+	}
+	return d.err
+}
+
+// unsupportedVersionError is returned when the broker supports none of the
+// versions of a request supported by the producer, it is not retriable.
+type unsupportedVersionError struct {
+	apiKey int16
+	broker apiVersionRange
+	ok     bool
+}
+
+func (e unsupportedVersionError) Error() string {
+	if !e.ok {
+		return fmt.Sprintf("kafka broker does not support api key %d", e.apiKey)
+	}
+	return fmt.Sprintf("kafka broker supports versions %d to %d of api key %d",
+		e.broker.min, e.broker.max, e.apiKey)
+}
+
+// negotiateVersion returns the highest version of the request supported by
+// both the producer and the broker.
+func negotiateVersion(req request, versions map[int16]apiVersionRange) (int16, error) {
+	min, max := req.versions()
+	broker, ok := versions[req.apiKey()]
+	if !ok || broker.min > max || broker.max < min {
+		return 0, unsupportedVersionError{apiKey: req.apiKey(), broker: broker, ok: ok}
+	}
+	if broker.max < max {
+		max = broker.max
+	}
+	return max, nil
+}
+
+type metadataRequest struct {
+	topics []string
+}
+
+func (r *metadataRequest) apiKey() int16 { return metadataAPIKey }
+
+func (r *metadataRequest) versions() (int16, int16) {
+	return minMetadataVersion, maxMetadataVersion
+}
+
+func (r *metadataRequest) encode(e *encoder, version int16) {
+	e.putArrayLength(len(r.topics))
+	for _, topic := range r.topics {
+		e.putString(topic)
+	}
+	if version >= 4 {
+		// Do not create the topic if it does not exist.
+		e.putBool(false)
+	}
+}
+
+type brokerMetadata struct {
+	nodeID int32
+	host   string
+	port   int32
+}
+
+func (b brokerMetadata) addr() string {
+	return fmt.Sprintf("%s:%d", b.host, b.port)
+}
+
+type partitionMetadata struct {
+	err       KError
+	partition int32
+	leader    int32
+}
+
+type topicMetadata struct {
+	err        KError
+	name       string
+	partitions []partitionMetadata
+}
+
+type metadataResponse struct {
+	brokers []brokerMetadata
+	topics  []topicMetadata
+}
+
+func (r *metadataResponse) decode(d *decoder, version int16) error {
+	if version >= 3 {
+		// Skip the throttle time.
+		d.int32()
+	}
+	r.brokers = make([]brokerMetadata, d.arrayLength())
+	for i := range r.brokers {
+		r.brokers[i] = brokerMetadata{
+			nodeID: d.int32(),
+			host:   d.string(),
+			port:   d.int32(),
+		}
+		if version >= 1 {
+			// Skip the rack.
+			d.nullableString()
+		}
+	}
+	if version >= 2 {
+		// Skip the cluster id.
+		d.nullableString()
+	}
+	if version >= 1 {
+		// Skip the controller id.
+		d.int32()
+	}
+	r.topics = make([]topicMetadata, d.arrayLength())
+	for i := range r.topics {
+		topic := &r.topics[i]
+		topic.err = KError(d.int16())
+		topic.name = d.string()
+		if version >= 1 {
+			// Skip whether the topic is internal.
+			d.bool()
+		}
+		topic.partitions = make([]partitionMetadata, d.arrayLength())
+		for j := range topic.partitions {
+			topic.partitions[j] = partitionMetadata{
+				err:       KError(d.int16()),
+				partition: d.int32(),
+				leader:    d.int32(),
+			}
+			// Skip the replicas and the in-sync replicas.
+			for k, n := 0, d.arrayLength(); k < n; k++ {
+				d.int32()
+			}
+			for k, n := 0, d.arrayLength(); k < n; k++ {
+				d.int32()
+			}
+		}
+	}
+	return d.err
+}
+
+type produceRequest struct {
+	requiredAcks int16
+	timeout      time.Duration
+	topic        string
+	partition    int32
+	msgs         []Message
+}
+
+func (r *produceRequest) apiKey() int16 { return produceAPIKey }
+
+func (r *produceRequest) versions() (int16, int16) {
+	return minProduceVersion, maxProduceVersion
+}
+
+func (r *produceRequest) encode(e *encoder, version int16) {
+	// Not a transactional producer.
+	e.putNullableString(nil)
+	e.putInt16(r.requiredAcks)
+	e.putInt32(int32(r.timeout / time.Millisecond))
+	e.putArrayLength(1)
+	e.putString(r.topic)
+	e.putArrayLength(1)
+	e.putInt32(r.partition)
+	sizePos := len(e.buf)
+	e.putInt32(0)
+	encodeRecordBatch(e, r.msgs)
+	e.putInt32At(sizePos, int32(len(e.buf)-sizePos-4))
+}
+
+// encodeRecordBatch encodes the messages as a single uncompressed record
+// batch (message format v2). The offsets are assigned by the broker.
+func encodeRecordBatch(e *encoder, msgs []Message) {
+	var (
+		firstTimestamp = msgs[0].TimestampNanos / int64(time.Millisecond)
+		maxTimestamp   = firstTimestamp
+	)
+	for _, msg := range msgs {
+		if ts := msg.TimestampNanos / int64(time.Millisecond); ts > maxTimestamp {
+			maxTimestamp = ts
+		}
+	}
+	// The base offset.
+	e.putInt64(0)
+	lengthPos := len(e.buf)
+	e.putInt32(0)
+	// The partition leader epoch is set by the broker.
+	e.putInt32(-1)
+	e.putInt8(recordBatchMagic)
+	crcPos := len(e.buf)
+	e.putInt32(0)
+	// No compression, timestamps are set by the producer.
+	e.putInt16(0)
+	e.putInt32(int32(len(msgs) - 1))
+	e.putInt64(firstTimestamp)
+	e.putInt64(maxTimestamp)
+	// Not an idempotent producer: no producer id, epoch or sequence.
+	e.putInt64(-1)
+	e.putInt16(-1)
+	e.putInt32(-1)
+	e.putArrayLength(len(msgs))
+	record := &encoder{}
+	for i, msg := range msgs {
+		record.buf = record.buf[:0]
+		// No record attributes.
+		record.putInt8(0)
+		record.putVarint(msg.TimestampNanos/int64(time.Millisecond) - firstTimestamp)
+		record.putVarint(int64(i))
+		record.putVarintBytes(msg.Key)
+		record.putVarintBytes(msg.Value)
+		// No headers.
+		record.putVarint(0)
+		e.putVarint(int64(len(record.buf)))
+		e.buf = append(e.buf, record.buf...)
+	}
+	e.putInt32At(crcPos, int32(crc32.Checksum(e.buf[crcPos+4:], crc32cTable)))
+	e.putInt32At(lengthPos, int32(len(e.buf)-lengthPos-4))
+}
+
+type producePartitionResponse struct {
+	partition  int32
+	err        KError
+	baseOffset int64
+}
+
+type produceResponse struct {
+	partitions []producePartitionResponse
+}
+
+func (r *produceResponse) decode(d *decoder, version int16) error {
+	for i, n := 0, d.arrayLength(); i < n; i++ {
+		// Only a single topic is produced to per request.
+		d.string()
+		for j, m := 0, d.arrayLength(); j < m; j++ {
+			r.partitions = append(r.partitions, producePartitionResponse{
+				partition:  d.int32(),
+				err:        KError(d.int16()),
+				baseOffset: d.int64(),
+			})
+			// Skip the log append time.
+			d.int64()
+			if version >= 5 {
+				// Skip the log start offset.
+				d.int64()
+			}
+		}
+	}
+	// Skip the throttle time.
+	d.int32()
+	return d.err
+}
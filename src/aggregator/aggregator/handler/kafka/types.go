@@ -0,0 +1,107 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package kafka provides a writer that publishes aggregated metrics to a
+// Kafka topic using the Kafka wire protocol.
+package kafka
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Message is a message produced to a Kafka topic partition.
+type Message struct {
+	Key            []byte
+	Value          []byte
+	TimestampNanos int64
+}
+
+// Producer produces messages to a Kafka topic.
+type Producer interface {
+	// Init initializes the producer by fetching the topic metadata.
+	Init() error
+
+	// NumPartitions returns the number of partitions of the topic when the
+	// producer was initialized.
+	NumPartitions() int32
+
+	// Produce buffers the messages to be produced to the given partition in
+	// the background, returning an error without buffering them if the
+	// producer is closed or its buffer is full. The messages that could not
+	// be produced after the configured retries are dropped.
+	Produce(partition int32, msgs []Message) error
+
+	// Close closes the producer after producing the buffered messages.
+	Close() error
+}
+
+// Encoding is the encoding of the metrics published to Kafka.
+type Encoding string
+
+// A list of supported encodings.
+const (
+	// JSONEncoding encodes metrics as JSON objects.
+	JSONEncoding Encoding = "json"
+
+	// ProtobufEncoding encodes metrics as metricpb.AggregatedMetric protobuf
+	// messages, the same format used by the m3msg protobuf writer.
+	ProtobufEncoding Encoding = "protobuf"
+
+	defaultEncoding = JSONEncoding
+)
+
+var (
+	validEncodings = []Encoding{
+		JSONEncoding,
+		ProtobufEncoding,
+	}
+)
+
+// UnmarshalYAML unmarshals YAML into an encoding.
+func (e *Encoding) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	if str == "" {
+		*e = defaultEncoding
+		return nil
+	}
+	validStrs := make([]string, 0, len(validEncodings))
+	for _, valid := range validEncodings {
+		if str == string(valid) {
+			*e = valid
+			return nil
+		}
+		validStrs = append(validStrs, string(valid))
+	}
+	return fmt.Errorf("invalid encoding '%s' valid encodings are: %s",
+		str, strings.Join(validStrs, ", "))
+}
+
+func (e Encoding) validate() error {
+	for _, valid := range validEncodings {
+		if e == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid encoding '%s'", e)
+}
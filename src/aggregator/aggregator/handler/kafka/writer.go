@@ -0,0 +1,226 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
+	"github.com/m3db/m3/src/aggregator/sharding"
+	"github.com/m3db/m3/src/metrics/encoding/protobuf"
+	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	xerrors "github.com/m3db/m3/src/x/errors"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	errWriterClosed    = errors.New("kafka writer is closed")
+	errNoPartitions    = errors.New("kafka topic has no partitions")
+	errUnknownEncoding = errors.New("unknown kafka encoding")
+)
+
+type writerMetrics struct {
+	writerClosed  tally.Counter
+	encodeSuccess tally.Counter
+	encodeErrors  tally.Counter
+	flushSuccess  tally.Counter
+	flushErrors   tally.Counter
+	dropped       tally.Counter
+}
+
+func newWriterMetrics(scope tally.Scope) writerMetrics {
+	encodeScope := scope.SubScope("encode")
+	flushScope := scope.SubScope("flush")
+	return writerMetrics{
+		writerClosed:  scope.Counter("writer-closed"),
+		encodeSuccess: encodeScope.Counter("success"),
+		encodeErrors:  encodeScope.Counter("errors"),
+		flushSuccess:  flushScope.Counter("success"),
+		flushErrors:   flushScope.Counter("errors"),
+		dropped:       flushScope.Counter("dropped"),
+	}
+}
+
+// kafkaWriter encodes metrics and buffers them per partition until they
+// are flushed to the producer.
+// kafkaWriter is not thread safe.
+type kafkaWriter struct {
+	p            Producer
+	encodeFn     encodeFn
+	shardFn      sharding.ShardFn
+	numShards    uint32
+	maxBatchSize int
+
+	closed     bool
+	m          aggregated.MetricWithStoragePolicy
+	pending    map[int32][]Message
+	numPending int
+	metrics    writerMetrics
+}
+
+// NewWriter creates a writer that publishes metrics to Kafka. The metrics
+// are hashed to shards by their ids, and each shard is mapped to a partition
+// of the topic.
+func NewWriter(p Producer, opts Options) (writer.Writer, error) {
+	shardFn, err := opts.HashType().ShardFn()
+	if err != nil {
+		return nil, err
+	}
+	encodeFn, err := newEncodeFn(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaWriter{
+		p:            p,
+		encodeFn:     encodeFn,
+		shardFn:      shardFn,
+		numShards:    opts.NumShards(),
+		maxBatchSize: opts.MaxBatchSize(),
+		pending:      make(map[int32][]Message),
+		metrics:      newWriterMetrics(opts.InstrumentOptions().MetricsScope()),
+	}, nil
+}
+
+func (w *kafkaWriter) Write(mp aggregated.ChunkedMetricWithStoragePolicy) error {
+	if w.closed {
+		w.metrics.writerClosed.Inc(1)
+		return errWriterClosed
+	}
+	numPartitions := uint32(w.p.NumPartitions())
+	if numPartitions == 0 {
+		return errNoPartitions
+	}
+	w.m.ID = w.m.ID[:0]
+	w.m.ID = append(w.m.ID, mp.Prefix...)
+	w.m.ID = append(w.m.ID, mp.Data...)
+	w.m.ID = append(w.m.ID, mp.Suffix...)
+	w.m.Metric.TimeNanos = mp.TimeNanos
+	w.m.Metric.Value = mp.Value
+	w.m.StoragePolicy = mp.StoragePolicy
+	value, err := w.encodeFn(w.m)
+	if err != nil {
+		w.metrics.encodeErrors.Inc(1)
+		return err
+	}
+	w.metrics.encodeSuccess.Inc(1)
+
+	numShards := w.numShards
+	if numShards == 0 {
+		numShards = numPartitions
+	}
+	partition := int32(w.shardFn(w.m.ID, numShards) % numPartitions)
+	w.pending[partition] = append(w.pending[partition], Message{
+		Key:            append([]byte(nil), w.m.ID...),
+		Value:          value,
+		TimestampNanos: mp.TimeNanos,
+	})
+	w.numPending++
+	if w.numPending >= w.maxBatchSize {
+		return w.Flush()
+	}
+	return nil
+}
+
+func (w *kafkaWriter) Flush() error {
+	if w.numPending == 0 {
+		return nil
+	}
+	partitions := make([]int32, 0, len(w.pending))
+	for partition := range w.pending {
+		partitions = append(partitions, partition)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	var multiErr xerrors.MultiError
+	for _, partition := range partitions {
+		msgs := w.pending[partition]
+		// The producer produces the messages in the background, the messages
+		// are dropped if they could not be buffered.
+		if err := w.p.Produce(partition, msgs); err != nil {
+			w.metrics.flushErrors.Inc(1)
+			w.metrics.dropped.Inc(int64(len(msgs)))
+			multiErr = multiErr.Add(err)
+		} else {
+			w.metrics.flushSuccess.Inc(1)
+		}
+		delete(w.pending, partition)
+	}
+	w.numPending = 0
+	return multiErr.FinalError()
+}
+
+func (w *kafkaWriter) Close() error {
+	if w.closed {
+		w.metrics.writerClosed.Inc(1)
+		return errWriterClosed
+	}
+	// Don't close the producer here, it is shared by other writers.
+	err := w.Flush()
+	w.closed = true
+	return err
+}
+
+type encodeFn func(m aggregated.MetricWithStoragePolicy) ([]byte, error)
+
+func newEncodeFn(opts Options) (encodeFn, error) {
+	switch opts.Encoding() {
+	case JSONEncoding:
+		return encodeJSON, nil
+	case ProtobufEncoding:
+		return newProtobufEncodeFn(protobuf.NewAggregatedEncoder(opts.BytesPool())), nil
+	default:
+		return nil, errUnknownEncoding
+	}
+}
+
+// JSONMetric is the JSON representation of a metric published to Kafka.
+type JSONMetric struct {
+	ID            string  `json:"id"`
+	TimeNanos     int64   `json:"timeNanos"`
+	Value         float64 `json:"value"`
+	StoragePolicy string  `json:"storagePolicy"`
+}
+
+func encodeJSON(m aggregated.MetricWithStoragePolicy) ([]byte, error) {
+	return json.Marshal(JSONMetric{
+		ID:            string(m.ID),
+		TimeNanos:     m.TimeNanos,
+		Value:         m.Value,
+		StoragePolicy: m.StoragePolicy.String(),
+	})
+}
+
+func newProtobufEncodeFn(encoder protobuf.AggregatedEncoder) encodeFn {
+	return func(m aggregated.MetricWithStoragePolicy) ([]byte, error) {
+		if err := encoder.Encode(m, 0); err != nil {
+			return nil, err
+		}
+		// The messages are buffered until flushed, copy the bytes so the
+		// encoder buffer can be returned to the pool.
+		buf := encoder.Buffer()
+		value := append([]byte(nil), buf.Bytes()...)
+		buf.Close()
+		return value, nil
+	}
+}
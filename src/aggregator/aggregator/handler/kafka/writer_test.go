@@ -0,0 +1,238 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package kafka
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/sharding"
+	"github.com/m3db/m3/src/metrics/encoding/protobuf"
+	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/policy"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testStoragePolicy = policy.NewStoragePolicy(10*time.Second, xtime.Second, 48*time.Hour)
+	testMetrics       = []aggregated.ChunkedMetricWithStoragePolicy{
+		testChunkedMetric("foo", 1000*int64(time.Second), 1.5),
+		testChunkedMetric("bar", 2000*int64(time.Second), 2.5),
+		testChunkedMetric("baz", 3000*int64(time.Second), 3.5),
+		testChunkedMetric("qux", 4000*int64(time.Second), 4.5),
+	}
+)
+
+func TestWriterJSONEncoding(t *testing.T) {
+	broker := newTestBroker(t, 4)
+	defer broker.Close()
+
+	opts := testOptions(broker).SetEncoding(JSONEncoding)
+	p := newTestProducer(t, opts)
+	defer p.Close()
+
+	w, err := NewWriter(p, opts)
+	require.NoError(t, err)
+	for _, m := range testMetrics {
+		require.NoError(t, w.Write(m))
+	}
+	require.Equal(t, 0, broker.NumProduceRequests())
+	require.NoError(t, w.Flush())
+	require.True(t, waitFor(func() bool { return numBrokerMessages(broker, 4) == len(testMetrics) }))
+
+	shardFn := sharding.Murmur32Hash.MustShardFn()
+	var numMessages int
+	for partition := int32(0); partition < 4; partition++ {
+		for _, msg := range broker.Messages(partition) {
+			numMessages++
+			require.Equal(t, partition, int32(shardFn(msg.Key, 4)))
+
+			var m JSONMetric
+			require.NoError(t, json.Unmarshal(msg.Value, &m))
+			require.Equal(t, string(msg.Key), m.ID)
+			require.Equal(t, "10s:2d", m.StoragePolicy)
+			require.Equal(t, m.TimeNanos, msg.TimestampNanos)
+			require.Equal(t, testValue(t, m.ID), m.Value)
+		}
+	}
+	require.Equal(t, len(testMetrics), numMessages)
+	require.NoError(t, w.Close())
+}
+
+func TestWriterProtobufEncoding(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	opts := testOptions(broker).SetEncoding(ProtobufEncoding)
+	p := newTestProducer(t, opts)
+	defer p.Close()
+
+	w, err := NewWriter(p, opts)
+	require.NoError(t, err)
+	for _, m := range testMetrics {
+		require.NoError(t, w.Write(m))
+	}
+	require.NoError(t, w.Close())
+	require.True(t, waitFor(func() bool { return len(broker.Messages(0)) == len(testMetrics) }))
+
+	msgs := broker.Messages(0)
+	d := protobuf.NewAggregatedDecoder(nil)
+	for i, msg := range msgs {
+		require.NoError(t, d.Decode(msg.Value))
+		expected := testMetrics[i]
+		require.Equal(t, expected.ChunkedID.String(), string(d.ID()))
+		require.Equal(t, expected.TimeNanos, d.TimeNanos())
+		require.Equal(t, expected.Value, d.Value())
+		sp, err := d.StoragePolicy()
+		require.NoError(t, err)
+		require.Equal(t, testStoragePolicy, sp)
+	}
+}
+
+func TestWriterPartitionByShard(t *testing.T) {
+	broker := newTestBroker(t, 2)
+	defer broker.Close()
+
+	opts := testOptions(broker).SetNumShards(8)
+	p := newTestProducer(t, opts)
+	defer p.Close()
+
+	w, err := NewWriter(p, opts)
+	require.NoError(t, err)
+	for _, m := range testMetrics {
+		require.NoError(t, w.Write(m))
+	}
+	require.NoError(t, w.Flush())
+	require.True(t, waitFor(func() bool { return numBrokerMessages(broker, 2) == len(testMetrics) }))
+
+	shardFn := sharding.Murmur32Hash.MustShardFn()
+	for partition := int32(0); partition < 2; partition++ {
+		for _, msg := range broker.Messages(partition) {
+			require.Equal(t, partition, int32(shardFn(msg.Key, 8)%2))
+		}
+	}
+}
+
+func TestWriterFlushOnMaxBatchSize(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	opts := testOptions(broker).SetMaxBatchSize(2)
+	p := newTestProducer(t, opts)
+	defer p.Close()
+
+	w, err := NewWriter(p, opts)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(testMetrics[0]))
+	require.Empty(t, broker.Messages(0))
+	require.NoError(t, w.Write(testMetrics[1]))
+	require.True(t, waitFor(func() bool { return len(broker.Messages(0)) == 2 }))
+	require.NoError(t, w.Write(testMetrics[2]))
+	require.Equal(t, 2, len(broker.Messages(0)))
+	require.NoError(t, w.Flush())
+	require.True(t, waitFor(func() bool { return len(broker.Messages(0)) == 3 }))
+}
+
+func TestWriterFlushError(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	opts := testOptions(broker).SetMaxBufferSize(1)
+	p := newTestProducer(t, opts)
+	defer p.Close()
+
+	w, err := NewWriter(p, opts)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(testMetrics[0]))
+	require.NoError(t, w.Write(testMetrics[1]))
+	require.EqualError(t, w.Flush(), errProducerBufferFull.Error())
+
+	// The messages that could not be buffered are dropped.
+	require.NoError(t, w.Flush())
+	require.NoError(t, p.Close())
+	require.Equal(t, 0, broker.NumProduceRequests())
+}
+
+func TestWriterEncodeError(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	opts := testOptions(broker).SetEncoding(JSONEncoding)
+	p := newTestProducer(t, opts)
+	defer p.Close()
+
+	w, err := NewWriter(p, opts)
+	require.NoError(t, err)
+	require.Error(t, w.Write(testChunkedMetric("foo", 1000, math.NaN())))
+}
+
+func TestWriterClosed(t *testing.T) {
+	broker := newTestBroker(t, 1)
+	defer broker.Close()
+
+	opts := testOptions(broker)
+	p := newTestProducer(t, opts)
+	defer p.Close()
+
+	w, err := NewWriter(p, opts)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Equal(t, errWriterClosed, w.Write(testMetrics[0]))
+	require.Equal(t, errWriterClosed, w.Close())
+}
+
+func testChunkedMetric(metricID string, timeNanos int64, value float64) aggregated.ChunkedMetricWithStoragePolicy {
+	return aggregated.ChunkedMetricWithStoragePolicy{
+		ChunkedMetric: aggregated.ChunkedMetric{
+			ChunkedID: id.ChunkedID{
+				Prefix: []byte("stats."),
+				Data:   []byte(metricID),
+				Suffix: []byte(".sum"),
+			},
+			TimeNanos: timeNanos,
+			Value:     value,
+		},
+		StoragePolicy: testStoragePolicy,
+	}
+}
+
+func testValue(t *testing.T, metricID string) float64 {
+	for _, m := range testMetrics {
+		if m.ChunkedID.String() == metricID {
+			return m.Value
+		}
+	}
+	require.FailNow(t, "unknown metric id", metricID)
+	return 0
+}
+
+func numBrokerMessages(broker *FakeBroker, numPartitions int32) int {
+	var n int
+	for partition := int32(0); partition < numPartitions; partition++ {
+		n += len(broker.Messages(partition))
+	}
+	return n
+}
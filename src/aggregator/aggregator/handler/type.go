@@ -32,12 +32,14 @@ type Type string
 const (
 	blackholeType Type = "blackhole"
 	loggingType   Type = "logging"
+	kafkaType     Type = "kafka"
 )
 
 var (
 	validHandlerTypes = []Type{
 		blackholeType,
 		loggingType,
+		kafkaType,
 	}
 )
 
@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/close"
@@ -43,6 +44,10 @@ import (
 const (
 	defaultSoftDeadlineCheckEvery = 128
 	defaultExpireBatchSize        = 1024
+
+	// NB: The entry list is walked with the map lock held which blocks the
+	// creation of new entries, so the number of snapshotted entries is capped.
+	maxSnapshotEntries = 1000
 )
 
 var (
@@ -61,6 +66,24 @@ const (
 	timedMetric
 )
 
+var (
+	metricCategories = []metricCategory{untimedMetric, forwardedMetric, timedMetric}
+	metricTypes      = []metric.Type{metric.CounterType, metric.TimerType, metric.GaugeType}
+)
+
+func (c metricCategory) String() string {
+	switch c {
+	case untimedMetric:
+		return "untimed"
+	case forwardedMetric:
+		return "forwarded"
+	case timedMetric:
+		return "timed"
+	default:
+		return "unknown"
+	}
+}
+
 type entryKey struct {
 	metricCategory metricCategory
 	metricType     metric.Type
@@ -72,6 +95,16 @@ type hashedEntry struct {
 	entry *Entry
 }
 
+func (e hashedEntry) snapshot() EntrySnapshot {
+	snapshot := e.entry.Snapshot()
+	snapshot.Category = e.key.metricCategory.String()
+	snapshot.Type = e.key.metricType.String()
+	if len(snapshot.Elems) > 0 {
+		snapshot.ID = snapshot.Elems[0].ID
+	}
+	return snapshot
+}
+
 type metricMapMetrics struct {
 	newEntries                 tally.Counter
 	noRateLimitWarmup          tally.Counter
@@ -237,6 +270,57 @@ func (m *metricMap) Close() {
 	m.closed = true
 }
 
+// Snapshot returns the snapshots of up to limit entries in the map, the limit
+// is capped at maxSnapshotEntries and is the cap if not positive.
+func (m *metricMap) Snapshot(limit int) []EntrySnapshot {
+	if limit <= 0 || limit > maxSnapshotEntries {
+		limit = maxSnapshotEntries
+	}
+	m.RLock()
+	numEntries := m.entryList.Len()
+	if limit < numEntries {
+		numEntries = limit
+	}
+	entries := make([]hashedEntry, 0, numEntries)
+	for elem := m.entryList.Front(); elem != nil && len(entries) < numEntries; elem = elem.Next() {
+		entries = append(entries, elem.Value.(hashedEntry))
+	}
+	m.RUnlock()
+
+	snapshots := make([]EntrySnapshot, 0, len(entries))
+	for _, entry := range entries {
+		snapshots = append(snapshots, entry.snapshot())
+	}
+	return snapshots
+}
+
+// SnapshotFor returns the snapshots of the entries for the given metric id
+// across all metric categories and types.
+func (m *metricMap) SnapshotFor(id id.RawID) []EntrySnapshot {
+	idHash := hash.Murmur3Hash128(id)
+	var entries []hashedEntry
+	m.RLock()
+	for _, category := range metricCategories {
+		for _, metricType := range metricTypes {
+			key := entryKey{
+				metricCategory: category,
+				metricType:     metricType,
+				idHash:         idHash,
+			}
+			if elem, exists := m.entries[key]; exists {
+				entries = append(entries, elem.Value.(hashedEntry))
+			}
+		}
+	}
+	m.RUnlock()
+
+	snapshots := make([]EntrySnapshot, 0, len(entries))
+	for _, entry := range entries {
+		snapshots = append(snapshots, entry.snapshot())
+	}
+	return snapshots
+}
+
 func (m *metricMap) findOrCreate(key entryKey) (*Entry, error) {
 	m.RLock()
 	if m.closed {
@@ -29,6 +29,7 @@ import (
 
 	"github.com/m3db/m3/src/metrics/metadata"
 	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	"github.com/m3db/m3/src/metrics/metric/id"
 	"github.com/m3db/m3/src/metrics/metric/unaggregated"
 	"github.com/m3db/m3/src/x/clock"
 
@@ -228,6 +229,14 @@ func (s *aggregatorShard) Tick(target time.Duration) tickResult {
 	return s.metricMap.Tick(target)
 }
 
+func (s *aggregatorShard) Snapshot(limit int) []EntrySnapshot {
+	return s.metricMap.Snapshot(limit)
+}
+
+func (s *aggregatorShard) SnapshotFor(id id.RawID) []EntrySnapshot {
+	return s.metricMap.SnapshotFor(id)
+}
+
 func (s *aggregatorShard) Close() {
 	s.Lock()
 	defer s.Unlock()
@@ -0,0 +1,54 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregator
+
+// EntrySnapshot is a point-in-time view of an entry and its elements,
+// intended for inspecting the aggregator state.
+type EntrySnapshot struct {
+	ID              string         `json:"id,omitempty"`
+	Category        string         `json:"category"`
+	Type            string         `json:"type"`
+	CutoverNanos    int64          `json:"cutoverNanos"`
+	LastAccessNanos int64          `json:"lastAccessNanos"`
+	Elems           []ElemSnapshot `json:"elems"`
+}
+
+// ElemSnapshot is a point-in-time view of an element and its pending
+// aggregations.
+type ElemSnapshot struct {
+	ID                string                `json:"id"`
+	Type              string                `json:"type"`
+	StoragePolicy     string                `json:"storagePolicy"`
+	AggregationTypes  []string              `json:"aggregationTypes"`
+	Pipeline          string                `json:"pipeline,omitempty"`
+	ForwardedID       string                `json:"forwardedID,omitempty"`
+	NumForwardedTimes int                   `json:"numForwardedTimes"`
+	Tombstoned        bool                  `json:"tombstoned"`
+	Values            []AggregationSnapshot `json:"values"`
+}
+
+// AggregationSnapshot is a point-in-time view of the aggregated values in
+// an aggregation window that has not been consumed yet. Values that are not
+// a number are omitted.
+type AggregationSnapshot struct {
+	StartAtNanos int64              `json:"startAtNanos"`
+	Values       map[string]float64 `json:"values"`
+}
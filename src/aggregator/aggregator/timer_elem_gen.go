@@ -274,6 +274,44 @@ func (e *TimerElem) Consume(
 	return canCollect
 }
 
+// Snapshot returns a snapshot of the element and its pending aggregations.
+func (e *TimerElem) Snapshot() ElemSnapshot {
+	e.RLock()
+	if e.closed {
+		e.RUnlock()
+		return ElemSnapshot{}
+	}
+	snapshot := e.snapshotWithLock()
+	snapshot.Type = e.Type().String()
+	aggTypes := make(maggregation.Types, len(e.aggTypes))
+	copy(aggTypes, e.aggTypes)
+	values := make([]timedTimer, len(e.values))
+	copy(values, e.values)
+	e.RUnlock()
+
+	snapshot.Values = make([]AggregationSnapshot, 0, len(values))
+	for _, value := range values {
+		value.lockedAgg.Lock()
+		if value.lockedAgg.closed {
+			// The aggregation has been consumed since the values were copied.
+			value.lockedAgg.Unlock()
+			continue
+		}
+		aggValues := make(map[string]float64, len(aggTypes))
+		for _, aggType := range aggTypes {
+			if v := value.lockedAgg.aggregation.ValueOf(aggType); !math.IsNaN(v) {
+				aggValues[aggType.String()] = v
+			}
+		}
+		value.lockedAgg.Unlock()
+		snapshot.Values = append(snapshot.Values, AggregationSnapshot{
+			StartAtNanos: value.startAtNanos,
+			Values:       aggValues,
+		})
+	}
+	return snapshot
+}
+
 // Close closes the element.
 func (e *TimerElem) Close() {
 	e.Lock()
@@ -407,44 +445,102 @@ func (e *TimerElem) processValueWithAggregationLock(
 	flushForwardedFn flushForwardedMetricFn,
 ) {
 	var (
-		transformations  = e.parsedPipeline.Transformations
 		discardNaNValues = e.opts.DiscardNaNAggregatedValues()
+		resolution       = e.sp.Resolution().Window
 	)
 	for aggTypeIdx, aggType := range e.aggTypes {
-		value := lockedAgg.aggregation.ValueOf(aggType)
-		for i := 0; i < transformations.Len(); i++ {
-			transformType := transformations.At(i).Transformation.Type
-			if transformType.IsUnaryTransform() {
-				fn := transformType.MustUnaryTransform()
-				res := fn(transformation.Datapoint{TimeNanos: timeNanos, Value: value})
-				value = res.Value
+		var (
+			dp            = transformation.Datapoint{TimeNanos: timeNanos, Value: lockedAgg.aggregation.ValueOf(aggType)}
+			prevTimeNanos = e.lastConsumedAtNanos
+			from          = 0
+		)
+		for {
+			value, extraDp, extraFrom, hasExtraDp := e.transformValueWithAggregationLock(aggTypeIdx, from, prevTimeNanos, dp, resolution)
+			e.flushValueWithAggregationLock(aggType, dp.TimeNanos, value, discardNaNValues, flushLocalFn, flushForwardedFn)
+			if !hasExtraDp {
+				break
+			}
+			// NB: the extra datapoint produced by a multi-output transformation is emitted
+			// after the current value, and goes through the remaining transformations in
+			// the pipeline with the current value as its previous datapoint.
+			prevTimeNanos, dp, from = dp.TimeNanos, extraDp, extraFrom
+		}
+	}
+	e.lastConsumedAtNanos = timeNanos
+}
+
+// transformValueWithAggregationLock applies the transformations in the pipeline
+// starting at the given index to the datapoint, returning the transformed value.
+// If a multi-output transformation produces an extra datapoint, it is returned
+// along with the index of the transformation following the one producing it.
+func (e *TimerElem) transformValueWithAggregationLock(
+	aggTypeIdx int,
+	from int,
+	prevTimeNanos int64,
+	dp transformation.Datapoint,
+	resolution time.Duration,
+) (float64, transformation.Datapoint, int, bool) {
+	var (
+		transformations = e.parsedPipeline.Transformations
+		value           = dp.Value
+		extraDp         transformation.Datapoint
+		extraFrom       int
+		hasExtraDp      bool
+	)
+	for i := from; i < transformations.Len(); i++ {
+		transformType := transformations.At(i).Transformation.Type
+		curr := transformation.Datapoint{TimeNanos: dp.TimeNanos, Value: value}
+		switch {
+		case transformType.IsUnaryTransform():
+			fn := transformType.MustUnaryTransform()
+			res := fn(curr)
+			value = res.Value
+		case transformType.IsUnaryMultiOutputTransform():
+			fn := transformType.MustUnaryMultiOutputTransform()
+			res, other := fn(curr, resolution)
+			value = res.Value
+			extraDp, extraFrom, hasExtraDp = other, i+1, true
+		default:
+			fn := transformType.MustBinaryTransform()
+			prev := transformation.Datapoint{TimeNanos: prevTimeNanos, Value: e.lastConsumedValues[aggTypeIdx]}
+			res := fn(prev, curr)
+			// NB: we only need to record the value needed for derivative transformations.
+			// We currently only support first-order derivative transformations so we only
+			// need to keep one value. In the future if we need to support higher-order
+			// derivative transformations, we need to store an array of values here.
+			// Cumulative transformations are applied against their own previous result
+			// so the result is recorded instead of the input value.
+			if transformType.IsCumulativeTransform() {
+				e.lastConsumedValues[aggTypeIdx] = res.Value
 			} else {
-				fn := transformType.MustBinaryTransform()
-				prev := transformation.Datapoint{TimeNanos: e.lastConsumedAtNanos, Value: e.lastConsumedValues[aggTypeIdx]}
-				curr := transformation.Datapoint{TimeNanos: timeNanos, Value: value}
-				res := fn(prev, curr)
-				// NB: we only need to record the value needed for derivative transformations.
-				// We currently only support first-order derivative transformations so we only
-				// need to keep one value. In the future if we need to support higher-order
-				// derivative transformations, we need to store an array of values here.
 				e.lastConsumedValues[aggTypeIdx] = value
-				value = res.Value
 			}
+			value = res.Value
 		}
-		if discardNaNValues && math.IsNaN(value) {
-			continue
-		}
-		if !e.parsedPipeline.HasRollup {
-			switch e.idPrefixSuffixType {
-			case NoPrefixNoSuffix:
-				flushLocalFn(nil, e.id, nil, timeNanos, value, e.sp)
-			case WithPrefixWithSuffix:
-				flushLocalFn(e.FullPrefix(e.opts), e.id, e.TypeStringFor(e.aggTypesOpts, aggType), timeNanos, value, e.sp)
-			}
-		} else {
-			forwardedAggregationKey, _ := e.ForwardedAggregationKey()
-			flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, value)
+	}
+	return value, extraDp, extraFrom, hasExtraDp
+}
+
+func (e *TimerElem) flushValueWithAggregationLock(
+	aggType maggregation.Type,
+	timeNanos int64,
+	value float64,
+	discardNaNValues bool,
+	flushLocalFn flushLocalMetricFn,
+	flushForwardedFn flushForwardedMetricFn,
+) {
+	if discardNaNValues && math.IsNaN(value) {
+		return
+	}
+	if !e.parsedPipeline.HasRollup {
+		switch e.idPrefixSuffixType {
+		case NoPrefixNoSuffix:
+			flushLocalFn(nil, e.id, nil, timeNanos, value, e.sp)
+		case WithPrefixWithSuffix:
+			flushLocalFn(e.FullPrefix(e.opts), e.id, e.TypeStringFor(e.aggTypesOpts, aggType), timeNanos, value, e.sp)
 		}
+	} else {
+		forwardedAggregationKey, _ := e.ForwardedAggregationKey()
+		flushForwardedFn(e.writeForwardedMetricFn, forwardedAggregationKey, timeNanos, value)
 	}
-	e.lastConsumedAtNanos = timeNanos
 }
@@ -24,29 +24,55 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/m3db/m3/src/aggregator/aggregator"
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/cluster/generated/proto/placementpb"
+	"github.com/m3db/m3/src/metrics/metric/id"
 	xerrors "github.com/m3db/m3/src/x/errors"
 )
 
 // A list of HTTP endpoints.
 const (
-	HealthPath = "/health"
-	ResignPath = "/resign"
-	StatusPath = "/status"
+	HealthPath     = "/health"
+	ResignPath     = "/resign"
+	StatusPath     = "/status"
+	EntriesPath    = "/entries"
+	MetricPath     = "/metric"
+	FlushTimesPath = "/flush_times"
+	PlacementPath  = "/placement"
+)
+
+// A list of query parameters.
+const (
+	ShardParam = "shard"
+	LimitParam = "limit"
+	IDParam    = "id"
+)
+
+const (
+	defaultEntriesLimit = 100
 )
 
 var (
 	errRequestMustBeGet  = xerrors.NewInvalidParamsError(errors.New("request must be GET"))
 	errRequestMustBePost = xerrors.NewInvalidParamsError(errors.New("request must be POST"))
+	errNoShard           = xerrors.NewInvalidParamsError(errors.New("shard must be specified"))
+	errNoID              = xerrors.NewInvalidParamsError(errors.New("id must be specified"))
 )
 
 func registerHandlers(mux *http.ServeMux, aggregator aggregator.Aggregator) {
 	registerHealthHandler(mux)
 	registerResignHandler(mux, aggregator)
 	registerStatusHandler(mux, aggregator)
+	registerEntriesHandler(mux, aggregator)
+	registerMetricHandler(mux, aggregator)
+	registerFlushTimesHandler(mux, aggregator)
+	registerPlacementHandler(mux, aggregator)
 }
 
 func registerHealthHandler(mux *http.ServeMux) {
@@ -92,6 +118,112 @@ func registerStatusHandler(mux *http.ServeMux, aggregator aggregator.Aggregator)
 	})
 }
 
+func registerEntriesHandler(mux *http.ServeMux, aggregator aggregator.Aggregator) {
+	mux.HandleFunc(EntriesPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if httpMethod := strings.ToUpper(r.Method); httpMethod != http.MethodGet {
+			writeErrorResponse(w, errRequestMustBeGet)
+			return
+		}
+
+		shard, limit, err := parseEntriesParams(r)
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		entries, err := aggregator.Entries(shard, limit)
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		writeEntriesResponse(w, entries)
+	})
+}
+
+func registerMetricHandler(mux *http.ServeMux, aggregator aggregator.Aggregator) {
+	mux.HandleFunc(MetricPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if httpMethod := strings.ToUpper(r.Method); httpMethod != http.MethodGet {
+			writeErrorResponse(w, errRequestMustBeGet)
+			return
+		}
+
+		metricID := r.URL.Query().Get(IDParam)
+		if metricID == "" {
+			writeErrorResponse(w, errNoID)
+			return
+		}
+		entries, err := aggregator.MetricEntries(id.RawID(metricID))
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		writeEntriesResponse(w, entries)
+	})
+}
+
+func registerFlushTimesHandler(mux *http.ServeMux, aggregator aggregator.Aggregator) {
+	mux.HandleFunc(FlushTimesPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if httpMethod := strings.ToUpper(r.Method); httpMethod != http.MethodGet {
+			writeErrorResponse(w, errRequestMustBeGet)
+			return
+		}
+
+		flushTimes, err := aggregator.FlushTimes()
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		writeFlushTimesResponse(w, flushTimes)
+	})
+}
+
+func registerPlacementHandler(mux *http.ServeMux, aggregator aggregator.Aggregator) {
+	mux.HandleFunc(PlacementPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if httpMethod := strings.ToUpper(r.Method); httpMethod != http.MethodGet {
+			writeErrorResponse(w, errRequestMustBeGet)
+			return
+		}
+
+		placement, err := aggregator.Placement()
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		pb, err := placement.Proto()
+		if err != nil {
+			writeErrorResponse(w, err)
+			return
+		}
+		writePlacementResponse(w, pb)
+	})
+}
+
+func parseEntriesParams(r *http.Request) (uint32, int, error) {
+	query := r.URL.Query()
+	shardStr := query.Get(ShardParam)
+	if shardStr == "" {
+		return 0, 0, errNoShard
+	}
+	shard, err := strconv.ParseUint(shardStr, 10, 32)
+	if err != nil {
+		return 0, 0, xerrors.NewInvalidParamsError(fmt.Errorf("invalid shard %s: %v", shardStr, err))
+	}
+	limit := defaultEntriesLimit
+	if limitStr := query.Get(LimitParam); limitStr != "" {
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			return 0, 0, xerrors.NewInvalidParamsError(fmt.Errorf("invalid limit %s: %v", limitStr, err))
+		}
+	}
+	return uint32(shard), limit, nil
+}
+
 // Response is an HTTP response.
 type Response struct {
 	State string `json:"state,omitempty"`
@@ -104,12 +236,39 @@ type StatusResponse struct {
 	Status aggregator.RuntimeStatus `json:"status,omitempty"`
 }
 
+// EntriesResponse is a response containing entry snapshots.
+type EntriesResponse struct {
+	Response
+	Entries []aggregator.EntrySnapshot `json:"entries"`
+}
+
+// FlushTimesResponse is a flush times response.
+type FlushTimesResponse struct {
+	Response
+	FlushTimes *schema.ShardSetFlushTimes `json:"flushTimes,omitempty"`
+}
+
+// PlacementResponse is a placement response.
+type PlacementResponse struct {
+	Response
+	Placement *placementpb.Placement `json:"placement,omitempty"`
+}
+
 // NewResponse creates a new empty response.
 func NewResponse() Response { return Response{} }
 
 // NewStatusResponse creates a new empty status response.
 func NewStatusResponse() StatusResponse { return StatusResponse{} }
 
+// NewEntriesResponse creates a new empty entries response.
+func NewEntriesResponse() EntriesResponse { return EntriesResponse{} }
+
+// NewFlushTimesResponse creates a new empty flush times response.
+func NewFlushTimesResponse() FlushTimesResponse { return FlushTimesResponse{} }
+
+// NewPlacementResponse creates a new empty placement response.
+func NewPlacementResponse() PlacementResponse { return PlacementResponse{} }
+
 func newSuccessResponse() Response {
 	return Response{State: "OK"}
 }
@@ -137,6 +296,24 @@ func writeStatusResponse(w http.ResponseWriter, status aggregator.RuntimeStatus)
 	writeResponse(w, response, nil)
 }
 
+func writeEntriesResponse(w http.ResponseWriter, entries []aggregator.EntrySnapshot) {
+	response := NewEntriesResponse()
+	response.Entries = entries
+	writeResponse(w, response, nil)
+}
+
+func writeFlushTimesResponse(w http.ResponseWriter, flushTimes *schema.ShardSetFlushTimes) {
+	response := NewFlushTimesResponse()
+	response.FlushTimes = flushTimes
+	writeResponse(w, response, nil)
+}
+
+func writePlacementResponse(w http.ResponseWriter, placement *placementpb.Placement) {
+	response := NewPlacementResponse()
+	response.Placement = placement
+	writeResponse(w, response, nil)
+}
+
 func writeResponse(w http.ResponseWriter, resp interface{}, err error) {
 	buf := bytes.NewBuffer(nil)
 	if encodeErr := json.NewEncoder(buf).Encode(&resp); encodeErr != nil {
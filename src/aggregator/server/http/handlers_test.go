@@ -0,0 +1,172 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/aggregator/aggregator/capture"
+	schema "github.com/m3db/m3/src/aggregator/generated/proto/flush"
+	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/metrics/metric/id"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEntries = []aggregator.EntrySnapshot{
+		{
+			ID:       "foo",
+			Category: "untimed",
+			Type:     "counter",
+			Elems: []aggregator.ElemSnapshot{
+				{
+					ID:               "foo",
+					Type:             "counter",
+					StoragePolicy:    "10s:2d",
+					AggregationTypes: []string{"Sum"},
+					Values: []aggregator.AggregationSnapshot{
+						{StartAtNanos: 1000, Values: map[string]float64{"Sum": 123}},
+					},
+				},
+			},
+		},
+	}
+)
+
+func TestEntriesHandler(t *testing.T) {
+	agg := &testAggregator{Aggregator: capture.NewAggregator(), entries: testEntries}
+	mux := testMux(agg)
+
+	resp := testRequest(mux, http.MethodPost, EntriesPath+"?shard=1")
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = testRequest(mux, http.MethodGet, EntriesPath)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = testRequest(mux, http.MethodGet, EntriesPath+"?shard=foo")
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = testRequest(mux, http.MethodGet, EntriesPath+"?shard=3")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, uint32(3), agg.shard)
+	require.Equal(t, defaultEntriesLimit, agg.limit)
+
+	resp = testRequest(mux, http.MethodGet, EntriesPath+"?shard=3&limit=5")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, 5, agg.limit)
+
+	var res EntriesResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+	require.Equal(t, testEntries, res.Entries)
+}
+
+func TestMetricHandler(t *testing.T) {
+	agg := &testAggregator{Aggregator: capture.NewAggregator(), entries: testEntries}
+	mux := testMux(agg)
+
+	resp := testRequest(mux, http.MethodGet, MetricPath)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = testRequest(mux, http.MethodGet, MetricPath+"?id=foo")
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, id.RawID("foo"), agg.id)
+
+	var res EntriesResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+	require.Equal(t, testEntries, res.Entries)
+}
+
+func TestFlushTimesHandler(t *testing.T) {
+	mux := testMux(capture.NewAggregator())
+
+	resp := testRequest(mux, http.MethodGet, FlushTimesPath)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var res FlushTimesResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+	require.Equal(t, &schema.ShardSetFlushTimes{}, res.FlushTimes)
+}
+
+func TestPlacementHandler(t *testing.T) {
+	resp := testRequest(testMux(capture.NewAggregator()), http.MethodGet, PlacementPath)
+	require.Equal(t, http.StatusInternalServerError, resp.Code)
+
+	p := placement.NewPlacement().
+		SetInstances([]placement.Instance{
+			placement.NewEmptyInstance("i1", "r1", "z1", "i1:1234", 1),
+		}).
+		SetShards([]uint32{0, 1}).
+		SetReplicaFactor(1).
+		SetIsSharded(true)
+	agg := &testAggregator{Aggregator: capture.NewAggregator(), placement: p}
+	resp = testRequest(testMux(agg), http.MethodGet, PlacementPath)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	var res PlacementResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &res))
+	require.Equal(t, uint32(2), res.Placement.NumShards)
+	require.Equal(t, uint32(1), res.Placement.ReplicaFactor)
+	require.Equal(t, 1, len(res.Placement.Instances))
+	require.Equal(t, "i1:1234", res.Placement.Instances["i1"].Endpoint)
+}
+
+type testAggregator struct {
+	aggregator.Aggregator
+
+	entries   []aggregator.EntrySnapshot
+	placement placement.Placement
+	shard     uint32
+	limit     int
+	id        id.RawID
+}
+
+func (agg *testAggregator) Entries(shard uint32, limit int) ([]aggregator.EntrySnapshot, error) {
+	agg.shard = shard
+	agg.limit = limit
+	return agg.entries, nil
+}
+
+func (agg *testAggregator) MetricEntries(id id.RawID) ([]aggregator.EntrySnapshot, error) {
+	agg.id = id
+	return agg.entries, nil
+}
+
+func (agg *testAggregator) Placement() (placement.Placement, error) {
+	return agg.placement, nil
+}
+
+func testMux(agg aggregator.Aggregator) *http.ServeMux {
+	mux := http.NewServeMux()
+	registerHandlers(mux, agg)
+	return mux
+}
+
+func testRequest(mux *http.ServeMux, method, url string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, nil)
+	resp := httptest.NewRecorder()
+	mux.ServeHTTP(resp, req)
+	return resp
+}
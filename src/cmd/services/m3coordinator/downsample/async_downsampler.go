@@ -32,6 +32,7 @@ const (
 
 var (
 	errDownsamplerUninitialized = errors.New("downsampler is not yet initialized")
+	errDownsamplerClosed        = errors.New("downsampler is closed")
 )
 
 // asyncDownsampler is an asynchronous downsampler that can be lazily
@@ -46,6 +47,7 @@ type asyncDownsampler struct {
 	downsampler Downsampler
 	done        chan<- struct{}
 	err         error
+	closed      bool
 }
 
 // NewDownsamplerFn creates a downsampler.
@@ -77,6 +79,13 @@ func NewAsyncDownsampler(
 			return
 		}
 
+		if asyncDownsampler.closed {
+			// NB: the downsampler was closed before it finished initializing.
+			downsampler.Close()
+			asyncDownsampler.err = errDownsamplerClosed
+			return
+		}
+
 		asyncDownsampler.downsampler = downsampler
 		asyncDownsampler.err = nil
 	}()
@@ -92,3 +101,17 @@ func (d *asyncDownsampler) NewMetricsAppender() (MetricsAppender, error) {
 	}
 	return d.downsampler.NewMetricsAppender()
 }
+
+func (d *asyncDownsampler) Close() error {
+	d.Lock()
+	defer d.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	if d.downsampler == nil {
+		return nil
+	}
+	d.err = errDownsamplerClosed
+	return d.downsampler.Close()
+}
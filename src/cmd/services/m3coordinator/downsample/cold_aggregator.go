@@ -0,0 +1,369 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	raggregation "github.com/m3db/m3/src/aggregator/aggregation"
+	"github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/aggregator/aggregator/handler"
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultColdWritesFlushInterval = 10 * time.Second
+	defaultColdWritesWindowTTL     = 10 * time.Minute
+)
+
+var (
+	errColdWritesWithRemoteAggregator = errors.New(
+		"cold writes aggregation is not supported with a remote aggregator")
+	errColdWindowExpired = errors.New(
+		"cold write rejected as its window has already expired")
+)
+
+type coldAggregatorMetrics struct {
+	samples        tally.Counter
+	samplesExpired tally.Counter
+	flushes        tally.Counter
+	flushErrors    tally.Counter
+	windows        tally.Gauge
+	windowsFlushed tally.Counter
+}
+
+func newColdAggregatorMetrics(scope tally.Scope) coldAggregatorMetrics {
+	return coldAggregatorMetrics{
+		samples:        scope.Counter("samples"),
+		samplesExpired: scope.Counter("samples-expired"),
+		flushes:        scope.Counter("flushes"),
+		flushErrors:    scope.Counter("flush-errors"),
+		windows:        scope.Gauge("windows"),
+		windowsFlushed: scope.Counter("windows-flushed"),
+	}
+}
+
+type coldWindowKey struct {
+	id            string
+	metricType    metric.Type
+	aggregationID aggregation.ID
+	storagePolicy policy.StoragePolicy
+	startNanos    int64
+}
+
+type coldWindow struct {
+	counter          raggregation.Counter
+	gauge            raggregation.Gauge
+	dirty            bool
+	expired          bool
+	lastUpdatedNanos int64
+}
+
+// coldAggregator aggregates timed samples that are too far in the past for
+// the aggregator to accept into the same resolution windows as the
+// aggregator would, writing the aggregated values through the flush handler.
+//
+// NB: windows are aggregated only over the samples received cold, so a
+// window that already has a value aggregated in real time is overwritten.
+// Once a window expires its aggregated values are released, and samples for
+// the window are rejected until it falls out of retention, so that a late
+// sample never overwrites the aggregated value with a partial aggregate.
+type coldAggregator struct {
+	sync.Mutex
+
+	aggTypesOpts  aggregation.TypesOptions
+	aggOpts       raggregation.Options
+	bufferPastFn  aggregator.BufferForPastTimedMetricFn
+	flushHandler  handler.Handler
+	flushInterval time.Duration
+	windowTTL     time.Duration
+	nowFn         clock.NowFn
+	scope         tally.Scope
+	logger        *zap.Logger
+	metrics       coldAggregatorMetrics
+
+	windows map[coldWindowKey]*coldWindow
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+type coldAggregatorOptions struct {
+	cfg            ColdWritesConfiguration
+	aggTypesOpts   aggregation.TypesOptions
+	bufferPastFn   aggregator.BufferForPastTimedMetricFn
+	flushHandler   handler.Handler
+	clockOpts      clock.Options
+	instrumentOpts instrument.Options
+}
+
+func newColdAggregator(opts coldAggregatorOptions) *coldAggregator {
+	flushInterval := opts.cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultColdWritesFlushInterval
+	}
+
+	windowTTL := opts.cfg.WindowTTL
+	if windowTTL <= 0 {
+		windowTTL = defaultColdWritesWindowTTL
+	}
+
+	scope := opts.instrumentOpts.MetricsScope().SubScope("cold-aggregator")
+	aggOpts := raggregation.NewOptions(opts.instrumentOpts)
+	// NB: the aggregation types vary by window, so always track the values
+	// required for the expensive aggregation types.
+	aggOpts.HasExpensiveAggregations = true
+
+	return &coldAggregator{
+		aggTypesOpts:  opts.aggTypesOpts,
+		aggOpts:       aggOpts,
+		bufferPastFn:  opts.bufferPastFn,
+		flushHandler:  opts.flushHandler,
+		flushInterval: flushInterval,
+		windowTTL:     windowTTL,
+		nowFn:         opts.clockOpts.NowFn(),
+		scope:         scope,
+		logger:        opts.instrumentOpts.Logger(),
+		metrics:       newColdAggregatorMetrics(scope),
+		windows:       make(map[coldWindowKey]*coldWindow),
+		closeCh:       make(chan struct{}),
+		doneCh:        make(chan struct{}),
+	}
+}
+
+// isCold returns whether the sample is too far in the past for the
+// aggregator to accept for the given storage policy.
+func (a *coldAggregator) isCold(
+	sample aggregated.Metric,
+	storagePolicy policy.StoragePolicy,
+) bool {
+	bufferPast := a.bufferPastFn(storagePolicy.Resolution().Window)
+	return a.nowFn().UnixNano()-sample.TimeNanos > bufferPast.Nanoseconds()
+}
+
+func (a *coldAggregator) add(
+	sample aggregated.Metric,
+	metadata metadata.TimedMetadata,
+) error {
+	if sample.Type != metric.CounterType && sample.Type != metric.GaugeType {
+		return fmt.Errorf("unsupported cold write metric type: %v", sample.Type)
+	}
+
+	var (
+		timestamp  = time.Unix(0, sample.TimeNanos)
+		resolution = metadata.StoragePolicy.Resolution().Window
+		key        = coldWindowKey{
+			id:            string(sample.ID),
+			metricType:    sample.Type,
+			aggregationID: metadata.AggregationID,
+			storagePolicy: metadata.StoragePolicy,
+			startNanos:    timestamp.Truncate(resolution).UnixNano(),
+		}
+	)
+
+	a.Lock()
+	defer a.Unlock()
+
+	window, ok := a.windows[key]
+	if ok && window.expired {
+		a.metrics.samplesExpired.Inc(1)
+		return errColdWindowExpired
+	}
+	if !ok {
+		window = &coldWindow{
+			counter: raggregation.NewCounter(a.aggOpts),
+			gauge:   raggregation.NewGauge(a.aggOpts),
+		}
+		a.windows[key] = window
+	}
+
+	if sample.Type == metric.CounterType {
+		window.counter.Update(timestamp, int64(sample.Value))
+	} else {
+		window.gauge.Update(timestamp, sample.Value)
+	}
+
+	window.dirty = true
+	window.lastUpdatedNanos = a.nowFn().UnixNano()
+	a.metrics.samples.Inc(1)
+	return nil
+}
+
+func (a *coldAggregator) start() {
+	go a.run()
+}
+
+func (a *coldAggregator) run() {
+	defer close(a.doneCh)
+
+	ticker := time.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.closeCh:
+			return
+		case <-ticker.C:
+		}
+
+		if err := a.flush(); err != nil {
+			a.metrics.flushErrors.Inc(1)
+			a.logger.Error("cold writes flush error", zap.Error(err))
+		}
+	}
+}
+
+// close stops flushing periodically and flushes the windows updated since
+// they were last flushed.
+func (a *coldAggregator) close() error {
+	close(a.closeCh)
+	<-a.doneCh
+	return a.flush()
+}
+
+// flush writes the aggregated values of all windows updated since they were
+// last flushed, expires windows that have not been updated recently and
+// removes expired windows that are past their retention.
+func (a *coldAggregator) flush() error {
+	var (
+		nowNanos = a.nowFn().UnixNano()
+		ttlNanos = a.windowTTL.Nanoseconds()
+		metrics  []aggregated.ChunkedMetricWithStoragePolicy
+		multiErr xerrors.MultiError
+	)
+
+	a.Lock()
+	for key, window := range a.windows {
+		if window.expired {
+			retention := key.storagePolicy.Retention().Duration()
+			if nowNanos-key.startNanos > retention.Nanoseconds() {
+				delete(a.windows, key)
+			}
+			continue
+		}
+
+		if !window.dirty {
+			if nowNanos-window.lastUpdatedNanos > ttlNanos {
+				// NB: keep the window to reject samples received for it
+				// later, but release its aggregated values.
+				window.expired = true
+				window.counter = raggregation.Counter{}
+				window.gauge = raggregation.Gauge{}
+			}
+			continue
+		}
+
+		var err error
+		window.dirty = false
+		metrics, err = a.appendWindowMetrics(metrics, key, window)
+		multiErr = multiErr.Add(err)
+	}
+	a.metrics.windows.Update(float64(len(a.windows)))
+	a.Unlock()
+
+	if len(metrics) == 0 {
+		return multiErr.FinalError()
+	}
+
+	writer, err := a.flushHandler.NewWriter(a.scope)
+	if err != nil {
+		return multiErr.Add(err).FinalError()
+	}
+
+	for _, m := range metrics {
+		multiErr = multiErr.Add(writer.Write(m))
+	}
+
+	multiErr = multiErr.Add(writer.Flush())
+	multiErr = multiErr.Add(writer.Close())
+	a.metrics.flushes.Inc(1)
+	return multiErr.FinalError()
+}
+
+func (a *coldAggregator) appendWindowMetrics(
+	metrics []aggregated.ChunkedMetricWithStoragePolicy,
+	key coldWindowKey,
+	window *coldWindow,
+) ([]aggregated.ChunkedMetricWithStoragePolicy, error) {
+	var (
+		aggTypes aggregation.Types
+		err      error
+	)
+
+	switch {
+	case !key.aggregationID.IsDefault():
+		aggTypes, err = key.aggregationID.Types()
+		if err != nil {
+			return metrics, err
+		}
+	case key.metricType == metric.CounterType:
+		aggTypes = a.aggTypesOpts.DefaultCounterAggregationTypes()
+	default:
+		aggTypes = a.aggTypesOpts.DefaultGaugeAggregationTypes()
+	}
+
+	// NB: the aggregator timestamps aggregated values with the end of the
+	// window they were aggregated over.
+	resolution := key.storagePolicy.Resolution().Window
+	timeNanos := key.startNanos + resolution.Nanoseconds()
+	for _, aggType := range aggTypes {
+		var (
+			value  float64
+			suffix []byte
+		)
+
+		if key.metricType == metric.CounterType {
+			value = window.counter.ValueOf(aggType)
+			suffix = a.aggTypesOpts.TypeStringForCounter(aggType)
+		} else {
+			value = window.gauge.ValueOf(aggType)
+			suffix = a.aggTypesOpts.TypeStringForGauge(aggType)
+		}
+
+		metrics = append(metrics, aggregated.ChunkedMetricWithStoragePolicy{
+			ChunkedMetric: aggregated.ChunkedMetric{
+				ChunkedID: id.ChunkedID{
+					Data:   []byte(key.id),
+					Suffix: suffix,
+				},
+				TimeNanos: timeNanos,
+				Value:     value,
+			},
+			StoragePolicy: key.storagePolicy,
+		})
+	}
+
+	a.metrics.windowsFlushed.Inc(1)
+	return metrics, nil
+}
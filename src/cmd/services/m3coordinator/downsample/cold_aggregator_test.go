@@ -0,0 +1,207 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package downsample
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/writer"
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/aggregated"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+type capturingFlushHandler struct {
+	writes []aggregated.ChunkedMetricWithStoragePolicy
+}
+
+func (h *capturingFlushHandler) NewWriter(tally.Scope) (writer.Writer, error) {
+	return &capturingWriter{handler: h}, nil
+}
+
+func (h *capturingFlushHandler) Close() {}
+
+type capturingWriter struct {
+	handler *capturingFlushHandler
+}
+
+func (w *capturingWriter) Write(m aggregated.ChunkedMetricWithStoragePolicy) error {
+	w.handler.writes = append(w.handler.writes, m)
+	return nil
+}
+
+func (w *capturingWriter) Flush() error { return nil }
+func (w *capturingWriter) Close() error { return nil }
+
+func newTestColdAggregator(
+	t *testing.T,
+	now *time.Time,
+) (*coldAggregator, *capturingFlushHandler) {
+	h := &capturingFlushHandler{}
+	a := newColdAggregator(coldAggregatorOptions{
+		cfg:          ColdWritesConfiguration{WindowTTL: time.Minute},
+		aggTypesOpts: aggregation.NewTypesOptions(),
+		bufferPastFn: func(time.Duration) time.Duration {
+			return 30 * time.Second
+		},
+		flushHandler: h,
+		clockOpts: clock.NewOptions().SetNowFn(func() time.Time {
+			return *now
+		}),
+		instrumentOpts: instrument.NewOptions(),
+	})
+	return a, h
+}
+
+func TestColdAggregatorIsCold(t *testing.T) {
+	now := time.Unix(1000, 0)
+	a, _ := newTestColdAggregator(t, &now)
+	sp := policy.MustParseStoragePolicy("10s:1d")
+
+	assert.False(t, a.isCold(aggregated.Metric{
+		TimeNanos: now.Add(-30 * time.Second).UnixNano(),
+	}, sp))
+	assert.True(t, a.isCold(aggregated.Metric{
+		TimeNanos: now.Add(-31 * time.Second).UnixNano(),
+	}, sp))
+}
+
+func TestColdAggregatorAggregatesWindows(t *testing.T) {
+	now := time.Unix(10000, 0)
+	a, h := newTestColdAggregator(t, &now)
+
+	var (
+		sp     = policy.MustParseStoragePolicy("1m:1d")
+		aggID  = aggregation.MustCompressTypes(aggregation.Sum, aggregation.Max)
+		meta   = metadata.TimedMetadata{AggregationID: aggID, StoragePolicy: sp}
+		start  = now.Add(-time.Hour).Truncate(time.Minute)
+		gauges = []aggregated.Metric{
+			{Type: metric.GaugeType, ID: []byte("foo"), TimeNanos: start.UnixNano(), Value: 1},
+			{Type: metric.GaugeType, ID: []byte("foo"), TimeNanos: start.Add(time.Second).UnixNano(), Value: 5},
+			{Type: metric.GaugeType, ID: []byte("foo"), TimeNanos: start.Add(time.Minute).UnixNano(), Value: 3},
+		}
+	)
+
+	for _, m := range gauges {
+		require.NoError(t, a.add(m, meta))
+	}
+
+	require.NoError(t, a.flush())
+	require.Len(t, h.writes, 4)
+
+	values := make(map[string]float64)
+	for _, w := range h.writes {
+		assert.Equal(t, "foo", string(w.ChunkedID.Data))
+		assert.Equal(t, sp, w.StoragePolicy)
+		key := time.Unix(0, w.TimeNanos).Sub(start).String() + "|" + string(w.ChunkedID.Suffix)
+		values[key] = w.Value
+	}
+
+	assert.Equal(t, map[string]float64{
+		"1m0s|sum":   6,
+		"1m0s|upper": 5,
+		"2m0s|sum":   3,
+		"2m0s|upper": 3,
+	}, values)
+
+	// Windows that are not updated are not written again.
+	h.writes = nil
+	require.NoError(t, a.flush())
+	assert.Len(t, h.writes, 0)
+
+	// Later samples for a window are aggregated with the earlier samples.
+	require.NoError(t, a.add(aggregated.Metric{
+		Type:      metric.GaugeType,
+		ID:        []byte("foo"),
+		TimeNanos: start.Add(2 * time.Second).UnixNano(),
+		Value:     10,
+	}, meta))
+	require.NoError(t, a.flush())
+	require.Len(t, h.writes, 2)
+	for _, w := range h.writes {
+		assert.Equal(t, start.Add(time.Minute).UnixNano(), w.TimeNanos)
+		if string(w.ChunkedID.Suffix) == "sum" {
+			assert.Equal(t, float64(16), w.Value)
+		} else {
+			assert.Equal(t, float64(10), w.Value)
+		}
+	}
+
+	// Windows expire once they have not been updated for the window TTL and
+	// later samples for them are rejected.
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, a.flush())
+	h.writes = nil
+	err := a.add(aggregated.Metric{
+		Type:      metric.GaugeType,
+		ID:        []byte("foo"),
+		TimeNanos: start.Add(3 * time.Second).UnixNano(),
+		Value:     1,
+	}, meta)
+	assert.Equal(t, errColdWindowExpired, err)
+	require.NoError(t, a.flush())
+	assert.Len(t, h.writes, 0)
+
+	// Expired windows are removed once they are past retention.
+	now = now.Add(24 * time.Hour)
+	require.NoError(t, a.flush())
+	assert.Len(t, a.windows, 0)
+}
+
+func TestColdAggregatorCloseFlushesWindows(t *testing.T) {
+	now := time.Unix(10000, 0)
+	a, h := newTestColdAggregator(t, &now)
+	a.start()
+
+	var (
+		sp    = policy.MustParseStoragePolicy("1m:1d")
+		aggID = aggregation.MustCompressTypes(aggregation.Sum)
+		meta  = metadata.TimedMetadata{AggregationID: aggID, StoragePolicy: sp}
+		start = now.Add(-time.Hour).Truncate(time.Minute)
+	)
+
+	require.NoError(t, a.add(aggregated.Metric{
+		Type:      metric.CounterType,
+		ID:        []byte("foo"),
+		TimeNanos: start.UnixNano(),
+		Value:     3,
+	}, meta))
+
+	require.NoError(t, a.close())
+	require.Len(t, h.writes, 1)
+	assert.Equal(t, float64(3), h.writes[0].Value)
+}
+
+func TestColdAggregatorUnsupportedType(t *testing.T) {
+	now := time.Unix(1000, 0)
+	a, _ := newTestColdAggregator(t, &now)
+	err := a.add(aggregated.Metric{Type: metric.TimerType}, metadata.TimedMetadata{})
+	assert.Error(t, err)
+}
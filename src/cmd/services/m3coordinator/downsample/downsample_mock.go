@@ -69,6 +69,20 @@ func (mr *MockDownsamplerMockRecorder) NewMetricsAppender() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMetricsAppender", reflect.TypeOf((*MockDownsampler)(nil).NewMetricsAppender))
 }
 
+// Close mocks base method
+func (m *MockDownsampler) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockDownsamplerMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDownsampler)(nil).Close))
+}
+
 // MockMetricsAppender is a mock of MetricsAppender interface
 type MockMetricsAppender struct {
 	ctrl     *gomock.Controller
@@ -30,6 +30,7 @@ import (
 // Downsampler is a downsampler.
 type Downsampler interface {
 	NewMetricsAppender() (MetricsAppender, error)
+	Close() error
 }
 
 // MetricsAppender is a metrics appender that can build a samples
@@ -99,6 +100,7 @@ func newDownsampler(opts downsamplerOptions) (*downsampler, error) {
 func (d *downsampler) NewMetricsAppender() (MetricsAppender, error) {
 	return newMetricsAppender(metricsAppenderOptions{
 		agg:                    d.agg.aggregator,
+		coldAgg:                d.agg.coldAggregator,
 		clientRemote:           d.agg.clientRemote,
 		defaultStagedMetadatas: d.agg.defaultStagedMetadatas,
 		clockOpts:              d.agg.clockOpts,
@@ -109,3 +111,10 @@ func (d *downsampler) NewMetricsAppender() (MetricsAppender, error) {
 		logger:                 d.logger,
 	}), nil
 }
+
+func (d *downsampler) Close() error {
+	if d.agg.coldAggregator == nil {
+		return nil
+	}
+	return d.agg.coldAggregator.close()
+}
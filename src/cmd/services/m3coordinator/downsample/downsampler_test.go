@@ -22,12 +22,14 @@ package downsample
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/kafka"
 	"github.com/m3db/m3/src/aggregator/client"
 	clusterclient "github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/cluster/kv/mem"
@@ -259,6 +261,92 @@ func TestDownsamplerAggregationWithRulesConfigRollupRules(t *testing.T) {
 	testDownsamplerAggregation(t, testDownsampler)
 }
 
+func TestDownsamplerAggregationWithRulesConfigHistogramRollupRules(t *testing.T) {
+	gaugeMetrics := []testGaugeMetric{
+		{
+			tags: map[string]string{
+				nameTag:    "http_request_latency_bucket",
+				"app":      "nginx_edge",
+				"instance": "a",
+				"le":       "0.5",
+			},
+			samples: []float64{10},
+		},
+		{
+			tags: map[string]string{
+				nameTag:    "http_request_latency_bucket",
+				"app":      "nginx_edge",
+				"instance": "b",
+				"le":       "0.50",
+			},
+			samples: []float64{5},
+		},
+		{
+			tags: map[string]string{
+				nameTag:    "http_request_latency_bucket",
+				"app":      "nginx_edge",
+				"instance": "c",
+				"le":       "invalid",
+			},
+			samples: []float64{100},
+		},
+	}
+	res := 5 * time.Second
+	ret := 30 * 24 * time.Hour
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{
+		instrumentOpts: instrument.NewTestOptions(t),
+		rulesConfig: &RulesConfiguration{
+			RollupRules: []RollupRuleConfiguration{
+				{
+					Filter: fmt.Sprintf(
+						"%s:http_request_latency_bucket app:*",
+						nameTag),
+					Transforms: []TransformConfiguration{
+						{
+							Rollup: &RollupOperationConfiguration{
+								MetricName:       "http_request_latency_bucket_by_app",
+								GroupBy:          []string{"app"},
+								Aggregations:     []aggregation.Type{aggregation.Sum},
+								HistogramBuckets: true,
+							},
+						},
+					},
+					StoragePolicies: []StoragePolicyConfiguration{
+						{
+							Resolution: res,
+							Retention:  ret,
+						},
+					},
+				},
+			},
+		},
+		ingest: &testDownsamplerOptionsIngest{
+			gaugeMetrics: gaugeMetrics,
+		},
+		expect: &testDownsamplerOptionsExpect{
+			writes: []testExpectedWrite{
+				{
+					tags: map[string]string{
+						nameTag:               "http_request_latency_bucket_by_app",
+						string(rollupTagName): string(rollupTagValue),
+						"app":                 "nginx_edge",
+						"le":                  "0.5",
+					},
+					value: 15,
+					attributes: &storage.Attributes{
+						MetricsType: storage.AggregatedMetricsType,
+						Resolution:  res,
+						Retention:   ret,
+					},
+				},
+			},
+		},
+	})
+
+	// Test expected output
+	testDownsamplerAggregation(t, testDownsampler)
+}
+
 func TestDownsamplerAggregationWithTimedSamples(t *testing.T) {
 	counterMetrics, counterMetricsExpect := testCounterMetrics(testCounterMetricsOptions{
 		timedSamples: true,
@@ -286,6 +374,93 @@ func TestDownsamplerAggregationWithTimedSamples(t *testing.T) {
 	testDownsamplerAggregation(t, testDownsampler)
 }
 
+func TestDownsamplerAggregationWithColdWrites(t *testing.T) {
+	// Use samples from an hour ago that all fall into the same window.
+	start := time.Now().Add(-time.Hour).Truncate(5 * time.Second)
+	gaugeMetric := testGaugeMetric{
+		tags: map[string]string{
+			nameTag: "foo_metric",
+			"app":   "nginx_edge",
+		},
+		timedSamples: []testGaugeMetricTimedSample{
+			{time: start.Add(1 * time.Second), value: 5},
+			{time: start.Add(2 * time.Second), value: 4},
+			{time: start.Add(3 * time.Second), value: 6},
+		},
+	}
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{
+		coldWrites: &ColdWritesConfiguration{
+			FlushInterval: 100 * time.Millisecond,
+		},
+		rulesConfig: &RulesConfiguration{
+			MappingRules: []MappingRuleConfiguration{
+				{
+					Filter:       "app:nginx*",
+					Aggregations: []aggregation.Type{aggregation.Sum},
+					StoragePolicies: []StoragePolicyConfiguration{
+						{
+							Resolution: 5 * time.Second,
+							Retention:  30 * 24 * time.Hour,
+						},
+					},
+				},
+			},
+		},
+		ingest: &testDownsamplerOptionsIngest{
+			gaugeMetrics: []testGaugeMetric{gaugeMetric},
+		},
+		expect: &testDownsamplerOptionsExpect{
+			writes: []testExpectedWrite{
+				{
+					tags:  gaugeMetric.tags,
+					value: 15,
+					attributes: &storage.Attributes{
+						MetricsType: storage.AggregatedMetricsType,
+						Resolution:  5 * time.Second,
+						Retention:   30 * 24 * time.Hour,
+					},
+				},
+			},
+		},
+	})
+
+	// Test expected output
+	testDownsamplerAggregation(t, testDownsampler)
+}
+
+func TestDownsamplerAggregationWithKafka(t *testing.T) {
+	broker, err := kafka.NewFakeBroker("metrics", 1)
+	require.NoError(t, err)
+	defer broker.Close()
+
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{
+		autoMappingRules: []AutoMappingRule{
+			{
+				Aggregations: []aggregation.Type{testAggregationType},
+				Policies:     testAggregationStoragePolicies,
+			},
+		},
+		kafka: &kafka.Configuration{
+			Brokers: []string{broker.Addr()},
+			Topic:   "metrics",
+		},
+	})
+
+	// Test expected output
+	testDownsamplerAggregation(t, testDownsampler)
+
+	// Aggregated metrics are published to kafka as well as written to storage.
+	require.True(t, clock.WaitUntil(func() bool {
+		return len(broker.Messages(0)) > 0
+	}, 10*time.Second))
+	for _, msg := range broker.Messages(0) {
+		var m kafka.JSONMetric
+		require.NoError(t, json.Unmarshal(msg.Value, &m))
+		require.Equal(t, string(msg.Key), m.ID)
+		require.Equal(t, testAggregationStoragePolicies[0].String(), m.StoragePolicy)
+	}
+}
+
 func TestDownsamplerAggregationWithOverrideRules(t *testing.T) {
 	counterMetrics, counterMetricsExpect := testCounterMetrics(testCounterMetricsOptions{})
 	counterMetricsExpect[0].value = 2
@@ -700,6 +875,8 @@ type testDownsamplerOptions struct {
 	sampleAppenderOpts *SampleAppenderOptions
 	remoteClientMock   *client.MockClient
 	rulesConfig        *RulesConfiguration
+	coldWrites         *ColdWritesConfiguration
+	kafka              *kafka.Configuration
 
 	// Test ingest and expectations overrides
 	ingest *testDownsamplerOptionsIngest
@@ -762,6 +939,12 @@ func newTestDownsampler(t *testing.T, opts testDownsamplerOptions) testDownsampl
 	if opts.rulesConfig != nil {
 		cfg.Rules = opts.rulesConfig
 	}
+	if opts.coldWrites != nil {
+		cfg.ColdWrites = opts.coldWrites
+	}
+	if opts.kafka != nil {
+		cfg.Kafka = opts.kafka
+	}
 
 	instance, err := cfg.NewDownsampler(DownsamplerOptions{
 		Storage:               storage,
@@ -50,6 +50,7 @@ type metricsAppender struct {
 // metricsAppenderOptions will have one of agg or clientRemote set.
 type metricsAppenderOptions struct {
 	agg          aggregator.Aggregator
+	coldAgg      *coldAggregator
 	clientRemote client.Client
 
 	defaultStagedMetadatas []metadata.StagedMetadatas
@@ -114,6 +115,7 @@ func (a *metricsAppender) SamplesAppender(opts SampleAppenderOptions) (SamplesAp
 
 			a.multiSamplesAppender.addSamplesAppender(samplesAppender{
 				agg:             a.agg,
+				coldAgg:         a.coldAgg,
 				clientRemote:    a.clientRemote,
 				unownedID:       unownedID,
 				stagedMetadatas: stagedMetadatas,
@@ -127,6 +129,7 @@ func (a *metricsAppender) SamplesAppender(opts SampleAppenderOptions) (SamplesAp
 
 			a.multiSamplesAppender.addSamplesAppender(samplesAppender{
 				agg:             a.agg,
+				coldAgg:         a.coldAgg,
 				clientRemote:    a.clientRemote,
 				unownedID:       unownedID,
 				stagedMetadatas: stagedMetadatas,
@@ -141,6 +144,7 @@ func (a *metricsAppender) SamplesAppender(opts SampleAppenderOptions) (SamplesAp
 			// Only sample if going to actually aggregate
 			a.multiSamplesAppender.addSamplesAppender(samplesAppender{
 				agg:             a.agg,
+				coldAgg:         a.coldAgg,
 				clientRemote:    a.clientRemote,
 				unownedID:       unownedID,
 				stagedMetadatas: stagedMetadatas,
@@ -156,6 +160,7 @@ func (a *metricsAppender) SamplesAppender(opts SampleAppenderOptions) (SamplesAp
 
 			a.multiSamplesAppender.addSamplesAppender(samplesAppender{
 				agg:             a.agg,
+				coldAgg:         a.coldAgg,
 				clientRemote:    a.clientRemote,
 				unownedID:       rollup.ID,
 				stagedMetadatas: rollup.Metadatas,
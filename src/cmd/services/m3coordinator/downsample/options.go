@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/aggregator/aggregator"
 	"github.com/m3db/m3/src/aggregator/aggregator/handler"
+	"github.com/m3db/m3/src/aggregator/aggregator/handler/kafka"
 	"github.com/m3db/m3/src/aggregator/client"
 	clusterclient "github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/cluster/kv"
@@ -82,16 +83,18 @@ const (
 var (
 	numShards = runtime.NumCPU()
 
-	errNoStorage               = errors.New("dynamic downsampling enabled with storage not set")
-	errNoClusterClient         = errors.New("dynamic downsampling enabled with cluster client not set")
-	errNoRulesStore            = errors.New("dynamic downsampling enabled with rules store not set")
-	errNoClockOptions          = errors.New("dynamic downsampling enabled with clock options not set")
-	errNoInstrumentOptions     = errors.New("dynamic downsampling enabled with instrument options not set")
-	errNoTagEncoderOptions     = errors.New("dynamic downsampling enabled with tag encoder options not set")
-	errNoTagDecoderOptions     = errors.New("dynamic downsampling enabled with tag decoder options not set")
-	errNoTagEncoderPoolOptions = errors.New("dynamic downsampling enabled with tag encoder pool options not set")
-	errNoTagDecoderPoolOptions = errors.New("dynamic downsampling enabled with tag decoder pool options not set")
-	errRollupRuleNoTransforms  = errors.New("rollup rule has no transforms set")
+	errNoStorage                 = errors.New("dynamic downsampling enabled with storage not set")
+	errNoClusterClient           = errors.New("dynamic downsampling enabled with cluster client not set")
+	errNoRulesStore              = errors.New("dynamic downsampling enabled with rules store not set")
+	errNoClockOptions            = errors.New("dynamic downsampling enabled with clock options not set")
+	errNoInstrumentOptions       = errors.New("dynamic downsampling enabled with instrument options not set")
+	errNoTagEncoderOptions       = errors.New("dynamic downsampling enabled with tag encoder options not set")
+	errNoTagDecoderOptions       = errors.New("dynamic downsampling enabled with tag decoder options not set")
+	errNoTagEncoderPoolOptions   = errors.New("dynamic downsampling enabled with tag encoder pool options not set")
+	errNoTagDecoderPoolOptions   = errors.New("dynamic downsampling enabled with tag decoder pool options not set")
+	errRollupRuleNoTransforms    = errors.New("rollup rule has no transforms set")
+	errKafkaWithRemoteAggregator = errors.New(
+		"kafka output is not supported with a remote aggregator")
 )
 
 // DownsamplerOptions is a set of required downsampler options.
@@ -177,6 +180,10 @@ type agg struct {
 	aggregator   aggregator.Aggregator
 	clientRemote client.Client
 
+	// coldAggregator is optionally set alongside aggregator to aggregate
+	// samples that are too far in the past for the aggregator to accept.
+	coldAggregator *coldAggregator
+
 	defaultStagedMetadatas []metadata.StagedMetadatas
 	clockOpts              clock.Options
 	matcher                matcher.Matcher
@@ -211,6 +218,16 @@ type Configuration struct {
 
 	// EntryTTL determines how long an entry remains alive before it may be expired due to inactivity.
 	EntryTTL time.Duration `yaml:"entryTTL"`
+
+	// ColdWrites enables aggregation of timed samples that are too far in the
+	// past to be aggregated in real time, only supported with the local
+	// aggregator.
+	ColdWrites *ColdWritesConfiguration `yaml:"coldWrites"`
+
+	// Kafka optionally publishes aggregated metrics to a kafka topic in
+	// addition to writing them to storage, only supported with the local
+	// aggregator.
+	Kafka *kafka.Configuration `yaml:"kafka"`
 }
 
 // RulesConfiguration is a set of rules configuration to use for downsampling.
@@ -381,12 +398,18 @@ func (r RollupRuleConfiguration) Rule() (view.RollupRule, error) {
 			if err != nil {
 				return view.RollupRule{}, err
 			}
+			rollupType := pipelinepb.RollupOp_GROUP_BY
+			if cfg.HistogramBuckets {
+				rollupType = pipelinepb.RollupOp_HISTOGRAM_BUCKETS
+			}
 			op, err := pipeline.NewOpUnionFromProto(pipelinepb.PipelineOp{
 				Type: pipelinepb.PipelineOp_ROLLUP,
 				Rollup: &pipelinepb.RollupOp{
 					NewName:          cfg.MetricName,
 					Tags:             cfg.GroupBy,
 					AggregationTypes: aggregationTypes,
+					Type:             rollupType,
+					HistogramBuckets: cfg.HistogramBucketBoundaries,
 				},
 			})
 			if err != nil {
@@ -470,6 +493,18 @@ type RollupOperationConfiguration struct {
 
 	// Aggregations is a set of aggregate operations to perform.
 	Aggregations []aggregation.Type `yaml:"aggregations"`
+
+	// HistogramBuckets, if set, rolls up Prometheus histogram bucket series
+	// such that the "le" label is always retained and bucket counters are
+	// aggregated per bucket upper bound. Series with a missing or invalid
+	// "le" label are not rolled up.
+	HistogramBuckets bool `yaml:"histogramBuckets"`
+
+	// HistogramBucketBoundaries, if set, are the upper bounds of the histogram
+	// buckets to roll up, excluding +Inf. Series whose "le" label is not one
+	// of these boundaries are not rolled up, so that histograms with differing
+	// bucket boundaries are never merged. Requires HistogramBuckets to be set.
+	HistogramBucketBoundaries []float64 `yaml:"histogramBucketBoundaries"`
 }
 
 // AggregateOperationConfiguration is an aggregate operation.
@@ -527,6 +562,19 @@ type BufferPastLimitConfiguration struct {
 	BufferPast time.Duration `yaml:"bufferPast"`
 }
 
+// ColdWritesConfiguration configures aggregation of timed samples that are
+// too far in the past for the aggregator to accept, such as backfilled data.
+type ColdWritesConfiguration struct {
+	// FlushInterval is how often aggregated cold windows are written.
+	FlushInterval time.Duration `yaml:"flushInterval"`
+
+	// WindowTTL is how long a cold window is retained after it was last
+	// updated, samples for the window received within this time are
+	// aggregated together with the samples received before them, while
+	// samples received after the window expired are rejected.
+	WindowTTL time.Duration `yaml:"windowTTL"`
+}
+
 // NewDownsampler returns a new downsampler.
 func (cfg Configuration) NewDownsampler(
 	opts DownsamplerOptions,
@@ -656,6 +704,13 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 	}
 
 	if remoteAgg := cfg.RemoteAggregator; remoteAgg != nil {
+		if cfg.ColdWrites != nil {
+			return agg{}, errColdWritesWithRemoteAggregator
+		}
+		if cfg.Kafka != nil {
+			return agg{}, errKafkaWithRemoteAggregator
+		}
+
 		// If downsampling setup to use a remote aggregator instead of local
 		// aggregator, set that up instead.
 		client, err := remoteAgg.newClient(o.ClusterClient, clockOpts,
@@ -703,6 +758,19 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 		placementManager, flushTimesManager, electionManager, instrumentOpts,
 		storageFlushConcurrency, pools)
 
+	if kafkaCfg := cfg.Kafka; kafkaCfg != nil {
+		kafkaOpts := kafkaCfg.NewOptions(instrumentOpts.SetMetricsScope(
+			instrumentOpts.MetricsScope().SubScope("kafka")))
+		kafkaHandler, err := handler.NewKafkaHandler(kafkaOpts)
+		if err != nil {
+			return agg{}, fmt.Errorf("could not create kafka flush handler: %v", err)
+		}
+		flushHandler = handler.NewBroadcastHandler([]handler.Handler{
+			flushHandler,
+			kafkaHandler,
+		})
+	}
+
 	bufferPastLimits := defaultBufferPastLimits
 	if numLimitsCfg := len(cfg.BufferPastLimits); numLimitsCfg > 0 {
 		// Allow overrides from config.
@@ -828,8 +896,22 @@ func (cfg Configuration) newAggregator(o DownsamplerOptions) (agg, error) {
 		time.Sleep(10 * time.Millisecond)
 	}
 
+	var coldAgg *coldAggregator
+	if coldWritesCfg := cfg.ColdWrites; coldWritesCfg != nil {
+		coldAgg = newColdAggregator(coldAggregatorOptions{
+			cfg:            *coldWritesCfg,
+			aggTypesOpts:   aggregatorOpts.AggregationTypesOptions(),
+			bufferPastFn:   bufferForPastTimedMetricFn,
+			flushHandler:   flushHandler,
+			clockOpts:      clockOpts,
+			instrumentOpts: instrumentOpts,
+		})
+		coldAgg.start()
+	}
+
 	return agg{
 		aggregator:             aggregatorInstance,
+		coldAggregator:         coldAgg,
 		defaultStagedMetadatas: defaultStagedMetadatas,
 		matcher:                matcher,
 		pools:                  pools,
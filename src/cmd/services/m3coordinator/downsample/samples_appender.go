@@ -35,6 +35,7 @@ import (
 // samplesAppender must have one of agg or client set
 type samplesAppender struct {
 	agg          aggregator.Aggregator
+	coldAgg      *coldAggregator
 	clientRemote client.Client
 
 	unownedID       []byte
@@ -111,6 +112,13 @@ func (a *samplesAppender) appendTimedSample(sample aggregated.Metric) error {
 					continue
 				}
 
+				if a.coldAgg != nil && a.coldAgg.isCold(sample, policy) {
+					// Aggregate samples the local aggregator would reject as
+					// too far in the past separately.
+					multiErr = multiErr.Add(a.coldAgg.add(sample, metadata))
+					continue
+				}
+
 				// Add timed to local aggregator.
 				multiErr = multiErr.Add(a.agg.AddTimed(sample, metadata))
 			}
@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	xsync "github.com/m3db/m3/src/x/sync"
@@ -86,6 +87,17 @@ type WriteOptions struct {
 
 	DownsampleOverride bool
 	WriteOverride      bool
+
+	// Tenant if set adds the tenant tag to every written series and enforces
+	// the write limits of the tenant.
+	Tenant tenant.Tenant
+}
+
+func (o WriteOptions) tenantName() string {
+	if o.Tenant == nil {
+		return ""
+	}
+	return o.Tenant.Name()
 }
 
 // downsamplerAndWriter encapsulates the logic for writing data to the downsampler,
@@ -117,6 +129,13 @@ func (d *downsamplerAndWriter) Write(
 	annotation []byte,
 	overrides WriteOptions,
 ) error {
+	if t := overrides.Tenant; t != nil {
+		tags = t.Tags(tags)
+		if err := t.CheckWrite(tags, len(datapoints)); err != nil {
+			return err
+		}
+	}
+
 	multiErr := xerrors.NewMultiError()
 	if d.shouldDownsample(overrides) {
 		err := d.writeToDownsampler(tags, datapoints, unit, overrides)
@@ -265,6 +284,7 @@ func (d *downsamplerAndWriter) writeToStorage(
 			Unit:       unit,
 			Annotation: annotation,
 			Attributes: storageAttributesFromPolicy(unaggregatedStoragePolicy),
+			Tenant:     overrides.tenantName(),
 		})
 	}
 
@@ -285,6 +305,7 @@ func (d *downsamplerAndWriter) writeToStorage(
 				Unit:       unit,
 				Annotation: annotation,
 				Attributes: storageAttributesFromPolicy(p),
+				Tenant:     overrides.tenantName(),
 			})
 			if err != nil {
 				errLock.Lock()
@@ -313,8 +334,13 @@ func (d *downsamplerAndWriter) WriteBatch(
 			multiErr = multiErr.Add(err)
 			errLock.Unlock()
 		}
+		tenantName = overrides.tenantName()
 	)
 
+	if t := overrides.Tenant; t != nil {
+		iter = newTenantIter(iter, t, addError)
+	}
+
 	if d.shouldWrite(overrides) {
 		// Write unaggregated. Spin up all the background goroutines that make
 		// network requests before we do the synchronous work of writing to the
@@ -336,6 +362,7 @@ func (d *downsamplerAndWriter) WriteBatch(
 						Unit:       unit,
 						Annotation: annotation,
 						Attributes: storageAttributesFromPolicy(p),
+						Tenant:     tenantName,
 					})
 					if err != nil {
 						addError(err)
@@ -415,6 +442,61 @@ func (d *downsamplerAndWriter) Storage() storage.Storage {
 	return d.store
 }
 
+// tenantIter adds the tenant tag to the series of an iterator and skips the
+// series that exceed the write limits of the tenant, the limits are checked
+// once per series regardless of how many times the iterator is reset.
+type tenantIter struct {
+	DownsampleAndWriteIter
+
+	tenant   tenant.Tenant
+	onReject func(err error)
+	accepted []bool
+	idx      int
+	tags     models.Tags
+}
+
+func newTenantIter(
+	iter DownsampleAndWriteIter,
+	t tenant.Tenant,
+	onReject func(err error),
+) *tenantIter {
+	return &tenantIter{
+		DownsampleAndWriteIter: iter,
+		tenant:                 t,
+		onReject:               onReject,
+		idx:                    -1,
+	}
+}
+
+func (i *tenantIter) Next() bool {
+	for i.DownsampleAndWriteIter.Next() {
+		i.idx++
+		tags, datapoints, _, _ := i.DownsampleAndWriteIter.Current()
+		i.tags = i.tenant.Tags(tags)
+		if i.idx == len(i.accepted) {
+			err := i.tenant.CheckWrite(i.tags, len(datapoints))
+			if err != nil {
+				i.onReject(err)
+			}
+			i.accepted = append(i.accepted, err == nil)
+		}
+		if i.accepted[i.idx] {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *tenantIter) Current() (models.Tags, ts.Datapoints, xtime.Unit, []byte) {
+	_, datapoints, unit, annotation := i.DownsampleAndWriteIter.Current()
+	return i.tags, datapoints, unit, annotation
+}
+
+func (i *tenantIter) Reset() error {
+	i.idx = -1
+	return i.DownsampleAndWriteIter.Reset()
+}
+
 func storageAttributesFromPolicy(
 	p policy.StoragePolicy,
 ) storage.Attributes {
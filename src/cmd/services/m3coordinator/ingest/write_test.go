@@ -33,8 +33,10 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
 	testm3 "github.com/m3db/m3/src/query/test/m3"
 	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
 	xsync "github.com/m3db/m3/src/x/sync"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -366,6 +368,57 @@ func TestDownsampleAndWriteBatch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDownsampleAndWriteBatchWithTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	downAndWrite, downsampler, session := newTestDownsamplerAndWriter(t, ctrl,
+		testDownsamplerAndWriterOptions{})
+
+	tenants, err := tenant.Configuration{
+		DefaultLimits: tenant.LimitsConfiguration{MaxSeries: 1},
+	}.NewTenants(tenant.NewOptions())
+	require.NoError(t, err)
+	testTenant, err := tenants.Resolve("foo")
+	require.NoError(t, err)
+
+	var (
+		mockSamplesAppender = downsample.NewMockSamplesAppender(ctrl)
+		mockMetricsAppender = downsample.NewMockMetricsAppender(ctrl)
+	)
+
+	// Only the first series is within the series limit of the tenant.
+	mockMetricsAppender.
+		EXPECT().
+		SamplesAppender(zeroDownsamplerAppenderOpts).
+		Return(mockSamplesAppender, nil)
+	mockMetricsAppender.EXPECT().AddTag([]byte(tenant.DefaultTagName), []byte("foo"))
+	for _, tag := range testTags1.Tags {
+		mockMetricsAppender.EXPECT().AddTag(tag.Name, tag.Value)
+	}
+	for _, dp := range testDatapoints1 {
+		mockSamplesAppender.EXPECT().AppendGaugeTimedSample(dp.Timestamp, dp.Value)
+	}
+	downsampler.EXPECT().NewMetricsAppender().Return(mockMetricsAppender, nil)
+
+	mockMetricsAppender.EXPECT().Reset()
+	mockMetricsAppender.EXPECT().Finalize()
+
+	expectDefaultStorageWrites(session, testDatapoints1, testAnnotation1)
+
+	iter := newTestIter(testEntries)
+	batchErr := downAndWrite.WriteBatch(context.Background(), iter, WriteOptions{
+		Tenant: testTenant,
+	})
+	require.Error(t, batchErr)
+	require.Equal(t, 1, len(batchErr.Errors()))
+	require.True(t, xerrors.IsInvalidParams(batchErr.Errors()[0]))
+
+	// Ensure the tags of the iterator are not mutated.
+	_, ok := testTags1.Get([]byte(tenant.DefaultTagName))
+	require.False(t, ok)
+}
+
 func TestDownsampleAndWriteBatchNoDownsampler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
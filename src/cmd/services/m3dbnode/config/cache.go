@@ -26,6 +26,9 @@ var (
 	defaultPostingsListCacheSize   = 2 << 14 // 32,768
 	defaultPostingsListCacheRegexp = true
 	defaultPostingsListCacheTerms  = true
+	defaultPostingsListPersistent  = false
+
+	defaultPostingsListPersistentMaxBytes = 1 << 30 // 1GiB
 )
 
 // CacheConfigurations is the cache configurations.
@@ -75,6 +78,9 @@ type PostingsListCacheConfiguration struct {
 	Size        *int  `yaml:"size"`
 	CacheRegexp *bool `yaml:"cacheRegexp"`
 	CacheTerms  *bool `yaml:"cacheTerms"`
+	Persistent  *bool `yaml:"persistent"`
+
+	PersistentMaxBytes *int64 `yaml:"persistentMaxBytes"`
 }
 
 // SizeOrDefault returns the provided size or the default value is none is
@@ -106,3 +112,24 @@ func (p *PostingsListCacheConfiguration) CacheTermsOrDefault() bool {
 
 	return *p.CacheTerms
 }
+
+// PersistentOrDefault returns whether the postings lists of flushed index
+// segments should be persisted to disk alongside the index filesets so that
+// the cache survives restarts, or the default value if none is specified.
+func (p *PostingsListCacheConfiguration) PersistentOrDefault() bool {
+	if p.Persistent == nil {
+		return defaultPostingsListPersistent
+	}
+
+	return *p.Persistent
+}
+
+// PersistentMaxBytesOrDefault returns the maximum number of bytes of postings
+// lists persisted to disk, or the default value if none is specified.
+func (p *PostingsListCacheConfiguration) PersistentMaxBytesOrDefault() int64 {
+	if p.PersistentMaxBytes == nil {
+		return int64(defaultPostingsListPersistentMaxBytes)
+	}
+
+	return *p.PersistentMaxBytes
+}
@@ -32,6 +32,7 @@ import (
 	coordinatorcfg "github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/environment"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/x/config/hostid"
 	"github.com/m3db/m3/src/x/instrument"
@@ -217,6 +218,23 @@ type IndexConfiguration struct {
 	// block boundaries by eagerly writing the series to the next block
 	// preemptively.
 	ForwardIndexThreshold float64 `yaml:"forwardIndexThreshold" validate:"min=0.0,max=1.0"`
+
+	// Compaction configures the compaction of adjacent flushed index blocks
+	// into larger blocks, flushed index blocks are not compacted if unset.
+	Compaction *IndexCompactionConfiguration `yaml:"compaction"`
+}
+
+// IndexCompactionConfiguration is the configuration for compacting adjacent
+// flushed index blocks into larger blocks, which reduces the number of
+// segments that queries spanning long time ranges need to search.
+type IndexCompactionConfiguration struct {
+	// BlockSize is the size of the compacted index blocks, it must be a
+	// multiple of the index block size of the namespaces to compact.
+	BlockSize time.Duration `yaml:"blockSize" validate:"nonzero"`
+
+	// MinAge is how long after the end of a compacted index block the flushed
+	// index blocks it spans are compacted.
+	MinAge time.Duration `yaml:"minAge" validate:"min=0"`
 }
 
 // TransformConfiguration contains configuration options that can transform
@@ -289,6 +307,18 @@ type CommitLogPolicy struct {
 	// enough for almost all workloads assuming a reasonable batch size is used.
 	QueueChannel *CommitLogQueuePolicy `yaml:"queueChannel"`
 
+	// The strategy used to acknowledge writes, one of write_wait, write_behind
+	// or group_commit. Defaults to write_behind.
+	Strategy *commitlog.Strategy `yaml:"strategy"`
+
+	// The compression applied to commit log chunks, one of none or snappy.
+	// Defaults to none.
+	Compression *commitlog.CompressionType `yaml:"compression"`
+
+	// The maximum amount of time a write waits to be fsync'd along with
+	// other writes when using the group_commit strategy.
+	GroupCommitWindow *time.Duration `yaml:"groupCommitWindow"`
+
 	// Deprecated. Left in struct to keep old YAMLs parseable.
 	// TODO(V1): remove
 	DeprecatedBlockSize *time.Duration `yaml:"blockSize"`
@@ -330,6 +360,10 @@ type RepairPolicy struct {
 	// If enabled, what percentage of metadata should perform a detailed debug
 	// shadow comparison.
 	DebugShadowComparisonsPercentage float64 `yaml:"debugShadowComparisonsPercentage"`
+
+	// The depth of the Merkle trees used to compare the block metadata of
+	// a shard with peers, uses the default depth if not set.
+	MerkleTreeDepth int `yaml:"merkleTreeDepth"`
 }
 
 // ReplicationPolicy is the replication policy.
@@ -25,9 +25,11 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/environment"
+	fscommitlog "github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/bootstrapper/commitlog"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
@@ -345,6 +347,7 @@ func TestConfiguration(t *testing.T) {
     maxQueryIDsConcurrency: 0
     forwardIndexProbability: 0
     forwardIndexThreshold: 0
+    compaction: null
   transforms:
     truncateBy: 0
     forceValue: null
@@ -384,6 +387,7 @@ func TestConfiguration(t *testing.T) {
     writeTimeout: 10s
     fetchTimeout: 15s
     connectTimeout: 20s
+    deleteTaggedTimeout: null
     writeRetry:
       initialBackoff: 500ms
       backoffFactor: 3
@@ -428,6 +432,8 @@ func TestConfiguration(t *testing.T) {
       size: 100
       cacheRegexp: false
       cacheTerms: false
+      persistent: null
+      persistentMaxBytes: null
   fs:
     filePathPrefix: /var/lib/m3db
     writeBufferSize: 65536
@@ -449,6 +455,9 @@ func TestConfiguration(t *testing.T) {
       calculationType: fixed
       size: 2097152
     queueChannel: null
+    strategy: null
+    compression: null
+    groupCommitWindow: null
     blockSize: null
   repair:
     enabled: false
@@ -456,6 +465,7 @@ func TestConfiguration(t *testing.T) {
     checkInterval: 1m0s
     debugShadowComparisonsEnabled: false
     debugShadowComparisonsPercentage: 0
+    merkleTreeDepth: 0
   replication: null
   pooling:
     blockAllocSize: 16
@@ -715,6 +725,8 @@ func TestConfiguration(t *testing.T) {
     maxOutstandingWriteRequests: 0
     maxOutstandingReadRequests: 0
     maxOutstandingRepairedBytes: 0
+    maxRecentlyQueriedDocs: null
+    maxDocsPerQuery: 0
   tchannel: null
 coordinator: null
 `
@@ -1046,3 +1058,52 @@ db:
 		result.NewOptions(), storage.DefaultTestOptions(), mapProvider, origin, adminClient)
 	require.NoError(t, err)
 }
+
+func TestCommitLogPolicyConfig(t *testing.T) {
+	testConf := `
+db:
+  metrics:
+      samplingRate: 1.0
+
+  listenAddress: 0.0.0.0:9000
+  clusterListenAddress: 0.0.0.0:9001
+  httpNodeListenAddress: 0.0.0.0:9002
+  httpClusterListenAddress: 0.0.0.0:9003
+
+  bootstrap:
+      bootstrappers:
+          - filesystem
+          - commitlog
+
+  commitlog:
+      flushMaxBytes: 524288
+      flushEvery: 1s
+      queue:
+          size: 2097152
+      strategy: group_commit
+      compression: snappy
+      groupCommitWindow: 2ms
+`
+	fd, err := ioutil.TempFile("", "config.yaml")
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fd.Close())
+		assert.NoError(t, os.Remove(fd.Name()))
+	}()
+
+	_, err = fd.Write([]byte(testConf))
+	require.NoError(t, err)
+
+	var cfg Configuration
+	err = xconfig.LoadFile(&cfg, fd.Name(), xconfig.Options{})
+	require.NoError(t, err)
+	require.NotNil(t, cfg.DB)
+
+	policy := cfg.DB.CommitLog
+	require.NotNil(t, policy.Strategy)
+	require.Equal(t, fscommitlog.StrategyGroupCommit, *policy.Strategy)
+	require.NotNil(t, policy.Compression)
+	require.Equal(t, fscommitlog.CompressionSnappy, *policy.Compression)
+	require.NotNil(t, policy.GroupCommitWindow)
+	require.Equal(t, 2*time.Millisecond, *policy.GroupCommitWindow)
+}
@@ -20,6 +20,8 @@
 
 package config
 
+import "time"
+
 // Limits contains configuration for configurable limits that can be applied to M3DB.
 type Limits struct {
 	// MaxOutstandingWriteRequests controls the maximum number of outstanding write requests
@@ -39,4 +41,22 @@ type Limits struct {
 	// process would pause until some of the repaired bytes had been persisted to disk (and subsequently
 	// evicted from memory) at which point it would resume.
 	MaxOutstandingRepairedBytes int64 `yaml:"maxOutstandingRepairedBytes" validate:"min=0"`
+
+	// MaxRecentlyQueriedDocs sets the upper limit on the number of index documents
+	// matched by all queries of the node within a given lookback period. Queries
+	// which are issued while this limit is exceeded will fail.
+	MaxRecentlyQueriedDocs *MaxRecentQueryResourceLimitConfiguration `yaml:"maxRecentlyQueriedDocs"`
+
+	// MaxDocsPerQuery sets the upper limit on the number of index documents a
+	// single query can match before it is aborted with partial results.
+	MaxDocsPerQuery int `yaml:"maxDocsPerQuery" validate:"min=0"`
+}
+
+// MaxRecentQueryResourceLimitConfiguration sets an upper limit on resources
+// consumed by all queries globally within a lookback period.
+type MaxRecentQueryResourceLimitConfiguration struct {
+	// Value sets the max value for the resource limit.
+	Value int64 `yaml:"value" validate:"min=0"`
+	// Lookback is the period in which a given resource limit is enforced.
+	Lookback time.Duration `yaml:"lookback" validate:"min=0"`
 }
@@ -31,10 +31,12 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/server/m3msg"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/cache"
 	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
 	xconfig "github.com/m3db/m3/src/x/config"
 	"github.com/m3db/m3/src/x/config/listenaddress"
 	"github.com/m3db/m3/src/x/cost"
@@ -136,6 +138,23 @@ type Configuration struct {
 	// Experimental is the configuration for the experimental API group.
 	Experimental ExperimentalAPIConfiguration `yaml:"experimental"`
 
+	// Rules is the configuration for evaluating Prometheus recording and
+	// alerting rules, if not set then no rules are evaluated.
+	Rules *RulesConfiguration `yaml:"rules"`
+
+	// ResultsCache is the configuration for the range query results cache,
+	// if not set then range query results are not cached.
+	ResultsCache *ResultsCacheConfiguration `yaml:"resultsCache"`
+
+	// InfluxDB is the configuration for the InfluxDB line protocol write and
+	// InfluxQL query endpoints.
+	InfluxDB InfluxDBConfiguration `yaml:"influxdb"`
+
+	// Tenants is the configuration for tenants, if set writes and queries are
+	// isolated to the tenant set by the M3-Tenant header and per-tenant limits
+	// are enforced.
+	Tenants *tenant.Configuration `yaml:"tenants"`
+
 	// Cache configurations.
 	//
 	// Deprecated: cache configurations are no longer supported. Remove from file
@@ -183,6 +202,134 @@ type DeprecatedQueryConversionCacheConfiguration struct {
 	Size *int `yaml:"size"`
 }
 
+// RulesConfiguration is the configuration for evaluating Prometheus recording
+// and alerting rules.
+type RulesConfiguration struct {
+	// RuleFiles are the Prometheus rule files to load rule groups from.
+	RuleFiles []string `yaml:"ruleFiles" validate:"nonzero"`
+
+	// EvaluationInterval is the evaluation interval for rule groups that do
+	// not specify an interval.
+	EvaluationInterval time.Duration `yaml:"evaluationInterval"`
+
+	// QueryTimeout is the timeout for evaluating a single rule.
+	QueryTimeout time.Duration `yaml:"queryTimeout"`
+}
+
+// ResultsCacheConfiguration is the configuration for the range query results
+// cache.
+type ResultsCacheConfiguration struct {
+	// Size is the maximum number of cached blocks of results.
+	Size int `yaml:"size"`
+
+	// MaxBytes is the approximate maximum number of bytes used by the cached
+	// blocks of results.
+	MaxBytes int `yaml:"maxBytes"`
+
+	// BlockSize is the block size that cached results are aligned to.
+	BlockSize time.Duration `yaml:"blockSize"`
+
+	// MaxFreshness is the duration before the request time for which results
+	// are not cached, since they may still change.
+	MaxFreshness *time.Duration `yaml:"maxFreshness"`
+}
+
+// NewResultsCache creates a new in-memory results cache from the
+// configuration.
+func (c ResultsCacheConfiguration) NewResultsCache(
+	instrumentOpts instrument.Options,
+) (cache.ResultsCache, error) {
+	opts := cache.NewOptions().SetInstrumentOptions(instrumentOpts)
+	if c.BlockSize != 0 {
+		opts = opts.SetBlockSize(c.BlockSize)
+	}
+	if c.MaxFreshness != nil {
+		opts = opts.SetMaxFreshness(*c.MaxFreshness)
+	}
+
+	return cache.NewResultsCache(cache.NewMemoryBackend(c.Size, c.MaxBytes), opts)
+}
+
+// InfluxDBStringFieldPolicy is the policy for handling string fields
+// written with the InfluxDB line protocol.
+type InfluxDBStringFieldPolicy string
+
+const (
+	// DropInfluxDBStringFields drops string fields, this is the default.
+	DropInfluxDBStringFields InfluxDBStringFieldPolicy = "drop"
+	// TagInfluxDBStringFields adds string fields as tags to the series
+	// written for the numeric fields of the same point.
+	TagInfluxDBStringFields InfluxDBStringFieldPolicy = "tag"
+)
+
+var validInfluxDBStringFieldPolicies = []InfluxDBStringFieldPolicy{
+	DropInfluxDBStringFields,
+	TagInfluxDBStringFields,
+}
+
+// UnmarshalYAML unmarshals a string field policy.
+func (p *InfluxDBStringFieldPolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	for _, valid := range validInfluxDBStringFieldPolicies {
+		if str == string(valid) {
+			*p = valid
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid InfluxDBStringFieldPolicy '%s' valid types are: %v",
+		str, validInfluxDBStringFieldPolicies)
+}
+
+// InfluxDBBooleanFieldPolicy is the policy for handling boolean fields
+// written with the InfluxDB line protocol.
+type InfluxDBBooleanFieldPolicy string
+
+const (
+	// NumericInfluxDBBooleanFields writes boolean fields as 1 for true and 0
+	// for false, this is the default.
+	NumericInfluxDBBooleanFields InfluxDBBooleanFieldPolicy = "numeric"
+	// DropInfluxDBBooleanFields drops boolean fields.
+	DropInfluxDBBooleanFields InfluxDBBooleanFieldPolicy = "drop"
+)
+
+var validInfluxDBBooleanFieldPolicies = []InfluxDBBooleanFieldPolicy{
+	NumericInfluxDBBooleanFields,
+	DropInfluxDBBooleanFields,
+}
+
+// UnmarshalYAML unmarshals a boolean field policy.
+func (p *InfluxDBBooleanFieldPolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	for _, valid := range validInfluxDBBooleanFieldPolicies {
+		if str == string(valid) {
+			*p = valid
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid InfluxDBBooleanFieldPolicy '%s' valid types are: %v",
+		str, validInfluxDBBooleanFieldPolicies)
+}
+
+// InfluxDBConfiguration is the configuration for the InfluxDB endpoints.
+type InfluxDBConfiguration struct {
+	// StringFields is the policy for string fields, defaults to dropping them.
+	StringFields InfluxDBStringFieldPolicy `yaml:"stringFields"`
+
+	// BooleanFields is the policy for boolean fields, defaults to writing
+	// them as numeric values.
+	BooleanFields InfluxDBBooleanFieldPolicy `yaml:"booleanFields"`
+}
+
 // ResultOptions are the result options for query.
 type ResultOptions struct {
 	// KeepNans keeps NaNs before returning query results.
@@ -249,6 +396,14 @@ type PerQueryLimitsConfiguration struct {
 
 	// MaxFetchedSeries limits the number of time series returned by a storage node.
 	MaxFetchedSeries int64 `yaml:"maxFetchedSeries"`
+
+	// MaxFetchedDocs limits the number of index documents matched by a
+	// storage node for a query.
+	MaxFetchedDocs int64 `yaml:"maxFetchedDocs"`
+
+	// RequireExhaustive results in an error if a query exceeds the series
+	// or docs limit rather than returning partial results.
+	RequireExhaustive bool `yaml:"requireExhaustive"`
 }
 
 // AsLimitManagerOptions converts this configuration to
@@ -260,15 +415,18 @@ func (l *PerQueryLimitsConfiguration) AsLimitManagerOptions() cost.LimitManagerO
 // AsFetchOptionsBuilderOptions converts this configuration to
 // handler.FetchOptionsBuilderOptions.
 func (l *PerQueryLimitsConfiguration) AsFetchOptionsBuilderOptions() handleroptions.FetchOptionsBuilderOptions {
-	if l.MaxFetchedSeries <= 0 {
-		return handleroptions.FetchOptionsBuilderOptions{
-			Limit: defaultStorageQueryLimit,
-		}
+	opts := handleroptions.FetchOptionsBuilderOptions{
+		Limit:             defaultStorageQueryLimit,
+		RequireExhaustive: l.RequireExhaustive,
 	}
-
-	return handleroptions.FetchOptionsBuilderOptions{
-		Limit: int(l.MaxFetchedSeries),
+	if l.MaxFetchedSeries > 0 {
+		opts.Limit = int(l.MaxFetchedSeries)
+	}
+	if l.MaxFetchedDocs > 0 {
+		opts.DocsLimit = int(l.MaxFetchedDocs)
 	}
+
+	return opts
 }
 
 func toLimitManagerOptions(limit int64) cost.LimitManagerOptions {
@@ -106,6 +106,8 @@ func main() {
 	if err != nil {
 		log.Fatalf("unable to open reader: %v", err)
 	}
+	log.Infof("reading fileset with data file compression: %s",
+		reader.Status().Compression)
 
 	for {
 		id, _, data, _, err := reader.Read()
@@ -44,6 +44,8 @@ type aggregateAttemptArgs struct {
 	ns    ident.ID
 	query index.Query
 	opts  index.AggregationOptions
+
+	retryResourceExhausted bool
 }
 
 func (f *aggregateAttempt) reset() {
@@ -56,7 +58,7 @@ func (f *aggregateAttempt) performAttempt() error {
 	var err error
 	f.resultIter, f.resultMetadata, err = f.session.aggregateAttempt(
 		f.args.ns, f.args.query, f.args.opts)
-	return err
+	return resourceExhaustedAttemptError(err, f.args.retryResourceExhausted)
 }
 
 type aggregateAttemptPool interface {
@@ -155,6 +155,21 @@ func (m *MockSession) EXPECT() *MockSessionMockRecorder {
 	return m.recorder
 }
 
+// DeleteTagged mocks base method
+func (m *MockSession) DeleteTagged(namespace ident.ID, q index.Query, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged
+func (mr *MockSessionMockRecorder) DeleteTagged(namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockSession)(nil).DeleteTagged), namespace, q, start, end)
+}
+
 // Write mocks base method
 func (m *MockSession) Write(namespace, id ident.ID, t time.Time, value float64, unit time0.Unit, annotation []byte) error {
 	m.ctrl.T.Helper()
@@ -757,6 +772,21 @@ func (m *MockAdminSession) EXPECT() *MockAdminSessionMockRecorder {
 	return m.recorder
 }
 
+// DeleteTagged mocks base method
+func (m *MockAdminSession) DeleteTagged(namespace ident.ID, q index.Query, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged
+func (mr *MockAdminSessionMockRecorder) DeleteTagged(namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockAdminSession)(nil).DeleteTagged), namespace, q, start, end)
+}
+
 // Write mocks base method
 func (m *MockAdminSession) Write(namespace, id ident.ID, t time.Time, value float64, unit time0.Unit, annotation []byte) error {
 	m.ctrl.T.Helper()
@@ -1538,6 +1568,34 @@ func (mr *MockOptionsMockRecorder) SetTruncateRequestTimeout(value interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTruncateRequestTimeout", reflect.TypeOf((*MockOptions)(nil).SetTruncateRequestTimeout), value)
 }
 
+// SetDeleteTaggedRequestTimeout mocks base method
+func (m *MockOptions) SetDeleteTaggedRequestTimeout(value time.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeleteTaggedRequestTimeout", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetDeleteTaggedRequestTimeout indicates an expected call of SetDeleteTaggedRequestTimeout
+func (mr *MockOptionsMockRecorder) SetDeleteTaggedRequestTimeout(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeleteTaggedRequestTimeout", reflect.TypeOf((*MockOptions)(nil).SetDeleteTaggedRequestTimeout), value)
+}
+
+// DeleteTaggedRequestTimeout mocks base method
+func (m *MockOptions) DeleteTaggedRequestTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaggedRequestTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// DeleteTaggedRequestTimeout indicates an expected call of DeleteTaggedRequestTimeout
+func (mr *MockOptionsMockRecorder) DeleteTaggedRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaggedRequestTimeout", reflect.TypeOf((*MockOptions)(nil).DeleteTaggedRequestTimeout))
+}
+
 // TruncateRequestTimeout mocks base method
 func (m *MockOptions) TruncateRequestTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -1776,6 +1834,34 @@ func (mr *MockOptionsMockRecorder) FetchRetrier() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRetrier", reflect.TypeOf((*MockOptions)(nil).FetchRetrier))
 }
 
+// SetResourceExhaustedRetrier mocks base method
+func (m *MockOptions) SetResourceExhaustedRetrier(value retry.Retrier) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetResourceExhaustedRetrier", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetResourceExhaustedRetrier indicates an expected call of SetResourceExhaustedRetrier
+func (mr *MockOptionsMockRecorder) SetResourceExhaustedRetrier(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResourceExhaustedRetrier", reflect.TypeOf((*MockOptions)(nil).SetResourceExhaustedRetrier), value)
+}
+
+// ResourceExhaustedRetrier mocks base method
+func (m *MockOptions) ResourceExhaustedRetrier() retry.Retrier {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceExhaustedRetrier")
+	ret0, _ := ret[0].(retry.Retrier)
+	return ret0
+}
+
+// ResourceExhaustedRetrier indicates an expected call of ResourceExhaustedRetrier
+func (mr *MockOptionsMockRecorder) ResourceExhaustedRetrier() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceExhaustedRetrier", reflect.TypeOf((*MockOptions)(nil).ResourceExhaustedRetrier))
+}
+
 // SetTagEncoderOptions mocks base method
 func (m *MockOptions) SetTagEncoderOptions(value serialize.TagEncoderOptions) Options {
 	m.ctrl.T.Helper()
@@ -2961,6 +3047,34 @@ func (mr *MockAdminOptionsMockRecorder) SetTruncateRequestTimeout(value interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTruncateRequestTimeout", reflect.TypeOf((*MockAdminOptions)(nil).SetTruncateRequestTimeout), value)
 }
 
+// SetDeleteTaggedRequestTimeout mocks base method
+func (m *MockAdminOptions) SetDeleteTaggedRequestTimeout(value time.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeleteTaggedRequestTimeout", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetDeleteTaggedRequestTimeout indicates an expected call of SetDeleteTaggedRequestTimeout
+func (mr *MockAdminOptionsMockRecorder) SetDeleteTaggedRequestTimeout(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeleteTaggedRequestTimeout", reflect.TypeOf((*MockAdminOptions)(nil).SetDeleteTaggedRequestTimeout), value)
+}
+
+// DeleteTaggedRequestTimeout mocks base method
+func (m *MockAdminOptions) DeleteTaggedRequestTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaggedRequestTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// DeleteTaggedRequestTimeout indicates an expected call of DeleteTaggedRequestTimeout
+func (mr *MockAdminOptionsMockRecorder) DeleteTaggedRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaggedRequestTimeout", reflect.TypeOf((*MockAdminOptions)(nil).DeleteTaggedRequestTimeout))
+}
+
 // TruncateRequestTimeout mocks base method
 func (m *MockAdminOptions) TruncateRequestTimeout() time.Duration {
 	m.ctrl.T.Helper()
@@ -3199,6 +3313,34 @@ func (mr *MockAdminOptionsMockRecorder) FetchRetrier() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRetrier", reflect.TypeOf((*MockAdminOptions)(nil).FetchRetrier))
 }
 
+// SetResourceExhaustedRetrier mocks base method
+func (m *MockAdminOptions) SetResourceExhaustedRetrier(value retry.Retrier) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetResourceExhaustedRetrier", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetResourceExhaustedRetrier indicates an expected call of SetResourceExhaustedRetrier
+func (mr *MockAdminOptionsMockRecorder) SetResourceExhaustedRetrier(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResourceExhaustedRetrier", reflect.TypeOf((*MockAdminOptions)(nil).SetResourceExhaustedRetrier), value)
+}
+
+// ResourceExhaustedRetrier mocks base method
+func (m *MockAdminOptions) ResourceExhaustedRetrier() retry.Retrier {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResourceExhaustedRetrier")
+	ret0, _ := ret[0].(retry.Retrier)
+	return ret0
+}
+
+// ResourceExhaustedRetrier indicates an expected call of ResourceExhaustedRetrier
+func (mr *MockAdminOptionsMockRecorder) ResourceExhaustedRetrier() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceExhaustedRetrier", reflect.TypeOf((*MockAdminOptions)(nil).ResourceExhaustedRetrier))
+}
+
 // SetTagEncoderOptions mocks base method
 func (m *MockAdminOptions) SetTagEncoderOptions(value serialize.TagEncoderOptions) Options {
 	m.ctrl.T.Helper()
@@ -4118,6 +4260,21 @@ func (m *MockclientSession) EXPECT() *MockclientSessionMockRecorder {
 	return m.recorder
 }
 
+// DeleteTagged mocks base method
+func (m *MockclientSession) DeleteTagged(namespace ident.ID, q index.Query, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged
+func (mr *MockclientSessionMockRecorder) DeleteTagged(namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockclientSession)(nil).DeleteTagged), namespace, q, start, end)
+}
+
 // Write mocks base method
 func (m *MockclientSession) Write(namespace, id ident.ID, t time.Time, value float64, unit time0.Unit, annotation []byte) error {
 	m.ctrl.T.Helper()
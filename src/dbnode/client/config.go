@@ -71,6 +71,9 @@ type Configuration struct {
 	// ConnectTimeout is the cluster connect timeout.
 	ConnectTimeout *time.Duration `yaml:"connectTimeout"`
 
+	// DeleteTaggedTimeout is the delete tagged request timeout.
+	DeleteTaggedTimeout *time.Duration `yaml:"deleteTaggedTimeout"`
+
 	// WriteRetry is the write retry config.
 	WriteRetry *retry.Configuration `yaml:"writeRetry"`
 
@@ -162,6 +165,10 @@ func (c *Configuration) Validate() error {
 		return fmt.Errorf("m3db client connectTimeout was: %d but must be >= 0", *c.ConnectTimeout)
 	}
 
+	if c.DeleteTaggedTimeout != nil && *c.DeleteTaggedTimeout < 0 {
+		return fmt.Errorf("m3db client deleteTaggedTimeout was: %d but must be >= 0", *c.DeleteTaggedTimeout)
+	}
+
 	if err := c.LogErrorSampleRate.Validate(); err != nil {
 		return fmt.Errorf("m3db client error validating log error sample rate: %v", err)
 	}
@@ -359,6 +366,9 @@ func (c Configuration) NewAdminClient(
 	if c.ConnectTimeout != nil {
 		v = v.SetClusterConnectTimeout(*c.ConnectTimeout)
 	}
+	if c.DeleteTaggedTimeout != nil {
+		v = v.SetDeleteTaggedRequestTimeout(*c.DeleteTaggedTimeout)
+	}
 	if c.WriteRetry != nil {
 		v = v.SetWriteRetrier(c.WriteRetry.NewRetrier(writeRequestScope))
 	}
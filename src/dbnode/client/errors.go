@@ -54,6 +54,29 @@ func IsBadRequestError(err error) bool {
 	return false
 }
 
+// IsResourceExhaustedError determines if the error is a resource exhausted
+// error, returned when a namespace rate limit is exceeded on a node, these
+// errors are retryable and are retried with backoff.
+func IsResourceExhaustedError(err error) bool {
+	for err != nil {
+		if e, ok := err.(*rpc.Error); ok && tterrors.IsResourceExhaustedError(e) {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
+
+// resourceExhaustedAttemptError returns the error of an attempt, errors due
+// to a namespace rate limit are not retried by the write and fetch retriers
+// since they are retried with the resource exhausted retrier instead.
+func resourceExhaustedAttemptError(err error, retryResourceExhausted bool) error {
+	if !retryResourceExhausted && IsResourceExhaustedError(err) {
+		return xerrors.NewNonRetryableError(err)
+	}
+	return err
+}
+
 // IsConsistencyResultError determines if the error is a consistency result error.
 func IsConsistencyResultError(err error) bool {
 	_, ok := err.(consistencyResultErr)
@@ -52,3 +52,20 @@ func TestConsistencyResultError(t *testing.T) {
 	assert.Equal(t, 1, NumSuccess(err))
 	assert.Equal(t, 2, NumError(err))
 }
+
+func TestResourceExhaustedError(t *testing.T) {
+	exhaustedErr := &rpc.Error{
+		Type:    rpc.ErrorType_RESOURCE_EXHAUSTED,
+		Message: "rate limit exceeded",
+	}
+
+	level := topology.ConsistencyLevelMajority
+	errs := []error{exhaustedErr, fmt.Errorf("another error")}
+	err := error(newConsistencyResultError(level, 3, 3, errs))
+
+	assert.True(t, IsResourceExhaustedError(err))
+	assert.False(t, IsBadRequestError(err))
+	assert.False(t, IsResourceExhaustedError(&rpc.Error{
+		Type: rpc.ErrorType_INTERNAL_ERROR,
+	}))
+}
@@ -47,6 +47,8 @@ type fetchAttemptArgs struct {
 	ids       ident.Iterator
 	start     time.Time
 	end       time.Time
+
+	retryResourceExhausted bool
 }
 
 func (f *fetchAttempt) reset() {
@@ -64,7 +66,7 @@ func (f *fetchAttempt) perform() error {
 		err = xerrors.NewNonRetryableError(err)
 	}
 
-	return err
+	return resourceExhaustedAttemptError(err, f.args.retryResourceExhausted)
 }
 
 type fetchAttemptPool struct {
@@ -48,6 +48,8 @@ type fetchTaggedAttemptArgs struct {
 	ns    ident.ID
 	query index.Query
 	opts  index.QueryOptions
+
+	retryResourceExhausted bool
 }
 
 func (f *fetchTaggedAttempt) reset() {
@@ -63,14 +65,14 @@ func (f *fetchTaggedAttempt) performIDsAttempt() error {
 	var err error
 	f.idsResultIter, f.idsResultMetadata, err = f.session.fetchTaggedIDsAttempt(
 		f.args.ns, f.args.query, f.args.opts)
-	return err
+	return resourceExhaustedAttemptError(err, f.args.retryResourceExhausted)
 }
 
 func (f *fetchTaggedAttempt) performDataAttempt() error {
 	var err error
 	f.dataResultIters, f.dataResultMetadata, err = f.session.fetchTaggedAttempt(
 		f.args.ns, f.args.query, f.args.opts)
-	return err
+	return resourceExhaustedAttemptError(err, f.args.retryResourceExhausted)
 }
 
 type fetchTaggedAttemptPool interface {
@@ -274,6 +274,8 @@ func (q *queue) drain() {
 				q.asyncAggregate(v)
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *deleteTaggedOp:
+				q.asyncDeleteTagged(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -954,6 +956,31 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	})
 }
 
+func (q *queue) asyncDeleteTagged(op *deleteTaggedOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.DeleteTaggedRequestTimeout())
+		if res, err := client.DeleteTagged(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
 func (q *queue) Len() int {
 	q.RLock()
 	v := q.opsSumSize
@@ -85,6 +85,9 @@ const (
 	// defaultTruncateRequestTimeout is the default truncate request timeout
 	defaultTruncateRequestTimeout = 60 * time.Second
 
+	// defaultDeleteTaggedRequestTimeout is the default delete tagged request timeout
+	defaultDeleteTaggedRequestTimeout = 60 * time.Second
+
 	// defaultIdentifierPoolSize is the default identifier pool size
 	defaultIdentifierPoolSize = 8192
 
@@ -192,6 +195,17 @@ var (
 			SetMaxRetries(3).
 			SetJitter(true))
 
+	// defaultResourceExhaustedRetrier is the default retrier for operations
+	// rejected by the rate limits of a namespace, it backs off for longer
+	// than the write and fetch retriers to let the rate limits refill
+	defaultResourceExhaustedRetrier = xretry.NewRetrier(
+		xretry.NewOptions().
+			SetInitialBackoff(time.Second).
+			SetBackoffFactor(2).
+			SetMaxBackoff(10 * time.Second).
+			SetMaxRetries(3).
+			SetJitter(true))
+
 	// defaultStreamBlocksRetrier is the default retrier for streaming blocks
 	defaultStreamBlocksRetrier = xretry.NewRetrier(
 		xretry.NewOptions().
@@ -229,6 +243,7 @@ type options struct {
 	writeRequestTimeout                     time.Duration
 	fetchRequestTimeout                     time.Duration
 	truncateRequestTimeout                  time.Duration
+	deleteTaggedRequestTimeout              time.Duration
 	backgroundConnectInterval               time.Duration
 	backgroundConnectStutter                time.Duration
 	backgroundHealthCheckInterval           time.Duration
@@ -241,6 +256,7 @@ type options struct {
 	tagDecoderPoolSize                      int
 	writeRetrier                            xretry.Retrier
 	fetchRetrier                            xretry.Retrier
+	resourceExhaustedRetrier                xretry.Retrier
 	streamBlocksRetrier                     xretry.Retrier
 	readerIteratorAllocate                  encoding.ReaderIteratorAllocate
 	writeOperationPoolSize                  int
@@ -334,6 +350,7 @@ func newOptions() *options {
 		writeRequestTimeout:                     defaultWriteRequestTimeout,
 		fetchRequestTimeout:                     defaultFetchRequestTimeout,
 		truncateRequestTimeout:                  defaultTruncateRequestTimeout,
+		deleteTaggedRequestTimeout:              defaultDeleteTaggedRequestTimeout,
 		backgroundConnectInterval:               defaultBackgroundConnectInterval,
 		backgroundConnectStutter:                defaultBackgroundConnectStutter,
 		backgroundHealthCheckInterval:           defaultBackgroundHealthCheckInterval,
@@ -342,6 +359,7 @@ func newOptions() *options {
 		backgroundHealthCheckFailThrottleFactor: defaultBackgroundHealthCheckFailThrottleFactor,
 		writeRetrier:                            defaultWriteRetrier,
 		fetchRetrier:                            defaultFetchRetrier,
+		resourceExhaustedRetrier:                defaultResourceExhaustedRetrier,
 		tagEncoderPoolSize:                      defaultTagEncoderPoolSize,
 		tagEncoderOpts:                          serialize.NewTagEncoderOptions(),
 		tagDecoderPoolSize:                      defaultTagDecoderPoolSize,
@@ -599,6 +617,16 @@ func (o *options) TruncateRequestTimeout() time.Duration {
 	return o.truncateRequestTimeout
 }
 
+func (o *options) SetDeleteTaggedRequestTimeout(value time.Duration) Options {
+	opts := *o
+	opts.deleteTaggedRequestTimeout = value
+	return &opts
+}
+
+func (o *options) DeleteTaggedRequestTimeout() time.Duration {
+	return o.deleteTaggedRequestTimeout
+}
+
 func (o *options) SetBackgroundConnectInterval(value time.Duration) Options {
 	opts := *o
 	opts.backgroundConnectInterval = value
@@ -679,6 +707,16 @@ func (o *options) FetchRetrier() xretry.Retrier {
 	return o.fetchRetrier
 }
 
+func (o *options) SetResourceExhaustedRetrier(value xretry.Retrier) Options {
+	opts := *o
+	opts.resourceExhaustedRetrier = value
+	return &opts
+}
+
+func (o *options) ResourceExhaustedRetrier() xretry.Retrier {
+	return o.resourceExhaustedRetrier
+}
+
 func (o *options) SetTagEncoderOptions(value serialize.TagEncoderOptions) Options {
 	opts := *o
 	opts.tagEncoderOpts = value
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/topology"
+	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
 	m3sync "github.com/m3db/m3/src/x/sync"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -241,6 +242,25 @@ func (s replicatedSession) FetchTaggedIDs(namespace ident.ID, q index.Query, opt
 	return s.session.FetchTaggedIDs(namespace, q, opts)
 }
 
+// DeleteTagged deletes the data within the time range [start, end) of
+// the series matching the query from all replicated clusters.
+func (s replicatedSession) DeleteTagged(
+	namespace ident.ID, q index.Query, start, end time.Time,
+) (int64, error) {
+	var multiErr xerrors.MultiError
+	for _, asyncSession := range s.asyncSessions {
+		if _, err := asyncSession.DeleteTagged(namespace, q, start, end); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
+	deleted, err := s.session.DeleteTagged(namespace, q, start, end)
+	if err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	return deleted, multiErr.FinalError()
+}
+
 // ShardID returns the given shard for an ID for callers
 // to easily discern what shard is failing when operations
 // for given IDs begin failing.
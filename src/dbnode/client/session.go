@@ -147,6 +147,7 @@ type session struct {
 	newHostQueueFn                   newHostQueueFn
 	writeRetrier                     xretry.Retrier
 	fetchRetrier                     xretry.Retrier
+	resourceExhaustedRetrier         xretry.Retrier
 	streamBlocksRetrier              xretry.Retrier
 	pools                            sessionPools
 	fetchBatchSize                   int
@@ -171,12 +172,14 @@ type sessionMetrics struct {
 	sync.RWMutex
 	writeSuccess                         tally.Counter
 	writeErrorsBadRequest                tally.Counter
+	writeErrorsResourceExhausted         tally.Counter
 	writeErrorsInternalError             tally.Counter
 	writeLatencyHistogram                tally.Histogram
 	writeNodesRespondingErrors           []tally.Counter
 	writeNodesRespondingBadRequestErrors []tally.Counter
 	fetchSuccess                         tally.Counter
 	fetchErrorsBadRequest                tally.Counter
+	fetchErrorsResourceExhausted         tally.Counter
 	fetchErrorsInternalError             tally.Counter
 	fetchLatencyHistogram                tally.Histogram
 	fetchNodesRespondingErrors           []tally.Counter
@@ -192,6 +195,9 @@ func newSessionMetrics(scope tally.Scope) sessionMetrics {
 		writeErrorsBadRequest: scope.Tagged(map[string]string{
 			"error_type": "bad_request",
 		}).Counter("write.errors"),
+		writeErrorsResourceExhausted: scope.Tagged(map[string]string{
+			"error_type": "resource_exhausted",
+		}).Counter("write.errors"),
 		writeErrorsInternalError: scope.Tagged(map[string]string{
 			"error_type": "internal_error",
 		}).Counter("write.errors"),
@@ -200,6 +206,9 @@ func newSessionMetrics(scope tally.Scope) sessionMetrics {
 		fetchErrorsBadRequest: scope.Tagged(map[string]string{
 			"error_type": "bad_request",
 		}).Counter("fetch.errors"),
+		fetchErrorsResourceExhausted: scope.Tagged(map[string]string{
+			"error_type": "resource_exhausted",
+		}).Counter("fetch.errors"),
 		fetchErrorsInternalError: scope.Tagged(map[string]string{
 			"error_type": "internal_error",
 		}).Counter("fetch.errors"),
@@ -273,17 +282,18 @@ func newSession(opts Options) (clientSession, error) {
 			queuesByHostID: make(map[string]hostQueue),
 			topo:           topo,
 		},
-		opts:                 opts,
-		scope:                scope,
-		nowFn:                opts.ClockOptions().NowFn(),
-		log:                  opts.InstrumentOptions().Logger(),
-		logWriteErrorSampler: logWriteErrorSampler,
-		logFetchErrorSampler: logFetchErrorSampler,
-		newHostQueueFn:       newHostQueue,
-		fetchBatchSize:       opts.FetchBatchSize(),
-		newPeerBlocksQueueFn: newPeerBlocksQueue,
-		writeRetrier:         opts.WriteRetrier(),
-		fetchRetrier:         opts.FetchRetrier(),
+		opts:                     opts,
+		scope:                    scope,
+		nowFn:                    opts.ClockOptions().NowFn(),
+		log:                      opts.InstrumentOptions().Logger(),
+		logWriteErrorSampler:     logWriteErrorSampler,
+		logFetchErrorSampler:     logFetchErrorSampler,
+		newHostQueueFn:           newHostQueue,
+		fetchBatchSize:           opts.FetchBatchSize(),
+		newPeerBlocksQueueFn:     newPeerBlocksQueue,
+		writeRetrier:             opts.WriteRetrier(),
+		fetchRetrier:             opts.FetchRetrier(),
+		resourceExhaustedRetrier: opts.ResourceExhaustedRetrier(),
 		pools: sessionPools{
 			context: opts.ContextPool(),
 			id:      opts.IdentifierPool(),
@@ -454,6 +464,8 @@ func (s *session) recordWriteMetrics(consistencyResultErr error, respErrs int32,
 		s.metrics.writeSuccess.Inc(1)
 	} else if IsBadRequestError(consistencyResultErr) {
 		s.metrics.writeErrorsBadRequest.Inc(1)
+	} else if IsResourceExhaustedError(consistencyResultErr) {
+		s.metrics.writeErrorsResourceExhausted.Inc(1)
 	} else {
 		s.metrics.writeErrorsInternalError.Inc(1)
 	}
@@ -478,6 +490,8 @@ func (s *session) recordFetchMetrics(consistencyResultErr error, respErrs int32,
 		s.metrics.fetchSuccess.Inc(1)
 	} else if IsBadRequestError(consistencyResultErr) {
 		s.metrics.fetchErrorsBadRequest.Inc(1)
+	} else if IsResourceExhaustedError(consistencyResultErr) {
+		s.metrics.fetchErrorsResourceExhausted.Inc(1)
 	} else {
 		s.metrics.fetchErrorsInternalError.Inc(1)
 	}
@@ -987,7 +1001,7 @@ func (s *session) Write(
 	w.args.tags = ident.EmptyTagIterator
 	w.args.t, w.args.value, w.args.unit, w.args.annotation =
 		t, value, unit, annotation
-	err := s.writeRetrier.Attempt(w.attemptFn)
+	err := s.attempt(s.writeRetrier, w.attemptFn, &w.args.retryResourceExhausted)
 	s.pools.writeAttempt.Put(w)
 	return err
 }
@@ -1005,11 +1019,27 @@ func (s *session) WriteTagged(
 	w.args.namespace, w.args.id, w.args.tags = nsID, id, tags
 	w.args.t, w.args.value, w.args.unit, w.args.annotation =
 		t, value, unit, annotation
-	err := s.writeRetrier.Attempt(w.attemptFn)
+	err := s.attempt(s.writeRetrier, w.attemptFn, &w.args.retryResourceExhausted)
 	s.pools.writeAttempt.Put(w)
 	return err
 }
 
+// attempt performs an attempt with the retrier, unless the attempt is
+// rejected by the rate limits of a namespace in which case it is retried
+// with the resource exhausted retrier which backs off for longer.
+func (s *session) attempt(
+	retrier xretry.Retrier,
+	fn xretry.Fn,
+	retryResourceExhausted *bool,
+) error {
+	err := retrier.Attempt(fn)
+	if !IsResourceExhaustedError(err) {
+		return err
+	}
+	*retryResourceExhausted = true
+	return s.resourceExhaustedRetrier.Attempt(fn)
+}
+
 func (s *session) writeAttempt(
 	wType writeAttemptType,
 	nsID, id ident.ID,
@@ -1199,7 +1229,7 @@ func (s *session) FetchIDs(
 	f := s.pools.fetchAttempt.Get()
 	f.args.namespace, f.args.ids = nsID, ids
 	f.args.start, f.args.end = startInclusive, endExclusive
-	err := s.fetchRetrier.Attempt(f.attemptFn)
+	err := s.attempt(s.fetchRetrier, f.attemptFn, &f.args.retryResourceExhausted)
 	result := f.result
 	s.pools.fetchAttempt.Put(f)
 	return result, err
@@ -1212,7 +1242,7 @@ func (s *session) Aggregate(
 	f.args.ns = ns
 	f.args.query = q
 	f.args.opts = opts
-	err := s.fetchRetrier.Attempt(f.attemptFn)
+	err := s.attempt(s.fetchRetrier, f.attemptFn, &f.args.retryResourceExhausted)
 	iter, metadata := f.resultIter, f.resultMetadata
 	s.pools.aggregateAttempt.Put(f)
 	return iter, metadata, err
@@ -1273,7 +1303,7 @@ func (s *session) FetchTagged(
 	f.args.ns = ns
 	f.args.query = q
 	f.args.opts = opts
-	err := s.fetchRetrier.Attempt(f.dataAttemptFn)
+	err := s.attempt(s.fetchRetrier, f.dataAttemptFn, &f.args.retryResourceExhausted)
 	iters, metadata := f.dataResultIters, f.dataResultMetadata
 	s.pools.fetchTaggedAttempt.Put(f)
 	return iters, metadata, err
@@ -1286,7 +1316,7 @@ func (s *session) FetchTaggedIDs(
 	f.args.ns = ns
 	f.args.query = q
 	f.args.opts = opts
-	err := s.fetchRetrier.Attempt(f.idsAttemptFn)
+	err := s.attempt(s.fetchRetrier, f.idsAttemptFn, &f.args.retryResourceExhausted)
 	iter, metadata := f.idsResultIter, f.idsResultMetadata
 	s.pools.fetchTaggedAttempt.Put(f)
 	return iter, metadata, err
@@ -1914,6 +1944,60 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) DeleteTagged(
+	namespace ident.ID,
+	q index.Query,
+	start, end time.Time,
+) (int64, error) {
+	req, err := convert.ToRPCDeleteTaggedRequest(namespace, q, start, end)
+	if err != nil {
+		return 0, xerrors.NewNonRetryableError(err)
+	}
+
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		deleted       int64
+	)
+
+	d := &deleteTaggedOp{request: req}
+	d.completionFn = func(result interface{}, err error) {
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		} else {
+			res := result.(*rpc.DeleteTaggedResult_)
+			atomic.AddInt64(&deleted, res.NumSeries)
+		}
+		wg.Done()
+	}
+
+	// NB: Deletes are sent to every host rather than only the replicas of
+	// each shard as the series matching the query are not known upfront.
+	s.state.RLock()
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(d); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Error("failed to enqueue request", zap.Error(err))
+		return 0, err
+	}
+
+	// Wait for the series to be deleted on all replicas
+	wg.Wait()
+
+	return deleted, resultErr.FinalError()
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
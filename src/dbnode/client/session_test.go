@@ -25,9 +25,11 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
@@ -221,6 +223,47 @@ func TestSessionClusterConnectConsistencyLevelNone(t *testing.T) {
 	}
 }
 
+func TestSessionAttemptResourceExhaustedRetrier(t *testing.T) {
+	newTestRetrier := func(maxRetries int) xretry.Retrier {
+		return xretry.NewRetrier(xretry.NewOptions().
+			SetInitialBackoff(time.Millisecond).
+			SetMaxRetries(maxRetries))
+	}
+
+	var (
+		s = &session{
+			resourceExhaustedRetrier: newTestRetrier(3),
+		}
+		retrier      = newTestRetrier(2)
+		exhaustedErr = &rpc.Error{
+			Type:    rpc.ErrorType_RESOURCE_EXHAUSTED,
+			Message: "rate limit exceeded",
+		}
+		internalErr = fmt.Errorf("internal error")
+	)
+
+	for _, test := range []struct {
+		err           error
+		expectedCalls int
+	}{
+		// Rejected attempts are retried by the resource exhausted retrier only.
+		{err: exhaustedErr, expectedCalls: 1 + 4},
+		{err: internalErr, expectedCalls: 3},
+	} {
+		var (
+			retryResourceExhausted bool
+			calls                  int
+		)
+		err := s.attempt(retrier, func() error {
+			calls++
+			return resourceExhaustedAttemptError(test.err, retryResourceExhausted)
+		}, &retryResourceExhausted)
+		require.Error(t, err)
+		assert.Equal(t, test.expectedCalls, calls)
+		assert.Equal(t, test.err == exhaustedErr, retryResourceExhausted)
+	}
+}
+
 func TestIteratorPools(t *testing.T) {
 	s := session{}
 	itPool, err := s.IteratorPools()
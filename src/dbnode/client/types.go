@@ -83,6 +83,11 @@ type Session interface {
 	// Aggregate aggregates values from the database for the given set of constraints.
 	Aggregate(namespace ident.ID, q index.Query, opts index.AggregationOptions) (AggregatedTagsIterator, FetchResponseMetadata, error)
 
+	// DeleteTagged deletes the data within the time range [start, end) of
+	// the series matching the query, returning the number of series deleted
+	// summed across all replicas.
+	DeleteTagged(namespace ident.ID, q index.Query, start, end time.Time) (int64, error)
+
 	// ShardID returns the given shard for an ID for callers
 	// to easily discern what shard is failing when operations
 	// for given IDs begin failing.
@@ -351,6 +356,12 @@ type Options interface {
 	// TruncateRequestTimeout returns the truncateRequestTimeout.
 	TruncateRequestTimeout() time.Duration
 
+	// SetDeleteTaggedRequestTimeout sets the deleteTaggedRequestTimeout.
+	SetDeleteTaggedRequestTimeout(value time.Duration) Options
+
+	// DeleteTaggedRequestTimeout returns the deleteTaggedRequestTimeout.
+	DeleteTaggedRequestTimeout() time.Duration
+
 	// SetBackgroundConnectInterval sets the backgroundConnectInterval.
 	SetBackgroundConnectInterval(value time.Duration) Options
 
@@ -411,6 +422,16 @@ type Options interface {
 	// a fetch operation. Only retryable errors are retried.
 	FetchRetrier() xretry.Retrier
 
+	// SetResourceExhaustedRetrier sets the retrier used instead of the write
+	// or fetch retrier once an operation is rejected by the rate limits of a
+	// namespace.
+	SetResourceExhaustedRetrier(value xretry.Retrier) Options
+
+	// ResourceExhaustedRetrier returns the retrier used instead of the write
+	// or fetch retrier once an operation is rejected by the rate limits of a
+	// namespace.
+	ResourceExhaustedRetrier() xretry.Retrier
+
 	// SetTagEncoderOptions sets the TagEncoderOptions.
 	SetTagEncoderOptions(value serialize.TagEncoderOptions) Options
 
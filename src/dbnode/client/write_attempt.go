@@ -58,6 +58,8 @@ type writeAttemptArgs struct {
 	annotation  []byte
 	unit        xtime.Unit
 	attemptType writeAttemptType
+
+	retryResourceExhausted bool
 }
 
 func (w *writeAttempt) reset() {
@@ -74,7 +76,7 @@ func (w *writeAttempt) perform() error {
 		err = xerrors.NewNonRetryableError(err)
 	}
 
-	return err
+	return resourceExhaustedAttemptError(err, w.args.retryResourceExhausted)
 }
 
 type writeAttemptPool struct {
@@ -22,20 +22,22 @@
 // THE SOFTWARE.
 
 /*
-	Package namespace is a generated protocol buffer package.
+Package namespace is a generated protocol buffer package.
 
-	It is generated from these files:
-		github.com/m3db/m3/src/dbnode/generated/proto/namespace/namespace.proto
-		github.com/m3db/m3/src/dbnode/generated/proto/namespace/schema.proto
+It is generated from these files:
 
-	It has these top-level messages:
-		RetentionOptions
-		IndexOptions
-		NamespaceOptions
-		Registry
-		SchemaOptions
-		SchemaHistory
-		FileDescriptorSet
+	github.com/m3db/m3/src/dbnode/generated/proto/namespace/namespace.proto
+	github.com/m3db/m3/src/dbnode/generated/proto/namespace/schema.proto
+
+It has these top-level messages:
+
+	RetentionOptions
+	IndexOptions
+	NamespaceOptions
+	Registry
+	SchemaOptions
+	SchemaHistory
+	FileDescriptorSet
 */
 package namespace
 
@@ -56,6 +58,27 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type DataFileCompression int32
+
+const (
+	DataFileCompression_NONE   DataFileCompression = 0
+	DataFileCompression_SNAPPY DataFileCompression = 1
+)
+
+var DataFileCompression_name = map[int32]string{
+	0: "NONE",
+	1: "SNAPPY",
+}
+var DataFileCompression_value = map[string]int32{
+	"NONE":   0,
+	"SNAPPY": 1,
+}
+
+func (x DataFileCompression) String() string {
+	return proto.EnumName(DataFileCompression_name, int32(x))
+}
+func (DataFileCompression) EnumDescriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{0} }
+
 type RetentionOptions struct {
 	RetentionPeriodNanos                     int64 `protobuf:"varint,1,opt,name=retentionPeriodNanos,proto3" json:"retentionPeriodNanos,omitempty"`
 	BlockSizeNanos                           int64 `protobuf:"varint,2,opt,name=blockSizeNanos,proto3" json:"blockSizeNanos,omitempty"`
@@ -145,16 +168,17 @@ func (m *IndexOptions) GetBlockSizeNanos() int64 {
 }
 
 type NamespaceOptions struct {
-	BootstrapEnabled  bool              `protobuf:"varint,1,opt,name=bootstrapEnabled,proto3" json:"bootstrapEnabled,omitempty"`
-	FlushEnabled      bool              `protobuf:"varint,2,opt,name=flushEnabled,proto3" json:"flushEnabled,omitempty"`
-	WritesToCommitLog bool              `protobuf:"varint,3,opt,name=writesToCommitLog,proto3" json:"writesToCommitLog,omitempty"`
-	CleanupEnabled    bool              `protobuf:"varint,4,opt,name=cleanupEnabled,proto3" json:"cleanupEnabled,omitempty"`
-	RepairEnabled     bool              `protobuf:"varint,5,opt,name=repairEnabled,proto3" json:"repairEnabled,omitempty"`
-	RetentionOptions  *RetentionOptions `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
-	SnapshotEnabled   bool              `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
-	IndexOptions      *IndexOptions     `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
-	SchemaOptions     *SchemaOptions    `protobuf:"bytes,9,opt,name=schemaOptions" json:"schemaOptions,omitempty"`
-	ColdWritesEnabled bool              `protobuf:"varint,10,opt,name=coldWritesEnabled,proto3" json:"coldWritesEnabled,omitempty"`
+	BootstrapEnabled    bool                `protobuf:"varint,1,opt,name=bootstrapEnabled,proto3" json:"bootstrapEnabled,omitempty"`
+	FlushEnabled        bool                `protobuf:"varint,2,opt,name=flushEnabled,proto3" json:"flushEnabled,omitempty"`
+	WritesToCommitLog   bool                `protobuf:"varint,3,opt,name=writesToCommitLog,proto3" json:"writesToCommitLog,omitempty"`
+	CleanupEnabled      bool                `protobuf:"varint,4,opt,name=cleanupEnabled,proto3" json:"cleanupEnabled,omitempty"`
+	RepairEnabled       bool                `protobuf:"varint,5,opt,name=repairEnabled,proto3" json:"repairEnabled,omitempty"`
+	RetentionOptions    *RetentionOptions   `protobuf:"bytes,6,opt,name=retentionOptions" json:"retentionOptions,omitempty"`
+	SnapshotEnabled     bool                `protobuf:"varint,7,opt,name=snapshotEnabled,proto3" json:"snapshotEnabled,omitempty"`
+	IndexOptions        *IndexOptions       `protobuf:"bytes,8,opt,name=indexOptions" json:"indexOptions,omitempty"`
+	SchemaOptions       *SchemaOptions      `protobuf:"bytes,9,opt,name=schemaOptions" json:"schemaOptions,omitempty"`
+	ColdWritesEnabled   bool                `protobuf:"varint,10,opt,name=coldWritesEnabled,proto3" json:"coldWritesEnabled,omitempty"`
+	DataFileCompression DataFileCompression `protobuf:"varint,11,opt,name=dataFileCompression,proto3,enum=namespace.DataFileCompression" json:"dataFileCompression,omitempty"`
 }
 
 func (m *NamespaceOptions) Reset()                    { *m = NamespaceOptions{} }
@@ -232,6 +256,13 @@ func (m *NamespaceOptions) GetColdWritesEnabled() bool {
 	return false
 }
 
+func (m *NamespaceOptions) GetDataFileCompression() DataFileCompression {
+	if m != nil {
+		return m.DataFileCompression
+	}
+	return DataFileCompression_NONE
+}
+
 type Registry struct {
 	Namespaces map[string]*NamespaceOptions `protobuf:"bytes,1,rep,name=namespaces" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value"`
 }
@@ -253,6 +284,7 @@ func init() {
 	proto.RegisterType((*IndexOptions)(nil), "namespace.IndexOptions")
 	proto.RegisterType((*NamespaceOptions)(nil), "namespace.NamespaceOptions")
 	proto.RegisterType((*Registry)(nil), "namespace.Registry")
+	proto.RegisterEnum("namespace.DataFileCompression", DataFileCompression_name, DataFileCompression_value)
 }
 func (m *RetentionOptions) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -460,6 +492,11 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i++
 	}
+	if m.DataFileCompression != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.DataFileCompression))
+	}
 	return i, nil
 }
 
@@ -593,6 +630,9 @@ func (m *NamespaceOptions) Size() (n int) {
 	if m.ColdWritesEnabled {
 		n += 2
 	}
+	if m.DataFileCompression != 0 {
+		n += 1 + sovNamespace(uint64(m.DataFileCompression))
+	}
 	return n
 }
 
@@ -1169,6 +1209,25 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.ColdWritesEnabled = bool(v != 0)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DataFileCompression", wireType)
+			}
+			m.DataFileCompression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DataFileCompression |= (DataFileCompression(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNamespace(dAtA[iNdEx:])
@@ -1473,41 +1532,45 @@ func init() {
 }
 
 var fileDescriptorNamespace = []byte{
-	// 575 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x51, 0x6b, 0xd3, 0x50,
-	0x14, 0xc7, 0x4d, 0xb3, 0xad, 0xed, 0x59, 0xe7, 0xe2, 0x45, 0xb0, 0x54, 0x28, 0xa3, 0x8a, 0x14,
-	0x91, 0x06, 0xdb, 0x17, 0x51, 0x18, 0xcc, 0xad, 0x0e, 0x41, 0x6a, 0xb9, 0x13, 0x84, 0xbd, 0xdd,
-	0x24, 0xa7, 0x6d, 0x58, 0x92, 0x1b, 0xee, 0xbd, 0xd1, 0xd5, 0xcf, 0xe0, 0x83, 0xdf, 0xc3, 0x2f,
-	0xe2, 0xa3, 0x1f, 0x41, 0xea, 0xd7, 0xf0, 0x41, 0x72, 0x63, 0xba, 0x24, 0x1d, 0x32, 0xf6, 0x52,
-	0x6e, 0xff, 0xe7, 0x77, 0xce, 0xb9, 0x3d, 0xff, 0x73, 0x0b, 0xa7, 0x73, 0x5f, 0x2d, 0x12, 0x67,
-	0xe0, 0xf2, 0xd0, 0x0e, 0x47, 0x9e, 0x63, 0x87, 0x23, 0x5b, 0x0a, 0xd7, 0xf6, 0x9c, 0x88, 0x7b,
-	0x68, 0xcf, 0x31, 0x42, 0xc1, 0x14, 0x7a, 0x76, 0x2c, 0xb8, 0xe2, 0x76, 0xc4, 0x42, 0x94, 0x31,
-	0x73, 0xf1, 0xea, 0x34, 0xd0, 0x11, 0xd2, 0x5c, 0x0b, 0x9d, 0x93, 0xdb, 0xd6, 0x94, 0xee, 0x02,
-	0x43, 0x96, 0x15, 0xec, 0x7d, 0x35, 0xc1, 0xa2, 0xa8, 0x30, 0x52, 0x3e, 0x8f, 0xde, 0xc7, 0xe9,
-	0xa7, 0x24, 0x43, 0xb8, 0x2f, 0x72, 0x6d, 0x8a, 0xc2, 0xe7, 0xde, 0x84, 0x45, 0x5c, 0xb6, 0x8d,
-	0x03, 0xa3, 0x6f, 0xd2, 0x6b, 0x63, 0xe4, 0x09, 0xdc, 0x75, 0x02, 0xee, 0x5e, 0x9c, 0xf9, 0x5f,
-	0x30, 0xa3, 0x6b, 0x9a, 0xae, 0xa8, 0xe4, 0x19, 0xdc, 0x73, 0x92, 0xd9, 0x0c, 0xc5, 0x9b, 0x44,
-	0x25, 0xe2, 0x1f, 0x6a, 0x6a, 0x74, 0x33, 0x40, 0xfa, 0xb0, 0x9f, 0x89, 0x53, 0x26, 0x55, 0xc6,
-	0x6e, 0x69, 0xb6, 0x2a, 0x6b, 0x32, 0xed, 0x74, 0xc2, 0x14, 0x1b, 0x5f, 0xc6, 0xbe, 0x58, 0xb6,
-	0xb7, 0x0f, 0x8c, 0x7e, 0x83, 0x56, 0x65, 0x72, 0x0e, 0xfd, 0x8a, 0x74, 0x34, 0x53, 0x28, 0x26,
-	0x5c, 0x1d, 0xb9, 0x2e, 0x4a, 0x59, 0xfc, 0xc5, 0x3b, 0xba, 0xd9, 0x8d, 0x79, 0x72, 0x08, 0x9d,
-	0x99, 0xbe, 0x3e, 0xbd, 0x6e, 0x7e, 0x75, 0x5d, 0xed, 0x3f, 0x44, 0x6f, 0x0a, 0xad, 0xb7, 0x91,
-	0x87, 0x97, 0xb9, 0x13, 0x6d, 0xa8, 0x63, 0xc4, 0x9c, 0x00, 0x3d, 0x3d, 0xfc, 0x06, 0xcd, 0xbf,
-	0xde, 0x74, 0xde, 0xbd, 0x3f, 0x26, 0x58, 0x93, 0xdc, 0xfb, 0xbc, 0xec, 0x53, 0xb0, 0x1c, 0xce,
-	0x95, 0x54, 0x82, 0xc5, 0xe3, 0x52, 0xfd, 0x0d, 0x9d, 0xf4, 0xa0, 0x35, 0x0b, 0x12, 0xb9, 0xc8,
-	0xb9, 0x9a, 0xe6, 0x4a, 0x5a, 0x6a, 0xea, 0x67, 0xe1, 0x2b, 0x94, 0x1f, 0xf8, 0x31, 0x0f, 0x43,
-	0x5f, 0xbd, 0xe3, 0x73, 0x6d, 0x6a, 0x83, 0x6e, 0x06, 0xd2, 0xab, 0xbb, 0x01, 0xb2, 0x28, 0x59,
-	0xf7, 0xde, 0xd2, 0x68, 0x45, 0x25, 0x8f, 0x61, 0x4f, 0x60, 0xcc, 0x7c, 0x91, 0x63, 0x99, 0xa1,
-	0x65, 0x91, 0x9c, 0x82, 0x25, 0x2a, 0x0b, 0xac, 0x6d, 0xdb, 0x1d, 0x3e, 0x1c, 0x5c, 0x3d, 0x9f,
-	0xea, 0x8e, 0xd3, 0x8d, 0xa4, 0x74, 0x83, 0x64, 0xc4, 0x62, 0xb9, 0xe0, 0x2a, 0x6f, 0x58, 0xcf,
-	0x36, 0xa8, 0x22, 0x93, 0x57, 0xd0, 0xf2, 0x0b, 0x2e, 0xb5, 0x1b, 0xba, 0xdd, 0x83, 0x42, 0xbb,
-	0xa2, 0x89, 0xb4, 0x04, 0x93, 0x43, 0xd8, 0xcb, 0x5e, 0x60, 0x9e, 0xdd, 0xd4, 0xd9, 0xed, 0x42,
-	0xf6, 0x59, 0x31, 0x4e, 0xcb, 0x78, 0x3a, 0x6b, 0x97, 0x07, 0xde, 0x47, 0x3d, 0xd6, 0xfc, 0xa2,
-	0x90, 0xcd, 0x7a, 0x23, 0xd0, 0xfb, 0x6e, 0x40, 0x83, 0xe2, 0xdc, 0x97, 0x4a, 0x2c, 0xc9, 0x31,
-	0xc0, 0xba, 0x49, 0xfa, 0x9a, 0xcd, 0xfe, 0xee, 0xf0, 0x51, 0x69, 0x48, 0x19, 0x38, 0x58, 0x2f,
-	0x8c, 0x1c, 0x47, 0x4a, 0x2c, 0x69, 0x21, 0xad, 0x73, 0x0e, 0xfb, 0x95, 0x30, 0xb1, 0xc0, 0xbc,
-	0xc0, 0xa5, 0xde, 0xa0, 0x26, 0x4d, 0x8f, 0xe4, 0x39, 0x6c, 0x7f, 0x62, 0x41, 0x82, 0x7a, 0x5b,
-	0xca, 0x4e, 0x54, 0x97, 0x91, 0x66, 0xe4, 0xcb, 0xda, 0x0b, 0xe3, 0xb5, 0xf5, 0x63, 0xd5, 0x35,
-	0x7e, 0xae, 0xba, 0xc6, 0xaf, 0x55, 0xd7, 0xf8, 0xf6, 0xbb, 0x7b, 0xc7, 0xd9, 0xd1, 0x7f, 0x53,
-	0xa3, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x60, 0xfa, 0xeb, 0x0c, 0x42, 0x05, 0x00, 0x00,
+	// 628 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x6f, 0x6b, 0xd3, 0x50,
+	0x14, 0xc6, 0x97, 0xb6, 0xdb, 0xba, 0xb3, 0x7f, 0xf1, 0x4e, 0x30, 0x4c, 0x28, 0xa3, 0x8a, 0x94,
+	0x29, 0x0d, 0x76, 0x6f, 0x44, 0x61, 0x30, 0xb7, 0x6e, 0x08, 0xd2, 0x95, 0x3b, 0x41, 0xdc, 0xbb,
+	0x9b, 0xe4, 0xb4, 0x0d, 0x4b, 0x72, 0xc3, 0xbd, 0x37, 0xba, 0xfa, 0x19, 0x7c, 0xe1, 0xf7, 0xf0,
+	0x8b, 0xf8, 0x4a, 0xfc, 0x08, 0x32, 0xbf, 0x88, 0xe4, 0xc6, 0x74, 0x69, 0x52, 0x64, 0xf8, 0xa6,
+	0xa4, 0xcf, 0xf9, 0x9d, 0x73, 0x6e, 0xce, 0x73, 0x72, 0xe1, 0x6c, 0xec, 0xab, 0x49, 0xe2, 0x74,
+	0x5d, 0x1e, 0xda, 0xe1, 0x81, 0xe7, 0xd8, 0xe1, 0x81, 0x2d, 0x85, 0x6b, 0x7b, 0x4e, 0xc4, 0x3d,
+	0xb4, 0xc7, 0x18, 0xa1, 0x60, 0x0a, 0x3d, 0x3b, 0x16, 0x5c, 0x71, 0x3b, 0x62, 0x21, 0xca, 0x98,
+	0xb9, 0x78, 0xfb, 0xd4, 0xd5, 0x11, 0xb2, 0x36, 0x13, 0x76, 0x4f, 0xfe, 0xb7, 0xa6, 0x74, 0x27,
+	0x18, 0xb2, 0xac, 0x60, 0xfb, 0x4b, 0x1d, 0x4c, 0x8a, 0x0a, 0x23, 0xe5, 0xf3, 0xe8, 0x3c, 0x4e,
+	0x7f, 0x25, 0xe9, 0xc1, 0x7d, 0x91, 0x6b, 0x43, 0x14, 0x3e, 0xf7, 0x06, 0x2c, 0xe2, 0xd2, 0x32,
+	0xf6, 0x8c, 0x4e, 0x9d, 0x2e, 0x8c, 0x91, 0x27, 0xb0, 0xe5, 0x04, 0xdc, 0xbd, 0xba, 0xf0, 0x3f,
+	0x63, 0x46, 0xd7, 0x34, 0x5d, 0x52, 0xc9, 0x33, 0xb8, 0xe7, 0x24, 0xa3, 0x11, 0x8a, 0xd3, 0x44,
+	0x25, 0xe2, 0x2f, 0x5a, 0xd7, 0x68, 0x35, 0x40, 0x3a, 0xb0, 0x9d, 0x89, 0x43, 0x26, 0x55, 0xc6,
+	0x36, 0x34, 0x5b, 0x96, 0x35, 0x99, 0x76, 0x3a, 0x61, 0x8a, 0xf5, 0xaf, 0x63, 0x5f, 0x4c, 0xad,
+	0xe5, 0x3d, 0xa3, 0xd3, 0xa4, 0x65, 0x99, 0x5c, 0x42, 0xa7, 0x24, 0x1d, 0x8d, 0x14, 0x8a, 0x01,
+	0x57, 0x47, 0xae, 0x8b, 0x52, 0x16, 0xdf, 0x78, 0x45, 0x37, 0xbb, 0x33, 0x4f, 0x0e, 0x61, 0x77,
+	0xa4, 0x8f, 0x4f, 0x17, 0xcd, 0x6f, 0x55, 0x57, 0xfb, 0x07, 0xd1, 0x1e, 0xc2, 0xc6, 0x9b, 0xc8,
+	0xc3, 0xeb, 0xdc, 0x09, 0x0b, 0x56, 0x31, 0x62, 0x4e, 0x80, 0x9e, 0x1e, 0x7e, 0x93, 0xe6, 0x7f,
+	0xef, 0x3a, 0xef, 0xf6, 0x8f, 0x06, 0x98, 0x83, 0xdc, 0xfb, 0xbc, 0xec, 0x3e, 0x98, 0x0e, 0xe7,
+	0x4a, 0x2a, 0xc1, 0xe2, 0xfe, 0x5c, 0xfd, 0x8a, 0x4e, 0xda, 0xb0, 0x31, 0x0a, 0x12, 0x39, 0xc9,
+	0xb9, 0x9a, 0xe6, 0xe6, 0xb4, 0xd4, 0xd4, 0x4f, 0xc2, 0x57, 0x28, 0xdf, 0xf1, 0x63, 0x1e, 0x86,
+	0xbe, 0x7a, 0xcb, 0xc7, 0xda, 0xd4, 0x26, 0xad, 0x06, 0xd2, 0xa3, 0xbb, 0x01, 0xb2, 0x28, 0x99,
+	0xf5, 0x6e, 0x68, 0xb4, 0xa4, 0x92, 0xc7, 0xb0, 0x29, 0x30, 0x66, 0xbe, 0xc8, 0xb1, 0xcc, 0xd0,
+	0x79, 0x91, 0x9c, 0x81, 0x29, 0x4a, 0x0b, 0xac, 0x6d, 0x5b, 0xef, 0x3d, 0xec, 0xde, 0x7e, 0x3e,
+	0xe5, 0x1d, 0xa7, 0x95, 0xa4, 0x74, 0x83, 0x64, 0xc4, 0x62, 0x39, 0xe1, 0x2a, 0x6f, 0xb8, 0x9a,
+	0x6d, 0x50, 0x49, 0x26, 0xaf, 0x60, 0xc3, 0x2f, 0xb8, 0x64, 0x35, 0x75, 0xbb, 0x07, 0x85, 0x76,
+	0x45, 0x13, 0xe9, 0x1c, 0x4c, 0x0e, 0x61, 0x33, 0xfb, 0x02, 0xf3, 0xec, 0x35, 0x9d, 0x6d, 0x15,
+	0xb2, 0x2f, 0x8a, 0x71, 0x3a, 0x8f, 0xa7, 0xb3, 0x76, 0x79, 0xe0, 0xbd, 0xd7, 0x63, 0xcd, 0x0f,
+	0x0a, 0xd9, 0xac, 0x2b, 0x01, 0x32, 0x84, 0x1d, 0x8f, 0x29, 0x76, 0xea, 0x07, 0x78, 0xcc, 0xc3,
+	0x58, 0xa0, 0x94, 0x3e, 0x8f, 0xac, 0xf5, 0x3d, 0xa3, 0xb3, 0xd5, 0x6b, 0x15, 0x7a, 0x9e, 0x54,
+	0x29, 0xba, 0x28, 0xb5, 0xfd, 0xcd, 0x80, 0x26, 0xc5, 0xb1, 0x2f, 0x95, 0x98, 0x92, 0x63, 0x80,
+	0x59, 0x89, 0xf4, 0x7e, 0xa8, 0x77, 0xd6, 0x7b, 0x8f, 0xe6, 0xc6, 0x9e, 0x81, 0xdd, 0xd9, 0x0a,
+	0xca, 0x7e, 0xa4, 0xc4, 0x94, 0x16, 0xd2, 0x76, 0x2f, 0x61, 0xbb, 0x14, 0x26, 0x26, 0xd4, 0xaf,
+	0x70, 0xaa, 0x77, 0x72, 0x8d, 0xa6, 0x8f, 0xe4, 0x39, 0x2c, 0x7f, 0x64, 0x41, 0x82, 0x56, 0xad,
+	0xe2, 0x6d, 0x79, 0xbd, 0x69, 0x46, 0xbe, 0xac, 0xbd, 0x30, 0xf6, 0x9f, 0xc2, 0xce, 0x82, 0x37,
+	0x23, 0x4d, 0x68, 0x0c, 0xce, 0x07, 0x7d, 0x73, 0x89, 0x00, 0xac, 0x5c, 0x0c, 0x8e, 0x86, 0xc3,
+	0x0f, 0xa6, 0xf1, 0xda, 0xfc, 0x7e, 0xd3, 0x32, 0x7e, 0xde, 0xb4, 0x8c, 0x5f, 0x37, 0x2d, 0xe3,
+	0xeb, 0xef, 0xd6, 0x92, 0xb3, 0xa2, 0x6f, 0xc9, 0x83, 0x3f, 0x03, 0x00, 0xf9, 0x1e, 0x2d, 0x85,
+	0xc1, 0x05, 0x00, 0x00,
 }
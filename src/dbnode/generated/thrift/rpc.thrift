@@ -29,7 +29,8 @@ enum TimeType {
 
 enum ErrorType {
 	INTERNAL_ERROR,
-	BAD_REQUEST
+	BAD_REQUEST,
+	RESOURCE_EXHAUSTED
 }
 
 exception Error {
@@ -63,6 +64,7 @@ service Node {
 	void writeTaggedBatchRawV2(1: WriteTaggedBatchRawV2Request req) throws (1: WriteBatchRawErrors err)
 	void repair() throws (1: Error err)
 	TruncateResult truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteTaggedResult deleteTagged(1: DeleteTaggedRequest req) throws (1: Error err)
 
 	// Management endpoints
 	NodeHealthResult health() throws (1: Error err)
@@ -165,11 +167,14 @@ struct FetchTaggedRequest {
 	5: required bool fetchData
 	6: optional i64 limit
 	7: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+	8: optional bool requireExhaustive = false
+	9: optional i64 docsLimit
 }
 
 struct FetchTaggedResult {
 	1: required list<FetchTaggedIDResult> elements
 	2: required bool exhaustive
+	3: optional i64 docsMatched
 }
 
 struct FetchTaggedIDResult {
@@ -297,6 +302,18 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteTaggedRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+}
+
+struct DeleteTaggedResult {
+	1: required i64 numSeries
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
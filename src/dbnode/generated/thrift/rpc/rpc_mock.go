@@ -240,6 +240,21 @@ func (mr *MockTChanNodeMockRecorder) BootstrappedInPlacementOrNoPlacement(ctx in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BootstrappedInPlacementOrNoPlacement", reflect.TypeOf((*MockTChanNode)(nil).BootstrappedInPlacementOrNoPlacement), ctx)
 }
 
+// DeleteTagged mocks base method
+func (m *MockTChanNode) DeleteTagged(ctx thrift.Context, req *DeleteTaggedRequest) (*DeleteTaggedResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", ctx, req)
+	ret0, _ := ret[0].(*DeleteTaggedResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged
+func (mr *MockTChanNodeMockRecorder) DeleteTagged(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockTChanNode)(nil).DeleteTagged), ctx, req)
+}
+
 // Fetch mocks base method
 func (m *MockTChanNode) Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error) {
 	m.ctrl.T.Helper()
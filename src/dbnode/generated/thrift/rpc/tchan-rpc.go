@@ -49,6 +49,7 @@ type TChanNode interface {
 	AggregateRaw(ctx thrift.Context, req *AggregateQueryRawRequest) (*AggregateQueryRawResult_, error)
 	Bootstrapped(ctx thrift.Context) (*NodeBootstrappedResult_, error)
 	BootstrappedInPlacementOrNoPlacement(ctx thrift.Context) (*NodeBootstrappedInPlacementOrNoPlacementResult_, error)
+	DeleteTagged(ctx thrift.Context, req *DeleteTaggedRequest) (*DeleteTaggedResult_, error)
 	Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error)
 	FetchBatchRaw(ctx thrift.Context, req *FetchBatchRawRequest) (*FetchBatchRawResult_, error)
 	FetchBatchRawV2(ctx thrift.Context, req *FetchBatchRawV2Request) (*FetchBatchRawResult_, error)
@@ -547,6 +548,24 @@ func (c *tchanNodeClient) BootstrappedInPlacementOrNoPlacement(ctx thrift.Contex
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanNodeClient) DeleteTagged(ctx thrift.Context, req *DeleteTaggedRequest) (*DeleteTaggedResult_, error) {
+	var resp NodeDeleteTaggedResult
+	args := NodeDeleteTaggedArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "deleteTagged", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for deleteTagged")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error) {
 	var resp NodeFetchResult
 	args := NodeFetchArgs{
@@ -989,6 +1008,7 @@ func (s *tchanNodeServer) Methods() []string {
 		"aggregateRaw",
 		"bootstrapped",
 		"bootstrappedInPlacementOrNoPlacement",
+		"deleteTagged",
 		"fetch",
 		"fetchBatchRaw",
 		"fetchBatchRawV2",
@@ -1026,6 +1046,8 @@ func (s *tchanNodeServer) Handle(ctx thrift.Context, methodName string, protocol
 		return s.handleBootstrapped(ctx, protocol)
 	case "bootstrappedInPlacementOrNoPlacement":
 		return s.handleBootstrappedInPlacementOrNoPlacement(ctx, protocol)
+	case "deleteTagged":
+		return s.handleDeleteTagged(ctx, protocol)
 	case "fetch":
 		return s.handleFetch(ctx, protocol)
 	case "fetchBatchRaw":
@@ -1192,6 +1214,34 @@ func (s *tchanNodeServer) handleBootstrappedInPlacementOrNoPlacement(ctx thrift.
 	return err == nil, &res, nil
 }
 
+func (s *tchanNodeServer) handleDeleteTagged(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeDeleteTaggedArgs
+	var res NodeDeleteTaggedResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.DeleteTagged(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleFetch(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeFetchArgs
 	var res NodeFetchResult
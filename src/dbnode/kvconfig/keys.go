@@ -44,4 +44,8 @@ const (
 	// ClientWriteConsistencyLevel is the KV config key for the runtime
 	// configuration specifying the client write consistency level
 	ClientWriteConsistencyLevel = "m3db.client.write-consistency-level"
+
+	// NamespaceLimitsKey is the KV config key for the runtime configuration
+	// specifying the per namespace write and read rate limits as YAML or JSON
+	NamespaceLimitsKey = "m3db.node.namespace-limits"
 )
@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"fmt"
+)
+
+// DataFileCompression is the compression applied to the data files of the
+// filesets flushed for a namespace.
+type DataFileCompression int
+
+const (
+	// DataFileCompressionNone writes data files uncompressed.
+	DataFileCompressionNone DataFileCompression = iota
+	// DataFileCompressionSnappy compresses groups of series streams in data
+	// files with snappy.
+	DataFileCompressionSnappy
+)
+
+var (
+	// ValidDataFileCompressions returns the valid data file compressions.
+	ValidDataFileCompressions = []DataFileCompression{
+		DataFileCompressionNone,
+		DataFileCompressionSnappy,
+	}
+)
+
+func (c DataFileCompression) String() string {
+	switch c {
+	case DataFileCompressionNone:
+		return "none"
+	case DataFileCompressionSnappy:
+		return "snappy"
+	}
+	return "unknown"
+}
+
+// ParseDataFileCompression parses a DataFileCompression from a string.
+func ParseDataFileCompression(str string) (DataFileCompression, error) {
+	var r DataFileCompression
+	if str == "" {
+		return DataFileCompressionNone, nil
+	}
+	for _, valid := range ValidDataFileCompressions {
+		if str == valid.String() {
+			r = valid
+			return r, nil
+		}
+	}
+	return r, fmt.Errorf("invalid DataFileCompression '%s' valid types are: %v",
+		str, ValidDataFileCompressions)
+}
+
+// UnmarshalYAML unmarshals a DataFileCompression into a valid type from string.
+func (c *DataFileCompression) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	r, err := ParseDataFileCompression(str)
+	if err != nil {
+		return err
+	}
+	*c = r
+	return nil
+}
@@ -158,6 +158,7 @@ metadatas:
     writesToCommitLog: true
     cleanupEnabled: true
     repairEnabled: true
+    dataFileCompression: snappy
     retention:
       retentionPeriod: 960h
       blockSize: 12h
@@ -186,6 +187,7 @@ metadatas:
 	require.Equal(t, false, opts.WritesToCommitLog())
 	require.Equal(t, false, opts.CleanupEnabled())
 	require.Equal(t, false, opts.RepairEnabled())
+	require.Equal(t, DataFileCompressionNone, opts.DataFileCompression())
 	require.Equal(t, false, opts.IndexOptions().Enabled())
 	testRetentionOpts := retention.NewOptions().
 		SetRetentionPeriod(8 * time.Hour).
@@ -222,6 +224,7 @@ metadatas:
 	require.Equal(t, true, opts.WritesToCommitLog())
 	require.Equal(t, true, opts.CleanupEnabled())
 	require.Equal(t, true, opts.RepairEnabled())
+	require.Equal(t, DataFileCompressionSnappy, opts.DataFileCompression())
 	require.Equal(t, true, opts.IndexOptions().Enabled())
 	require.Equal(t, 24*time.Hour, opts.IndexOptions().BlockSize())
 	testRetentionOpts = retention.NewOptions().
@@ -113,7 +113,8 @@ func ToMetadata(
 		SetSchemaHistory(sr).
 		SetRetentionOptions(ropts).
 		SetIndexOptions(iopts).
-		SetColdWritesEnabled(opts.ColdWritesEnabled)
+		SetColdWritesEnabled(opts.ColdWritesEnabled).
+		SetDataFileCompression(DataFileCompression(opts.DataFileCompression))
 
 	return NewMetadata(ident.StringID(id), mopts)
 }
@@ -170,6 +171,7 @@ func OptionsToProto(opts Options) *nsproto.NamespaceOptions {
 			Enabled:        iopts.Enabled(),
 			BlockSizeNanos: iopts.BlockSize().Nanoseconds(),
 		},
-		ColdWritesEnabled: opts.ColdWritesEnabled(),
+		ColdWritesEnabled:   opts.ColdWritesEnabled(),
+		DataFileCompression: nsproto.DataFileCompression(opts.DataFileCompression()),
 	}
 }
@@ -64,13 +64,14 @@ var (
 			SchemaOptions:     testSchemaOptions,
 		},
 		nsproto.NamespaceOptions{
-			BootstrapEnabled:  true,
-			FlushEnabled:      true,
-			WritesToCommitLog: true,
-			CleanupEnabled:    true,
-			RepairEnabled:     true,
-			RetentionOptions:  &validRetentionOpts,
-			IndexOptions:      &validIndexOpts,
+			BootstrapEnabled:    true,
+			FlushEnabled:        true,
+			WritesToCommitLog:   true,
+			CleanupEnabled:      true,
+			RepairEnabled:       true,
+			RetentionOptions:    &validRetentionOpts,
+			IndexOptions:        &validIndexOpts,
+			DataFileCompression: nsproto.DataFileCompression_SNAPPY,
 		},
 	}
 
@@ -280,6 +281,7 @@ func assertEqualMetadata(t *testing.T, name string, expected nsproto.NamespaceOp
 	require.Equal(t, expected.WritesToCommitLog, opts.WritesToCommitLog())
 	require.Equal(t, expected.CleanupEnabled, opts.CleanupEnabled())
 	require.Equal(t, expected.RepairEnabled, opts.RepairEnabled())
+	require.Equal(t, int(expected.DataFileCompression), int(opts.DataFileCompression()))
 	expectedSchemaReg, err := namespace.LoadSchemaHistory(expected.SchemaOptions)
 	require.NoError(t, err)
 	require.NotNil(t, expectedSchemaReg)
@@ -284,6 +284,34 @@ func (mr *MockOptionsMockRecorder) ColdWritesEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdWritesEnabled", reflect.TypeOf((*MockOptions)(nil).ColdWritesEnabled))
 }
 
+// SetDataFileCompression mocks base method
+func (m *MockOptions) SetDataFileCompression(value DataFileCompression) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDataFileCompression", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetDataFileCompression indicates an expected call of SetDataFileCompression
+func (mr *MockOptionsMockRecorder) SetDataFileCompression(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDataFileCompression", reflect.TypeOf((*MockOptions)(nil).SetDataFileCompression), value)
+}
+
+// DataFileCompression mocks base method
+func (m *MockOptions) DataFileCompression() DataFileCompression {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataFileCompression")
+	ret0, _ := ret[0].(DataFileCompression)
+	return ret0
+}
+
+// DataFileCompression indicates an expected call of DataFileCompression
+func (mr *MockOptionsMockRecorder) DataFileCompression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataFileCompression", reflect.TypeOf((*MockOptions)(nil).DataFileCompression))
+}
+
 // SetRetentionOptions mocks base method
 func (m *MockOptions) SetRetentionOptions(value retention.Options) Options {
 	m.ctrl.T.Helper()
@@ -47,6 +47,9 @@ const (
 
 	// Namespace with cold writes disabled by default.
 	defaultColdWritesEnabled = false
+
+	// Namespace with data file compression disabled by default.
+	defaultDataFileCompression = DataFileCompressionNone
 )
 
 var (
@@ -63,6 +66,7 @@ type options struct {
 	cleanupEnabled    bool
 	repairEnabled     bool
 	coldWritesEnabled bool
+	dataFileCompress  DataFileCompression
 	retentionOpts     retention.Options
 	indexOpts         IndexOptions
 	schemaHis         SchemaHistory
@@ -83,6 +87,7 @@ func NewOptions() Options {
 		cleanupEnabled:    defaultCleanupEnabled,
 		repairEnabled:     defaultRepairEnabled,
 		coldWritesEnabled: defaultColdWritesEnabled,
+		dataFileCompress:  defaultDataFileCompression,
 		retentionOpts:     retention.NewOptions(),
 		indexOpts:         NewIndexOptions(),
 		schemaHis:         NewSchemaHistory(),
@@ -122,6 +127,7 @@ func (o *options) Equal(value Options) bool {
 		o.cleanupEnabled == value.CleanupEnabled() &&
 		o.repairEnabled == value.RepairEnabled() &&
 		o.coldWritesEnabled == value.ColdWritesEnabled() &&
+		o.dataFileCompress == value.DataFileCompression() &&
 		o.retentionOpts.Equal(value.RetentionOptions()) &&
 		o.indexOpts.Equal(value.IndexOptions()) &&
 		o.schemaHis.Equal(value.SchemaHistory())
@@ -197,6 +203,16 @@ func (o *options) ColdWritesEnabled() bool {
 	return o.coldWritesEnabled
 }
 
+func (o *options) SetDataFileCompression(value DataFileCompression) Options {
+	opts := *o
+	opts.dataFileCompress = value
+	return &opts
+}
+
+func (o *options) DataFileCompression() DataFileCompression {
+	return o.dataFileCompress
+}
+
 func (o *options) SetRetentionOptions(value retention.Options) Options {
 	opts := *o
 	opts.retentionOpts = value
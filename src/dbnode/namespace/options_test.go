@@ -51,6 +51,16 @@ func TestOptionsEqualsIndexOpts(t *testing.T) {
 	require.False(t, o2.Equal(o1))
 }
 
+func TestOptionsEqualsDataFileCompression(t *testing.T) {
+	o1 := NewOptions()
+	require.Equal(t, DataFileCompressionNone, o1.DataFileCompression())
+	o2 := o1.SetDataFileCompression(DataFileCompressionSnappy)
+	require.Equal(t, DataFileCompressionSnappy, o2.DataFileCompression())
+	require.True(t, o2.Equal(o2))
+	require.False(t, o1.Equal(o2))
+	require.False(t, o2.Equal(o1))
+}
+
 func TestOptionsEqualsSchema(t *testing.T) {
 	o1 := NewOptions()
 	s1, err := LoadSchemaHistory(testSchemaOptions)
@@ -80,6 +80,14 @@ type Options interface {
 	// ColdWritesEnabled returns whether cold writes are enabled for this namespace.
 	ColdWritesEnabled() bool
 
+	// SetDataFileCompression sets the compression used for the data files
+	// of filesets flushed for this namespace.
+	SetDataFileCompression(value DataFileCompression) Options
+
+	// DataFileCompression returns the compression used for the data files
+	// of filesets flushed for this namespace.
+	DataFileCompression() DataFileCompression
+
 	// SetRetentionOptions sets the retention options for this namespace
 	SetRetentionOptions(value retention.Options) Options
 
@@ -235,6 +235,12 @@ func RegisterHandlers(mux *http.ServeMux, service interface{}, opts ServerOption
 	return nil
 }
 
+// WriteError writes an HTTP JSON error response, the status code is derived
+// from the error.
+func WriteError(w http.ResponseWriter, err error) {
+	writeError(w, err)
+}
+
 func writeError(w http.ResponseWriter, errValue interface{}) {
 	result := respErrorResult{respError{}}
 	if value, ok := errValue.(error); ok {
@@ -0,0 +1,224 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/network/server/httpjson"
+	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/x/context"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+)
+
+const (
+	// IndexSearchURL is the path of the index search debug endpoint.
+	IndexSearchURL = "/debug/index/search"
+
+	queryParam = "query"
+	startParam = "start"
+	endParam   = "end"
+	limitParam = "limit"
+
+	defaultIndexSearchLimit = 100
+)
+
+var (
+	errIndexSearchRequestMustBeGet = xerrors.NewInvalidParamsError(
+		errors.New("index search request must be GET"))
+	errIndexSearchNamespaceRequired = xerrors.NewInvalidParamsError(
+		fmt.Errorf("index search request must specify %s", namespaceParam))
+	errIndexSearchQueryRequired = xerrors.NewInvalidParamsError(
+		fmt.Errorf("index search request must specify %s", queryParam))
+	errIndexSearchDatabaseNotSet = httpjson.NewError(
+		errors.New("database is not yet initialized"), http.StatusServiceUnavailable)
+)
+
+// IndexSearchResponse is the response of the index search endpoint.
+type IndexSearchResponse struct {
+	Results     []IndexSearchResult `json:"results"`
+	Exhaustive  bool                `json:"exhaustive"`
+	DocsMatched int                 `json:"docsMatched"`
+}
+
+// IndexSearchResult is a series matched by an index search.
+type IndexSearchResult struct {
+	ID   string           `json:"id"`
+	Tags []IndexSearchTag `json:"tags"`
+}
+
+// IndexSearchTag is a tag of a series matched by an index search.
+type IndexSearchTag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// IndexSearchHandler serves the IDs and tags of the series of a namespace
+// matched by a Lucene-style query string, see idx.ParseQuery for the syntax.
+type IndexSearchHandler struct {
+	sync.RWMutex
+	db    storage.Database
+	nowFn func() time.Time
+}
+
+// NewIndexSearchHandler returns a new index search handler, the database
+// must be set with SetDatabase once it has been constructed.
+func NewIndexSearchHandler() *IndexSearchHandler {
+	return &IndexSearchHandler{nowFn: time.Now}
+}
+
+// SetDatabase sets the database to search the index of.
+func (h *IndexSearchHandler) SetDatabase(db storage.Database) {
+	h.Lock()
+	h.db = db
+	h.Unlock()
+}
+
+func (h *IndexSearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if strings.ToUpper(r.Method) != http.MethodGet {
+		httpjson.WriteError(w, errIndexSearchRequestMustBeGet)
+		return
+	}
+
+	h.RLock()
+	db := h.db
+	h.RUnlock()
+	if db == nil {
+		httpjson.WriteError(w, errIndexSearchDatabaseNotSet)
+		return
+	}
+
+	namespace, query, opts, err := h.parseRequest(r)
+	if err != nil {
+		httpjson.WriteError(w, xerrors.NewInvalidParamsError(err))
+		return
+	}
+
+	ctx := context.NewContext()
+	defer ctx.BlockingClose()
+
+	result, err := db.QueryIDs(ctx, ident.StringID(namespace), query, opts)
+	if err != nil {
+		httpjson.WriteError(w, err)
+		return
+	}
+
+	resp := IndexSearchResponse{
+		Results:     make([]IndexSearchResult, 0, result.Results.Size()),
+		Exhaustive:  result.Exhaustive,
+		DocsMatched: result.Results.TotalDocsCount(),
+	}
+	for _, entry := range result.Results.Map().Iter() {
+		tags := entry.Value()
+		elem := IndexSearchResult{
+			ID:   entry.Key().String(),
+			Tags: make([]IndexSearchTag, 0, tags.Remaining()),
+		}
+		for tags.Next() {
+			tag := tags.Current()
+			elem.Tags = append(elem.Tags, IndexSearchTag{
+				Name:  tag.Name.String(),
+				Value: tag.Value.String(),
+			})
+		}
+		if err := tags.Err(); err != nil {
+			httpjson.WriteError(w, err)
+			return
+		}
+		resp.Results = append(resp.Results, elem)
+	}
+
+	buff := bytes.NewBuffer(nil)
+	if err := json.NewEncoder(buff).Encode(resp); err != nil {
+		httpjson.WriteError(w, fmt.Errorf("failed to encode response body: %v", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(buff.Bytes())
+}
+
+func (h *IndexSearchHandler) parseRequest(
+	r *http.Request,
+) (string, index.Query, index.QueryOptions, error) {
+	values := r.URL.Query()
+	namespace := values.Get(namespaceParam)
+	if namespace == "" {
+		return "", index.Query{}, index.QueryOptions{}, errIndexSearchNamespaceRequired
+	}
+
+	str := values.Get(queryParam)
+	if str == "" {
+		return "", index.Query{}, index.QueryOptions{}, errIndexSearchQueryRequired
+	}
+	q, err := idx.ParseQuery(str)
+	if err != nil {
+		return "", index.Query{}, index.QueryOptions{},
+			fmt.Errorf("could not parse %s: %v", queryParam, err)
+	}
+
+	// Search all index blocks within retention by default.
+	opts := index.QueryOptions{
+		StartInclusive: time.Unix(0, 0),
+		EndExclusive:   h.nowFn(),
+		Limit:          defaultIndexSearchLimit,
+	}
+	if str := values.Get(startParam); str != "" {
+		if opts.StartInclusive, err = parseUnixSeconds(str); err != nil {
+			return "", index.Query{}, index.QueryOptions{},
+				fmt.Errorf("could not parse %s: %v", startParam, err)
+		}
+	}
+	if str := values.Get(endParam); str != "" {
+		if opts.EndExclusive, err = parseUnixSeconds(str); err != nil {
+			return "", index.Query{}, index.QueryOptions{},
+				fmt.Errorf("could not parse %s: %v", endParam, err)
+		}
+	}
+	if str := values.Get(limitParam); str != "" {
+		if opts.Limit, err = strconv.Atoi(str); err != nil {
+			return "", index.Query{}, index.QueryOptions{},
+				fmt.Errorf("could not parse %s: %v", limitParam, err)
+		}
+	}
+
+	return namespace, index.Query{Query: q}, opts, nil
+}
+
+func parseUnixSeconds(str string) (time.Time, error) {
+	secs, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(secs, 0), nil
+}
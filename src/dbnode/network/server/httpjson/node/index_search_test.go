@@ -0,0 +1,122 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexSearchHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	results := index.NewQueryResults(ident.StringID("metrics"),
+		index.QueryResultsOptions{}, index.NewOptions())
+	_, err := results.AddDocuments([]doc.Document{{
+		ID: []byte("foo"),
+		Fields: []doc.Field{
+			{Name: []byte("service"), Value: []byte("api")},
+		},
+	}})
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	db := storage.NewMockDatabase(ctrl)
+	db.EXPECT().
+		QueryIDs(gomock.Any(), ident.NewIDMatcher("metrics"), gomock.Any(), index.QueryOptions{
+			StartInclusive: now.Add(-time.Hour),
+			EndExclusive:   now,
+			Limit:          10,
+		}).
+		DoAndReturn(func(_, _ interface{}, q index.Query, _ index.QueryOptions) (index.QueryResult, error) {
+			expected := idx.NewConjunctionQuery(
+				idx.NewTermQuery([]byte("service"), []byte("api")),
+				idx.NewNegationQuery(idx.NewTermQuery([]byte("env"), []byte("dev"))),
+			)
+			require.True(t, expected.Equal(q.Query))
+			return index.QueryResult{Results: results, Exhaustive: true}, nil
+		})
+
+	h := NewIndexSearchHandler()
+	h.SetDatabase(db)
+
+	req := httptest.NewRequest(http.MethodGet, IndexSearchURL, nil)
+	values := req.URL.Query()
+	values.Set("namespace", "metrics")
+	values.Set("query", "service:api -env:dev")
+	values.Set("limit", "10")
+	values.Set("start", formatUnixSeconds(now.Add(-time.Hour)))
+	values.Set("end", formatUnixSeconds(now))
+	req.URL.RawQuery = values.Encode()
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp IndexSearchResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Equal(t, IndexSearchResponse{
+		Results: []IndexSearchResult{{
+			ID:   "foo",
+			Tags: []IndexSearchTag{{Name: "service", Value: "api"}},
+		}},
+		Exhaustive:  true,
+		DocsMatched: 1,
+	}, resp)
+}
+
+func TestIndexSearchHandlerInvalidRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewIndexSearchHandler()
+	h.SetDatabase(storage.NewMockDatabase(ctrl))
+
+	for _, rawQuery := range []string{
+		"query=service:api",
+		"namespace=metrics",
+		"namespace=metrics&query=service:(api",
+		"namespace=metrics&query=service:api&start=foo",
+		"namespace=metrics&query=service:api&limit=foo",
+	} {
+		req := httptest.NewRequest(http.MethodGet, IndexSearchURL+"?"+rawQuery, nil)
+		recorder := httptest.NewRecorder()
+		h.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusBadRequest, recorder.Code, rawQuery)
+	}
+}
+
+func formatUnixSeconds(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/network/server/httpjson"
+	"github.com/m3db/m3/src/dbnode/storage"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
+	xerrors "github.com/m3db/m3/src/x/errors"
+)
+
+const (
+	// RepairStatusURL is the path of the repair status endpoint.
+	RepairStatusURL = "/repair/status"
+
+	namespaceParam = "namespace"
+)
+
+var (
+	errRepairStatusRequestMustBeGet = xerrors.NewInvalidParamsError(
+		errors.New("repair status request must be GET"))
+	errRepairStatusDatabaseNotSet = httpjson.NewError(
+		errors.New("database is not yet initialized"), http.StatusServiceUnavailable)
+)
+
+// RepairStatusResponse is the response of the repair status endpoint.
+type RepairStatusResponse struct {
+	Namespaces []repair.NamespaceRepairStatus `json:"namespaces"`
+}
+
+// RepairStatusHandler serves the repair status and progress of each
+// namespace of the database, optionally filtered by namespace.
+type RepairStatusHandler struct {
+	sync.RWMutex
+	db storage.Database
+}
+
+// NewRepairStatusHandler returns a new repair status handler, the database
+// must be set with SetDatabase once it has been constructed.
+func NewRepairStatusHandler() *RepairStatusHandler {
+	return &RepairStatusHandler{}
+}
+
+// SetDatabase sets the database to serve the repair status of.
+func (h *RepairStatusHandler) SetDatabase(db storage.Database) {
+	h.Lock()
+	h.db = db
+	h.Unlock()
+}
+
+func (h *RepairStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if strings.ToUpper(r.Method) != http.MethodGet {
+		httpjson.WriteError(w, errRepairStatusRequestMustBeGet)
+		return
+	}
+
+	h.RLock()
+	db := h.db
+	h.RUnlock()
+	if db == nil {
+		httpjson.WriteError(w, errRepairStatusDatabaseNotSet)
+		return
+	}
+
+	var (
+		namespace = r.URL.Query().Get(namespaceParam)
+		resp      = RepairStatusResponse{
+			Namespaces: make([]repair.NamespaceRepairStatus, 0),
+		}
+	)
+	for _, status := range db.RepairStatus() {
+		if namespace != "" && status.Namespace != namespace {
+			continue
+		}
+		resp.Namespaces = append(resp.Namespaces, status)
+	}
+
+	buff := bytes.NewBuffer(nil)
+	if err := json.NewEncoder(buff).Encode(resp); err != nil {
+		httpjson.WriteError(w, fmt.Errorf("failed to encode response body: %v", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(buff.Bytes())
+}
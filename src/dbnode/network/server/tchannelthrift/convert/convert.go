@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/dbnode/x/xpool"
@@ -192,6 +193,9 @@ func ToRPCError(err error) *rpc.Error {
 	if xerrors.IsInvalidParams(err) {
 		return tterrors.NewBadRequestError(err)
 	}
+	if dberrors.IsRateLimitExceededError(err) {
+		return tterrors.NewResourceExhaustedError(err)
+	}
 	return tterrors.NewInternalError(err)
 }
 
@@ -219,12 +223,16 @@ func FromRPCFetchTaggedRequest(
 	}
 
 	opts := index.QueryOptions{
-		StartInclusive: start,
-		EndExclusive:   end,
+		StartInclusive:    start,
+		EndExclusive:      end,
+		RequireExhaustive: req.RequireExhaustive,
 	}
 	if l := req.Limit; l != nil {
 		opts.Limit = int(*l)
 	}
+	if l := req.DocsLimit; l != nil {
+		opts.DocsLimit = int(*l)
+	}
 
 	q, err := idx.Unmarshal(req.Query)
 	if err != nil {
@@ -269,16 +277,75 @@ func ToRPCFetchTaggedRequest(
 		RangeEnd:   rangeEnd,
 		FetchData:  fetchData,
 		Query:      query,
+
+		RequireExhaustive: opts.RequireExhaustive,
 	}
 
 	if opts.Limit > 0 {
 		l := int64(opts.Limit)
 		request.Limit = &l
 	}
+	if opts.DocsLimit > 0 {
+		l := int64(opts.DocsLimit)
+		request.DocsLimit = &l
+	}
 
 	return request, nil
 }
 
+// FromRPCDeleteTaggedRequest converts the rpc request type for DeleteTaggedRequest into corresponding Go API types.
+func FromRPCDeleteTaggedRequest(
+	req *rpc.DeleteTaggedRequest,
+) (ident.ID, index.Query, time.Time, time.Time, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, req.RangeTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, timeZero, timeZero, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, req.RangeTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, timeZero, timeZero, rangeEndErr
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, timeZero, timeZero, err
+	}
+
+	ns := ident.StringID(string(req.NameSpace))
+	return ns, index.Query{Query: q}, start, end, nil
+}
+
+// ToRPCDeleteTaggedRequest converts the Go `client/` types into rpc request type for DeleteTaggedRequest.
+func ToRPCDeleteTaggedRequest(
+	ns ident.ID,
+	q index.Query,
+	start, end time.Time,
+) (rpc.DeleteTaggedRequest, error) {
+	rangeStart, tsErr := ToValue(start, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.DeleteTaggedRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(end, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.DeleteTaggedRequest{}, tsErr
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.DeleteTaggedRequest{}, queryErr
+	}
+
+	return rpc.DeleteTaggedRequest{
+		NameSpace:     ns.Bytes(),
+		Query:         query,
+		RangeStart:    rangeStart,
+		RangeEnd:      rangeEnd,
+		RangeTimeType: fetchTaggedTimeType,
+	}, nil
+}
+
 // FromRPCAggregateQueryRequest converts the rpc request type for AggregateRawQueryRequest into corresponding Go API types.
 func FromRPCAggregateQueryRequest(
 	req *rpc.AggregateQueryRequest,
@@ -103,18 +103,25 @@ func conjunctionQueryATestCase(t *testing.T) (idx.Query, []byte) {
 func TestConvertFetchTaggedRequest(t *testing.T) {
 	ns := ident.StringID("abc")
 	opts := index.QueryOptions{
-		StartInclusive: time.Now().Add(-900 * time.Hour),
-		EndExclusive:   time.Now(),
-		Limit:          10,
+		StartInclusive:    time.Now().Add(-900 * time.Hour),
+		EndExclusive:      time.Now(),
+		Limit:             10,
+		DocsLimit:         100,
+		RequireExhaustive: true,
 	}
 	fetchData := true
-	var limit int64 = 10
+	var (
+		limit     int64 = 10
+		docsLimit int64 = 100
+	)
 	requestSkeleton := &rpc.FetchTaggedRequest{
-		NameSpace:  ns.Bytes(),
-		RangeStart: mustToRpcTime(t, opts.StartInclusive),
-		RangeEnd:   mustToRpcTime(t, opts.EndExclusive),
-		FetchData:  fetchData,
-		Limit:      &limit,
+		NameSpace:         ns.Bytes(),
+		RangeStart:        mustToRpcTime(t, opts.StartInclusive),
+		RangeEnd:          mustToRpcTime(t, opts.EndExclusive),
+		FetchData:         fetchData,
+		Limit:             &limit,
+		DocsLimit:         &docsLimit,
+		RequireExhaustive: true,
 	}
 	requireEqual := func(a, b interface{}) {
 		d := cmp.Diff(a, b)
@@ -165,6 +172,56 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 	}
 }
 
+func TestConvertDeleteTaggedRequest(t *testing.T) {
+	var (
+		ns    = ident.StringID("abc")
+		start = time.Now().Add(-900 * time.Hour)
+		end   = time.Now()
+	)
+	requestSkeleton := &rpc.DeleteTaggedRequest{
+		NameSpace:     ns.Bytes(),
+		RangeStart:    mustToRpcTime(t, start),
+		RangeEnd:      mustToRpcTime(t, end),
+		RangeTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+	}
+	requireEqual := func(a, b interface{}) {
+		d := cmp.Diff(a, b)
+		assert.Equal(t, "", d, d)
+	}
+
+	type inputFn func(t *testing.T) (idx.Query, []byte)
+
+	testCases := []struct {
+		name string
+		fn   inputFn
+	}{
+		{"Term Query", termQueryTestCase},
+		{"Regexp Query", regexpQueryTestCase},
+		{"Conjunction Query A", conjunctionQueryATestCase},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Forward %s", tc.name), func(t *testing.T) {
+			q, rpcQ := tc.fn(t)
+			expectedReq := &(*requestSkeleton)
+			expectedReq.Query = rpcQ
+			observedReq, err := convert.ToRPCDeleteTaggedRequest(ns, index.Query{Query: q}, start, end)
+			require.NoError(t, err)
+			requireEqual(expectedReq, &observedReq)
+		})
+		t.Run(fmt.Sprintf("Backward %s", tc.name), func(t *testing.T) {
+			expectedQuery, rpcQ := tc.fn(t)
+			rpcRequest := &(*requestSkeleton)
+			rpcRequest.Query = rpcQ
+			id, observedQuery, observedStart, observedEnd, err := convert.FromRPCDeleteTaggedRequest(rpcRequest)
+			require.NoError(t, err)
+			require.Equal(t, ns.String(), id.String())
+			require.True(t, index.NewQueryMatcher(index.Query{Query: expectedQuery}).Matches(observedQuery))
+			require.True(t, start.Equal(observedStart))
+			require.True(t, end.Equal(observedEnd))
+		})
+	}
+}
+
 func TestConvertAggregateRawQueryRequest(t *testing.T) {
 	ns := ident.StringID("abc")
 	opts := index.AggregationOptions{
@@ -43,6 +43,12 @@ func IsBadRequestError(err *rpc.Error) bool {
 	return err != nil && err.Type == rpc.ErrorType_BAD_REQUEST
 }
 
+// IsResourceExhaustedError returns whether the error is a resource exhausted
+// error, which is returned when a rate limit is exceeded and is retryable
+func IsResourceExhaustedError(err *rpc.Error) bool {
+	return err != nil && err.Type == rpc.ErrorType_RESOURCE_EXHAUSTED
+}
+
 // NewInternalError creates a new internal error
 func NewInternalError(err error) *rpc.Error {
 	return newError(rpc.ErrorType_INTERNAL_ERROR, err)
@@ -53,6 +59,11 @@ func NewBadRequestError(err error) *rpc.Error {
 	return newError(rpc.ErrorType_BAD_REQUEST, err)
 }
 
+// NewResourceExhaustedError creates a new resource exhausted error
+func NewResourceExhaustedError(err error) *rpc.Error {
+	return newError(rpc.ErrorType_RESOURCE_EXHAUSTED, err)
+}
+
 // NewWriteBatchRawError creates a new write batch error
 func NewWriteBatchRawError(index int, err error) *rpc.WriteBatchRawError {
 	batchErr := rpc.NewWriteBatchRawError()
@@ -68,3 +79,12 @@ func NewBadRequestWriteBatchRawError(index int, err error) *rpc.WriteBatchRawErr
 	batchErr.Err = NewBadRequestError(err)
 	return batchErr
 }
+
+// NewResourceExhaustedWriteBatchRawError creates a new resource exhausted
+// write batch error
+func NewResourceExhaustedWriteBatchRawError(index int, err error) *rpc.WriteBatchRawError {
+	batchErr := rpc.NewWriteBatchRawError()
+	batchErr.Index = int64(index)
+	batchErr.Err = NewResourceExhaustedError(err)
+	return batchErr
+}
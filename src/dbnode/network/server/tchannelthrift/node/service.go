@@ -35,6 +35,7 @@ import (
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -109,6 +110,7 @@ type serviceMetrics struct {
 	fetchBlocksMetadata     instrument.MethodMetrics
 	repair                  instrument.MethodMetrics
 	truncate                instrument.MethodMetrics
+	deleteTagged            instrument.MethodMetrics
 	fetchBatchRawRPCS       tally.Counter
 	fetchBatchRaw           instrument.BatchMethodMetrics
 	writeBatchRawRPCs       tally.Counter
@@ -129,6 +131,7 @@ func newServiceMetrics(scope tally.Scope, samplingRate float64) serviceMetrics {
 		fetchBlocksMetadata:     instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		repair:                  instrument.NewMethodMetrics(scope, "repair", samplingRate),
 		truncate:                instrument.NewMethodMetrics(scope, "truncate", samplingRate),
+		deleteTagged:            instrument.NewMethodMetrics(scope, "deleteTagged", samplingRate),
 		fetchBatchRawRPCS:       scope.Counter("fetchBatchRaw-rpcs"),
 		fetchBatchRaw:           instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", samplingRate),
 		writeBatchRawRPCs:       scope.Counter("writeBatchRaw-rpcs"),
@@ -452,6 +455,11 @@ func (s *service) query(ctx context.Context, db storage.Database, req *rpc.Query
 	if l := req.Limit; l != nil {
 		opts.Limit = int(*l)
 	}
+	fetchData := true
+	if req.NoData != nil && *req.NoData {
+		fetchData = false
+	}
+	opts.SeriesDataFetched = fetchData
 	queryResult, err := db.QueryIDs(ctx, nsID, index.Query{Query: q}, opts)
 	if err != nil {
 		return nil, convert.ToRPCError(err)
@@ -461,10 +469,6 @@ func (s *service) query(ctx context.Context, db storage.Database, req *rpc.Query
 		Results:    make([]*rpc.QueryResultElement, 0, queryResult.Results.Map().Len()),
 		Exhaustive: queryResult.Exhaustive,
 	}
-	fetchData := true
-	if req.NoData != nil && *req.NoData {
-		fetchData = false
-	}
 	for _, entry := range queryResult.Results.Map().Iter() {
 		tags := entry.Value()
 		elem := &rpc.QueryResultElement{
@@ -618,6 +622,7 @@ func (s *service) fetchTagged(ctx context.Context, db storage.Database, req *rpc
 		return nil, tterrors.NewBadRequestError(err)
 	}
 
+	opts.SeriesDataFetched = fetchData
 	queryResult, err := db.QueryIDs(ctx, ns, query, opts)
 	if err != nil {
 		s.metrics.fetchTagged.ReportError(s.nowFn().Sub(callStart))
@@ -625,9 +630,11 @@ func (s *service) fetchTagged(ctx context.Context, db storage.Database, req *rpc
 	}
 
 	results := queryResult.Results
+	docsMatched := int64(results.TotalDocsCount())
 	response := &rpc.FetchTaggedResult_{
-		Exhaustive: queryResult.Exhaustive,
-		Elements:   make([]*rpc.FetchTaggedIDResult_, 0, results.Size()),
+		Exhaustive:  queryResult.Exhaustive,
+		Elements:    make([]*rpc.FetchTaggedIDResult_, 0, results.Size()),
+		DocsMatched: &docsMatched,
 	}
 	nsID := results.Namespace()
 	nsIDBytes := nsID.Bytes()
@@ -1777,6 +1784,35 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) DeleteTagged(tctx thrift.Context, req *rpc.DeleteTaggedRequest) (*rpc.DeleteTaggedResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	ns, query, start, end, err := convert.FromRPCDeleteTaggedRequest(req)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	deleted, err := db.DeleteTagged(ctx, ns, query, start, end)
+	if err != nil {
+		s.metrics.deleteTagged.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteTaggedResult_()
+	res.NumSeries = deleted
+
+	s.metrics.deleteTagged.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
@@ -2288,6 +2324,14 @@ func (r *writeBatchPooledReq) HandleError(index int, err error) {
 		return
 	}
 
+	if dberrors.IsRateLimitExceededError(err) {
+		r.retryableErrors++
+		r.errs = append(
+			r.errs,
+			tterrors.NewResourceExhaustedWriteBatchRawError(index, err))
+		return
+	}
+
 	r.retryableErrors++
 	r.errs = append(
 		r.errs,
@@ -299,9 +299,10 @@ func TestServiceQuery(t *testing.T) {
 		ident.NewIDMatcher(nsID),
 		index.NewQueryMatcher(qry),
 		index.QueryOptions{
-			StartInclusive: start,
-			EndExclusive:   end,
-			Limit:          10,
+			StartInclusive:    start,
+			EndExclusive:      end,
+			Limit:             10,
+			SeriesDataFetched: true,
 		}).Return(index.QueryResult{Results: resMap, Exhaustive: true}, nil)
 
 	limit := int64(10)
@@ -454,9 +455,10 @@ func TestServiceQueryUnknownErr(t *testing.T) {
 		ident.NewIDMatcher(nsID),
 		index.NewQueryMatcher(qry),
 		index.QueryOptions{
-			StartInclusive: start,
-			EndExclusive:   end,
-			Limit:          10,
+			StartInclusive:    start,
+			EndExclusive:      end,
+			Limit:             10,
+			SeriesDataFetched: true,
 		}).Return(index.QueryResult{}, unknownErr)
 
 	limit := int64(10)
@@ -1555,9 +1557,10 @@ func TestServiceFetchTagged(t *testing.T) {
 		ident.NewIDMatcher(nsID),
 		index.NewQueryMatcher(qry),
 		index.QueryOptions{
-			StartInclusive: start,
-			EndExclusive:   end,
-			Limit:          10,
+			StartInclusive:    start,
+			EndExclusive:      end,
+			Limit:             10,
+			SeriesDataFetched: true,
 		}).Return(index.QueryResult{Results: resMap, Exhaustive: true}, nil)
 
 	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
@@ -2768,6 +2771,46 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID    = "metrics"
+		start   = time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+		end     = start.Add(time.Hour)
+		deleted = int64(12)
+	)
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+
+	mockDB.EXPECT().DeleteTagged(gomock.Any(), ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(index.Query{Query: req}), start, end).
+		Return(deleted, nil)
+
+	r, err := service.DeleteTagged(tctx, &rpc.DeleteTaggedRequest{
+		NameSpace:  []byte(nsID),
+		Query:      data,
+		RangeStart: start.Unix(),
+		RangeEnd:   end.Unix(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, deleted, r.NumSeries)
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
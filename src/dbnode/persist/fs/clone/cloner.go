@@ -71,7 +71,8 @@ func (c *cloner) Clone(src FileSetID, dest FileSetID, destBlocksize time.Duratio
 		return fmt.Errorf("unable to create fileset writer: %v", err)
 	}
 	writerOpts := fs.DataWriterOpenOptions{
-		BlockSize: destBlocksize,
+		BlockSize:   destBlocksize,
+		Compression: reader.Status().Compression,
 		Identifier: fs.FileSetFileIdentifier{
 			Namespace:  ident.StringID(dest.Namespace),
 			Shard:      dest.Shard,
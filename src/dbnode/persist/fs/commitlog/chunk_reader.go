@@ -22,9 +22,15 @@ package commitlog
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+
+	"github.com/golang/snappy"
 )
 
 const (
@@ -34,18 +40,33 @@ const (
 	checksumSizeEnd   = checksumSizeStart + chunkHeaderSizeLen
 	checksumDataStart = checksumSizeEnd
 	checksumDataEnd   = checksumDataStart + chunkHeaderChecksumDataLen
+
+	// The trailing bytes of a file scanned when determining whether a
+	// chunk header with a bad checksum is a torn tail of zero filled pages.
+	tornTailScanLen = 4096
+)
+
+var (
+	errCommitLogReaderChunkDataChecksumMismatch = errors.New("commit log reader encountered chunk data checksum mismatch")
 )
 
 type chunkReader struct {
-	fd        *os.File
-	buffer    *bufio.Reader
-	remaining int
-	charBuff  []byte
+	fd       *os.File
+	buffer   *bufio.Reader
+	header   []byte
+	chunk    []byte
+	decoded  []byte
+	data     []byte
+	offset   int64
+	tornTail bool
+	charBuff []byte
 }
 
 func newChunkReader(bufferLen int) *chunkReader {
 	return &chunkReader{
 		buffer:   bufio.NewReaderSize(nil, bufferLen),
+		header:   make([]byte, chunkHeaderLen),
+		chunk:    make([]byte, 0, bufferLen),
 		charBuff: make([]byte, 1),
 	}
 }
@@ -53,84 +74,141 @@ func newChunkReader(bufferLen int) *chunkReader {
 func (r *chunkReader) reset(fd *os.File) {
 	r.fd = fd
 	r.buffer.Reset(fd)
-	r.remaining = 0
+	r.data = nil
+	r.offset = 0
+	r.tornTail = false
+}
+
+// hasTornTail returns whether the reader stopped at an incomplete
+// or corrupt chunk at the end of the file, which is expected when
+// the process exited while a chunk was being written.
+func (r *chunkReader) hasTornTail() bool {
+	return r.tornTail
 }
 
 func (r *chunkReader) readHeader() error {
-	header, err := r.buffer.Peek(chunkHeaderLen)
+	if r.tornTail {
+		return io.EOF
+	}
+
+	chunkStart := r.offset
+	n, err := io.ReadFull(r.buffer, r.header)
+	r.offset += int64(n)
+	if err == io.ErrUnexpectedEOF {
+		// Partially written header at the end of the file.
+		r.tornTail = true
+		return io.EOF
+	}
 	if err != nil {
 		return err
 	}
 
-	size := endianness.Uint32(header[sizeStart:sizeEnd])
+	sizeAndFlags := endianness.Uint32(r.header[sizeStart:sizeEnd])
 	checksumSize := digest.
-		Buffer(header[checksumSizeStart:checksumSizeEnd]).
+		Buffer(r.header[checksumSizeStart:checksumSizeEnd]).
 		ReadDigest()
 	checksumData := digest.
-		Buffer(header[checksumDataStart:checksumDataEnd]).
+		Buffer(r.header[checksumDataStart:checksumDataEnd]).
 		ReadDigest()
 
 	// Verify size checksum
-	if digest.Checksum(header[sizeStart:sizeEnd]) != checksumSize {
+	if digest.Checksum(r.header[sizeStart:sizeEnd]) != checksumSize {
+		if r.isZeroFilledFrom(chunkStart) {
+			// Pages allocated but never written at the end of the file.
+			r.tornTail = true
+			return io.EOF
+		}
 		return errCommitLogReaderChunkSizeChecksumMismatch
 	}
 
-	// Discard the peeked header
-	if _, err := r.buffer.Discard(chunkHeaderLen); err != nil {
-		return err
+	size := int(sizeAndFlags & chunkSizeMask)
+	flags := chunkFlags(sizeAndFlags >> chunkSizeBits)
+
+	// Read and verify data
+	r.chunk = resizeBufferOrGrowIfNeeded(r.chunk, size)
+	n, err = io.ReadFull(r.buffer, r.chunk)
+	r.offset += int64(n)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		// Partially written chunk at the end of the file.
+		r.tornTail = true
+		return io.EOF
 	}
-
-	// Verify data checksum
-	data, err := r.buffer.Peek(int(size))
 	if err != nil {
 		return err
 	}
 
-	if digest.Checksum(data) != checksumData {
-		return errCommitLogReaderChunkSizeChecksumMismatch
+	if digest.Checksum(r.chunk) != checksumData {
+		if r.isLastChunk() {
+			// Partially persisted chunk at the end of the file.
+			r.tornTail = true
+			return io.EOF
+		}
+		return errCommitLogReaderChunkDataChecksumMismatch
 	}
 
-	// Set remaining data to be consumed
-	r.remaining = int(size)
+	switch flags {
+	case chunkFlagsNone:
+		r.data = r.chunk
+	case chunkFlagsSnappy:
+		r.decoded, err = snappy.Decode(r.decoded[:cap(r.decoded)], r.chunk)
+		if err != nil {
+			return err
+		}
+		r.data = r.decoded
+	default:
+		return fmt.Errorf("commit log reader encountered unknown chunk flags: %d", flags)
+	}
 
 	return nil
 }
 
+// isLastChunk returns whether the reader has consumed the entire file.
+func (r *chunkReader) isLastChunk() bool {
+	info, err := r.fd.Stat()
+	if err != nil {
+		return false
+	}
+	return r.offset >= info.Size()
+}
+
+// isZeroFilledFrom returns whether the file contains only zeroes from
+// the given offset to the end of the file.
+func (r *chunkReader) isZeroFilledFrom(offset int64) bool {
+	var (
+		buf  = make([]byte, tornTailScanLen)
+		zero = make([]byte, tornTailScanLen)
+	)
+	for {
+		n, err := r.fd.ReadAt(buf, offset)
+		if !bytes.Equal(buf[:n], zero[:n]) {
+			return false
+		}
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+		offset += int64(n)
+	}
+}
+
 func (r *chunkReader) Read(p []byte) (int, error) {
-	size := len(p)
 	read := 0
-	// Check if requesting for size larger than this chunk
-	if r.remaining < size {
-		// Copy any remaining
-		if r.remaining > 0 {
-			n, err := r.buffer.Read(p[:r.remaining])
-			r.remaining -= n
-			read += n
-			if err != nil {
+	for read < len(p) {
+		if len(r.data) == 0 {
+			if err := r.readHeader(); err != nil {
 				return read, err
 			}
+			continue
 		}
 
-		// Read next header
-		if err := r.readHeader(); err != nil {
-			return read, err
-		}
-
-		// Reset read target
-		p = p[read:]
-
-		// Perform consecutive read(s)
-		n, err := r.Read(p)
+		n := copy(p[read:], r.data)
+		r.data = r.data[n:]
 		read += n
-		return read, err
 	}
-
-	n, err := r.buffer.Read(p)
-	r.remaining -= n
-	read += n
-	return read, err
+	return read, nil
 }
-
 func (r *chunkReader) ReadByte() (c byte, err error) {
 	if _, err := r.Read(r.charBuff); err != nil {
 		return byte(0), err
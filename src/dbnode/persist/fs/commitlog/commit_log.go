@@ -63,10 +63,12 @@ type commitLogFailFn func(err error)
 
 // writeOrWriteBatch is a union type of write or writeBatch so that
 // we can handle both cases without having to allocate as slice of size
-// 1 to handle a single write.
+// 1 to handle a single write. If the tombstone is set the write is a
+// delete of the data of write.Series instead.
 type writeOrWriteBatch struct {
 	write      ts.Write
 	writeBatch ts.WriteBatch
+	tombstone  Tombstone
 }
 
 type commitLog struct {
@@ -89,6 +91,9 @@ type commitLog struct {
 	// it does not require the closedState lock to be acquired before
 	// being accessed.
 	closeErr chan error
+	// closeCh is closed when the commitlog is closed so that the
+	// background goroutines can exit without waiting for their next tick.
+	closeCh chan struct{}
 
 	writes       chan commitLogWrite
 	maxQueueSize int64
@@ -181,6 +186,7 @@ type commitLogMetrics struct {
 	closeErrors      tally.Counter
 	flushErrors      tally.Counter
 	flushDone        tally.Counter
+	syncSkipped      tally.Counter
 }
 
 type eventType int
@@ -191,6 +197,7 @@ const (
 	flushEventType
 	activeLogsEventType
 	rotateLogsEventType
+	syncEventType
 )
 
 type callbackFn func(callbackResult)
@@ -269,6 +276,7 @@ func NewCommitLog(opts Options) (CommitLog, error) {
 		},
 		maxQueueSize: int64(opts.BacklogQueueSize()),
 		closeErr:     make(chan error),
+		closeCh:      make(chan struct{}),
 		metrics: commitLogMetrics{
 			numWritesInQueue: scope.Gauge("writes.queued"),
 			queueLength:      scope.Gauge("writes.queue-length"),
@@ -279,6 +287,7 @@ func NewCommitLog(opts Options) (CommitLog, error) {
 			closeErrors:      scope.Counter("writes.close-errors"),
 			flushErrors:      scope.Counter("writes.flush-errors"),
 			flushDone:        scope.Counter("writes.flush-done"),
+			syncSkipped:      scope.Counter("writes.sync-skipped"),
 		},
 	}
 	// Setup backreferences for onFlush().
@@ -286,7 +295,7 @@ func NewCommitLog(opts Options) (CommitLog, error) {
 	commitLog.writerState.secondary.commitlog = commitLog
 
 	switch opts.Strategy() {
-	case StrategyWriteWait:
+	case StrategyWriteWait, StrategyGroupCommit:
 		commitLog.writeFn = commitLog.writeWait
 	default:
 		commitLog.writeFn = commitLog.writeBehind
@@ -324,6 +333,11 @@ func (l *commitLog) Open() error {
 		go l.flushEvery(flushInterval)
 	}
 
+	if l.opts.Strategy() == StrategyGroupCommit {
+		// Continually fsync the commit log to acknowledge pending writes
+		go l.syncEvery(l.opts.GroupCommitWindow())
+	}
+
 	return nil
 }
 
@@ -445,6 +459,39 @@ func (l *commitLog) flushEvery(interval time.Duration) {
 	}
 }
 
+func (l *commitLog) syncEvery(window time.Duration) {
+	// Periodically request an fsync of the underlying commit log writer so
+	// that all writes pending acknowledgement within the group commit
+	// window share a single fsync
+	ticker := time.NewTicker(window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.closeCh:
+			return
+		case <-ticker.C:
+		}
+
+		// Request a sync
+		l.closedState.RLock()
+		if l.closedState.closed {
+			l.closedState.RUnlock()
+			return
+		}
+
+		select {
+		case l.writes <- commitLogWrite{eventType: syncEventType}:
+		default:
+			// The queue is full, rather than blocking while holding the
+			// closed state lock (which would block Close) request the sync
+			// on the next tick, by which time the queue has drained.
+			l.metrics.syncSkipped.Inc(1)
+		}
+		l.closedState.RUnlock()
+	}
+}
+
 func (l *commitLog) write() {
 	// We use these to make the batch and non-batched write paths the same
 	// by turning non-batched writes into a batch of size one while avoiding
@@ -458,6 +505,15 @@ func (l *commitLog) write() {
 			continue
 		}
 
+		if write.eventType == syncEventType {
+			// Only fsync if there are writes waiting to be acknowledged, the
+			// flush callback fired by the sync will acknowledge them
+			if len(l.writerState.primary.pendingFlushFns) > 0 {
+				l.writerState.primary.writer.Flush(true)
+			}
+			continue
+		}
+
 		if write.eventType == activeLogsEventType {
 			write.callbackFn(callbackResult{
 				eventType: write.eventType,
@@ -499,6 +555,18 @@ func (l *commitLog) write() {
 			continue
 		}
 
+		if !write.write.tombstone.Range.IsEmpty() {
+			err := l.writerState.primary.writer.WriteTombstone(
+				write.write.write.Series, write.write.tombstone)
+			if err != nil {
+				l.handleWriteErr(err)
+			} else {
+				l.metrics.success.Inc(1)
+			}
+			atomic.AddInt64(&l.numWritesInQueue, -1)
+			continue
+		}
+
 		var (
 			numWritesSuccess int64
 			numDequeued      int
@@ -738,6 +806,19 @@ func (l *commitLog) WriteBatch(
 	})
 }
 
+func (l *commitLog) WriteTombstone(
+	ctx context.Context,
+	series ts.Series,
+	tombstone Tombstone,
+) error {
+	return l.writeFn(ctx, writeOrWriteBatch{
+		write: ts.Write{
+			Series: series,
+		},
+		tombstone: tombstone,
+	})
+}
+
 func (l *commitLog) writeWait(
 	ctx context.Context,
 	write writeOrWriteBatch,
@@ -850,6 +931,7 @@ func (l *commitLog) Close() error {
 	}
 
 	l.closedState.closed = true
+	close(l.closeCh)
 	close(l.writes)
 	l.closedState.Unlock()
 
@@ -106,6 +106,20 @@ func (mr *MockCommitLogMockRecorder) WriteBatch(ctx, writes interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteBatch", reflect.TypeOf((*MockCommitLog)(nil).WriteBatch), ctx, writes)
 }
 
+// WriteTombstone mocks base method
+func (m *MockCommitLog) WriteTombstone(ctx context.Context, series ts.Series, tombstone Tombstone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteTombstone", ctx, series, tombstone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteTombstone indicates an expected call of WriteTombstone
+func (mr *MockCommitLogMockRecorder) WriteTombstone(ctx, series, tombstone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteTombstone", reflect.TypeOf((*MockCommitLog)(nil).WriteTombstone), ctx, series, tombstone)
+}
+
 // Close mocks base method
 func (m *MockCommitLog) Close() error {
 	m.ctrl.T.Helper()
@@ -446,6 +460,62 @@ func (mr *MockOptionsMockRecorder) Strategy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Strategy", reflect.TypeOf((*MockOptions)(nil).Strategy))
 }
 
+// SetCompression mocks base method
+func (m *MockOptions) SetCompression(value CompressionType) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCompression", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetCompression indicates an expected call of SetCompression
+func (mr *MockOptionsMockRecorder) SetCompression(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCompression", reflect.TypeOf((*MockOptions)(nil).SetCompression), value)
+}
+
+// Compression mocks base method
+func (m *MockOptions) Compression() CompressionType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compression")
+	ret0, _ := ret[0].(CompressionType)
+	return ret0
+}
+
+// Compression indicates an expected call of Compression
+func (mr *MockOptionsMockRecorder) Compression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compression", reflect.TypeOf((*MockOptions)(nil).Compression))
+}
+
+// SetGroupCommitWindow mocks base method
+func (m *MockOptions) SetGroupCommitWindow(value time.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGroupCommitWindow", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetGroupCommitWindow indicates an expected call of SetGroupCommitWindow
+func (mr *MockOptionsMockRecorder) SetGroupCommitWindow(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGroupCommitWindow", reflect.TypeOf((*MockOptions)(nil).SetGroupCommitWindow), value)
+}
+
+// GroupCommitWindow mocks base method
+func (m *MockOptions) GroupCommitWindow() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupCommitWindow")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GroupCommitWindow indicates an expected call of GroupCommitWindow
+func (mr *MockOptionsMockRecorder) GroupCommitWindow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupCommitWindow", reflect.TypeOf((*MockOptions)(nil).GroupCommitWindow))
+}
+
 // SetFlushInterval mocks base method
 func (m *MockOptions) SetFlushInterval(value time.Duration) Options {
 	m.ctrl.T.Helper()
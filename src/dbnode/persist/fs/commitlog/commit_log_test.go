@@ -23,6 +23,7 @@ package commitlog
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -68,6 +69,7 @@ type overrides struct {
 	flushInterval    *time.Duration
 	backlogQueueSize *int
 	strategy         Strategy
+	compression      CompressionType
 }
 
 var testOpts = NewOptions().
@@ -108,7 +110,9 @@ func newTestOptions(
 		opts = opts.SetBacklogQueueSize(*overrides.backlogQueueSize)
 	}
 
-	opts = opts.SetStrategy(overrides.strategy)
+	opts = opts.
+		SetStrategy(overrides.strategy).
+		SetCompression(overrides.compression)
 
 	return opts, scope
 }
@@ -172,10 +176,11 @@ func snapshotCounterValue(
 }
 
 type mockCommitLogWriter struct {
-	openFn  func() (persist.CommitLogFile, error)
-	writeFn func(ts.Series, ts.Datapoint, xtime.Unit, ts.Annotation) error
-	flushFn func(sync bool) error
-	closeFn func() error
+	openFn           func() (persist.CommitLogFile, error)
+	writeFn          func(ts.Series, ts.Datapoint, xtime.Unit, ts.Annotation) error
+	writeTombstoneFn func(ts.Series, Tombstone) error
+	flushFn          func(sync bool) error
+	closeFn          func() error
 }
 
 func newMockCommitLogWriter() *mockCommitLogWriter {
@@ -186,6 +191,9 @@ func newMockCommitLogWriter() *mockCommitLogWriter {
 		writeFn: func(ts.Series, ts.Datapoint, xtime.Unit, ts.Annotation) error {
 			return nil
 		},
+		writeTombstoneFn: func(ts.Series, Tombstone) error {
+			return nil
+		},
 		flushFn: func(sync bool) error {
 			return nil
 		},
@@ -208,6 +216,13 @@ func (w *mockCommitLogWriter) Write(
 	return w.writeFn(series, datapoint, unit, annotation)
 }
 
+func (w *mockCommitLogWriter) WriteTombstone(
+	series ts.Series,
+	tombstone Tombstone,
+) error {
+	return w.writeTombstoneFn(series, tombstone)
+}
+
 func (w *mockCommitLogWriter) Flush(sync bool) error {
 	return w.flushFn(sync)
 }
@@ -373,6 +388,53 @@ func TestCommitLogWrite(t *testing.T) {
 	assertCommitLogWritesByIterating(t, commitLog, writes)
 }
 
+func TestCommitLogWriteTombstone(t *testing.T) {
+	opts, scope := newTestOptions(t, overrides{
+		strategy: StrategyWriteWait,
+	})
+	defer cleanup(t, opts)
+
+	commitLog := newTestCommitLog(t, opts)
+
+	series := testSeries(0, "foo.bar", ident.NewTags(ident.StringTag("name1", "val1")), 127)
+	writes := []testWrite{
+		{series, time.Now(), 123.456, xtime.Second, nil, nil},
+	}
+	writeCommitLogs(t, scope, commitLog, writes).Wait()
+
+	start := time.Now().Truncate(time.Second).Add(-time.Hour)
+	tombstone := Tombstone{
+		Range:       xtime.Range{Start: start, End: start.Add(time.Hour)},
+		FlushedOnly: true,
+	}
+	require.NoError(t, commitLog.WriteTombstone(context.NewContext(), series, tombstone))
+
+	require.NoError(t, commitLog.Close())
+
+	iter, corruptFiles, err := NewIterator(IteratorOpts{
+		CommitLogOptions:    opts,
+		FileFilterPredicate: ReadAllPredicate(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(corruptFiles))
+	defer iter.Close()
+
+	var entries []LogEntry
+	for iter.Next() {
+		entries = append(entries, iter.Current())
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, 2, len(entries))
+
+	require.True(t, entries[0].Tombstone.Range.IsEmpty())
+	writes[0].assert(t, entries[0].Series, entries[0].Datapoint,
+		entries[0].Unit, entries[0].Annotation)
+
+	require.Equal(t, series.ID.String(), entries[1].Series.ID.String())
+	require.True(t, tombstone.Range.Equal(entries[1].Tombstone.Range))
+	require.True(t, entries[1].Tombstone.FlushedOnly)
+}
+
 func TestReadCommitLogMissingMetadata(t *testing.T) {
 	readConc := 4
 	// Make sure we're not leaking goroutines
@@ -628,6 +690,200 @@ func TestCommitLogWriteBehind(t *testing.T) {
 	assertCommitLogWritesByIterating(t, commitLog, writes)
 }
 
+func TestCommitLogWriteGroupCommit(t *testing.T) {
+	// Disable the periodic flush so that only group commits acknowledge writes
+	flushInterval := time.Duration(0)
+	opts, scope := newTestOptions(t, overrides{
+		strategy:      StrategyGroupCommit,
+		flushInterval: &flushInterval,
+	})
+	defer cleanup(t, opts)
+
+	commitLog := newTestCommitLog(t, opts)
+
+	writes := []testWrite{
+		{testSeries(0, "foo.bar", testTags1, 127), time.Now(), 123.456, xtime.Millisecond, nil, nil},
+		{testSeries(1, "foo.baz", testTags2, 150), time.Now(), 456.789, xtime.Millisecond, nil, nil},
+		{testSeries(2, "foo.qux", testTags3, 291), time.Now(), 789.123, xtime.Millisecond, nil, nil},
+	}
+
+	// Call write sync and wait for the group commit to acknowledge the writes
+	writeCommitLogs(t, scope, commitLog, writes).Wait()
+
+	// Acknowledged writes must be readable before the commit log is closed
+	assertCommitLogWritesByIterating(t, commitLog, writes)
+
+	require.NoError(t, commitLog.Close())
+}
+
+func TestCommitLogGroupCommitCloseStopsSync(t *testing.T) {
+	// Make sure the group commit goroutine does not wait for its next tick
+	defer leaktest.CheckTimeout(t, time.Second)()
+
+	flushInterval := time.Duration(0)
+	opts, _ := newTestOptions(t, overrides{
+		strategy:      StrategyGroupCommit,
+		flushInterval: &flushInterval,
+	})
+	opts = opts.SetGroupCommitWindow(time.Hour)
+	defer cleanup(t, opts)
+
+	commitLog := newTestCommitLog(t, opts)
+	require.NoError(t, commitLog.Close())
+}
+
+func TestCommitLogWriteChunkTooLarge(t *testing.T) {
+	w := newChunkWriter(func(error) {}, false, false, CompressionNone).(*fsChunkWriter)
+	require.Equal(t, errChunkTooLarge, w.writeChunk(make([]byte, maxChunkSize+1)))
+}
+
+func TestCommitLogWriteCompressed(t *testing.T) {
+	opts, scope := newTestOptions(t, overrides{
+		strategy:    StrategyWriteWait,
+		compression: CompressionSnappy,
+	})
+	defer cleanup(t, opts)
+
+	commitLog := newTestCommitLog(t, opts)
+
+	annotation := []byte(strings.Repeat("compressible", 64))
+	var writes []testWrite
+	for i := 0; i < 32; i++ {
+		writes = append(writes, testWrite{
+			testSeries(uint64(i), fmt.Sprintf("foo.bar.%d", i), testTags1, 127),
+			time.Now(), float64(i), xtime.Second, annotation, nil,
+		})
+	}
+
+	// Call write sync
+	writeCommitLogs(t, scope, commitLog, writes).Wait()
+
+	// Close the commit log and consequently flush
+	require.NoError(t, commitLog.Close())
+
+	// Assert writes occurred by reading the commit log
+	assertCommitLogWritesByIterating(t, commitLog, writes)
+
+	// Assert chunks were stored compressed
+	files, corruptFiles, err := Files(opts)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(corruptFiles))
+	var size int64
+	for _, file := range files {
+		info, err := os.Stat(file.FilePath)
+		require.NoError(t, err)
+		size += info.Size()
+	}
+	require.True(t, size < int64(len(writes)*len(annotation)))
+}
+
+func TestCommitLogReadTornTail(t *testing.T) {
+	tests := []struct {
+		name  string
+		tail  func(t *testing.T, f *os.File)
+		fails bool
+	}{
+		{
+			name: "partial header",
+			tail: func(t *testing.T, f *os.File) {
+				_, err := f.Write([]byte{1, 2, 3})
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "partial chunk",
+			tail: func(t *testing.T, f *os.File) {
+				w := newChunkWriter(func(error) {}, false, false, CompressionNone)
+				cw := w.(*fsChunkWriter)
+				cw.reset(f)
+				_, err := cw.Write(make([]byte, 128))
+				require.NoError(t, err)
+				info, err := f.Stat()
+				require.NoError(t, err)
+				require.NoError(t, f.Truncate(info.Size()-64))
+			},
+		},
+		{
+			name: "corrupt last chunk",
+			tail: func(t *testing.T, f *os.File) {
+				w := newChunkWriter(func(error) {}, false, false, CompressionNone)
+				cw := w.(*fsChunkWriter)
+				cw.reset(f)
+				_, err := cw.Write([]byte{1, 2, 3, 4, 5, 6, 7, 8})
+				require.NoError(t, err)
+				info, err := f.Stat()
+				require.NoError(t, err)
+				_, err = f.WriteAt([]byte{0, 0, 0, 0}, info.Size()-4)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "zero filled pages",
+			tail: func(t *testing.T, f *os.File) {
+				_, err := f.Write(make([]byte, 8192))
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "garbage",
+			tail: func(t *testing.T, f *os.File) {
+				_, err := f.Write([]byte(strings.Repeat("garbage", 64)))
+				require.NoError(t, err)
+			},
+			fails: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts, scope := newTestOptions(t, overrides{
+				strategy: StrategyWriteWait,
+			})
+			defer cleanup(t, opts)
+
+			commitLog := newTestCommitLog(t, opts)
+
+			writes := []testWrite{
+				{testSeries(0, "foo.bar", testTags1, 127), time.Now(), 123.456, xtime.Second, nil, nil},
+				{testSeries(1, "foo.baz", testTags2, 150), time.Now(), 456.789, xtime.Second, nil, nil},
+			}
+
+			writeCommitLogs(t, scope, commitLog, writes).Wait()
+			require.NoError(t, commitLog.Close())
+
+			// Append the tail to the file holding the writes
+			files, _, err := Files(opts)
+			require.NoError(t, err)
+			require.True(t, len(files) > 0)
+			f, err := os.OpenFile(files[0].FilePath, os.O_RDWR, 0)
+			require.NoError(t, err)
+			_, err = f.Seek(0, io.SeekEnd)
+			require.NoError(t, err)
+			test.tail(t, f)
+			require.NoError(t, f.Close())
+
+			iter, corruptFiles, err := NewIterator(IteratorOpts{
+				CommitLogOptions:    opts,
+				FileFilterPredicate: ReadAllPredicate(),
+			})
+			require.NoError(t, err)
+			require.Equal(t, 0, len(corruptFiles))
+			defer iter.Close()
+
+			read := 0
+			for iter.Next() {
+				read++
+			}
+			if test.fails {
+				require.Error(t, iter.Err())
+				return
+			}
+			require.NoError(t, iter.Err())
+			require.Equal(t, len(writes), read)
+		})
+	}
+}
+
 func TestCommitLogWriteErrorOnClosed(t *testing.T) {
 	opts, _ := newTestOptions(t, overrides{})
 	defer cleanup(t, opts)
@@ -43,6 +43,12 @@ const (
 	// defaultFlushSize is the default commit log flush size
 	defaultFlushSize = 65536
 
+	// defaultCompression is the default commit log chunk compression
+	defaultCompression = CompressionNone
+
+	// defaultGroupCommitWindow is the default commit log group commit window
+	defaultGroupCommitWindow = 5 * time.Millisecond
+
 	// defaultBlockSize is the default commit log block size
 	defaultBlockSize = 15 * time.Minute
 
@@ -63,9 +69,11 @@ var (
 )
 
 var (
-	errFlushIntervalNonNegative = errors.New("flush interval must be non-negative")
-	errBlockSizePositive        = errors.New("block size must be a positive duration")
-	errReadConcurrencyPositive  = errors.New("read concurrency must be a positive integer")
+	errFlushIntervalNonNegative  = errors.New("flush interval must be non-negative")
+	errBlockSizePositive         = errors.New("block size must be a positive duration")
+	errReadConcurrencyPositive   = errors.New("read concurrency must be a positive integer")
+	errGroupCommitWindowPositive = errors.New("group commit window must be a positive duration")
+	errFlushSizePositive         = errors.New("flush size must be a positive integer")
 )
 
 type options struct {
@@ -76,6 +84,8 @@ type options struct {
 	strategy                Strategy
 	flushSize               int
 	flushInterval           time.Duration
+	compression             CompressionType
+	groupCommitWindow       time.Duration
 	backlogQueueSize        int
 	backlogQueueChannelSize int
 	bytesPool               pool.CheckedBytesPool
@@ -93,6 +103,8 @@ func NewOptions() Options {
 		strategy:                defaultStrategy,
 		flushSize:               defaultFlushSize,
 		flushInterval:           defaultFlushInterval,
+		compression:             defaultCompression,
+		groupCommitWindow:       defaultGroupCommitWindow,
 		backlogQueueSize:        defaultBacklogQueueSize,
 		backlogQueueChannelSize: defaultBacklogQueueChannelSize,
 		bytesPool: pool.NewCheckedBytesPool(nil, nil, func(s []pool.Bucket) pool.BytesPool {
@@ -110,6 +122,19 @@ func (o *options) Validate() error {
 		return errFlushIntervalNonNegative
 	}
 
+	if o.FlushSize() <= 0 {
+		return errFlushSizePositive
+	}
+
+	if o.FlushSize() > maxChunkSize {
+		return fmt.Errorf("flush size must be at most: %d, but was: %d",
+			maxChunkSize, o.FlushSize())
+	}
+
+	if o.Strategy() == StrategyGroupCommit && o.GroupCommitWindow() <= 0 {
+		return errGroupCommitWindowPositive
+	}
+
 	if o.BlockSize() <= 0 {
 		return errBlockSizePositive
 	}
@@ -197,6 +222,26 @@ func (o *options) FlushInterval() time.Duration {
 	return o.flushInterval
 }
 
+func (o *options) SetCompression(value CompressionType) Options {
+	opts := *o
+	opts.compression = value
+	return &opts
+}
+
+func (o *options) Compression() CompressionType {
+	return o.compression
+}
+
+func (o *options) SetGroupCommitWindow(value time.Duration) Options {
+	opts := *o
+	opts.groupCommitWindow = value
+	return &opts
+}
+
+func (o *options) GroupCommitWindow() time.Duration {
+	return o.groupCommitWindow
+}
+
 func (o *options) SetBacklogQueueSize(value int) Options {
 	opts := *o
 	opts.backlogQueueSize = value
@@ -437,7 +437,7 @@ type clState struct {
 
 // generator for commit log write
 func genState(t *testing.T, basePath string, seed int64) gopter.Gen {
-	return gopter.CombineGens(gen.Identifier(), gen.Bool(), gen.Float64Range(0, 1)).
+	return gopter.CombineGens(gen.Identifier(), gen.Bool(), gen.Float64Range(0, 1), gen.Bool()).
 		MapResult(func(r *gopter.GenResult) *gopter.GenResult {
 			iface, ok := r.Retrieve()
 			if !ok {
@@ -452,8 +452,9 @@ func genState(t *testing.T, basePath string, seed int64) gopter.Gen {
 				initPath              = path.Join(basePath, p[0].(string))
 				shouldCorrupt         = p[1].(bool)
 				corruptionProbability = p[2].(float64)
+				shouldCompress        = p[3].(bool)
 				result                = newInitState(
-					t, initPath, shouldCorrupt, corruptionProbability, seed)
+					t, initPath, shouldCorrupt, corruptionProbability, shouldCompress, seed)
 			)
 			return gopter.NewGenResult(result, gopter.NoShrinker)
 		})
@@ -464,9 +465,15 @@ func newInitState(
 	dir string,
 	shouldCorrupt bool,
 	corruptionProbability float64,
+	shouldCompress bool,
 	seed int64,
 ) *clState {
+	compression := CompressionNone
+	if shouldCompress {
+		compression = CompressionSnappy
+	}
 	opts := testOpts.
+		SetCompression(compression).
 		SetStrategy(StrategyWriteBehind).
 		SetFlushInterval(defaultTestFlushInterval).
 		// Need to set this to a relatively low value otherwise the test will
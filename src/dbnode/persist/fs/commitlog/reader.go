@@ -185,6 +185,16 @@ func (r *reader) Read() (LogEntry, error) {
 		result.Annotation = append(ts.Annotation(nil), ts.Annotation(entry.Annotation)...)
 	}
 
+	if entry.DeleteEnd != 0 {
+		result.Tombstone = Tombstone{
+			Range: xtime.Range{
+				Start: time.Unix(0, entry.Timestamp),
+				End:   time.Unix(0, entry.DeleteEnd),
+			},
+			FlushedOnly: entry.DeleteFlushedOnly,
+		}
+	}
+
 	return result, nil
 }
 
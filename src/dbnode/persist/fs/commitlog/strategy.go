@@ -0,0 +1,121 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package commitlog
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errStrategyUnspecified        = errors.New("commit log strategy not specified")
+	errCompressionTypeUnspecified = errors.New("commit log compression type not specified")
+)
+
+// ValidStrategies returns the valid commit log strategies.
+func ValidStrategies() []Strategy {
+	return []Strategy{StrategyWriteWait, StrategyWriteBehind, StrategyGroupCommit}
+}
+
+func (s Strategy) String() string {
+	switch s {
+	case StrategyWriteWait:
+		return "write_wait"
+	case StrategyWriteBehind:
+		return "write_behind"
+	case StrategyGroupCommit:
+		return "group_commit"
+	}
+	return "unknown"
+}
+
+// ParseStrategy parses a Strategy from a string.
+func ParseStrategy(str string) (Strategy, error) {
+	var r Strategy
+	if str == "" {
+		return r, errStrategyUnspecified
+	}
+	for _, valid := range ValidStrategies() {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+	return r, fmt.Errorf("invalid commit log Strategy '%s' valid types are: %v",
+		str, ValidStrategies())
+}
+
+// UnmarshalYAML unmarshals a Strategy into a valid type from string.
+func (s *Strategy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	r, err := ParseStrategy(str)
+	if err != nil {
+		return err
+	}
+	*s = r
+	return nil
+}
+
+// ValidCompressionTypes returns the valid commit log compression types.
+func ValidCompressionTypes() []CompressionType {
+	return []CompressionType{CompressionNone, CompressionSnappy}
+}
+
+func (c CompressionType) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionSnappy:
+		return "snappy"
+	}
+	return "unknown"
+}
+
+// ParseCompressionType parses a CompressionType from a string.
+func ParseCompressionType(str string) (CompressionType, error) {
+	var r CompressionType
+	if str == "" {
+		return r, errCompressionTypeUnspecified
+	}
+	for _, valid := range ValidCompressionTypes() {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+	return r, fmt.Errorf("invalid commit log CompressionType '%s' valid types are: %v",
+		str, ValidCompressionTypes())
+}
+
+// UnmarshalYAML unmarshals a CompressionType into a valid type from string.
+func (c *CompressionType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	r, err := ParseCompressionType(str)
+	if err != nil {
+		return err
+	}
+	*c = r
+	return nil
+}
@@ -47,6 +47,24 @@ const (
 	// for the buffered commit log chunk that contains a write to flush
 	// before acknowledging a write
 	StrategyWriteBehind
+
+	// StrategyGroupCommit describes the strategy that waits for the
+	// buffered commit log chunk that contains a write to be fsync'd
+	// before acknowledging a write, batching the fsyncs of all writes
+	// that arrive within the group commit window into a single fsync
+	StrategyGroupCommit
+)
+
+// CompressionType describes the compression of commit log chunks.
+type CompressionType int
+
+const (
+	// CompressionNone describes commit log chunks that are not compressed.
+	CompressionNone CompressionType = iota
+
+	// CompressionSnappy describes commit log chunks that are compressed
+	// with snappy block compression.
+	CompressionSnappy
 )
 
 // CommitLog provides a synchronized commit log
@@ -69,6 +87,16 @@ type CommitLog interface {
 		writes ts.WriteBatch,
 	) error
 
+	// WriteTombstone will write an entry in the commit log that deletes the
+	// data of a given series within the tombstone range, so that the delete
+	// is replayed if the node restarts before the affected filesets are
+	// rewritten.
+	WriteTombstone(
+		ctx context.Context,
+		series ts.Series,
+		tombstone Tombstone,
+	) error
+
 	// Close the commit log
 	Close() error
 
@@ -91,6 +119,19 @@ type LogEntry struct {
 	Unit       xtime.Unit
 	Annotation ts.Annotation
 	Metadata   LogEntryMetadata
+	// Tombstone is set if the entry deletes the data of the series rather
+	// than writing a datapoint.
+	Tombstone Tombstone
+}
+
+// Tombstone describes a delete of the data of a series within a time range.
+type Tombstone struct {
+	// Range is the time range of the deleted data, the tombstone is unset
+	// if the range is empty.
+	Range xtime.Range
+	// FlushedOnly is set if the delete only applies to data that has been
+	// flushed to disk and not to data held in memory.
+	FlushedOnly bool
 }
 
 // LogEntryMetadata is a set of metadata about a commit log entry being read.
@@ -177,6 +218,20 @@ type Options interface {
 	// Strategy returns the strategy.
 	Strategy() Strategy
 
+	// SetCompression sets the compression of commit log chunks.
+	SetCompression(value CompressionType) Options
+
+	// Compression returns the compression of commit log chunks.
+	Compression() CompressionType
+
+	// SetGroupCommitWindow sets the max time a write waits for its chunk
+	// to be fsync'd when using the group commit strategy.
+	SetGroupCommitWindow(value time.Duration) Options
+
+	// GroupCommitWindow returns the max time a write waits for its chunk
+	// to be fsync'd when using the group commit strategy.
+	GroupCommitWindow() time.Duration
+
 	// SetFlushInterval sets the flush interval.
 	SetFlushInterval(value time.Duration) Options
 
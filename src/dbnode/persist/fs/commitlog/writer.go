@@ -27,6 +27,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/golang/snappy"
 	"github.com/m3db/bitset"
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/digest"
@@ -53,6 +54,13 @@ const (
 		chunkHeaderChecksumSizeLen +
 		chunkHeaderChecksumDataLen
 
+	// The chunk size field stores the size of the chunk data in its low
+	// bits and the chunk flags in its high byte, chunks written before
+	// flags were introduced always have a zero high byte.
+	chunkSizeBits = 24
+	chunkSizeMask = 1<<chunkSizeBits - 1
+	maxChunkSize  = chunkSizeMask
+
 	defaultBitSetLength = 65536
 
 	defaultEncoderBuffSize = 16384
@@ -61,10 +69,19 @@ const (
 var (
 	errCommitLogWriterAlreadyOpen = errors.New("commit log writer already open")
 	errTagEncoderDataNotAvailable = errors.New("tag iterator data not available")
+	errChunkTooLarge              = errors.New("commit log chunk exceeds max chunk size")
 
 	endianness = binary.LittleEndian
 )
 
+// chunkFlags describes how the data of a chunk is encoded.
+type chunkFlags uint8
+
+const (
+	chunkFlagsNone chunkFlags = iota
+	chunkFlagsSnappy
+)
+
 type commitLogWriter interface {
 	// Open opens the commit log for writing data
 	Open() (persist.CommitLogFile, error)
@@ -77,6 +94,13 @@ type commitLogWriter interface {
 		annotation ts.Annotation,
 	) error
 
+	// WriteTombstone will write an entry in the commit log that deletes the
+	// data of a given series within the tombstone range
+	WriteTombstone(
+		series ts.Series,
+		tombstone Tombstone,
+	) error
+
 	// Flush will flush any data in the writers buffer to the chunkWriter, essentially forcing
 	// a new chunk to be created. Optionally forces the data to be FSync'd to disk.
 	Flush(sync bool) error
@@ -118,14 +142,17 @@ func newCommitLogWriter(
 	flushFn flushFn,
 	opts Options,
 ) commitLogWriter {
-	shouldFsync := opts.Strategy() == StrategyWriteWait
+	var (
+		shouldFsync       = opts.Strategy() == StrategyWriteWait
+		shouldFlushOnSync = opts.Strategy() == StrategyGroupCommit
+	)
 
 	return &writer{
 		filePathPrefix:      opts.FilesystemOptions().FilePathPrefix(),
 		newFileMode:         opts.FilesystemOptions().NewFileMode(),
 		newDirectoryMode:    opts.FilesystemOptions().NewDirectoryMode(),
 		nowFn:               opts.ClockOptions().NowFn(),
-		chunkWriter:         newChunkWriter(flushFn, shouldFsync),
+		chunkWriter:         newChunkWriter(flushFn, shouldFsync, shouldFlushOnSync, opts.Compression()),
 		chunkReserveHeader:  make([]byte, chunkHeaderLen),
 		buffer:              bufio.NewWriterSize(nil, opts.FlushSize()),
 		sizeBuffer:          make([]byte, binary.MaxVarintLen64),
@@ -198,6 +225,28 @@ func (w *writer) Write(
 	annotation ts.Annotation,
 ) error {
 	var logEntry schema.LogEntry
+	logEntry.Timestamp = datapoint.Timestamp.UnixNano()
+	logEntry.Value = datapoint.Value
+	logEntry.Unit = uint32(unit)
+	logEntry.Annotation = annotation
+	return w.writeEntry(series, logEntry)
+}
+
+func (w *writer) WriteTombstone(
+	series ts.Series,
+	tombstone Tombstone,
+) error {
+	var logEntry schema.LogEntry
+	logEntry.Timestamp = tombstone.Range.Start.UnixNano()
+	logEntry.DeleteEnd = tombstone.Range.End.UnixNano()
+	logEntry.DeleteFlushedOnly = tombstone.FlushedOnly
+	return w.writeEntry(series, logEntry)
+}
+
+func (w *writer) writeEntry(
+	series ts.Series,
+	logEntry schema.LogEntry,
+) error {
 	logEntry.Create = w.nowFn().UnixNano()
 	logEntry.Index = series.UniqueIndex
 
@@ -240,11 +289,6 @@ func (w *writer) Write(
 		logEntry.Metadata = w.metadataEncoderBuff
 	}
 
-	logEntry.Timestamp = datapoint.Timestamp.UnixNano()
-	logEntry.Value = datapoint.Value
-	logEntry.Unit = uint32(unit)
-	logEntry.Annotation = annotation
-
 	var err error
 	w.logEncoderBuff, err = msgpack.EncodeLogEntryFast(w.logEncoderBuff[:0], logEntry)
 	if err != nil {
@@ -317,17 +361,27 @@ func (w *writer) write(data []byte) error {
 }
 
 type fsChunkWriter struct {
-	fd      xos.File
-	flushFn flushFn
-	buff    []byte
-	fsync   bool
+	fd          xos.File
+	flushFn     flushFn
+	buff        []byte
+	compressed  []byte
+	fsync       bool
+	flushOnSync bool
+	compression CompressionType
 }
 
-func newChunkWriter(flushFn flushFn, fsync bool) chunkWriter {
+func newChunkWriter(
+	flushFn flushFn,
+	fsync bool,
+	flushOnSync bool,
+	compression CompressionType,
+) chunkWriter {
 	return &fsChunkWriter{
-		flushFn: flushFn,
-		buff:    make([]byte, chunkHeaderLen),
-		fsync:   fsync,
+		flushFn:     flushFn,
+		buff:        make([]byte, chunkHeaderLen),
+		fsync:       fsync,
+		flushOnSync: flushOnSync,
+		compression: compression,
 	}
 }
 
@@ -346,11 +400,51 @@ func (w *fsChunkWriter) isOpen() bool {
 }
 
 func (w *fsChunkWriter) sync() error {
-	return w.fd.Sync()
+	err := w.fd.Sync()
+	if w.flushOnSync {
+		// Writes are only acknowledged once they have been fsync'd when
+		// flushing on sync, so fire the flush callback here rather than
+		// after each chunk is written.
+		w.flushFn(err)
+	}
+	return err
 }
 
 func (w *fsChunkWriter) Write(p []byte) (int, error) {
-	size := len(p)
+	written := 0
+	for written < len(p) {
+		end := len(p)
+		if end-written > maxChunkSize {
+			end = written + maxChunkSize
+		}
+		if err := w.writeChunk(p[written:end]); err != nil {
+			w.flushFn(err)
+			return written, err
+		}
+		written = end
+	}
+
+	var err error
+
+	// Fsync if required to
+	if w.fsync {
+		err = w.fd.Sync()
+	}
+
+	// Fire flush callback, deferred until the next sync if flushing on sync
+	if !w.flushOnSync || err != nil {
+		w.flushFn(err)
+	}
+	return written, err
+}
+
+func (w *fsChunkWriter) writeChunk(p []byte) error {
+	data, flags := w.encode(p)
+	if len(data) > maxChunkSize {
+		// The size would overflow into the chunk flags.
+		return errChunkTooLarge
+	}
+	size := uint32(len(data)) | uint32(flags)<<chunkSizeBits
 
 	sizeStart, sizeEnd :=
 		0, chunkHeaderSizeLen
@@ -360,11 +454,11 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 		checksumSizeEnd, checksumSizeEnd+chunkHeaderChecksumDataLen
 
 	// Write size
-	endianness.PutUint32(w.buff[sizeStart:sizeEnd], uint32(size))
+	endianness.PutUint32(w.buff[sizeStart:sizeEnd], size)
 
 	// Calculate checksums
 	checksumSize := digest.Checksum(w.buff[sizeStart:sizeEnd])
-	checksumData := digest.Checksum(p)
+	checksumData := digest.Checksum(data)
 
 	// Write checksums
 	digest.
@@ -375,21 +469,23 @@ func (w *fsChunkWriter) Write(p []byte) (int, error) {
 		WriteDigest(checksumData)
 
 	// Combine buffers to reduce to a single syscall
-	w.buff = append(w.buff[:chunkHeaderLen], p...)
+	w.buff = append(w.buff[:chunkHeaderLen], data...)
 
 	// Write contents to file descriptor
-	n, err := w.fd.Write(w.buff)
-	if err != nil {
-		w.flushFn(err)
-		return n, err
-	}
+	_, err := w.fd.Write(w.buff)
+	return err
+}
 
-	// Fsync if required to
-	if w.fsync {
-		err = w.sync()
+// encode returns the data to store for a chunk, the data is only stored
+// compressed if compression is enabled, actually reduces its size and fits
+// within the max chunk size.
+func (w *fsChunkWriter) encode(p []byte) ([]byte, chunkFlags) {
+	switch w.compression {
+	case CompressionSnappy:
+		w.compressed = snappy.Encode(w.compressed[:cap(w.compressed)], p)
+		if len(w.compressed) < len(p) && len(w.compressed) <= maxChunkSize {
+			return w.compressed, chunkFlagsSnappy
+		}
 	}
-
-	// Fire flush callback
-	w.flushFn(err)
-	return n, err
+	return p, chunkFlagsNone
 }
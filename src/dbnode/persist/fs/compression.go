@@ -0,0 +1,234 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	// dataFileCompressedBlockTargetSize is the uncompressed size that a
+	// compressed data file block is filled to before it is flushed, blocks
+	// only ever contain whole series streams so may exceed this size.
+	dataFileCompressedBlockTargetSize = 64 * 1024
+
+	// dataFileBlockTableEntrySize is the size of each block table entry,
+	// the compressed and uncompressed block sizes.
+	dataFileBlockTableEntrySize = 8
+
+	// dataFileBlockTableFooterSize is the size of the footer at the end of
+	// a compressed data file, the number of blocks and the table checksum.
+	dataFileBlockTableFooterSize = 8
+)
+
+var (
+	errDataFileBlockTableTooShort         = errors.New("compressed data file too short for block table")
+	errDataFileBlockTableChecksumMismatch = errors.New("compressed data file block table checksum mismatch")
+	errDataFileBlockNotFound              = errors.New("compressed data file block not found for offset")
+	errDataFileBlockSizeMismatch          = errors.New("compressed data file block decompressed to unexpected size")
+)
+
+// dataFileCompressed returns whether the data file of the fileset described
+// by info is compressed.
+func dataFileCompressed(info schema.IndexInfo) bool {
+	return namespace.DataFileCompression(info.DataFileCompression) !=
+		namespace.DataFileCompressionNone
+}
+
+// dataFileBlock describes a single compressed block of series streams in a
+// compressed data file. Index entries reference the logical (uncompressed)
+// offsets of series streams which are mapped to blocks using the block table
+// appended to the end of the data file.
+type dataFileBlock struct {
+	physicalOffset   int64
+	logicalOffset    int64
+	compressedSize   uint32
+	uncompressedSize uint32
+}
+
+type dataFileBlocks []dataFileBlock
+
+// blockForOffset returns the block that contains the logical offset.
+func (b dataFileBlocks) blockForOffset(offset int64) (dataFileBlock, error) {
+	idx := sort.Search(len(b), func(i int) bool {
+		return b[i].logicalOffset+int64(b[i].uncompressedSize) > offset
+	})
+	if idx >= len(b) || b[idx].logicalOffset > offset {
+		return dataFileBlock{}, errDataFileBlockNotFound
+	}
+	return b[idx], nil
+}
+
+// physicalSize returns the size of the compressed blocks in the data file,
+// excluding the block table.
+func (b dataFileBlocks) physicalSize() int64 {
+	if len(b) == 0 {
+		return 0
+	}
+	last := b[len(b)-1]
+	return last.physicalOffset + int64(last.compressedSize)
+}
+
+// appendDataFileBlockTable appends the encoded block table for blocks to dst.
+func appendDataFileBlockTable(dst []byte, blocks dataFileBlocks) []byte {
+	var buf [dataFileBlockTableEntrySize]byte
+	start := len(dst)
+	for _, block := range blocks {
+		binary.LittleEndian.PutUint32(buf[:4], block.compressedSize)
+		binary.LittleEndian.PutUint32(buf[4:], block.uncompressedSize)
+		dst = append(dst, buf[:]...)
+	}
+	checksum := digest.Checksum(dst[start:])
+	binary.LittleEndian.PutUint32(buf[:4], uint32(len(blocks)))
+	binary.LittleEndian.PutUint32(buf[4:], checksum)
+	return append(dst, buf[:]...)
+}
+
+// decodeDataFileBlockTable decodes the block table from the tail of a
+// compressed data file, tail must end with the block table footer.
+func decodeDataFileBlockTable(tail []byte) (dataFileBlocks, error) {
+	if len(tail) < dataFileBlockTableFooterSize {
+		return nil, errDataFileBlockTableTooShort
+	}
+	footer := tail[len(tail)-dataFileBlockTableFooterSize:]
+	numBlocks := int(binary.LittleEndian.Uint32(footer[:4]))
+	checksum := binary.LittleEndian.Uint32(footer[4:])
+
+	tableSize := dataFileBlockTableSize(numBlocks) - dataFileBlockTableFooterSize
+	if len(tail)-dataFileBlockTableFooterSize < tableSize {
+		return nil, errDataFileBlockTableTooShort
+	}
+	table := tail[len(tail)-dataFileBlockTableFooterSize-tableSize : len(tail)-dataFileBlockTableFooterSize]
+	if digest.Checksum(table) != checksum {
+		return nil, errDataFileBlockTableChecksumMismatch
+	}
+
+	var (
+		blocks   = make(dataFileBlocks, 0, numBlocks)
+		physical int64
+		logical  int64
+	)
+	for i := 0; i < numBlocks; i++ {
+		entry := table[i*dataFileBlockTableEntrySize:]
+		block := dataFileBlock{
+			physicalOffset:   physical,
+			logicalOffset:    logical,
+			compressedSize:   binary.LittleEndian.Uint32(entry[:4]),
+			uncompressedSize: binary.LittleEndian.Uint32(entry[4:]),
+		}
+		physical += int64(block.compressedSize)
+		logical += int64(block.uncompressedSize)
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// dataFileBlockTableFromBytes decodes the block table of a compressed data
+// file held entirely in memory.
+func dataFileBlockTableFromBytes(data []byte) (dataFileBlocks, error) {
+	blocks, err := decodeDataFileBlockTable(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDataFileBlocks(blocks, int64(len(data))); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// readDataFileBlockTable reads the block table of a compressed data file.
+func readDataFileBlockTable(fd *os.File) (dataFileBlocks, error) {
+	stat, err := fd.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := stat.Size()
+	if size < dataFileBlockTableFooterSize {
+		return nil, errDataFileBlockTableTooShort
+	}
+
+	var footer [dataFileBlockTableFooterSize]byte
+	if _, err := fd.ReadAt(footer[:], size-dataFileBlockTableFooterSize); err != nil {
+		return nil, err
+	}
+	numBlocks := int(binary.LittleEndian.Uint32(footer[:4]))
+	tailSize := int64(dataFileBlockTableSize(numBlocks))
+	if size < tailSize {
+		return nil, errDataFileBlockTableTooShort
+	}
+
+	tail := make([]byte, tailSize)
+	if _, err := fd.ReadAt(tail, size-tailSize); err != nil {
+		return nil, err
+	}
+	blocks, err := decodeDataFileBlockTable(tail)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDataFileBlocks(blocks, size); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+func validateDataFileBlocks(blocks dataFileBlocks, fileSize int64) error {
+	expected := fileSize - int64(dataFileBlockTableSize(len(blocks)))
+	if blocks.physicalSize() != expected {
+		return fmt.Errorf(
+			"compressed data file size mismatch: blocks=%d, expected=%d",
+			blocks.physicalSize(), expected)
+	}
+	return nil
+}
+
+func dataFileBlockTableSize(numBlocks int) int {
+	return numBlocks*dataFileBlockTableEntrySize + dataFileBlockTableFooterSize
+}
+
+// decompressDataFileBlock decompresses a block into dst, growing it if required.
+func decompressDataFileBlock(
+	dst []byte,
+	block dataFileBlock,
+	compressed []byte,
+) ([]byte, error) {
+	if cap(dst) < int(block.uncompressedSize) {
+		dst = make([]byte, block.uncompressedSize)
+	}
+	dst = dst[:block.uncompressedSize]
+	decoded, err := snappy.Decode(dst, compressed)
+	if err != nil {
+		return dst, err
+	}
+	if len(decoded) != int(block.uncompressedSize) {
+		return dst, errDataFileBlockSizeMismatch
+	}
+	return decoded, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataFileBlockTableRoundTrip(t *testing.T) {
+	blocks := dataFileBlocks{
+		{physicalOffset: 0, logicalOffset: 0, compressedSize: 10, uncompressedSize: 100},
+		{physicalOffset: 10, logicalOffset: 100, compressedSize: 20, uncompressedSize: 50},
+		{physicalOffset: 30, logicalOffset: 150, compressedSize: 5, uncompressedSize: 5},
+	}
+
+	data := make([]byte, blocks.physicalSize())
+	data = appendDataFileBlockTable(data, blocks)
+	require.Equal(t, int(blocks.physicalSize())+dataFileBlockTableSize(len(blocks)), len(data))
+
+	decoded, err := dataFileBlockTableFromBytes(data)
+	require.NoError(t, err)
+	require.Equal(t, blocks, decoded)
+
+	// Corrupt the table.
+	data[blocks.physicalSize()]++
+	_, err = dataFileBlockTableFromBytes(data)
+	require.Equal(t, errDataFileBlockTableChecksumMismatch, err)
+
+	_, err = dataFileBlockTableFromBytes(data[:dataFileBlockTableFooterSize-1])
+	require.Equal(t, errDataFileBlockTableTooShort, err)
+}
+
+func TestDataFileBlockTableSizeMismatch(t *testing.T) {
+	blocks := dataFileBlocks{
+		{physicalOffset: 0, logicalOffset: 0, compressedSize: 10, uncompressedSize: 100},
+	}
+	data := appendDataFileBlockTable(make([]byte, 5), blocks)
+	_, err := dataFileBlockTableFromBytes(data)
+	require.Error(t, err)
+}
+
+func TestDataFileBlocksBlockForOffset(t *testing.T) {
+	blocks := dataFileBlocks{
+		{physicalOffset: 0, logicalOffset: 0, compressedSize: 10, uncompressedSize: 100},
+		{physicalOffset: 10, logicalOffset: 100, compressedSize: 20, uncompressedSize: 50},
+	}
+
+	for _, test := range []struct {
+		offset   int64
+		expected int
+	}{
+		{offset: 0, expected: 0},
+		{offset: 99, expected: 0},
+		{offset: 100, expected: 1},
+		{offset: 149, expected: 1},
+	} {
+		block, err := blocks.blockForOffset(test.offset)
+		require.NoError(t, err)
+		require.Equal(t, blocks[test.expected], block)
+	}
+
+	_, err := blocks.blockForOffset(150)
+	require.Equal(t, errDataFileBlockNotFound, err)
+}
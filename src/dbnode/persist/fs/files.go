@@ -58,6 +58,8 @@ const (
 	snapshotDirName   = "snapshots"
 	commitLogsDirName = "commitlogs"
 
+	postingsCacheDirName = "postings_cache"
+
 	// The maximum number of delimeters ('-' or '.') that is expected in a
 	// (base) filename.
 	maxDelimNum = 4
@@ -1349,6 +1351,18 @@ func NamespaceIndexSnapshotDirPath(prefix string, namespace ident.ID) string {
 	return path.Join(prefix, indexDirName, snapshotDirName, namespace.String())
 }
 
+// IndexPostingsCacheDirPath returns the path to the persisted postings list
+// cache directory.
+func IndexPostingsCacheDirPath(prefix string) string {
+	return path.Join(prefix, indexDirName, postingsCacheDirName)
+}
+
+// NamespaceIndexPostingsCacheDirPath returns the path to the persisted postings
+// list cache directory for a given namespace.
+func NamespaceIndexPostingsCacheDirPath(prefix string, namespace ident.ID) string {
+	return path.Join(IndexPostingsCacheDirPath(prefix), namespace.String())
+}
+
 // SnapshotsDirPath returns the path to the snapshots directory.
 func SnapshotsDirPath(prefix string) string {
 	return path.Join(prefix, snapshotDirName)
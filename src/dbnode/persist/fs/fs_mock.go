@@ -1219,3 +1219,17 @@ func (mr *MockMergeWithMockRecorder) Read(arg0, arg1, arg2, arg3 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockMergeWith)(nil).Read), arg0, arg1, arg2, arg3)
 }
+
+// Tombstones mocks base method
+func (m *MockMergeWith) Tombstones(arg0 ident.ID, arg1 time0.UnixNano) time0.Ranges {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tombstones", arg0, arg1)
+	ret0, _ := ret[0].(time0.Ranges)
+	return ret0
+}
+
+// Tombstones indicates an expected call of Tombstones
+func (mr *MockMergeWithMockRecorder) Tombstones(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tombstones", reflect.TypeOf((*MockMergeWith)(nil).Tombstones), arg0, arg1)
+}
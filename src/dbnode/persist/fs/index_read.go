@@ -22,6 +22,7 @@ package fs
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -139,6 +140,14 @@ func (r *indexReader) Open(
 	for _, shard := range r.info.Shards {
 		result.Shards[shard] = struct{}{}
 	}
+	result.SegmentChecksums = make([]uint32, 0, len(r.expectedDigest.SegmentDigests))
+	for _, segment := range r.expectedDigest.SegmentDigests {
+		buf := make([]byte, 4*len(segment.Files))
+		for i, file := range segment.Files {
+			binary.LittleEndian.PutUint32(buf[4*i:], file.Digest)
+		}
+		result.SegmentChecksums = append(result.SegmentChecksums, digest.Checksum(buf))
+	}
 	return result, nil
 }
 
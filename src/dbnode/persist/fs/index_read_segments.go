@@ -24,6 +24,7 @@ import (
 	"errors"
 	"io"
 
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
 	m3ninxpersist "github.com/m3db/m3/src/m3ninx/persist"
 )
@@ -47,7 +48,8 @@ type ReadIndexSegmentsOptions struct {
 	newPersistentSegmentFn newPersistentSegmentFn
 }
 
-// ReadIndexSegments will read a set of segments.
+// ReadIndexSegments will read a set of segments, the segments of flushed
+// filesets record the volume they were read from.
 func ReadIndexSegments(
 	opts ReadIndexSegmentsOptions,
 ) ([]segment.Segment, error) {
@@ -87,7 +89,8 @@ func ReadIndexSegments(
 		}
 	}()
 
-	if _, err := reader.Open(readerOpts); err != nil {
+	openResult, err := reader.Open(readerOpts)
+	if err != nil {
 		return nil, err
 	}
 	segments = make([]segment.Segment, 0, reader.SegmentFileSets())
@@ -107,7 +110,21 @@ func ReadIndexSegments(
 			return nil, err
 		}
 
-		segments = append(segments, seg)
+		if readerOpts.FileSetType != persist.FileSetFlushType {
+			segments = append(segments, seg)
+			continue
+		}
+
+		volume := persist.IndexSegmentVolume{
+			Namespace:    readerOpts.Identifier.Namespace.String(),
+			BlockStart:   readerOpts.Identifier.BlockStart,
+			VolumeIndex:  readerOpts.Identifier.VolumeIndex,
+			SegmentIndex: len(segments),
+		}
+		if volume.SegmentIndex < len(openResult.SegmentChecksums) {
+			volume.SegmentChecksum = openResult.SegmentChecksums[volume.SegmentIndex]
+		}
+		segments = append(segments, persist.NewIndexVolumeSegment(seg, volume))
 	}
 
 	// Indicate we don't need the defer() above to release any resources, as we are
@@ -203,7 +203,6 @@ func (m *merger) Merge(
 
 		segmentReaders = segmentReaders[:0]
 		seg := segmentReaderFromData(data, checksum, segReader)
-		segmentReaders = append(segmentReaders, seg)
 
 		// Check if this series is in memory (and thus requires merging).
 		ctx.Reset()
@@ -211,6 +210,20 @@ func (m *merger) Merge(
 		if err != nil {
 			return err
 		}
+
+		// Drop any data from disk that has since been deleted.
+		hasTombstones := false
+		if tombstones := mergeWith.Tombstones(id, blockStart); !tombstones.IsEmpty() {
+			hasTombstones = true
+			seg, err = segmentReaderExcluding(ctx, seg, tombstones, iterResources)
+			if err != nil {
+				return err
+			}
+		}
+		if seg != nil {
+			segmentReaders = append(segmentReaders, seg)
+		}
+
 		if hasInMemoryData {
 			segmentReaders = appendBlockReadersToSegmentReaders(segmentReaders, mergeWithData)
 		}
@@ -227,7 +240,7 @@ func (m *merger) Merge(
 		// In the special (but common) case that we're just copying the series data from the old file
 		// into the new one without merging or adding any additional data we can avoid recalculating
 		// the checksum.
-		if len(segmentReaders) == 1 && hasInMemoryData == false {
+		if len(segmentReaders) == 1 && hasInMemoryData == false && hasTombstones == false {
 			segment, err := segmentReaders[0].Segment()
 			if err != nil {
 				return err
@@ -290,6 +303,47 @@ func segmentReaderFromData(
 	return segReader
 }
 
+// segmentReaderExcluding returns a segment reader for the data in the given
+// segment reader with any datapoints in the excluded ranges removed, or nil
+// if no datapoints remain.
+func segmentReaderExcluding(
+	ctx context.Context,
+	segReader xio.SegmentReader,
+	excluded xtime.Ranges,
+	ir iterResources,
+) (xio.SegmentReader, error) {
+	it := ir.multiIter
+	it.Reset([]xio.SegmentReader{segReader}, ir.blockStart, ir.blockSize, ir.schema)
+	encoder := ir.encoderPool.Get()
+	encoder.Reset(ir.blockStart, ir.blockAllocSize, ir.schema)
+	for it.Next() {
+		dp, unit, annotation := it.Current()
+		if excluded.Overlaps(xtime.Range{Start: dp.Timestamp, End: dp.Timestamp.Add(time.Nanosecond)}) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return nil, err
+		}
+	}
+	if err := it.Err(); err != nil {
+		encoder.Close()
+		return nil, err
+	}
+
+	segment := encoder.Discard()
+	if segment.Len() == 0 {
+		segment.Finalize()
+		return nil, nil
+	}
+
+	// The context is closed once the series has been persisted so the
+	// segment will be finalized after it is no longer referenced.
+	reader := xio.NewSegmentReader(segment)
+	ctx.RegisterFinalizer(reader)
+	return reader, nil
+}
+
 func persistSegmentReaders(
 	id ident.ID,
 	tags ident.Tags,
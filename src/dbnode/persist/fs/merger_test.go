@@ -425,11 +425,71 @@ func TestMergeWithNoData(t *testing.T) {
 	testMergeWith(t, diskData, mergeTargetData, expected)
 }
 
+func TestMergeWithTombstones(t *testing.T) {
+	// This test scenario is when some of the data on disk has been deleted.
+	// id0 has all of its data on disk deleted.
+	// id1 has some of its data on disk deleted and also has data in the
+	// merge target.
+	// id2 has no data deleted.
+	diskData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	diskData.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{Timestamp: startTime.Add(0 * time.Second), Value: 0},
+		{Timestamp: startTime.Add(1 * time.Second), Value: 1},
+	}))
+	diskData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{Timestamp: startTime.Add(2 * time.Second), Value: 2},
+		{Timestamp: startTime.Add(3 * time.Second), Value: 3},
+		{Timestamp: startTime.Add(4 * time.Second), Value: 4},
+		{Timestamp: startTime.Add(5 * time.Second), Value: 5},
+	}))
+	diskData.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{Timestamp: startTime.Add(6 * time.Second), Value: 6},
+	}))
+
+	mergeTargetData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	mergeTargetData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{Timestamp: startTime.Add(7 * time.Second), Value: 7},
+	}))
+
+	tombstones := map[string]xtime.Ranges{
+		id0.String(): xtime.NewRanges(xtime.Range{
+			Start: startTime,
+			End:   startTime.Add(blockSize),
+		}),
+		id1.String(): xtime.NewRanges(xtime.Range{
+			Start: startTime.Add(3 * time.Second),
+			End:   startTime.Add(5 * time.Second),
+		}),
+	}
+
+	expected := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	expected.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{Timestamp: startTime.Add(2 * time.Second), Value: 2},
+		{Timestamp: startTime.Add(5 * time.Second), Value: 5},
+		{Timestamp: startTime.Add(7 * time.Second), Value: 7},
+	}))
+	expected.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{Timestamp: startTime.Add(6 * time.Second), Value: 6},
+	}))
+
+	testMergeWithTombstones(t, diskData, mergeTargetData, tombstones, expected)
+}
+
 func testMergeWith(
 	t *testing.T,
 	diskData *checkedBytesMap,
 	mergeTargetData *checkedBytesMap,
 	expectedData *checkedBytesMap,
+) {
+	testMergeWithTombstones(t, diskData, mergeTargetData, nil, expectedData)
+}
+
+func testMergeWithTombstones(
+	t *testing.T,
+	diskData *checkedBytesMap,
+	mergeTargetData *checkedBytesMap,
+	tombstones map[string]xtime.Ranges,
+	expectedData *checkedBytesMap,
 ) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -461,7 +521,7 @@ func testMergeWith(
 		Shard:      uint32(8),
 		BlockStart: startTime,
 	}
-	mergeWith := mockMergeWithFromData(t, ctrl, diskData, mergeTargetData)
+	mergeWith := mockMergeWithFromData(t, ctrl, diskData, mergeTargetData, tombstones)
 	err := merger.Merge(fsID, mergeWith, 1, preparer, nsCtx)
 	require.NoError(t, err)
 
@@ -545,8 +605,15 @@ func mockMergeWithFromData(
 	ctrl *gomock.Controller,
 	diskData *checkedBytesMap,
 	mergeTargetData *checkedBytesMap,
+	tombstones map[string]xtime.Ranges,
 ) *MockMergeWith {
 	mergeWith := NewMockMergeWith(ctrl)
+	mergeWith.EXPECT().
+		Tombstones(gomock.Any(), xtime.ToUnixNano(startTime)).
+		DoAndReturn(func(id ident.ID, blockStart xtime.UnixNano) xtime.Ranges {
+			return tombstones[id.String()]
+		}).
+		AnyTimes()
 
 	// Get the series IDs in the merge target that does not exist in disk data.
 	// This logic is not tested here because it should be part of tests of the
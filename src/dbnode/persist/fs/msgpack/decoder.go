@@ -172,6 +172,7 @@ func (dec *Decoder) DecodeLogEntry() (schema.LogEntry, error) {
 type DecodeLogEntryRemainingToken struct {
 	numFieldsToSkip1 int
 	numFieldsToSkip2 int
+	numFields        int
 }
 
 // DecodeLogEntryUniqueIndex decodes a log entry as much as is required to return
@@ -183,7 +184,7 @@ func (dec *Decoder) DecodeLogEntryUniqueIndex() (DecodeLogEntryRemainingToken, u
 	}
 
 	_, numFieldsToSkip1 := dec.decodeRootObject(logEntryVersion, logEntryType)
-	numFieldsToSkip2, _, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
+	numFieldsToSkip2, actual, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
 	if !ok {
 		return emptyLogEntryRemainingToken, 0, errorUnableToDetermineNumFieldsToSkip
 	}
@@ -192,6 +193,7 @@ func (dec *Decoder) DecodeLogEntryUniqueIndex() (DecodeLogEntryRemainingToken, u
 	token := DecodeLogEntryRemainingToken{
 		numFieldsToSkip1: numFieldsToSkip1,
 		numFieldsToSkip2: numFieldsToSkip2,
+		numFields:        actual,
 	}
 	return token, idx, nil
 }
@@ -211,6 +213,10 @@ func (dec *Decoder) DecodeLogEntryRemaining(token DecodeLogEntryRemainingToken,
 	logEntry.Value = dec.decodeFloat64()
 	logEntry.Unit = uint32(dec.decodeVarUint())
 	logEntry.Annotation, _, _ = dec.decodeBytes()
+	if token.numFields > minNumLogEntryFields {
+		logEntry.DeleteEnd = dec.decodeVarint()
+		logEntry.DeleteFlushedOnly = dec.decodeVarUint() != 0
+	}
 
 	dec.skip(token.numFieldsToSkip1)
 	if dec.err != nil {
@@ -258,6 +264,11 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 		opts.override = true
 		opts.numExpectedMinFields = 6
 		opts.numExpectedCurrFields = 9
+	case legacyEncodingIndexVersionV4:
+		// V4 had 10 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 6
+		opts.numExpectedCurrFields = 10
 	}
 
 	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(indexInfoType, opts)
@@ -301,6 +312,15 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 	// Decode fields added in V4.
 	indexInfo.VolumeIndex = int(dec.decodeVarint())
 
+	// At this point if its a V4 file we've decoded all the available fields.
+	if dec.legacy.decodeLegacyIndexInfoVersion == legacyEncodingIndexVersionV4 || actual < 11 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	// Decode fields added in V5.
+	indexInfo.DataFileCompression = dec.decodeVarint()
+
 	dec.skip(numFieldsToSkip)
 	return indexInfo
 }
@@ -423,7 +443,7 @@ func (dec *Decoder) decodeLogInfo() schema.LogInfo {
 }
 
 func (dec *Decoder) decodeLogEntry() schema.LogEntry {
-	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
+	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(logEntryType, checkNumFieldsOptions{})
 	if !ok {
 		return emptyLogEntry
 	}
@@ -435,6 +455,12 @@ func (dec *Decoder) decodeLogEntry() schema.LogEntry {
 	logEntry.Value = dec.decodeFloat64()
 	logEntry.Unit = uint32(dec.decodeVarUint())
 	logEntry.Annotation, _, _ = dec.decodeBytes()
+	if actual > minNumLogEntryFields {
+		// Entries written before deletes were introduced do not have the
+		// delete fields.
+		logEntry.DeleteEnd = dec.decodeVarint()
+		logEntry.DeleteFlushedOnly = dec.decodeVarUint() != 0
+	}
 	dec.skip(numFieldsToSkip)
 	if dec.err != nil {
 		return emptyLogEntry
@@ -108,6 +108,23 @@ func DecodeLogEntryFast(b []byte) (schema.LogEntry, error) {
 		return empty, err
 	}
 
+	if len(b) == 0 {
+		// Entries written before deletes were introduced do not have the
+		// delete fields.
+		return schema, nil
+	}
+
+	schema.DeleteEnd, b, err = decodeInt(b)
+	if err != nil {
+		return empty, err
+	}
+
+	deleteFlushedOnly, _, err := decodeUint(b)
+	if err != nil {
+		return empty, err
+	}
+	schema.DeleteFlushedOnly = deleteFlushedOnly != 0
+
 	return schema, err
 }
 
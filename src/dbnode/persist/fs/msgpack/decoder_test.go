@@ -197,8 +197,10 @@ func TestDecodeLogEntryFewerFieldsThanExpected(t *testing.T) {
 		dec = NewDecoder(nil)
 	)
 
-	// Intentionally bump number of fields for the log entry object
-	enc.encodeNumObjectFieldsForFn = testGenEncodeNumObjectFieldsForFn(enc, logEntryType, -1)
+	// Intentionally drop the number of fields for the log entry object below
+	// the minimum number of fields
+	enc.encodeNumObjectFieldsForFn = testGenEncodeNumObjectFieldsForFn(enc, logEntryType,
+		minNumLogEntryFields-currNumLogEntryFields-1)
 	require.NoError(t, enc.EncodeLogEntry(testLogEntry))
 
 	// Verify we can successfully skip unnecessary fields
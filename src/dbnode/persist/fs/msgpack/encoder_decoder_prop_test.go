@@ -177,6 +177,8 @@ func genLogEntry() gopter.Gen {
 		gen.Float64(),
 		gen.UInt32(),
 		genByteSlice(),
+		gen.Int64(),
+		gen.Bool(),
 	).Map(func(inputs []interface{}) schema.LogEntry {
 		return schema.LogEntry{
 			Index:      inputs[0].(uint64),
@@ -186,6 +188,9 @@ func genLogEntry() gopter.Gen {
 			Value:      inputs[4].(float64),
 			Unit:       inputs[5].(uint32),
 			Annotation: inputs[6].([]byte),
+			DeleteEnd:  inputs[7].(int64),
+
+			DeleteFlushedOnly: inputs[8].(bool),
 		}
 	})
 }
@@ -82,6 +82,7 @@ func testExpectedResultForIndexInfo(t *testing.T, indexInfo schema.IndexInfo) []
 		int64(indexInfo.FileType),
 		indexInfo.SnapshotID,
 		int64(indexInfo.VolumeIndex),
+		indexInfo.DataFileCompression,
 	}
 }
 
@@ -131,6 +132,8 @@ func testExpectedResultForLogEntry(t *testing.T, logEntry schema.LogEntry) []int
 		logEntry.Value,
 		uint64(logEntry.Unit),
 		logEntry.Annotation,
+		logEntry.DeleteEnd,
+		boolToUint64(logEntry.DeleteFlushedOnly),
 	}
 }
 
@@ -44,10 +44,11 @@ var (
 			NumElementsM: 2075674,
 			NumHashesK:   7,
 		},
-		SnapshotTime: time.Now().UnixNano(),
-		FileType:     persist.FileSetSnapshotType,
-		SnapshotID:   []byte("some_bytes"),
-		VolumeIndex:  1,
+		SnapshotTime:        time.Now().UnixNano(),
+		FileType:            persist.FileSetSnapshotType,
+		SnapshotID:          []byte("some_bytes"),
+		VolumeIndex:         1,
+		DataFileCompression: 1,
 	}
 
 	testIndexEntry = schema.IndexEntry{
@@ -77,6 +78,9 @@ var (
 		Value:      903.234,
 		Unit:       9,
 		Annotation: []byte("testAnnotation"),
+		DeleteEnd:  time.Now().Add(time.Hour).UnixNano(),
+
+		DeleteFlushedOnly: true,
 	}
 
 	testLogMetadata = schema.LogMetadata{
@@ -99,7 +103,7 @@ func TestIndexInfoRoundtrip(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V4 decoding code can handle the V1 file format.
+// Make sure the V5 decoding code can handle the V1 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	var (
 		opts = legacyEncodingOptions{encodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV1}
@@ -113,20 +117,23 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	// because the new decoder won't try and read the new fields from
 	// the old file format
 	var (
-		currSnapshotTime = testIndexInfo.SnapshotTime
-		currFileType     = testIndexInfo.FileType
-		currSnapshotID   = testIndexInfo.SnapshotID
-		currVolumeIndex  = testIndexInfo.VolumeIndex
+		currSnapshotTime        = testIndexInfo.SnapshotTime
+		currFileType            = testIndexInfo.FileType
+		currSnapshotID          = testIndexInfo.SnapshotID
+		currVolumeIndex         = testIndexInfo.VolumeIndex
+		currDataFileCompression = testIndexInfo.DataFileCompression
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
+	testIndexInfo.DataFileCompression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
+		testIndexInfo.DataFileCompression = currDataFileCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -136,7 +143,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V1 decoder code can handle the V4 file format.
+// Make sure the V1 decoder code can handle the V5 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	var (
 		opts = legacyEncodingOptions{decodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV1}
@@ -148,10 +155,11 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields
 	var (
-		currSnapshotTime = testIndexInfo.SnapshotTime
-		currFileType     = testIndexInfo.FileType
-		currSnapshotID   = testIndexInfo.SnapshotID
-		currVolumeIndex  = testIndexInfo.VolumeIndex
+		currSnapshotTime        = testIndexInfo.SnapshotTime
+		currFileType            = testIndexInfo.FileType
+		currSnapshotID          = testIndexInfo.SnapshotID
+		currVolumeIndex         = testIndexInfo.VolumeIndex
+		currDataFileCompression = testIndexInfo.DataFileCompression
 	)
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -162,11 +170,13 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
+	testIndexInfo.DataFileCompression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
+		testIndexInfo.DataFileCompression = currDataFileCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -175,7 +185,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V4 decoding code can handle the V2 file format.
+// Make sure the V5 decoding code can handle the V2 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = legacyEncodingOptions{encodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV2}
@@ -188,20 +198,23 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	// because the new decoder won't try and read the new fields from
 	// the old file format.
 	var (
-		currSnapshotTime = testIndexInfo.SnapshotTime
-		currFileType     = testIndexInfo.FileType
-		currSnapshotID   = testIndexInfo.SnapshotID
-		currVolumeIndex  = testIndexInfo.VolumeIndex
+		currSnapshotTime        = testIndexInfo.SnapshotTime
+		currFileType            = testIndexInfo.FileType
+		currSnapshotID          = testIndexInfo.SnapshotID
+		currVolumeIndex         = testIndexInfo.VolumeIndex
+		currDataFileCompression = testIndexInfo.DataFileCompression
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
+	testIndexInfo.DataFileCompression = 0
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
+		testIndexInfo.DataFileCompression = currDataFileCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -211,7 +224,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V2 decoder code can handle the V4 file format.
+// Make sure the V2 decoder code can handle the V5 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = legacyEncodingOptions{decodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV2}
@@ -224,6 +237,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// because the old decoder won't read the new fields.
 	currSnapshotID := testIndexInfo.SnapshotID
 	currVolumeIndex := testIndexInfo.VolumeIndex
+	currDataFileCompression := testIndexInfo.DataFileCompression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -231,9 +245,11 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	// encoded the data.
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
+	testIndexInfo.DataFileCompression = 0
 	defer func() {
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
+		testIndexInfo.DataFileCompression = currDataFileCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -242,7 +258,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V4 decoding code can handle the V3 file format.
+// Make sure the V5 decoding code can handle the V3 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	var (
 		opts = legacyEncodingOptions{encodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV3}
@@ -255,11 +271,14 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	// because the new decoder won't try and read the new fields from
 	// the old file format.
 	var (
-		currVolumeIndex = testIndexInfo.VolumeIndex
+		currVolumeIndex         = testIndexInfo.VolumeIndex
+		currDataFileCompression = testIndexInfo.DataFileCompression
 	)
 	testIndexInfo.VolumeIndex = 0
+	testIndexInfo.DataFileCompression = 0
 	defer func() {
 		testIndexInfo.VolumeIndex = currVolumeIndex
+		testIndexInfo.DataFileCompression = currDataFileCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -269,7 +288,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V3 decoder code can handle the V4 file format.
+// Make sure the V3 decoder code can handle the V5 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	var (
 		opts = legacyEncodingOptions{decodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV3}
@@ -281,14 +300,70 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields.
 	currVolumeIndex := testIndexInfo.VolumeIndex
+	currDataFileCompression := testIndexInfo.DataFileCompression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
 	// Make sure to zero them before we compare, but after we have
 	// encoded the data.
 	testIndexInfo.VolumeIndex = 0
+	testIndexInfo.DataFileCompression = 0
 	defer func() {
 		testIndexInfo.VolumeIndex = currVolumeIndex
+		testIndexInfo.DataFileCompression = currDataFileCompression
+	}()
+
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V5 decoding code can handle the V4 file format.
+func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{encodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV4}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V4,
+	// and then restore them at the end of the test - This is required
+	// because the new decoder won't try and read the new fields from
+	// the old file format.
+	currDataFileCompression := testIndexInfo.DataFileCompression
+	testIndexInfo.DataFileCompression = 0
+	defer func() {
+		testIndexInfo.DataFileCompression = currDataFileCompression
+	}()
+
+	enc.EncodeIndexInfo(testIndexInfo)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V4 decoder code can handle the V5 file format.
+func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
+	var (
+		opts = legacyEncodingOptions{decodeLegacyIndexInfoVersion: legacyEncodingIndexVersionV4}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V4
+	// and then restore them at the end of the test - This is required
+	// because the old decoder won't read the new fields.
+	currDataFileCompression := testIndexInfo.DataFileCompression
+
+	enc.EncodeIndexInfo(testIndexInfo)
+
+	// Make sure to zero them before we compare, but after we have
+	// encoded the data.
+	testIndexInfo.DataFileCompression = 0
+	defer func() {
+		testIndexInfo.DataFileCompression = currDataFileCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -430,6 +505,44 @@ func TestLogEntryRoundtripUniqueIndexAndRemaining(t *testing.T) {
 	require.Equal(t, testLogEntry, res)
 }
 
+// Make sure log entries written before deletes were introduced can still be
+// decoded.
+func TestLogEntryRoundtripBackwardsCompatibility(t *testing.T) {
+	enc := NewEncoder()
+	enc.encodeRootObject(logEntryVersion, logEntryType)
+	enc.encodeArrayLenFn(7) // Log entries without deletes had 7 fields.
+	enc.encodeVarUintFn(testLogEntry.Index)
+	enc.encodeVarintFn(testLogEntry.Create)
+	enc.encodeBytesFn(testLogEntry.Metadata)
+	enc.encodeVarintFn(testLogEntry.Timestamp)
+	enc.encodeFloat64Fn(testLogEntry.Value)
+	enc.encodeVarUintFn(uint64(testLogEntry.Unit))
+	enc.encodeBytesFn(testLogEntry.Annotation)
+	require.NoError(t, enc.err)
+
+	expected := testLogEntry
+	expected.DeleteEnd = 0
+	expected.DeleteFlushedOnly = false
+
+	dec := NewDecoder(nil)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeLogEntry()
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	token, idx, err := dec.DecodeLogEntryUniqueIndex()
+	require.NoError(t, err)
+	res, err = dec.DecodeLogEntryRemaining(token, idx)
+	require.NoError(t, err)
+	res.Index = idx
+	require.Equal(t, expected, res)
+
+	res, err = DecodeLogEntryFast(enc.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
 func TestLogMetadataRoundtrip(t *testing.T) {
 	var (
 		enc = NewEncoder()
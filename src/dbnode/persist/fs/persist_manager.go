@@ -267,7 +267,10 @@ func (pm *persistManager) PrepareIndex(opts persist.IndexPrepareOptions) (persis
 		BlockStart:         blockStart,
 		VolumeIndex:        volumeIndex,
 	}
-	blockSize := nsMetadata.Options().IndexOptions().BlockSize()
+	blockSize := opts.BlockSize
+	if blockSize == 0 {
+		blockSize = nsMetadata.Options().IndexOptions().BlockSize()
+	}
 	idxWriterOpts := IndexWriterOpenOptions{
 		BlockSize:   blockSize,
 		FileSetType: opts.FileSetType,
@@ -466,7 +469,8 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 
 	blockSize := nsMetadata.Options().RetentionOptions().BlockSize()
 	dataWriterOpts := DataWriterOpenOptions{
-		BlockSize: blockSize,
+		BlockSize:   blockSize,
+		Compression: nsMetadata.Options().DataFileCompression(),
 		Snapshot: DataWriterSnapshotOptions{
 			SnapshotTime: snapshotTime,
 			SnapshotID:   snapshotID,
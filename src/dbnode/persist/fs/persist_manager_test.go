@@ -468,7 +468,10 @@ func TestPersistenceManagerPrepareIndexSuccess(t *testing.T) {
 	segs, err := prepared.Close()
 	require.NoError(t, err)
 	require.Len(t, segs, 1)
-	require.Equal(t, fsSeg, segs[0])
+	require.Equal(t, persist.NewIndexVolumeSegment(fsSeg, persist.IndexSegmentVolume{
+		Namespace:  testNs1ID.String(),
+		BlockStart: blockStart,
+	}), segs[0])
 }
 
 func TestPersistenceManagerNoRateLimit(t *testing.T) {
@@ -25,11 +25,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"sort"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
@@ -80,6 +82,14 @@ type reader struct {
 	dataMmap   mmap.Descriptor
 	dataReader digest.ReaderWithDigest
 
+	// Compressed data files are read block by block using the block table,
+	// the current decompressed block is held in dataBlockBuf.
+	compression   namespace.DataFileCompression
+	dataBlocks    dataFileBlocks
+	dataBlock     dataFileBlock
+	dataBlockBuf  []byte
+	dataBlockRead bool
+
 	bloomFilterFd *os.File
 
 	entries         int
@@ -277,12 +287,13 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 
 func (r *reader) Status() DataFileSetReaderStatus {
 	return DataFileSetReaderStatus{
-		Open:       r.open,
-		Namespace:  r.namespace,
-		Shard:      r.shard,
-		Volume:     r.volume,
-		BlockStart: r.start,
-		BlockSize:  time.Duration(r.blockSize),
+		Open:        r.open,
+		Namespace:   r.namespace,
+		Shard:       r.shard,
+		Volume:      r.volume,
+		BlockStart:  r.start,
+		BlockSize:   time.Duration(r.blockSize),
+		Compression: r.compression,
 	}
 }
 
@@ -325,6 +336,13 @@ func (r *reader) readInfo(size int) error {
 	r.entriesRead = 0
 	r.metadataRead = 0
 	r.bloomFilterInfo = info.BloomFilter
+	r.compression = namespace.DataFileCompression(info.DataFileCompression)
+	if dataFileCompressed(info) {
+		r.dataBlocks, err = dataFileBlockTableFromBytes(r.dataMmap.Bytes)
+		if err != nil {
+			return fmt.Errorf("unable to read data file block table: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -370,12 +388,18 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 		defer data.DecRef()
 	}
 
-	n, err := r.dataReader.Read(data.Bytes())
-	if err != nil {
-		return nil, nil, nil, 0, err
-	}
-	if n != int(entry.Size) {
-		return nil, nil, nil, 0, errReadNotExpectedSize
+	if r.dataBlocks != nil {
+		if err := r.readCompressed(entry, data.Bytes()); err != nil {
+			return nil, nil, nil, 0, err
+		}
+	} else {
+		n, err := r.dataReader.Read(data.Bytes())
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+		if n != int(entry.Size) {
+			return nil, nil, nil, 0, errReadNotExpectedSize
+		}
 	}
 
 	id := r.entryClonedID(entry.ID)
@@ -385,6 +409,34 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 	return id, tags, data, uint32(entry.Checksum), nil
 }
 
+// readCompressed copies the data for the entry out of the compressed data
+// file block that contains it, decompressing the block if it is not the
+// block currently held.
+func (r *reader) readCompressed(entry schema.IndexEntry, dst []byte) error {
+	if !r.dataBlockRead ||
+		entry.Offset < r.dataBlock.logicalOffset ||
+		entry.Offset >= r.dataBlock.logicalOffset+int64(r.dataBlock.uncompressedSize) {
+		block, err := r.dataBlocks.blockForOffset(entry.Offset)
+		if err != nil {
+			return err
+		}
+		compressed := r.dataMmap.Bytes[block.physicalOffset : block.physicalOffset+int64(block.compressedSize)]
+		r.dataBlockBuf, err = decompressDataFileBlock(r.dataBlockBuf, block, compressed)
+		if err != nil {
+			return err
+		}
+		r.dataBlock = block
+		r.dataBlockRead = true
+	}
+
+	start := entry.Offset - r.dataBlock.logicalOffset
+	if start+int64(entry.Size) > int64(len(r.dataBlockBuf)) {
+		return errReadNotExpectedSize
+	}
+	copy(dst, r.dataBlockBuf[start:start+int64(entry.Size)])
+	return nil
+}
+
 func (r *reader) ReadMetadata() (ident.ID, ident.TagIterator, int, uint32, error) {
 	if r.metadataRead >= r.entries {
 		return nil, nil, 0, 0, io.EOF
@@ -463,6 +515,13 @@ func (r *reader) ValidateMetadata() error {
 // NB(xichen): ValidateData should be called after all data is read because
 // the digest is calculated for the entire data file.
 func (r *reader) ValidateData() error {
+	if r.dataBlocks != nil {
+		// NB: Compressed data files are read by block rather than through
+		// the data reader so drain it to digest the entire file.
+		if _, err := io.Copy(ioutil.Discard, r.dataReader); err != nil {
+			return fmt.Errorf("could not validate data file: %v", err)
+		}
+	}
 	err := r.dataReader.Validate(r.expectedDataDigest)
 	if err != nil {
 		return fmt.Errorf("could not validate data file: %v", err)
@@ -500,6 +559,7 @@ func (r *reader) Close() error {
 		r.indexEntriesByOffsetAsc[i].ID = nil
 	}
 	r.indexEntriesByOffsetAsc = r.indexEntriesByOffsetAsc[:0]
+	r.dataBlocks = nil
 
 	// Save fields we want to reassign after resetting struct
 	opts := r.opts
@@ -515,6 +575,7 @@ func (r *reader) Close() error {
 	bytesPool := r.bytesPool
 	tagDecoderPool := r.tagDecoderPool
 	indexEntriesByOffsetAsc := r.indexEntriesByOffsetAsc
+	dataBlockBuf := r.dataBlockBuf[:0]
 
 	// Reset struct
 	*r = reader{}
@@ -533,6 +594,7 @@ func (r *reader) Close() error {
 	r.bytesPool = bytesPool
 	r.tagDecoderPool = tagDecoderPool
 	r.indexEntriesByOffsetAsc = indexEntriesByOffsetAsc
+	r.dataBlockBuf = dataBlockBuf
 
 	return multiErr.FinalError()
 }
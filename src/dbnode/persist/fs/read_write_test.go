@@ -23,6 +23,7 @@ package fs
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/m3db/bloom"
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/x/checked"
 	"github.com/m3db/m3/src/x/ident"
@@ -120,6 +122,15 @@ func writeTestDataWithVolume(
 		writerOpts.Snapshot.SnapshotID = testSnapshotID
 	}
 
+	writeTestDataWithOpenOptions(t, w, writerOpts, entries)
+}
+
+func writeTestDataWithOpenOptions(
+	t *testing.T,
+	w DataFileSetWriter,
+	writerOpts DataWriterOpenOptions,
+	entries []testEntry,
+) {
 	err := w.Open(writerOpts)
 	assert.NoError(t, err)
 
@@ -272,6 +283,66 @@ func TestSimpleReadWrite(t *testing.T) {
 	readTestData(t, r, 0, testWriterStart, entries)
 }
 
+func TestCompressedReadWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	// Enough entries to span several compressed blocks, including entries
+	// larger than the block target size.
+	var entries []testEntry
+	for i := 0; i < 200; i++ {
+		data := bytes.Repeat([]byte{byte(i), byte(i >> 8), 1, 2, 3}, 100+i*10)
+		entries = append(entries, testEntry{fmt.Sprintf("foo.%d", i), nil, data})
+	}
+	entries = append(entries,
+		testEntry{"bar", nil, make([]byte, 2*dataFileCompressedBlockTargetSize)},
+		testEntry{"baz+qux=qaz", map[string]string{"qux": "qaz"}, []byte{7, 8, 9}})
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestDataWithOpenOptions(t, w, DataWriterOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+		Compression: namespace.DataFileCompressionSnappy,
+	}, entries)
+
+	// The blocks should be smaller on disk than the uncompressed data.
+	var uncompressed int
+	for _, entry := range entries {
+		uncompressed += len(entry.data)
+	}
+	dataFilePath := dataFilesetPathFromTimeAndIndex(
+		ShardDataDirPath(filePathPrefix, testNs1ID, 0), testWriterStart, 0, dataFileSuffix, false)
+	stat, err := os.Stat(dataFilePath)
+	require.NoError(t, err)
+	require.True(t, stat.Size() < int64(uncompressed))
+
+	r := newTestReader(t, filePathPrefix)
+	readTestData(t, r, 0, testWriterStart, entries)
+
+	// Read through once more to validate the data file digest.
+	require.NoError(t, r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	}))
+	require.Equal(t, namespace.DataFileCompressionSnappy, r.Status().Compression)
+	for i := 0; i < r.Entries(); i++ {
+		_, _, data, _, err := r.Read()
+		require.NoError(t, err)
+		data.Finalize()
+	}
+	require.NoError(t, r.Validate())
+	require.NoError(t, r.Close())
+}
+
 func TestCheckpointFileSizeBytesSize(t *testing.T) {
 	// These values need to match so that the logic for determining whether
 	// a checkpoint file is complete or not remains correct.
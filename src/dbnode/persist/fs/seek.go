@@ -72,6 +72,10 @@ type seeker struct {
 	indexFd       *os.File
 	indexFileSize int64
 
+	// Block table of the data file if it is compressed, nil otherwise. It
+	// is read only once opened and so is shared among clones.
+	dataBlocks dataFileBlocks
+
 	unreadBuf []byte
 
 	// Bloom filter associated with the shard / block the seeker is responsible
@@ -225,6 +229,14 @@ func (s *seeker) Open(
 	s.start = xtime.UnixNano(info.BlockStart)
 	s.blockSize = time.Duration(info.BlockSize)
 
+	if dataFileCompressed(info) {
+		s.dataBlocks, err = readDataFileBlockTable(s.dataFd)
+		if err != nil {
+			s.Close()
+			return fmt.Errorf("unable to read data file block table: %v", err)
+		}
+	}
+
 	err = s.validateIndexFileDigest(
 		indexFdWithDigest, expectedDigests.indexDigest)
 	if err != nil {
@@ -333,6 +345,10 @@ func (s *seeker) SeekByIndexEntry(
 	entry IndexEntry,
 	resources ReusableSeekerResources,
 ) (checked.Bytes, error) {
+	if s.dataBlocks != nil {
+		return s.seekCompressedByIndexEntry(entry, resources)
+	}
+
 	resources.offsetFileReader.reset(s.dataFd, entry.Offset)
 
 	// Obtain an appropriately sized buffer.
@@ -370,6 +386,65 @@ func (s *seeker) SeekByIndexEntry(
 	return buffer, nil
 }
 
+// seekCompressedByIndexEntry reads and decompresses the data file block that
+// contains the entry and copies the entry's data out of it.
+func (s *seeker) seekCompressedByIndexEntry(
+	entry IndexEntry,
+	resources ReusableSeekerResources,
+) (checked.Bytes, error) {
+	block, err := s.dataBlocks.blockForOffset(entry.Offset)
+	if err != nil {
+		return nil, err
+	}
+	start := entry.Offset - block.logicalOffset
+	end := start + int64(entry.Size)
+	if end > int64(block.uncompressedSize) {
+		return nil, fmt.Errorf(
+			"entry at offset %d with size %d spans compressed data file blocks",
+			entry.Offset, entry.Size)
+	}
+
+	bufs := resources.dataBlockBuffers
+	if bufs.decompressedFd != s.dataFd || bufs.decompressedOffset != block.physicalOffset {
+		// NB: Reset the cached block first so that it is not reused if reading
+		// or decompressing the block fails.
+		bufs.decompressedFd = nil
+		if cap(bufs.compressed) < int(block.compressedSize) {
+			bufs.compressed = make([]byte, block.compressedSize)
+		}
+		bufs.compressed = bufs.compressed[:block.compressedSize]
+		if _, err := s.dataFd.ReadAt(bufs.compressed, block.physicalOffset); err != nil {
+			return nil, err
+		}
+		bufs.decompressed, err = decompressDataFileBlock(bufs.decompressed, block, bufs.compressed)
+		if err != nil {
+			return nil, err
+		}
+		bufs.decompressedFd = s.dataFd
+		bufs.decompressedOffset = block.physicalOffset
+	}
+
+	data := bufs.decompressed[start:end]
+	// NB: Check the checksum against the known checksum as the data file
+	// might not have been verified if we haven't read through the file yet.
+	if entry.Checksum != digest.Checksum(data) {
+		return nil, errSeekChecksumMismatch
+	}
+
+	var buffer checked.Bytes
+	if s.opts.bytesPool != nil {
+		buffer = s.opts.bytesPool.Get(int(entry.Size))
+	} else {
+		buffer = checked.NewBytes(make([]byte, 0, entry.Size), nil)
+	}
+	buffer.IncRef()
+	defer buffer.DecRef()
+	buffer.Resize(0)
+	buffer.AppendAll(data)
+
+	return buffer, nil
+}
+
 // SeekIndexEntry performs the following steps:
 //
 //     1. Go to the indexLookup and it will give us an offset that is a good starting
@@ -487,6 +562,7 @@ func (s *seeker) Close() error {
 		multiErr = multiErr.Add(s.dataFd.Close())
 		s.dataFd = nil
 	}
+	s.dataBlocks = nil
 	return multiErr.FinalError()
 }
 
@@ -505,6 +581,8 @@ func (s *seeker) ConcurrentClone() (ConcurrentDataFileSetSeeker, error) {
 		bloomFilter: s.bloomFilter,
 		indexLookup: indexLookupClone,
 		isClone:     true,
+		// Data blocks are read only once opened.
+		dataBlocks: s.dataBlocks,
 
 		// Index and data fd's are always accessed via the ReadAt() / pread APIs so
 		// they are concurrency safe and can be shared among clones.
@@ -549,10 +627,23 @@ type ReusableSeekerResources struct {
 	// since the ReusableSeekerResources is only ever used by a single seeker at
 	// a time, we can size this pool such that it almost never has to allocate.
 	decodeIndexEntryBytesPool pool.BytesPool
+	// Buffers used to read and decompress blocks of compressed data files.
+	dataBlockBuffers *dataBlockBuffers
 
 	seekerOpenResources reusableSeekerOpenResources
 }
 
+type dataBlockBuffers struct {
+	compressed   []byte
+	decompressed []byte
+
+	// The data file and offset of the block held decompressed, consecutive
+	// seeks of series that are stored in the same block reuse it rather than
+	// reading and decompressing the block again.
+	decompressedFd     *os.File
+	decompressedOffset int64
+}
+
 // reusableSeekerOpenResources contains resources used for the Open() method of the seeker.
 type reusableSeekerOpenResources struct {
 	infoFDDigestReader           digest.FdWithDigestReader
@@ -582,6 +673,7 @@ func NewReusableSeekerResources(opts Options) ReusableSeekerResources {
 		byteDecoderStream:         xmsgpack.NewByteDecoderStream(nil),
 		offsetFileReader:          newOffsetFileReader(),
 		decodeIndexEntryBytesPool: newSimpleBytesPool(),
+		dataBlockBuffers:          &dataBlockBuffers{},
 		seekerOpenResources:       newReusableSeekerOpenResources(opts),
 	}
 }
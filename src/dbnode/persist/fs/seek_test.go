@@ -21,7 +21,9 @@
 package fs
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -29,6 +31,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/x/ident"
 
 	"github.com/stretchr/testify/assert"
@@ -224,6 +227,130 @@ func TestSeek(t *testing.T) {
 	assert.NoError(t, s.Close())
 }
 
+func TestSeekCompressed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writerOpts := DataWriterOpenOptions{
+		BlockSize: testBlockSize,
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+		Compression: namespace.DataFileCompressionSnappy,
+	}
+	err = w.Open(writerOpts)
+	assert.NoError(t, err)
+
+	// Write enough data to span multiple compressed blocks.
+	data := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("foo%d", i)
+		data[id] = bytes.Repeat([]byte{byte(i), 1, 2}, 1000)
+		assert.NoError(t, w.Write(
+			ident.StringID(id),
+			ident.Tags{},
+			bytesRefd(data[id]),
+			digest.Checksum(data[id])))
+	}
+	assert.NoError(t, w.Close())
+
+	resources := newTestReusableSeekerResources()
+	s := newTestSeeker(filePathPrefix)
+	err = s.Open(testNs1ID, 0, testWriterStart, 0, resources)
+	require.NoError(t, err)
+	require.True(t, len(s.(*seeker).dataBlocks) > 1)
+
+	clone, err := s.ConcurrentClone()
+	require.NoError(t, err)
+
+	for id, expected := range data {
+		result, err := s.SeekByID(ident.StringID(id), resources)
+		require.NoError(t, err)
+		result.IncRef()
+		assert.Equal(t, expected, result.Bytes())
+		result.DecRef()
+
+		entry, err := s.SeekIndexEntry(ident.StringID(id), resources)
+		require.NoError(t, err)
+		result, err = clone.SeekByIndexEntry(entry, resources)
+		require.NoError(t, err)
+		result.IncRef()
+		assert.Equal(t, expected, result.Bytes())
+		result.DecRef()
+	}
+
+	_, err = s.SeekByID(ident.StringID("foo"), resources)
+	assert.Equal(t, errSeekIDNotFound, err)
+
+	assert.NoError(t, clone.Close())
+	assert.NoError(t, s.Close())
+}
+
+func TestSeekCompressedReusesDecompressedBlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	w := newTestWriter(t, filePathPrefix)
+	writerOpts := DataWriterOpenOptions{
+		BlockSize: testBlockSize,
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+		Compression: namespace.DataFileCompressionSnappy,
+	}
+	err = w.Open(writerOpts)
+	assert.NoError(t, err)
+
+	data := map[string][]byte{
+		"foo1": {1, 2, 3},
+		"foo2": {4, 5, 6},
+	}
+	for _, id := range []string{"foo1", "foo2"} {
+		assert.NoError(t, w.Write(
+			ident.StringID(id),
+			ident.Tags{},
+			bytesRefd(data[id]),
+			digest.Checksum(data[id])))
+	}
+	assert.NoError(t, w.Close())
+
+	resources := newTestReusableSeekerResources()
+	s := newTestSeeker(filePathPrefix)
+	err = s.Open(testNs1ID, 0, testWriterStart, 0, resources)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(s.(*seeker).dataBlocks))
+
+	result, err := s.SeekByID(ident.StringID("foo1"), resources)
+	require.NoError(t, err)
+	result.IncRef()
+	assert.Equal(t, data["foo1"], result.Bytes())
+	result.DecRef()
+
+	// Seeking a series in the same block must not read the data file again.
+	require.NoError(t, s.(*seeker).dataFd.Close())
+	result, err = s.SeekByID(ident.StringID("foo2"), resources)
+	require.NoError(t, err)
+	result.IncRef()
+	assert.Equal(t, data["foo2"], result.Bytes())
+	result.DecRef()
+
+	// NB: The data file was closed above so only the close error is expected.
+	assert.Error(t, s.Close())
+}
+
 // TestSeekIDNotExists is similar to TestSeek, but it covers more edge cases
 // around IDs not existing.
 func TestSeekIDNotExists(t *testing.T) {
@@ -62,6 +62,8 @@ type DataWriterOpenOptions struct {
 	FileSetContentType persist.FileSetContentType
 	Identifier         FileSetFileIdentifier
 	BlockSize          time.Duration
+	// Compression is the compression to apply to the data file.
+	Compression namespace.DataFileCompression
 	// Only used when writing snapshot files
 	Snapshot DataWriterSnapshotOptions
 }
@@ -110,6 +112,8 @@ type DataFileSetReaderStatus struct {
 	Volume     int
 	Open       bool
 	BlockSize  time.Duration
+	// Compression is the compression applied to the data file.
+	Compression namespace.DataFileCompression
 }
 
 // DataReaderOpenOptions is options struct for the reader open method.
@@ -320,6 +324,9 @@ type IndexReaderOpenOptions struct {
 // index file set volume.
 type IndexReaderOpenResult struct {
 	Shards map[uint32]struct{}
+	// SegmentChecksums are the checksums of the digests of the files of each
+	// segment in the index file set.
+	SegmentChecksums []uint32
 }
 
 // IndexFileSetReader is an index file set reader.
@@ -533,6 +540,14 @@ type MergeWith interface {
 		fn ForEachRemainingFn,
 		nsCtx namespace.Context,
 	) error
+
+	// Tombstones returns the time ranges that have been deleted for the given
+	// block start and series ID, data within these ranges is dropped from
+	// the fileset data when merging.
+	Tombstones(
+		seriesID ident.ID,
+		blockStart xtime.UnixNano,
+	) xtime.Ranges
 }
 
 // Merger is in charge of merging filesets with some target MergeWith interface.
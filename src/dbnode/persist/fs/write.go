@@ -31,6 +31,7 @@ import (
 
 	"github.com/m3db/bloom"
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
@@ -39,6 +40,7 @@ import (
 	"github.com/m3db/m3/src/x/serialize"
 	xtime "github.com/m3db/m3/src/x/time"
 
+	"github.com/golang/snappy"
 	"github.com/pborman/uuid"
 )
 
@@ -82,6 +84,14 @@ type writer struct {
 	singleCheckedBytes []checked.Bytes
 	tagEncoderPool     serialize.TagEncoderPool
 	err                error
+
+	// Compressed data files buffer whole series streams into blocks which
+	// are compressed and written out once they reach the target size.
+	compression  namespace.DataFileCompression
+	blockBuf     []byte
+	compressBuf  []byte
+	blocks       dataFileBlocks
+	physicalSize int64
 }
 
 type indexEntry struct {
@@ -230,6 +240,10 @@ func (w *writer) reset(opts DataWriterOpenOptions) {
 	w.currIdx = 0
 	w.currOffset = 0
 	w.err = nil
+	w.compression = opts.Compression
+	w.blockBuf = w.blockBuf[:0]
+	w.blocks = w.blocks[:0]
+	w.physicalSize = 0
 	// This happens after writing the previous set of files index files, however, do it
 	// again to ensure they get cleared even if there was a premature error writing out the
 	// previous set of files which would have prevented them from being cleared.
@@ -241,6 +255,11 @@ func (w *writer) writeData(data []byte) error {
 	if len(data) == 0 {
 		return nil
 	}
+	if w.compression != namespace.DataFileCompressionNone {
+		w.blockBuf = append(w.blockBuf, data...)
+		w.currOffset += int64(len(data))
+		return nil
+	}
 	written, err := w.dataFdWithDigest.Write(data)
 	if err != nil {
 		return err
@@ -249,6 +268,41 @@ func (w *writer) writeData(data []byte) error {
 	return nil
 }
 
+// flushDataBlock compresses and writes out the buffered series streams of
+// a compressed data file.
+func (w *writer) flushDataBlock() error {
+	if len(w.blockBuf) == 0 {
+		return nil
+	}
+	w.compressBuf = snappy.Encode(w.compressBuf[:cap(w.compressBuf)], w.blockBuf)
+	if _, err := w.dataFdWithDigest.Write(w.compressBuf); err != nil {
+		return err
+	}
+	w.blocks = append(w.blocks, dataFileBlock{
+		physicalOffset:   w.physicalSize,
+		logicalOffset:    w.currOffset - int64(len(w.blockBuf)),
+		compressedSize:   uint32(len(w.compressBuf)),
+		uncompressedSize: uint32(len(w.blockBuf)),
+	})
+	w.physicalSize += int64(len(w.compressBuf))
+	w.blockBuf = w.blockBuf[:0]
+	return nil
+}
+
+// writeDataBlockTable flushes any remaining buffered series streams and
+// appends the block table to a compressed data file.
+func (w *writer) writeDataBlockTable() error {
+	if w.compression == namespace.DataFileCompressionNone {
+		return nil
+	}
+	if err := w.flushDataBlock(); err != nil {
+		return err
+	}
+	w.compressBuf = appendDataFileBlockTable(w.compressBuf[:0], w.blocks)
+	_, err := w.dataFdWithDigest.Write(w.compressBuf)
+	return err
+}
+
 func (w *writer) Write(
 	id ident.ID,
 	tags ident.Tags,
@@ -313,6 +367,9 @@ func (w *writer) writeAll(
 	w.indexEntries = append(w.indexEntries, entry)
 	w.currIdx++
 
+	if len(w.blockBuf) >= dataFileCompressedBlockTargetSize {
+		return w.flushDataBlock()
+	}
 	return nil
 }
 
@@ -335,6 +392,10 @@ func (w *writer) Close() error {
 }
 
 func (w *writer) close() error {
+	if err := w.writeDataBlockTable(); err != nil {
+		return err
+	}
+
 	if err := w.writeIndexRelatedFiles(); err != nil {
 		return err
 	}
@@ -556,6 +617,7 @@ func (w *writer) writeInfoFileContents(
 			NumElementsM: int64(bloomFilter.M()),
 			NumHashesK:   int64(bloomFilter.K()),
 		},
+		DataFileCompression: int64(w.compression),
 	}
 
 	w.encoder.Reset()
@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package persist
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+)
+
+// IndexSegmentVolume identifies a segment of a volume of a flushed index fileset.
+type IndexSegmentVolume struct {
+	Namespace   string
+	BlockStart  time.Time
+	VolumeIndex int
+	// SegmentIndex is the position of the segment within the volume.
+	SegmentIndex int
+	// SegmentChecksum is the checksum of the digests of the segment files, it
+	// changes whenever the contents of the segment change.
+	SegmentChecksum uint32
+}
+
+func (v IndexSegmentVolume) String() string {
+	return fmt.Sprintf("{namespace: %s, blockStart: %d, volumeIndex: %d, segmentIndex: %d, segmentChecksum: %d}",
+		v.Namespace, v.BlockStart.UnixNano(), v.VolumeIndex, v.SegmentIndex, v.SegmentChecksum)
+}
+
+// IndexVolumeSegment is an immutable segment read from a volume of a flushed
+// index fileset.
+type IndexVolumeSegment interface {
+	segment.ImmutableSegment
+
+	// Volume returns the volume the segment was read from.
+	Volume() IndexSegmentVolume
+}
+
+type indexVolumeSegment struct {
+	segment.ImmutableSegment
+
+	volume IndexSegmentVolume
+}
+
+// NewIndexVolumeSegment returns a new segment which records the volume of a
+// flushed index fileset that it was read from.
+func NewIndexVolumeSegment(
+	seg segment.ImmutableSegment,
+	volume IndexSegmentVolume,
+) IndexVolumeSegment {
+	return &indexVolumeSegment{
+		ImmutableSegment: seg,
+		volume:           volume,
+	}
+}
+
+func (s *indexVolumeSegment) Volume() IndexSegmentVolume {
+	return s.volume
+}
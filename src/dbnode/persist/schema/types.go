@@ -41,6 +41,12 @@ type IndexInfo struct {
 	FileType     persist.FileSetType
 	SnapshotID   []byte
 	VolumeIndex  int
+	// DataFileCompression is the compression applied to the data file,
+	// zero if the data file is not compressed. It was added in V5 of the
+	// index info, decoders of earlier versions skip it and read a compressed
+	// data file as if it were uncompressed, so filesets written compressed
+	// cannot be read after downgrading to a version without V5 support.
+	DataFileCompression int64
 }
 
 // IndexSummariesInfo stores metadata about the summaries
@@ -90,6 +96,14 @@ type LogEntry struct {
 	Value      float64
 	Unit       uint32
 	Annotation []byte
+	// DeleteEnd is set if the entry deletes the data of the series within
+	// [Timestamp, DeleteEnd) rather than writing a datapoint.
+	DeleteEnd int64
+	// DeleteFlushedOnly is set if the delete only applies to data that has
+	// been flushed to disk and not to data held in memory, pending deletes
+	// are logged this way again once the commit log that first recorded
+	// them has been rotated.
+	DeleteFlushedOnly bool
 }
 
 // LogMetadata stores metadata information about a commit log
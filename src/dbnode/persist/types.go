@@ -153,6 +153,10 @@ type IndexPrepareOptions struct {
 	BlockStart        time.Time
 	FileSetType       FileSetType
 	Shards            map[uint32]struct{}
+	// BlockSize is the size of the block persisted, if zero the index block
+	// size of the namespace is used. It is larger than the index block size
+	// of the namespace when persisting blocks compacted together.
+	BlockSize time.Duration
 }
 
 // DataPrepareSnapshotOptions is the options struct for the Prepare method that contains
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"fmt"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// NamespaceLimit is the set of rate limits applied to a single namespace,
+// a zero value for any of the limits disables that limit.
+type NamespaceLimit struct {
+	// WriteDatapointsPerSecond is the number of datapoints that can be
+	// written to the namespace per second.
+	WriteDatapointsPerSecond float64 `yaml:"writeDatapointsPerSecond" json:"writeDatapointsPerSecond"`
+
+	// WriteNewSeriesPerSecond is the number of new series that can be
+	// created in the namespace per second.
+	WriteNewSeriesPerSecond float64 `yaml:"writeNewSeriesPerSecond" json:"writeNewSeriesPerSecond"`
+
+	// ReadSeriesPerSecond is the number of series that can be read from
+	// or matched by queries against the namespace per second.
+	ReadSeriesPerSecond float64 `yaml:"readSeriesPerSecond" json:"readSeriesPerSecond"`
+
+	// ReadBytesPerSecond is the number of bytes that can be read from
+	// the namespace per second.
+	ReadBytesPerSecond float64 `yaml:"readBytesPerSecond" json:"readBytesPerSecond"`
+
+	// BurstSeconds is the number of seconds worth of each limit that can
+	// be consumed in a burst, defaults to one second.
+	BurstSeconds float64 `yaml:"burstSeconds" json:"burstSeconds"`
+}
+
+// Validate validates the namespace limit.
+func (l NamespaceLimit) Validate() error {
+	for name, v := range map[string]float64{
+		"writeDatapointsPerSecond": l.WriteDatapointsPerSecond,
+		"writeNewSeriesPerSecond":  l.WriteNewSeriesPerSecond,
+		"readSeriesPerSecond":      l.ReadSeriesPerSecond,
+		"readBytesPerSecond":       l.ReadBytesPerSecond,
+		"burstSeconds":             l.BurstSeconds,
+	} {
+		if v < 0 {
+			return fmt.Errorf("namespace limit %s must not be negative: %v", name, v)
+		}
+	}
+	return nil
+}
+
+// Burst returns the burst for a given per second limit.
+func (l NamespaceLimit) Burst(perSecond float64) float64 {
+	if l.BurstSeconds <= 0 {
+		return perSecond
+	}
+	return perSecond * l.BurstSeconds
+}
+
+// NamespaceLimits is the set of rate limits keyed by namespace name.
+type NamespaceLimits map[string]NamespaceLimit
+
+// ParseNamespaceLimits parses namespace limits from YAML (or JSON, which
+// is a subset of YAML), for instance:
+//
+//	default:
+//	  writeDatapointsPerSecond: 100000
+//	  readSeriesPerSecond: 10000
+func ParseNamespaceLimits(value string) (NamespaceLimits, error) {
+	var limits NamespaceLimits
+	if err := yaml.Unmarshal([]byte(value), &limits); err != nil {
+		return nil, err
+	}
+	for ns, limit := range limits {
+		if err := limit.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid limit for namespace %s: %v", ns, err)
+		}
+	}
+	return limits, nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/clock"
+
+	"go.uber.org/atomic"
+)
+
+// TokenBucket is a token bucket rate limiter that is refilled at a constant
+// rate up to a maximum burst. A token bucket with a non-positive rate does
+// not limit.
+type TokenBucket struct {
+	sync.Mutex
+
+	// NB: enabled is checked without the lock so that token buckets that do
+	// not limit never contend on the lock.
+	enabled *atomic.Bool
+
+	nowFn  clock.NowFn
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a new token bucket refilled with rate tokens per
+// second, holding at most burst tokens. A non-positive burst defaults to
+// the rate.
+func NewTokenBucket(rate, burst float64, nowFn clock.NowFn) *TokenBucket {
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	b := &TokenBucket{enabled: atomic.NewBool(false), nowFn: nowFn}
+	b.Reset(rate, burst)
+	return b
+}
+
+// Reset resets the rate and burst of the token bucket and refills it.
+func (b *TokenBucket) Reset(rate, burst float64) {
+	if burst <= 0 {
+		burst = rate
+	}
+	b.Lock()
+	b.rate = rate
+	b.burst = burst
+	b.tokens = burst
+	b.last = b.nowFn()
+	b.enabled.Store(rate > 0)
+	b.Unlock()
+}
+
+// Enabled returns whether the token bucket limits.
+func (b *TokenBucket) Enabled() bool {
+	return b.enabled.Load()
+}
+
+// TryTake takes n tokens if they are available and returns whether
+// they were taken. Taking more tokens than the burst only requires a full
+// token bucket and leaves it in debt, since it could never succeed otherwise.
+func (b *TokenBucket) TryTake(n float64) bool {
+	if !b.enabled.Load() {
+		return true
+	}
+	b.Lock()
+	defer b.Unlock()
+	if b.rate <= 0 {
+		return true
+	}
+	b.refillWithLock()
+	if b.tokens < math.Min(n, b.burst) {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
+
+// Take takes n tokens regardless of whether they are available, this is
+// used to charge for work whose cost is only known once it has been
+// performed and can leave the token bucket in debt.
+func (b *TokenBucket) Take(n float64) {
+	if !b.enabled.Load() {
+		return
+	}
+	b.Lock()
+	defer b.Unlock()
+	if b.rate <= 0 {
+		return
+	}
+	b.refillWithLock()
+	b.tokens -= n
+}
+
+// HasTokens returns whether the token bucket has any tokens available.
+func (b *TokenBucket) HasTokens() bool {
+	if !b.enabled.Load() {
+		return true
+	}
+	b.Lock()
+	defer b.Unlock()
+	if b.rate <= 0 {
+		return true
+	}
+	b.refillWithLock()
+	return b.tokens > 0
+}
+
+func (b *TokenBucket) refillWithLock() {
+	now := b.nowFn()
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+	b.last = now
+	b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+}
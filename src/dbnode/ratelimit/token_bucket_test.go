@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucketTryTake(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewTokenBucket(10, 20, func() time.Time { return now })
+
+	require.True(t, b.TryTake(15))
+	require.False(t, b.TryTake(10))
+	require.True(t, b.TryTake(5))
+	require.False(t, b.HasTokens())
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, b.TryTake(5))
+	require.False(t, b.TryTake(1))
+
+	// Refill is capped by the burst.
+	now = now.Add(time.Hour)
+	require.True(t, b.TryTake(20))
+	require.False(t, b.TryTake(1))
+}
+
+func TestTokenBucketTryTakeOverBurst(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewTokenBucket(10, 20, func() time.Time { return now })
+
+	// Taking more than the burst succeeds once the token bucket is full and
+	// leaves it in debt.
+	require.True(t, b.TryTake(30))
+	require.False(t, b.HasTokens())
+	require.False(t, b.TryTake(30))
+
+	now = now.Add(time.Second)
+	require.False(t, b.HasTokens())
+
+	now = now.Add(time.Second)
+	require.False(t, b.TryTake(30))
+	require.True(t, b.TryTake(10))
+}
+
+func TestTokenBucketTakeDebt(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewTokenBucket(10, 0, func() time.Time { return now })
+
+	b.Take(30)
+	require.False(t, b.HasTokens())
+
+	now = now.Add(time.Second)
+	require.False(t, b.HasTokens())
+
+	now = now.Add(1100 * time.Millisecond)
+	require.True(t, b.HasTokens())
+}
+
+func TestTokenBucketDisabled(t *testing.T) {
+	b := NewTokenBucket(0, 0, nil)
+	require.False(t, b.Enabled())
+	require.True(t, b.TryTake(1e9))
+	b.Take(1e9)
+	require.True(t, b.HasTokens())
+
+	b.Reset(1, 1)
+	require.True(t, b.Enabled())
+	require.True(t, b.TryTake(1))
+	require.False(t, b.TryTake(1))
+}
+
+func TestParseNamespaceLimits(t *testing.T) {
+	limits, err := ParseNamespaceLimits(`
+default:
+  writeDatapointsPerSecond: 1000
+  readBytesPerSecond: 1048576
+  burstSeconds: 2
+`)
+	require.NoError(t, err)
+	assert.Equal(t, NamespaceLimits{
+		"default": NamespaceLimit{
+			WriteDatapointsPerSecond: 1000,
+			ReadBytesPerSecond:       1048576,
+			BurstSeconds:             2,
+		},
+	}, limits)
+	assert.Equal(t, 2000.0, limits["default"].Burst(1000))
+
+	limits, err = ParseNamespaceLimits(`{"metrics": {"readSeriesPerSecond": 10}}`)
+	require.NoError(t, err)
+	assert.Equal(t, 10.0, limits["metrics"].ReadSeriesPerSecond)
+	assert.Equal(t, 10.0, limits["metrics"].Burst(10))
+
+	_, err = ParseNamespaceLimits(`{"metrics": {"readSeriesPerSecond": -1}}`)
+	require.Error(t, err)
+}
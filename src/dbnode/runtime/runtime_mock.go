@@ -408,6 +408,34 @@ func (mr *MockOptionsMockRecorder) IndexDefaultQueryTimeout() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexDefaultQueryTimeout", reflect.TypeOf((*MockOptions)(nil).IndexDefaultQueryTimeout))
 }
 
+// SetNamespaceLimits mocks base method
+func (m *MockOptions) SetNamespaceLimits(value ratelimit.NamespaceLimits) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNamespaceLimits", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetNamespaceLimits indicates an expected call of SetNamespaceLimits
+func (mr *MockOptionsMockRecorder) SetNamespaceLimits(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNamespaceLimits", reflect.TypeOf((*MockOptions)(nil).SetNamespaceLimits), value)
+}
+
+// NamespaceLimits mocks base method
+func (m *MockOptions) NamespaceLimits() ratelimit.NamespaceLimits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NamespaceLimits")
+	ret0, _ := ret[0].(ratelimit.NamespaceLimits)
+	return ret0
+}
+
+// NamespaceLimits indicates an expected call of NamespaceLimits
+func (mr *MockOptionsMockRecorder) NamespaceLimits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NamespaceLimits", reflect.TypeOf((*MockOptions)(nil).NamespaceLimits))
+}
+
 // MockOptionsManager is a mock of OptionsManager interface
 type MockOptionsManager struct {
 	ctrl     *gomock.Controller
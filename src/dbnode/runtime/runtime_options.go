@@ -75,6 +75,7 @@ type options struct {
 	clientReadConsistencyLevel           topology.ReadConsistencyLevel
 	clientWriteConsistencyLevel          topology.ConsistencyLevel
 	indexDefaultQueryTimeout             time.Duration
+	namespaceLimits                      ratelimit.NamespaceLimits
 }
 
 // NewOptions creates a new set of runtime options with defaults
@@ -239,3 +240,13 @@ func (o *options) SetIndexDefaultQueryTimeout(value time.Duration) Options {
 func (o *options) IndexDefaultQueryTimeout() time.Duration {
 	return o.indexDefaultQueryTimeout
 }
+
+func (o *options) SetNamespaceLimits(value ratelimit.NamespaceLimits) Options {
+	opts := *o
+	opts.namespaceLimits = value
+	return &opts
+}
+
+func (o *options) NamespaceLimits() ratelimit.NamespaceLimits {
+	return o.namespaceLimits
+}
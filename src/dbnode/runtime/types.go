@@ -168,6 +168,14 @@ type Options interface {
 	// IndexDefaultQueryTimeout is the hard timeout value to use if none is
 	// specified for a specific query, zero specifies to use no timeout at all.
 	IndexDefaultQueryTimeout() time.Duration
+
+	// SetNamespaceLimits sets the per namespace write and read rate limits,
+	// namespaces without limits are not rate limited.
+	SetNamespaceLimits(value ratelimit.NamespaceLimits) Options
+
+	// NamespaceLimits returns the per namespace write and read rate limits,
+	// namespaces without limits are not rate limited.
+	NamespaceLimits() ratelimit.NamespaceLimits
 }
 
 // OptionsManager updates and supplies runtime options.
@@ -49,6 +49,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/environment"
 	"github.com/m3db/m3/src/dbnode/kvconfig"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/network/server/httpjson"
 	hjcluster "github.com/m3db/m3/src/dbnode/network/server/httpjson/cluster"
 	hjnode "github.com/m3db/m3/src/dbnode/network/server/httpjson/node"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
@@ -64,6 +65,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/cluster"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -394,6 +396,39 @@ func Run(runOpts RunOptions) {
 	}
 	defer stopReporting()
 
+	var persistentPostingsListCache *index.PersistentPostingsListCache
+	if plCacheConfig.PersistentOrDefault() {
+		persistentPostingsListCache, err = index.NewPersistentPostingsListCache(
+			index.PersistentPostingsListCacheOptions{
+				FilePathPrefix:    cfg.Filesystem.FilePathPrefixOrDefault(),
+				MaxBytes:          plCacheConfig.PersistentMaxBytesOrDefault(),
+				InstrumentOptions: plCacheOptions.InstrumentOptions,
+			})
+		if err != nil {
+			logger.Fatal("could not construct persistent postings list cache", zap.Error(err))
+		}
+		defer persistentPostingsListCache.Close()
+	}
+
+	// Setup query limits.
+	docsLimitOpts := limits.DefaultLookbackLimitOptions()
+	if limitConfig := cfg.Limits.MaxRecentlyQueriedDocs; limitConfig != nil {
+		docsLimitOpts.Limit = limitConfig.Value
+		if limitConfig.Lookback > 0 {
+			docsLimitOpts.Lookback = limitConfig.Lookback
+		}
+	}
+	queryLimits, err := limits.NewQueryLimits(limits.QueryLimitsOptions{
+		DocsLimitOptions:  docsLimitOpts,
+		MaxDocsPerQuery:   cfg.Limits.MaxDocsPerQuery,
+		InstrumentOptions: iopts,
+	})
+	if err != nil {
+		logger.Fatal("could not construct query limits", zap.Error(err))
+	}
+	queryLimits.Start()
+	defer queryLimits.Stop()
+
 	// FOLLOWUP(prateek): remove this once we have the runtime options<->index wiring done
 	indexOpts := opts.IndexOptions()
 	insertMode := index.InsertSync
@@ -402,11 +437,20 @@ func Run(runOpts RunOptions) {
 	}
 	indexOpts = indexOpts.SetInsertMode(insertMode).
 		SetPostingsListCache(postingsListCache).
+		SetPersistentPostingsListCache(persistentPostingsListCache).
 		SetReadThroughSegmentOptions(index.ReadThroughSegmentOptions{
 			CacheRegexp: plCacheConfig.CacheRegexpOrDefault(),
 			CacheTerms:  plCacheConfig.CacheTermsOrDefault(),
 		}).
-		SetMmapReporter(mmapReporter)
+		SetMmapReporter(mmapReporter).
+		SetQueryLimits(queryLimits)
+	if compactionCfg := cfg.Index.Compaction; compactionCfg != nil {
+		indexOpts = indexOpts.SetFlushedBlockCompactionOptions(index.FlushedBlockCompactionOptions{
+			Enabled:   true,
+			BlockSize: compactionCfg.BlockSize,
+			MinAge:    compactionCfg.MinAge,
+		})
+	}
 	opts = opts.SetIndexOptions(indexOpts)
 
 	if tick := cfg.Tick; tick != nil {
@@ -500,14 +544,24 @@ func Run(runOpts RunOptions) {
 	// Apply pooling options.
 	opts = withEncodingAndPoolingOptions(cfg, logger, opts, cfg.PoolingPolicy)
 
-	opts = opts.SetCommitLogOptions(opts.CommitLogOptions().
+	commitLogOpts := opts.CommitLogOptions().
 		SetInstrumentOptions(opts.InstrumentOptions()).
 		SetFilesystemOptions(fsopts).
 		SetStrategy(commitlog.StrategyWriteBehind).
 		SetFlushSize(cfg.CommitLog.FlushMaxBytes).
 		SetFlushInterval(cfg.CommitLog.FlushEvery).
 		SetBacklogQueueSize(commitLogQueueSize).
-		SetBacklogQueueChannelSize(commitLogQueueChannelSize))
+		SetBacklogQueueChannelSize(commitLogQueueChannelSize)
+	if v := cfg.CommitLog.Strategy; v != nil {
+		commitLogOpts = commitLogOpts.SetStrategy(*v)
+	}
+	if v := cfg.CommitLog.Compression; v != nil {
+		commitLogOpts = commitLogOpts.SetCompression(*v)
+	}
+	if v := cfg.CommitLog.GroupCommitWindow; v != nil {
+		commitLogOpts = commitLogOpts.SetGroupCommitWindow(*v)
+	}
+	opts = opts.SetCommitLogOptions(commitLogOpts)
 
 	// Setup the block retriever
 	switch seriesCachePolicy {
@@ -616,8 +670,17 @@ func Run(runOpts RunOptions) {
 	defer tchannelthriftNodeClose()
 	logger.Info("node tchannelthrift: listening", zap.String("address", cfg.ListenAddress))
 
+	// Similarly the repair status and index search handlers have the database
+	// set once it has been initialized.
+	repairStatusHandler := hjnode.NewRepairStatusHandler()
+	indexSearchHandler := hjnode.NewIndexSearchHandler()
+	httpjsonOpts := httpjson.NewServerOptions().
+		SetHandlers(map[string]http.Handler{
+			hjnode.RepairStatusURL: repairStatusHandler,
+			hjnode.IndexSearchURL:  indexSearchHandler,
+		})
 	httpjsonNodeClose, err := hjnode.NewServer(service,
-		cfg.HTTPNodeListenAddress, contextPool, nil).ListenAndServe()
+		cfg.HTTPNodeListenAddress, contextPool, httpjsonOpts).ListenAndServe()
 	if err != nil {
 		logger.Fatal("could not open httpjson interface",
 			zap.String("address", cfg.HTTPNodeListenAddress), zap.Error(err))
@@ -770,6 +833,9 @@ func Run(runOpts RunOptions) {
 				// Set conditionally to avoid stomping on the default value of 1.0.
 				repairOpts = repairOpts.SetDebugShadowComparisonsPercentage(cfg.Repair.DebugShadowComparisonsPercentage)
 			}
+			if cfg.Repair.MerkleTreeDepth > 0 {
+				repairOpts = repairOpts.SetMerkleTreeDepth(cfg.Repair.MerkleTreeDepth)
+			}
 		}
 
 		opts = opts.
@@ -835,6 +901,8 @@ func Run(runOpts RunOptions) {
 			}
 		})
 
+	kvWatchNamespaceLimits(syncCfg.KVStore, logger, runtimeOptsMgr)
+
 	// Start the cluster services now that the M3DB client is available.
 	tchannelthriftClusterClose, err := ttcluster.NewServer(m3dbClient,
 		cfg.ClusterListenAddress, contextPool, tchannelOpts).ListenAndServe()
@@ -877,6 +945,8 @@ func Run(runOpts RunOptions) {
 
 	// Now that we've initialized the database we can set it on the service.
 	service.SetDatabase(db)
+	repairStatusHandler.SetDatabase(db)
+	indexSearchHandler.SetDatabase(db)
 
 	go func() {
 		if runOpts.BootstrapCh != nil {
@@ -1083,6 +1153,27 @@ func kvWatchClientConsistencyLevels(
 		})
 }
 
+func kvWatchNamespaceLimits(
+	store kv.Store,
+	logger *zap.Logger,
+	runtimeOptsMgr m3dbruntime.OptionsManager,
+) {
+	kvWatchStringValue(store, logger,
+		kvconfig.NamespaceLimitsKey,
+		func(value string) error {
+			limits, err := ratelimit.ParseNamespaceLimits(value)
+			if err != nil {
+				return err
+			}
+			return runtimeOptsMgr.Update(runtimeOptsMgr.Get().
+				SetNamespaceLimits(limits))
+		},
+		func() error {
+			return runtimeOptsMgr.Update(runtimeOptsMgr.Get().
+				SetNamespaceLimits(nil))
+		})
+}
+
 func kvWatchStringValue(
 	store kv.Store,
 	logger *zap.Logger,
@@ -105,6 +105,10 @@ type accumulateArg struct {
 	dp         ts.Datapoint
 	unit       xtime.Unit
 	annotation ts.Annotation
+
+	// barrier is set instead of a write to signal once every write enqueued
+	// before it has been accumulated.
+	barrier *sync.WaitGroup
 }
 
 type accumulateWorker struct {
@@ -345,6 +349,7 @@ func (s *commitLogSource) Read(
 		commitLogNamespaces    []*bootstrapNamespace
 		commitLogSeries        = make(map[seriesMapKey]seriesMapEntry)
 		workerEnqueue          = 0
+		tombstoneErrors        = 0
 		tagDecoder             = s.opts.CommitLogOptions().FilesystemOptions().TagDecoderPool().Get()
 		tagDecoderCheckedBytes = checked.NewBytes(nil, nil)
 	)
@@ -501,6 +506,39 @@ func (s *commitLogSource) Read(
 			continue
 		}
 
+		if tombstone := entry.Tombstone; !tombstone.Range.IsEmpty() {
+			// Deletes apply to the writes that preceded them, so wait for all
+			// enqueued writes to be accumulated before deleting, unless the
+			// delete only applies to data on disk.
+			var (
+				series = seriesEntry.series.Series
+				start  = tombstone.Range.Start
+				end    = tombstone.Range.End
+				err    error
+			)
+			if tombstone.FlushedOnly {
+				err = series.DeleteFlushedRange(start, end)
+			} else {
+				var barrier sync.WaitGroup
+				barrier.Add(len(workers))
+				for _, worker := range workers {
+					worker.inputCh <- accumulateArg{barrier: &barrier}
+				}
+				barrier.Wait()
+
+				err = series.DeleteRange(start, end,
+					seriesEntry.namespace.namespaceContext)
+			}
+			if err != nil {
+				s.log.Error("failed to delete commit log tombstone range",
+					zap.Stringer("series", series.ID()),
+					zap.Stringer("range", tombstone.Range),
+					zap.Error(err))
+				tombstoneErrors++
+			}
+			continue
+		}
+
 		// Distribute work.
 		// NB(r): In future we could batch a few points together before sending
 		// to a channel to alleviate lock contention/stress on the channels.
@@ -536,7 +574,7 @@ func (s *commitLogSource) Read(
 	// Log the outcome and calculate if required to return unfulfilled.
 	s.logAccumulateOutcome(workers, iter)
 	shouldReturnUnfulfilled, err := s.shouldReturnUnfulfilled(
-		workers, encounteredCorruptData, initialTopologyState)
+		workers, tombstoneErrors, encounteredCorruptData, initialTopologyState)
 	if err != nil {
 		return bootstrap.NamespaceResults{}, err
 	}
@@ -885,6 +923,11 @@ func (s *commitLogSource) startAccumulateWorker(worker *accumulateWorker) {
 	defer ctx.Close()
 
 	for input := range worker.inputCh {
+		if input.barrier != nil {
+			input.barrier.Done()
+			continue
+		}
+
 		var (
 			namespace  = input.namespace
 			entry      = input.series
@@ -945,10 +988,11 @@ func (s *commitLogSource) logAccumulateOutcome(
 // down or if the commitlog contained data that the peers do not have.
 func (s commitLogSource) shouldReturnUnfulfilled(
 	workers []*accumulateWorker,
+	tombstoneErrors int,
 	encounteredCorruptData bool,
 	initialTopologyState *topology.StateSnapshot,
 ) (bool, error) {
-	errs := 0
+	errs := tombstoneErrors
 	for _, worker := range workers {
 		errs += worker.numErrors
 	}
@@ -225,6 +225,77 @@ func testReadUnorderedValues(t *testing.T, opts Options, md namespace.Metadata,
 	tester.EnsureNoLoadedBlocks()
 }
 
+func TestReadTombstones(t *testing.T) {
+	opts := testDefaultOpts
+	md := testNsMetadata(t)
+	nsCtx := namespace.NewContextFrom(md)
+	src := newCommitLogSource(opts, fs.Inspection{}).(*commitLogSource)
+
+	blockSize := md.Options().RetentionOptions().BlockSize()
+	now := time.Now()
+	start := now.Truncate(blockSize).Add(-blockSize)
+	end := now.Truncate(blockSize)
+
+	ranges := xtime.Ranges{}
+	ranges = ranges.AddRange(xtime.Range{
+		Start: start,
+		End:   end,
+	})
+
+	foo := ts.Series{Namespace: nsCtx.ID, Shard: 0, ID: ident.StringID("foo")}
+	bar := ts.Series{Namespace: nsCtx.ID, Shard: 1, ID: ident.StringID("bar")}
+
+	var values testValues
+	for i := 0; i < 100; i++ {
+		values = append(values,
+			testValue{foo, start.Add(time.Duration(i) * time.Second), float64(i), xtime.Second, nil},
+			testValue{bar, start.Add(time.Duration(i) * time.Second), float64(i), xtime.Second, nil})
+	}
+	tombstones := map[int]commitlog.Tombstone{
+		len(values): {
+			Range: xtime.Range{Start: start, End: start.Add(time.Minute)},
+		},
+		len(values) + 1: {
+			Range:       xtime.Range{Start: start, End: end},
+			FlushedOnly: true,
+		},
+	}
+	values = append(values,
+		testValue{foo, start, 0, xtime.Second, nil},
+		testValue{bar, start, 0, xtime.Second, nil},
+		// Writes after the delete are kept.
+		testValue{foo, start.Add(time.Second), 100, xtime.Second, nil})
+
+	src.newIteratorFn = func(
+		_ commitlog.IteratorOpts,
+	) (commitlog.Iterator, []commitlog.ErrorWithPath, error) {
+		iter := newTestCommitLogIterator(values, nil)
+		iter.tombstones = tombstones
+		return iter, nil, nil
+	}
+
+	targetRanges := result.ShardTimeRanges{0: ranges, 1: ranges}
+	tester := bootstrap.BuildNamespacesTester(t, testDefaultRunOpts, targetRanges, md)
+	defer tester.Finish()
+
+	tester.TestReadWith(src)
+	tester.TestUnfulfilledForNamespaceIsEmpty(md)
+
+	var expected testValues
+	for _, v := range values[:len(values)-3] {
+		if v.s.ID.Equal(foo.ID) && v.t.Before(start.Add(time.Minute)) {
+			continue
+		}
+		expected = append(expected, v)
+	}
+	expected = append(expected, values[len(values)-1])
+
+	read := tester.EnsureDumpWritesForNamespace(md)
+	require.Equal(t, 2, len(read))
+	enforceValuesAreCorrect(t, expected, read)
+	tester.EnsureNoLoadedBlocks()
+}
+
 // TestReadHandlesDifferentSeriesWithIdenticalUniqueIndex was added as a
 // regression test to make sure that the commit log bootstrapper does not make
 // any assumptions about series having a unique index because that only holds
@@ -492,6 +563,10 @@ type testCommitLogIterator struct {
 	idx    int
 	err    error
 	closed bool
+
+	// tombstones turns the values at the given indexes into deletes of
+	// their series.
+	tombstones map[int]commitlog.Tombstone
 }
 
 func newTestCommitLogIterator(values testValues, err error) *testCommitLogIterator {
@@ -518,6 +593,7 @@ func (i *testCommitLogIterator) Current() commitlog.LogEntry {
 			FileReadID:        uint64(idx) + 1,
 			SeriesUniqueIndex: v.s.UniqueIndex,
 		},
+		Tombstone: i.tombstones[idx],
 	}
 }
 
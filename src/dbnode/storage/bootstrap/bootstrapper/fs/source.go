@@ -767,6 +767,10 @@ func (s *fileSystemSource) bootstrapFromIndexPersistedBlocks(
 			Start: indexBlockStart,
 			End:   indexBlockStart.Add(indexBlockSize),
 		}
+		if info.BlockSize > int64(indexBlockSize) {
+			// Fileset compacted from multiple flushed index blocks.
+			indexBlockRange.End = indexBlockStart.Add(time.Duration(info.BlockSize))
+		}
 		willFulfill := result.ShardTimeRanges{}
 		for _, shard := range info.Shards {
 			tr, ok := shardsTimeRanges[shard]
@@ -40,3 +40,8 @@ func NewSegment(segment segment.Segment, persisted bool) *Segment {
 func (s *Segment) IsPersisted() bool {
 	return s.persisted
 }
+
+// Unwrap returns the underlying segment.
+func (s *Segment) Unwrap() segment.Segment {
+	return s.Segment
+}
@@ -279,6 +279,28 @@ func (a *TestDataAccumulator) checkoutSeriesWithLock(
 				return true, nil
 			}).AnyTimes()
 
+	mockSeries.EXPECT().
+		DeleteRange(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(start, end time.Time, _ namespace.Context) error {
+			a.Lock()
+			remaining := a.writeMap[stringID][:0]
+			for _, value := range a.writeMap[stringID] {
+				if !value.Timestamp.Before(start) && value.Timestamp.Before(end) {
+					continue
+				}
+				remaining = append(remaining, value)
+			}
+			a.writeMap[stringID] = remaining
+			a.Unlock()
+			return nil
+		}).AnyTimes()
+
+	// Deletes of flushed data do not affect the writes accumulated in memory.
+	mockSeries.EXPECT().
+		DeleteFlushedRange(gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
 	result := CheckoutSeriesResult{
 		Shard:       shardID,
 		Series:      mockSeries,
@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
@@ -947,6 +948,10 @@ func (d *db) Repair() error {
 	return d.mediator.Repair()
 }
 
+func (d *db) RepairStatus() []repair.NamespaceRepairStatus {
+	return d.mediator.RepairStatus()
+}
+
 func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	n, err := d.namespaceFor(namespace)
 	if err != nil {
@@ -955,6 +960,19 @@ func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	return n.Truncate()
 }
 
+func (d *db) DeleteTagged(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	start, end time.Time,
+) (int64, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return 0, err
+	}
+	return n.DeleteTagged(ctx, query, start, end)
+}
+
 func (d *db) IsOverloaded() bool {
 	queueSize := float64(d.commitLog.QueueLength())
 	queueCapacity := float64(d.opts.CommitLogOptions().BacklogQueueSize())
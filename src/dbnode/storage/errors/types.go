@@ -63,3 +63,32 @@ func IsUnknownNamespaceError(err error) bool {
 	_, ok := nsErr.(unknownNamespace)
 	return ok
 }
+
+// NewRateLimitExceededError returns a new retryable error indicating that a
+// rate limit of a namespace was exceeded.
+func NewRateLimitExceededError(namespace, limit string) error {
+	return xerrors.NewRetryableError(rateLimitExceeded{
+		namespace: namespace,
+		limit:     limit,
+	})
+}
+
+type rateLimitExceeded struct {
+	namespace string
+	limit     string
+}
+
+func (e rateLimitExceeded) Error() string {
+	return fmt.Sprintf("rate limit exceeded for namespace %s: %s",
+		e.namespace, e.limit)
+}
+
+// IsRateLimitExceededError returns true if this is a rate limit exceeded error.
+func IsRateLimitExceededError(err error) bool {
+	limitErr := xerrors.GetInnerRetryableError(err)
+	if limitErr == nil {
+		return false
+	}
+	_, ok := limitErr.(rateLimitExceeded)
+	return ok
+}
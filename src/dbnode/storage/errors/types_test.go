@@ -31,3 +31,12 @@ func TestUnknownNamespaceError(t *testing.T) {
 	require.Equal(t, "unknown namespace: ns", err.Error())
 	require.True(t, IsUnknownNamespaceError(err))
 }
+
+func TestRateLimitExceededError(t *testing.T) {
+	err := NewRateLimitExceededError("ns", "writeDatapointsPerSecond")
+	require.Equal(t,
+		"rate limit exceeded for namespace ns: writeDatapointsPerSecond",
+		err.Error())
+	require.True(t, IsRateLimitExceededError(err))
+	require.False(t, IsRateLimitExceededError(NewUnknownNamespaceError("ns")))
+}
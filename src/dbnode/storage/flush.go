@@ -165,6 +165,14 @@ func (m *flushManager) Flush(startTime time.Time) error {
 		// value by however many bytes had been tracked when the cold flush began.
 		memTracker.DecPendingLoadedBytes()
 
+		// Deletes that are yet to be removed from disk are only recorded in the
+		// commit logs, which may be cleaned up once the snapshot succeeds, so
+		// they need to be written to the rotated commit log before snapshotting.
+		if err = m.logPendingDeletes(namespaces); err != nil {
+			multiErr = multiErr.Add(err)
+			return multiErr.FinalError()
+		}
+
 		if err = m.dataSnapshot(namespaces, startTime, rotatedCommitlogID); err != nil {
 			multiErr = multiErr.Add(err)
 		}
@@ -235,6 +243,18 @@ func (m *flushManager) dataColdFlush(
 	return multiErr.FinalError()
 }
 
+func (m *flushManager) logPendingDeletes(
+	namespaces []databaseNamespace,
+) error {
+	multiErr := xerrors.NewMultiError()
+	for _, ns := range namespaces {
+		if err := ns.LogPendingDeletes(); err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+	return multiErr.FinalError()
+}
+
 func (m *flushManager) dataSnapshot(
 	namespaces []databaseNamespace,
 	startTime time.Time,
@@ -103,6 +103,13 @@ func (m *fsMergeWithMem) fetchBlocks(
 	return nil, false, nil
 }
 
+func (m *fsMergeWithMem) Tombstones(
+	seriesID ident.ID,
+	blockStart xtime.UnixNano,
+) xtime.Ranges {
+	return m.shard.SeriesTombstones(seriesID, blockStart.ToTime())
+}
+
 // The data passed to ForEachRemaining (through the fs.ForEachRemainingFn) is
 // basically a copy that will be finalized when the context is closed, but the
 // ID and tags are expected to live for as long as the caller of the MergeWith
@@ -42,6 +42,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/dbnode/storage/index/convert"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
@@ -112,6 +113,7 @@ type nsIndex struct {
 	// NB(r): Use a pooled goroutine worker once pooled goroutine workers
 	// support timeouts for query workers pool.
 	queryWorkersPool xsync.WorkerPool
+	queryLimits      limits.QueryLimits
 
 	// queriesWg tracks outstanding queries to ensure
 	// we wait for all queries to complete before actually closing
@@ -123,6 +125,17 @@ type nsIndex struct {
 	// forwardIndexDice determines if an incoming index write should be dual
 	// written to the next block.
 	forwardIndexDice forwardIndexDice
+
+	// tombstones tracks the deleted time ranges of series so that they are
+	// not returned by queries that only span deleted data.
+	tombstones nsIndexTombstones
+}
+
+type nsIndexTombstones struct {
+	sync.RWMutex
+
+	// ranges holds the sorted deleted ranges of each series.
+	ranges map[string][]xtime.Range
 }
 
 type nsIndexState struct {
@@ -307,6 +320,7 @@ func newNamespaceIndexWithOptions(
 		aggregateResultsPool: indexOpts.AggregateResultsPool(),
 
 		queryWorkersPool: newIndexOpts.opts.QueryIDsWorkerPool(),
+		queryLimits:      indexOpts.QueryLimits(),
 		metrics:          newNamespaceIndexMetrics(indexOpts, instrumentOpts),
 	}
 
@@ -332,6 +346,15 @@ func newNamespaceIndexWithOptions(
 	}
 	idx.state.insertQueue = queue
 
+	if compactionOpts := indexOpts.FlushedBlockCompactionOptions(); compactionOpts.Enabled &&
+		!canCompactFlushedBlocks(compactionOpts, idx.blockSize) {
+		idx.logger.Warn("index compaction block size is not a multiple of the index block size, "+
+			"flushed index blocks will not be compacted",
+			zap.Stringer("namespace", nsMD.ID()),
+			zap.Duration("compactionBlockSize", compactionOpts.BlockSize),
+			zap.Duration("blockSize", idx.blockSize))
+	}
+
 	// allocate the current block to ensure we're able to index as soon as we return
 	currentBlock := nowFn().Truncate(idx.blockSize)
 	idx.state.RLock()
@@ -670,7 +693,21 @@ func (i *nsIndex) Bootstrap(
 
 	var multiErr xerrors.MultiError
 	for blockStart, blockResults := range bootstrapResults {
-		block, err := i.ensureBlockPresentWithRLock(blockStart.ToTime())
+		var (
+			block     index.Block
+			err       error
+			blockSize = i.bootstrapBlockSize(blockStart.ToTime(), blockResults)
+		)
+		if blockSize == i.blockSize {
+			block, err = i.ensureBlockPresentWithRLock(blockStart.ToTime())
+		} else {
+			// Results of a fileset compacted from multiple flushed blocks.
+			block, err = i.ensureCompactedBlockPresentWithRLock(blockStart.ToTime(), blockSize)
+		}
+		if err == errDbIndexCompactedBlockConflict {
+			multiErr = multiErr.Add(err)
+			continue
+		}
 		if err != nil { // should never happen
 			multiErr = multiErr.Add(i.unableToAllocBlockInvariantError(err))
 			continue
@@ -697,6 +734,8 @@ func (i *nsIndex) Tick(c context.Cancellable, startTime time.Time) (namespaceInd
 		lastSealableBlockStart     = retention.FlushTimeEndForBlockSize(i.blockSize, startTime.Add(-i.bufferPast))
 	)
 
+	i.expireTombstones(earliestBlockStartToRetain)
+
 	i.state.Lock()
 	defer func() {
 		i.updateBlockStartsWithLock()
@@ -712,8 +751,10 @@ func (i *nsIndex) Tick(c context.Cancellable, startTime time.Time) (namespaceInd
 			return result, multiErr.FinalError()
 		}
 
-		// drop any blocks past the retention period
-		if blockStart.ToTime().Before(earliestBlockStartToRetain) {
+		// drop any blocks past the retention period, compacted blocks span
+		// multiple block starts so are only dropped once all of them are.
+		if blockStart.ToTime().Before(earliestBlockStartToRetain) &&
+			!block.EndTime().After(earliestBlockStartToRetain) {
 			multiErr = multiErr.Add(block.Close())
 			delete(i.state.blocksByTime, blockStart)
 			result.NumBlocksEvicted++
@@ -784,7 +825,10 @@ func (i *nsIndex) Flush(
 		}
 	}
 	i.metrics.BlocksEvictedMutableSegments.Inc(int64(evicted))
-	return nil
+
+	// Now that the blocks are flushed, compact the flushed blocks that are
+	// old enough into larger blocks.
+	return i.compactFlushedBlocks(flush)
 }
 
 func (i *nsIndex) flushableBlocks(
@@ -960,6 +1004,91 @@ func (i *nsIndex) shardsFilterID() func(id ident.ID) bool {
 	return v
 }
 
+// queryFilterID returns a filter that excludes IDs not owned by this node
+// and IDs that have been deleted for the entire range of the query.
+func (i *nsIndex) queryFilterID(opts index.QueryOptions) func(id ident.ID) bool {
+	shardsFilterID := i.shardsFilterID()
+
+	i.tombstones.RLock()
+	hasTombstones := len(i.tombstones.ranges) > 0
+	i.tombstones.RUnlock()
+	if !hasTombstones {
+		return shardsFilterID
+	}
+
+	queryRange := xtime.Range{
+		Start: opts.StartInclusive,
+		End:   opts.EndExclusive,
+	}
+	return func(id ident.ID) bool {
+		if shardsFilterID != nil && !shardsFilterID(id) {
+			return false
+		}
+
+		// NB: The string conversion in the map lookup does not allocate.
+		i.tombstones.RLock()
+		deleted := i.tombstones.ranges[string(id.Bytes())]
+		i.tombstones.RUnlock()
+
+		// Walk the sorted deleted ranges to check whether they cover the
+		// entire query range without any gaps.
+		covered := queryRange.Start
+		for _, tr := range deleted {
+			if tr.Start.After(covered) {
+				break
+			}
+			if tr.End.After(covered) {
+				covered = tr.End
+			}
+			if !covered.Before(queryRange.End) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func (i *nsIndex) Tombstone(ids []ident.ID, tr xtime.Range) {
+	if len(ids) == 0 || tr.IsEmpty() {
+		return
+	}
+
+	i.tombstones.Lock()
+	if i.tombstones.ranges == nil {
+		i.tombstones.ranges = make(map[string][]xtime.Range, len(ids))
+	}
+	for _, id := range ids {
+		key := id.String()
+		ranges := xtime.NewRanges(i.tombstones.ranges[key]...).AddRange(tr)
+		i.tombstones.ranges[key] = rangesToSlice(ranges)
+	}
+	i.tombstones.Unlock()
+}
+
+func (i *nsIndex) expireTombstones(earliestToRetain time.Time) {
+	expired := xtime.Range{Start: time.Time{}, End: earliestToRetain}
+
+	i.tombstones.Lock()
+	for key, deleted := range i.tombstones.ranges {
+		ranges := xtime.NewRanges(deleted...).RemoveRange(expired)
+		if ranges.IsEmpty() {
+			delete(i.tombstones.ranges, key)
+			continue
+		}
+		i.tombstones.ranges[key] = rangesToSlice(ranges)
+	}
+	i.tombstones.Unlock()
+}
+
+func rangesToSlice(ranges xtime.Ranges) []xtime.Range {
+	result := make([]xtime.Range, 0, ranges.Len())
+	iter := ranges.Iter()
+	for iter.Next() {
+		result = append(result, iter.Value())
+	}
+	return result
+}
+
 func (i *nsIndex) Query(
 	ctx context.Context,
 	query index.Query,
@@ -981,7 +1110,7 @@ func (i *nsIndex) Query(
 	results := i.resultsPool.Get()
 	results.Reset(i.nsMetadata.ID(), index.QueryResultsOptions{
 		SizeLimit: opts.Limit,
-		FilterID:  i.shardsFilterID(),
+		FilterID:  i.queryFilterID(opts),
 	})
 	ctx.RegisterFinalizer(results)
 	exhaustive, err := i.query(ctx, query, results, opts, i.execBlockQueryFn, logFields)
@@ -1131,8 +1260,7 @@ func (i *nsIndex) queryWithSpan(
 		// number of results that we're allowed to return. If thats the case, there
 		// is no value in kicking off more parallel queries, so we break out of
 		// the loop.
-		size := results.Size()
-		alreadyExceededLimit := opts.LimitExceeded(size)
+		alreadyExceededLimit := !opts.Exhaustive(results.Size(), results.TotalDocsCount())
 		if alreadyExceededLimit {
 			state.Lock()
 			state.exhaustive = false
@@ -1218,6 +1346,17 @@ func (i *nsIndex) queryWithSpan(
 		return false, err
 	}
 
+	if !exhaustive {
+		if opts.RequireExhaustive {
+			i.metrics.QueryNonExhaustiveLimitError.Inc(1)
+			return false, limits.NewQueryLimitExceededError(fmt.Sprintf(
+				"query exceeded limit: require_exhaustive=true, "+
+					"series_limit=%d, series_matched=%d, docs_limit=%d, docs_matched=%d",
+				opts.Limit, results.Size(), opts.DocsLimit, results.TotalDocsCount()))
+		}
+		i.metrics.QueryNonExhaustiveSuccess.Inc(1)
+	}
+
 	return exhaustive, nil
 }
 
@@ -1308,9 +1447,27 @@ func (i *nsIndex) execBlockAggregateQueryFn(
 func (i *nsIndex) timeoutForQueryWithRLock(
 	ctx context.Context,
 ) time.Duration {
-	// TODO(r): Allow individual queries to specify timeouts using
-	// deadlines passed by the context.
-	return i.state.runtimeOpts.defaultQueryTimeout
+	timeout := i.state.runtimeOpts.defaultQueryTimeout
+	goCtx, ok := ctx.GoContext()
+	if !ok {
+		return timeout
+	}
+	deadline, ok := goCtx.Deadline()
+	if !ok {
+		return timeout
+	}
+	// Use the deadline of the query if it is sooner than the default timeout,
+	// so that a query does not keep querying blocks after the caller has
+	// given up on it.
+	if untilDeadline := deadline.Sub(i.nowFn()); timeout <= 0 || untilDeadline < timeout {
+		timeout = untilDeadline
+	}
+	if timeout <= 0 {
+		// Already past the deadline, use the smallest positive timeout so
+		// that the query times out instead of running without a timeout.
+		timeout = time.Nanosecond
+	}
+	return timeout
 }
 
 func (i *nsIndex) overriddenOptsForQueryWithRLock(
@@ -1324,6 +1481,11 @@ func (i *nsIndex) overriddenOptsForQueryWithRLock(
 			zap.Int64("maxAllowed", i.state.runtimeOpts.maxQueryLimit)) // FOLLOWUP(prateek): log query too once it's serializable.
 		opts.Limit = int(i.state.runtimeOpts.maxQueryLimit)
 	}
+	// Override query docs limit if needed.
+	if max := i.queryLimits.MaxDocsPerQuery(); max > 0 &&
+		(opts.DocsLimit == 0 || opts.DocsLimit > max) {
+		opts.DocsLimit = max
+	}
 	return opts
 }
 
@@ -1481,8 +1643,13 @@ func (i *nsIndex) CleanupExpiredFileSets(t time.Time) error {
 		return err
 	}
 
-	// and delete them
-	return i.deleteFilesFn(filesets)
+	// and delete them, along with any postings lists persisted for them.
+	multiErr := xerrors.NewMultiError()
+	if plCache := i.opts.IndexOptions().PersistentPostingsListCache(); plCache != nil {
+		multiErr = multiErr.Add(plCache.DeleteBefore(nsID.String(), earliestBlockStartToRetain))
+	}
+	multiErr = multiErr.Add(i.deleteFilesFn(filesets))
+	return multiErr.FinalError()
 }
 
 func (i *nsIndex) Close() error {
@@ -1551,6 +1718,10 @@ type nsIndexMetrics struct {
 	QueryAfterClose              tally.Counter
 	InsertEndToEndLatency        tally.Timer
 	BlocksEvictedMutableSegments tally.Counter
+	QueryNonExhaustiveSuccess    tally.Counter
+	QueryNonExhaustiveLimitError tally.Counter
+	BlocksCompacted              tally.Counter
+	BlockCompactionErrors        tally.Counter
 	BlockMetrics                 nsIndexBlocksMetrics
 }
 
@@ -1575,7 +1746,19 @@ func newNamespaceIndexMetrics(
 			scope.Timer("insert-end-to-end-latency"),
 			iopts.MetricsSamplingRate()),
 		BlocksEvictedMutableSegments: scope.Counter("blocks-evicted-mutable-segments"),
-		BlockMetrics:                 newNamespaceIndexBlocksMetrics(opts, blocksScope),
+		QueryNonExhaustiveSuccess: scope.Tagged(map[string]string{
+			"exhaustive": "false",
+			"result":     "success",
+		}).Counter("query"),
+		QueryNonExhaustiveLimitError: scope.Tagged(map[string]string{
+			"exhaustive": "false",
+			"result":     "error_require_exhaustive",
+		}).Counter("query"),
+		BlocksCompacted: scope.Counter("blocks-compacted"),
+		BlockCompactionErrors: scope.Tagged(map[string]string{
+			"error_type": "block-compaction",
+		}).Counter("index-error"),
+		BlockMetrics: newNamespaceIndexBlocksMetrics(opts, blocksScope),
 	}
 }
 
@@ -37,7 +37,8 @@ type aggregatedResults struct {
 	nsID          ident.ID
 	aggregateOpts AggregateResultsOptions
 
-	resultsMap *AggregateResultsMap
+	resultsMap     *AggregateResultsMap
+	totalDocsCount int
 
 	idPool    ident.Pool
 	bytesPool pool.CheckedBytesPool
@@ -70,6 +71,7 @@ func (r *aggregatedResults) Reset(
 	r.Lock()
 
 	r.aggregateOpts = aggregateOpts
+	r.totalDocsCount = 0
 
 	// finalize existing held nsID
 	if r.nsID != nil {
@@ -100,6 +102,7 @@ func (r *aggregatedResults) AddDocuments(batch []doc.Document) (int, error) {
 	r.Lock()
 	err := r.addDocumentsBatchWithLock(batch)
 	size := r.resultsMap.Len()
+	r.totalDocsCount += len(batch)
 	r.Unlock()
 	return size, err
 }
@@ -294,6 +297,13 @@ func (r *aggregatedResults) Size() int {
 	return l
 }
 
+func (r *aggregatedResults) TotalDocsCount() int {
+	r.RLock()
+	v := r.totalDocsCount
+	r.RUnlock()
+	return v
+}
+
 func (r *aggregatedResults) Finalize() {
 	r.Reset(nil, AggregateResultsOptions{})
 	if r.pool == nil {
@@ -28,9 +28,11 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
 	"github.com/m3db/m3/src/dbnode/storage/index/segments"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	m3ninxindex "github.com/m3db/m3/src/m3ninx/index"
@@ -184,6 +186,9 @@ type blockShardRangesSegments struct {
 type BlockOptions struct {
 	ForegroundCompactorMmapDocsData bool
 	BackgroundCompactorMmapDocsData bool
+	// BlockSize overrides the index block size of the namespace, it is set
+	// for blocks that were compacted from multiple flushed blocks.
+	BlockSize time.Duration
 }
 
 // NewBlock returns a new Block, representing a complete reverse index for the
@@ -195,6 +200,9 @@ func NewBlock(
 	indexOpts Options,
 ) (Block, error) {
 	blockSize := md.Options().IndexOptions().BlockSize()
+	if opts.BlockSize > 0 {
+		blockSize = opts.BlockSize
+	}
 	iopts := indexOpts.InstrumentOptions()
 	b := &block{
 		state:      blockStateOpen,
@@ -877,6 +885,8 @@ func (b *block) queryWithSpan(
 	var (
 		iterCloser = safeCloser{closable: iter}
 		size       = results.Size()
+		docsCount  = results.TotalDocsCount()
+		docsLimit  = b.opts.QueryLimits().DocsLimit()
 		docsPool   = b.opts.DocumentArrayPool()
 		batch      = docsPool.Get()
 		batchSize  = cap(batch)
@@ -892,7 +902,7 @@ func (b *block) queryWithSpan(
 	}()
 
 	for iter.Next() {
-		if opts.LimitExceeded(size) {
+		if opts.LimitExceeded(size) || opts.DocsLimitExceeded(docsCount+len(batch)) {
 			break
 		}
 
@@ -901,7 +911,7 @@ func (b *block) queryWithSpan(
 			continue
 		}
 
-		batch, size, err = b.addQueryResults(cancellable, results, batch)
+		batch, size, docsCount, err = b.addQueryResults(cancellable, docsLimit, results, batch)
 		if err != nil {
 			return false, err
 		}
@@ -909,7 +919,7 @@ func (b *block) queryWithSpan(
 
 	// Add last batch to results if remaining.
 	if len(batch) > 0 {
-		batch, size, err = b.addQueryResults(cancellable, results, batch)
+		batch, size, docsCount, err = b.addQueryResults(cancellable, docsLimit, results, batch)
 		if err != nil {
 			return false, err
 		}
@@ -922,7 +932,7 @@ func (b *block) queryWithSpan(
 		return false, err
 	}
 
-	exhaustive := !opts.LimitExceeded(size)
+	exhaustive := opts.Exhaustive(size, docsCount)
 	return exhaustive, nil
 }
 
@@ -935,18 +945,26 @@ func (b *block) closeExecutorAsync(exec search.Executor) {
 
 func (b *block) addQueryResults(
 	cancellable *resource.CancellableLifetime,
+	docsLimit limits.LookbackLimit,
 	results BaseResults,
 	batch []doc.Document,
-) ([]doc.Document, int, error) {
+) ([]doc.Document, int, int, error) {
+	// update the recently matched docs of the node, aborting the query if
+	// the limit has been exceeded.
+	if err := docsLimit.Inc(len(batch)); err != nil {
+		return batch, 0, 0, err
+	}
+
 	// checkout the lifetime of the query before adding results.
 	queryValid := cancellable.TryCheckout()
 	if !queryValid {
 		// query not valid any longer, do not add results and return early.
-		return batch, 0, errCancelledQuery
+		return batch, 0, 0, errCancelledQuery
 	}
 
 	// try to add the docs to the resource.
 	size, err := results.AddDocuments(batch)
+	docsCount := results.TotalDocsCount()
 
 	// immediately release the checkout on the lifetime of query.
 	cancellable.ReleaseCheckout()
@@ -959,7 +977,7 @@ func (b *block) addQueryResults(
 	batch = batch[:0]
 
 	// return results.
-	return batch, size, err
+	return batch, size, docsCount, err
 }
 
 // Aggregate acquires a read lock on the block so that the segments
@@ -1219,14 +1237,25 @@ func (b *block) AddResults(
 	}
 
 	var (
-		plCache         = b.opts.PostingsListCache()
-		readThroughOpts = b.opts.ReadThroughSegmentOptions()
-		segments        = results.Segments()
+		plCache           = b.opts.PostingsListCache()
+		persistentPLCache = b.opts.PersistentPostingsListCache()
+		readThroughOpts   = b.opts.ReadThroughSegmentOptions()
+		segments          = results.Segments()
 	)
 	readThroughSegments := make([]segment.Segment, 0, len(segments))
 	for _, seg := range segments {
 		readThroughSeg := seg
-		if immSeg, ok := seg.(segment.ImmutableSegment); ok {
+		if volumeSeg, ok := indexVolumeSegment(seg); ok && persistentPLCache != nil {
+			// segments of flushed index filesets also persist their postings lists.
+			var err error
+			readThroughSeg, err = NewPersistentReadThroughSegment(volumeSeg,
+				plCache, persistentPLCache, readThroughOpts)
+			if err != nil {
+				b.logger.Warn("could not load persisted postings lists",
+					zap.Stringer("volume", volumeSeg.Volume()), zap.Error(err))
+				readThroughSeg = NewReadThroughSegment(volumeSeg, plCache, readThroughOpts)
+			}
+		} else if immSeg, ok := seg.(segment.ImmutableSegment); ok {
 			// only wrap the immutable segments with a read through cache.
 			readThroughSeg = NewReadThroughSegment(immSeg, plCache, readThroughOpts)
 		}
@@ -1257,6 +1286,10 @@ func (b *block) AddResults(
 	// This is the case where the new segments can wholly replace the
 	// current set of blocks since unfullfilled by the new segments is zero.
 	multiErr := xerrors.NewMultiError()
+	if persistentPLCache != nil {
+		multiErr = multiErr.Add(b.deleteReplacedPersistedPostingsLists(
+			persistentPLCache, readThroughSegments))
+	}
 	for i, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
 			// Make sure to close the existing segments.
@@ -1269,6 +1302,68 @@ func (b *block) AddResults(
 	return multiErr.FinalError()
 }
 
+// deleteReplacedPersistedPostingsLists removes the persisted postings lists of
+// the volumes backing the current segments that are not backing any of the
+// replacement segments, i.e. volumes that have been compacted into a new one.
+func (b *block) deleteReplacedPersistedPostingsLists(
+	persistentPLCache *PersistentPostingsListCache,
+	replacements []segment.Segment,
+) error {
+	retained := make(map[persistentPostingsListCacheVolume]struct{}, len(replacements))
+	for _, seg := range replacements {
+		if volume, ok := readThroughSegmentVolume(seg); ok {
+			retained[newPersistentPostingsListCacheVolume(volume)] = struct{}{}
+		}
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, group := range b.shardRangesSegments {
+		for _, seg := range group.segments {
+			volume, ok := readThroughSegmentVolume(seg)
+			if !ok {
+				continue
+			}
+			key := newPersistentPostingsListCacheVolume(volume)
+			if _, ok := retained[key]; ok {
+				continue
+			}
+			// Only delete each volume once.
+			retained[key] = struct{}{}
+			multiErr = multiErr.Add(persistentPLCache.DeleteVolume(volume))
+		}
+	}
+	return multiErr.FinalError()
+}
+
+func readThroughSegmentVolume(seg segment.Segment) (persist.IndexSegmentVolume, bool) {
+	readThroughSeg, ok := seg.(*ReadThroughSegment)
+	if !ok {
+		return persist.IndexSegmentVolume{}, false
+	}
+	return readThroughSeg.Volume()
+}
+
+// indexVolumeSegment returns the segment of a flushed index fileset volume
+// that backs the provided segment, if any.
+func indexVolumeSegment(seg segment.Segment) (persist.IndexVolumeSegment, bool) {
+	for {
+		switch s := seg.(type) {
+		case persist.IndexVolumeSegment:
+			return s, true
+		case unwrapSegment:
+			seg = s.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// unwrapSegment is a segment that wraps another segment, such as the segments
+// returned by bootstrappers.
+type unwrapSegment interface {
+	Unwrap() segment.Segment
+}
+
 func (b *block) Tick(c context.Cancellable) (BlockTickResult, error) {
 	b.Lock()
 	defer b.Unlock()
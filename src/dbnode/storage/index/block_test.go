@@ -23,18 +23,23 @@ package index
 import (
 	stdlibctx "context"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/index/segment/mem"
 	"github.com/m3db/m3/src/m3ninx/search"
 	"github.com/m3db/m3/src/x/context"
@@ -715,6 +720,100 @@ func TestBlockMockQueryLimitExhaustive(t *testing.T) {
 	ctx.BlockingClose()
 }
 
+func TestBlockMockQueryDocsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, testMD, BlockOptions{}, testOpts)
+	require.NoError(t, err)
+
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	exec := search.NewMockExecutor(ctrl)
+	b.newExecutorFn = func() (search.Executor, error) {
+		return exec, nil
+	}
+
+	dIter := doc.NewMockIterator(ctrl)
+	gomock.InOrder(
+		exec.EXPECT().Execute(gomock.Any()).Return(dIter, nil),
+		dIter.EXPECT().Next().Return(true),
+		dIter.EXPECT().Current().Return(testDoc1()),
+		dIter.EXPECT().Next().Return(true),
+		dIter.EXPECT().Err().Return(nil),
+		dIter.EXPECT().Close().Return(nil),
+		exec.EXPECT().Close().Return(nil),
+	)
+	results := NewQueryResults(nil, QueryResultsOptions{}, testOpts)
+
+	ctx := context.NewContext()
+
+	exhaustive, err := b.Query(ctx, resource.NewCancellableLifetime(),
+		defaultQuery, QueryOptions{DocsLimit: 1}, results, emptyLogFields)
+	require.NoError(t, err)
+	require.False(t, exhaustive)
+
+	require.Equal(t, 1, results.Map().Len())
+	require.Equal(t, 1, results.TotalDocsCount())
+
+	// NB(r): Make sure to call finalizers blockingly (to finish
+	// the expected close calls)
+	ctx.BlockingClose()
+}
+
+func TestBlockMockQueryRecentDocsLimitExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	queryLimits, err := limits.NewQueryLimits(limits.QueryLimitsOptions{
+		DocsLimitOptions: limits.LookbackLimitOptions{
+			Limit:    1,
+			Lookback: time.Minute,
+		},
+	})
+	require.NoError(t, err)
+
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, testMD, BlockOptions{},
+		testOpts.SetQueryLimits(queryLimits))
+	require.NoError(t, err)
+
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	exec := search.NewMockExecutor(ctrl)
+	b.newExecutorFn = func() (search.Executor, error) {
+		return exec, nil
+	}
+
+	dIter := doc.NewMockIterator(ctrl)
+	gomock.InOrder(
+		exec.EXPECT().Execute(gomock.Any()).Return(dIter, nil),
+		dIter.EXPECT().Next().Return(true),
+		dIter.EXPECT().Current().Return(testDoc1()),
+		dIter.EXPECT().Next().Return(true),
+		dIter.EXPECT().Current().Return(testDoc2()),
+		dIter.EXPECT().Close().Return(nil),
+		exec.EXPECT().Close().Return(nil),
+	)
+	results := NewQueryResults(nil, QueryResultsOptions{}, testOpts)
+
+	ctx := context.NewContext()
+
+	_, err = b.Query(ctx, resource.NewCancellableLifetime(),
+		defaultQuery, QueryOptions{}, results, emptyLogFields)
+	require.Error(t, err)
+	require.True(t, limits.IsQueryLimitExceededError(err))
+
+	// NB(r): Make sure to call finalizers blockingly (to finish
+	// the expected close calls)
+	ctx.BlockingClose()
+}
+
 func TestBlockMockQueryMergeResultsMapLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1023,6 +1122,65 @@ func TestBlockAddResultsCoversCurrentData(t *testing.T) {
 	require.NoError(t, b.Close())
 }
 
+func TestBlockAddResultsDeletesReplacedPersistedPostingsLists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "postings-list-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	var (
+		compacted = persist.IndexSegmentVolume{
+			Namespace:  testMD.ID().String(),
+			BlockStart: start,
+		}
+		replacement = persist.IndexSegmentVolume{
+			Namespace:   testMD.ID().String(),
+			BlockStart:  start,
+			VolumeIndex: 1,
+		}
+		pl = newTestPersistedPostingsList(t, 1)
+	)
+
+	persistentCache := newTestPersistentPostingsListCache(t, dir)
+	require.NoError(t, persistentCache.Put(compacted, "field", "term", PatternTypeTerm, pl))
+	require.NoError(t, persistentCache.Put(replacement, "field", "term", PatternTypeTerm, pl))
+	require.NoError(t, persistentCache.Close())
+
+	persistentCache = newTestPersistentPostingsListCache(t, dir)
+	defer persistentCache.Close()
+
+	blk, err := NewBlock(start, testMD, BlockOptions{},
+		testOpts.SetPersistentPostingsListCache(persistentCache))
+	require.NoError(t, err)
+
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	seg1 := fst.NewMockSegment(ctrl)
+	require.NoError(t, b.AddResults(
+		result.NewIndexBlock(start, []segment.Segment{
+			persist.NewIndexVolumeSegment(seg1, compacted),
+		}, result.NewShardTimeRanges(start, start.Add(time.Hour), 1, 2, 3))))
+
+	seg2 := fst.NewMockSegment(ctrl)
+	seg1.EXPECT().Close().Return(nil)
+	require.NoError(t, b.AddResults(
+		result.NewIndexBlock(start, []segment.Segment{
+			persist.NewIndexVolumeSegment(seg2, replacement),
+		}, result.NewShardTimeRanges(start, start.Add(time.Hour), 1, 2, 3))))
+
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, persistentCache, compacted)))
+	require.Equal(t, 1, len(loadTestPersistedPostingsLists(t, persistentCache, replacement)))
+
+	require.NoError(t, b.Seal())
+	seg2.EXPECT().Close().Return(nil)
+	require.NoError(t, b.Close())
+}
+
 func TestBlockAddResultsDoesNotCoverCurrentData(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
@@ -99,6 +100,20 @@ func (mr *MockBaseResultsMockRecorder) Size() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockBaseResults)(nil).Size))
 }
 
+// TotalDocsCount mocks base method
+func (m *MockBaseResults) TotalDocsCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalDocsCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// TotalDocsCount indicates an expected call of TotalDocsCount
+func (mr *MockBaseResultsMockRecorder) TotalDocsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalDocsCount", reflect.TypeOf((*MockBaseResults)(nil).TotalDocsCount))
+}
+
 // AddDocuments mocks base method
 func (m *MockBaseResults) AddDocuments(batch []doc.Document) (int, error) {
 	m.ctrl.T.Helper()
@@ -177,6 +192,20 @@ func (mr *MockQueryResultsMockRecorder) Size() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockQueryResults)(nil).Size))
 }
 
+// TotalDocsCount mocks base method
+func (m *MockQueryResults) TotalDocsCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalDocsCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// TotalDocsCount indicates an expected call of TotalDocsCount
+func (mr *MockQueryResultsMockRecorder) TotalDocsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalDocsCount", reflect.TypeOf((*MockQueryResults)(nil).TotalDocsCount))
+}
+
 // AddDocuments mocks base method
 func (m *MockQueryResults) AddDocuments(batch []doc.Document) (int, error) {
 	m.ctrl.T.Helper()
@@ -342,6 +371,20 @@ func (mr *MockAggregateResultsMockRecorder) Size() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockAggregateResults)(nil).Size))
 }
 
+// TotalDocsCount mocks base method
+func (m *MockAggregateResults) TotalDocsCount() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalDocsCount")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// TotalDocsCount indicates an expected call of TotalDocsCount
+func (mr *MockAggregateResultsMockRecorder) TotalDocsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalDocsCount", reflect.TypeOf((*MockAggregateResults)(nil).TotalDocsCount))
+}
+
 // AddDocuments mocks base method
 func (m *MockAggregateResults) AddDocuments(batch []doc.Document) (int, error) {
 	m.ctrl.T.Helper()
@@ -1441,6 +1484,34 @@ func (mr *MockOptionsMockRecorder) PostingsListCache() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostingsListCache", reflect.TypeOf((*MockOptions)(nil).PostingsListCache))
 }
 
+// SetPersistentPostingsListCache mocks base method
+func (m *MockOptions) SetPersistentPostingsListCache(value *PersistentPostingsListCache) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPersistentPostingsListCache", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetPersistentPostingsListCache indicates an expected call of SetPersistentPostingsListCache
+func (mr *MockOptionsMockRecorder) SetPersistentPostingsListCache(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPersistentPostingsListCache", reflect.TypeOf((*MockOptions)(nil).SetPersistentPostingsListCache), value)
+}
+
+// PersistentPostingsListCache mocks base method
+func (m *MockOptions) PersistentPostingsListCache() *PersistentPostingsListCache {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistentPostingsListCache")
+	ret0, _ := ret[0].(*PersistentPostingsListCache)
+	return ret0
+}
+
+// PersistentPostingsListCache indicates an expected call of PersistentPostingsListCache
+func (mr *MockOptionsMockRecorder) PersistentPostingsListCache() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistentPostingsListCache", reflect.TypeOf((*MockOptions)(nil).PersistentPostingsListCache))
+}
+
 // SetReadThroughSegmentOptions mocks base method
 func (m *MockOptions) SetReadThroughSegmentOptions(value ReadThroughSegmentOptions) Options {
 	m.ctrl.T.Helper()
@@ -1469,6 +1540,34 @@ func (mr *MockOptionsMockRecorder) ReadThroughSegmentOptions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadThroughSegmentOptions", reflect.TypeOf((*MockOptions)(nil).ReadThroughSegmentOptions))
 }
 
+// SetFlushedBlockCompactionOptions mocks base method
+func (m *MockOptions) SetFlushedBlockCompactionOptions(value FlushedBlockCompactionOptions) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFlushedBlockCompactionOptions", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetFlushedBlockCompactionOptions indicates an expected call of SetFlushedBlockCompactionOptions
+func (mr *MockOptionsMockRecorder) SetFlushedBlockCompactionOptions(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFlushedBlockCompactionOptions", reflect.TypeOf((*MockOptions)(nil).SetFlushedBlockCompactionOptions), value)
+}
+
+// FlushedBlockCompactionOptions mocks base method
+func (m *MockOptions) FlushedBlockCompactionOptions() FlushedBlockCompactionOptions {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushedBlockCompactionOptions")
+	ret0, _ := ret[0].(FlushedBlockCompactionOptions)
+	return ret0
+}
+
+// FlushedBlockCompactionOptions indicates an expected call of FlushedBlockCompactionOptions
+func (mr *MockOptionsMockRecorder) FlushedBlockCompactionOptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushedBlockCompactionOptions", reflect.TypeOf((*MockOptions)(nil).FlushedBlockCompactionOptions))
+}
+
 // SetForwardIndexProbability mocks base method
 func (m *MockOptions) SetForwardIndexProbability(value float64) Options {
 	m.ctrl.T.Helper()
@@ -1552,3 +1651,31 @@ func (mr *MockOptionsMockRecorder) MmapReporter() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MmapReporter", reflect.TypeOf((*MockOptions)(nil).MmapReporter))
 }
+
+// SetQueryLimits mocks base method
+func (m *MockOptions) SetQueryLimits(value limits.QueryLimits) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetQueryLimits", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetQueryLimits indicates an expected call of SetQueryLimits
+func (mr *MockOptionsMockRecorder) SetQueryLimits(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetQueryLimits", reflect.TypeOf((*MockOptions)(nil).SetQueryLimits), value)
+}
+
+// QueryLimits mocks base method
+func (m *MockOptions) QueryLimits() limits.QueryLimits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryLimits")
+	ret0, _ := ret[0].(limits.QueryLimits)
+	return ret0
+}
+
+// QueryLimits indicates an expected call of QueryLimits
+func (mr *MockOptionsMockRecorder) QueryLimits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLimits", reflect.TypeOf((*MockOptions)(nil).QueryLimits))
+}
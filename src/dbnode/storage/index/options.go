@@ -25,6 +25,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
@@ -120,8 +121,11 @@ type opts struct {
 	foregroundCompactionPlannerOpts compaction.PlannerOptions
 	backgroundCompactionPlannerOpts compaction.PlannerOptions
 	postingsListCache               *PostingsListCache
+	persistentPostingsListCache     *PersistentPostingsListCache
 	readThroughSegmentOptions       ReadThroughSegmentOptions
+	flushedBlockCompactionOptions   FlushedBlockCompactionOptions
 	mmapReporter                    mmap.Reporter
+	queryLimits                     limits.QueryLimits
 }
 
 var undefinedUUIDFn = func() ([]byte, error) { return nil, errIDGenerationDisabled }
@@ -172,6 +176,7 @@ func NewOptions() Options {
 		aggResultsEntryArrayPool:        aggResultsEntryArrayPool,
 		foregroundCompactionPlannerOpts: defaultForegroundCompactionOpts,
 		backgroundCompactionPlannerOpts: defaultBackgroundCompactionOpts,
+		queryLimits:                     limits.NoOpQueryLimits(),
 	}
 	resultsPool.Init(func() QueryResults {
 		return NewQueryResults(nil, QueryResultsOptions{}, opts)
@@ -375,6 +380,16 @@ func (o *opts) PostingsListCache() *PostingsListCache {
 	return o.postingsListCache
 }
 
+func (o *opts) SetPersistentPostingsListCache(value *PersistentPostingsListCache) Options {
+	opts := *o
+	opts.persistentPostingsListCache = value
+	return &opts
+}
+
+func (o *opts) PersistentPostingsListCache() *PersistentPostingsListCache {
+	return o.persistentPostingsListCache
+}
+
 func (o *opts) SetReadThroughSegmentOptions(value ReadThroughSegmentOptions) Options {
 	opts := *o
 	opts.readThroughSegmentOptions = value
@@ -385,6 +400,16 @@ func (o *opts) ReadThroughSegmentOptions() ReadThroughSegmentOptions {
 	return o.readThroughSegmentOptions
 }
 
+func (o *opts) SetFlushedBlockCompactionOptions(value FlushedBlockCompactionOptions) Options {
+	opts := *o
+	opts.flushedBlockCompactionOptions = value
+	return &opts
+}
+
+func (o *opts) FlushedBlockCompactionOptions() FlushedBlockCompactionOptions {
+	return o.flushedBlockCompactionOptions
+}
+
 func (o *opts) SetForwardIndexProbability(value float64) Options {
 	opts := *o
 	opts.forwardIndexProbability = value
@@ -414,3 +439,13 @@ func (o *opts) SetMmapReporter(mmapReporter mmap.Reporter) Options {
 func (o *opts) MmapReporter() mmap.Reporter {
 	return o.mmapReporter
 }
+
+func (o *opts) SetQueryLimits(value limits.QueryLimits) Options {
+	opts := *o
+	opts.queryLimits = value
+	return &opts
+}
+
+func (o *opts) QueryLimits() limits.QueryLimits {
+	return o.queryLimits
+}
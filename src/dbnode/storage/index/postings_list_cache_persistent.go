@@ -0,0 +1,832 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package index
+
+import (
+	"container/list"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/postings/pilosa"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/cespare/xxhash"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPersistentPostingsListCacheQueueSize = 1024
+	defaultPersistentPostingsListCacheMaxBytes  = 1 << 30 // 1GiB
+
+	persistentPostingsListCacheFilePrefix = "segment"
+	persistentPostingsListCacheFileSuffix = ".db"
+	persistentPostingsListCacheTmpSuffix  = ".tmp"
+	persistentPostingsListCacheFileDelim  = "-"
+
+	persistentPostingsListCacheChecksumLen = 4
+)
+
+var (
+	errPersistentPostingsListCacheClosed        = errors.New("persistent postings list cache is closed")
+	errPersistentPostingsListCacheEntryCorrupt  = errors.New("persistent postings list cache entry is corrupt")
+	errPersistentPostingsListCacheNoPathPrefix  = errors.New("persistent postings list cache file path prefix is unset")
+	errPersistentPostingsListCacheInvalidVolume = errors.New("persistent postings list cache volume directory is invalid")
+)
+
+// PersistentPostingsListCacheOptions is the options struct for the persistent
+// postings list cache.
+type PersistentPostingsListCacheOptions struct {
+	// FilePathPrefix is the file path prefix of the index filesets, the cached
+	// postings lists are stored alongside them.
+	FilePathPrefix string
+	// MaxBytes is the maximum number of bytes of postings lists persisted to
+	// disk, the least recently written or loaded postings lists are removed
+	// once it is exceeded.
+	MaxBytes int64
+	// QueueSize is the number of postings lists that may be waiting to be
+	// written to disk, and the number of segments that may be waiting for their
+	// postings lists to be loaded, before further requests are dropped.
+	QueueSize int
+	// InstrumentOptions is the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+// PersistentPostingsListCacheLoadFn is called for each postings list loaded
+// from the persistent postings list cache.
+type PersistentPostingsListCacheLoadFn func(
+	field string,
+	pattern string,
+	patternType PatternType,
+	pl postings.List,
+)
+
+// persistentPostingsListCacheSegment guards the postings lists of a segment,
+// which may point into the mmap'd region of the segment, so that they are only
+// used while the segment is open.
+type persistentPostingsListCacheSegment interface {
+	// withOpen calls fn and returns true if the segment is open, the segment
+	// can not be closed until fn returns.
+	withOpen(fn func()) bool
+}
+
+// PersistentPostingsListCache persists the postings lists of queries against
+// segments of flushed index filesets so that the postings list cache can be
+// warmed when the segments are bootstrapped after a restart. Entries are keyed
+// by the volume of the segment, a checksum of the segment and a hash of the
+// query, so entries of a segment are never served for a different segment
+// that reuses its volume index. Entries are encoded, written and loaded in the
+// background and the least recently used entries are removed once the cache
+// exceeds its max bytes.
+type PersistentPostingsListCache struct {
+	sync.RWMutex
+
+	filePathPrefix string
+	maxBytes       int64
+	closed         bool
+	writesCh       chan persistentPostingsListCacheWrite
+	loadsCh        chan persistentPostingsListCacheLoad
+	writesDoneCh   chan struct{}
+	loadsDoneCh    chan struct{}
+
+	// fsLock serializes the mutations of the cache directory so that a pending
+	// write can not recreate the entries of a volume after it was deleted.
+	fsLock      sync.Mutex
+	invalidated map[persistentPostingsListCacheVolume]struct{}
+	retainFrom  map[string]time.Time
+
+	// entriesLock protects the entries persisted to disk, ordered from the
+	// most to the least recently written or loaded.
+	entriesLock sync.Mutex
+	entries     map[string]*list.Element
+	lru         *list.List
+	bytes       int64
+
+	logger  *zap.Logger
+	metrics persistentPostingsListCacheMetrics
+}
+
+type persistentPostingsListCacheVolume struct {
+	namespace   string
+	blockStart  int64
+	volumeIndex int
+}
+
+type persistentPostingsListCacheEntry struct {
+	filePath string
+	size     int64
+}
+
+type persistentPostingsListCacheWrite struct {
+	volume   persistentPostingsListCacheVolume
+	filePath string
+	key      []byte
+	pl       postings.List
+	segment  persistentPostingsListCacheSegment
+}
+
+type persistentPostingsListCacheLoad struct {
+	volume  persist.IndexSegmentVolume
+	segment persistentPostingsListCacheSegment
+	fn      PersistentPostingsListCacheLoadFn
+}
+
+// NewPersistentPostingsListCache creates a new persistent postings list cache.
+func NewPersistentPostingsListCache(
+	opts PersistentPostingsListCacheOptions,
+) (*PersistentPostingsListCache, error) {
+	if opts.FilePathPrefix == "" {
+		return nil, errPersistentPostingsListCacheNoPathPrefix
+	}
+
+	maxBytes := opts.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultPersistentPostingsListCacheMaxBytes
+	}
+
+	queueSize := opts.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultPersistentPostingsListCacheQueueSize
+	}
+
+	iopts := opts.InstrumentOptions
+	if iopts == nil {
+		iopts = instrument.NewOptions()
+	}
+
+	c := &PersistentPostingsListCache{
+		filePathPrefix: opts.FilePathPrefix,
+		maxBytes:       maxBytes,
+		writesCh:       make(chan persistentPostingsListCacheWrite, queueSize),
+		loadsCh:        make(chan persistentPostingsListCacheLoad, queueSize),
+		writesDoneCh:   make(chan struct{}),
+		loadsDoneCh:    make(chan struct{}),
+		invalidated:    make(map[persistentPostingsListCacheVolume]struct{}),
+		retainFrom:     make(map[string]time.Time),
+		entries:        make(map[string]*list.Element),
+		lru:            list.New(),
+		logger:         iopts.Logger(),
+		metrics:        newPersistentPostingsListCacheMetrics(iopts.MetricsScope()),
+	}
+
+	if err := c.loadEntries(); err != nil {
+		return nil, err
+	}
+
+	go c.writeLoop()
+	go c.loadLoop()
+	return c, nil
+}
+
+// loadEntries adds the entries persisted before a restart, ordered by when
+// they were written, and removes the entries that exceed the max bytes.
+func (c *PersistentPostingsListCache) loadEntries() error {
+	type fileEntry struct {
+		persistentPostingsListCacheEntry
+		modTime time.Time
+	}
+
+	var files []fileEntry
+	root := fs.IndexPostingsCacheDirPath(c.filePathPrefix)
+	err := filepath.Walk(root, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+
+		switch {
+		case info.IsDir():
+		case strings.HasSuffix(filePath, persistentPostingsListCacheTmpSuffix):
+			// Incomplete write.
+			if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		case strings.HasSuffix(filePath, persistentPostingsListCacheFileSuffix):
+			files = append(files, fileEntry{
+				persistentPostingsListCacheEntry: persistentPostingsListCacheEntry{
+					filePath: filePath,
+					size:     info.Size(),
+				},
+				modTime: info.ModTime(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, f := range files {
+		c.addEntry(f.filePath, f.size)
+	}
+
+	c.fsLock.Lock()
+	defer c.fsLock.Unlock()
+	return c.evictWithFSLock()
+}
+
+// Put enqueues the postings list of a query against the segment of the
+// provided volume to be encoded and written to disk, the write is dropped if
+// too many writes are already pending. The postings list must remain valid
+// until it has been written.
+func (c *PersistentPostingsListCache) Put(
+	volume persist.IndexSegmentVolume,
+	field string,
+	pattern string,
+	patternType PatternType,
+	pl postings.List,
+) error {
+	return c.put(volume, nil, field, pattern, patternType, pl)
+}
+
+// put enqueues the postings list of a query against the segment of the
+// provided volume, the postings list is only encoded if the segment is still
+// open since it may point into the mmap'd region of the segment.
+func (c *PersistentPostingsListCache) put(
+	volume persist.IndexSegmentVolume,
+	segment persistentPostingsListCacheSegment,
+	field string,
+	pattern string,
+	patternType PatternType,
+	pl postings.List,
+) error {
+	key := encodePersistentPostingsListCacheKey(field, pattern, patternType)
+	filePath := c.entryFilePath(volume, xxhash.Sum64(key))
+	if c.hasEntry(filePath) {
+		// Already persisted.
+		return nil
+	}
+
+	write := persistentPostingsListCacheWrite{
+		volume:   newPersistentPostingsListCacheVolume(volume),
+		filePath: filePath,
+		key:      key,
+		pl:       pl,
+		segment:  segment,
+	}
+
+	c.RLock()
+	defer c.RUnlock()
+	if c.closed {
+		return errPersistentPostingsListCacheClosed
+	}
+
+	select {
+	case c.writesCh <- write:
+	default:
+		c.metrics.dropped.Inc(1)
+	}
+	return nil
+}
+
+// Load calls the provided function with each of the postings lists persisted
+// for the segment of the provided volume. Corrupt entries and entries of a
+// segment with a different checksum are removed.
+func (c *PersistentPostingsListCache) Load(
+	volume persist.IndexSegmentVolume,
+	fn PersistentPostingsListCacheLoadFn,
+) error {
+	c.RLock()
+	closed := c.closed
+	c.RUnlock()
+	if closed {
+		return errPersistentPostingsListCacheClosed
+	}
+
+	dir := c.volumeDirPath(volume)
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var (
+		segmentPrefix = persistentPostingsListCacheFilePrefix +
+			persistentPostingsListCacheFileDelim +
+			strconv.Itoa(volume.SegmentIndex) +
+			persistentPostingsListCacheFileDelim
+		checksumPrefix = segmentPrefix +
+			fmt.Sprintf("%08x", volume.SegmentChecksum) +
+			persistentPostingsListCacheFileDelim
+	)
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() ||
+			!strings.HasPrefix(name, segmentPrefix) ||
+			!strings.HasSuffix(name, persistentPostingsListCacheFileSuffix) {
+			continue
+		}
+
+		filePath := path.Join(dir, name)
+		if !strings.HasPrefix(name, checksumPrefix) {
+			// Persisted for a previous segment with the same volume index.
+			if err := c.removeFile(filePath); err != nil {
+				return err
+			}
+			c.metrics.deletes.Inc(1)
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			// Evicted or deleted concurrently.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		field, pattern, patternType, pl, err := decodePersistentPostingsListCacheEntry(data)
+		if err != nil {
+			c.metrics.loadErrors.Inc(1)
+			c.logger.Warn("removing corrupt persisted postings list",
+				zap.String("path", filePath), zap.Error(err))
+			if err := c.removeFile(filePath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		c.touchEntry(filePath)
+		fn(field, pattern, patternType, pl)
+		c.metrics.loads.Inc(1)
+	}
+
+	return nil
+}
+
+// loadAsync enqueues loading the postings lists persisted for the provided
+// segment in the background, the provided function is only called while the
+// segment is open. The load is dropped if too many loads are already pending.
+func (c *PersistentPostingsListCache) loadAsync(
+	volume persist.IndexSegmentVolume,
+	segment persistentPostingsListCacheSegment,
+	fn PersistentPostingsListCacheLoadFn,
+) error {
+	c.RLock()
+	defer c.RUnlock()
+	if c.closed {
+		return errPersistentPostingsListCacheClosed
+	}
+
+	select {
+	case c.loadsCh <- persistentPostingsListCacheLoad{
+		volume:  volume,
+		segment: segment,
+		fn:      fn,
+	}:
+	default:
+		c.metrics.loadsDropped.Inc(1)
+	}
+	return nil
+}
+
+// DeleteVolume removes all the postings lists persisted for the segments of
+// the provided volume, it must be called once a volume has been replaced.
+func (c *PersistentPostingsListCache) DeleteVolume(volume persist.IndexSegmentVolume) error {
+	c.fsLock.Lock()
+	defer c.fsLock.Unlock()
+
+	c.invalidated[newPersistentPostingsListCacheVolume(volume)] = struct{}{}
+	c.metrics.deletes.Inc(1)
+
+	dir := c.volumeDirPath(volume)
+	c.removeEntriesInDir(dir)
+	return os.RemoveAll(dir)
+}
+
+// DeleteBefore removes all the postings lists persisted for volumes of the
+// namespace with a block start before the provided time.
+func (c *PersistentPostingsListCache) DeleteBefore(namespace string, t time.Time) error {
+	c.fsLock.Lock()
+	defer c.fsLock.Unlock()
+
+	if existing, ok := c.retainFrom[namespace]; !ok || existing.Before(t) {
+		c.retainFrom[namespace] = t
+	}
+	for volume := range c.invalidated {
+		if volume.namespace == namespace && volume.blockStart < t.UnixNano() {
+			delete(c.invalidated, volume)
+		}
+	}
+
+	dir := fs.NamespaceIndexPostingsCacheDirPath(c.filePathPrefix, ident.StringID(namespace))
+	volumeDirs, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, volumeDir := range volumeDirs {
+		if !volumeDir.IsDir() {
+			continue
+		}
+
+		blockStart, _, err := parsePersistentPostingsListCacheVolumeDir(volumeDir.Name())
+		if err != nil {
+			c.logger.Warn("skipping unrecognized persisted postings list directory",
+				zap.String("path", path.Join(dir, volumeDir.Name())), zap.Error(err))
+			continue
+		}
+		if !blockStart.Before(t) {
+			continue
+		}
+
+		volumeDirPath := path.Join(dir, volumeDir.Name())
+		c.removeEntriesInDir(volumeDirPath)
+		if err := os.RemoveAll(volumeDirPath); err != nil {
+			return err
+		}
+		c.metrics.deletes.Inc(1)
+	}
+
+	return nil
+}
+
+// Close stops accepting postings lists to persist and waits for the pending
+// writes to complete, pending loads are dropped.
+func (c *PersistentPostingsListCache) Close() error {
+	c.Lock()
+	if c.closed {
+		c.Unlock()
+		return errPersistentPostingsListCacheClosed
+	}
+	c.closed = true
+	close(c.writesCh)
+	close(c.loadsCh)
+	c.Unlock()
+
+	<-c.writesDoneCh
+	<-c.loadsDoneCh
+	return nil
+}
+
+func (c *PersistentPostingsListCache) writeLoop() {
+	defer close(c.writesDoneCh)
+
+	for write := range c.writesCh {
+		if err := c.write(write); err != nil {
+			c.metrics.writeErrors.Inc(1)
+			c.logger.Error("could not persist postings list",
+				zap.String("path", write.filePath), zap.Error(err))
+		}
+	}
+}
+
+func (c *PersistentPostingsListCache) loadLoop() {
+	defer close(c.loadsDoneCh)
+
+	for load := range c.loadsCh {
+		load := load
+		if !load.segment.withOpen(func() {}) {
+			continue
+		}
+
+		err := c.Load(load.volume, func(
+			field string,
+			pattern string,
+			patternType PatternType,
+			pl postings.List,
+		) {
+			load.segment.withOpen(func() {
+				load.fn(field, pattern, patternType, pl)
+			})
+		})
+		if err == errPersistentPostingsListCacheClosed {
+			continue
+		}
+		if err != nil {
+			c.metrics.loadErrors.Inc(1)
+			c.logger.Error("could not load persisted postings lists",
+				zap.Stringer("volume", load.volume), zap.Error(err))
+		}
+	}
+}
+
+func (c *PersistentPostingsListCache) write(write persistentPostingsListCacheWrite) error {
+	// NB: Encode outside of the fs lock since encoding large postings lists
+	// is expensive, the encoded entry is the checksum followed by the key and
+	// the pilosa encoded postings list.
+	var (
+		encoded   []byte
+		encodeErr error
+		encode    = func() {
+			encoded, encodeErr = pilosa.NewEncoder().Encode(write.pl)
+		}
+	)
+	if write.segment == nil {
+		encode()
+	} else if !write.segment.withOpen(encode) {
+		// The segment was closed before the postings list was encoded.
+		return nil
+	}
+	if encodeErr != nil {
+		return encodeErr
+	}
+
+	data := make([]byte, persistentPostingsListCacheChecksumLen,
+		persistentPostingsListCacheChecksumLen+len(write.key)+len(encoded))
+	data = append(data, write.key...)
+	data = append(data, encoded...)
+	binary.LittleEndian.PutUint32(data, digest.Checksum(data[persistentPostingsListCacheChecksumLen:]))
+
+	c.fsLock.Lock()
+	defer c.fsLock.Unlock()
+
+	if _, ok := c.invalidated[write.volume]; ok {
+		return nil
+	}
+	if retainFrom, ok := c.retainFrom[write.volume.namespace]; ok &&
+		write.volume.blockStart < retainFrom.UnixNano() {
+		return nil
+	}
+	if c.hasEntry(write.filePath) {
+		// Already persisted.
+		return nil
+	}
+
+	if err := os.MkdirAll(path.Dir(write.filePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmpFilePath := write.filePath + persistentPostingsListCacheTmpSuffix
+	if err := ioutil.WriteFile(tmpFilePath, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFilePath, write.filePath); err != nil {
+		return err
+	}
+
+	c.addEntry(write.filePath, int64(len(data)))
+	c.metrics.writes.Inc(1)
+	return c.evictWithFSLock()
+}
+
+// evictWithFSLock removes the least recently used entries until the entries
+// no longer exceed the max bytes.
+func (c *PersistentPostingsListCache) evictWithFSLock() error {
+	multiErr := xerrors.NewMultiError()
+	for {
+		c.entriesLock.Lock()
+		elem := c.lru.Back()
+		if c.bytes <= c.maxBytes || elem == nil {
+			c.entriesLock.Unlock()
+			return multiErr.FinalError()
+		}
+		entry := c.removeEntryWithLock(elem)
+		c.entriesLock.Unlock()
+
+		if err := os.Remove(entry.filePath); err != nil && !os.IsNotExist(err) {
+			multiErr = multiErr.Add(err)
+		}
+		c.metrics.evictions.Inc(1)
+	}
+}
+
+// removeFile removes a persisted entry.
+func (c *PersistentPostingsListCache) removeFile(filePath string) error {
+	c.fsLock.Lock()
+	defer c.fsLock.Unlock()
+
+	c.entriesLock.Lock()
+	if elem, ok := c.entries[filePath]; ok {
+		c.removeEntryWithLock(elem)
+	}
+	c.entriesLock.Unlock()
+
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *PersistentPostingsListCache) hasEntry(filePath string) bool {
+	c.entriesLock.Lock()
+	_, ok := c.entries[filePath]
+	c.entriesLock.Unlock()
+	return ok
+}
+
+func (c *PersistentPostingsListCache) addEntry(filePath string, size int64) {
+	c.entriesLock.Lock()
+	defer c.entriesLock.Unlock()
+
+	if elem, ok := c.entries[filePath]; ok {
+		c.removeEntryWithLock(elem)
+	}
+	c.entries[filePath] = c.lru.PushFront(&persistentPostingsListCacheEntry{
+		filePath: filePath,
+		size:     size,
+	})
+	c.bytes += size
+	c.metrics.bytes.Update(float64(c.bytes))
+}
+
+func (c *PersistentPostingsListCache) touchEntry(filePath string) {
+	c.entriesLock.Lock()
+	if elem, ok := c.entries[filePath]; ok {
+		c.lru.MoveToFront(elem)
+	}
+	c.entriesLock.Unlock()
+}
+
+func (c *PersistentPostingsListCache) removeEntriesInDir(dir string) {
+	prefix := dir + string(filepath.Separator)
+
+	c.entriesLock.Lock()
+	defer c.entriesLock.Unlock()
+	for filePath, elem := range c.entries {
+		if strings.HasPrefix(filePath, prefix) {
+			c.removeEntryWithLock(elem)
+		}
+	}
+}
+
+func (c *PersistentPostingsListCache) removeEntryWithLock(
+	elem *list.Element,
+) *persistentPostingsListCacheEntry {
+	entry := elem.Value.(*persistentPostingsListCacheEntry)
+	c.lru.Remove(elem)
+	delete(c.entries, entry.filePath)
+	c.bytes -= entry.size
+	c.metrics.bytes.Update(float64(c.bytes))
+	return entry
+}
+
+func (c *PersistentPostingsListCache) volumeDirPath(volume persist.IndexSegmentVolume) string {
+	return path.Join(
+		fs.NamespaceIndexPostingsCacheDirPath(c.filePathPrefix, ident.StringID(volume.Namespace)),
+		fmt.Sprintf("%d%s%d", volume.BlockStart.UnixNano(),
+			persistentPostingsListCacheFileDelim, volume.VolumeIndex))
+}
+
+func (c *PersistentPostingsListCache) entryFilePath(
+	volume persist.IndexSegmentVolume,
+	keyHash uint64,
+) string {
+	return path.Join(c.volumeDirPath(volume), fmt.Sprintf("%s%s%d%s%08x%s%016x%s",
+		persistentPostingsListCacheFilePrefix, persistentPostingsListCacheFileDelim,
+		volume.SegmentIndex, persistentPostingsListCacheFileDelim,
+		volume.SegmentChecksum, persistentPostingsListCacheFileDelim, keyHash,
+		persistentPostingsListCacheFileSuffix))
+}
+
+func newPersistentPostingsListCacheVolume(
+	volume persist.IndexSegmentVolume,
+) persistentPostingsListCacheVolume {
+	return persistentPostingsListCacheVolume{
+		namespace:   volume.Namespace,
+		blockStart:  volume.BlockStart.UnixNano(),
+		volumeIndex: volume.VolumeIndex,
+	}
+}
+
+func parsePersistentPostingsListCacheVolumeDir(name string) (time.Time, int, error) {
+	parts := strings.Split(name, persistentPostingsListCacheFileDelim)
+	if len(parts) != 2 {
+		return time.Time{}, 0, errPersistentPostingsListCacheInvalidVolume
+	}
+
+	blockStart, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	volumeIndex, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return time.Unix(0, blockStart), volumeIndex, nil
+}
+
+// encodePersistentPostingsListCacheKey encodes the query of an entry as the
+// pattern type followed by the length prefixed field and pattern.
+func encodePersistentPostingsListCacheKey(
+	field string,
+	pattern string,
+	patternType PatternType,
+) []byte {
+	key := make([]byte, 0, 1+2*binary.MaxVarintLen64+len(field)+len(pattern))
+	key = append(key, byte(patternType))
+	key = appendPersistentPostingsListCacheBytes(key, field)
+	key = appendPersistentPostingsListCacheBytes(key, pattern)
+	return key
+}
+
+func appendPersistentPostingsListCacheBytes(dst []byte, value string) []byte {
+	var lenBytes [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(lenBytes[:], uint64(len(value)))
+	dst = append(dst, lenBytes[:n]...)
+	return append(dst, value...)
+}
+
+func decodePersistentPostingsListCacheEntry(
+	data []byte,
+) (string, string, PatternType, postings.List, error) {
+	if len(data) < persistentPostingsListCacheChecksumLen+1 {
+		return "", "", 0, nil, errPersistentPostingsListCacheEntryCorrupt
+	}
+
+	checksum := binary.LittleEndian.Uint32(data)
+	data = data[persistentPostingsListCacheChecksumLen:]
+	if digest.Checksum(data) != checksum {
+		return "", "", 0, nil, errPersistentPostingsListCacheEntryCorrupt
+	}
+
+	patternType := PatternType(data[0])
+	data = data[1:]
+
+	field, data, err := readPersistentPostingsListCacheBytes(data)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+	pattern, data, err := readPersistentPostingsListCacheBytes(data)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	pl, err := pilosa.Unmarshal(data)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	return field, pattern, patternType, pl, nil
+}
+
+func readPersistentPostingsListCacheBytes(data []byte) (string, []byte, error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return "", nil, errPersistentPostingsListCacheEntryCorrupt
+	}
+	data = data[n:]
+	return string(data[:length]), data[length:], nil
+}
+
+type persistentPostingsListCacheMetrics struct {
+	writes       tally.Counter
+	writeErrors  tally.Counter
+	dropped      tally.Counter
+	loads        tally.Counter
+	loadErrors   tally.Counter
+	loadsDropped tally.Counter
+	deletes      tally.Counter
+	evictions    tally.Counter
+	bytes        tally.Gauge
+}
+
+func newPersistentPostingsListCacheMetrics(scope tally.Scope) persistentPostingsListCacheMetrics {
+	scope = scope.SubScope("persistent-postings-list-cache")
+	return persistentPostingsListCacheMetrics{
+		writes:       scope.Counter("writes"),
+		writeErrors:  scope.Counter("write-errors"),
+		dropped:      scope.Counter("dropped"),
+		loads:        scope.Counter("loads"),
+		loadErrors:   scope.Counter("load-errors"),
+		loadsDropped: scope.Counter("loads-dropped"),
+		deletes:      scope.Counter("deletes"),
+		evictions:    scope.Counter("evictions"),
+		bytes:        scope.Gauge("bytes"),
+	}
+}
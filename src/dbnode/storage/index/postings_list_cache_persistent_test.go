@@ -0,0 +1,302 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package index
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/m3ninx/postings"
+	"github.com/m3db/m3/src/m3ninx/postings/roaring"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testPersistedPostingsList struct {
+	field       string
+	pattern     string
+	patternType PatternType
+	pl          postings.List
+}
+
+func newTestPersistentPostingsListCache(
+	t *testing.T,
+	filePathPrefix string,
+) *PersistentPostingsListCache {
+	c, err := NewPersistentPostingsListCache(PersistentPostingsListCacheOptions{
+		FilePathPrefix: filePathPrefix,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+type testPersistentPostingsListCacheSegment struct {
+	closed bool
+}
+
+func (s *testPersistentPostingsListCacheSegment) withOpen(fn func()) bool {
+	if s.closed {
+		return false
+	}
+	fn()
+	return true
+}
+
+func newTestPersistedPostingsList(t *testing.T, ids ...postings.ID) postings.List {
+	pl := roaring.NewPostingsList()
+	for _, id := range ids {
+		require.NoError(t, pl.Insert(id))
+	}
+	return pl
+}
+
+func loadTestPersistedPostingsLists(
+	t *testing.T,
+	c *PersistentPostingsListCache,
+	volume persist.IndexSegmentVolume,
+) map[string]testPersistedPostingsList {
+	loaded := make(map[string]testPersistedPostingsList)
+	require.NoError(t, c.Load(volume, func(
+		field string,
+		pattern string,
+		patternType PatternType,
+		pl postings.List,
+	) {
+		loaded[field] = testPersistedPostingsList{
+			field:       field,
+			pattern:     pattern,
+			patternType: patternType,
+			pl:          pl,
+		}
+	}))
+	return loaded
+}
+
+func TestPersistentPostingsListCachePutLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postings-list-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		volume = persist.IndexSegmentVolume{
+			Namespace:   "ns",
+			BlockStart:  time.Unix(7200, 0),
+			VolumeIndex: 1,
+		}
+		otherSegment = persist.IndexSegmentVolume{
+			Namespace:    "ns",
+			BlockStart:   time.Unix(7200, 0),
+			VolumeIndex:  1,
+			SegmentIndex: 1,
+		}
+		expected = []testPersistedPostingsList{
+			{field: "a", pattern: "foo.*", patternType: PatternTypeRegexp, pl: newTestPersistedPostingsList(t, 1, 3)},
+			{field: "b", pattern: "bar", patternType: PatternTypeTerm, pl: newTestPersistedPostingsList(t, 2)},
+			{field: "c", pattern: emptyPattern, patternType: PatternTypeField, pl: newTestPersistedPostingsList(t, 1, 2, 3)},
+		}
+	)
+
+	c := newTestPersistentPostingsListCache(t, dir)
+	for _, e := range expected {
+		require.NoError(t, c.Put(volume, e.field, e.pattern, e.patternType, e.pl))
+	}
+	require.NoError(t, c.Put(otherSegment, "d", "baz", PatternTypeTerm,
+		newTestPersistedPostingsList(t, 4)))
+	// Close waits for the pending writes.
+	require.NoError(t, c.Close())
+
+	// Reload the entries as would happen after a restart.
+	c = newTestPersistentPostingsListCache(t, dir)
+	defer c.Close()
+
+	loaded := loadTestPersistedPostingsLists(t, c, volume)
+	require.Equal(t, len(expected), len(loaded))
+	for _, e := range expected {
+		actual, ok := loaded[e.field]
+		require.True(t, ok)
+		require.Equal(t, e.pattern, actual.pattern)
+		require.Equal(t, e.patternType, actual.patternType)
+		require.True(t, e.pl.Equal(actual.pl))
+	}
+
+	loaded = loadTestPersistedPostingsLists(t, c, otherSegment)
+	require.Equal(t, 1, len(loaded))
+	require.True(t, newTestPersistedPostingsList(t, 4).Equal(loaded["d"].pl))
+}
+
+func TestPersistentPostingsListCacheLoadRemovesCorruptEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postings-list-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	volume := persist.IndexSegmentVolume{
+		Namespace:  "ns",
+		BlockStart: time.Unix(7200, 0),
+	}
+
+	c := newTestPersistentPostingsListCache(t, dir)
+	defer c.Close()
+
+	corruptPath := c.entryFilePath(volume, 42)
+	require.NoError(t, os.MkdirAll(path.Dir(corruptPath), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile(corruptPath, []byte("corrupt"), 0644))
+
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, c, volume)))
+	_, err = os.Stat(corruptPath)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestPersistentPostingsListCacheDelete(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postings-list-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		compacted = persist.IndexSegmentVolume{Namespace: "ns", BlockStart: time.Unix(7200, 0)}
+		retained  = persist.IndexSegmentVolume{Namespace: "ns", BlockStart: time.Unix(7200, 0), VolumeIndex: 1}
+		expired   = persist.IndexSegmentVolume{Namespace: "ns", BlockStart: time.Unix(0, 0)}
+		otherNs   = persist.IndexSegmentVolume{Namespace: "other", BlockStart: time.Unix(0, 0)}
+		pl        = newTestPersistedPostingsList(t, 1)
+	)
+
+	c := newTestPersistentPostingsListCache(t, dir)
+	for _, volume := range []persist.IndexSegmentVolume{compacted, retained, expired, otherNs} {
+		require.NoError(t, c.Put(volume, "field", "term", PatternTypeTerm, pl))
+	}
+	require.NoError(t, c.Close())
+
+	c = newTestPersistentPostingsListCache(t, dir)
+	require.NoError(t, c.DeleteVolume(compacted))
+	require.NoError(t, c.DeleteBefore("ns", time.Unix(7200, 0)))
+
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, c, compacted)))
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, c, expired)))
+	require.Equal(t, 1, len(loadTestPersistedPostingsLists(t, c, retained)))
+	require.Equal(t, 1, len(loadTestPersistedPostingsLists(t, c, otherNs)))
+
+	// Postings lists of deleted volumes are no longer persisted.
+	require.NoError(t, c.Put(compacted, "field", "term", PatternTypeTerm, pl))
+	require.NoError(t, c.Put(expired, "field", "term", PatternTypeTerm, pl))
+	require.NoError(t, c.Close())
+
+	c = newTestPersistentPostingsListCache(t, dir)
+	defer c.Close()
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, c, compacted)))
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, c, expired)))
+}
+
+func TestPersistentPostingsListCacheLoadIgnoresOtherSegmentChecksum(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postings-list-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		volume = persist.IndexSegmentVolume{
+			Namespace:       "ns",
+			BlockStart:      time.Unix(7200, 0),
+			SegmentChecksum: 1,
+		}
+		// A different segment reusing the same volume index.
+		reused = persist.IndexSegmentVolume{
+			Namespace:       "ns",
+			BlockStart:      time.Unix(7200, 0),
+			SegmentChecksum: 2,
+		}
+	)
+
+	c := newTestPersistentPostingsListCache(t, dir)
+	require.NoError(t, c.Put(volume, "field", "term", PatternTypeTerm,
+		newTestPersistedPostingsList(t, 1)))
+	require.NoError(t, c.Close())
+
+	c = newTestPersistentPostingsListCache(t, dir)
+	defer c.Close()
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, c, reused)))
+
+	// The stale entry is removed.
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, c, volume)))
+}
+
+func TestPersistentPostingsListCacheEvictsOverMaxBytes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postings-list-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		volume = persist.IndexSegmentVolume{Namespace: "ns", BlockStart: time.Unix(7200, 0)}
+		pl     = newTestPersistedPostingsList(t, 1, 2, 3)
+		fields = []string{"a", "b", "c"}
+	)
+
+	// Determine the size of a single entry.
+	c := newTestPersistentPostingsListCache(t, dir)
+	require.NoError(t, c.Put(volume, "a", "term", PatternTypeTerm, pl))
+	require.NoError(t, c.Close())
+	size := c.bytes
+	require.True(t, size > 0)
+
+	c, err = NewPersistentPostingsListCache(PersistentPostingsListCacheOptions{
+		FilePathPrefix: dir,
+		MaxBytes:       2 * size,
+	})
+	require.NoError(t, err)
+	for _, field := range fields[1:] {
+		require.NoError(t, c.Put(volume, field, "term", PatternTypeTerm, pl))
+	}
+	require.NoError(t, c.Close())
+
+	// The least recently written entry is evicted.
+	c, err = NewPersistentPostingsListCache(PersistentPostingsListCacheOptions{
+		FilePathPrefix: dir,
+		MaxBytes:       2 * size,
+	})
+	require.NoError(t, err)
+	defer c.Close()
+	require.Equal(t, 2*size, c.bytes)
+
+	loaded := loadTestPersistedPostingsLists(t, c, volume)
+	require.Equal(t, 2, len(loaded))
+	_, ok := loaded["a"]
+	require.False(t, ok)
+}
+
+func TestPersistentPostingsListCacheSkipsClosedSegment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "postings-list-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		volume  = persist.IndexSegmentVolume{Namespace: "ns", BlockStart: time.Unix(7200, 0)}
+		segment = &testPersistentPostingsListCacheSegment{closed: true}
+	)
+
+	c := newTestPersistentPostingsListCache(t, dir)
+	require.NoError(t, c.put(volume, segment, "field", "term", PatternTypeTerm,
+		newTestPersistedPostingsList(t, 1)))
+	require.NoError(t, c.Close())
+
+	c = newTestPersistentPostingsListCache(t, dir)
+	defer c.Close()
+	require.Equal(t, 0, len(loadTestPersistedPostingsLists(t, c, volume)))
+}
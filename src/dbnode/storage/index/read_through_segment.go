@@ -24,6 +24,7 @@ import (
 	"errors"
 	"sync"
 
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
@@ -56,6 +57,11 @@ type ReadThroughSegment struct {
 	uuid              uuid.UUID
 	postingsListCache *PostingsListCache
 
+	// persistentCache and volume are only set for segments of flushed
+	// index filesets whose postings lists are persisted.
+	persistentCache *PersistentPostingsListCache
+	volume          persist.IndexSegmentVolume
+
 	opts ReadThroughSegmentOptions
 
 	closed bool
@@ -84,6 +90,43 @@ func NewReadThroughSegment(
 	}
 }
 
+// NewPersistentReadThroughSegment creates a new read through segment for a
+// segment of a flushed index fileset which also persists the postings lists
+// of its queries, the postings lists previously persisted for the segment are
+// loaded into the postings list cache in the background.
+func NewPersistentReadThroughSegment(
+	seg persist.IndexVolumeSegment,
+	cache *PostingsListCache,
+	persistentCache *PersistentPostingsListCache,
+	opts ReadThroughSegmentOptions,
+) (segment.Segment, error) {
+	r := &ReadThroughSegment{
+		segment:           seg,
+		opts:              opts,
+		uuid:              uuid.NewUUID(),
+		postingsListCache: cache,
+		persistentCache:   persistentCache,
+		volume:            seg.Volume(),
+	}
+
+	if cache == nil || persistentCache == nil {
+		return r, nil
+	}
+
+	err := persistentCache.loadAsync(r.volume, r, func(
+		field string,
+		pattern string,
+		patternType PatternType,
+		pl postings.List,
+	) {
+		cache.put(r.uuid, field, pattern, patternType, pl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 // Reader returns a read through reader for the read through segment.
 func (r *ReadThroughSegment) Reader() (index.Reader, error) {
 	r.RLock()
@@ -96,8 +139,26 @@ func (r *ReadThroughSegment) Reader() (index.Reader, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newReadThroughSegmentReader(
-		reader, r.uuid, r.postingsListCache, r.opts), nil
+	return newReadThroughSegmentReader(reader, r.uuid, r.postingsListCache,
+		r.persistentCache, r.volume, r, r.opts), nil
+}
+
+// Volume returns the volume of the flushed index fileset the segment was read
+// from and whether the segment has one.
+func (r *ReadThroughSegment) Volume() (persist.IndexSegmentVolume, bool) {
+	return r.volume, r.persistentCache != nil
+}
+
+// withOpen calls fn and returns true if the segment is not closed, the segment
+// can not be closed until fn returns.
+func (r *ReadThroughSegment) withOpen(fn func()) bool {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return false
+	}
+	fn()
+	return true
 }
 
 // Close purges all entries in the cache associated with this segment,
@@ -163,12 +224,18 @@ type readThroughSegmentReader struct {
 	opts              ReadThroughSegmentOptions
 	uuid              uuid.UUID
 	postingsListCache *PostingsListCache
+	persistentCache   *PersistentPostingsListCache
+	volume            persist.IndexSegmentVolume
+	segment           persistentPostingsListCacheSegment
 }
 
 func newReadThroughSegmentReader(
 	reader index.Reader,
 	uuid uuid.UUID,
 	cache *PostingsListCache,
+	persistentCache *PersistentPostingsListCache,
+	volume persist.IndexSegmentVolume,
+	segment persistentPostingsListCacheSegment,
 	opts ReadThroughSegmentOptions,
 ) index.Reader {
 	return &readThroughSegmentReader{
@@ -176,6 +243,9 @@ func newReadThroughSegmentReader(
 		opts:              opts,
 		uuid:              uuid,
 		postingsListCache: cache,
+		persistentCache:   persistentCache,
+		volume:            volume,
+		segment:           segment,
 	}
 }
 
@@ -200,6 +270,7 @@ func (s *readThroughSegmentReader) MatchRegexp(
 	pl, err := s.reader.MatchRegexp(field, c)
 	if err == nil {
 		s.postingsListCache.PutRegexp(s.uuid, fieldStr, patternStr, pl)
+		s.persist(fieldStr, patternStr, PatternTypeRegexp, pl)
 	}
 	return pl, err
 }
@@ -224,6 +295,7 @@ func (s *readThroughSegmentReader) MatchTerm(
 	pl, err := s.reader.MatchTerm(field, term)
 	if err == nil {
 		s.postingsListCache.PutTerm(s.uuid, fieldStr, patternStr, pl)
+		s.persist(fieldStr, patternStr, PatternTypeTerm, pl)
 	}
 	return pl, err
 }
@@ -245,10 +317,34 @@ func (s *readThroughSegmentReader) MatchField(field []byte) (postings.List, erro
 	pl, err := s.reader.MatchField(field)
 	if err == nil {
 		s.postingsListCache.PutField(s.uuid, fieldStr, pl)
+		s.persist(fieldStr, emptyPattern, PatternTypeField, pl)
 	}
 	return pl, err
 }
 
+// persist enqueues the postings list of a cache miss to be written to the
+// persistent postings list cache, if any. Failing to persist only costs a
+// cache miss after restart so it does not fail the query.
+func (s *readThroughSegmentReader) persist(
+	field string,
+	pattern string,
+	patternType PatternType,
+	pl postings.List,
+) {
+	if s.persistentCache == nil {
+		return
+	}
+	_ = s.persistentCache.put(s.volume, s.segment, field, pattern, patternType, pl)
+}
+
+// MatchRange is a pass through call, range queries are not cached.
+func (s *readThroughSegmentReader) MatchRange(
+	field []byte,
+	termRange index.TermRange,
+) (postings.List, error) {
+	return s.reader.MatchRange(field, termRange)
+}
+
 // MatchAll is a pass through call, since there's no postings list to cache.
 // NB(r): The postings list returned by match all is just an iterator
 // from zero to the maximum document number indexed by the segment and as such
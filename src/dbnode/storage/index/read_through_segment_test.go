@@ -21,9 +21,13 @@
 package index
 
 import (
+	"io/ioutil"
+	"os"
 	"regexp/syntax"
 	"testing"
+	"time"
 
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/m3ninx/postings/roaring"
@@ -413,3 +417,74 @@ func TestCloseNoCache(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, readThrough.(*ReadThroughSegment).closed)
 }
+
+func TestReadThroughSegmentPersistentReloadsPostingsLists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "postings-list-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		volume = persist.IndexSegmentVolume{
+			Namespace:  "ns",
+			BlockStart: time.Unix(7200, 0),
+		}
+		field = []byte("some-field")
+		term  = []byte("some-term")
+	)
+	fstSegment := fst.NewMockSegment(ctrl)
+	reader := index.NewMockReader(ctrl)
+	fstSegment.EXPECT().Reader().Return(reader, nil).Times(2)
+
+	originalPL := roaring.NewPostingsList()
+	require.NoError(t, originalPL.Insert(1))
+	// Only expect a single call, the postings list is reloaded after restart.
+	reader.EXPECT().MatchTerm(field, term).Return(originalPL, nil)
+
+	cache, stopReporting, err := NewPostingsListCache(1, testPostingListCacheOptions)
+	require.NoError(t, err)
+	defer stopReporting()
+
+	persistentCache := newTestPersistentPostingsListCache(t, dir)
+	seg, err := NewPersistentReadThroughSegment(
+		persist.NewIndexVolumeSegment(fstSegment, volume),
+		cache, persistentCache, defaultReadThroughSegmentOptions)
+	require.NoError(t, err)
+
+	readThrough, err := seg.Reader()
+	require.NoError(t, err)
+	pl, err := readThrough.MatchTerm(field, term)
+	require.NoError(t, err)
+	require.True(t, pl.Equal(originalPL))
+	require.NoError(t, persistentCache.Close())
+
+	// Restart with empty postings list caches.
+	cache, stopReporting, err = NewPostingsListCache(1, testPostingListCacheOptions)
+	require.NoError(t, err)
+	defer stopReporting()
+
+	persistentCache = newTestPersistentPostingsListCache(t, dir)
+	defer persistentCache.Close()
+
+	seg, err = NewPersistentReadThroughSegment(
+		persist.NewIndexVolumeSegment(fstSegment, volume),
+		cache, persistentCache, defaultReadThroughSegmentOptions)
+	require.NoError(t, err)
+
+	// Wait for the persisted postings lists to be loaded in the background.
+	uuid := seg.(*ReadThroughSegment).uuid
+	for start := time.Now(); time.Since(start) < 5*time.Second; {
+		if _, ok := cache.GetTerm(uuid, string(field), string(term)); ok {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	readThrough, err = seg.Reader()
+	require.NoError(t, err)
+	pl, err = readThrough.MatchTerm(field, term)
+	require.NoError(t, err)
+	require.True(t, pl.Equal(originalPL))
+}
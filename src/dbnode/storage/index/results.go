@@ -40,7 +40,8 @@ type results struct {
 	nsID ident.ID
 	opts QueryResultsOptions
 
-	resultsMap *ResultsMap
+	resultsMap     *ResultsMap
+	totalDocsCount int
 
 	idPool    ident.Pool
 	bytesPool pool.CheckedBytesPool
@@ -69,6 +70,7 @@ func (r *results) Reset(nsID ident.ID, opts QueryResultsOptions) {
 	r.Lock()
 
 	r.opts = opts
+	r.totalDocsCount = 0
 
 	// Finalize existing held nsID.
 	if r.nsID != nil {
@@ -101,6 +103,7 @@ func (r *results) AddDocuments(batch []doc.Document) (int, error) {
 	r.Lock()
 	err := r.addDocumentsBatchWithLock(batch)
 	size := r.resultsMap.Len()
+	r.totalDocsCount += len(batch)
 	r.Unlock()
 	return size, err
 }
@@ -175,6 +178,13 @@ func (r *results) Size() int {
 	return v
 }
 
+func (r *results) TotalDocsCount() int {
+	r.RLock()
+	v := r.totalDocsCount
+	r.RUnlock()
+	return v
+}
+
 func (r *results) Finalize() {
 	// Reset locks so cannot hold onto lock for call to Finalize.
 	r.Reset(nil, QueryResultsOptions{})
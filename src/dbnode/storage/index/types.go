@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index/compaction"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
@@ -79,10 +80,16 @@ type Query struct {
 // QueryOptions enables users to specify constraints and
 // preferences on query execution.
 type QueryOptions struct {
-	StartInclusive   time.Time
-	EndExclusive     time.Time
-	Limit            int
-	IterationOptions IterationOptions
+	StartInclusive    time.Time
+	EndExclusive      time.Time
+	Limit             int
+	DocsLimit         int
+	RequireExhaustive bool
+	IterationOptions  IterationOptions
+	// SeriesDataFetched is set when the data of the matched series is read
+	// after the query, the namespace read limits are then charged as each
+	// series is read rather than for the series matched by the query.
+	SeriesDataFetched bool
 }
 
 // IterationOptions enables users to specify iteration preferences.
@@ -96,6 +103,19 @@ func (o QueryOptions) LimitExceeded(size int) bool {
 	return o.Limit > 0 && size >= o.Limit
 }
 
+// DocsLimitExceeded returns whether a given number of documents matched
+// exceeds the docs limit the query options imposes, if it is enabled.
+func (o QueryOptions) DocsLimitExceeded(docsCount int) bool {
+	return o.DocsLimit > 0 && docsCount >= o.DocsLimit
+}
+
+// Exhaustive returns whether a query with the given number of results and
+// documents matched is exhaustive with respect to the limits of the query
+// options.
+func (o QueryOptions) Exhaustive(size, docsCount int) bool {
+	return !o.LimitExceeded(size) && !o.DocsLimitExceeded(docsCount)
+}
+
 // AggregationOptions enables users to specify constraints on aggregations.
 type AggregationOptions struct {
 	QueryOptions
@@ -125,6 +145,11 @@ type BaseResults interface {
 	// Size returns the number of IDs tracked.
 	Size() int
 
+	// TotalDocsCount returns the total number of documents matched and
+	// added to the results set, including duplicates and documents not
+	// added due to the size limit.
+	TotalDocsCount() int
+
 	// AddDocuments adds the batch of documents to the results set, it will
 	// take a copy of the bytes backing the documents so the original can be
 	// modified after this function returns without affecting the results map.
@@ -782,6 +807,19 @@ type fieldsAndTermsIterator interface {
 	Reset(seg segment.Segment, opts fieldsAndTermsIteratorOpts) error
 }
 
+// FlushedBlockCompactionOptions is the options struct for compacting adjacent
+// flushed index blocks into a single larger block.
+type FlushedBlockCompactionOptions struct {
+	// Enabled is whether flushed index blocks are compacted.
+	Enabled bool
+	// BlockSize is the size of the compacted blocks, it must be a multiple
+	// of the index block size of the namespace.
+	BlockSize time.Duration
+	// MinAge is how long after the end of a compacted block the flushed
+	// blocks it spans are compacted.
+	MinAge time.Duration
+}
+
 // Options control the Indexing knobs.
 type Options interface {
 	// Validate validates assumptions baked into the code.
@@ -883,12 +921,25 @@ type Options interface {
 	// PostingsListCache returns the postings list cache.
 	PostingsListCache() *PostingsListCache
 
+	// SetPersistentPostingsListCache sets the persistent postings list cache,
+	// which is optional.
+	SetPersistentPostingsListCache(value *PersistentPostingsListCache) Options
+
+	// PersistentPostingsListCache returns the persistent postings list cache.
+	PersistentPostingsListCache() *PersistentPostingsListCache
+
 	// SetReadThroughSegmentOptions sets the read through segment cache options.
 	SetReadThroughSegmentOptions(value ReadThroughSegmentOptions) Options
 
 	// ReadThroughSegmentOptions returns the read through segment cache options.
 	ReadThroughSegmentOptions() ReadThroughSegmentOptions
 
+	// SetFlushedBlockCompactionOptions sets the flushed block compaction options.
+	SetFlushedBlockCompactionOptions(value FlushedBlockCompactionOptions) Options
+
+	// FlushedBlockCompactionOptions returns the flushed block compaction options.
+	FlushedBlockCompactionOptions() FlushedBlockCompactionOptions
+
 	// SetForwardIndexProbability sets the probability chance for forward writes.
 	SetForwardIndexProbability(value float64) Options
 
@@ -907,4 +958,10 @@ type Options interface {
 
 	// MmapReporter returns the mmap reporter.
 	MmapReporter() mmap.Reporter
+
+	// SetQueryLimits sets the query limits.
+	SetQueryLimits(value limits.QueryLimits) Options
+
+	// QueryLimits returns the query limits.
+	QueryLimits() limits.QueryLimits
 }
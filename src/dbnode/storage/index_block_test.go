@@ -32,10 +32,12 @@ import (
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/storage/limits"
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/x/context"
+	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
 	xtest "github.com/m3db/m3/src/x/test"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -403,6 +405,7 @@ func TestNamespaceIndexTickExpire(t *testing.T) {
 	b0 := index.NewMockBlock(ctrl)
 	b0.EXPECT().Stats(gomock.Any()).Return(nil).AnyTimes()
 	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
 	newBlockFn := func(
 		ts time.Time,
 		md namespace.Metadata,
@@ -623,6 +626,92 @@ func TestNamespaceIndexBlockQuery(t *testing.T) {
 	require.Len(t, spans, 11)
 }
 
+func TestNamespaceIndexBlockQueryRequireExhaustive(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{T: t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	t0Nanos := xtime.ToUnixNano(t0)
+	t1 := t0.Add(1 * blockSize)
+	var nowLock sync.Mutex
+	nowFn := func() time.Time {
+		nowLock.Lock()
+		defer nowLock.Unlock()
+		return now
+	}
+	queryLimits, err := limits.NewQueryLimits(limits.QueryLimitsOptions{
+		DocsLimitOptions: limits.DefaultLookbackLimitOptions(),
+		MaxDocsPerQuery:  100,
+	})
+	require.NoError(t, err)
+	opts := DefaultTestOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn)).
+		SetIndexOptions(opts.IndexOptions().SetQueryLimits(queryLimits))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().Stats(gomock.Any()).Return(nil).AnyTimes()
+	b0.EXPECT().Close().Return(nil)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	newBlockFn := func(
+		ts time.Time,
+		md namespace.Metadata,
+		_ index.BlockOptions,
+		io index.Options,
+	) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	idx, err := newNamespaceIndexWithNewBlockFn(md, testShardSet, newBlockFn, opts)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, idx.Close())
+	}()
+
+	seg1 := segment.NewMockSegment(ctrl)
+	bootstrapResults := result.IndexResults{
+		t0Nanos: result.NewIndexBlock(t0, []segment.Segment{seg1}, result.NewShardTimeRanges(t0, t1, 1, 2, 3)),
+	}
+
+	b0.EXPECT().AddResults(bootstrapResults[t0Nanos]).Return(nil)
+	require.NoError(t, idx.Bootstrap(bootstrapResults))
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	q := defaultQuery
+	qOpts := index.QueryOptions{
+		StartInclusive: t0,
+		EndExclusive:   now.Add(time.Minute),
+	}
+
+	// the docs limit is capped by the max docs per query of the node and a
+	// non-exhaustive result is returned when exhaustive results are not required.
+	blockOpts := qOpts
+	blockOpts.DocsLimit = 100
+	b0.EXPECT().Query(gomock.Any(), gomock.Any(), q, blockOpts, gomock.Any(), gomock.Any()).Return(false, nil)
+	result, err := idx.Query(ctx, q, qOpts)
+	require.NoError(t, err)
+	require.False(t, result.Exhaustive)
+
+	// a non-exhaustive result returns an error when exhaustive results are
+	// required.
+	qOpts.RequireExhaustive = true
+	blockOpts.RequireExhaustive = true
+	b0.EXPECT().Query(gomock.Any(), gomock.Any(), q, blockOpts, gomock.Any(), gomock.Any()).Return(false, nil)
+	_, err = idx.Query(ctx, q, qOpts)
+	require.Error(t, err)
+	require.True(t, limits.IsQueryLimitExceededError(err))
+	require.True(t, xerrors.IsInvalidParams(err))
+}
+
 func TestNamespaceIndexBlockQueryReleasingContext(t *testing.T) {
 	ctrl := gomock.NewController(xtest.Reporter{T: t})
 	defer ctrl.Finish()
@@ -0,0 +1,454 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/index/segment"
+	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"go.uber.org/zap"
+)
+
+var errDbIndexCompactedBlockConflict = errors.New("compacted index block conflicts with existing block")
+
+// flushedBlocksWindow is a set of adjacent flushed index blocks which span a
+// single compacted block.
+type flushedBlocksWindow struct {
+	start  time.Time
+	blocks []index.Block
+}
+
+// compactFlushedBlocks compacts adjacent flushed index blocks into blocks of
+// the compacted block size, so that queries spanning long time ranges search
+// far fewer segments. The filesets of the flushed blocks are merged into a
+// single fileset of the compacted block size which then replaces them.
+func (i *nsIndex) compactFlushedBlocks(flush persist.IndexFlush) error {
+	compactionOpts := i.opts.IndexOptions().FlushedBlockCompactionOptions()
+	if !canCompactFlushedBlocks(compactionOpts, i.blockSize) {
+		return nil
+	}
+
+	windows, err := i.compactableFlushedBlocks(compactionOpts)
+	if err != nil || len(windows) == 0 {
+		return err
+	}
+
+	var (
+		fsOpts    = i.opts.CommitLogOptions().FilesystemOptions()
+		infoFiles = fs.ReadIndexInfoFiles(fsOpts.FilePathPrefix(), i.nsMetadata.ID(),
+			fsOpts.InfoReaderBufferSize())
+		volumesByBlockStart = make(map[xtime.UnixNano][]fs.ReadIndexInfoFileResult)
+	)
+	for _, infoFile := range infoFiles {
+		blockStart := xtime.UnixNano(infoFile.ID.BlockStart.UnixNano())
+		volumesByBlockStart[blockStart] = append(volumesByBlockStart[blockStart], infoFile)
+	}
+
+	multiErr := xerrors.NewMultiError()
+	for _, window := range windows {
+		if err := i.compactFlushedBlocksWindow(flush, window,
+			compactionOpts.BlockSize, volumesByBlockStart); err != nil {
+			i.metrics.BlockCompactionErrors.Inc(1)
+			multiErr = multiErr.Add(fmt.Errorf(
+				"unable to compact index blocks starting at %s: %v", window.start.String(), err))
+		}
+	}
+	return multiErr.FinalError()
+}
+
+// compactableFlushedBlocks returns the windows of adjacent blocks that are old
+// enough, sealed and flushed, and that entirely span a compacted block.
+func (i *nsIndex) compactableFlushedBlocks(
+	compactionOpts index.FlushedBlockCompactionOptions,
+) ([]flushedBlocksWindow, error) {
+	i.state.RLock()
+	defer i.state.RUnlock()
+	if !i.isOpenWithRLock() {
+		return nil, errDbIndexUnableToFlushClosed
+	}
+
+	var (
+		now                        = i.nowFn()
+		compactBefore              = now.Add(-compactionOpts.MinAge)
+		earliestBlockStartToRetain = retention.FlushTimeStartForRetentionPeriod(i.retentionPeriod, i.blockSize, now)
+		windowsByStart             = make(map[xtime.UnixNano]*flushedBlocksWindow)
+		ineligible                 = make(map[xtime.UnixNano]struct{})
+	)
+	for blockStart, block := range i.state.blocksByTime {
+		windowStart := blockStart.ToTime().Truncate(compactionOpts.BlockSize)
+		windowKey := xtime.ToUnixNano(windowStart)
+		if windowStart.Before(earliestBlockStartToRetain) ||
+			windowStart.Add(compactionOpts.BlockSize).After(compactBefore) ||
+			block.EndTime().Sub(block.StartTime()) != i.blockSize ||
+			!block.IsSealed() ||
+			block.NeedsMutableSegmentsEvicted() {
+			ineligible[windowKey] = struct{}{}
+			continue
+		}
+
+		window, ok := windowsByStart[windowKey]
+		if !ok {
+			window = &flushedBlocksWindow{start: windowStart}
+			windowsByStart[windowKey] = window
+		}
+		window.blocks = append(window.blocks, block)
+	}
+
+	blocksPerWindow := int(compactionOpts.BlockSize / i.blockSize)
+	windows := make([]flushedBlocksWindow, 0, len(windowsByStart))
+	for windowKey, window := range windowsByStart {
+		if _, ok := ineligible[windowKey]; ok || len(window.blocks) != blocksPerWindow {
+			continue
+		}
+		sort.Slice(window.blocks, func(a, b int) bool {
+			return window.blocks[a].StartTime().Before(window.blocks[b].StartTime())
+		})
+		windows = append(windows, *window)
+	}
+
+	sort.Slice(windows, func(a, b int) bool {
+		return windows[a].start.Before(windows[b].start)
+	})
+	return windows, nil
+}
+
+func (i *nsIndex) compactFlushedBlocksWindow(
+	flush persist.IndexFlush,
+	window flushedBlocksWindow,
+	blockSize time.Duration,
+	volumesByBlockStart map[xtime.UnixNano][]fs.ReadIndexInfoFileResult,
+) error {
+	// Only compact the window if every block has been flushed for the same
+	// shards, otherwise the compacted block would claim to fulfill shard time
+	// ranges that were not indexed.
+	var (
+		volumes []fs.ReadIndexInfoFileResult
+		shards  map[uint32]struct{}
+	)
+	for _, block := range window.blocks {
+		blockVolumes := volumesByBlockStart[xtime.ToUnixNano(block.StartTime())]
+		if len(blockVolumes) == 0 {
+			return nil
+		}
+
+		blockShards := make(map[uint32]struct{})
+		for _, volume := range blockVolumes {
+			if volume.Err.Error() != nil || volume.Info.BlockSize != int64(i.blockSize) {
+				return nil
+			}
+			for _, shard := range volume.Info.Shards {
+				blockShards[shard] = struct{}{}
+			}
+		}
+
+		if shards == nil {
+			shards = blockShards
+		} else if !equalShardSets(shards, blockShards) {
+			return nil
+		}
+		volumes = append(volumes, blockVolumes...)
+	}
+
+	compactedSegments, err := i.persistCompactedFlushedBlocks(flush, window.start,
+		blockSize, shards, volumes)
+	if err != nil {
+		return err
+	}
+
+	shardIDs := make([]uint32, 0, len(shards))
+	for shard := range shards {
+		shardIDs = append(shardIDs, shard)
+	}
+	fulfilled := result.NewShardTimeRanges(window.start, window.start.Add(blockSize), shardIDs...)
+
+	compactedBlock, err := i.newBlockFn(window.start, i.nsMetadata,
+		index.BlockOptions{BlockSize: blockSize}, i.opts.IndexOptions())
+	if err != nil {
+		for _, seg := range compactedSegments {
+			seg.Close()
+		}
+		return err
+	}
+	if err := compactedBlock.AddResults(result.NewIndexBlock(window.start,
+		compactedSegments, fulfilled)); err != nil {
+		for _, seg := range compactedSegments {
+			seg.Close()
+		}
+		return err
+	}
+	if err := compactedBlock.Seal(); err != nil {
+		return xerrors.FirstError(err, compactedBlock.Close())
+	}
+
+	if !i.replaceFlushedBlocks(window, compactedBlock) {
+		// The blocks changed while being compacted, remove the compacted
+		// fileset, the blocks will be compacted again once flushed.
+		i.logger.Warn("index blocks changed while compacting, discarding compacted block",
+			zap.Time("blockStart", window.start))
+		compactedVolumes := make([]fs.FileSetFileIdentifier, 0, 1)
+		for _, seg := range compactedSegments {
+			if volumeSeg, ok := seg.(persist.IndexVolumeSegment); ok {
+				compactedVolumes = append(compactedVolumes, fs.FileSetFileIdentifier{
+					BlockStart:  volumeSeg.Volume().BlockStart,
+					VolumeIndex: volumeSeg.Volume().VolumeIndex,
+				})
+				break
+			}
+		}
+		return xerrors.FirstError(compactedBlock.Close(),
+			i.deleteIndexVolumes(compactedVolumes))
+	}
+
+	i.metrics.BlocksCompacted.Inc(1)
+
+	// The compacted block now serves the queries for the window, so the blocks
+	// it was compacted from and their filesets can be removed.
+	multiErr := xerrors.NewMultiError()
+	for _, block := range window.blocks {
+		multiErr = multiErr.Add(block.Close())
+	}
+
+	replacedVolumes := make([]fs.FileSetFileIdentifier, 0, len(volumes))
+	for _, volume := range volumes {
+		replacedVolumes = append(replacedVolumes, volume.ID)
+	}
+	multiErr = multiErr.Add(i.deleteIndexVolumes(replacedVolumes))
+
+	if plCache := i.opts.IndexOptions().PersistentPostingsListCache(); plCache != nil {
+		for _, volume := range volumes {
+			multiErr = multiErr.Add(plCache.DeleteVolume(persist.IndexSegmentVolume{
+				Namespace:   i.nsMetadata.ID().String(),
+				BlockStart:  volume.ID.BlockStart,
+				VolumeIndex: volume.ID.VolumeIndex,
+			}))
+		}
+	}
+
+	return multiErr.FinalError()
+}
+
+// persistCompactedFlushedBlocks merges the segments of the provided volumes
+// into a single fileset spanning the compacted block and returns its segments.
+func (i *nsIndex) persistCompactedFlushedBlocks(
+	flush persist.IndexFlush,
+	blockStart time.Time,
+	blockSize time.Duration,
+	shards map[uint32]struct{},
+	volumes []fs.ReadIndexInfoFileResult,
+) ([]segment.Segment, error) {
+	fsOpts := i.opts.CommitLogOptions().FilesystemOptions()
+
+	var segments []segment.Segment
+	defer func() {
+		// The merged segments reference the segments being merged, so they
+		// can only be closed once the compacted fileset has been written.
+		for _, seg := range segments {
+			seg.Close()
+		}
+	}()
+	for _, volume := range volumes {
+		volumeSegments, err := fs.ReadIndexSegments(fs.ReadIndexSegmentsOptions{
+			ReaderOptions: fs.IndexReaderOpenOptions{
+				Identifier:  volume.ID,
+				FileSetType: persist.FileSetFlushType,
+			},
+			FilesystemOptions: fsOpts,
+		})
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, volumeSegments...)
+	}
+
+	segmentsBuilder := builder.NewBuilderFromSegments(i.opts.IndexOptions().SegmentBuilderOptions())
+	segmentsBuilder.Reset(0)
+	if err := segmentsBuilder.AddSegments(segments); err != nil {
+		return nil, err
+	}
+
+	preparedPersist, err := flush.PrepareIndex(persist.IndexPrepareOptions{
+		NamespaceMetadata: i.nsMetadata,
+		BlockStart:        blockStart,
+		FileSetType:       persist.FileSetFlushType,
+		Shards:            shards,
+		BlockSize:         blockSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := preparedPersist.Persist(segmentsBuilder); err != nil {
+		compactedSegments, _ := preparedPersist.Close()
+		// NB: Safe to for over a nil array so disregard error here.
+		for _, seg := range compactedSegments {
+			seg.Close()
+		}
+		return nil, err
+	}
+
+	return preparedPersist.Close()
+}
+
+// replaceFlushedBlocks replaces the blocks of the window with the block they
+// were compacted into, if none of the blocks changed in the meantime.
+func (i *nsIndex) replaceFlushedBlocks(
+	window flushedBlocksWindow,
+	compactedBlock index.Block,
+) bool {
+	i.state.Lock()
+	defer i.state.Unlock()
+	if !i.isOpenWithRLock() {
+		return false
+	}
+
+	for _, block := range window.blocks {
+		existing, ok := i.state.blocksByTime[xtime.ToUnixNano(block.StartTime())]
+		if !ok || existing != block {
+			return false
+		}
+	}
+
+	for _, block := range window.blocks {
+		delete(i.state.blocksByTime, xtime.ToUnixNano(block.StartTime()))
+	}
+	i.state.blocksByTime[xtime.ToUnixNano(window.start)] = compactedBlock
+	i.updateBlockStartsWithLock()
+	return true
+}
+
+// deleteIndexVolumes deletes the files of the provided index fileset volumes.
+func (i *nsIndex) deleteIndexVolumes(volumes []fs.FileSetFileIdentifier) error {
+	var (
+		filePathPrefix = i.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+		toDelete       = make(map[xtime.UnixNano]map[int]struct{})
+		filePaths      []string
+	)
+	for _, volume := range volumes {
+		blockStart := xtime.ToUnixNano(volume.BlockStart)
+		if _, ok := toDelete[blockStart]; !ok {
+			toDelete[blockStart] = make(map[int]struct{})
+		}
+		toDelete[blockStart][volume.VolumeIndex] = struct{}{}
+	}
+
+	for blockStart, volumeIndices := range toDelete {
+		filesets, err := fs.IndexFileSetsAt(filePathPrefix, i.nsMetadata.ID(), blockStart.ToTime())
+		if err != nil {
+			return err
+		}
+		for _, fileset := range filesets {
+			if _, ok := volumeIndices[fileset.ID.VolumeIndex]; ok {
+				filePaths = append(filePaths, fileset.AbsoluteFilepaths...)
+			}
+		}
+	}
+
+	return i.deleteFilesFn(filePaths)
+}
+
+// ensureCompactedBlockPresentWithRLock guarantees an index.Block spanning the
+// provided compacted block size exists for the specified blockStart.
+func (i *nsIndex) ensureCompactedBlockPresentWithRLock(
+	blockStart time.Time,
+	blockSize time.Duration,
+) (index.Block, error) {
+	blockStartNanos := xtime.ToUnixNano(blockStart)
+	if block, ok := i.state.blocksByTime[blockStartNanos]; ok {
+		if !block.EndTime().Equal(blockStart.Add(blockSize)) {
+			return nil, errDbIndexCompactedBlockConflict
+		}
+		return block, nil
+	}
+
+	// Need to release the RLock to acquire the write lock, all exit paths
+	// must leave with the RLock.
+	i.state.RUnlock()
+	i.state.Lock()
+	defer func() {
+		i.state.Unlock()
+		i.state.RLock()
+	}()
+
+	if block, ok := i.state.blocksByTime[blockStartNanos]; ok {
+		if !block.EndTime().Equal(blockStart.Add(blockSize)) {
+			return nil, errDbIndexCompactedBlockConflict
+		}
+		return block, nil
+	}
+
+	block, err := i.newBlockFn(blockStart, i.nsMetadata,
+		index.BlockOptions{BlockSize: blockSize}, i.opts.IndexOptions())
+	if err != nil {
+		return nil, i.unableToAllocBlockInvariantError(err)
+	}
+
+	i.state.blocksByTime[blockStartNanos] = block
+	i.updateBlockStartsWithLock()
+	return block, nil
+}
+
+// bootstrapBlockSize returns the size of the block that the bootstrapped index
+// results for a block start span, which is larger than the index block size
+// for the results of filesets compacted from multiple blocks.
+func (i *nsIndex) bootstrapBlockSize(blockStart time.Time, results result.IndexBlock) time.Duration {
+	_, max := results.Fulfilled().MinMax()
+	if !max.After(blockStart.Add(i.blockSize)) {
+		return i.blockSize
+	}
+
+	// Compacted block sizes are multiples of the index block size.
+	blocks := (max.Sub(blockStart) + i.blockSize - 1) / i.blockSize
+	return blocks * i.blockSize
+}
+
+// canCompactFlushedBlocks returns whether flushed blocks of the provided index
+// block size can be compacted with the provided options.
+func canCompactFlushedBlocks(
+	compactionOpts index.FlushedBlockCompactionOptions,
+	blockSize time.Duration,
+) bool {
+	return compactionOpts.Enabled &&
+		compactionOpts.BlockSize > blockSize &&
+		compactionOpts.BlockSize%blockSize == 0
+}
+
+func equalShardSets(a, b map[uint32]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for shard := range a {
+		if _, ok := b[shard]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3/src/m3ninx/index/segment/builder"
+	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCompactionIndex(
+	t *testing.T,
+	dir string,
+	now time.Time,
+	compactedBlockSize time.Duration,
+) (*nsIndex, fs.Options) {
+	md, err := namespace.NewMetadata(ident.StringID("testns"), namespaceOptions.
+		SetRetentionOptions(namespaceOptions.RetentionOptions().
+			SetRetentionPeriod(48*time.Hour).
+			SetBlockSize(time.Hour)).
+		SetIndexOptions(namespaceIndexOptions.
+			SetEnabled(true).
+			SetBlockSize(time.Hour)))
+	require.NoError(t, err)
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(dir)
+	opts := DefaultTestOptions()
+	opts = opts.
+		SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time { return now })).
+		SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts)).
+		SetIndexOptions(opts.IndexOptions().
+			SetFlushedBlockCompactionOptions(index.FlushedBlockCompactionOptions{
+				Enabled:   true,
+				BlockSize: compactedBlockSize,
+			}))
+
+	newIdx, err := newNamespaceIndex(md, testShardSet, opts)
+	require.NoError(t, err)
+	return newIdx.(*nsIndex), fsOpts
+}
+
+func flushTestCompactionBlock(
+	t *testing.T,
+	nsIdx *nsIndex,
+	flush persist.IndexFlush,
+	blockStart time.Time,
+	ids ...string,
+) {
+	docsBuilder, err := builder.NewBuilderFromDocuments(builder.NewOptions())
+	require.NoError(t, err)
+	for _, id := range ids {
+		_, err := docsBuilder.Insert(doc.Document{
+			ID:     []byte(id),
+			Fields: []doc.Field{{Name: []byte("name"), Value: []byte(id)}},
+		})
+		require.NoError(t, err)
+	}
+
+	shards := map[uint32]struct{}{0: {}, 1: {}}
+	prepared, err := flush.PrepareIndex(persist.IndexPrepareOptions{
+		NamespaceMetadata: nsIdx.nsMetadata,
+		BlockStart:        blockStart,
+		FileSetType:       persist.FileSetFlushType,
+		Shards:            shards,
+	})
+	require.NoError(t, err)
+	require.NoError(t, prepared.Persist(docsBuilder))
+	segments, err := prepared.Close()
+	require.NoError(t, err)
+
+	block, err := index.NewBlock(blockStart, nsIdx.nsMetadata,
+		index.BlockOptions{}, nsIdx.opts.IndexOptions())
+	require.NoError(t, err)
+	fulfilled := result.NewShardTimeRanges(blockStart, blockStart.Add(nsIdx.blockSize), 0, 1)
+	require.NoError(t, block.AddResults(result.NewIndexBlock(blockStart, segments, fulfilled)))
+	require.NoError(t, block.Seal())
+
+	nsIdx.state.Lock()
+	nsIdx.state.blocksByTime[xtime.ToUnixNano(blockStart)] = block
+	nsIdx.updateBlockStartsWithLock()
+	nsIdx.state.Unlock()
+}
+
+func TestNamespaceIndexCompactFlushedBlocks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "index-compaction")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		compactedBlockSize = 4 * time.Hour
+		now                = time.Now().Truncate(compactedBlockSize)
+		windowStart        = now.Add(-2 * compactedBlockSize)
+		windowEnd          = windowStart.Add(compactedBlockSize)
+	)
+	nsIdx, fsOpts := newTestCompactionIndex(t, dir, now, compactedBlockSize)
+	defer func() {
+		require.NoError(t, nsIdx.Close())
+	}()
+
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	flush, err := pm.StartIndexPersist()
+	require.NoError(t, err)
+
+	// Flush every block of the window and only part of the following window.
+	for blockStart := windowStart; blockStart.Before(windowEnd.Add(time.Hour)); blockStart = blockStart.Add(time.Hour) {
+		flushTestCompactionBlock(t, nsIdx, flush, blockStart,
+			fmt.Sprintf("series-%d", blockStart.Unix()))
+	}
+
+	require.NoError(t, nsIdx.compactFlushedBlocks(flush))
+	require.NoError(t, flush.DoneIndex())
+
+	nsIdx.state.RLock()
+	compacted, ok := nsIdx.state.blocksByTime[xtime.ToUnixNano(windowStart)]
+	require.True(t, ok)
+	require.Equal(t, windowEnd, compacted.EndTime())
+	require.True(t, compacted.IsSealed())
+	for blockStart := windowStart.Add(time.Hour); blockStart.Before(windowEnd); blockStart = blockStart.Add(time.Hour) {
+		_, ok := nsIdx.state.blocksByTime[xtime.ToUnixNano(blockStart)]
+		require.False(t, ok)
+	}
+	_, ok = nsIdx.state.blocksByTime[xtime.ToUnixNano(windowEnd)]
+	require.True(t, ok)
+	nsIdx.state.RUnlock()
+
+	// Only the compacted fileset remains for the window.
+	infoFiles := fs.ReadIndexInfoFiles(dir, nsIdx.nsMetadata.ID(), fsOpts.InfoReaderBufferSize())
+	var windowInfoFiles []fs.ReadIndexInfoFileResult
+	for _, infoFile := range infoFiles {
+		require.NoError(t, infoFile.Err.Error())
+		if infoFile.ID.BlockStart.Before(windowEnd) {
+			windowInfoFiles = append(windowInfoFiles, infoFile)
+		}
+	}
+	require.Len(t, windowInfoFiles, 1)
+	require.Equal(t, windowStart, windowInfoFiles[0].ID.BlockStart)
+	require.Equal(t, int64(compactedBlockSize), windowInfoFiles[0].Info.BlockSize)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	res, err := nsIdx.Query(ctx, index.Query{Query: idx.NewAllQuery()}, index.QueryOptions{
+		StartInclusive: windowStart,
+		EndExclusive:   windowEnd,
+	})
+	require.NoError(t, err)
+	require.True(t, res.Exhaustive)
+	require.Equal(t, 4, res.Results.Size())
+}
+
+func TestNamespaceIndexBootstrapCompactedBlock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "index-compaction")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		compactedBlockSize = 4 * time.Hour
+		now                = time.Now().Truncate(compactedBlockSize)
+		windowStart        = now.Add(-2 * compactedBlockSize)
+		windowEnd          = windowStart.Add(compactedBlockSize)
+	)
+	nsIdx, _ := newTestCompactionIndex(t, dir, now, compactedBlockSize)
+	defer func() {
+		require.NoError(t, nsIdx.Close())
+	}()
+
+	require.Equal(t, time.Hour, nsIdx.bootstrapBlockSize(windowStart,
+		result.NewIndexBlock(windowStart, nil,
+			result.NewShardTimeRanges(windowStart, windowStart.Add(time.Hour), 0))))
+	require.Equal(t, compactedBlockSize, nsIdx.bootstrapBlockSize(windowStart,
+		result.NewIndexBlock(windowStart, nil,
+			result.NewShardTimeRanges(windowStart, windowEnd, 0))))
+
+	results := result.NewIndexBootstrapResult()
+	results.Add(result.NewIndexBlock(windowStart, nil,
+		result.NewShardTimeRanges(windowStart, windowEnd, 0)), nil)
+	require.NoError(t, nsIdx.Bootstrap(results.IndexResults()))
+
+	nsIdx.state.RLock()
+	defer nsIdx.state.RUnlock()
+	block, ok := nsIdx.state.blocksByTime[xtime.ToUnixNano(windowStart)]
+	require.True(t, ok)
+	require.Equal(t, windowEnd, block.EndTime())
+}
@@ -64,6 +64,38 @@ func TestNamespaceIndexCleanupExpiredFilesets(t *testing.T) {
 	require.NoError(t, idx.CleanupExpiredFileSets(now))
 }
 
+func TestNamespaceIndexQueryFilterIDTombstones(t *testing.T) {
+	md := testNamespaceMetadata(time.Hour, time.Hour*8)
+	nsIdx, err := newNamespaceIndex(md, testShardSet, DefaultTestOptions())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, nsIdx.Close())
+	}()
+
+	var (
+		idx     = nsIdx.(*nsIndex)
+		now     = time.Now().Truncate(time.Hour)
+		foo     = ident.StringID("foo")
+		bar     = ident.StringID("bar")
+		deleted = index.QueryOptions{StartInclusive: now.Add(-2 * time.Hour), EndExclusive: now}
+		partial = index.QueryOptions{StartInclusive: now.Add(-3 * time.Hour), EndExclusive: now}
+	)
+	idx.Tombstone([]ident.ID{foo}, xtime.Range{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)})
+	idx.Tombstone([]ident.ID{foo}, xtime.Range{Start: now.Add(-time.Hour), End: now})
+
+	// The adjacent deletes together hide the series for the query.
+	require.False(t, idx.queryFilterID(deleted)(foo))
+	require.True(t, idx.queryFilterID(deleted)(bar))
+	require.True(t, idx.queryFilterID(partial)(foo))
+
+	idx.expireTombstones(now.Add(-90 * time.Minute))
+	require.True(t, idx.queryFilterID(deleted)(foo))
+	require.False(t, idx.queryFilterID(index.QueryOptions{
+		StartInclusive: now.Add(-90 * time.Minute),
+		EndExclusive:   now,
+	})(foo))
+}
+
 func TestNamespaceIndexCleanupExpiredFilesetsWithBlocks(t *testing.T) {
 	ctrl := gomock.NewController(xtest.Reporter{T: t})
 	defer ctrl.Finish()
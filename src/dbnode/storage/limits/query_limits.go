@@ -0,0 +1,216 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/atomic"
+)
+
+const (
+	defaultLookback = 15 * time.Second
+)
+
+var (
+	errNegativeLimit       = errors.New("query limit must not be negative")
+	errNonPositiveLookback = errors.New("query limit lookback must be positive")
+)
+
+type queryLimits struct {
+	docsLimit       *lookbackLimit
+	maxDocsPerQuery int
+}
+
+type lookbackLimit struct {
+	name    string
+	options LookbackLimitOptions
+	metrics lookbackLimitMetrics
+	recent  *atomic.Int64
+	stopCh  chan struct{}
+	stopWg  sync.WaitGroup
+}
+
+type lookbackLimitMetrics struct {
+	recentCount tally.Gauge
+	exceeded    tally.Counter
+}
+
+var (
+	_ QueryLimits   = (*queryLimits)(nil)
+	_ LookbackLimit = (*lookbackLimit)(nil)
+)
+
+// DefaultLookbackLimitOptions returns the default lookback limit options,
+// which do not limit.
+func DefaultLookbackLimitOptions() LookbackLimitOptions {
+	return LookbackLimitOptions{
+		Lookback: defaultLookback,
+	}
+}
+
+// Validate validates the lookback limit options.
+func (opts LookbackLimitOptions) Validate() error {
+	if opts.Limit < 0 {
+		return errNegativeLimit
+	}
+	if opts.Lookback <= 0 {
+		return errNonPositiveLookback
+	}
+	return nil
+}
+
+// NewQueryLimits returns new query limits.
+func NewQueryLimits(opts QueryLimitsOptions) (QueryLimits, error) {
+	if err := opts.DocsLimitOptions.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.MaxDocsPerQuery < 0 {
+		return nil, errNegativeLimit
+	}
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+	return &queryLimits{
+		docsLimit: newLookbackLimit(iOpts.MetricsScope(), "docs-matched",
+			opts.DocsLimitOptions),
+		maxDocsPerQuery: opts.MaxDocsPerQuery,
+	}, nil
+}
+
+// NoOpQueryLimits returns query limits that do not limit.
+func NoOpQueryLimits() QueryLimits {
+	return &queryLimits{
+		docsLimit: newLookbackLimit(tally.NoopScope, "docs-matched",
+			DefaultLookbackLimitOptions()),
+	}
+}
+
+func newLookbackLimit(
+	scope tally.Scope,
+	name string,
+	opts LookbackLimitOptions,
+) *lookbackLimit {
+	scope = scope.SubScope("query-limit").Tagged(map[string]string{
+		"limit": name,
+	})
+	return &lookbackLimit{
+		name:    name,
+		options: opts,
+		metrics: lookbackLimitMetrics{
+			recentCount: scope.Gauge("recent-count"),
+			exceeded:    scope.Counter("exceeded"),
+		},
+		recent: atomic.NewInt64(0),
+		stopCh: make(chan struct{}),
+	}
+}
+
+func (q *queryLimits) DocsLimit() LookbackLimit {
+	return q.docsLimit
+}
+
+func (q *queryLimits) MaxDocsPerQuery() int {
+	return q.maxDocsPerQuery
+}
+
+func (q *queryLimits) Start() {
+	q.docsLimit.start()
+}
+
+func (q *queryLimits) Stop() {
+	q.docsLimit.stop()
+}
+
+func (q *lookbackLimit) Inc(new int) error {
+	if q.options.Limit <= 0 {
+		return nil
+	}
+	recent := q.recent.Add(int64(new))
+	if recent > q.options.Limit {
+		q.metrics.exceeded.Inc(1)
+		return NewQueryLimitExceededError(fmt.Sprintf(
+			"query aborted, global recent %s limit exceeded: limit=%d, lookback=%s",
+			q.name, q.options.Limit, q.options.Lookback.String()))
+	}
+	return nil
+}
+
+func (q *lookbackLimit) start() {
+	q.stopWg.Add(1)
+	go func() {
+		defer q.stopWg.Done()
+		ticker := time.NewTicker(q.options.Lookback)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				q.reset()
+			case <-q.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (q *lookbackLimit) stop() {
+	close(q.stopCh)
+	q.stopWg.Wait()
+}
+
+func (q *lookbackLimit) reset() {
+	recent := q.recent.Swap(0)
+	q.metrics.recentCount.Update(float64(recent))
+}
+
+type queryLimitExceededError struct {
+	msg string
+}
+
+func (e queryLimitExceededError) Error() string {
+	return e.msg
+}
+
+// NewQueryLimitExceededError returns a new error indicating a query limit
+// was exceeded, these errors are not retryable.
+func NewQueryLimitExceededError(msg string) error {
+	return xerrors.NewInvalidParamsError(queryLimitExceededError{msg: msg})
+}
+
+// IsQueryLimitExceededError returns true if the error is a query limit
+// exceeded error.
+func IsQueryLimitExceededError(err error) bool {
+	for err != nil {
+		if _, ok := err.(queryLimitExceededError); ok {
+			return true
+		}
+		err = xerrors.InnerError(err)
+	}
+	return false
+}
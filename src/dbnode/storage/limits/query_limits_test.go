@@ -0,0 +1,117 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package limits
+
+import (
+	"testing"
+	"time"
+
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestQueryLimitsValidation(t *testing.T) {
+	_, err := NewQueryLimits(QueryLimitsOptions{
+		DocsLimitOptions: LookbackLimitOptions{Limit: -1, Lookback: time.Second},
+	})
+	require.Error(t, err)
+
+	_, err = NewQueryLimits(QueryLimitsOptions{
+		DocsLimitOptions: LookbackLimitOptions{Limit: 1},
+	})
+	require.Error(t, err)
+
+	_, err = NewQueryLimits(QueryLimitsOptions{
+		DocsLimitOptions: DefaultLookbackLimitOptions(),
+		MaxDocsPerQuery:  -1,
+	})
+	require.Error(t, err)
+}
+
+func TestLookbackLimit(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	limits, err := NewQueryLimits(QueryLimitsOptions{
+		DocsLimitOptions: LookbackLimitOptions{
+			Limit:    10,
+			Lookback: time.Hour,
+		},
+		MaxDocsPerQuery:   5,
+		InstrumentOptions: instrument.NewOptions().SetMetricsScope(scope),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, limits.MaxDocsPerQuery())
+
+	docsLimit := limits.DocsLimit()
+	require.NoError(t, docsLimit.Inc(4))
+	require.NoError(t, docsLimit.Inc(6))
+
+	err = docsLimit.Inc(1)
+	require.Error(t, err)
+	require.True(t, IsQueryLimitExceededError(err))
+	require.True(t, xerrors.IsInvalidParams(err))
+
+	counters := scope.Snapshot().Counters()
+	exceeded, ok := counters["query-limit.exceeded+limit=docs-matched"]
+	require.True(t, ok)
+	require.Equal(t, int64(1), exceeded.Value())
+
+	// Resetting the limit allows queries to proceed.
+	docsLimit.(*lookbackLimit).reset()
+	require.NoError(t, docsLimit.Inc(10))
+
+	gauges := scope.Snapshot().Gauges()
+	recent, ok := gauges["query-limit.recent-count+limit=docs-matched"]
+	require.True(t, ok)
+	require.Equal(t, float64(11), recent.Value())
+}
+
+func TestLookbackLimitResetsEachLookback(t *testing.T) {
+	limits, err := NewQueryLimits(QueryLimitsOptions{
+		DocsLimitOptions: LookbackLimitOptions{
+			Limit:    1,
+			Lookback: 10 * time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+
+	limits.Start()
+	defer limits.Stop()
+
+	require.NoError(t, limits.DocsLimit().Inc(1))
+	require.Error(t, limits.DocsLimit().Inc(1))
+
+	for start := time.Now(); time.Since(start) < 5*time.Second; {
+		if limits.DocsLimit().Inc(0) == nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.FailNow(t, "lookback limit was not reset")
+}
+
+func TestNoOpQueryLimits(t *testing.T) {
+	limits := NoOpQueryLimits()
+	require.Equal(t, 0, limits.MaxDocsPerQuery())
+	require.NoError(t, limits.DocsLimit().Inc(1<<30))
+}
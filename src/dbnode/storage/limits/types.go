@@ -0,0 +1,72 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package limits contains limits applied to the queries of a node.
+package limits
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// QueryLimits provides the limits applied to the index queries of a node.
+type QueryLimits interface {
+	// DocsLimit returns the limit on the number of index documents matched by
+	// all queries of the node within the lookback duration.
+	DocsLimit() LookbackLimit
+
+	// MaxDocsPerQuery returns the maximum number of index documents a single
+	// query can match before it is aborted, zero means no limit.
+	MaxDocsPerQuery() int
+
+	// Start starts resetting the lookback limits at each lookback.
+	Start()
+
+	// Stop stops resetting the lookback limits.
+	Stop()
+}
+
+// LookbackLimit is a limit on a value accumulated over a lookback duration.
+type LookbackLimit interface {
+	// Inc increments the current value and returns an error if the limit
+	// has been exceeded.
+	Inc(new int) error
+}
+
+// LookbackLimitOptions holds options for a lookback limit.
+type LookbackLimitOptions struct {
+	// Limit is the maximum value within the lookback, zero disables the limit.
+	Limit int64
+	// Lookback is the duration after which the value is reset.
+	Lookback time.Duration
+}
+
+// QueryLimitsOptions holds options for the query limits.
+type QueryLimitsOptions struct {
+	// DocsLimitOptions are the options of the lookback limit on the number
+	// of documents matched by all queries of the node.
+	DocsLimitOptions LookbackLimitOptions
+	// MaxDocsPerQuery is the maximum number of documents matched by a
+	// single query, zero disables the limit.
+	MaxDocsPerQuery int
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/x/instrument"
 
 	"github.com/uber-go/tally"
@@ -153,6 +154,10 @@ func (m *mediator) Report() {
 	m.databaseFileSystemManager.Report()
 }
 
+func (m *mediator) RepairStatus() []repair.NamespaceRepairStatus {
+	return m.databaseRepairer.Status()
+}
+
 func (m *mediator) Close() error {
 	m.Lock()
 	defer m.Unlock()
@@ -26,12 +26,14 @@ import (
 	"math"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
@@ -58,6 +60,8 @@ import (
 var (
 	errNamespaceAlreadyClosed    = errors.New("namespace already closed")
 	errNamespaceIndexingDisabled = errors.New("namespace indexing is disabled")
+
+	errNamespaceDeleteInvalidRange = errors.New("delete range start must be before end")
 )
 
 type commitLogWriter interface {
@@ -68,35 +72,35 @@ type commitLogWriter interface {
 		unit xtime.Unit,
 		annotation ts.Annotation,
 	) error
+
+	WriteTombstone(
+		ctx context.Context,
+		series ts.Series,
+		tombstone commitlog.Tombstone,
+	) error
 }
 
-type commitLogWriterFn func(
-	ctx context.Context,
-	series ts.Series,
-	datapoint ts.Datapoint,
-	unit xtime.Unit,
-	annotation ts.Annotation,
-) error
+type commitLogWriterNoOp struct{}
 
-func (fn commitLogWriterFn) Write(
+func (commitLogWriterNoOp) Write(
 	ctx context.Context,
 	series ts.Series,
 	datapoint ts.Datapoint,
 	unit xtime.Unit,
 	annotation ts.Annotation,
 ) error {
-	return fn(ctx, series, datapoint, unit, annotation)
+	return nil
 }
 
-var commitLogWriteNoOp = commitLogWriter(commitLogWriterFn(func(
+func (commitLogWriterNoOp) WriteTombstone(
 	ctx context.Context,
 	series ts.Series,
-	datapoint ts.Datapoint,
-	unit xtime.Unit,
-	annotation ts.Annotation,
+	tombstone commitlog.Tombstone,
 ) error {
 	return nil
-}))
+}
+
+var commitLogWriteNoOp = commitLogWriter(commitLogWriterNoOp{})
 
 type dbNamespace struct {
 	sync.RWMutex
@@ -128,11 +132,19 @@ type dbNamespace struct {
 	increasingIndex increasingIndex
 	commitLogWriter commitLogWriter
 	reverseIndex    namespaceIndex
+	limiter         *namespaceLimiter
 
 	tickWorkers            xsync.WorkerPool
 	tickWorkersConcurrency int
 	statsLastTick          databaseNamespaceStatsLastTick
 
+	// pendingDeleteBlocksLastTick is the number of series blocks with deletes
+	// that are yet to be removed from disk as of the last tick and
+	// deletesSinceLastTick the number of series deleted since, while either
+	// is non-zero cold flushes run even if cold writes are disabled.
+	pendingDeleteBlocksLastTick int64
+	deletesSinceLastTick        int64
+
 	metrics databaseNamespaceMetrics
 }
 
@@ -162,6 +174,7 @@ type databaseNamespaceMetrics struct {
 	fetchBlocksMetadata instrument.MethodMetrics
 	queryIDs            instrument.MethodMetrics
 	aggregateQuery      instrument.MethodMetrics
+	deleteTagged        instrument.MethodMetrics
 	unfulfilled         tally.Counter
 	bootstrapStart      tally.Counter
 	bootstrapEnd        tally.Counter
@@ -189,6 +202,7 @@ type databaseNamespaceTickMetrics struct {
 	errors                 tally.Counter
 	index                  databaseNamespaceIndexTickMetrics
 	evictedBuckets         tally.Counter
+	pendingDeleteBlocks    tally.Gauge
 }
 
 type databaseNamespaceIndexTickMetrics struct {
@@ -241,6 +255,7 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		queryIDs:            instrument.NewMethodMetrics(scope, "queryIDs", samplingRate),
 		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", samplingRate),
+		deleteTagged:        instrument.NewMethodMetrics(scope, "deleteTagged", samplingRate),
 		unfulfilled:         scope.Counter("bootstrap.unfulfilled"),
 		bootstrapStart:      scope.Counter("bootstrap.start"),
 		bootstrapEnd:        scope.Counter("bootstrap.end"),
@@ -267,7 +282,8 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 				numBlocksSealed:  indexTickScope.Counter("num-blocks-sealed"),
 				numBlocksEvicted: indexTickScope.Counter("num-blocks-evicted"),
 			},
-			evictedBuckets: tickScope.Counter("evicted-buckets"),
+			evictedBuckets:      tickScope.Counter("evicted-buckets"),
+			pendingDeleteBlocks: tickScope.Gauge("pending-delete-blocks"),
 		},
 		status: databaseNamespaceStatusMetrics{
 			activeSeries: statusScope.Gauge("active-series"),
@@ -293,7 +309,7 @@ func newDatabaseNamespace(
 		nopts = metadata.Options()
 		id    = metadata.ID()
 	)
-	if !nopts.WritesToCommitLog() {
+	if !nopts.WritesToCommitLog() || commitLogWriter == nil {
 		commitLogWriter = commitLogWriteNoOp
 	}
 
@@ -347,6 +363,7 @@ func newDatabaseNamespace(
 		increasingIndex:        increasingIndex,
 		commitLogWriter:        commitLogWriter,
 		reverseIndex:           index,
+		limiter:                newNamespaceLimiter(id, scope, opts),
 		tickWorkers:            tickWorkers,
 		tickWorkersConcurrency: tickWorkersConcurrency,
 		metrics:                newDatabaseNamespaceMetrics(scope, iops.MetricsSamplingRate()),
@@ -479,7 +496,7 @@ func (n *dbNamespace) AssignShardSet(shardSet sharding.ShardSet) {
 			bootstrapEnabled := n.nopts.BootstrapEnabled()
 			n.shards[shard] = newDatabaseShard(metadata, shard, n.blockRetriever,
 				n.namespaceReaderMgr, n.increasingIndex, n.reverseIndex,
-				bootstrapEnabled, n.opts, n.seriesOpts)
+				n.limiter, bootstrapEnabled, n.opts, n.seriesOpts)
 			n.metrics.shards.add.Inc(1)
 		}
 	}
@@ -539,6 +556,10 @@ func (n *dbNamespace) Tick(c context.Cancellable, startTime time.Time) error {
 	nsCtx := n.nsContextWithRLock()
 	n.RUnlock()
 
+	// Any series deleted from here on may be ticked before the delete and
+	// so must keep counting towards the pending deletes until the next tick.
+	deletesBeforeTick := atomic.SwapInt64(&n.deletesSinceLastTick, 0)
+
 	// Tick through the shards at a capped level of concurrency.
 	var (
 		r        tickResult
@@ -582,9 +603,13 @@ func (n *dbNamespace) Tick(c context.Cancellable, startTime time.Time) error {
 	// NB: we early terminate here to ensure we are not reporting metrics
 	// based on in-accurate/partial tick results.
 	if err := multiErr.FinalError(); err != nil || c.IsCancelled() {
+		// Make sure deletes are still accounted for by the next tick.
+		atomic.AddInt64(&n.deletesSinceLastTick, deletesBeforeTick)
 		return err
 	}
 
+	atomic.StoreInt64(&n.pendingDeleteBlocksLastTick, int64(r.pendingDeleteBlocks))
+
 	n.statsLastTick.Lock()
 	n.statsLastTick.activeSeries = int64(r.activeSeries)
 	n.statsLastTick.activeBlocks = int64(r.activeBlocks)
@@ -605,6 +630,7 @@ func (n *dbNamespace) Tick(c context.Cancellable, startTime time.Time) error {
 	n.metrics.tick.madeUnwiredBlocks.Inc(int64(r.madeUnwiredBlocks))
 	n.metrics.tick.mergedOutOfOrderBlocks.Inc(int64(r.mergedOutOfOrderBlocks))
 	n.metrics.tick.evictedBuckets.Inc(int64(r.evictedBuckets))
+	n.metrics.tick.pendingDeleteBlocks.Update(float64(r.pendingDeleteBlocks))
 	n.metrics.tick.index.numDocs.Update(float64(indexTickResults.NumTotalDocs))
 	n.metrics.tick.index.numBlocks.Update(float64(indexTickResults.NumBlocks))
 	n.metrics.tick.index.numSegments.Update(float64(indexTickResults.NumSegments))
@@ -722,14 +748,99 @@ func (n *dbNamespace) QueryIDs(
 			xerrors.NewRetryableError(err)
 	}
 
+	if err := n.limiter.AllowRead(); err != nil {
+		n.metrics.queryIDs.ReportError(n.nowFn().Sub(callStart))
+		sp.LogFields(opentracinglog.Error(err))
+		return index.QueryResult{}, err
+	}
+
 	res, err := n.reverseIndex.Query(ctx, query, opts)
 	if err != nil {
 		sp.LogFields(opentracinglog.Error(err))
+	} else if res.Results != nil && !opts.SeriesDataFetched {
+		// NB: The series are charged as their data is read otherwise.
+		n.limiter.ChargeReadSeries(res.Results.Size())
 	}
 	n.metrics.queryIDs.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
 	return res, err
 }
 
+func (n *dbNamespace) DeleteTagged(
+	ctx context.Context,
+	query index.Query,
+	start, end time.Time,
+) (int64, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil { // only happens if indexing is enabled.
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return 0, errNamespaceIndexingDisabled
+	}
+
+	if !start.Before(end) {
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return 0, xerrors.NewInvalidParamsError(errNamespaceDeleteInvalidRange)
+	}
+
+	if n.reverseIndex.BootstrapsDone() < 1 {
+		// Similar to reading shard data, return not bootstrapped
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return 0, xerrors.NewRetryableError(errIndexNotBootstrappedToRead)
+	}
+
+	res, err := n.reverseIndex.Query(ctx, query, index.QueryOptions{
+		StartInclusive: start,
+		EndExclusive:   end,
+	})
+	if err != nil {
+		n.metrics.deleteTagged.ReportError(n.nowFn().Sub(callStart))
+		return 0, err
+	}
+
+	var (
+		multiErr xerrors.MultiError
+		deleted  = make([]ident.ID, 0, res.Results.Size())
+	)
+	for _, entry := range res.Results.Map().Iter() {
+		id := entry.Key()
+		shard, nsCtx, err := n.shardFor(id)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		tags := entry.Value().Duplicate()
+		series, err := shard.DeleteSeriesRange(id, tags, start, end, nsCtx)
+		tags.Close()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		deleted = append(deleted, id)
+		atomic.AddInt64(&n.deletesSinceLastTick, 1)
+
+		// Record the delete so that it is replayed if the node restarts before
+		// a cold flush has removed the data from disk.
+		err = n.commitLogWriter.WriteTombstone(ctx, series, commitlog.Tombstone{
+			Range: xtime.Range{Start: start, End: end},
+		})
+		if err != nil {
+			multiErr = multiErr.Add(err)
+		}
+	}
+
+	// NB: Only hide the series from queries for the time that has passed so
+	// that any writes received after the delete remain queryable.
+	if now := n.nowFn(); end.After(now) {
+		end = now
+	}
+	n.reverseIndex.Tombstone(deleted, xtime.Range{Start: start, End: end})
+
+	err = multiErr.FinalError()
+	n.metrics.deleteTagged.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return int64(len(deleted)), err
+}
+
 func (n *dbNamespace) AggregateQuery(
 	ctx context.Context,
 	query index.Query,
@@ -795,7 +906,14 @@ func (n *dbNamespace) ReadEncoded(
 		n.metrics.read.ReportError(n.nowFn().Sub(callStart))
 		return nil, err
 	}
+	if err := n.limiter.AllowRead(); err != nil {
+		n.metrics.read.ReportError(n.nowFn().Sub(callStart))
+		return nil, err
+	}
 	res, err := shard.ReadEncoded(ctx, id, start, end, nsCtx)
+	if err == nil {
+		n.limiter.ChargeReadEncoded(res)
+	}
 	n.metrics.read.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
 	return res, err
 }
@@ -879,6 +997,7 @@ func (n *dbNamespace) Bootstrap(
 
 	var (
 		bootstrappedShards = bootstrapResult.Shards
+		succeededShards    = make([]databaseShard, 0, len(bootstrappedShards))
 		multiErr           = xerrors.NewMultiError()
 		mutex              sync.Mutex
 		wg                 sync.WaitGroup
@@ -920,6 +1039,9 @@ func (n *dbNamespace) Bootstrap(
 
 			mutex.Lock()
 			multiErr = multiErr.Add(err)
+			if err == nil {
+				succeededShards = append(succeededShards, shard)
+			}
 			mutex.Unlock()
 
 			wg.Done()
@@ -933,6 +1055,12 @@ func (n *dbNamespace) Bootstrap(
 			zap.Int("numIndexBlocks", len(indexResults)))
 		err := n.reverseIndex.Bootstrap(indexResults)
 		multiErr = multiErr.Add(err)
+
+		// The flushed index segments still contain the series deleted before
+		// the node restarted, so hide them from queries again.
+		for _, shard := range succeededShards {
+			multiErr = multiErr.Add(n.restoreIndexTombstones(shard))
+		}
 	}
 
 	markAnyUnfulfilled := func(
@@ -1106,8 +1234,9 @@ func (n *dbNamespace) ColdFlush(flushPersist persist.FlushPreparer) error {
 	n.RUnlock()
 
 	// If repair is enabled we still need cold flush regardless of whether cold writes is
-	// enabled since repairs are dependent on the cold flushing logic.
-	if !n.nopts.ColdWritesEnabled() && !n.nopts.RepairEnabled() {
+	// enabled since repairs are dependent on the cold flushing logic, the same
+	// applies to deletes which are removed from disk by rewriting the blocks.
+	if !n.nopts.ColdWritesEnabled() && !n.nopts.RepairEnabled() && !n.hasPendingDeletes() {
 		n.metrics.flushColdData.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
 	}
@@ -1133,6 +1262,73 @@ func (n *dbNamespace) ColdFlush(flushPersist persist.FlushPreparer) error {
 	return res
 }
 
+func (n *dbNamespace) hasPendingDeletes() bool {
+	return atomic.LoadInt64(&n.pendingDeleteBlocksLastTick) > 0 ||
+		atomic.LoadInt64(&n.deletesSinceLastTick) > 0
+}
+
+func (n *dbNamespace) LogPendingDeletes() error {
+	if !n.hasPendingDeletes() {
+		return nil
+	}
+
+	ctx := n.opts.ContextPool().Get()
+	defer ctx.Close()
+
+	multiErr := xerrors.NewMultiError()
+	for _, shard := range n.GetOwnedShards() {
+		err := shard.ForEachPendingTombstone(func(
+			series ts.Series,
+			tombstones xtime.Ranges,
+		) error {
+			// NB: The deletes only need to apply to data on disk since the
+			// data in memory is captured by the snapshot that follows, any
+			// writes received after the delete must not be removed on replay.
+			iter := tombstones.Iter()
+			for iter.Next() {
+				err := n.commitLogWriter.WriteTombstone(ctx, series, commitlog.Tombstone{
+					Range:       iter.Value(),
+					FlushedOnly: true,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to log pending deletes: %v", shard.ID(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	return multiErr.FinalError()
+}
+
+// restoreIndexTombstones tombstones the series of the shard in the index
+// with the deletes replayed from the commit log that are yet to be removed
+// from disk.
+func (n *dbNamespace) restoreIndexTombstones(shard databaseShard) error {
+	// NB: Only hide the series from queries for the time that has passed,
+	// the same as when the series were deleted.
+	now := n.nowFn()
+	return shard.ForEachPendingTombstone(func(
+		series ts.Series,
+		tombstones xtime.Ranges,
+	) error {
+		ids := []ident.ID{series.ID}
+		iter := tombstones.Iter()
+		for iter.Next() {
+			tr := iter.Value()
+			if tr.End.After(now) {
+				tr.End = now
+			}
+			n.reverseIndex.Tombstone(ids, tr)
+		}
+		return nil
+	})
+}
+
 func (n *dbNamespace) FlushIndex(flush persist.IndexFlush) error {
 	callStart := n.nowFn()
 	n.RLock()
@@ -1428,7 +1624,7 @@ func (n *dbNamespace) initShards(needBootstrap bool) {
 	for _, shard := range shards {
 		dbShards[shard] = newDatabaseShard(n.metadata, shard, n.blockRetriever,
 			n.namespaceReaderMgr, n.increasingIndex, n.reverseIndex,
-			needBootstrap, n.opts, n.seriesOpts)
+			n.limiter, needBootstrap, n.opts, n.seriesOpts)
 	}
 	n.shards = dbShards
 	n.Unlock()
@@ -1448,6 +1644,7 @@ func (n *dbNamespace) Close() error {
 	n.namespaceReaderMgr.close()
 	n.closeShards(shards, true)
 	close(n.shutdownCh)
+	n.limiter.Close()
 	if n.reverseIndex != nil {
 		return n.reverseIndex.Close()
 	}
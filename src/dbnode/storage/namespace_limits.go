@@ -0,0 +1,213 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"sync"
+
+	"github.com/m3db/m3/src/dbnode/ratelimit"
+	"github.com/m3db/m3/src/dbnode/runtime"
+	dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	xclose "github.com/m3db/m3/src/x/close"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	writeDatapointsLimitName = "writeDatapointsPerSecond"
+	writeNewSeriesLimitName  = "writeNewSeriesPerSecond"
+	readSeriesLimitName      = "readSeriesPerSecond"
+	readBytesLimitName       = "readBytesPerSecond"
+)
+
+type namespaceLimiterMetrics struct {
+	writeDatapointsLimited tally.Counter
+	writeNewSeriesLimited  tally.Counter
+	readSeriesLimited      tally.Counter
+	readBytesLimited       tally.Counter
+	readSeries             tally.Counter
+	readBytes              tally.Counter
+}
+
+func newNamespaceLimiterMetrics(scope tally.Scope) namespaceLimiterMetrics {
+	scope = scope.SubScope("rate-limit")
+	limitedCounter := func(limit string) tally.Counter {
+		return scope.Tagged(map[string]string{
+			"limit": limit,
+		}).Counter("limited")
+	}
+	return namespaceLimiterMetrics{
+		writeDatapointsLimited: limitedCounter(writeDatapointsLimitName),
+		writeNewSeriesLimited:  limitedCounter(writeNewSeriesLimitName),
+		readSeriesLimited:      limitedCounter(readSeriesLimitName),
+		readBytesLimited:       limitedCounter(readBytesLimitName),
+		readSeries:             scope.Counter("read-series"),
+		readBytes:              scope.Counter("read-bytes"),
+	}
+}
+
+// namespaceLimiter enforces the write and read rate limits of a namespace
+// set by the runtime options, all methods are safe to call on a nil limiter
+// which does not limit.
+type namespaceLimiter struct {
+	sync.RWMutex
+
+	namespace string
+	limit     ratelimit.NamespaceLimit
+
+	writeDatapoints *ratelimit.TokenBucket
+	writeNewSeries  *ratelimit.TokenBucket
+	readSeries      *ratelimit.TokenBucket
+	readBytes       *ratelimit.TokenBucket
+
+	runtimeOptsListener xclose.SimpleCloser
+	metrics             namespaceLimiterMetrics
+}
+
+func newNamespaceLimiter(
+	id ident.ID,
+	scope tally.Scope,
+	opts Options,
+) *namespaceLimiter {
+	nowFn := opts.ClockOptions().NowFn()
+	l := &namespaceLimiter{
+		namespace:       id.String(),
+		writeDatapoints: ratelimit.NewTokenBucket(0, 0, nowFn),
+		writeNewSeries:  ratelimit.NewTokenBucket(0, 0, nowFn),
+		readSeries:      ratelimit.NewTokenBucket(0, 0, nowFn),
+		readBytes:       ratelimit.NewTokenBucket(0, 0, nowFn),
+		metrics:         newNamespaceLimiterMetrics(scope),
+	}
+	l.runtimeOptsListener = opts.RuntimeOptionsManager().RegisterListener(l)
+	return l
+}
+
+func (l *namespaceLimiter) SetRuntimeOptions(value runtime.Options) {
+	limit := value.NamespaceLimits()[l.namespace]
+
+	l.Lock()
+	defer l.Unlock()
+	if limit == l.limit {
+		// Avoid refilling the token buckets on unrelated updates.
+		return
+	}
+	l.limit = limit
+	l.writeDatapoints.Reset(limit.WriteDatapointsPerSecond,
+		limit.Burst(limit.WriteDatapointsPerSecond))
+	l.writeNewSeries.Reset(limit.WriteNewSeriesPerSecond,
+		limit.Burst(limit.WriteNewSeriesPerSecond))
+	l.readSeries.Reset(limit.ReadSeriesPerSecond,
+		limit.Burst(limit.ReadSeriesPerSecond))
+	l.readBytes.Reset(limit.ReadBytesPerSecond,
+		limit.Burst(limit.ReadBytesPerSecond))
+}
+
+// AllowWriteDatapoints returns an error if writing n datapoints would
+// exceed the datapoints write limit.
+func (l *namespaceLimiter) AllowWriteDatapoints(n int) error {
+	if l == nil {
+		return nil
+	}
+	if !l.writeDatapoints.TryTake(float64(n)) {
+		l.metrics.writeDatapointsLimited.Inc(1)
+		return dberrors.NewRateLimitExceededError(l.namespace,
+			writeDatapointsLimitName)
+	}
+	return nil
+}
+
+// AllowWriteNewSeries returns an error if creating a new series would
+// exceed the new series write limit.
+func (l *namespaceLimiter) AllowWriteNewSeries() error {
+	if l == nil {
+		return nil
+	}
+	if !l.writeNewSeries.TryTake(1) {
+		l.metrics.writeNewSeriesLimited.Inc(1)
+		return dberrors.NewRateLimitExceededError(l.namespace,
+			writeNewSeriesLimitName)
+	}
+	return nil
+}
+
+// AllowRead returns an error if the read limits have been exhausted, since
+// the cost of a read is only known once it has been performed reads are
+// admitted while tokens are available and charged afterwards.
+func (l *namespaceLimiter) AllowRead() error {
+	if l == nil {
+		return nil
+	}
+	if !l.readSeries.HasTokens() {
+		l.metrics.readSeriesLimited.Inc(1)
+		return dberrors.NewRateLimitExceededError(l.namespace,
+			readSeriesLimitName)
+	}
+	if !l.readBytes.HasTokens() {
+		l.metrics.readBytesLimited.Inc(1)
+		return dberrors.NewRateLimitExceededError(l.namespace,
+			readBytesLimitName)
+	}
+	return nil
+}
+
+// ChargeReadSeries charges the read series limit for series read or
+// matched by a query.
+func (l *namespaceLimiter) ChargeReadSeries(series int) {
+	if l == nil {
+		return
+	}
+	l.readSeries.Take(float64(series))
+	l.metrics.readSeries.Inc(int64(series))
+}
+
+// ChargeReadEncoded charges the read limits for a single series read, the
+// bytes read are only accounted for when the bytes read limit is enabled.
+func (l *namespaceLimiter) ChargeReadEncoded(readers [][]xio.BlockReader) {
+	if l == nil {
+		return
+	}
+	l.ChargeReadSeries(1)
+	if !l.readBytes.Enabled() {
+		return
+	}
+	var bytes int
+	for _, blockReaders := range readers {
+		for _, reader := range blockReaders {
+			segment, err := reader.Segment()
+			if err != nil {
+				continue
+			}
+			bytes += segment.Len()
+		}
+	}
+	l.readBytes.Take(float64(bytes))
+	l.metrics.readBytes.Inc(int64(bytes))
+}
+
+// Close stops listening for runtime options updates.
+func (l *namespaceLimiter) Close() {
+	if l == nil {
+		return
+	}
+	l.runtimeOptsListener.Close()
+}
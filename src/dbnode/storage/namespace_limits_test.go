@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/ratelimit"
+	"github.com/m3db/m3/src/dbnode/runtime"
+	"github.com/m3db/m3/src/dbnode/storage/errors"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/x/context"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestNamespaceLimiterNilDoesNotLimit(t *testing.T) {
+	var l *namespaceLimiter
+	require.NoError(t, l.AllowWriteDatapoints(1))
+	require.NoError(t, l.AllowWriteNewSeries())
+	require.NoError(t, l.AllowRead())
+	l.ChargeReadSeries(1)
+	l.ChargeReadEncoded(nil)
+	l.Close()
+}
+
+func TestNamespaceLimiterRuntimeOptions(t *testing.T) {
+	now := time.Now()
+	runtimeOptsMgr := runtime.NewOptionsManager()
+	defer runtimeOptsMgr.Close()
+	opts := DefaultTestOptions().
+		SetRuntimeOptionsManager(runtimeOptsMgr).
+		SetClockOptions(DefaultTestOptions().ClockOptions().
+			SetNowFn(func() time.Time { return now }))
+
+	l := newNamespaceLimiter(ident.StringID("ns"), tally.NoopScope, opts)
+	defer l.Close()
+
+	// No limits set.
+	require.NoError(t, l.AllowWriteDatapoints(1000))
+	require.NoError(t, l.AllowWriteNewSeries())
+
+	// Runtime options updates are delivered asynchronously so set them
+	// directly to avoid waiting on the listener.
+	l.SetRuntimeOptions(runtime.NewOptions().
+		SetNamespaceLimits(ratelimit.NamespaceLimits{
+			"ns": ratelimit.NamespaceLimit{
+				WriteDatapointsPerSecond: 10,
+				WriteNewSeriesPerSecond:  1,
+				ReadSeriesPerSecond:      5,
+			},
+			"other": ratelimit.NamespaceLimit{
+				WriteDatapointsPerSecond: 1,
+			},
+		}))
+
+	require.NoError(t, l.AllowWriteDatapoints(10))
+	err := l.AllowWriteDatapoints(1)
+	require.Error(t, err)
+	require.True(t, errors.IsRateLimitExceededError(err))
+	require.True(t, xerrors.IsRetryableError(err))
+
+	require.NoError(t, l.AllowWriteNewSeries())
+	require.True(t, errors.IsRateLimitExceededError(l.AllowWriteNewSeries()))
+
+	require.NoError(t, l.AllowRead())
+	l.ChargeReadSeries(10)
+	require.True(t, errors.IsRateLimitExceededError(l.AllowRead()))
+
+	// Tokens are refilled over time.
+	now = now.Add(time.Second)
+	require.NoError(t, l.AllowWriteDatapoints(10))
+	require.NoError(t, l.AllowWriteNewSeries())
+	require.True(t, errors.IsRateLimitExceededError(l.AllowRead()))
+	now = now.Add(2 * time.Second)
+	require.NoError(t, l.AllowRead())
+
+	// Removing the limits disables them.
+	l.SetRuntimeOptions(runtime.NewOptions())
+	require.NoError(t, l.AllowWriteDatapoints(1000))
+	require.NoError(t, l.AllowWriteNewSeries())
+}
+
+func TestShardWriteRateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	opts := DefaultTestOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	shard.limiter = newNamespaceLimiter(defaultTestNs1ID, tally.NoopScope, opts)
+	shard.limiter.SetRuntimeOptions(runtime.NewOptions().
+		SetNamespaceLimits(ratelimit.NamespaceLimits{
+			defaultTestNs1ID.String(): ratelimit.NamespaceLimit{
+				WriteDatapointsPerSecond: 2,
+				WriteNewSeriesPerSecond:  1,
+			},
+		}))
+	// Consume the only new series token.
+	require.NoError(t, shard.limiter.AllowWriteNewSeries())
+
+	var (
+		id   = ident.StringID("foo")
+		now  = time.Now()
+		unit = xtime.Second
+	)
+	s := addMockSeries(ctrl, shard, id, ident.Tags{}, 0)
+	s.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+
+	// Rejected new series must not consume datapoint tokens.
+	_, wasWritten, err := shard.Write(ctx, ident.StringID("bar"), now, 1.0,
+		unit, nil, series.WriteOptions{})
+	require.True(t, errors.IsRateLimitExceededError(err))
+	require.False(t, wasWritten)
+
+	for i := 0; i < 2; i++ {
+		_, wasWritten, err = shard.Write(ctx, id, now, 1.0, unit, nil,
+			series.WriteOptions{})
+		require.NoError(t, err)
+		require.True(t, wasWritten)
+	}
+
+	_, wasWritten, err = shard.Write(ctx, id, now, 1.0, unit, nil,
+		series.WriteOptions{})
+	require.True(t, errors.IsRateLimitExceededError(err))
+	require.False(t, wasWritten)
+}
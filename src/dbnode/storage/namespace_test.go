@@ -25,11 +25,13 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/m3db/m3/src/cluster/shard"
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
@@ -41,6 +43,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	"github.com/m3db/m3/src/dbnode/ts"
 	xmetrics "github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	xidx "github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/x/context"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -414,6 +417,48 @@ func TestNamespaceBootstrapOnlyNonBootstrappedShards(t *testing.T) {
 	require.Equal(t, BootstrapNotStarted, ns.bootstrapState)
 }
 
+func TestNamespaceBootstrapRestoresIndexTombstones(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	var (
+		now        = time.Now().Truncate(time.Hour)
+		series     = ts.Series{ID: ident.StringID("foo")}
+		past       = xtime.Range{Start: now.Add(-3 * time.Hour), End: now.Add(-2 * time.Hour)}
+		current    = xtime.Range{Start: now.Add(-time.Hour), End: now.Add(time.Hour)}
+		tombstones = xtime.NewRanges(past, current)
+	)
+	ns.nowFn = func() time.Time { return now }
+
+	shardID := testShardIDs[0].ID()
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().IsBootstrapped().Return(false)
+	shard.EXPECT().ID().Return(shardID).AnyTimes()
+	shard.EXPECT().Bootstrap().Return(nil)
+	shard.EXPECT().
+		ForEachPendingTombstone(gomock.Any()).
+		DoAndReturn(func(fn func(ts.Series, xtime.Ranges) error) error {
+			return fn(series, tombstones)
+		})
+	ns.shards[shardID] = shard
+
+	idx.EXPECT().Bootstrap(gomock.Any()).Return(nil)
+	idx.EXPECT().Tombstone([]ident.ID{series.ID}, past)
+	// The deletes are only applied to the index up until now.
+	idx.EXPECT().Tombstone([]ident.ID{series.ID}, xtime.Range{Start: current.Start, End: now})
+
+	nsResult := bootstrap.NamespaceResult{
+		DataResult:  result.NewDataBootstrapResult(),
+		IndexResult: result.NewIndexBootstrapResult(),
+		Shards:      []uint32{shardID},
+	}
+	require.NoError(t, ns.Bootstrap(nsResult))
+}
+
 func TestNamespaceFlushNotBootstrapped(t *testing.T) {
 	ns, closer := newTestNamespace(t)
 	defer closer()
@@ -431,6 +476,134 @@ func TestNamespaceFlushDontNeedFlush(t *testing.T) {
 	require.NoError(t, ns.ColdFlush(nil))
 }
 
+func TestNamespaceColdFlushPendingDeletes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	ns.bootstrapState = Bootstrapped
+	require.False(t, ns.nopts.ColdWritesEnabled())
+
+	shards := make([]*MockdatabaseShard, 0, len(testShardIDs))
+	for _, shardID := range testShardIDs {
+		shard := NewMockdatabaseShard(ctrl)
+		shard.EXPECT().ID().Return(shardID.ID()).AnyTimes()
+		ns.shards[shardID.ID()] = shard
+		shards = append(shards, shard)
+	}
+
+	// Without cold writes the blocks are only rewritten for pending deletes.
+	require.NoError(t, ns.ColdFlush(nil))
+
+	atomic.StoreInt64(&ns.pendingDeleteBlocksLastTick, 1)
+	for _, shard := range shards {
+		shard.EXPECT().ColdFlush(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	}
+	require.NoError(t, ns.ColdFlush(nil))
+}
+
+func TestNamespaceLogPendingDeletes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	commitLog := commitlog.NewMockCommitLog(ctrl)
+	ns.commitLogWriter = commitLog
+
+	var (
+		start  = time.Now().Truncate(time.Hour)
+		first  = xtime.Range{Start: start.Add(-2 * time.Hour), End: start.Add(-time.Hour)}
+		second = xtime.Range{Start: start, End: start.Add(time.Minute)}
+		series = ts.Series{UniqueIndex: 1, ID: ident.StringID("foo")}
+	)
+	for i, shardID := range testShardIDs {
+		shard := NewMockdatabaseShard(ctrl)
+		shard.EXPECT().ID().Return(shardID.ID()).AnyTimes()
+		if i == 0 {
+			shard.EXPECT().
+				ForEachPendingTombstone(gomock.Any()).
+				DoAndReturn(func(fn func(ts.Series, xtime.Ranges) error) error {
+					return fn(series, xtime.NewRanges(first, second))
+				})
+		} else {
+			shard.EXPECT().ForEachPendingTombstone(gomock.Any()).Return(nil)
+		}
+		ns.shards[shardID.ID()] = shard
+	}
+
+	// Nothing is logged unless there are pending deletes.
+	require.NoError(t, ns.LogPendingDeletes())
+
+	atomic.StoreInt64(&ns.deletesSinceLastTick, 1)
+	gomock.InOrder(
+		commitLog.EXPECT().WriteTombstone(gomock.Any(), series, commitlog.Tombstone{
+			Range:       first,
+			FlushedOnly: true,
+		}).Return(nil),
+		commitLog.EXPECT().WriteTombstone(gomock.Any(), series, commitlog.Tombstone{
+			Range:       second,
+			FlushedOnly: true,
+		}).Return(nil),
+	)
+	require.NoError(t, ns.LogPendingDeletes())
+}
+
+func TestNamespaceDeleteTaggedWritesTombstones(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	idx.EXPECT().BootstrapsDone().Return(uint(1))
+
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	commitLog := commitlog.NewMockCommitLog(ctrl)
+	ns.commitLogWriter = commitLog
+	shard := NewMockdatabaseShard(ctrl)
+	ns.shards[testShardIDs[0].ID()] = shard
+
+	var (
+		start   = time.Now().Truncate(time.Hour).Add(-2 * time.Hour)
+		end     = start.Add(time.Hour)
+		query   = index.Query{Query: xidx.NewTermQuery([]byte("foo"), []byte("bar"))}
+		series  = ts.Series{UniqueIndex: 1, ID: ident.StringID("foo")}
+		results = index.NewQueryResults(ns.ID(), index.QueryResultsOptions{},
+			ns.opts.IndexOptions())
+	)
+	_, err := results.AddDocuments([]doc.Document{{ID: []byte("foo")}})
+	require.NoError(t, err)
+
+	idx.EXPECT().
+		Query(gomock.Any(), query, index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		}).
+		Return(index.QueryResult{Results: results}, nil)
+	shard.EXPECT().
+		DeleteSeriesRange(ident.NewIDMatcher("foo"), gomock.Any(), start, end, gomock.Any()).
+		Return(series, nil)
+	commitLog.EXPECT().
+		WriteTombstone(gomock.Any(), series, commitlog.Tombstone{
+			Range: xtime.Range{Start: start, End: end},
+		}).
+		Return(nil)
+	idx.EXPECT().Tombstone(gomock.Any(), xtime.Range{Start: start, End: end})
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	require.False(t, ns.hasPendingDeletes())
+	deleted, err := ns.DeleteTagged(ctx, query, start, end)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	require.True(t, ns.hasPendingDeletes())
+}
+
 func TestNamespaceFlushSkipFlushed(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
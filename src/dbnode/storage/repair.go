@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -177,9 +178,27 @@ func (r shardRepairer) Repair(
 		}
 	}
 
+	// Gather the local and peer metadata and build Merkle trees from them per
+	// block start so that only the metadata of series in leaves that diverged
+	// between the replicas needs to be compared and repaired individually.
+	// NB: The trees are built from the full metadata of each replica since
+	// peers cannot yet serve the levels of their trees, so the trees narrow
+	// down the series that are compared and streamed but do not reduce the
+	// metadata fetched from peers.
+	var (
+		merkleTrees   = repair.NewReplicaMerkleTrees(origin, r.rpopts.MerkleTreeDepth())
+		localMetadata []block.Metadata
+		peerMetadata  []block.ReplicaMetadata
+		totals        = newRepairTotals()
+	)
 	localIter := block.NewFilteredBlocksMetadataIter(accumLocalMetadata)
-	err = metadata.AddLocalMetadata(localIter)
-	if err != nil {
+	for localIter.Next() {
+		_, localBlock := localIter.Current()
+		merkleTrees.AddLocal(localBlock)
+		totals.add(localBlock)
+		localMetadata = append(localMetadata, localBlock)
+	}
+	if err := localIter.Err(); err != nil {
 		return repair.MetadataComparisonResult{}, err
 	}
 
@@ -194,11 +213,34 @@ func (r shardRepairer) Repair(
 		if err != nil {
 			return repair.MetadataComparisonResult{}, err
 		}
-		if err := metadata.AddPeerMetadata(peerIter); err != nil {
+		for peerIter.Next() {
+			peer, peerBlock := peerIter.Current()
+			merkleTrees.AddPeer(peer, peerBlock)
+			totals.add(peerBlock)
+			peerMetadata = append(peerMetadata, block.ReplicaMetadata{
+				Host:     peer,
+				Metadata: peerBlock,
+			})
+		}
+		if err := peerIter.Err(); err != nil {
 			return repair.MetadataComparisonResult{}, err
 		}
 	}
 
+	numDivergentBlockStarts, err := merkleTrees.Compare()
+	if err != nil {
+		return repair.MetadataComparisonResult{}, err
+	}
+
+	err = metadata.AddLocalMetadata(newDivergentLocalMetadataIter(localMetadata, merkleTrees))
+	if err != nil {
+		return repair.MetadataComparisonResult{}, err
+	}
+	err = metadata.AddPeerMetadata(newDivergentPeerMetadataIter(peerMetadata, merkleTrees))
+	if err != nil {
+		return repair.MetadataComparisonResult{}, err
+	}
+
 	var (
 		// TODO(rartoul): Pool these slices.
 		metadatasToFetchBlocksForPerSession = make([][]block.ReplicaMetadata, len(sessions))
@@ -206,6 +248,13 @@ func (r shardRepairer) Repair(
 		seriesWithChecksumMismatches        = metadataRes.ChecksumDifferences.Series()
 	)
 
+	// The comparer only saw the metadata of divergent series so report the
+	// totals across all of the metadata instead.
+	metadataRes.NumComparedSeries = metadataRes.NumSeries
+	metadataRes.NumSeries = totals.numSeries()
+	metadataRes.NumBlocks = totals.numBlocks
+	metadataRes.NumDivergentBlockStarts = int64(numDivergentBlockStarts)
+
 	originID := origin.ID()
 	for _, e := range seriesWithChecksumMismatches.Iter() {
 		for blockStart, replicaMetadataBlocks := range e.Value().Metadata.Blocks() {
@@ -262,48 +311,85 @@ func (r shardRepairer) Repair(
 		}
 	}
 
-	// TODO(rartoul): Copying the IDs for the purposes of the map key is wasteful. Considering using
-	// SetUnsafe or marking as NoFinalize() and making the map check IsNoFinalize().
-	numMismatchSeries := seriesWithChecksumMismatches.Len()
-	results := result.NewShardResult(numMismatchSeries, rsOpts)
+	// Stream the divergent blocks from peers in batches and load each batch
+	// into the shard before streaming the next so that the MemoryTracker can
+	// throttle the repair, bounding the repaired bytes that are outstanding
+	// until the next cold flush merges them into the filesets.
+	batchBytes := r.rpopts.StreamBatchBytes()
 	for i, metadatasToFetchBlocksFor := range metadatasToFetchBlocksForPerSession {
-		if len(metadatasToFetchBlocksFor) == 0 {
-			continue
-		}
-
 		session := sessions[i].session
-		perSeriesReplicaIter, err := session.FetchBlocksFromPeers(nsMeta, shard.ID(), level, metadatasToFetchBlocksFor, rsOpts)
-		if err != nil {
-			return repair.MetadataComparisonResult{}, err
+		for len(metadatasToFetchBlocksFor) > 0 {
+			var batch []block.ReplicaMetadata
+			batch, metadatasToFetchBlocksFor = nextStreamBatch(metadatasToFetchBlocksFor, batchBytes)
+			err := r.streamBlocksIntoShard(session, nsMeta, shard, level, batch, rsOpts, &metadataRes)
+			if err != nil {
+				return repair.MetadataComparisonResult{}, err
+			}
 		}
+	}
 
-		for perSeriesReplicaIter.Next() {
-			_, id, block := perSeriesReplicaIter.Current()
-			// TODO(rartoul): Handle tags in both branches: https://github.com/m3db/m3/issues/1848
-			if existing, ok := results.BlockAt(id, block.StartTime()); ok {
-				if err := existing.Merge(block); err != nil {
-					return repair.MetadataComparisonResult{}, err
-				}
-			} else {
-				results.AddBlock(id, ident.Tags{}, block)
-			}
+	r.recordFn(nsCtx.ID, shard, metadataRes)
+
+	return metadataRes, nil
+}
+
+// nextStreamBatch splits off the metadata of the next batch of blocks to
+// stream whose sizes add up to at most batchBytes, a batch always contains at
+// least one block so that blocks larger than batchBytes are still repaired.
+func nextStreamBatch(
+	metadatas []block.ReplicaMetadata,
+	batchBytes int64,
+) ([]block.ReplicaMetadata, []block.ReplicaMetadata) {
+	var size int64
+	for i, m := range metadatas {
+		size += m.Size
+		if i > 0 && size > batchBytes {
+			return metadatas[:i], metadatas[i:]
 		}
 	}
+	return metadatas, nil
+}
 
-	if err := r.loadDataIntoShard(shard, results); err != nil {
-		return repair.MetadataComparisonResult{}, err
+func (r shardRepairer) streamBlocksIntoShard(
+	session client.AdminSession,
+	nsMeta namespace.Metadata,
+	shard databaseShard,
+	level topology.ReadConsistencyLevel,
+	metadatas []block.ReplicaMetadata,
+	rsOpts result.Options,
+	metadataRes *repair.MetadataComparisonResult,
+) error {
+	perSeriesReplicaIter, err := session.FetchBlocksFromPeers(nsMeta, shard.ID(), level, metadatas, rsOpts)
+	if err != nil {
+		return err
 	}
 
-	r.recordFn(nsCtx.ID, shard, metadataRes)
+	// TODO(rartoul): Copying the IDs for the purposes of the map key is wasteful. Considering using
+	// SetUnsafe or marking as NoFinalize() and making the map check IsNoFinalize().
+	results := result.NewShardResult(len(metadatas), rsOpts)
+	for perSeriesReplicaIter.Next() {
+		_, id, block := perSeriesReplicaIter.Current()
+		metadataRes.NumStreamedBlocks++
+		// TODO(rartoul): Handle tags in both branches: https://github.com/m3db/m3/issues/1848
+		if existing, ok := results.BlockAt(id, block.StartTime()); ok {
+			if err := existing.Merge(block); err != nil {
+				return err
+			}
+		} else {
+			results.AddBlock(id, ident.Tags{}, block)
+		}
+	}
+	if err := perSeriesReplicaIter.Err(); err != nil {
+		return err
+	}
 
-	return metadataRes, nil
+	metadataRes.NumStreamedBytes += result.EstimateMapBytesSize(results.AllSeries())
+	return r.loadDataIntoShard(shard, results)
 }
 
-// TODO(rartoul): Currently throttling via the MemoryTracker can only occur at the level of an entire
-// block for a given namespace/shard/blockStart. For almost all practical use-cases this is fine, but
-// this could be improved and made more granular by breaking data that is being loaded into the shard
-// into smaller batches (less than one complete block). This would improve the granularity of throttling
-// for clusters where the number of shards is low.
+// loadDataIntoShard loads the repaired blocks into the shard as cold writes,
+// which are merged into the existing filesets by the next cold flush, waiting
+// whenever the MemoryTracker limit of outstanding loaded bytes is hit.
 func (r shardRepairer) loadDataIntoShard(shard databaseShard, data result.ShardResult) error {
 	var (
 		waitingGauge  = r.scope.Gauge("waiting-for-limit")
@@ -380,6 +466,117 @@ func (r shardRepairer) recordDifferences(
 	// Record checksum differences.
 	checksumDiffScope.Counter("series").Inc(diffRes.ChecksumDifferences.NumSeries())
 	checksumDiffScope.Counter("blocks").Inc(diffRes.ChecksumDifferences.NumBlocks())
+
+	// Record divergence detected by the Merkle trees and data streamed from peers.
+	shardScope.Counter("divergent-block-starts").Inc(diffRes.NumDivergentBlockStarts)
+	shardScope.Counter("compared-series").Inc(diffRes.NumComparedSeries)
+	shardScope.Counter("streamed-blocks").Inc(diffRes.NumStreamedBlocks)
+	shardScope.Counter("streamed-bytes").Inc(diffRes.NumStreamedBytes)
+}
+
+// repairTotals counts the distinct series and series blocks seen across the
+// local and peer metadata of a shard.
+type repairTotals struct {
+	blockStartsBySeries map[string]map[xtime.UnixNano]struct{}
+	numBlocks           int64
+}
+
+func newRepairTotals() *repairTotals {
+	return &repairTotals{
+		blockStartsBySeries: make(map[string]map[xtime.UnixNano]struct{}),
+	}
+}
+
+func (t *repairTotals) add(metadata block.Metadata) {
+	blockStarts, ok := t.blockStartsBySeries[string(metadata.ID.Bytes())]
+	if !ok {
+		blockStarts = make(map[xtime.UnixNano]struct{}, 1)
+		t.blockStartsBySeries[metadata.ID.String()] = blockStarts
+	}
+	blockStart := xtime.ToUnixNano(metadata.Start)
+	if _, ok := blockStarts[blockStart]; ok {
+		return
+	}
+	blockStarts[blockStart] = struct{}{}
+	t.numBlocks++
+}
+
+func (t *repairTotals) numSeries() int64 {
+	return int64(len(t.blockStartsBySeries))
+}
+
+// divergentLocalMetadataIter iterates over the local metadata of the series
+// that belong to Merkle tree leaves which diverged from a peer.
+type divergentLocalMetadataIter struct {
+	metadata  []block.Metadata
+	divergent *repair.ReplicaMerkleTrees
+	idx       int
+}
+
+func newDivergentLocalMetadataIter(
+	metadata []block.Metadata,
+	divergent *repair.ReplicaMerkleTrees,
+) block.FilteredBlocksMetadataIter {
+	return &divergentLocalMetadataIter{
+		metadata:  metadata,
+		divergent: divergent,
+		idx:       -1,
+	}
+}
+
+func (it *divergentLocalMetadataIter) Next() bool {
+	for it.idx++; it.idx < len(it.metadata); it.idx++ {
+		if it.divergent.Divergent(it.metadata[it.idx]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (it *divergentLocalMetadataIter) Current() (ident.ID, block.Metadata) {
+	curr := it.metadata[it.idx]
+	return curr.ID, curr
+}
+
+func (it *divergentLocalMetadataIter) Err() error {
+	return nil
+}
+
+// divergentPeerMetadataIter iterates over the peer metadata of the series
+// that belong to Merkle tree leaves which diverged from the local host.
+type divergentPeerMetadataIter struct {
+	metadata  []block.ReplicaMetadata
+	divergent *repair.ReplicaMerkleTrees
+	idx       int
+}
+
+func newDivergentPeerMetadataIter(
+	metadata []block.ReplicaMetadata,
+	divergent *repair.ReplicaMerkleTrees,
+) client.PeerBlockMetadataIter {
+	return &divergentPeerMetadataIter{
+		metadata:  metadata,
+		divergent: divergent,
+		idx:       -1,
+	}
+}
+
+func (it *divergentPeerMetadataIter) Next() bool {
+	for it.idx++; it.idx < len(it.metadata); it.idx++ {
+		if it.divergent.Divergent(it.metadata[it.idx].Metadata) {
+			return true
+		}
+	}
+	return false
+}
+
+func (it *divergentPeerMetadataIter) Current() (topology.Host, block.Metadata) {
+	curr := it.metadata[it.idx]
+	return curr.Host, curr.Metadata
+}
+
+func (it *divergentPeerMetadataIter) Err() error {
+	return nil
 }
 
 type repairFn func() error
@@ -462,6 +659,9 @@ type dbRepairer struct {
 	closedLock sync.Mutex
 	running    int32
 	closed     bool
+
+	statusLock sync.RWMutex
+	statusByNs map[string]*repair.NamespaceRepairStatus
 }
 
 func newDatabaseRepairer(database database, opts Options) (databaseRepairer, error) {
@@ -491,6 +691,7 @@ func newDatabaseRepairer(database database, opts Options) (databaseRepairer, err
 		repairCheckInterval: ropts.RepairCheckInterval(),
 		scope:               scope,
 		status:              scope.Gauge("repair"),
+		statusByNs:          make(map[string]*repair.NamespaceRepairStatus),
 	}
 	r.repairFn = r.Repair
 
@@ -613,6 +814,9 @@ func (r *dbRepairer) Repair() error {
 		})
 
 		// Update metrics with statistics about repair status.
+		r.updateStatus(n.ID(), func(status *repair.NamespaceRepairStatus) {
+			status.NumUnrepairedBlocks = numUnrepairedBlocks
+		})
 		r.scope.Tagged(map[string]string{
 			"namespace": n.ID().String(),
 		}).Gauge("num-unrepaired-blocks").Update(float64(numUnrepairedBlocks))
@@ -654,18 +858,46 @@ func (r *dbRepairer) repairNamespaceBlockstart(n databaseNamespace, blockStart t
 		blockSize   = n.Options().RetentionOptions().BlockSize()
 		repairRange = xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
 		repairTime  = r.nowFn()
+		numShards   = len(n.GetOwnedShards())
 	)
-	if err := r.repairNamespaceWithTimeRange(n, repairRange); err != nil {
+	r.updateStatus(n.ID(), func(status *repair.NamespaceRepairStatus) {
+		*status = repair.NamespaceRepairStatus{
+			Namespace:           status.Namespace,
+			Running:             true,
+			BlockStart:          blockStart,
+			NumShards:           numShards,
+			NumUnrepairedBlocks: status.NumUnrepairedBlocks,
+			LastRepairTime:      repairTime,
+		}
+	})
+
+	shardRepairer := progressShardRepairer{
+		databaseShardRepairer: r.shardRepairer,
+		namespace:             n.ID(),
+		repairer:              r,
+	}
+	if err := r.repairNamespaceWithTimeRange(n, shardRepairer, repairRange); err != nil {
 		r.markRepairAttempt(n.ID(), blockStart, repairTime, repairFailed)
+		r.updateStatus(n.ID(), func(status *repair.NamespaceRepairStatus) {
+			status.Running = false
+			status.LastRepairError = err.Error()
+		})
 		return err
 	}
 
 	r.markRepairAttempt(n.ID(), blockStart, repairTime, repairSuccess)
+	r.updateStatus(n.ID(), func(status *repair.NamespaceRepairStatus) {
+		status.Running = false
+	})
 	return nil
 }
 
-func (r *dbRepairer) repairNamespaceWithTimeRange(n databaseNamespace, tr xtime.Range) error {
-	if err := n.Repair(r.shardRepairer, tr); err != nil {
+func (r *dbRepairer) repairNamespaceWithTimeRange(
+	n databaseNamespace,
+	shardRepairer databaseShardRepairer,
+	tr xtime.Range,
+) error {
+	if err := n.Repair(shardRepairer, tr); err != nil {
 		return fmt.Errorf("namespace %s failed to repair time range %v: %v", n.ID().String(), tr, err)
 	}
 
@@ -683,6 +915,72 @@ func (r *dbRepairer) markRepairAttempt(
 	r.repairStatesByNs.setRepairState(namespace, blockStart, repairState)
 }
 
+// Status returns the repair status of each namespace that has been repaired,
+// sorted by namespace.
+func (r *dbRepairer) Status() []repair.NamespaceRepairStatus {
+	r.statusLock.RLock()
+	statuses := make([]repair.NamespaceRepairStatus, 0, len(r.statusByNs))
+	for _, status := range r.statusByNs {
+		statuses = append(statuses, *status)
+	}
+	r.statusLock.RUnlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Namespace < statuses[j].Namespace
+	})
+	return statuses
+}
+
+func (r *dbRepairer) updateStatus(
+	namespace ident.ID,
+	fn func(status *repair.NamespaceRepairStatus),
+) {
+	r.statusLock.Lock()
+	defer r.statusLock.Unlock()
+
+	status, ok := r.statusByNs[namespace.String()]
+	if !ok {
+		status = &repair.NamespaceRepairStatus{Namespace: namespace.String()}
+		r.statusByNs[namespace.String()] = status
+	}
+	fn(status)
+}
+
+// progressShardRepairer records the result of each shard repair in the
+// repair status of the namespace being repaired.
+type progressShardRepairer struct {
+	databaseShardRepairer
+
+	namespace ident.ID
+	repairer  *dbRepairer
+}
+
+func (r progressShardRepairer) Repair(
+	ctx context.Context,
+	nsCtx namespace.Context,
+	nsMeta namespace.Metadata,
+	tr xtime.Range,
+	shard databaseShard,
+) (repair.MetadataComparisonResult, error) {
+	res, err := r.databaseShardRepairer.Repair(ctx, nsCtx, nsMeta, tr, shard)
+	if err != nil {
+		return res, err
+	}
+
+	r.repairer.updateStatus(r.namespace, func(status *repair.NamespaceRepairStatus) {
+		status.NumShardsRepaired++
+		status.NumSeries += res.NumSeries
+		status.NumBlocks += res.NumBlocks
+		status.NumComparedSeries += res.NumComparedSeries
+		status.NumStreamedBlocks += res.NumStreamedBlocks
+		status.NumStreamedBytes += res.NumStreamedBytes
+		if res.ChecksumDifferences != nil {
+			status.NumChecksumDiffSeries += res.ChecksumDifferences.NumSeries()
+		}
+	})
+	return res, nil
+}
+
 var noOpRepairer databaseRepairer = repairerNoOp{}
 
 type repairerNoOp struct{}
@@ -694,6 +992,8 @@ func (r repairerNoOp) Stop()         {}
 func (r repairerNoOp) Repair() error { return nil }
 func (r repairerNoOp) Report()       {}
 
+func (r repairerNoOp) Status() []repair.NamespaceRepairStatus { return nil }
+
 func (r shardRepairer) shadowCompare(
 	start time.Time,
 	end time.Time,
@@ -0,0 +1,294 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package repair
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/spaolacci/murmur3"
+)
+
+const (
+	// MinMerkleTreeDepth is the minimum depth of a Merkle tree.
+	MinMerkleTreeDepth = 1
+	// MaxMerkleTreeDepth is the maximum depth of a Merkle tree.
+	MaxMerkleTreeDepth = 20
+)
+
+var (
+	errMerkleTreeDepthMismatch = errors.New("cannot compare merkle trees of different depths")
+)
+
+// MerkleTree is a fixed depth binary hash tree that summarizes the block
+// metadata of the series of a single shard and block start. Series are assigned
+// to leaves by hashing their IDs so that replicas holding the same series always
+// place them in the same leaf, which allows two trees to be compared top down
+// and narrows any divergence to the leaves (and as a result series) that differ.
+//
+// Leaves are the sum of the digests of the series assigned to them so that the
+// tree is independent of the order in which series are added.
+type MerkleTree struct {
+	depth   uint
+	nodes   []uint32
+	built   bool
+	scratch [12]byte
+}
+
+// NewMerkleTree returns a new Merkle tree with the given depth, the depth
+// must be between MinMerkleTreeDepth and MaxMerkleTreeDepth.
+func NewMerkleTree(depth int) *MerkleTree {
+	depth = clampMerkleTreeDepth(depth)
+	return &MerkleTree{
+		depth: uint(depth),
+		// Nodes are laid out as a heap with the root at index 1 and the
+		// leaves at indexes [1<<depth, 2<<depth).
+		nodes: make([]uint32, 2<<uint(depth)),
+	}
+}
+
+// Depth returns the depth of the tree.
+func (t *MerkleTree) Depth() int {
+	return int(t.depth)
+}
+
+// NumLeaves returns the number of leaves of the tree.
+func (t *MerkleTree) NumLeaves() int {
+	return 1 << t.depth
+}
+
+// Leaf returns the leaf a series is assigned to.
+func (t *MerkleTree) Leaf(id ident.ID) int {
+	return merkleTreeLeaf(id, t.depth)
+}
+
+// Add adds the block metadata of a series to the tree.
+func (t *MerkleTree) Add(metadata block.Metadata) {
+	var checksum uint32
+	if metadata.Checksum != nil {
+		checksum = *metadata.Checksum
+	}
+	binary.LittleEndian.PutUint32(t.scratch[:4], checksum)
+	binary.LittleEndian.PutUint64(t.scratch[4:], uint64(metadata.Size))
+
+	d := digest.NewDigest().
+		Update(metadata.ID.Bytes()).
+		Update(t.scratch[:]).
+		Sum32()
+
+	t.nodes[t.NumLeaves()+t.Leaf(metadata.ID)] += d
+	t.built = false
+}
+
+// Root returns the root digest of the tree.
+func (t *MerkleTree) Root() uint32 {
+	t.build()
+	return t.nodes[1]
+}
+
+// DivergentLeaves returns the leaves whose digests differ between the
+// two trees.
+func (t *MerkleTree) DivergentLeaves(other *MerkleTree) ([]int, error) {
+	if t.depth != other.depth {
+		return nil, errMerkleTreeDepthMismatch
+	}
+
+	t.build()
+	other.build()
+
+	var (
+		leaves    []int
+		numLeaves = t.NumLeaves()
+		stack     = []int{1}
+	)
+	for len(stack) > 0 {
+		idx := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if t.nodes[idx] == other.nodes[idx] {
+			continue
+		}
+		if idx >= numLeaves {
+			leaves = append(leaves, idx-numLeaves)
+			continue
+		}
+		// Push the right child first so that leaves are returned in order.
+		stack = append(stack, 2*idx+1, 2*idx)
+	}
+	return leaves, nil
+}
+
+// Reset resets the tree so that it can be reused.
+func (t *MerkleTree) Reset() {
+	for i := range t.nodes {
+		t.nodes[i] = 0
+	}
+	t.built = false
+}
+
+func (t *MerkleTree) build() {
+	if t.built {
+		return
+	}
+	for i := t.NumLeaves() - 1; i >= 1; i-- {
+		binary.LittleEndian.PutUint32(t.scratch[:4], t.nodes[2*i])
+		binary.LittleEndian.PutUint32(t.scratch[4:8], t.nodes[2*i+1])
+		t.nodes[i] = digest.Checksum(t.scratch[:8])
+	}
+	t.built = true
+}
+
+// ReplicaMerkleTrees builds a Merkle tree per block start from the block
+// metadata of the series of a shard on the local host and each of its peers,
+// and uses them to determine which series have diverged between the replicas.
+type ReplicaMerkleTrees struct {
+	origin    topology.Host
+	depth     int
+	local     map[xtime.UnixNano]*MerkleTree
+	peers     map[string]map[xtime.UnixNano]*MerkleTree
+	divergent map[xtime.UnixNano]map[int]struct{}
+}
+
+// NewReplicaMerkleTrees returns a new set of replica Merkle trees.
+func NewReplicaMerkleTrees(origin topology.Host, depth int) *ReplicaMerkleTrees {
+	return &ReplicaMerkleTrees{
+		origin:    origin,
+		depth:     clampMerkleTreeDepth(depth),
+		local:     make(map[xtime.UnixNano]*MerkleTree),
+		peers:     make(map[string]map[xtime.UnixNano]*MerkleTree),
+		divergent: make(map[xtime.UnixNano]map[int]struct{}),
+	}
+}
+
+// AddLocal adds block metadata from the local host.
+func (r *ReplicaMerkleTrees) AddLocal(metadata block.Metadata) {
+	r.add(r.local, metadata)
+}
+
+// AddPeer adds block metadata from a peer.
+func (r *ReplicaMerkleTrees) AddPeer(peer topology.Host, metadata block.Metadata) {
+	if peer.ID() == r.origin.ID() {
+		return
+	}
+	trees, ok := r.peers[peer.ID()]
+	if !ok {
+		trees = make(map[xtime.UnixNano]*MerkleTree)
+		r.peers[peer.ID()] = trees
+	}
+	r.add(trees, metadata)
+}
+
+func (r *ReplicaMerkleTrees) add(
+	trees map[xtime.UnixNano]*MerkleTree,
+	metadata block.Metadata,
+) {
+	if metadata.Checksum == nil {
+		// Metadata without a checksum represents unmerged or pending data that
+		// the comparer skips, so exclude it from the trees too.
+		return
+	}
+	blockStart := xtime.ToUnixNano(metadata.Start)
+	tree, ok := trees[blockStart]
+	if !ok {
+		tree = NewMerkleTree(r.depth)
+		trees[blockStart] = tree
+	}
+	tree.Add(metadata)
+}
+
+// Compare compares the local trees with the trees of every peer and records
+// the leaves that diverged for each block start, it returns the number of
+// block starts that diverged from at least one peer.
+func (r *ReplicaMerkleTrees) Compare() (int, error) {
+	empty := NewMerkleTree(r.depth)
+	for _, peerTrees := range r.peers {
+		for blockStart, peerTree := range peerTrees {
+			if err := r.compare(blockStart, r.local[blockStart], peerTree, empty); err != nil {
+				return 0, err
+			}
+		}
+		for blockStart, localTree := range r.local {
+			if _, ok := peerTrees[blockStart]; ok {
+				continue
+			}
+			if err := r.compare(blockStart, localTree, empty, empty); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(r.divergent), nil
+}
+
+func (r *ReplicaMerkleTrees) compare(
+	blockStart xtime.UnixNano,
+	localTree, peerTree, empty *MerkleTree,
+) error {
+	if localTree == nil {
+		localTree = empty
+	}
+	if localTree.Root() == peerTree.Root() {
+		return nil
+	}
+	leaves, err := localTree.DivergentLeaves(peerTree)
+	if err != nil {
+		return err
+	}
+	divergent, ok := r.divergent[blockStart]
+	if !ok {
+		divergent = make(map[int]struct{}, len(leaves))
+		r.divergent[blockStart] = divergent
+	}
+	for _, leaf := range leaves {
+		divergent[leaf] = struct{}{}
+	}
+	return nil
+}
+
+// Divergent returns whether the block metadata belongs to a leaf that
+// diverged between the local host and at least one of its peers, only
+// valid after calling Compare.
+func (r *ReplicaMerkleTrees) Divergent(metadata block.Metadata) bool {
+	divergent, ok := r.divergent[xtime.ToUnixNano(metadata.Start)]
+	if !ok {
+		return false
+	}
+	_, ok = divergent[merkleTreeLeaf(metadata.ID, uint(r.depth))]
+	return ok
+}
+
+func merkleTreeLeaf(id ident.ID, depth uint) int {
+	return int(murmur3.Sum32(id.Bytes()) & (1<<depth - 1))
+}
+
+func clampMerkleTreeDepth(depth int) int {
+	if depth < MinMerkleTreeDepth {
+		return MinMerkleTreeDepth
+	}
+	if depth > MaxMerkleTreeDepth {
+		return MaxMerkleTreeDepth
+	}
+	return depth
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package repair
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testMerkleMetadata(id string, start time.Time, size int64, checksum uint32) block.Metadata {
+	return block.NewMetadata(ident.StringID(id), ident.Tags{}, start, size, &checksum, time.Time{})
+}
+
+func TestMerkleTreeOrderIndependent(t *testing.T) {
+	var (
+		start = time.Now().Truncate(time.Hour)
+		a     = NewMerkleTree(8)
+		b     = NewMerkleTree(8)
+	)
+	for i := 0; i < 100; i++ {
+		a.Add(testMerkleMetadata(fmt.Sprintf("series-%d", i), start, int64(i), uint32(i)))
+	}
+	for i := 99; i >= 0; i-- {
+		b.Add(testMerkleMetadata(fmt.Sprintf("series-%d", i), start, int64(i), uint32(i)))
+	}
+
+	require.Equal(t, a.Root(), b.Root())
+	leaves, err := a.DivergentLeaves(b)
+	require.NoError(t, err)
+	require.Empty(t, leaves)
+}
+
+func TestMerkleTreeDivergentLeaves(t *testing.T) {
+	var (
+		start = time.Now().Truncate(time.Hour)
+		a     = NewMerkleTree(8)
+		b     = NewMerkleTree(8)
+	)
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("series-%d", i)
+		a.Add(testMerkleMetadata(id, start, 1, uint32(i)))
+		switch i {
+		case 10:
+			// Checksum mismatch.
+			b.Add(testMerkleMetadata(id, start, 1, uint32(i+1)))
+		case 20:
+			// Size mismatch.
+			b.Add(testMerkleMetadata(id, start, 2, uint32(i)))
+		case 30:
+			// Missing series.
+		default:
+			b.Add(testMerkleMetadata(id, start, 1, uint32(i)))
+		}
+	}
+
+	require.NotEqual(t, a.Root(), b.Root())
+	leaves, err := a.DivergentLeaves(b)
+	require.NoError(t, err)
+
+	expected := make(map[int]struct{})
+	for _, i := range []int{10, 20, 30} {
+		expected[a.Leaf(ident.StringID(fmt.Sprintf("series-%d", i)))] = struct{}{}
+	}
+	require.Equal(t, len(expected), len(leaves))
+	for _, leaf := range leaves {
+		_, ok := expected[leaf]
+		require.True(t, ok)
+	}
+}
+
+func TestMerkleTreeDepthMismatch(t *testing.T) {
+	_, err := NewMerkleTree(4).DivergentLeaves(NewMerkleTree(5))
+	require.Equal(t, errMerkleTreeDepthMismatch, err)
+}
+
+func TestMerkleTreeReset(t *testing.T) {
+	var (
+		start = time.Now().Truncate(time.Hour)
+		tree  = NewMerkleTree(4)
+		empty = tree.Root()
+	)
+	tree.Add(testMerkleMetadata("foo", start, 1, 2))
+	require.NotEqual(t, empty, tree.Root())
+
+	tree.Reset()
+	require.Equal(t, empty, tree.Root())
+}
+
+func TestReplicaMerkleTreesDivergent(t *testing.T) {
+	var (
+		origin = topology.NewHost("0", "addr0")
+		peer1  = topology.NewHost("1", "addr1")
+		peer2  = topology.NewHost("2", "addr2")
+		start  = time.Now().Truncate(time.Hour)
+		next   = start.Add(time.Hour)
+		trees  = NewReplicaMerkleTrees(origin, 10)
+	)
+
+	// Identical across all replicas.
+	for _, host := range []topology.Host{origin, peer1, peer2} {
+		metadata := testMerkleMetadata("foo", start, 1, 1)
+		if host == origin {
+			trees.AddLocal(metadata)
+		} else {
+			trees.AddPeer(host, metadata)
+		}
+	}
+
+	// Checksum mismatch with the second peer.
+	trees.AddLocal(testMerkleMetadata("bar", start, 1, 1))
+	trees.AddPeer(peer1, testMerkleMetadata("bar", start, 1, 1))
+	trees.AddPeer(peer2, testMerkleMetadata("bar", start, 1, 2))
+
+	// Only exists on a peer in the next block.
+	trees.AddPeer(peer1, testMerkleMetadata("baz", next, 1, 1))
+
+	// Metadata from the origin through the peers is ignored.
+	trees.AddPeer(origin, testMerkleMetadata("qux", start, 1, 1))
+
+	numDivergent, err := trees.Compare()
+	require.NoError(t, err)
+	require.Equal(t, 2, numDivergent)
+
+	tree := NewMerkleTree(10)
+	fooLeaf := tree.Leaf(ident.StringID("foo"))
+	barLeaf := tree.Leaf(ident.StringID("bar"))
+	require.Equal(t, fooLeaf == barLeaf, trees.Divergent(testMerkleMetadata("foo", start, 1, 1)))
+	require.True(t, trees.Divergent(testMerkleMetadata("bar", start, 1, 1)))
+	require.True(t, trees.Divergent(testMerkleMetadata("baz", next, 1, 1)))
+	require.False(t, trees.Divergent(testMerkleMetadata("foo", next.Add(time.Hour), 1, 1)))
+}
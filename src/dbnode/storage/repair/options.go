@@ -39,6 +39,8 @@ const (
 	defaultRepairShardConcurrency           = 1
 	defaultDebugShadowComparisonsEnabled    = false
 	defaultDebugShadowComparisonsPercentage = 1.0
+	defaultMerkleTreeDepth                  = 12
+	defaultStreamBatchBytes                 = 64 << 20
 )
 
 var (
@@ -48,6 +50,9 @@ var (
 	errNoReplicaMetadataSlicePool              = errors.New("no replica metadata pool in repair options")
 	errNoResultOptions                         = errors.New("no result options in repair options")
 	errInvalidDebugShadowComparisonsPercentage = errors.New("debug shadow comparisons percentage must be between 0 and 1")
+	errInvalidStreamBatchBytes                 = errors.New("stream batch bytes must be positive")
+	errInvalidMerkleTreeDepth                  = fmt.Errorf(
+		"merkle tree depth must be between %d and %d", MinMerkleTreeDepth, MaxMerkleTreeDepth)
 )
 
 type options struct {
@@ -60,6 +65,8 @@ type options struct {
 	resultOptions                    result.Options
 	debugShadowComparisonsEnabled    bool
 	debugShadowComparisonsPercentage float64
+	merkleTreeDepth                  int
+	streamBatchBytes                 int64
 }
 
 // NewOptions creates new bootstrap options
@@ -73,6 +80,8 @@ func NewOptions() Options {
 		resultOptions:                    result.NewOptions(),
 		debugShadowComparisonsEnabled:    defaultDebugShadowComparisonsEnabled,
 		debugShadowComparisonsPercentage: defaultDebugShadowComparisonsPercentage,
+		merkleTreeDepth:                  defaultMerkleTreeDepth,
+		streamBatchBytes:                 defaultStreamBatchBytes,
 	}
 }
 
@@ -166,6 +175,26 @@ func (o *options) DebugShadowComparisonsPercentage() float64 {
 	return o.debugShadowComparisonsPercentage
 }
 
+func (o *options) SetMerkleTreeDepth(value int) Options {
+	opts := *o
+	opts.merkleTreeDepth = value
+	return &opts
+}
+
+func (o *options) MerkleTreeDepth() int {
+	return o.merkleTreeDepth
+}
+
+func (o *options) SetStreamBatchBytes(value int64) Options {
+	opts := *o
+	opts.streamBatchBytes = value
+	return &opts
+}
+
+func (o *options) StreamBatchBytes() int64 {
+	return o.streamBatchBytes
+}
+
 func (o *options) Validate() error {
 	if len(o.adminClients) == 0 {
 		return errNoAdminClient
@@ -202,5 +231,12 @@ func (o *options) Validate() error {
 		o.debugShadowComparisonsPercentage < 0 {
 		return errInvalidDebugShadowComparisonsPercentage
 	}
+	if o.merkleTreeDepth < MinMerkleTreeDepth ||
+		o.merkleTreeDepth > MaxMerkleTreeDepth {
+		return errInvalidMerkleTreeDepth
+	}
+	if o.streamBatchBytes <= 0 {
+		return errInvalidStreamBatchBytes
+	}
 	return nil
 }
@@ -140,6 +140,66 @@ type MetadataComparisonResult struct {
 
 	// ChecksumDifferences returns the checksum differences
 	ChecksumDifferences ReplicaSeriesMetadata
+
+	// NumDivergentBlockStarts returns the number of block starts whose
+	// Merkle trees diverged from at least one peer
+	NumDivergentBlockStarts int64
+
+	// NumComparedSeries returns the number of series whose metadata was
+	// compared with peers after filtering out series in matching leaves
+	NumComparedSeries int64
+
+	// NumStreamedBlocks returns the number of blocks streamed from peers
+	NumStreamedBlocks int64
+
+	// NumStreamedBytes returns the estimated size of the data streamed from peers
+	NumStreamedBytes int64
+}
+
+// NamespaceRepairStatus describes the repair status and progress of a namespace.
+type NamespaceRepairStatus struct {
+	// Namespace is the namespace being repaired.
+	Namespace string `json:"namespace"`
+
+	// Running is whether a block of the namespace is being repaired.
+	Running bool `json:"running"`
+
+	// BlockStart is the start of the block being (or last) repaired.
+	BlockStart time.Time `json:"blockStart"`
+
+	// NumShards is the number of shards to repair for the block.
+	NumShards int `json:"numShards"`
+
+	// NumShardsRepaired is the number of shards repaired for the block.
+	NumShardsRepaired int `json:"numShardsRepaired"`
+
+	// NumUnrepairedBlocks is the number of blocks that are yet to be
+	// successfully repaired.
+	NumUnrepairedBlocks int `json:"numUnrepairedBlocks"`
+
+	// LastRepairTime is the time the last block repair started.
+	LastRepairTime time.Time `json:"lastRepairTime"`
+
+	// LastRepairError is the error of the last block repair, if any.
+	LastRepairError string `json:"lastRepairError,omitempty"`
+
+	// NumSeries is the total number of series of the block.
+	NumSeries int64 `json:"numSeries"`
+
+	// NumBlocks is the total number of series blocks of the block.
+	NumBlocks int64 `json:"numBlocks"`
+
+	// NumComparedSeries is the number of series compared with peers.
+	NumComparedSeries int64 `json:"numComparedSeries"`
+
+	// NumChecksumDiffSeries is the number of series with checksum differences.
+	NumChecksumDiffSeries int64 `json:"numChecksumDiffSeries"`
+
+	// NumStreamedBlocks is the number of blocks streamed from peers.
+	NumStreamedBlocks int64 `json:"numStreamedBlocks"`
+
+	// NumStreamedBytes is the estimated size of the data streamed from peers.
+	NumStreamedBytes int64 `json:"numStreamedBytes"`
 }
 
 // Options are the repair options
@@ -200,6 +260,22 @@ type Options interface {
 	// DebugShadowComparisonsPercentage returns the debug shadow comparisons percentage.
 	DebugShadowComparisonsPercentage() float64
 
+	// SetMerkleTreeDepth sets the depth of the Merkle trees used to compare
+	// the block metadata of a shard with its peers.
+	SetMerkleTreeDepth(value int) Options
+
+	// MerkleTreeDepth returns the depth of the Merkle trees used to compare
+	// the block metadata of a shard with its peers.
+	MerkleTreeDepth() int
+
+	// SetStreamBatchBytes sets the max estimated size of the blocks streamed
+	// from peers and loaded into a shard at a time while repairing.
+	SetStreamBatchBytes(value int64) Options
+
+	// StreamBatchBytes returns the max estimated size of the blocks streamed
+	// from peers and loaded into a shard at a time while repairing.
+	StreamBatchBytes() int64
+
 	// Validate checks if the options are valid.
 	Validate() error
 }
@@ -21,6 +21,7 @@
 package storage
 
 import (
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -244,6 +245,7 @@ func testDatabaseShardRepairerRepair(t *testing.T, withLimit bool) {
 		peerBlocksIter := client.NewMockPeerBlocksIter(ctrl)
 		dbBlock1 := block.NewMockDatabaseBlock(ctrl)
 		dbBlock1.EXPECT().StartTime().Return(inputBlocks[2].Metadata.Start).AnyTimes()
+		dbBlock1.EXPECT().Len().Return(int(sizes[2])).AnyTimes()
 		dbBlock2 := block.NewMockDatabaseBlock(ctrl)
 		dbBlock2.EXPECT().StartTime().Return(inputBlocks[2].Metadata.Start).AnyTimes()
 		// Ensure merging logic works.
@@ -254,6 +256,7 @@ func testDatabaseShardRepairerRepair(t *testing.T, withLimit bool) {
 			peerBlocksIter.EXPECT().Next().Return(true),
 			peerBlocksIter.EXPECT().Current().Return(inputBlocks[2].Host, inputBlocks[2].Metadata.ID, dbBlock2),
 			peerBlocksIter.EXPECT().Next().Return(false),
+			peerBlocksIter.EXPECT().Err().Return(nil),
 		)
 		nsMeta, err := namespace.NewMetadata(namespaceID, namespace.NewOptions())
 		require.NoError(t, err)
@@ -286,6 +289,9 @@ func testDatabaseShardRepairerRepair(t *testing.T, withLimit bool) {
 		require.Equal(t, resShard, shard)
 		require.Equal(t, int64(2), resDiff.NumSeries)
 		require.Equal(t, int64(3), resDiff.NumBlocks)
+		require.True(t, resDiff.NumDivergentBlockStarts > 0)
+		require.Equal(t, int64(2), resDiff.NumStreamedBlocks)
+		require.True(t, resDiff.NumStreamedBytes >= sizes[2])
 
 		checksumDiffSeries := resDiff.ChecksumDifferences.Series()
 		require.Equal(t, 1, checksumDiffSeries.Len())
@@ -418,7 +424,8 @@ func TestDatabaseShardRepairerRepairMultiSession(t *testing.T) {
 		FetchBlocksMetadataV2(any, start, end, any, nonNilPageToken, fetchOpts).
 		Return(expectedResults, nil, nil)
 	shard.EXPECT().ID().Return(shardID).AnyTimes()
-	shard.EXPECT().LoadBlocks(gomock.Any()).Return(nil)
+	// The blocks streamed from the peers of each session are loaded separately.
+	shard.EXPECT().LoadBlocks(gomock.Any()).Return(nil).Times(len(mocks))
 
 	inputBlocks := []block.ReplicaMetadata{
 		{
@@ -479,6 +486,7 @@ func TestDatabaseShardRepairerRepairMultiSession(t *testing.T) {
 		peerBlocksIter := client.NewMockPeerBlocksIter(ctrl)
 		dbBlock1 := block.NewMockDatabaseBlock(ctrl)
 		dbBlock1.EXPECT().StartTime().Return(inputBlocksForSession[2].Metadata.Start).AnyTimes()
+		dbBlock1.EXPECT().Len().Return(int(sizes[2])).AnyTimes()
 		dbBlock2 := block.NewMockDatabaseBlock(ctrl)
 		dbBlock2.EXPECT().StartTime().Return(inputBlocksForSession[2].Metadata.Start).AnyTimes()
 		// Ensure merging logic works. Nede AnyTimes() because the Merge() will only be called on dbBlock1
@@ -491,6 +499,7 @@ func TestDatabaseShardRepairerRepairMultiSession(t *testing.T) {
 			peerBlocksIter.EXPECT().Next().Return(true),
 			peerBlocksIter.EXPECT().Current().Return(inputBlocksForSession[2].Host, inputBlocks[2].Metadata.ID, dbBlock2),
 			peerBlocksIter.EXPECT().Next().Return(false),
+			peerBlocksIter.EXPECT().Err().Return(nil),
 		)
 		require.NoError(t, err)
 		session.EXPECT().
@@ -563,6 +572,29 @@ type expectedRepair struct {
 	repairRange xtime.Range
 }
 
+func TestNextStreamBatch(t *testing.T) {
+	metadatas := make([]block.ReplicaMetadata, 0, 4)
+	for _, size := range []int64{3, 4, 10, 1} {
+		metadatas = append(metadatas, block.ReplicaMetadata{
+			Metadata: block.Metadata{Size: size},
+		})
+	}
+
+	var batches [][]block.ReplicaMetadata
+	for remaining := metadatas; len(remaining) > 0; {
+		var batch []block.ReplicaMetadata
+		batch, remaining = nextStreamBatch(remaining, 8)
+		batches = append(batches, batch)
+	}
+
+	// Blocks larger than the batch size are streamed on their own.
+	require.Equal(t, [][]block.ReplicaMetadata{
+		metadatas[:2],
+		metadatas[2:3],
+		metadatas[3:],
+	}, batches)
+}
+
 func TestDatabaseRepairPrioritizationLogic(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -675,6 +707,9 @@ func TestDatabaseRepairPrioritizationLogic(t *testing.T) {
 			ns1.EXPECT().ID().Return(ident.StringID("ns1")).AnyTimes()
 			ns2.EXPECT().ID().Return(ident.StringID("ns2")).AnyTimes()
 
+			ns1.EXPECT().GetOwnedShards().Return(nil).AnyTimes()
+			ns2.EXPECT().GetOwnedShards().Return(nil).AnyTimes()
+
 			ns1.EXPECT().Repair(gomock.Any(), tc.expectedNS1Repair.repairRange)
 			ns2.EXPECT().Repair(gomock.Any(), tc.expectedNS2Repair.repairRange)
 
@@ -683,3 +718,91 @@ func TestDatabaseRepairPrioritizationLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseRepairerStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		rOpts = retention.NewOptions().
+			SetRetentionPeriod(retention.NewOptions().BlockSize() * 2)
+		nsOpts = namespace.NewOptions().
+			SetRetentionOptions(rOpts)
+		blockSize = rOpts.BlockSize()
+		now       = time.Now().Truncate(blockSize).Add(rOpts.BufferPast()).Add(time.Second)
+
+		opts         = DefaultTestOptions().SetRepairOptions(testRepairOptions(ctrl))
+		mockDatabase = NewMockdatabase(ctrl)
+	)
+
+	databaseRepairer, err := newDatabaseRepairer(mockDatabase, opts)
+	require.NoError(t, err)
+	repairer := databaseRepairer.(*dbRepairer)
+	repairer.nowFn = func() time.Time {
+		return now
+	}
+	require.Empty(t, repairer.Status())
+
+	shardRepairer := NewMockdatabaseShardRepairer(ctrl)
+	shardRepairer.EXPECT().
+		Repair(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(repair.MetadataComparisonResult{
+			NumSeries:           3,
+			NumBlocks:           4,
+			NumComparedSeries:   2,
+			NumStreamedBlocks:   1,
+			NumStreamedBytes:    10,
+			ChecksumDifferences: repair.NewReplicaSeriesMetadata(),
+		}, nil).
+		Times(2)
+	repairer.shardRepairer = shardRepairer
+
+	var (
+		ns1        = NewMockdatabaseNamespace(ctrl)
+		ns2        = NewMockdatabaseNamespace(ctrl)
+		namespaces = []databaseNamespace{ns2, ns1}
+		shards     = []databaseShard{NewMockdatabaseShard(ctrl), NewMockdatabaseShard(ctrl)}
+		repairErr  = errors.New("repair failed")
+	)
+	for _, ns := range []*MockdatabaseNamespace{ns1, ns2} {
+		ns.EXPECT().Options().Return(nsOpts).AnyTimes()
+		ns.EXPECT().GetOwnedShards().Return(shards).AnyTimes()
+	}
+	ns1.EXPECT().ID().Return(ident.StringID("ns1")).AnyTimes()
+	ns2.EXPECT().ID().Return(ident.StringID("ns2")).AnyTimes()
+
+	ns1.EXPECT().Repair(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(r databaseShardRepairer, tr xtime.Range) error {
+			for _, shard := range shards {
+				_, err := r.Repair(nil, namespace.Context{}, nil, tr, shard)
+				require.NoError(t, err)
+			}
+			return nil
+		})
+	ns2.EXPECT().Repair(gomock.Any(), gomock.Any()).Return(repairErr)
+
+	mockDatabase.EXPECT().IsBootstrapped().Return(true)
+	mockDatabase.EXPECT().GetOwnedNamespaces().Return(namespaces, nil)
+	require.Error(t, repairer.Repair())
+
+	status := repairer.Status()
+	require.Equal(t, 2, len(status))
+
+	require.Equal(t, "ns1", status[0].Namespace)
+	require.False(t, status[0].Running)
+	require.Equal(t, 2, status[0].NumShards)
+	require.Equal(t, 2, status[0].NumShardsRepaired)
+	require.Equal(t, 2, status[0].NumUnrepairedBlocks)
+	require.Equal(t, now, status[0].LastRepairTime)
+	require.Equal(t, "", status[0].LastRepairError)
+	require.Equal(t, int64(6), status[0].NumSeries)
+	require.Equal(t, int64(8), status[0].NumBlocks)
+	require.Equal(t, int64(4), status[0].NumComparedSeries)
+	require.Equal(t, int64(2), status[0].NumStreamedBlocks)
+	require.Equal(t, int64(20), status[0].NumStreamedBytes)
+
+	require.Equal(t, "ns2", status[1].Namespace)
+	require.False(t, status[1].Running)
+	require.Equal(t, 0, status[1].NumShardsRepaired)
+	require.Contains(t, status[1].LastRepairError, repairErr.Error())
+}
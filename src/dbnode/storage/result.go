@@ -32,6 +32,7 @@ type tickResult struct {
 	mergedOutOfOrderBlocks int
 	errors                 int
 	evictedBuckets         int
+	pendingDeleteBlocks    int
 }
 
 func (r tickResult) merge(other tickResult) tickResult {
@@ -47,5 +48,6 @@ func (r tickResult) merge(other tickResult) tickResult {
 		mergedOutOfOrderBlocks: r.mergedOutOfOrderBlocks + other.mergedOutOfOrderBlocks,
 		errors:                 r.errors + other.errors,
 		evictedBuckets:         r.evictedBuckets + other.evictedBuckets,
+		pendingDeleteBlocks:    r.pendingDeleteBlocks + other.pendingDeleteBlocks,
 	}
 }
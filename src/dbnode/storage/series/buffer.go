@@ -130,6 +130,8 @@ type databaseBuffer interface {
 
 	ColdFlushBlockStarts(blockStates map[xtime.UnixNano]BlockState) OptimizedTimes
 
+	DeleteRange(start, end time.Time, nsCtx namespace.Context) error
+
 	Stats() bufferStats
 
 	Tick(versions ShardBlockStateSnapshot, nsCtx namespace.Context) bufferTickResult
@@ -389,6 +391,31 @@ func (b *dbBuffer) ColdFlushBlockStarts(blockStates map[xtime.UnixNano]BlockStat
 	return times
 }
 
+func (b *dbBuffer) DeleteRange(start, end time.Time, nsCtx namespace.Context) error {
+	var (
+		blockSize = b.opts.RetentionOptions().BlockSize()
+		deleted   = xtime.Range{Start: start, End: end}
+		excluded  = xtime.NewRanges(deleted)
+	)
+	for _, bucketVersions := range b.bucketsMap {
+		blockRange := xtime.Range{
+			Start: bucketVersions.start,
+			End:   bucketVersions.start.Add(blockSize),
+		}
+		if !blockRange.Overlaps(deleted) {
+			continue
+		}
+
+		for _, bucket := range bucketVersions.buckets {
+			if err := bucket.mergeExcluding(excluded, nsCtx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (b *dbBuffer) Stats() bufferStats {
 	return bufferStats{
 		wiredBlocks: len(b.bucketsMap),
@@ -578,7 +605,7 @@ func (b *dbBuffer) WarmFlush(
 		// there be buckets for previous versions. In this case, we need to try
 		// to flush them again, so we merge them together to one stream and
 		// persist it.
-		encoder, _, err := mergeStreamsToEncoder(blockStart, streams, b.opts, nsCtx)
+		encoder, _, err := mergeStreamsToEncoder(blockStart, streams, xtime.Ranges{}, b.opts, nsCtx)
 		if err != nil {
 			return FlushOutcomeErr, err
 		}
@@ -1315,6 +1342,20 @@ func (b *BufferBucket) merge(nsCtx namespace.Context) (int, error) {
 		return 0, nil
 	}
 
+	return b.mergeWithExcluded(xtime.Ranges{}, nsCtx)
+}
+
+// mergeExcluding merges all streams in the bucket into a single encoder,
+// dropping any datapoints that fall within the excluded ranges.
+func (b *BufferBucket) mergeExcluding(excluded xtime.Ranges, nsCtx namespace.Context) error {
+	_, err := b.mergeWithExcluded(excluded, nsCtx)
+	return err
+}
+
+func (b *BufferBucket) mergeWithExcluded(
+	excluded xtime.Ranges,
+	nsCtx namespace.Context,
+) (int, error) {
 	var (
 		start   = b.start
 		readers = make([]xio.SegmentReader, 0, len(b.encoders)+len(b.loadedBlocks))
@@ -1350,7 +1391,7 @@ func (b *BufferBucket) merge(nsCtx namespace.Context) (int, error) {
 		}
 	}
 
-	encoder, lastWriteAt, err := mergeStreamsToEncoder(start, readers, b.opts, nsCtx)
+	encoder, lastWriteAt, err := mergeStreamsToEncoder(start, readers, excluded, b.opts, nsCtx)
 	if err != nil {
 		return 0, err
 	}
@@ -1367,11 +1408,12 @@ func (b *BufferBucket) merge(nsCtx namespace.Context) (int, error) {
 }
 
 // mergeStreamsToEncoder merges streams to an encoder and returns the last
-// write time. It is the responsibility of the caller to close the returned
-// encoder when appropriate.
+// write time, datapoints within the excluded ranges are dropped. It is the
+// responsibility of the caller to close the returned encoder when appropriate.
 func mergeStreamsToEncoder(
 	blockStart time.Time,
 	streams []xio.SegmentReader,
+	excluded xtime.Ranges,
 	opts Options,
 	nsCtx namespace.Context,
 ) (encoding.Encoder, time.Time, error) {
@@ -1385,6 +1427,9 @@ func mergeStreamsToEncoder(
 	iter.Reset(streams, blockStart, opts.RetentionOptions().BlockSize(), nsCtx.Schema)
 	for iter.Next() {
 		dp, unit, annotation := iter.Current()
+		if isExcluded(excluded, dp.Timestamp) {
+			continue
+		}
 		if err := encoder.Encode(dp, unit, annotation); err != nil {
 			encoder.Close()
 			return nil, timeZero, err
@@ -1399,6 +1444,14 @@ func mergeStreamsToEncoder(
 	return encoder, lastWriteAt, nil
 }
 
+// isExcluded returns whether the timestamp falls within the excluded ranges.
+func isExcluded(excluded xtime.Ranges, t time.Time) bool {
+	if excluded.IsEmpty() {
+		return false
+	}
+	return excluded.Overlaps(xtime.Range{Start: t, End: t.Add(time.Nanosecond)})
+}
+
 // mergeToStream merges all streams in this BufferBucket into one stream and
 // returns it.
 func (b *BufferBucket) mergeToStream(ctx context.Context, nsCtx namespace.Context) (xio.SegmentReader, bool, error) {
@@ -62,6 +62,20 @@ func (m *MockdatabaseBuffer) EXPECT() *MockdatabaseBufferMockRecorder {
 	return m.recorder
 }
 
+// DeleteRange mocks base method
+func (m *MockdatabaseBuffer) DeleteRange(start, end time.Time, nsCtx namespace.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRange", start, end, nsCtx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRange indicates an expected call of DeleteRange
+func (mr *MockdatabaseBufferMockRecorder) DeleteRange(start, end, nsCtx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRange", reflect.TypeOf((*MockdatabaseBuffer)(nil).DeleteRange), start, end, nsCtx)
+}
+
 // Write mocks base method
 func (m *MockdatabaseBuffer) Write(ctx context.Context, timestamp time.Time, value float64, unit time0.Unit, annotation []byte, wOpts WriteOptions) (bool, error) {
 	m.ctrl.T.Helper()
@@ -979,6 +979,46 @@ func TestBufferRemoveBucket(t *testing.T) {
 	assert.True(t, buffer.IsEmpty())
 }
 
+func TestBufferDeleteRange(t *testing.T) {
+	opts := newBufferTestOptions()
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer().(*dbBuffer)
+	buffer.Reset(databaseBufferResetOptions{
+		ID:      ident.StringID("foo"),
+		Options: opts,
+	})
+
+	// Write out of order so that the bucket holds multiple encoders.
+	data := []DecodedTestValue{
+		{curr.Add(secs(3)), 3, xtime.Second, nil},
+		{curr.Add(secs(1)), 1, xtime.Second, nil},
+		{curr.Add(secs(4)), 4, xtime.Second, nil},
+		{curr.Add(secs(2)), 2, xtime.Second, nil},
+		{curr.Add(secs(5)), 5, xtime.Second, nil},
+	}
+	for _, v := range data {
+		verifyWriteToBuffer(t, buffer, v, nil)
+	}
+
+	err := buffer.DeleteRange(curr.Add(secs(2)), curr.Add(secs(4)), namespace.Context{})
+	require.NoError(t, err)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	results, err := buffer.ReadEncoded(ctx, timeZero, timeDistantFuture, namespace.Context{})
+	require.NoError(t, err)
+	requireReaderValuesEqual(t, []DecodedTestValue{
+		{curr.Add(secs(1)), 1, xtime.Second, nil},
+		{curr.Add(secs(4)), 4, xtime.Second, nil},
+		{curr.Add(secs(5)), 5, xtime.Second, nil},
+	}, results, opts, namespace.Context{})
+}
+
 func TestBuffertoStream(t *testing.T) {
 	opts := newBufferTestOptions()
 
@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/x/context"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
 )
 
 var (
@@ -49,6 +50,7 @@ type Reader struct {
 	retriever  QueryableBlockRetriever
 	onRetrieve block.OnRetrieveBlock
 	onRead     block.OnReadBlock
+	tombstones map[xtime.UnixNano]seriesTombstone
 }
 
 // NewReaderUsingRetriever returns a reader for a series
@@ -148,6 +150,10 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 				if err != nil {
 					return nil, err
 				}
+				streamedBlock, err = r.excludeTombstoned(ctx, streamedBlock, nsCtx)
+				if err != nil {
+					return nil, err
+				}
 				if streamedBlock.IsNotEmpty() {
 					resultsBlock = append(resultsBlock, streamedBlock)
 					// NB(r): Mark this block as read now
@@ -176,6 +182,10 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 					if err != nil {
 						return nil, err
 					}
+					streamedBlock, err = r.excludeTombstoned(ctx, streamedBlock, nsCtx)
+					if err != nil {
+						return nil, err
+					}
 					if streamedBlock.IsNotEmpty() {
 						resultsBlock = append(resultsBlock, streamedBlock)
 					}
@@ -253,6 +263,9 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 		if seriesBlocks != nil {
 			if b, exists := seriesBlocks.BlockAt(start); exists {
 				streamedBlock, err := b.Stream(ctx)
+				if err == nil {
+					streamedBlock, err = r.excludeTombstoned(ctx, streamedBlock, nsCtx)
+				}
 				if err != nil {
 					// Short-circuit this entire blockstart if an error was encountered.
 					r := block.NewFetchBlockResult(start, nil,
@@ -288,6 +301,9 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 
 				if isRetrievable {
 					streamedBlock, err := r.retriever.Stream(ctx, r.id, start, onRetrieve, nsCtx)
+					if err == nil {
+						streamedBlock, err = r.excludeTombstoned(ctx, streamedBlock, nsCtx)
+					}
 					if err != nil {
 						// Short-circuit this entire blockstart if an error was encountered.
 						r := block.NewFetchBlockResult(start, nil,
@@ -344,3 +360,41 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 	block.SortFetchBlockResultByTimeAscending(res)
 	return res, nil
 }
+
+// excludeTombstoned removes any deleted datapoints from a block that was
+// read from disk, returning the block unchanged if nothing in it has been
+// deleted.
+func (r Reader) excludeTombstoned(
+	ctx context.Context,
+	br xio.BlockReader,
+	nsCtx namespace.Context,
+) (xio.BlockReader, error) {
+	if len(r.tombstones) == 0 || !br.IsNotEmpty() {
+		return br, nil
+	}
+
+	tombstone, ok := r.tombstones[xtime.ToUnixNano(br.Start)]
+	if !ok || tombstone.ranges.IsEmpty() {
+		return br, nil
+	}
+
+	encoder, _, err := mergeStreamsToEncoder(br.Start,
+		[]xio.SegmentReader{br.SegmentReader}, tombstone.ranges, r.opts, nsCtx)
+	if err != nil {
+		return xio.EmptyBlockReader, err
+	}
+
+	segment := encoder.Discard()
+	if segment.Len() == 0 {
+		segment.Finalize()
+		return xio.EmptyBlockReader, nil
+	}
+
+	reader := xio.NewSegmentReader(segment)
+	ctx.RegisterFinalizer(reader)
+	return xio.BlockReader{
+		SegmentReader: reader,
+		Start:         br.Start,
+		BlockSize:     br.BlockSize,
+	}, nil
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/xio"
@@ -50,6 +51,7 @@ var (
 	// specified for a write but the current series unique index is invalid.
 	errSeriesMatchUniqueIndexInvalid = errors.New("series write failed due to unique index being invalid")
 
+	errSeriesDeleteInvalidRange          = errors.New("series delete range is invalid")
 	errSeriesAlreadyBootstrapped         = errors.New("series is already bootstrapped")
 	errSeriesNotBootstrapped             = errors.New("series is not yet bootstrapped")
 	errBlockStateSnapshotNotBootstrapped = errors.New("block state snapshot is not bootstrapped")
@@ -73,6 +75,19 @@ type dbSeries struct {
 	onRetrieveBlock             block.OnRetrieveBlock
 	blockOnEvictedFromWiredList block.OnEvictedFromWiredList
 	pool                        DatabaseSeriesPool
+
+	// tombstones tracks data that has been deleted from blocks that have
+	// potentially been flushed to disk but not yet rewritten by a cold flush.
+	tombstones map[xtime.UnixNano]seriesTombstone
+}
+
+// seriesTombstone is the set of deleted ranges for a single block start along
+// with the cold version that was retrievable when the data was deleted, once
+// a newer cold version is retrievable the deleted data has been removed from
+// disk and the tombstone can be dropped.
+type seriesTombstone struct {
+	ranges      xtime.Ranges
+	coldVersion int
 }
 
 // NewDatabaseSeries creates a new database series.
@@ -141,10 +156,11 @@ func (s *dbSeries) Tick(blockStates ShardBlockStateSnapshot, nsCtx namespace.Con
 	r.TickStatus = update.TickStatus
 	r.MadeExpiredBlocks, r.MadeUnwiredBlocks =
 		update.madeExpiredBlocks, update.madeUnwiredBlocks
+	r.PendingDeleteBlocks = len(s.tombstones)
 
 	s.Unlock()
 
-	if update.ActiveBlocks == 0 {
+	if update.ActiveBlocks == 0 && r.PendingDeleteBlocks == 0 {
 		return r, ErrSeriesAllDatapointsExpired
 	}
 	return r, nil
@@ -260,15 +276,45 @@ func (s *dbSeries) updateBlocksWithLock(
 	result.ActiveBlocks += bufferStats.wiredBlocks
 	result.WiredBlocks += bufferStats.wiredBlocks
 
+	s.updateTombstonesWithLock(blockStates, expireCutoff)
+
 	return result, nil
 }
 
+func (s *dbSeries) updateTombstonesWithLock(
+	blockStates ShardBlockStateSnapshot,
+	expireCutoff time.Time,
+) {
+	if len(s.tombstones) == 0 {
+		return
+	}
+
+	blockStatesSnapshot, bootstrapped := blockStates.UnwrapValue()
+	for blockStart, tombstone := range s.tombstones {
+		if blockStart.ToTime().Before(expireCutoff) {
+			delete(s.tombstones, blockStart)
+			continue
+		}
+
+		// Only drop the tombstone once a cold flush that applied it has
+		// become retrievable, otherwise the deleted data would resurface
+		// from the older volume.
+		if !bootstrapped {
+			continue
+		}
+		if blockStatesSnapshot.Snapshot[blockStart].ColdVersion > tombstone.coldVersion {
+			delete(s.tombstones, blockStart)
+		}
+	}
+}
+
 func (s *dbSeries) IsEmpty() bool {
 	s.RLock()
 	blocksLen := s.cachedBlocks.Len()
 	bufferEmpty := s.buffer.IsEmpty()
+	tombstonesLen := len(s.tombstones)
 	s.RUnlock()
-	if blocksLen == 0 && bufferEmpty {
+	if blocksLen == 0 && bufferEmpty && tombstonesLen == 0 {
 		return true
 	}
 	return false
@@ -310,6 +356,107 @@ func (s *dbSeries) Write(
 	return wasWritten, err
 }
 
+func (s *dbSeries) DeleteRange(
+	start, end time.Time,
+	nsCtx namespace.Context,
+) error {
+	if !start.Before(end) {
+		return errSeriesDeleteInvalidRange
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	// Data in memory can be removed straight away.
+	if err := s.buffer.DeleteRange(start, end, nsCtx); err != nil {
+		return err
+	}
+
+	return s.tombstoneWithLock(start, end)
+}
+
+func (s *dbSeries) DeleteFlushedRange(start, end time.Time) error {
+	if !start.Before(end) {
+		return errSeriesDeleteInvalidRange
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	return s.tombstoneWithLock(start, end)
+}
+
+func (s *dbSeries) tombstoneWithLock(start, end time.Time) error {
+	var (
+		now       = s.now()
+		ropts     = s.opts.RetentionOptions()
+		blockSize = ropts.BlockSize()
+		deleted   = xtime.Range{Start: start, End: end}
+		earliest  = retention.FlushTimeStart(ropts, now)
+		latest    = now.Add(ropts.BufferFuture()).Truncate(blockSize)
+	)
+
+	if s.blockRetriever == nil {
+		// Nothing could have been flushed to disk.
+		return nil
+	}
+
+	// Any data that may be on disk needs to be tombstoned until a cold flush
+	// rewrites the block without it, squeeze the range by what is within
+	// retention to avoid walking every block for ranges like [0, now).
+	first := start.Truncate(blockSize)
+	if first.Before(earliest) {
+		first = earliest
+	}
+	for blockStart := first; blockStart.Before(end) && !blockStart.After(latest); blockStart = blockStart.Add(blockSize) {
+		blockRange := xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
+		tombstoned, ok := blockRange.Intersect(deleted)
+		if !ok {
+			continue
+		}
+
+		coldVersion, err := s.blockRetriever.RetrievableBlockColdVersion(blockStart)
+		if err != nil {
+			return err
+		}
+
+		if s.tombstones == nil {
+			s.tombstones = make(map[xtime.UnixNano]seriesTombstone)
+		}
+		key := xtime.ToUnixNano(blockStart)
+		tombstone := s.tombstones[key]
+		tombstone.ranges = tombstone.ranges.AddRange(tombstoned)
+		tombstone.coldVersion = coldVersion
+		s.tombstones[key] = tombstone
+	}
+
+	return nil
+}
+
+func (s *dbSeries) Tombstones(blockStart time.Time) xtime.Ranges {
+	s.RLock()
+	tombstone := s.tombstones[xtime.ToUnixNano(blockStart)]
+	s.RUnlock()
+	return tombstone.ranges
+}
+
+func (s *dbSeries) PendingTombstones() xtime.Ranges {
+	s.RLock()
+	defer s.RUnlock()
+
+	var pending xtime.Ranges
+	for blockStart, tombstone := range s.tombstones {
+		// Skip tombstones that a cold flush has already applied but that
+		// have not been dropped by a tick yet.
+		coldVersion, err := s.blockRetriever.RetrievableBlockColdVersion(blockStart.ToTime())
+		if err == nil && coldVersion > tombstone.coldVersion {
+			continue
+		}
+		pending = pending.AddRanges(tombstone.ranges)
+	}
+	return pending
+}
+
 func (s *dbSeries) ReadEncoded(
 	ctx context.Context,
 	start, end time.Time,
@@ -317,6 +464,7 @@ func (s *dbSeries) ReadEncoded(
 ) ([][]xio.BlockReader, error) {
 	s.RLock()
 	reader := NewReaderUsingRetriever(s.id, s.blockRetriever, s.onRetrieveBlock, s, s.opts)
+	reader.tombstones = s.tombstones
 	r, err := reader.readersWithBlocksMapAndBuffer(ctx, start, end, s.cachedBlocks, s.buffer, nsCtx)
 	s.RUnlock()
 	return r, err
@@ -348,6 +496,7 @@ func (s *dbSeries) FetchBlocks(
 		id:         s.id,
 		retriever:  s.blockRetriever,
 		onRetrieve: s.onRetrieveBlock,
+		tombstones: s.tombstones,
 	}.fetchBlocksWithBlocksMapAndBuffer(ctx, starts, s.cachedBlocks, s.buffer, nsCtx)
 	s.RUnlock()
 	return r, err
@@ -551,7 +700,16 @@ func (s *dbSeries) ColdFlushBlockStarts(blockStates BootstrappedBlockStateSnapsh
 	s.RLock()
 	defer s.RUnlock()
 
-	return s.buffer.ColdFlushBlockStarts(blockStates.Snapshot)
+	blockStarts := s.buffer.ColdFlushBlockStarts(blockStates.Snapshot)
+	for blockStart, tombstone := range s.tombstones {
+		// Tombstoned blocks need to be rewritten until a cold flush that
+		// applied the tombstones has become retrievable.
+		if blockStates.Snapshot[blockStart].ColdVersion <= tombstone.coldVersion &&
+			!blockStarts.Contains(blockStart) {
+			blockStarts.Add(blockStart)
+		}
+	}
+	return blockStarts
 }
 
 func (s *dbSeries) Close() {
@@ -562,6 +720,7 @@ func (s *dbSeries) Close() {
 	s.id = nil
 	s.tags = ident.Tags{}
 	s.uniqueIndex = 0
+	s.tombstones = nil
 
 	switch s.opts.CachePolicy() {
 	case CacheLRU:
@@ -606,6 +765,7 @@ func (s *dbSeries) Reset(opts DatabaseSeriesOptions) {
 	s.id = opts.ID
 	s.tags = opts.Tags
 	s.uniqueIndex = opts.UniqueIndex
+	s.tombstones = nil
 	s.cachedBlocks.Reset()
 	s.buffer.Reset(databaseBufferResetOptions{
 		ID:             opts.ID,
@@ -89,6 +89,34 @@ func (mr *MockDatabaseSeriesMockRecorder) ColdFlushBlockStarts(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdFlushBlockStarts", reflect.TypeOf((*MockDatabaseSeries)(nil).ColdFlushBlockStarts), arg0)
 }
 
+// DeleteRange mocks base method
+func (m *MockDatabaseSeries) DeleteRange(arg0 time.Time, arg1 time.Time, arg2 namespace.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRange", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRange indicates an expected call of DeleteRange
+func (mr *MockDatabaseSeriesMockRecorder) DeleteRange(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRange", reflect.TypeOf((*MockDatabaseSeries)(nil).DeleteRange), arg0, arg1, arg2)
+}
+
+// DeleteFlushedRange mocks base method
+func (m *MockDatabaseSeries) DeleteFlushedRange(arg0, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlushedRange", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFlushedRange indicates an expected call of DeleteFlushedRange
+func (mr *MockDatabaseSeriesMockRecorder) DeleteFlushedRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlushedRange", reflect.TypeOf((*MockDatabaseSeries)(nil).DeleteFlushedRange), arg0, arg1)
+}
+
 // FetchBlocks mocks base method
 func (m *MockDatabaseSeries) FetchBlocks(arg0 context.Context, arg1 []time.Time, arg2 namespace.Context) ([]block.FetchBlockResult, error) {
 	m.ctrl.T.Helper()
@@ -284,6 +312,34 @@ func (mr *MockDatabaseSeriesMockRecorder) Tick(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tick", reflect.TypeOf((*MockDatabaseSeries)(nil).Tick), arg0, arg1)
 }
 
+// PendingTombstones mocks base method
+func (m *MockDatabaseSeries) PendingTombstones() time0.Ranges {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingTombstones")
+	ret0, _ := ret[0].(time0.Ranges)
+	return ret0
+}
+
+// PendingTombstones indicates an expected call of PendingTombstones
+func (mr *MockDatabaseSeriesMockRecorder) PendingTombstones() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingTombstones", reflect.TypeOf((*MockDatabaseSeries)(nil).PendingTombstones))
+}
+
+// Tombstones mocks base method
+func (m *MockDatabaseSeries) Tombstones(arg0 time.Time) time0.Ranges {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tombstones", arg0)
+	ret0, _ := ret[0].(time0.Ranges)
+	return ret0
+}
+
+// Tombstones indicates an expected call of Tombstones
+func (mr *MockDatabaseSeriesMockRecorder) Tombstones(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tombstones", reflect.TypeOf((*MockDatabaseSeries)(nil).Tombstones), arg0)
+}
+
 // UniqueIndex mocks base method
 func (m *MockDatabaseSeries) UniqueIndex() uint64 {
 	m.ctrl.T.Helper()
@@ -1274,6 +1274,137 @@ func TestSeriesWriteReadFromTheSameBucket(t *testing.T) {
 	require.Equal(t, 3, len(values))
 }
 
+func TestSeriesDeleteRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions()
+	ropts := opts.RetentionOptions()
+	curr := time.Now().Truncate(ropts.BlockSize())
+	prev := curr.Add(-ropts.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+
+	blockRetriever := NewMockQueryableBlockRetriever(ctrl)
+	blockRetriever.EXPECT().
+		IsBlockRetrievable(gomock.Any()).
+		Return(false, nil).
+		AnyTimes()
+	blockRetriever.EXPECT().RetrievableBlockColdVersion(prev).Return(1, nil)
+	blockRetriever.EXPECT().RetrievableBlockColdVersion(curr).Return(0, nil)
+
+	series := NewDatabaseSeries(DatabaseSeriesOptions{
+		ID:             ident.StringID("foo"),
+		BlockRetriever: blockRetriever,
+		Options:        opts,
+	}).(*dbSeries)
+
+	verifyWriteToSeries(t, series, DecodedTestValue{
+		Timestamp: curr.Add(time.Second),
+		Value:     1,
+		Unit:      xtime.Second,
+	})
+	require.Error(t, series.DeleteRange(curr, curr, namespace.Context{}))
+	require.NoError(t, series.DeleteRange(prev.Add(30*time.Second),
+		curr.Add(2*time.Second), namespace.Context{}))
+
+	// The data in memory is removed straight away.
+	ctx := context.NewContext()
+	defer ctx.Close()
+	results, err := series.ReadEncoded(ctx, prev, curr.Add(ropts.BlockSize()), namespace.Context{})
+	require.NoError(t, err)
+	requireReaderValuesEqual(t, []DecodedTestValue{}, results, opts, namespace.Context{})
+
+	// The data on disk is tombstoned until it has been cold flushed.
+	require.Equal(t, xtime.NewRanges(xtime.Range{
+		Start: prev.Add(30 * time.Second),
+		End:   curr,
+	}).String(), series.Tombstones(prev).String())
+	require.Equal(t, xtime.NewRanges(xtime.Range{
+		Start: curr,
+		End:   curr.Add(2 * time.Second),
+	}).String(), series.Tombstones(curr).String())
+
+	blockStarts := series.ColdFlushBlockStarts(BootstrappedBlockStateSnapshot{
+		Snapshot: map[xtime.UnixNano]BlockState{
+			xtime.ToUnixNano(prev): BlockState{WarmRetrievable: true, ColdVersion: 1},
+		},
+	})
+	require.True(t, blockStarts.Contains(xtime.ToUnixNano(prev)))
+	require.True(t, blockStarts.Contains(xtime.ToUnixNano(curr)))
+
+	// Tombstones already applied by a cold flush are no longer pending.
+	blockRetriever.EXPECT().RetrievableBlockColdVersion(prev).Return(2, nil)
+	blockRetriever.EXPECT().RetrievableBlockColdVersion(curr).Return(0, nil)
+	require.Equal(t, xtime.NewRanges(xtime.Range{
+		Start: curr,
+		End:   curr.Add(2 * time.Second),
+	}).String(), series.PendingTombstones().String())
+
+	// Only the tombstones of blocks with a newer cold version are dropped.
+	result, err := series.Tick(NewShardBlockStateSnapshot(true, BootstrappedBlockStateSnapshot{
+		Snapshot: map[xtime.UnixNano]BlockState{
+			xtime.ToUnixNano(prev): BlockState{WarmRetrievable: true, ColdVersion: 2},
+		},
+	}), namespace.Context{})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.PendingDeleteBlocks)
+	require.True(t, series.Tombstones(prev).IsEmpty())
+	require.False(t, series.Tombstones(curr).IsEmpty())
+	require.False(t, series.IsEmpty())
+}
+
+func TestSeriesDeleteFlushedRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions()
+	ropts := opts.RetentionOptions()
+	curr := time.Now().Truncate(ropts.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+
+	blockRetriever := NewMockQueryableBlockRetriever(ctrl)
+	blockRetriever.EXPECT().
+		IsBlockRetrievable(gomock.Any()).
+		Return(false, nil).
+		AnyTimes()
+	blockRetriever.EXPECT().
+		RetrievableBlockColdVersion(curr).
+		Return(0, nil).
+		AnyTimes()
+
+	series := NewDatabaseSeries(DatabaseSeriesOptions{
+		ID:             ident.StringID("foo"),
+		BlockRetriever: blockRetriever,
+		Options:        opts,
+	}).(*dbSeries)
+
+	data := []DecodedTestValue{
+		{Timestamp: curr.Add(time.Second), Value: 1, Unit: xtime.Second},
+	}
+	verifyWriteToSeries(t, series, data[0])
+	require.Error(t, series.DeleteFlushedRange(curr, curr))
+	require.NoError(t, series.DeleteFlushedRange(curr, curr.Add(2*time.Second)))
+
+	// The data in memory is kept.
+	ctx := context.NewContext()
+	defer ctx.Close()
+	results, err := series.ReadEncoded(ctx, curr, curr.Add(ropts.BlockSize()), namespace.Context{})
+	require.NoError(t, err)
+	requireReaderValuesEqual(t, data, results, opts, namespace.Context{})
+
+	// The data on disk is tombstoned.
+	tombstoned := xtime.NewRanges(xtime.Range{
+		Start: curr,
+		End:   curr.Add(2 * time.Second),
+	}).String()
+	require.Equal(t, tombstoned, series.Tombstones(curr).String())
+	require.Equal(t, tombstoned, series.PendingTombstones().String())
+}
+
 func TestSeriesCloseNonCacheLRUPolicy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -140,6 +140,24 @@ type DatabaseSeries interface {
 	// ColdFlushBlockStarts returns the block starts that need cold flushes.
 	ColdFlushBlockStarts(blockStates BootstrappedBlockStateSnapshot) OptimizedTimes
 
+	// DeleteRange deletes all datapoints within [start, end), data in memory
+	// is removed immediately and data that may have been flushed to disk is
+	// tombstoned until a cold flush rewrites the affected blocks.
+	DeleteRange(start, end time.Time, nsCtx namespace.Context) error
+
+	// DeleteFlushedRange tombstones any data within [start, end) that may have
+	// been flushed to disk without removing any data in memory, it is used to
+	// restore deletes that were pending when the commit log was rotated.
+	DeleteFlushedRange(start, end time.Time) error
+
+	// Tombstones returns the deleted ranges for the given block start that
+	// are yet to be removed from disk.
+	Tombstones(blockStart time.Time) xtime.Ranges
+
+	// PendingTombstones returns all the deleted ranges that are yet to be
+	// removed from disk.
+	PendingTombstones() xtime.Ranges
+
 	// Close will close the series and if pooled returned to the pool.
 	Close()
 
@@ -234,6 +252,9 @@ type TickResult struct {
 	MergedOutOfOrderBlocks int
 	// EvictedBuckets is count of buckets just evicted from the buffer map.
 	EvictedBuckets int
+	// PendingDeleteBlocks is count of blocks with deletes that are yet to be
+	// removed from disk.
+	PendingDeleteBlocks int
 }
 
 // DatabaseSeriesAllocate allocates a database series for a pool.
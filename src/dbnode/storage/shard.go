@@ -160,6 +160,7 @@ type dbShard struct {
 	increasingIndex          increasingIndex
 	seriesPool               series.DatabaseSeriesPool
 	reverseIndex             namespaceIndex
+	limiter                  *namespaceLimiter
 	insertQueue              *dbShardInsertQueue
 	lookup                   *shardMap
 	list                     *list.List
@@ -247,6 +248,7 @@ func newDatabaseShard(
 	namespaceReaderMgr databaseNamespaceReaderManager,
 	increasingIndex increasingIndex,
 	reverseIndex namespaceIndex,
+	limiter *namespaceLimiter,
 	needsBootstrap bool,
 	opts Options,
 	seriesOpts series.Options,
@@ -265,6 +267,7 @@ func newDatabaseShard(
 		increasingIndex:      increasingIndex,
 		seriesPool:           opts.DatabaseSeriesPool(),
 		reverseIndex:         reverseIndex,
+		limiter:              limiter,
 		lookup:               newShardMap(shardMapOptions{}),
 		list:                 list.New(),
 		newMergerFn:          fs.NewMerger,
@@ -753,6 +756,7 @@ func (s *dbShard) tickAndExpire(
 			r.madeUnwiredBlocks += result.MadeUnwiredBlocks
 			r.mergedOutOfOrderBlocks += result.MergedOutOfOrderBlocks
 			r.evictedBuckets += result.EvictedBuckets
+			r.pendingDeleteBlocks += result.PendingDeleteBlocks
 			i++
 		}
 
@@ -864,6 +868,16 @@ func (s *dbShard) writeAndIndex(
 	}
 
 	writable := entry != nil
+	if !writable {
+		if err := s.limiter.AllowWriteNewSeries(); err != nil {
+			return ts.Series{}, false, err
+		}
+	}
+	// NB: Only take datapoint tokens once the series has been admitted so
+	// that writes rejected by the new series limit do not consume them.
+	if err := s.limiter.AllowWriteDatapoints(1); err != nil {
+		return ts.Series{}, false, err
+	}
 
 	// If no entry and we are not writing new series asynchronously.
 	if !writable && !opts.writeNewSeriesAsync {
@@ -1581,6 +1595,69 @@ func (s *dbShard) FetchBlocksForColdFlush(
 	return entry.Series.FetchBlocksForColdFlush(ctx, start, version, nsCtx)
 }
 
+func (s *dbShard) DeleteSeriesRange(
+	id ident.ID,
+	tags ident.TagIterator,
+	start, end time.Time,
+	nsCtx namespace.Context,
+) (ts.Series, error) {
+	// NB: The series may have been evicted from memory after being flushed,
+	// so make sure it is present to hold the tombstones for its data on disk.
+	entry, err := s.writableSeries(id, tags)
+	if err != nil {
+		return ts.Series{}, err
+	}
+	defer entry.DecrementReaderWriterCount()
+
+	if err := entry.Series.DeleteRange(start, end, nsCtx); err != nil {
+		return ts.Series{}, err
+	}
+
+	return s.commitLogSeries(entry), nil
+}
+
+func (s *dbShard) ForEachPendingTombstone(
+	fn func(series ts.Series, tombstones xtime.Ranges) error,
+) error {
+	var fnErr error
+	err := s.forEachShardEntry(func(entry *lookup.Entry) bool {
+		tombstones := entry.Series.PendingTombstones()
+		if tombstones.IsEmpty() {
+			return true
+		}
+		fnErr = fn(s.commitLogSeries(entry), tombstones)
+		return fnErr == nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	return err
+}
+
+func (s *dbShard) commitLogSeries(entry *lookup.Entry) ts.Series {
+	return ts.Series{
+		UniqueIndex: entry.Index,
+		Namespace:   s.namespace.ID(),
+		ID:          entry.Series.ID(),
+		Tags:        entry.Series.Tags(),
+		Shard:       s.shard,
+	}
+}
+
+func (s *dbShard) SeriesTombstones(
+	seriesID ident.ID,
+	blockStart time.Time,
+) xtime.Ranges {
+	s.RLock()
+	entry, _, err := s.lookupEntryWithLock(seriesID)
+	s.RUnlock()
+	if entry == nil || err != nil {
+		return xtime.Ranges{}
+	}
+
+	return entry.Series.Tombstones(blockStart)
+}
+
 func (s *dbShard) fetchActiveBlocksMetadata(
 	ctx context.Context,
 	start, end time.Time,
@@ -83,7 +83,7 @@ func testDatabaseShardWithIndexFn(
 		SetBufferBucketVersionsPool(series.NewBufferBucketVersionsPool(nil)).
 		SetBufferBucketPool(series.NewBufferBucketPool(nil))
 	return newDatabaseShard(metadata, 0, nil, nsReaderMgr,
-		&testIncreasingIndex{}, idx, true, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, idx, nil, true, opts, seriesOpts).(*dbShard)
 }
 
 func addMockSeries(ctrl *gomock.Controller, shard *dbShard, id ident.ID, tags ident.Tags, index uint64) *series.MockDatabaseSeries {
@@ -103,7 +103,7 @@ func TestShardDontNeedBootstrap(t *testing.T) {
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
 	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
-		&testIncreasingIndex{}, nil, false, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, nil, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
 	require.Equal(t, Bootstrapped, shard.bootstrapState)
@@ -116,7 +116,7 @@ func TestShardErrorIfDoubleBootstrap(t *testing.T) {
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
 	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
-		&testIncreasingIndex{}, nil, false, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, nil, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
 	require.Equal(t, Bootstrapped, shard.bootstrapState)
@@ -727,6 +727,13 @@ func (m *noopMergeWith) ForEachRemaining(
 	return nil
 }
 
+func (m *noopMergeWith) Tombstones(
+	seriesID ident.ID,
+	blockStart xtime.UnixNano,
+) xtime.Ranges {
+	return xtime.Ranges{}
+}
+
 func TestShardSnapshotShardNotBootstrapped(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1332,6 +1339,42 @@ func TestPurgeExpiredSeriesWriteAfterTicking(t *testing.T) {
 	require.Equal(t, 1, shard.lookup.Len())
 }
 
+func TestShardForEachPendingTombstone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := DefaultTestOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	var (
+		now        = time.Now()
+		tombstones = xtime.NewRanges(xtime.Range{Start: now.Add(-time.Hour), End: now})
+		tags       = ident.NewTags(ident.StringTag("name", "foo"))
+	)
+	foo := addMockSeries(ctrl, shard, ident.StringID("foo"), tags, 1)
+	foo.EXPECT().PendingTombstones().Return(tombstones)
+	bar := addMockSeries(ctrl, shard, ident.StringID("bar"), ident.Tags{}, 2)
+	bar.EXPECT().PendingTombstones().Return(xtime.Ranges{})
+
+	var visited []ts.Series
+	require.NoError(t, shard.ForEachPendingTombstone(func(
+		series ts.Series,
+		seriesTombstones xtime.Ranges,
+	) error {
+		require.Equal(t, tombstones.String(), seriesTombstones.String())
+		visited = append(visited, series)
+		return nil
+	}))
+	require.Equal(t, []ts.Series{{
+		UniqueIndex: 1,
+		Namespace:   shard.namespace.ID(),
+		ID:          ident.StringID("foo"),
+		Tags:        tags,
+		Shard:       shard.ID(),
+	}}, visited)
+}
+
 // This tests the scenario where tickForEachSeries finishes, and before purgeExpiredSeries
 // starts, we receive a write for a series, then purgeExpiredSeries runs, then we write to
 // the series. The expected behavior is not to expire series in this case.
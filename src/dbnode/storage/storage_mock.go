@@ -115,6 +115,21 @@ func (m *MockDatabase) EXPECT() *MockDatabaseMockRecorder {
 	return m.recorder
 }
 
+// DeleteTagged mocks base method
+func (m *MockDatabase) DeleteTagged(ctx context.Context, namespace ident.ID, query index.Query, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", ctx, namespace, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged
+func (mr *MockDatabaseMockRecorder) DeleteTagged(ctx, namespace, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockDatabase)(nil).DeleteTagged), ctx, namespace, query, start, end)
+}
+
 // Options mocks base method
 func (m *MockDatabase) Options() Options {
 	m.ctrl.T.Helper()
@@ -443,6 +458,20 @@ func (mr *MockDatabaseMockRecorder) Repair() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockDatabase)(nil).Repair))
 }
 
+// RepairStatus mocks base method
+func (m *MockDatabase) RepairStatus() []repair.NamespaceRepairStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairStatus")
+	ret0, _ := ret[0].([]repair.NamespaceRepairStatus)
+	return ret0
+}
+
+// RepairStatus indicates an expected call of RepairStatus
+func (mr *MockDatabaseMockRecorder) RepairStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairStatus", reflect.TypeOf((*MockDatabase)(nil).RepairStatus))
+}
+
 // Truncate mocks base method
 func (m *MockDatabase) Truncate(namespace ident.ID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -510,6 +539,21 @@ func (m *Mockdatabase) EXPECT() *MockdatabaseMockRecorder {
 	return m.recorder
 }
 
+// DeleteTagged mocks base method
+func (m *Mockdatabase) DeleteTagged(ctx context.Context, namespace ident.ID, query index.Query, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", ctx, namespace, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged
+func (mr *MockdatabaseMockRecorder) DeleteTagged(ctx, namespace, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*Mockdatabase)(nil).DeleteTagged), ctx, namespace, query, start, end)
+}
+
 // Options mocks base method
 func (m *Mockdatabase) Options() Options {
 	m.ctrl.T.Helper()
@@ -838,6 +882,20 @@ func (mr *MockdatabaseMockRecorder) Repair() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*Mockdatabase)(nil).Repair))
 }
 
+// RepairStatus mocks base method
+func (m *Mockdatabase) RepairStatus() []repair.NamespaceRepairStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairStatus")
+	ret0, _ := ret[0].([]repair.NamespaceRepairStatus)
+	return ret0
+}
+
+// RepairStatus indicates an expected call of RepairStatus
+func (mr *MockdatabaseMockRecorder) RepairStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairStatus", reflect.TypeOf((*Mockdatabase)(nil).RepairStatus))
+}
+
 // Truncate mocks base method
 func (m *Mockdatabase) Truncate(namespace ident.ID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1041,6 +1099,21 @@ func (m *MockdatabaseNamespace) EXPECT() *MockdatabaseNamespaceMockRecorder {
 	return m.recorder
 }
 
+// DeleteTagged mocks base method
+func (m *MockdatabaseNamespace) DeleteTagged(ctx context.Context, query index.Query, start, end time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTagged", ctx, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTagged indicates an expected call of DeleteTagged
+func (mr *MockdatabaseNamespaceMockRecorder) DeleteTagged(ctx, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTagged", reflect.TypeOf((*MockdatabaseNamespace)(nil).DeleteTagged), ctx, query, start, end)
+}
+
 // Options mocks base method
 func (m *MockdatabaseNamespace) Options() namespace.Options {
 	m.ctrl.T.Helper()
@@ -1373,6 +1446,20 @@ func (mr *MockdatabaseNamespaceMockRecorder) ColdFlush(flush interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdFlush", reflect.TypeOf((*MockdatabaseNamespace)(nil).ColdFlush), flush)
 }
 
+// LogPendingDeletes mocks base method
+func (m *MockdatabaseNamespace) LogPendingDeletes() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogPendingDeletes")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogPendingDeletes indicates an expected call of LogPendingDeletes
+func (mr *MockdatabaseNamespaceMockRecorder) LogPendingDeletes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogPendingDeletes", reflect.TypeOf((*MockdatabaseNamespace)(nil).LogPendingDeletes))
+}
+
 // Snapshot mocks base method
 func (m *MockdatabaseNamespace) Snapshot(blockStart, snapshotTime time.Time, flush persist.SnapshotPreparer) error {
 	m.ctrl.T.Helper()
@@ -1578,6 +1665,35 @@ func (m *MockdatabaseShard) EXPECT() *MockdatabaseShardMockRecorder {
 	return m.recorder
 }
 
+// DeleteSeriesRange mocks base method
+func (m *MockdatabaseShard) DeleteSeriesRange(id ident.ID, tags ident.TagIterator, start, end time.Time, nsCtx namespace.Context) (ts.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeriesRange", id, tags, start, end, nsCtx)
+	ret0, _ := ret[0].(ts.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeriesRange indicates an expected call of DeleteSeriesRange
+func (mr *MockdatabaseShardMockRecorder) DeleteSeriesRange(id, tags, start, end, nsCtx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeriesRange", reflect.TypeOf((*MockdatabaseShard)(nil).DeleteSeriesRange), id, tags, start, end, nsCtx)
+}
+
+// ForEachPendingTombstone mocks base method
+func (m *MockdatabaseShard) ForEachPendingTombstone(fn func(ts.Series, time0.Ranges) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachPendingTombstone", fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachPendingTombstone indicates an expected call of ForEachPendingTombstone
+func (mr *MockdatabaseShardMockRecorder) ForEachPendingTombstone(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachPendingTombstone", reflect.TypeOf((*MockdatabaseShard)(nil).ForEachPendingTombstone), fn)
+}
+
 // ID mocks base method
 func (m *MockdatabaseShard) ID() uint32 {
 	m.ctrl.T.Helper()
@@ -1660,6 +1776,20 @@ func (mr *MockdatabaseShardMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockdatabaseShard)(nil).Close))
 }
 
+// SeriesTombstones mocks base method
+func (m *MockdatabaseShard) SeriesTombstones(seriesID ident.ID, blockStart time.Time) time0.Ranges {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SeriesTombstones", seriesID, blockStart)
+	ret0, _ := ret[0].(time0.Ranges)
+	return ret0
+}
+
+// SeriesTombstones indicates an expected call of SeriesTombstones
+func (mr *MockdatabaseShardMockRecorder) SeriesTombstones(seriesID, blockStart interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeriesTombstones", reflect.TypeOf((*MockdatabaseShard)(nil).SeriesTombstones), seriesID, blockStart)
+}
+
 // Tick mocks base method
 func (m *MockdatabaseShard) Tick(c context.Cancellable, startTime time.Time, nsCtx namespace.Context) (tickResult, error) {
 	m.ctrl.T.Helper()
@@ -2002,6 +2132,18 @@ func (mr *MocknamespaceIndexMockRecorder) BlockStartForWriteTime(writeTime inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockStartForWriteTime", reflect.TypeOf((*MocknamespaceIndex)(nil).BlockStartForWriteTime), writeTime)
 }
 
+// Tombstone mocks base method
+func (m *MocknamespaceIndex) Tombstone(ids []ident.ID, tr time0.Range) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Tombstone", ids, tr)
+}
+
+// Tombstone indicates an expected call of Tombstone
+func (mr *MocknamespaceIndexMockRecorder) Tombstone(ids, tr interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tombstone", reflect.TypeOf((*MocknamespaceIndex)(nil).Tombstone), ids, tr)
+}
+
 // WriteBatch mocks base method
 func (m *MocknamespaceIndex) WriteBatch(batch *index.WriteBatch) error {
 	m.ctrl.T.Helper()
@@ -2648,6 +2790,20 @@ func (mr *MockdatabaseRepairerMockRecorder) Report() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*MockdatabaseRepairer)(nil).Report))
 }
 
+// Status mocks base method
+func (m *MockdatabaseRepairer) Status() []repair.NamespaceRepairStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].([]repair.NamespaceRepairStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status
+func (mr *MockdatabaseRepairerMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockdatabaseRepairer)(nil).Status))
+}
+
 // MockdatabaseTickManager is a mock of databaseTickManager interface
 type MockdatabaseTickManager struct {
 	ctrl     *gomock.Controller
@@ -2818,6 +2974,20 @@ func (mr *MockdatabaseMediatorMockRecorder) Repair() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockdatabaseMediator)(nil).Repair))
 }
 
+// RepairStatus mocks base method
+func (m *MockdatabaseMediator) RepairStatus() []repair.NamespaceRepairStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairStatus")
+	ret0, _ := ret[0].([]repair.NamespaceRepairStatus)
+	return ret0
+}
+
+// RepairStatus indicates an expected call of RepairStatus
+func (mr *MockdatabaseMediatorMockRecorder) RepairStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairStatus", reflect.TypeOf((*MockdatabaseMediator)(nil).RepairStatus))
+}
+
 // Close mocks base method
 func (m *MockdatabaseMediator) Close() error {
 	m.ctrl.T.Helper()
@@ -208,9 +208,23 @@ type Database interface {
 	// Repair will issue a repair and return nil on success or error on error.
 	Repair() error
 
+	// RepairStatus returns the repair status and progress of each namespace.
+	RepairStatus() []repair.NamespaceRepairStatus
+
 	// Truncate truncates data for the given namespace.
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteTagged deletes the data within the time range [start, end) of
+	// all series matching the given query, returning the number of series
+	// that were deleted. Deleted data is removed from memory immediately and
+	// removed from disk at the next cold flush of the affected blocks.
+	DeleteTagged(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		start, end time.Time,
+	) (int64, error)
+
 	// BootstrapState captures and returns a snapshot of the databases'
 	// bootstrap state.
 	BootstrapState() DatabaseBootstrapState
@@ -313,6 +327,15 @@ type databaseNamespace interface {
 		opts index.AggregationOptions,
 	) (index.AggregateQueryResult, error)
 
+	// DeleteTagged deletes the data within the time range [start, end) of
+	// all series matching the given query, returning the number of series
+	// that were deleted.
+	DeleteTagged(
+		ctx context.Context,
+		query index.Query,
+		start, end time.Time,
+	) (int64, error)
+
 	// ReadEncoded reads data for given id within [start, end).
 	ReadEncoded(
 		ctx context.Context,
@@ -355,11 +378,17 @@ type databaseNamespace interface {
 		flush persist.IndexFlush,
 	) error
 
-	// ColdFlush flushes unflushed in-memory ColdWrites.
+	// ColdFlush flushes unflushed in-memory ColdWrites, it also rewrites
+	// any blocks with deletes that are yet to be removed from disk.
 	ColdFlush(
 		flush persist.FlushPreparer,
 	) error
 
+	// LogPendingDeletes writes any deletes that are yet to be removed from
+	// disk to the commit log again, so that they survive the cleanup of the
+	// commit logs they were originally written to.
+	LogPendingDeletes() error
+
 	// Snapshot snapshots unflushed in-memory WarmWrites.
 	Snapshot(blockStart, snapshotTime time.Time, flush persist.SnapshotPreparer) error
 
@@ -485,6 +514,26 @@ type databaseShard interface {
 		nsCtx namespace.Context,
 	) ([]xio.BlockReader, error)
 
+	// DeleteSeriesRange deletes the data of a series within a time range,
+	// datapoints on disk are tombstoned until they are removed by a cold flush.
+	// It returns the series to record the delete in the commit log with.
+	DeleteSeriesRange(
+		id ident.ID,
+		tags ident.TagIterator,
+		start, end time.Time,
+		nsCtx namespace.Context,
+	) (ts.Series, error)
+
+	// ForEachPendingTombstone calls fn with the deleted ranges of each series
+	// that are yet to be removed from disk.
+	ForEachPendingTombstone(
+		fn func(series ts.Series, tombstones xtime.Ranges) error,
+	) error
+
+	// SeriesTombstones returns the deleted ranges of a series within a block
+	// that are yet to be removed from disk.
+	SeriesTombstones(seriesID ident.ID, blockStart time.Time) xtime.Ranges
+
 	// FetchBlocksMetadataV2 retrieves blocks metadata.
 	FetchBlocksMetadataV2(
 		ctx context.Context,
@@ -604,6 +653,10 @@ type namespaceIndex interface {
 		opts index.AggregationOptions,
 	) (index.AggregateQueryResult, error)
 
+	// Tombstone marks the given IDs as deleted for the time range, queries
+	// that only span deleted time ranges will no longer return them.
+	Tombstone(ids []ident.ID, tr xtime.Range)
+
 	// Bootstrap bootstraps the index the provided segments.
 	Bootstrap(
 		bootstrapResults result.IndexResults,
@@ -763,6 +816,9 @@ type databaseRepairer interface {
 
 	// Report reports runtime information.
 	Report()
+
+	// Status returns the repair status and progress of each namespace.
+	Status() []repair.NamespaceRepairStatus
 }
 
 // databaseTickManager performs periodic ticking.
@@ -800,6 +856,9 @@ type databaseMediator interface {
 	// Repair repairs the database.
 	Repair() error
 
+	// RepairStatus returns the repair status and progress of each namespace.
+	RepairStatus() []repair.NamespaceRepairStatus
+
 	// Close closes the mediator.
 	Close() error
 
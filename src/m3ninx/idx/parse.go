@@ -0,0 +1,513 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package idx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	errEmptyQueryString = errors.New("query string is empty")
+)
+
+type queryTokenType int
+
+const (
+	tokenEOF queryTokenType = iota
+	tokenLeftParen
+	tokenRightParen
+	tokenColon
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenMust
+	tokenMustNot
+	tokenWord
+	tokenPhrase
+	tokenRegexp
+	tokenRangeStart
+	tokenRangeEnd
+)
+
+type queryToken struct {
+	typ queryTokenType
+	pos int
+	// value is the unescaped value of a word, phrase or regexp.
+	value string
+	// pattern is the regular expression equivalent of a word containing
+	// wildcards.
+	pattern  string
+	wildcard bool
+	// inclusive is whether a range start or end token includes its bound.
+	inclusive bool
+}
+
+// ParseQuery parses a Lucene-style query string into a Query. Clauses are of
+// the form field:value, where the value may be a term, a "quoted phrase" which
+// is matched exactly, a /regular expression/, a term with * and ? wildcards,
+// or * to match any document which has the field. A term with a single trailing
+// * wildcard is matched as a prefix. Ranges of values are written as
+// field:[min TO max], with [] for inclusive and {} for exclusive bounds and *
+// for an unbounded side, or as field:>=min, field:>min, field:<=max and
+// field:<max. Ranges compare values numerically when every bound is a number
+// and lexicographically otherwise. Clauses may be combined
+// with AND, OR, NOT (or &&, || and !), prefixed with + or -, grouped with
+// parentheses and, for a single field, grouped as field:(a OR b). Adjacent
+// clauses without an operator are combined with AND, and *:* matches all
+// documents.
+//
+// For example: service:api AND (env:prod OR env:staging) -host:foo* shard:[10 TO 20}
+func ParseQuery(str string) (Query, error) {
+	tokens, err := lexQuery(str)
+	if err != nil {
+		return Query{}, err
+	}
+	if len(tokens) == 1 {
+		return Query{}, errEmptyQueryString
+	}
+
+	p := &queryParser{tokens: tokens}
+	q, err := p.parseOr("")
+	if err != nil {
+		return Query{}, err
+	}
+	if tok := p.peek(); tok.typ != tokenEOF {
+		return Query{}, p.unexpected(tok)
+	}
+	return q, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	idx    int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.idx]
+}
+
+func (p *queryParser) next() queryToken {
+	tok := p.tokens[p.idx]
+	if tok.typ != tokenEOF {
+		p.idx++
+	}
+	return tok
+}
+
+func (p *queryParser) unexpected(tok queryToken) error {
+	if tok.typ == tokenEOF {
+		return fmt.Errorf("unexpected end of query at position %d", tok.pos)
+	}
+	return fmt.Errorf("unexpected token at position %d", tok.pos)
+}
+
+// parseOr parses clauses separated by OR, the field is the field of an
+// enclosing field group, if any.
+func (p *queryParser) parseOr(field string) (Query, error) {
+	q, err := p.parseAnd(field)
+	if err != nil {
+		return Query{}, err
+	}
+
+	queries := []Query{q}
+	for p.peek().typ == tokenOr {
+		p.next()
+		q, err := p.parseAnd(field)
+		if err != nil {
+			return Query{}, err
+		}
+		queries = append(queries, q)
+	}
+
+	if len(queries) == 1 {
+		return queries[0], nil
+	}
+	return NewDisjunctionQuery(queries...), nil
+}
+
+// parseAnd parses clauses separated by AND or no operator at all.
+func (p *queryParser) parseAnd(field string) (Query, error) {
+	q, err := p.parseUnary(field)
+	if err != nil {
+		return Query{}, err
+	}
+
+	queries := []Query{q}
+	for {
+		switch p.peek().typ {
+		case tokenAnd:
+			p.next()
+		case tokenLeftParen, tokenNot, tokenMust, tokenMustNot,
+			tokenWord, tokenPhrase, tokenRegexp, tokenRangeStart:
+		default:
+			if len(queries) == 1 {
+				return queries[0], nil
+			}
+			return NewConjunctionQuery(queries...), nil
+		}
+
+		q, err := p.parseUnary(field)
+		if err != nil {
+			return Query{}, err
+		}
+		queries = append(queries, q)
+	}
+}
+
+func (p *queryParser) parseUnary(field string) (Query, error) {
+	switch p.peek().typ {
+	case tokenNot, tokenMustNot:
+		p.next()
+		q, err := p.parseUnary(field)
+		if err != nil {
+			return Query{}, err
+		}
+		return NewNegationQuery(q), nil
+	case tokenMust:
+		p.next()
+		return p.parseUnary(field)
+	}
+	return p.parsePrimary(field)
+}
+
+func (p *queryParser) parsePrimary(field string) (Query, error) {
+	tok := p.next()
+	switch tok.typ {
+	case tokenLeftParen:
+		q, err := p.parseOr(field)
+		if err != nil {
+			return Query{}, err
+		}
+		if tok := p.next(); tok.typ != tokenRightParen {
+			return Query{}, p.unexpected(tok)
+		}
+		return q, nil
+	case tokenRangeStart:
+		if field != "" {
+			return p.parseRange(field, tok)
+		}
+	case tokenWord:
+		if p.peek().typ == tokenColon {
+			if field != "" {
+				return Query{}, fmt.Errorf(
+					"nested field at position %d within group of field %s",
+					tok.pos, field)
+			}
+			p.next()
+			return p.parseFieldValue(tok)
+		}
+	}
+
+	if field == "" {
+		if tok.typ == tokenWord || tok.typ == tokenPhrase || tok.typ == tokenRegexp {
+			return Query{}, fmt.Errorf("missing field for value at position %d", tok.pos)
+		}
+		return Query{}, p.unexpected(tok)
+	}
+	return newValueQuery(field, tok)
+}
+
+// parseFieldValue parses the value following field:, which may be a group of
+// values within parentheses.
+func (p *queryParser) parseFieldValue(fieldTok queryToken) (Query, error) {
+	if fieldTok.wildcard {
+		// Only *:* is supported as a field with wildcards.
+		tok := p.next()
+		if fieldTok.value != "*" || tok.typ != tokenWord || tok.value != "*" {
+			return Query{}, fmt.Errorf(
+				"wildcard field at position %d not supported", fieldTok.pos)
+		}
+		return NewAllQuery(), nil
+	}
+
+	field := fieldTok.value
+	if p.peek().typ == tokenLeftParen {
+		return p.parsePrimary(field)
+	}
+
+	tok := p.next()
+	switch tok.typ {
+	case tokenWord, tokenPhrase, tokenRegexp:
+		return newValueQuery(field, tok)
+	case tokenRangeStart:
+		return p.parseRange(field, tok)
+	}
+	return Query{}, p.unexpected(tok)
+}
+
+// parseRange parses a range of the form [min TO max] following its start token.
+func (p *queryParser) parseRange(field string, startTok queryToken) (Query, error) {
+	min, err := p.parseRangeBound()
+	if err != nil {
+		return Query{}, err
+	}
+	if tok := p.next(); tok.typ != tokenWord || tok.value != "TO" {
+		return Query{}, p.unexpected(tok)
+	}
+	max, err := p.parseRangeBound()
+	if err != nil {
+		return Query{}, err
+	}
+	endTok := p.next()
+	if endTok.typ != tokenRangeEnd {
+		return Query{}, p.unexpected(endTok)
+	}
+	return newRangeQuery(field, min, max, startTok.inclusive, endTok.inclusive)
+}
+
+// parseRangeBound parses a bound of a range, * leaves the bound unbounded.
+func (p *queryParser) parseRangeBound() (string, error) {
+	tok := p.next()
+	if tok.typ == tokenMustNot {
+		// A leading - is part of a negative number rather than an operator.
+		next := p.next()
+		if next.typ != tokenWord || next.pos != tok.pos+1 {
+			return "", p.unexpected(next)
+		}
+		return "-" + next.value, nil
+	}
+	switch tok.typ {
+	case tokenWord:
+		if tok.wildcard {
+			if tok.value != "*" {
+				return "", fmt.Errorf(
+					"wildcard range bound at position %d not supported", tok.pos)
+			}
+			return "", nil
+		}
+		return tok.value, nil
+	case tokenPhrase:
+		return tok.value, nil
+	}
+	return "", p.unexpected(tok)
+}
+
+// newRangeQuery returns a numeric range query when every bound is a number and
+// a lexicographic range query otherwise.
+func newRangeQuery(
+	field, min, max string,
+	minInclusive, maxInclusive bool,
+) (Query, error) {
+	if min == "" && max == "" {
+		return NewFieldQuery([]byte(field)), nil
+	}
+	if isNumber(min) && isNumber(max) {
+		return NewNumericRangeQuery([]byte(field), []byte(min), []byte(max),
+			minInclusive, maxInclusive)
+	}
+	return NewRangeQuery([]byte(field), []byte(min), []byte(max),
+		minInclusive, maxInclusive), nil
+}
+
+// isNumber returns whether the bound is a number, an unbounded bound is
+// considered a number.
+func isNumber(bound string) bool {
+	if bound == "" {
+		return true
+	}
+	_, err := strconv.ParseFloat(bound, 64)
+	return err == nil
+}
+
+// comparisons are the operators which can prefix a value to match a range.
+var comparisons = []struct {
+	op        string
+	lower     bool
+	inclusive bool
+}{
+	{op: ">=", lower: true, inclusive: true},
+	{op: "<=", lower: false, inclusive: true},
+	{op: ">", lower: true, inclusive: false},
+	{op: "<", lower: false, inclusive: false},
+}
+
+func newValueQuery(field string, tok queryToken) (Query, error) {
+	switch {
+	case tok.typ == tokenRegexp:
+		q, err := NewRegexpQuery([]byte(field), []byte(tok.value))
+		if err != nil {
+			return Query{}, fmt.Errorf(
+				"invalid regexp at position %d: %v", tok.pos, err)
+		}
+		return q, nil
+	case tok.typ == tokenWord && tok.wildcard:
+		if tok.value == "*" {
+			return NewFieldQuery([]byte(field)), nil
+		}
+		if prefix := strings.TrimSuffix(tok.value, "*"); tok.pattern == regexp.QuoteMeta(prefix)+".*" {
+			// A single trailing wildcard can be matched natively as a prefix.
+			return NewPrefixQuery([]byte(field), []byte(prefix)), nil
+		}
+		return NewRegexpQuery([]byte(field), []byte(tok.pattern))
+	case tok.typ == tokenWord:
+		for _, c := range comparisons {
+			if !strings.HasPrefix(tok.value, c.op) || len(tok.value) == len(c.op) {
+				continue
+			}
+			bound := tok.value[len(c.op):]
+			if c.lower {
+				return newRangeQuery(field, bound, "", c.inclusive, false)
+			}
+			return newRangeQuery(field, "", bound, false, c.inclusive)
+		}
+	}
+	return NewTermQuery([]byte(field), []byte(tok.value)), nil
+}
+
+func lexQuery(str string) ([]queryToken, error) {
+	var (
+		tokens []queryToken
+		runes  = []rune(str)
+	)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{typ: tokenLeftParen, pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{typ: tokenRightParen, pos: i})
+			i++
+		case r == ':':
+			tokens = append(tokens, queryToken{typ: tokenColon, pos: i})
+			i++
+		case r == '+':
+			tokens = append(tokens, queryToken{typ: tokenMust, pos: i})
+			i++
+		case r == '-':
+			tokens = append(tokens, queryToken{typ: tokenMustNot, pos: i})
+			i++
+		case r == '!':
+			tokens = append(tokens, queryToken{typ: tokenNot, pos: i})
+			i++
+		case r == '&' && i+1 < len(runes) && runes[i+1] == '&':
+			tokens = append(tokens, queryToken{typ: tokenAnd, pos: i})
+			i += 2
+		case r == '|' && i+1 < len(runes) && runes[i+1] == '|':
+			tokens = append(tokens, queryToken{typ: tokenOr, pos: i})
+			i += 2
+		case r == '[' || r == '{':
+			tokens = append(tokens, queryToken{typ: tokenRangeStart, pos: i, inclusive: r == '['})
+			i++
+		case r == ']' || r == '}':
+			tokens = append(tokens, queryToken{typ: tokenRangeEnd, pos: i, inclusive: r == ']'})
+			i++
+		case r == '"' || r == '/':
+			tok, n, err := lexDelimited(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i += n
+		default:
+			tok, n := lexWord(runes, i)
+			tokens = append(tokens, tok)
+			i += n
+		}
+	}
+	return append(tokens, queryToken{typ: tokenEOF, pos: len(runes)}), nil
+}
+
+// lexDelimited lexes a phrase or regexp delimited by quotes or slashes, the
+// delimiter can be escaped with a backslash.
+func lexDelimited(runes []rune, start int) (queryToken, int, error) {
+	var (
+		delim = runes[start]
+		buf   bytes.Buffer
+		tok   = queryToken{typ: tokenPhrase, pos: start}
+	)
+	if delim == '/' {
+		tok.typ = tokenRegexp
+	}
+	for i := start + 1; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes) && runes[i+1] == delim:
+			buf.WriteRune(delim)
+			i++
+		case r == '\\' && i+1 < len(runes) && delim == '"':
+			buf.WriteRune(runes[i+1])
+			i++
+		case r == delim:
+			tok.value = buf.String()
+			return tok, i - start + 1, nil
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return queryToken{}, 0, fmt.Errorf(
+		"unterminated %c at position %d", delim, start)
+}
+
+// lexWord lexes a word, which ends at whitespace or an unescaped special
+// character, any character can be escaped with a backslash.
+func lexWord(runes []rune, start int) (queryToken, int) {
+	var (
+		value   bytes.Buffer
+		pattern bytes.Buffer
+		tok     = queryToken{typ: tokenWord, pos: start}
+		i       = start
+	)
+	for ; i < len(runes); i++ {
+		r := runes[i]
+		if r == '\\' && i+1 < len(runes) {
+			i++
+			value.WriteRune(runes[i])
+			pattern.WriteString(regexp.QuoteMeta(string(runes[i])))
+			continue
+		}
+		if strings.ContainsRune(" \t\n\r():\"/[]{}", r) {
+			break
+		}
+		value.WriteRune(r)
+		switch r {
+		case '*':
+			tok.wildcard = true
+			pattern.WriteString(".*")
+		case '?':
+			tok.wildcard = true
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	tok.value = value.String()
+	tok.pattern = pattern.String()
+	if !tok.wildcard && i-start == len(tok.value) {
+		// Only unescaped words can be operators.
+		switch tok.value {
+		case "AND":
+			tok.typ = tokenAnd
+		case "OR":
+			tok.typ = tokenOr
+		case "NOT":
+			tok.typ = tokenNot
+		}
+	}
+	return tok, i - start
+}
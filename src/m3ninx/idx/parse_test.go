@@ -0,0 +1,206 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package idx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Query
+	}{
+		{
+			name:     "term",
+			input:    "service:api",
+			expected: NewTermQuery([]byte("service"), []byte("api")),
+		},
+		{
+			name:     "phrase",
+			input:    `service:"api gateway"`,
+			expected: NewTermQuery([]byte("service"), []byte("api gateway")),
+		},
+		{
+			name:     "escaped special characters",
+			input:    `path:\/var\/lib\:data`,
+			expected: NewTermQuery([]byte("path"), []byte("/var/lib:data")),
+		},
+		{
+			name:     "regexp",
+			input:    `host:/foo-[0-9]+/`,
+			expected: MustCreateRegexpQuery([]byte("host"), []byte("foo-[0-9]+")),
+		},
+		{
+			name:     "wildcards",
+			input:    "host:foo.?*",
+			expected: MustCreateRegexpQuery([]byte("host"), []byte(`foo\...*`)),
+		},
+		{
+			name:     "prefix",
+			input:    "host:foo\\*bar*",
+			expected: NewPrefixQuery([]byte("host"), []byte("foo*bar")),
+		},
+		{
+			name:     "numeric range",
+			input:    "shard:[10 TO 20}",
+			expected: mustNumericRangeQuery(t, "shard", "10", "20", true, false),
+		},
+		{
+			name:     "numeric range with negative bound",
+			input:    "temp:{-5 TO *]",
+			expected: mustNumericRangeQuery(t, "temp", "-5", "", false, true),
+		},
+		{
+			name:     "lexicographic range",
+			input:    `name:[a TO "m z"]`,
+			expected: NewRangeQuery([]byte("name"), []byte("a"), []byte("m z"), true, true),
+		},
+		{
+			name:     "unbounded range",
+			input:    "name:[* TO *]",
+			expected: NewFieldQuery([]byte("name")),
+		},
+		{
+			name:     "greater than or equal",
+			input:    "version:>=1.2",
+			expected: mustNumericRangeQuery(t, "version", "1.2", "", true, false),
+		},
+		{
+			name:     "less than",
+			input:    "version:<v2",
+			expected: NewRangeQuery([]byte("version"), nil, []byte("v2"), false, false),
+		},
+		{
+			name:  "range within field group",
+			input: "shard:(1 OR [10 TO 20])",
+			expected: NewDisjunctionQuery(
+				NewTermQuery([]byte("shard"), []byte("1")),
+				mustNumericRangeQuery(t, "shard", "10", "20", true, true),
+			),
+		},
+		{
+			name:     "field exists",
+			input:    "host:*",
+			expected: NewFieldQuery([]byte("host")),
+		},
+		{
+			name:     "all",
+			input:    "*:*",
+			expected: NewAllQuery(),
+		},
+		{
+			name:  "implicit and",
+			input: "service:api env:prod",
+			expected: NewConjunctionQuery(
+				NewTermQuery([]byte("service"), []byte("api")),
+				NewTermQuery([]byte("env"), []byte("prod")),
+			),
+		},
+		{
+			name:  "operators",
+			input: "service:api && !env:prod || NOT env:staging",
+			expected: NewDisjunctionQuery(
+				NewConjunctionQuery(
+					NewTermQuery([]byte("service"), []byte("api")),
+					NewNegationQuery(NewTermQuery([]byte("env"), []byte("prod"))),
+				),
+				NewNegationQuery(NewTermQuery([]byte("env"), []byte("staging"))),
+			),
+		},
+		{
+			name:  "groups and prefixes",
+			input: "service:api AND (env:prod OR env:staging) -host:foo*",
+			expected: NewConjunctionQuery(
+				NewTermQuery([]byte("service"), []byte("api")),
+				NewDisjunctionQuery(
+					NewTermQuery([]byte("env"), []byte("prod")),
+					NewTermQuery([]byte("env"), []byte("staging")),
+				),
+				NewNegationQuery(NewPrefixQuery([]byte("host"), []byte("foo"))),
+			),
+		},
+		{
+			name:  "field group",
+			input: "+env:(prod OR staging*)",
+			expected: NewDisjunctionQuery(
+				NewTermQuery([]byte("env"), []byte("prod")),
+				NewPrefixQuery([]byte("env"), []byte("staging")),
+			),
+		},
+		{
+			name:     "values may contain dashes",
+			input:    "region:us-east-1",
+			expected: NewTermQuery([]byte("region"), []byte("us-east-1")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q, err := ParseQuery(test.input)
+			require.NoError(t, err)
+			require.True(t, test.expected.Equal(q),
+				"expected %s, actual %s", test.expected, q)
+		})
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: "  "},
+		{name: "missing field", input: "api"},
+		{name: "missing value", input: "service:"},
+		{name: "unbalanced parentheses", input: "(service:api"},
+		{name: "unexpected closing parenthesis", input: "service:api)"},
+		{name: "unterminated phrase", input: `service:"api`},
+		{name: "unterminated regexp", input: "service:/api"},
+		{name: "invalid regexp", input: "service:/(api/"},
+		{name: "dangling operator", input: "service:api AND"},
+		{name: "nested field group", input: "env:(service:api)"},
+		{name: "wildcard field", input: "serv*:api"},
+		{name: "unterminated range", input: "shard:[10 TO 20"},
+		{name: "range missing TO", input: "shard:[10 20]"},
+		{name: "wildcard range bound", input: "name:[a* TO b]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseQuery(test.input)
+			require.Error(t, err)
+		})
+	}
+}
+
+func mustNumericRangeQuery(
+	t *testing.T,
+	field, min, max string,
+	minInclusive, maxInclusive bool,
+) Query {
+	q, err := NewNumericRangeQuery([]byte(field), []byte(min), []byte(max),
+		minInclusive, maxInclusive)
+	require.NoError(t, err)
+	return q
+}
@@ -94,6 +94,36 @@ func MustCreateRegexpQuery(field, regexp []byte) Query {
 	}
 }
 
+// NewPrefixQuery returns a new query for finding documents which have a term for the field
+// beginning with the given prefix.
+func NewPrefixQuery(field, prefix []byte) Query {
+	return Query{
+		query: query.NewPrefixQuery(field, prefix),
+	}
+}
+
+// NewRangeQuery returns a new query for finding documents which have a term for the field
+// falling lexicographically within the given range. An empty bound leaves that side of the
+// range unbounded.
+func NewRangeQuery(field, min, max []byte, minInclusive, maxInclusive bool) Query {
+	return Query{
+		query: query.NewRangeQuery(field, min, max, minInclusive, maxInclusive),
+	}
+}
+
+// NewNumericRangeQuery returns a new query for finding documents which have a term for the
+// field which is a number within the given range. An empty bound leaves that side of the
+// range unbounded.
+func NewNumericRangeQuery(field, min, max []byte, minInclusive, maxInclusive bool) (Query, error) {
+	q, err := query.NewNumericRangeQuery(field, min, max, minInclusive, maxInclusive)
+	if err != nil {
+		return Query{}, err
+	}
+	return Query{
+		query: q,
+	}, nil
+}
+
 // NewNegationQuery returns a new query for finding documents which don't match a given query.
 func NewNegationQuery(q Query) Query {
 	return Query{
@@ -0,0 +1,224 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package index
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+var (
+	errRangeMinNotNumeric = errors.New("range minimum is not a number")
+	errRangeMaxNotNumeric = errors.New("range maximum is not a number")
+)
+
+// TermRange is a range of terms within a field. By default terms are compared
+// lexicographically, numeric ranges instead parse terms as floating point
+// numbers and never match terms which are not numbers. An empty bound leaves
+// that side of the range unbounded.
+type TermRange struct {
+	min          []byte
+	max          []byte
+	minInclusive bool
+	maxInclusive bool
+
+	numeric    bool
+	minNumeric float64
+	maxNumeric float64
+}
+
+// NewTermRange returns a new range which compares terms lexicographically.
+func NewTermRange(min, max []byte, minInclusive, maxInclusive bool) TermRange {
+	return TermRange{
+		min:          min,
+		max:          max,
+		minInclusive: minInclusive,
+		maxInclusive: maxInclusive,
+	}
+}
+
+// NewNumericTermRange returns a new range which compares terms numerically, it
+// returns an error if either of the non-empty bounds is not a number.
+func NewNumericTermRange(min, max []byte, minInclusive, maxInclusive bool) (TermRange, error) {
+	r := NewTermRange(min, max, minInclusive, maxInclusive)
+	r.numeric = true
+
+	if len(min) > 0 {
+		v, err := strconv.ParseFloat(string(min), 64)
+		if err != nil {
+			return TermRange{}, errRangeMinNotNumeric
+		}
+		r.minNumeric = v
+	}
+
+	if len(max) > 0 {
+		v, err := strconv.ParseFloat(string(max), 64)
+		if err != nil {
+			return TermRange{}, errRangeMaxNotNumeric
+		}
+		r.maxNumeric = v
+	}
+
+	return r, nil
+}
+
+// NewPrefixTermRange returns a new range which matches all terms beginning with
+// the given prefix.
+func NewPrefixTermRange(prefix []byte) TermRange {
+	return NewTermRange(prefix, prefixSuccessor(prefix), true, false)
+}
+
+// Min returns the lower bound of the range.
+func (r TermRange) Min() []byte { return r.min }
+
+// Max returns the upper bound of the range.
+func (r TermRange) Max() []byte { return r.max }
+
+// MinInclusive returns whether the lower bound is included in the range.
+func (r TermRange) MinInclusive() bool { return r.minInclusive }
+
+// MaxInclusive returns whether the upper bound is included in the range.
+func (r TermRange) MaxInclusive() bool { return r.maxInclusive }
+
+// Numeric returns whether terms are compared numerically.
+func (r TermRange) Numeric() bool { return r.numeric }
+
+// Bounds returns the lexicographic bounds, as a start key inclusive and an end key
+// exclusive, that every term within the range falls between. Terms between the
+// bounds must still be checked with Contains. A nil bound is unbounded.
+func (r TermRange) Bounds() (startInclusive []byte, endExclusive []byte) {
+	if r.numeric {
+		// NB: numbers do not sort lexicographically so every term needs checking.
+		return nil, nil
+	}
+
+	if len(r.min) > 0 {
+		startInclusive = r.min
+	}
+
+	if len(r.max) > 0 {
+		endExclusive = r.max
+		if r.maxInclusive {
+			// The smallest key greater than the maximum is the maximum with a zero byte appended.
+			endExclusive = make([]byte, 0, len(r.max)+1)
+			endExclusive = append(endExclusive, r.max...)
+			endExclusive = append(endExclusive, 0)
+		}
+	}
+
+	return startInclusive, endExclusive
+}
+
+// Contains returns whether the term falls within the range.
+func (r TermRange) Contains(term []byte) bool {
+	if r.numeric {
+		return r.containsNumeric(term)
+	}
+
+	if len(r.min) > 0 {
+		cmp := bytes.Compare(term, r.min)
+		if cmp < 0 || (cmp == 0 && !r.minInclusive) {
+			return false
+		}
+	}
+
+	if len(r.max) > 0 {
+		cmp := bytes.Compare(term, r.max)
+		if cmp > 0 || (cmp == 0 && !r.maxInclusive) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r TermRange) containsNumeric(term []byte) bool {
+	v, err := strconv.ParseFloat(string(term), 64)
+	if err != nil {
+		return false
+	}
+
+	if len(r.min) > 0 {
+		if v < r.minNumeric || (v == r.minNumeric && !r.minInclusive) {
+			return false
+		}
+	}
+
+	if len(r.max) > 0 {
+		if v > r.maxNumeric || (v == r.maxNumeric && !r.maxInclusive) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether r is equivalent to o.
+func (r TermRange) Equal(o TermRange) bool {
+	return bytes.Equal(r.min, o.min) &&
+		bytes.Equal(r.max, o.max) &&
+		r.minInclusive == o.minInclusive &&
+		r.maxInclusive == o.maxInclusive &&
+		r.numeric == o.numeric
+}
+
+func (r TermRange) String() string {
+	var b bytes.Buffer
+	if r.minInclusive {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if len(r.min) > 0 {
+		b.Write(r.min)
+	} else {
+		b.WriteString("*")
+	}
+	b.WriteString(", ")
+	if len(r.max) > 0 {
+		b.Write(r.max)
+	} else {
+		b.WriteString("*")
+	}
+	if r.maxInclusive {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+	if r.numeric {
+		return "numeric" + b.String()
+	}
+	return b.String()
+}
+
+// prefixSuccessor returns the smallest key which is greater than every key with
+// the given prefix, or nil if there is no such key.
+func prefixSuccessor(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			succ := make([]byte, i+1)
+			copy(succ, prefix[:i+1])
+			succ[i]++
+			return succ
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTermRangeContains(t *testing.T) {
+	mustNumeric := func(min, max string, minInclusive, maxInclusive bool) TermRange {
+		r, err := NewNumericTermRange([]byte(min), []byte(max), minInclusive, maxInclusive)
+		require.NoError(t, err)
+		return r
+	}
+
+	tests := []struct {
+		name      string
+		termRange TermRange
+		matches   []string
+		misses    []string
+	}{
+		{
+			name:      "lexicographic half open",
+			termRange: NewTermRange([]byte("b"), []byte("d"), true, false),
+			matches:   []string{"b", "banana", "c", "cz"},
+			misses:    []string{"a", "d", "da"},
+		},
+		{
+			name:      "lexicographic exclusive minimum inclusive maximum",
+			termRange: NewTermRange([]byte("b"), []byte("d"), false, true),
+			matches:   []string{"ba", "c", "d"},
+			misses:    []string{"b", "da"},
+		},
+		{
+			name:      "lexicographic unbounded maximum",
+			termRange: NewTermRange([]byte("1.2"), nil, true, false),
+			matches:   []string{"1.2", "1.3", "2"},
+			misses:    []string{"1.1", "0.9"},
+		},
+		{
+			name:      "prefix",
+			termRange: NewPrefixTermRange([]byte("app")),
+			matches:   []string{"app", "apple", "application"},
+			misses:    []string{"ap", "apq", "banana"},
+		},
+		{
+			name:      "numeric half open",
+			termRange: mustNumeric("10", "20", true, false),
+			matches:   []string{"10", "15", "19.99", "1e1"},
+			misses:    []string{"9", "20", "100", "abc"},
+		},
+		{
+			name:      "numeric unbounded minimum",
+			termRange: mustNumeric("", "0", false, true),
+			matches:   []string{"-5", "0"},
+			misses:    []string{"0.1", "zero"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, term := range test.matches {
+				require.True(t, test.termRange.Contains([]byte(term)), term)
+			}
+			for _, term := range test.misses {
+				require.False(t, test.termRange.Contains([]byte(term)), term)
+			}
+		})
+	}
+}
+
+func TestTermRangeBounds(t *testing.T) {
+	start, end := NewTermRange([]byte("b"), []byte("d"), true, false).Bounds()
+	require.Equal(t, []byte("b"), start)
+	require.Equal(t, []byte("d"), end)
+
+	start, end = NewTermRange([]byte("b"), []byte("d"), false, true).Bounds()
+	require.Equal(t, []byte("b"), start)
+	require.Equal(t, []byte("d\x00"), end)
+
+	start, end = NewPrefixTermRange([]byte("ab\xff")).Bounds()
+	require.Equal(t, []byte("ab\xff"), start)
+	require.Equal(t, []byte("ac"), end)
+
+	start, end = NewPrefixTermRange([]byte("\xff")).Bounds()
+	require.Equal(t, []byte("\xff"), start)
+	require.Nil(t, end)
+
+	numeric, err := NewNumericTermRange([]byte("10"), []byte("20"), true, false)
+	require.NoError(t, err)
+	start, end = numeric.Bounds()
+	require.Nil(t, start)
+	require.Nil(t, end)
+}
+
+func TestNumericTermRangeInvalid(t *testing.T) {
+	_, err := NewNumericTermRange([]byte("ten"), nil, true, false)
+	require.Error(t, err)
+
+	_, err = NewNumericTermRange(nil, []byte("twenty"), true, false)
+	require.Error(t, err)
+}
@@ -355,6 +355,21 @@ func (mr *MockSegmentMockRecorder) MatchField(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchField", reflect.TypeOf((*MockSegment)(nil).MatchField), arg0)
 }
 
+// MatchRange mocks base method
+func (m *MockSegment) MatchRange(arg0 []byte, arg1 index.TermRange) (postings.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchRange", arg0, arg1)
+	ret0, _ := ret[0].(postings.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MatchRange indicates an expected call of MatchRange
+func (mr *MockSegmentMockRecorder) MatchRange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchRange", reflect.TypeOf((*MockSegment)(nil).MatchRange), arg0, arg1)
+}
+
 // MatchRegexp mocks base method
 func (m *MockSegment) MatchRegexp(arg0 []byte, arg1 index.CompiledRegex) (postings.List, error) {
 	m.ctrl.T.Helper()
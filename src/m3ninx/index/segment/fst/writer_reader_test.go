@@ -394,6 +394,70 @@ func TestPostingsListRegexAll(t *testing.T) {
 	}
 }
 
+func TestPostingsListEqualForMatchRange(t *testing.T) {
+	numericRange, err := index.NewNumericTermRange([]byte("0"), []byte("100"), true, false)
+	require.NoError(t, err)
+
+	ranges := []index.TermRange{
+		index.NewTermRange([]byte("b"), []byte("p"), true, false),
+		index.NewTermRange([]byte("apple"), []byte("pineapple"), false, true),
+		index.NewTermRange(nil, []byte("m"), false, false),
+		index.NewPrefixTermRange([]byte("a")),
+		index.NewPrefixTermRange([]byte("node_")),
+		numericRange,
+	}
+
+	for _, test := range testDocuments {
+		t.Run(test.name, func(t *testing.T) {
+			for _, tc := range newTestCases(t, test.docs) {
+				t.Run(tc.name, func(t *testing.T) {
+					expSeg, obsSeg := tc.expected, tc.observed
+					expReader, err := expSeg.Reader()
+					require.NoError(t, err)
+					obsReader, err := obsSeg.Reader()
+					require.NoError(t, err)
+
+					fieldsIter, err := expSeg.FieldsIterable().Fields()
+					require.NoError(t, err)
+					fields := toSlice(t, fieldsIter)
+					for _, f := range fields {
+						for _, r := range ranges {
+							expPl, err := expReader.MatchRange(f, r)
+							require.NoError(t, err)
+							obsPl, err := obsReader.MatchRange(f, r)
+							require.NoError(t, err)
+							require.True(t, expPl.Equal(obsPl),
+								"%s:%s - [%v] != [%v]", f, r, pprintIter(expPl), pprintIter(obsPl))
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestMatchRangePrefix(t *testing.T) {
+	_, fstSeg := newTestSegments(t, fewTestDocuments)
+	reader, err := fstSeg.Reader()
+	require.NoError(t, err)
+
+	pl, err := reader.MatchRange([]byte("fruit"), index.NewPrefixTermRange([]byte("app")))
+	require.NoError(t, err)
+	require.Equal(t, 1, pl.Len())
+
+	pl, err = reader.MatchRange([]byte("fruit"), index.NewPrefixTermRange([]byte("pine")))
+	require.NoError(t, err)
+	require.Equal(t, 1, pl.Len())
+
+	pl, err = reader.MatchRange([]byte("fruit"), index.NewTermRange([]byte("apple"), []byte("pineapple"), true, true))
+	require.NoError(t, err)
+	require.Equal(t, 3, pl.Len())
+
+	pl, err = reader.MatchRange([]byte("unknown"), index.NewPrefixTermRange([]byte("a")))
+	require.NoError(t, err)
+	require.Equal(t, 0, pl.Len())
+}
+
 func TestSegmentDocs(t *testing.T) {
 	for _, test := range testDocuments {
 		t.Run(test.name, func(t *testing.T) {
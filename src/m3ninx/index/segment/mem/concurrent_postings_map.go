@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"sync"
 
+	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/postings"
 )
 
@@ -122,3 +123,26 @@ func (m *concurrentPostingsMap) GetRegex(re *regexp.Regexp) (postings.List, bool
 	}
 	return pl, true
 }
+
+// GetRange returns the union of the postings lists whose keys fall within the
+// provided range.
+func (m *concurrentPostingsMap) GetRange(termRange index.TermRange) (postings.List, bool) {
+	var pl postings.MutableList
+
+	m.RLock()
+	for _, mapEntry := range m.postingsMap.Iter() {
+		if termRange.Contains(mapEntry.Key()) {
+			if pl == nil {
+				pl = mapEntry.Value().Clone()
+			} else {
+				pl.Union(mapEntry.Value())
+			}
+		}
+	}
+	m.RUnlock()
+
+	if pl == nil {
+		return nil, false
+	}
+	return pl, true
+}
@@ -96,6 +96,16 @@ func (r *reader) MatchRegexp(field []byte, compiled index.CompiledRegex) (postin
 	return r.segment.matchRegexp(field, compileRE)
 }
 
+func (r *reader) MatchRange(field []byte, termRange index.TermRange) (postings.List, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	return r.segment.matchRange(field, termRange)
+}
+
 func (r *reader) MatchAll() (postings.MutableList, error) {
 	r.RLock()
 	defer r.RUnlock()
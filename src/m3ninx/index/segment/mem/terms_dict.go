@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index"
 	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/postings"
 	"github.com/m3db/m3/src/m3ninx/postings/roaring"
@@ -151,6 +152,23 @@ func (d *termsDict) MatchRegexp(
 	return pl
 }
 
+func (d *termsDict) MatchRange(
+	field []byte,
+	termRange index.TermRange,
+) postings.List {
+	d.fields.RLock()
+	postingsMap, ok := d.fields.Get(field)
+	d.fields.RUnlock()
+	if !ok {
+		return d.opts.PostingsListPool().Get()
+	}
+	pl, ok := postingsMap.GetRange(termRange)
+	if !ok {
+		return d.opts.PostingsListPool().Get()
+	}
+	return pl
+}
+
 func (d *termsDict) Reset() {
 	d.fields.Lock()
 	defer d.fields.Unlock()
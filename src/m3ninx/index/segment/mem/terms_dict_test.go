@@ -27,6 +27,7 @@ import (
 	"testing"
 
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index"
 	"github.com/m3db/m3/src/m3ninx/postings"
 
 	"github.com/leanovate/gopter"
@@ -274,6 +275,35 @@ func (t *termsDictionaryTestSuite) TestMatchRegex() {
 	props.TestingRun(t.T())
 }
 
+func (t *termsDictionaryTestSuite) TestMatchRange() {
+	props := getProperties()
+	props.Property(
+		"The dictionary should support prefix and range queries",
+		prop.ForAll(
+			func(f doc.Field, id postings.ID) (bool, error) {
+				t.termsDict.Insert(f, id)
+
+				prefix := f.Value[:len(f.Value)/2]
+				pl := t.termsDict.MatchRange(f.Name, index.NewPrefixTermRange(prefix))
+				if !pl.Contains(id) {
+					return false, fmt.Errorf("id of new document '%v' is not in list of prefix matches", id)
+				}
+
+				r := index.NewTermRange(f.Value, f.Value, true, true)
+				pl = t.termsDict.MatchRange(f.Name, r)
+				if !pl.Contains(id) {
+					return false, fmt.Errorf("id of new document '%v' is not in list of range matches", id)
+				}
+
+				return true, nil
+			},
+			genField(),
+			genDocID(),
+		))
+
+	props.TestingRun(t.T())
+}
+
 func (t *termsDictionaryTestSuite) TestMatchRegexNoResults() {
 	props := getProperties()
 	props.Property(
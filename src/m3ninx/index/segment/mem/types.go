@@ -24,6 +24,7 @@ import (
 	re "regexp"
 
 	"github.com/m3db/m3/src/m3ninx/doc"
+	"github.com/m3db/m3/src/m3ninx/index"
 	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/postings"
 )
@@ -49,6 +50,10 @@ type termsDictionary interface {
 	// given egular expression.
 	MatchRegexp(field []byte, compiled *re.Regexp) postings.List
 
+	// MatchRange returns the postings list corresponding to documents which have a
+	// term for the given field within the range.
+	MatchRange(field []byte, termRange index.TermRange) postings.List
+
 	// Fields returns the known fields.
 	Fields() sgmt.FieldsIterator
 
@@ -75,6 +80,10 @@ type ReadableSegment interface {
 	// matchRegexp returns the postings list of documents which match the given regular expression.
 	matchRegexp(field []byte, compiled *re.Regexp) (postings.List, error)
 
+	// matchRange returns the postings list of documents which have a term for the
+	// given field within the range.
+	matchRange(field []byte, termRange index.TermRange) (postings.List, error)
+
 	// getDoc returns the document associated with the given ID.
 	getDoc(id postings.ID) (doc.Document, error)
 }
@@ -72,6 +72,10 @@ type Readable interface {
 	// regular expression.
 	MatchRegexp(field []byte, c CompiledRegex) (postings.List, error)
 
+	// MatchRange returns a postings list over all documents which have a term for
+	// the given field within the range.
+	MatchRange(field []byte, r TermRange) (postings.List, error)
+
 	// MatchAll returns a postings list for all documents known to the Reader.
 	MatchAll() (postings.MutableList, error)
 
@@ -63,6 +63,16 @@ func unmarshal(q *querypb.Query) (search.Query, error) {
 	case *querypb.Query_Regexp:
 		return NewRegexpQuery(q.Regexp.Field, q.Regexp.Regexp)
 
+	case *querypb.Query_Prefix:
+		return NewPrefixQuery(q.Prefix.Field, q.Prefix.Prefix), nil
+
+	case *querypb.Query_Range:
+		r := q.Range
+		if r.Numeric {
+			return NewNumericRangeQuery(r.Field, r.Min, r.Max, r.MinInclusive, r.MaxInclusive)
+		}
+		return NewRangeQuery(r.Field, r.Min, r.Max, r.MinInclusive, r.MaxInclusive), nil
+
 	case *querypb.Query_Negation:
 		inner, err := unmarshal(q.Negation.Query)
 		if err != nil {
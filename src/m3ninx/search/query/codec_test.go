@@ -49,6 +49,18 @@ func TestMarshal(t *testing.T) {
 			name:  "regexp query",
 			query: MustCreateRegexpQuery([]byte("fruit"), []byte(".*ple")),
 		},
+		{
+			name:  "prefix query",
+			query: NewPrefixQuery([]byte("fruit"), []byte("app")),
+		},
+		{
+			name:  "range query",
+			query: NewRangeQuery([]byte("version"), []byte("1.2"), nil, true, false),
+		},
+		{
+			name:  "numeric range query",
+			query: MustCreateNumericRangeQuery([]byte("shard"), []byte("10"), []byte("20"), true, false),
+		},
 		{
 			name:  "negation query",
 			query: NewNegationQuery(NewTermQuery([]byte("fruit"), []byte("apple"))),
@@ -0,0 +1,81 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package query
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/m3db/m3/src/m3ninx/generated/proto/querypb"
+	"github.com/m3db/m3/src/m3ninx/index"
+	"github.com/m3db/m3/src/m3ninx/search"
+	"github.com/m3db/m3/src/m3ninx/search/searcher"
+)
+
+// PrefixQuery finds documents which have a term for the given field beginning
+// with the given prefix.
+type PrefixQuery struct {
+	field  []byte
+	prefix []byte
+}
+
+// NewPrefixQuery constructs a new PrefixQuery for the given field and prefix.
+func NewPrefixQuery(field, prefix []byte) search.Query {
+	return &PrefixQuery{
+		field:  field,
+		prefix: prefix,
+	}
+}
+
+// Searcher returns a searcher over the provided readers.
+func (q *PrefixQuery) Searcher() (search.Searcher, error) {
+	return searcher.NewRangeSearcher(q.field, index.NewPrefixTermRange(q.prefix)), nil
+}
+
+// Equal reports whether q is equivalent to o.
+func (q *PrefixQuery) Equal(o search.Query) bool {
+	o, ok := singular(o)
+	if !ok {
+		return false
+	}
+
+	inner, ok := o.(*PrefixQuery)
+	if !ok {
+		return false
+	}
+
+	return bytes.Equal(q.field, inner.field) && bytes.Equal(q.prefix, inner.prefix)
+}
+
+// ToProto returns the Protobuf query struct corresponding to the prefix query.
+func (q *PrefixQuery) ToProto() *querypb.Query {
+	prefix := querypb.PrefixQuery{
+		Field:  q.field,
+		Prefix: q.prefix,
+	}
+
+	return &querypb.Query{
+		Query: &querypb.Query_Prefix{Prefix: &prefix},
+	}
+}
+
+func (q *PrefixQuery) String() string {
+	return fmt.Sprintf("prefix(%s, %s)", q.field, q.prefix)
+}
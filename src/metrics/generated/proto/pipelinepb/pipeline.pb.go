@@ -46,6 +46,8 @@ import _ "github.com/gogo/protobuf/gogoproto"
 import aggregationpb "github.com/m3db/m3/src/metrics/generated/proto/aggregationpb"
 import transformationpb "github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
 
+import binary "encoding/binary"
+
 import io "io"
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -59,6 +61,27 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type RollupOp_Type int32
+
+const (
+	RollupOp_GROUP_BY          RollupOp_Type = 0
+	RollupOp_HISTOGRAM_BUCKETS RollupOp_Type = 1
+)
+
+var RollupOp_Type_name = map[int32]string{
+	0: "GROUP_BY",
+	1: "HISTOGRAM_BUCKETS",
+}
+var RollupOp_Type_value = map[string]int32{
+	"GROUP_BY":          0,
+	"HISTOGRAM_BUCKETS": 1,
+}
+
+func (x RollupOp_Type) String() string {
+	return proto.EnumName(RollupOp_Type_name, int32(x))
+}
+func (RollupOp_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptorPipeline, []int{2, 0} }
+
 type PipelineOp_Type int32
 
 const (
@@ -148,6 +171,8 @@ type RollupOp struct {
 	NewName          string                          `protobuf:"bytes,1,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
 	Tags             []string                        `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty"`
 	AggregationTypes []aggregationpb.AggregationType `protobuf:"varint,3,rep,packed,name=aggregation_types,json=aggregationTypes,enum=aggregationpb.AggregationType" json:"aggregation_types,omitempty"`
+	Type             RollupOp_Type                   `protobuf:"varint,4,opt,name=type,proto3,enum=pipelinepb.RollupOp_Type" json:"type,omitempty"`
+	HistogramBuckets []float64                       `protobuf:"fixed64,5,rep,packed,name=histogram_buckets,json=histogramBuckets" json:"histogram_buckets,omitempty"`
 }
 
 func (m *RollupOp) Reset()                    { *m = RollupOp{} }
@@ -176,6 +201,20 @@ func (m *RollupOp) GetAggregationTypes() []aggregationpb.AggregationType {
 	return nil
 }
 
+func (m *RollupOp) GetType() RollupOp_Type {
+	if m != nil {
+		return m.Type
+	}
+	return RollupOp_GROUP_BY
+}
+
+func (m *RollupOp) GetHistogramBuckets() []float64 {
+	if m != nil {
+		return m.HistogramBuckets
+	}
+	return nil
+}
+
 type PipelineOp struct {
 	Type           PipelineOp_Type   `protobuf:"varint,1,opt,name=type,proto3,enum=pipelinepb.PipelineOp_Type" json:"type,omitempty"`
 	Aggregation    *AggregationOp    `protobuf:"bytes,2,opt,name=aggregation" json:"aggregation,omitempty"`
@@ -319,6 +358,7 @@ func init() {
 	proto.RegisterType((*AppliedRollupOp)(nil), "pipelinepb.AppliedRollupOp")
 	proto.RegisterType((*AppliedPipelineOp)(nil), "pipelinepb.AppliedPipelineOp")
 	proto.RegisterType((*AppliedPipeline)(nil), "pipelinepb.AppliedPipeline")
+	proto.RegisterEnum("pipelinepb.RollupOp_Type", RollupOp_Type_name, RollupOp_Type_value)
 	proto.RegisterEnum("pipelinepb.PipelineOp_Type", PipelineOp_Type_name, PipelineOp_Type_value)
 	proto.RegisterEnum("pipelinepb.AppliedPipelineOp_Type", AppliedPipelineOp_Type_name, AppliedPipelineOp_Type_value)
 }
@@ -421,6 +461,21 @@ func (m *RollupOp) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintPipeline(dAtA, i, uint64(j1))
 		i += copy(dAtA[i:], dAtA2[:j1])
 	}
+	if m.Type != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(m.Type))
+	}
+	if len(m.HistogramBuckets) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintPipeline(dAtA, i, uint64(len(m.HistogramBuckets)*8))
+		for _, num := range m.HistogramBuckets {
+			f3 := math.Float64bits(float64(num))
+			binary.LittleEndian.PutUint64(dAtA[i:], uint64(f3))
+			i += 8
+		}
+	}
 	return i, nil
 }
 
@@ -659,6 +714,12 @@ func (m *RollupOp) Size() (n int) {
 		}
 		n += 1 + sovPipeline(uint64(l)) + l
 	}
+	if m.Type != 0 {
+		n += 1 + sovPipeline(uint64(m.Type))
+	}
+	if len(m.HistogramBuckets) > 0 {
+		n += 1 + sovPipeline(uint64(len(m.HistogramBuckets)*8)) + len(m.HistogramBuckets)*8
+	}
 	return n
 }
 
@@ -1036,6 +1097,71 @@ func (m *RollupOp) Unmarshal(dAtA []byte) error {
 			} else {
 				return fmt.Errorf("proto: wrong wireType = %d for field AggregationTypes", wireType)
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPipeline
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (RollupOp_Type(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.HistogramBuckets = append(m.HistogramBuckets, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPipeline
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthPipeline
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.HistogramBuckets = append(m.HistogramBuckets, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistogramBuckets", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPipeline(dAtA[iNdEx:])
@@ -1743,42 +1869,47 @@ func init() {
 }
 
 var fileDescriptorPipeline = []byte{
-	// 587 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0x4d, 0x6e, 0xd3, 0x40,
-	0x1c, 0xc5, 0x3b, 0x76, 0x94, 0xa6, 0xff, 0xd0, 0x34, 0x1d, 0x21, 0xe4, 0x7e, 0x10, 0x22, 0x8b,
-	0x45, 0x16, 0x60, 0x4b, 0x89, 0x40, 0x7c, 0xac, 0x52, 0x02, 0x69, 0x94, 0x60, 0x57, 0x43, 0x2a,
-	0x24, 0x36, 0x95, 0x1d, 0x4f, 0x8d, 0xa5, 0xd8, 0x1e, 0xd9, 0x8e, 0xaa, 0x5e, 0x80, 0x35, 0x87,
-	0xe1, 0x10, 0x5d, 0x72, 0x02, 0x84, 0xc2, 0x31, 0xd8, 0xa0, 0xd8, 0x4e, 0x32, 0x93, 0x04, 0x44,
-	0xbb, 0x1b, 0x8f, 0xdf, 0x7b, 0xf3, 0x9f, 0xf7, 0xb3, 0x0c, 0xa7, 0xae, 0x97, 0x7c, 0x9e, 0xd8,
-	0xda, 0x28, 0xf4, 0x75, 0xbf, 0xe5, 0xd8, 0xba, 0xdf, 0xd2, 0xe3, 0x68, 0xa4, 0xfb, 0x34, 0x89,
-	0xbc, 0x51, 0xac, 0xbb, 0x34, 0xa0, 0x91, 0x95, 0x50, 0x47, 0x67, 0x51, 0x98, 0x84, 0x3a, 0xf3,
-	0x18, 0x1d, 0x7b, 0x01, 0x65, 0xf6, 0x62, 0xa9, 0xa5, 0x6f, 0x30, 0x2c, 0x5f, 0x1d, 0x3e, 0xe5,
-	0x52, 0xdd, 0xd0, 0x0d, 0x33, 0xb3, 0x3d, 0xb9, 0x4c, 0x9f, 0xb2, 0xa4, 0xd9, 0x2a, 0xb3, 0x1e,
-	0x1a, 0xb7, 0x1c, 0xc2, 0x72, 0xdd, 0x88, 0xba, 0x56, 0xe2, 0x85, 0x01, 0xb3, 0xf9, 0xa7, 0x3c,
-	0x6f, 0x78, 0xcb, 0xbc, 0x24, 0xb2, 0x82, 0xf8, 0x32, 0x8c, 0xfc, 0x79, 0xa4, 0xb8, 0x91, 0xa5,
-	0xaa, 0x6f, 0x60, 0xb7, 0xbd, 0x3c, 0xca, 0x64, 0xb8, 0x09, 0x85, 0xe4, 0x9a, 0x51, 0x05, 0xd5,
-	0x51, 0xa3, 0xd2, 0xac, 0x69, 0xc2, 0x58, 0x1a, 0xa7, 0x1d, 0x5e, 0x33, 0x4a, 0x52, 0xad, 0x3a,
-	0x80, 0xea, 0x50, 0x08, 0x37, 0x19, 0x7e, 0x21, 0xe4, 0x3c, 0xd6, 0x56, 0xc7, 0xd1, 0x44, 0x07,
-	0x97, 0xf6, 0x05, 0x41, 0x89, 0x84, 0xe3, 0xf1, 0x84, 0x99, 0x0c, 0x1f, 0x40, 0x29, 0xa0, 0x57,
-	0x17, 0x81, 0xe5, 0x67, 0x51, 0x3b, 0x64, 0x3b, 0xa0, 0x57, 0x86, 0xe5, 0x53, 0x8c, 0xa1, 0x90,
-	0x58, 0x6e, 0xac, 0x48, 0x75, 0xb9, 0xb1, 0x43, 0xd2, 0x35, 0xee, 0xc3, 0x3e, 0x37, 0xf0, 0xc5,
-	0x2c, 0x2f, 0x56, 0xe4, 0xba, 0xfc, 0x1f, 0x57, 0xa9, 0x5a, 0xe2, 0x46, 0xac, 0x7e, 0x93, 0x00,
-	0xce, 0x72, 0xfe, 0x26, 0xc3, 0xba, 0x70, 0xa3, 0x23, 0x6d, 0xf9, 0x69, 0x68, 0x4b, 0x95, 0xb6,
-	0xbc, 0x08, 0x7e, 0x0d, 0x65, 0x2e, 0x53, 0x91, 0xea, 0xa8, 0x51, 0x6e, 0x1e, 0xf0, 0x3e, 0xa1,
-	0x7a, 0xc2, 0xab, 0x71, 0x07, 0x2a, 0x62, 0x65, 0x8a, 0x9c, 0xfa, 0x8f, 0x79, 0xff, 0x6a, 0xeb,
-	0x64, 0xc5, 0x83, 0x9f, 0x40, 0x31, 0x4a, 0xab, 0x54, 0x0a, 0xa9, 0xfb, 0x3e, 0xef, 0x9e, 0x97,
-	0x4c, 0x72, 0x8d, 0xda, 0x81, 0xc2, 0x6c, 0x7c, 0x5c, 0x86, 0xed, 0x73, 0xa3, 0x6f, 0x98, 0x1f,
-	0x8d, 0xea, 0x16, 0xde, 0x83, 0x72, 0xbb, 0xdb, 0x25, 0x6f, 0xbb, 0xed, 0x61, 0xcf, 0x34, 0xaa,
-	0x08, 0x63, 0xa8, 0x0c, 0x49, 0xdb, 0xf8, 0xf0, 0xce, 0x24, 0xef, 0xb3, 0x3d, 0x09, 0x03, 0x14,
-	0x89, 0x39, 0x18, 0x9c, 0x9f, 0x55, 0x65, 0xf5, 0x15, 0x94, 0xe6, 0x7d, 0x60, 0x0d, 0xe4, 0x90,
-	0xc5, 0x0a, 0xaa, 0xcb, 0x8d, 0x72, 0xf3, 0xc1, 0xe6, 0xca, 0x4e, 0x0a, 0x37, 0x3f, 0x1e, 0x6d,
-	0x91, 0x99, 0x50, 0x1d, 0xc3, 0x5e, 0x9b, 0xb1, 0xb1, 0x47, 0x9d, 0xc5, 0x17, 0x50, 0x01, 0xc9,
-	0x73, 0xd2, 0xd2, 0xef, 0x11, 0xc9, 0x73, 0x70, 0x0f, 0x2a, 0x3c, 0x62, 0xcf, 0xc9, 0x8b, 0x3d,
-	0xfe, 0x3b, 0xdf, 0x5e, 0x27, 0x3f, 0x63, 0x97, 0x93, 0xf4, 0x1c, 0xf5, 0x37, 0x82, 0xfd, 0xfc,
-	0x38, 0x8e, 0xf3, 0x73, 0x81, 0xb3, 0x2a, 0xf0, 0x5a, 0x15, 0xf3, 0xb8, 0xd7, 0x89, 0x49, 0x77,
-	0x20, 0xd6, 0x5a, 0x10, 0xcb, 0x78, 0x1f, 0x6d, 0x38, 0x7f, 0x0d, 0x5c, 0x6b, 0x13, 0xb8, 0x75,
-	0x4e, 0x88, 0xe3, 0x24, 0xa9, 0xa7, 0x8b, 0xae, 0x17, 0xb8, 0x9e, 0xf1, 0xb8, 0x1e, 0xfe, 0xf3,
-	0xe6, 0x1c, 0xb5, 0x93, 0xfe, 0xcd, 0xb4, 0x86, 0xbe, 0x4f, 0x6b, 0xe8, 0xe7, 0xb4, 0x86, 0xbe,
-	0xfe, 0xaa, 0x6d, 0x7d, 0x7a, 0x79, 0xe7, 0x3f, 0xb0, 0x5d, 0x4c, 0x77, 0x5a, 0x7f, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0x26, 0x29, 0x00, 0xcc, 0xc5, 0x05, 0x00, 0x00,
+	// 662 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0xdd, 0x6e, 0x9b, 0x4a,
+	0x10, 0xc7, 0x0d, 0xf8, 0x24, 0xce, 0x38, 0x71, 0xf0, 0xea, 0x9c, 0x23, 0xf2, 0x71, 0x7c, 0x2c,
+	0xd4, 0x0b, 0x4b, 0x69, 0x40, 0xb2, 0xd5, 0xaa, 0x1f, 0x57, 0x76, 0x92, 0x3a, 0x96, 0x13, 0x88,
+	0x36, 0x58, 0x55, 0x7b, 0x63, 0x81, 0xd9, 0x10, 0x54, 0x03, 0x2b, 0xc0, 0x8a, 0xf2, 0x14, 0xed,
+	0xc3, 0xf4, 0x21, 0x72, 0xd9, 0x27, 0xa8, 0xaa, 0xf4, 0x31, 0x7a, 0x53, 0x19, 0x88, 0xbd, 0x38,
+	0x6e, 0xd5, 0xe4, 0x6e, 0xd9, 0x9d, 0xf9, 0xcf, 0xcc, 0xff, 0x37, 0x12, 0x70, 0xec, 0xb8, 0xf1,
+	0xe5, 0xc4, 0x52, 0x46, 0x81, 0xa7, 0x7a, 0x2d, 0xdb, 0x52, 0xbd, 0x96, 0x1a, 0x85, 0x23, 0xd5,
+	0x23, 0x71, 0xe8, 0x8e, 0x22, 0xd5, 0x21, 0x3e, 0x09, 0xcd, 0x98, 0xd8, 0x2a, 0x0d, 0x83, 0x38,
+	0x50, 0xa9, 0x4b, 0xc9, 0xd8, 0xf5, 0x09, 0xb5, 0x66, 0x47, 0x25, 0x79, 0x41, 0x30, 0x7f, 0xda,
+	0xde, 0x67, 0x54, 0x9d, 0xc0, 0x09, 0xd2, 0x64, 0x6b, 0x72, 0x91, 0x7c, 0xa5, 0x4a, 0xd3, 0x53,
+	0x9a, 0xba, 0xad, 0x3d, 0xb0, 0x09, 0xd3, 0x71, 0x42, 0xe2, 0x98, 0xb1, 0x1b, 0xf8, 0xd4, 0x62,
+	0xbf, 0x32, 0x3d, 0xe3, 0x81, 0x7a, 0x71, 0x68, 0xfa, 0xd1, 0x45, 0x10, 0x7a, 0x77, 0x92, 0xf9,
+	0x8b, 0x54, 0x55, 0x3e, 0x80, 0x8d, 0xf6, 0xbc, 0x94, 0x4e, 0x51, 0x13, 0x8a, 0xf1, 0x35, 0x25,
+	0x12, 0x57, 0xe7, 0x1a, 0x95, 0x66, 0x4d, 0xc9, 0xb5, 0xa5, 0x30, 0xb1, 0xc6, 0x35, 0x25, 0x38,
+	0x89, 0x95, 0x4f, 0x40, 0x34, 0x72, 0xe2, 0x3a, 0x45, 0x2f, 0x72, 0x3a, 0x4f, 0x94, 0xc5, 0x76,
+	0x94, 0x7c, 0x06, 0xa3, 0xf6, 0x91, 0x87, 0x12, 0x0e, 0xc6, 0xe3, 0x09, 0xd5, 0x29, 0xda, 0x82,
+	0x92, 0x4f, 0xae, 0x86, 0xbe, 0xe9, 0xa5, 0x52, 0x6b, 0x78, 0xd5, 0x27, 0x57, 0x9a, 0xe9, 0x11,
+	0x84, 0xa0, 0x18, 0x9b, 0x4e, 0x24, 0xf1, 0x75, 0xa1, 0xb1, 0x86, 0x93, 0x33, 0xea, 0x43, 0x95,
+	0x69, 0x78, 0x38, 0xd5, 0x8b, 0x24, 0xa1, 0x2e, 0xfc, 0xc1, 0x28, 0xa2, 0x99, 0xbf, 0x88, 0xd0,
+	0x7e, 0x36, 0x42, 0x31, 0x19, 0x61, 0x4b, 0x99, 0xef, 0x82, 0x72, 0xd7, 0x9f, 0x32, 0xef, 0x1b,
+	0xed, 0x41, 0xf5, 0xd2, 0x8d, 0xe2, 0xc0, 0x09, 0x4d, 0x6f, 0x68, 0x4d, 0x46, 0x1f, 0x48, 0x1c,
+	0x49, 0x7f, 0xd5, 0x85, 0x06, 0x87, 0xc5, 0xd9, 0x43, 0x27, 0xbd, 0x97, 0xf7, 0xa0, 0x38, 0x4d,
+	0x45, 0xeb, 0x50, 0xea, 0x62, 0x7d, 0x70, 0x36, 0xec, 0xbc, 0x13, 0x0b, 0xe8, 0x1f, 0xa8, 0x1e,
+	0xf7, 0xce, 0x0d, 0xbd, 0x8b, 0xdb, 0xa7, 0xc3, 0xce, 0xe0, 0xa0, 0x7f, 0x64, 0x9c, 0x8b, 0x9c,
+	0xfc, 0x99, 0x07, 0x38, 0xcb, 0x8a, 0xeb, 0x14, 0xa9, 0x39, 0x6b, 0x77, 0xd8, 0xbe, 0xe6, 0x51,
+	0x6c, 0x67, 0xaf, 0xa1, 0xcc, 0x0c, 0x27, 0xf1, 0x75, 0xae, 0x51, 0xce, 0xcf, 0x93, 0xdb, 0x01,
+	0xcc, 0x46, 0xa3, 0x43, 0xa8, 0xe4, 0xd9, 0x49, 0x42, 0x92, 0xbf, 0xcb, 0xe6, 0x2f, 0xe2, 0xc7,
+	0x0b, 0x39, 0xe8, 0x29, 0xac, 0x84, 0x89, 0x67, 0x89, 0x9b, 0xe5, 0xe6, 0xdf, 0xcb, 0xdc, 0xc4,
+	0x59, 0x8c, 0x7c, 0x98, 0xb9, 0x53, 0x86, 0xd5, 0x81, 0xd6, 0xd7, 0xf4, 0xb7, 0x9a, 0x58, 0x40,
+	0x9b, 0x50, 0x6e, 0x77, 0xbb, 0xf8, 0xa8, 0xdb, 0x36, 0x7a, 0xba, 0x26, 0x72, 0x08, 0x41, 0xc5,
+	0xc0, 0x6d, 0xed, 0xfc, 0x8d, 0x8e, 0x4f, 0xd3, 0x3b, 0x1e, 0x01, 0xac, 0x60, 0xfd, 0xe4, 0x64,
+	0x70, 0x26, 0x0a, 0xf2, 0x2b, 0x28, 0xdd, 0xf9, 0x81, 0x14, 0x10, 0x02, 0x1a, 0x49, 0x5c, 0x5d,
+	0x68, 0x94, 0x9b, 0xff, 0x2e, 0xb7, 0xac, 0x53, 0xbc, 0xf9, 0xfa, 0x7f, 0x01, 0x4f, 0x03, 0xe5,
+	0x31, 0x6c, 0xb6, 0x29, 0x1d, 0xbb, 0xc4, 0x9e, 0xad, 0x62, 0x05, 0x78, 0xd7, 0x4e, 0x4c, 0x5f,
+	0xc7, 0xbc, 0x6b, 0xa3, 0x1e, 0x54, 0xd8, 0x5d, 0x73, 0xed, 0xcc, 0xd8, 0xdd, 0x5f, 0x2f, 0x5a,
+	0xef, 0x30, 0xab, 0xb1, 0xc1, 0x84, 0xf4, 0x6c, 0xf9, 0x07, 0x07, 0xd5, 0xac, 0x1c, 0xc3, 0xf9,
+	0x79, 0x8e, 0xb3, 0x9c, 0xe3, 0xb5, 0x18, 0xcc, 0xe2, 0xbe, 0x4f, 0x8c, 0x7f, 0x04, 0xb1, 0xd6,
+	0x8c, 0x58, 0xca, 0x7b, 0x67, 0x49, 0xfd, 0x7b, 0xe0, 0x5a, 0xcb, 0xc0, 0xdd, 0xe7, 0xc4, 0x31,
+	0x9c, 0x78, 0xf9, 0x18, 0x36, 0x17, 0xe6, 0x41, 0xcf, 0x58, 0x5c, 0xff, 0xfd, 0x76, 0x72, 0x86,
+	0x5a, 0xa7, 0x7f, 0x73, 0x5b, 0xe3, 0xbe, 0xdc, 0xd6, 0xb8, 0x6f, 0xb7, 0x35, 0xee, 0xd3, 0xf7,
+	0x5a, 0xe1, 0xfd, 0xcb, 0x47, 0xff, 0x0a, 0xac, 0x95, 0xe4, 0xa6, 0xf5, 0x73, 0x00, 0x79, 0x4b,
+	0xdc, 0x58, 0x4e, 0x06, 0x00, 0x00,
 }
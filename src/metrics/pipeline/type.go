@@ -25,6 +25,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/m3db/m3/src/metrics/aggregation"
@@ -39,8 +40,14 @@ var (
 	errNilRollupOpProto         = errors.New("nil rollup op proto message")
 	errNilPipelineProto         = errors.New("nil pipeline proto message")
 	errNoOpInUnionMarshaler     = errors.New("no operation in union JSON value")
+	errHistogramBucketsNotSet   = errors.New("histogram buckets set on a rollup that is not a histogram buckets rollup")
+	errInvalidHistogramBuckets  = errors.New("histogram buckets must be finite and strictly increasing")
 )
 
+// HistogramBucketTagName is the name of the tag containing the upper bound of
+// a histogram bucket.
+const HistogramBucketTagName = "le"
+
 // OpType defines the type of an operation.
 type OpType int
 
@@ -166,6 +173,62 @@ func (op TransformationOp) MarshalText() (text []byte, err error) {
 	return op.Type.MarshalText()
 }
 
+// RollupType defines the type of a rollup operation.
+type RollupType int
+
+// List of supported rollup types.
+const (
+	// GroupByRollupType rolls up metrics grouped by the rollup tags.
+	GroupByRollupType RollupType = iota
+	// HistogramBucketsRollupType rolls up histogram bucket metrics grouped by the
+	// rollup tags and the histogram bucket tag, such that bucket counters are
+	// aggregated per bucket upper bound.
+	HistogramBucketsRollupType
+)
+
+// NewRollupTypeFromProto creates a new rollup type from proto.
+func NewRollupTypeFromProto(pb pipelinepb.RollupOp_Type) (RollupType, error) {
+	switch pb {
+	case pipelinepb.RollupOp_GROUP_BY:
+		return GroupByRollupType, nil
+	case pipelinepb.RollupOp_HISTOGRAM_BUCKETS:
+		return HistogramBucketsRollupType, nil
+	default:
+		return GroupByRollupType, fmt.Errorf("unknown rollup type in proto: %v", pb)
+	}
+}
+
+// Proto returns the proto message for the given rollup type.
+func (t RollupType) Proto() (pipelinepb.RollupOp_Type, error) {
+	switch t {
+	case GroupByRollupType:
+		return pipelinepb.RollupOp_GROUP_BY, nil
+	case HistogramBucketsRollupType:
+		return pipelinepb.RollupOp_HISTOGRAM_BUCKETS, nil
+	default:
+		return pipelinepb.RollupOp_GROUP_BY, fmt.Errorf("unknown rollup type: %v", t)
+	}
+}
+
+// UnmarshalText extracts this type from the textual representation.
+func (t *RollupType) UnmarshalText(text []byte) error {
+	for i := 0; i < len(_RollupType_index)-1; i++ {
+		if candidate := RollupType(i); candidate.String() == string(text) {
+			*t = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid rollup type: %s", text)
+}
+
+// MarshalText serializes this type to its textual representation.
+func (t RollupType) MarshalText() ([]byte, error) {
+	if t < 0 || int(t) >= len(_RollupType_index)-1 {
+		return nil, fmt.Errorf("invalid rollup type: %v", t)
+	}
+	return []byte(t.String()), nil
+}
+
 // RollupOp is a rollup operation.
 type RollupOp struct {
 	// New metric name generated as a result of the rollup.
@@ -174,6 +237,13 @@ type RollupOp struct {
 	Tags [][]byte
 	// Types of aggregation performed within each unique dimension combination.
 	AggregationID aggregation.ID
+	// Type of rollup performed.
+	Type RollupType
+	// HistogramBuckets are the upper bounds of the histogram buckets rolled
+	// up by a histogram buckets rollup, excluding +Inf. If set, series whose
+	// histogram bucket upper bound is not one of these are not rolled up, so
+	// that series with differing bucket boundaries are never merged.
+	HistogramBuckets []float64
 }
 
 // NewRollupOpFromProto creates a new rollup op from proto.
@@ -187,22 +257,103 @@ func NewRollupOpFromProto(pb *pipelinepb.RollupOp) (RollupOp, error) {
 	if err != nil {
 		return rollup, err
 	}
+	rollupType, err := NewRollupTypeFromProto(pb.Type)
+	if err != nil {
+		return rollup, err
+	}
+	if err := validateHistogramBuckets(pb.HistogramBuckets, rollupType); err != nil {
+		return rollup, err
+	}
 	tags := make([]string, len(pb.Tags))
 	copy(tags, pb.Tags)
+	tags = rollupTags(tags, rollupType)
 	sort.Strings(tags)
 	return RollupOp{
-		NewName:       []byte(pb.NewName),
-		Tags:          xbytes.ArraysFromStringArray(tags),
-		AggregationID: aggregationID,
+		NewName:          []byte(pb.NewName),
+		Tags:             xbytes.ArraysFromStringArray(tags),
+		AggregationID:    aggregationID,
+		Type:             rollupType,
+		HistogramBuckets: cloneHistogramBuckets(pb.HistogramBuckets),
 	}, nil
 }
 
+// rollupTags returns the rollup tags for the given rollup type, histogram
+// bucket rollups always retain the histogram bucket tag. The tags passed in
+// are never modified, and the histogram bucket tag is inserted such that
+// sorted tags remain sorted.
+func rollupTags(tags []string, rollupType RollupType) []string {
+	if rollupType != HistogramBucketsRollupType {
+		return tags
+	}
+	for _, tag := range tags {
+		if tag == HistogramBucketTagName {
+			return tags
+		}
+	}
+	idx := sort.SearchStrings(tags, HistogramBucketTagName)
+	res := make([]string, 0, len(tags)+1)
+	res = append(res, tags[:idx]...)
+	res = append(res, HistogramBucketTagName)
+	return append(res, tags[idx:]...)
+}
+
+// validateHistogramBuckets validates the histogram buckets of a rollup.
+func validateHistogramBuckets(buckets []float64, rollupType RollupType) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+	if rollupType != HistogramBucketsRollupType {
+		return errHistogramBucketsNotSet
+	}
+	for i, bucket := range buckets {
+		if math.IsNaN(bucket) || math.IsInf(bucket, 0) {
+			return errInvalidHistogramBuckets
+		}
+		if i > 0 && bucket <= buckets[i-1] {
+			return errInvalidHistogramBuckets
+		}
+	}
+	return nil
+}
+
+func cloneHistogramBuckets(buckets []float64) []float64 {
+	if len(buckets) == 0 {
+		return nil
+	}
+	cloned := make([]float64, len(buckets))
+	copy(cloned, buckets)
+	return cloned
+}
+
+// HasHistogramBucket returns true if the rollup operation rolls up histogram
+// buckets with the given upper bound. All upper bounds are accepted if no
+// histogram buckets are set, and +Inf is always accepted.
+func (op RollupOp) HasHistogramBucket(upperBound float64) bool {
+	if len(op.HistogramBuckets) == 0 || math.IsInf(upperBound, 1) {
+		return true
+	}
+	idx := sort.SearchFloat64s(op.HistogramBuckets, upperBound)
+	return idx < len(op.HistogramBuckets) && op.HistogramBuckets[idx] == upperBound
+}
+
 // SameTransform returns true if the two rollup operations have the same rollup transformation
-// (i.e., same new rollup metric name and same set of rollup tags).
+// (i.e., same new rollup metric name, same rollup type, same histogram buckets and same set
+// of rollup tags).
 func (op RollupOp) SameTransform(other RollupOp) bool {
 	if !bytes.Equal(op.NewName, other.NewName) {
 		return false
 	}
+	if op.Type != other.Type {
+		return false
+	}
+	if len(op.HistogramBuckets) != len(other.HistogramBuckets) {
+		return false
+	}
+	for i := range op.HistogramBuckets {
+		if op.HistogramBuckets[i] != other.HistogramBuckets[i] {
+			return false
+		}
+	}
 	if len(op.Tags) != len(other.Tags) {
 		return false
 	}
@@ -232,9 +383,11 @@ func (op RollupOp) Clone() RollupOp {
 	newName := make([]byte, len(op.NewName))
 	copy(newName, op.NewName)
 	return RollupOp{
-		NewName:       newName,
-		Tags:          xbytes.ArrayCopy(op.Tags),
-		AggregationID: op.AggregationID,
+		NewName:          newName,
+		Tags:             xbytes.ArrayCopy(op.Tags),
+		AggregationID:    op.AggregationID,
+		Type:             op.Type,
+		HistogramBuckets: cloneHistogramBuckets(op.HistogramBuckets),
 	}
 }
 
@@ -248,10 +401,16 @@ func (op RollupOp) Proto() (*pipelinepb.RollupOp, error) {
 	if err != nil {
 		return nil, err
 	}
+	pbType, err := op.Type.Proto()
+	if err != nil {
+		return nil, err
+	}
 	return &pipelinepb.RollupOp{
 		NewName:          string(op.NewName),
 		Tags:             xbytes.ArraysToStringArray(op.Tags),
 		AggregationTypes: pbAggTypes,
+		Type:             pbType,
+		HistogramBuckets: cloneHistogramBuckets(op.HistogramBuckets),
 	}, nil
 }
 
@@ -268,6 +427,12 @@ func (op RollupOp) String() string {
 	}
 	b.WriteString("], ")
 	fmt.Fprintf(&b, "aggregation: %v", op.AggregationID)
+	if op.Type != GroupByRollupType {
+		fmt.Fprintf(&b, ", type: %v", op.Type)
+	}
+	if len(op.HistogramBuckets) > 0 {
+		fmt.Fprintf(&b, ", histogramBuckets: %v", op.HistogramBuckets)
+	}
 	b.WriteString("}")
 	return b.String()
 }
@@ -283,7 +448,11 @@ func (op *RollupOp) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &converted); err != nil {
 		return err
 	}
-	*op = converted.RollupOp()
+	rollup, err := converted.RollupOp()
+	if err != nil {
+		return err
+	}
+	*op = rollup
 	return nil
 }
 
@@ -293,7 +462,11 @@ func (op *RollupOp) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err := unmarshal(&converted); err != nil {
 		return err
 	}
-	*op = converted.RollupOp()
+	rollup, err := converted.RollupOp()
+	if err != nil {
+		return err
+	}
+	*op = rollup
 	return nil
 }
 
@@ -303,25 +476,34 @@ func (op RollupOp) MarshalYAML() (interface{}, error) {
 }
 
 type rollupMarshaler struct {
-	NewName       string         `json:"newName" yaml:"newName"`
-	Tags          []string       `json:"tags" yaml:"tags"`
-	AggregationID aggregation.ID `json:"aggregation,omitempty" yaml:"aggregation"`
+	NewName          string         `json:"newName" yaml:"newName"`
+	Tags             []string       `json:"tags" yaml:"tags"`
+	AggregationID    aggregation.ID `json:"aggregation,omitempty" yaml:"aggregation"`
+	Type             RollupType     `json:"type,omitempty" yaml:"type,omitempty"`
+	HistogramBuckets []float64      `json:"histogramBuckets,omitempty" yaml:"histogramBuckets,omitempty"`
 }
 
 func newRollupMarshaler(op RollupOp) rollupMarshaler {
 	return rollupMarshaler{
-		NewName:       string(op.NewName),
-		Tags:          xbytes.ArraysToStringArray(op.Tags),
-		AggregationID: op.AggregationID,
+		NewName:          string(op.NewName),
+		Tags:             xbytes.ArraysToStringArray(op.Tags),
+		AggregationID:    op.AggregationID,
+		Type:             op.Type,
+		HistogramBuckets: op.HistogramBuckets,
 	}
 }
 
-func (m rollupMarshaler) RollupOp() RollupOp {
-	return RollupOp{
-		NewName:       []byte(m.NewName),
-		Tags:          xbytes.ArraysFromStringArray(m.Tags),
-		AggregationID: m.AggregationID,
+func (m rollupMarshaler) RollupOp() (RollupOp, error) {
+	if err := validateHistogramBuckets(m.HistogramBuckets, m.Type); err != nil {
+		return RollupOp{}, err
 	}
+	return RollupOp{
+		NewName:          []byte(m.NewName),
+		Tags:             xbytes.ArraysFromStringArray(rollupTags(m.Tags, m.Type)),
+		AggregationID:    m.AggregationID,
+		Type:             m.Type,
+		HistogramBuckets: cloneHistogramBuckets(m.HistogramBuckets),
+	}, nil
 }
 
 // OpUnion is a union of different types of operation.
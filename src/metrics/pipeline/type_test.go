@@ -22,9 +22,11 @@ package pipeline
 
 import (
 	"encoding/json"
+	"math"
 	"testing"
 
 	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/generated/proto/aggregationpb"
 	"github.com/m3db/m3/src/metrics/generated/proto/pipelinepb"
 	"github.com/m3db/m3/src/metrics/generated/proto/transformationpb"
 	"github.com/m3db/m3/src/metrics/transformation"
@@ -260,12 +262,95 @@ func TestRollupOpSameTransform(t *testing.T) {
 			op:     RollupOp{NewName: b("baz"), Tags: bs("bar2", "bar1")},
 			result: false,
 		},
+		{
+			op:     RollupOp{NewName: b("foo"), Tags: bs("bar1", "bar2"), Type: HistogramBucketsRollupType},
+			result: false,
+		},
 	}
 	for _, input := range inputs {
 		require.Equal(t, input.result, rollupOp.SameTransform(input.op))
 	}
 }
 
+func TestRollupOpFromProtoHistogramBuckets(t *testing.T) {
+	pb := &pipelinepb.RollupOp{
+		NewName:          "foo",
+		Tags:             []string{"service", "endpoint"},
+		AggregationTypes: []aggregationpb.AggregationType{aggregationpb.AggregationType_SUM},
+		Type:             pipelinepb.RollupOp_HISTOGRAM_BUCKETS,
+	}
+	op, err := NewRollupOpFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, HistogramBucketsRollupType, op.Type)
+	require.Equal(t, bs("endpoint", "le", "service"), op.Tags)
+
+	// The histogram bucket tag is not duplicated if already present.
+	pb.Tags = []string{"le", "service"}
+	op, err = NewRollupOpFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, bs("le", "service"), op.Tags)
+
+	res, err := op.Proto()
+	require.NoError(t, err)
+	require.Equal(t, pipelinepb.RollupOp_HISTOGRAM_BUCKETS, res.Type)
+	require.Equal(t, []string{"le", "service"}, res.Tags)
+
+	// Histogram buckets round trip through proto.
+	pb.HistogramBuckets = []float64{0.1, 0.5, 1}
+	op, err = NewRollupOpFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, []float64{0.1, 0.5, 1}, op.HistogramBuckets)
+	require.True(t, op.HasHistogramBucket(0.5))
+	require.True(t, op.HasHistogramBucket(math.Inf(1)))
+	require.False(t, op.HasHistogramBucket(0.25))
+
+	res, err = op.Proto()
+	require.NoError(t, err)
+	require.Equal(t, []float64{0.1, 0.5, 1}, res.HistogramBuckets)
+}
+
+func TestRollupOpFromProtoInvalidHistogramBuckets(t *testing.T) {
+	inputs := []struct {
+		rollupType pipelinepb.RollupOp_Type
+		buckets    []float64
+	}{
+		{rollupType: pipelinepb.RollupOp_GROUP_BY, buckets: []float64{1}},
+		{rollupType: pipelinepb.RollupOp_HISTOGRAM_BUCKETS, buckets: []float64{1, 0.5}},
+		{rollupType: pipelinepb.RollupOp_HISTOGRAM_BUCKETS, buckets: []float64{1, 1}},
+		{rollupType: pipelinepb.RollupOp_HISTOGRAM_BUCKETS, buckets: []float64{1, math.Inf(1)}},
+		{rollupType: pipelinepb.RollupOp_HISTOGRAM_BUCKETS, buckets: []float64{math.NaN()}},
+	}
+	for _, input := range inputs {
+		_, err := NewRollupOpFromProto(&pipelinepb.RollupOp{
+			NewName:          "foo",
+			Tags:             []string{"service"},
+			Type:             input.rollupType,
+			HistogramBuckets: input.buckets,
+		})
+		require.Error(t, err)
+	}
+}
+
+func TestRollupTagsDoesNotMutateInput(t *testing.T) {
+	tags := make([]string, 2, 3)
+	copy(tags, []string{"endpoint", "service"})
+	res := rollupTags(tags, HistogramBucketsRollupType)
+	require.Equal(t, []string{"endpoint", "le", "service"}, res)
+	require.Equal(t, []string{"endpoint", "service"}, tags)
+	require.Equal(t, "", tags[:3][2])
+
+	res = rollupTags(tags, GroupByRollupType)
+	require.Equal(t, []string{"endpoint", "service"}, res)
+}
+
+func TestRollupOpFromProtoBadType(t *testing.T) {
+	_, err := NewRollupOpFromProto(&pipelinepb.RollupOp{
+		NewName: "foo",
+		Type:    pipelinepb.RollupOp_Type(100),
+	})
+	require.Error(t, err)
+}
+
 func TestOpUnionMarshalJSON(t *testing.T) {
 	inputs := []struct {
 		op       OpUnion
@@ -296,6 +381,18 @@ func TestOpUnionMarshalJSON(t *testing.T) {
 			},
 			expected: `{"rollup":{"newName":"testRollup","tags":["tag1","tag2"],"aggregation":["Min","Max"]}}`,
 		},
+		{
+			op: OpUnion{
+				Type: RollupOpType,
+				Rollup: RollupOp{
+					NewName:       b("testRollup"),
+					Tags:          bs("le", "tag1"),
+					AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+					Type:          HistogramBucketsRollupType,
+				},
+			},
+			expected: `{"rollup":{"newName":"testRollup","tags":["le","tag1"],"aggregation":["Sum"],"type":"HistogramBucketsRollupType"}}`,
+		},
 		{
 			op: OpUnion{
 				Type: RollupOpType,
@@ -347,8 +347,7 @@ func (as *activeRuleSet) toRollupResults(
 			var matched bool
 			rollupID, matched = as.matchRollupTarget(
 				sortedTagPairBytes,
-				firstOp.Rollup.NewName,
-				firstOp.Rollup.Tags,
+				firstOp.Rollup,
 				tagPairs,
 				matchRollupTargetOptions{generateRollupID: true},
 			)
@@ -397,11 +396,13 @@ func (as *activeRuleSet) toRollupResults(
 
 // matchRollupTarget matches an incoming metric ID against a rollup target,
 // returns the new rollup ID if the metric ID contains the full list of rollup
-// tags, and nil otherwise.
+// tags, and nil otherwise. For histogram bucket rollups, the metric ID must
+// additionally contain a valid histogram bucket tag, whose value is normalized
+// so that buckets with the same upper bound are rolled up together, and whose
+// upper bound is one of the histogram buckets of the rollup if any are set.
 func (as *activeRuleSet) matchRollupTarget(
 	sortedTagPairBytes []byte,
-	newName []byte,
-	rollupTags [][]byte,
+	rollupOp mpipeline.RollupOp,
 	tagPairs []metricID.TagPair, // buffer for reuse to generate rollup ID across calls
 	opts matchRollupTargetOptions,
 ) ([]byte, bool) {
@@ -409,11 +410,22 @@ func (as *activeRuleSet) matchRollupTarget(
 		sortedTagIter = as.tagsFilterOpts.SortedTagIteratorFn(sortedTagPairBytes)
 		hasMoreTags   = sortedTagIter.Next()
 		currTagIdx    = 0
+		rollupTags    = rollupOp.Tags
+		isHistogram   = rollupOp.Type == mpipeline.HistogramBucketsRollupType
 	)
 	for hasMoreTags && currTagIdx < len(rollupTags) {
 		tagName, tagVal := sortedTagIter.Current()
 		res := bytes.Compare(tagName, rollupTags[currTagIdx])
 		if res == 0 {
+			if isHistogram && bytes.Equal(tagName, histogramBucketTagName) {
+				normalized, upperBound, ok := normalizeHistogramBucketValue(tagVal)
+				if !ok || !rollupOp.HasHistogramBucket(upperBound) {
+					// An invalid bucket upper bound, or one that differs from the
+					// bucket boundaries of the rollup, is considered a non-match.
+					break
+				}
+				tagVal = normalized
+			}
 			if opts.generateRollupID {
 				tagPairs = append(tagPairs, metricID.TagPair{Name: tagName, Value: tagVal})
 			}
@@ -437,7 +449,7 @@ func (as *activeRuleSet) matchRollupTarget(
 	if !opts.generateRollupID {
 		return nil, true
 	}
-	return as.newRollupIDFn(newName, tagPairs), true
+	return as.newRollupIDFn(rollupOp.NewName, tagPairs), true
 }
 
 func (as *activeRuleSet) applyIDToPipeline(
@@ -460,8 +472,7 @@ func (as *activeRuleSet) applyIDToPipeline(
 			var matched bool
 			rollupID, matched := as.matchRollupTarget(
 				sortedTagPairBytes,
-				rollupOp.NewName,
-				rollupOp.Tags,
+				rollupOp,
 				tagPairs,
 				matchRollupTargetOptions{generateRollupID: true},
 			)
@@ -557,8 +568,7 @@ func (as *activeRuleSet) reverseMappingsForRollupID(
 				}
 				if _, matched := as.matchRollupTarget(
 					sortedTagPairBytes,
-					rollupOp.NewName,
-					rollupOp.Tags,
+					rollupOp,
 					nil,
 					matchRollupTargetOptions{generateRollupID: false},
 				); !matched {
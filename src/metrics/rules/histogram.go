@@ -0,0 +1,52 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"math"
+	"strconv"
+
+	mpipeline "github.com/m3db/m3/src/metrics/pipeline"
+)
+
+var (
+	histogramBucketTagName = []byte(mpipeline.HistogramBucketTagName)
+	histogramBucketInf     = []byte("+Inf")
+)
+
+// normalizeHistogramBucketValue validates a histogram bucket upper bound and
+// returns its canonical representation along with the parsed upper bound, so
+// that bucket boundaries formatted differently across instances (e.g. "1" and
+// "1.0") are rolled up together.
+func normalizeHistogramBucketValue(value []byte) ([]byte, float64, bool) {
+	upperBound, err := strconv.ParseFloat(string(value), 64)
+	if err != nil || math.IsNaN(upperBound) {
+		return nil, 0, false
+	}
+	if math.IsInf(upperBound, 1) {
+		return histogramBucketInf, upperBound, true
+	}
+	normalized := strconv.FormatFloat(upperBound, 'g', -1, 64)
+	if normalized == string(value) {
+		return value, upperBound, true
+	}
+	return []byte(normalized), upperBound, true
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/pipeline"
+	"github.com/m3db/m3/src/metrics/policy"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeHistogramBucketValue(t *testing.T) {
+	inputs := []struct {
+		value    string
+		expected string
+		valid    bool
+	}{
+		{value: "1", expected: "1", valid: true},
+		{value: "1.0", expected: "1", valid: true},
+		{value: "0.250", expected: "0.25", valid: true},
+		{value: "1e3", expected: "1000", valid: true},
+		{value: "+Inf", expected: "+Inf", valid: true},
+		{value: "Inf", expected: "+Inf", valid: true},
+		{value: "NaN", valid: false},
+		{value: "", valid: false},
+		{value: "foo", valid: false},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.value, func(t *testing.T) {
+			res, _, ok := normalizeHistogramBucketValue([]byte(input.value))
+			require.Equal(t, input.valid, ok)
+			if input.valid {
+				require.Equal(t, input.expected, string(res))
+			}
+		})
+	}
+}
+
+func TestActiveRuleSetHistogramBucketsRollup(t *testing.T) {
+	targets := []rollupTarget{
+		{
+			Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+				{
+					Type: pipeline.RollupOpType,
+					Rollup: pipeline.RollupOp{
+						NewName:       b("latency_bucket"),
+						Tags:          bs("le", "service"),
+						AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+						Type:          pipeline.HistogramBucketsRollupType,
+					},
+				},
+			}),
+			StoragePolicies: policy.StoragePolicies{
+				policy.NewStoragePolicy(10*time.Second, xtime.Second, 24*time.Hour),
+			},
+		},
+	}
+	as := newActiveRuleSet(0, nil, nil, testTagsFilterOptions(), mockNewID, nil)
+
+	inputs := []struct {
+		id       string
+		expected string
+	}{
+		{id: "instance=a,le=0.5,service=foo", expected: "latency_bucket|le=0.5,service=foo"},
+		{id: "instance=b,le=0.50,service=foo", expected: "latency_bucket|le=0.5,service=foo"},
+		{id: "instance=a,le=+Inf,service=foo", expected: "latency_bucket|le=+Inf,service=foo"},
+		{id: "instance=b,le=inf,service=foo", expected: "latency_bucket|le=+Inf,service=foo"},
+		{id: "instance=a,le=bar,service=foo"},
+		{id: "instance=a,service=foo"},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.id, func(t *testing.T) {
+			res, err := as.toRollupResults(b(input.id), 0, targets)
+			require.NoError(t, err)
+			if input.expected == "" {
+				require.Equal(t, 0, len(res.forNewRollupIDs))
+				return
+			}
+			require.Equal(t, 1, len(res.forNewRollupIDs))
+			require.Equal(t, input.expected, string(res.forNewRollupIDs[0].id))
+		})
+	}
+}
+
+func TestActiveRuleSetHistogramBucketsRollupWithBuckets(t *testing.T) {
+	targets := []rollupTarget{
+		{
+			Pipeline: pipeline.NewPipeline([]pipeline.OpUnion{
+				{
+					Type: pipeline.RollupOpType,
+					Rollup: pipeline.RollupOp{
+						NewName:          b("latency_bucket"),
+						Tags:             bs("le", "service"),
+						AggregationID:    aggregation.MustCompressTypes(aggregation.Sum),
+						Type:             pipeline.HistogramBucketsRollupType,
+						HistogramBuckets: []float64{0.5, 1},
+					},
+				},
+			}),
+			StoragePolicies: policy.StoragePolicies{
+				policy.NewStoragePolicy(10*time.Second, xtime.Second, 24*time.Hour),
+			},
+		},
+	}
+	as := newActiveRuleSet(0, nil, nil, testTagsFilterOptions(), mockNewID, nil)
+
+	inputs := []struct {
+		id       string
+		expected string
+	}{
+		{id: "instance=a,le=0.5,service=foo", expected: "latency_bucket|le=0.5,service=foo"},
+		{id: "instance=b,le=1.0,service=foo", expected: "latency_bucket|le=1,service=foo"},
+		{id: "instance=a,le=+Inf,service=foo", expected: "latency_bucket|le=+Inf,service=foo"},
+		// Buckets that differ from the boundaries of the rollup are not rolled up.
+		{id: "instance=c,le=0.25,service=foo"},
+		{id: "instance=c,le=2,service=foo"},
+	}
+
+	for _, input := range inputs {
+		t.Run(input.id, func(t *testing.T) {
+			res, err := as.toRollupResults(b(input.id), 0, targets)
+			require.NoError(t, err)
+			if input.expected == "" {
+				require.Equal(t, 0, len(res.forNewRollupIDs))
+				return
+			}
+			require.Equal(t, 1, len(res.forNewRollupIDs))
+			require.Equal(t, input.expected, string(res.forNewRollupIDs[0].id))
+		})
+	}
+}
@@ -45,3 +45,37 @@ func perSecond(prev, curr Datapoint) Datapoint {
 	rate := diff * float64(nanosPerSecond) / float64(curr.TimeNanos-prev.TimeNanos)
 	return Datapoint{TimeNanos: curr.TimeNanos, Value: rate}
 }
+
+// increase computes the difference between consecutive datapoints, handling
+// counter resets.
+// * It skips NaN values.
+// * It assumes the timestamps are monotonically increasing, otherwise an empty
+//   datapoint is returned.
+// * If the current value is less than the previous value, the counter is assumed
+//   to have been reset and the current value is returned as the increase.
+func increase(prev, curr Datapoint) Datapoint {
+	if prev.TimeNanos >= curr.TimeNanos || math.IsNaN(prev.Value) || math.IsNaN(curr.Value) {
+		return emptyDatapoint
+	}
+	diff := curr.Value - prev.Value
+	if diff < 0 {
+		diff = curr.Value
+	}
+	return Datapoint{TimeNanos: curr.TimeNanos, Value: diff}
+}
+
+// add adds the current datapoint value to the previous result, producing a
+// running total. The previous datapoint is expected to be the previous output
+// of this transformation rather than the previous input.
+// * NaN values are treated as zero, and the previous result is returned as is
+//   if the current value is NaN.
+func add(prev, curr Datapoint) Datapoint {
+	total := prev.Value
+	if math.IsNaN(total) {
+		total = 0
+	}
+	if !math.IsNaN(curr.Value) {
+		total += curr.Value
+	}
+	return Datapoint{TimeNanos: curr.TimeNanos, Value: total}
+}
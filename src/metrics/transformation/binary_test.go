@@ -79,3 +79,74 @@ func TestPerSecond(t *testing.T) {
 		}
 	}
 }
+
+func TestIncrease(t *testing.T) {
+	inputs := []struct {
+		prev        Datapoint
+		curr        Datapoint
+		expectedNaN bool
+		expected    Datapoint
+	}{
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 30},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 5},
+		},
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 30},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 20},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 20},
+		},
+		{
+			prev:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25},
+			curr:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 30},
+			expectedNaN: true,
+		},
+		{
+			prev:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: math.NaN()},
+			curr:        Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 20},
+			expectedNaN: true,
+		},
+		{
+			prev:        Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 20},
+			curr:        Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: math.NaN()},
+			expectedNaN: true,
+		},
+	}
+
+	for _, input := range inputs {
+		if input.expectedNaN {
+			require.True(t, increase(input.prev, input.curr).IsEmpty())
+		} else {
+			require.Equal(t, input.expected, increase(input.prev, input.curr))
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	inputs := []struct {
+		prev     Datapoint
+		curr     Datapoint
+		expected Datapoint
+	}{
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 5},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 30},
+		},
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: math.NaN()},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 5},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 5},
+		},
+		{
+			prev:     Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25},
+			curr:     Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: math.NaN()},
+			expected: Datapoint{TimeNanos: time.Unix(1240, 0).UnixNano(), Value: 25},
+		},
+	}
+
+	for _, input := range inputs {
+		require.Equal(t, input.expected, add(input.prev, input.curr))
+	}
+}
@@ -20,7 +20,10 @@
 
 package transformation
 
-import "math"
+import (
+	"math"
+	"time"
+)
 
 var (
 	emptyDatapoint = Datapoint{Value: math.NaN()}
@@ -44,3 +47,9 @@ type UnaryTransform func(dp Datapoint) Datapoint
 // previous and the current datapoint as input and produces
 // a single datapoint as the transformation result.
 type BinaryTransform func(prev, curr Datapoint) Datapoint
+
+// UnaryMultiOutputTransform is a unary transformation that takes a single
+// datapoint as input and transforms it into two datapoints as output. The
+// resolution of the storage policy the datapoint belongs to is provided so
+// the second datapoint can be placed in between the current and next datapoint.
+type UnaryMultiOutputTransform func(dp Datapoint, resolution time.Duration) (Datapoint, Datapoint)
@@ -34,11 +34,14 @@ const (
 	UnknownType Type = iota
 	Absolute
 	PerSecond
+	Increase
+	Add
+	Reset
 )
 
 // IsValid checks if the transformation type is valid.
 func (t Type) IsValid() bool {
-	return t.IsUnaryTransform() || t.IsBinaryTransform()
+	return t.IsUnaryTransform() || t.IsBinaryTransform() || t.IsUnaryMultiOutputTransform()
 }
 
 // IsUnaryTransform returns whether this is a unary transformation.
@@ -53,6 +56,20 @@ func (t Type) IsBinaryTransform() bool {
 	return exists
 }
 
+// IsUnaryMultiOutputTransform returns whether this is a unary transformation
+// that produces multiple outputs.
+func (t Type) IsUnaryMultiOutputTransform() bool {
+	_, exists := unaryMultiOutputTransforms[t]
+	return exists
+}
+
+// IsCumulativeTransform returns whether this is a binary transformation whose
+// previous datapoint is its own previous result rather than the previous input.
+func (t Type) IsCumulativeTransform() bool {
+	_, exists := cumulativeTransforms[t]
+	return exists
+}
+
 // UnaryTransform returns the unary transformation function associated with
 // the transformation type if applicable, or an error otherwise.
 func (t Type) UnaryTransform() (UnaryTransform, error) {
@@ -93,6 +110,26 @@ func (t Type) MustBinaryTransform() BinaryTransform {
 	return tf
 }
 
+// UnaryMultiOutputTransform returns the unary multi-output transformation function
+// associated with the transformation type if applicable, or an error otherwise.
+func (t Type) UnaryMultiOutputTransform() (UnaryMultiOutputTransform, error) {
+	tf, exists := unaryMultiOutputTransforms[t]
+	if !exists {
+		return nil, fmt.Errorf("%v is not a unary multi-output transformation", t)
+	}
+	return tf, nil
+}
+
+// MustUnaryMultiOutputTransform returns the unary multi-output transformation
+// function associated with the transformation type if applicable, or panics otherwise.
+func (t Type) MustUnaryMultiOutputTransform() UnaryMultiOutputTransform {
+	tf, err := t.UnaryMultiOutputTransform()
+	if err != nil {
+		panic(err)
+	}
+	return tf
+}
+
 // ToProto converts the transformation type to a protobuf message in place.
 func (t Type) ToProto(pb *transformationpb.TransformationType) error {
 	switch t {
@@ -100,6 +137,12 @@ func (t Type) ToProto(pb *transformationpb.TransformationType) error {
 		*pb = transformationpb.TransformationType_ABSOLUTE
 	case PerSecond:
 		*pb = transformationpb.TransformationType_PERSECOND
+	case Increase:
+		*pb = transformationpb.TransformationType_INCREASE
+	case Add:
+		*pb = transformationpb.TransformationType_ADD
+	case Reset:
+		*pb = transformationpb.TransformationType_RESET
 	default:
 		return fmt.Errorf("unknown transformation type: %v", t)
 	}
@@ -113,6 +156,12 @@ func (t *Type) FromProto(pb transformationpb.TransformationType) error {
 		*t = Absolute
 	case transformationpb.TransformationType_PERSECOND:
 		*t = PerSecond
+	case transformationpb.TransformationType_INCREASE:
+		*t = Increase
+	case transformationpb.TransformationType_ADD:
+		*t = Add
+	case transformationpb.TransformationType_RESET:
+		*t = Reset
 	default:
 		return fmt.Errorf("unknown transformation type in proto: %v", pb)
 	}
@@ -166,6 +215,14 @@ var (
 	}
 	binaryTransforms = map[Type]BinaryTransform{
 		PerSecond: perSecond,
+		Increase:  increase,
+		Add:       add,
+	}
+	unaryMultiOutputTransforms = map[Type]UnaryMultiOutputTransform{
+		Reset: reset,
+	}
+	cumulativeTransforms = map[Type]struct{}{
+		Add: {},
 	}
 	typeStringMap map[string]Type
 )
@@ -178,4 +235,7 @@ func init() {
 	for t := range binaryTransforms {
 		typeStringMap[t.String()] = t
 	}
+	for t := range unaryMultiOutputTransforms {
+		typeStringMap[t.String()] = t
+	}
 }
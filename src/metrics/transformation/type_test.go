@@ -46,6 +46,8 @@ func TestIsUnaryTransform(t *testing.T) {
 		{typ: Absolute, expected: true},
 		{typ: UnknownType, expected: false},
 		{typ: PerSecond, expected: false},
+		{typ: Increase, expected: false},
+		{typ: Reset, expected: false},
 		{typ: Type(10000), expected: false},
 	}
 
@@ -60,8 +62,11 @@ func TestIsBinaryTransform(t *testing.T) {
 		expected bool
 	}{
 		{typ: PerSecond, expected: true},
+		{typ: Increase, expected: true},
+		{typ: Add, expected: true},
 		{typ: UnknownType, expected: false},
 		{typ: Absolute, expected: false},
+		{typ: Reset, expected: false},
 		{typ: Type(10000), expected: false},
 	}
 
@@ -70,6 +75,40 @@ func TestIsBinaryTransform(t *testing.T) {
 	}
 }
 
+func TestIsUnaryMultiOutputTransform(t *testing.T) {
+	inputs := []struct {
+		typ      Type
+		expected bool
+	}{
+		{typ: Reset, expected: true},
+		{typ: UnknownType, expected: false},
+		{typ: Absolute, expected: false},
+		{typ: PerSecond, expected: false},
+		{typ: Type(10000), expected: false},
+	}
+
+	for _, input := range inputs {
+		require.Equal(t, input.expected, input.typ.IsUnaryMultiOutputTransform())
+	}
+}
+
+func TestIsCumulativeTransform(t *testing.T) {
+	inputs := []struct {
+		typ      Type
+		expected bool
+	}{
+		{typ: Add, expected: true},
+		{typ: PerSecond, expected: false},
+		{typ: Increase, expected: false},
+		{typ: Reset, expected: false},
+		{typ: Type(10000), expected: false},
+	}
+
+	for _, input := range inputs {
+		require.Equal(t, input.expected, input.typ.IsCumulativeTransform())
+	}
+}
+
 func TestUnaryTransform(t *testing.T) {
 	inputs := []Type{
 		Absolute,
@@ -125,6 +164,8 @@ func TestMustUnaryTransformPanics(t *testing.T) {
 func TestBinaryTransform(t *testing.T) {
 	inputs := []Type{
 		PerSecond,
+		Increase,
+		Add,
 	}
 
 	for _, input := range inputs {
@@ -174,6 +215,29 @@ func TestMustBinaryTransformPanics(t *testing.T) {
 	}
 }
 
+func TestUnaryMultiOutputTransform(t *testing.T) {
+	tf, err := Reset.UnaryMultiOutputTransform()
+	require.NoError(t, err)
+	require.NotNil(t, tf)
+	require.NotPanics(t, func() { Reset.MustUnaryMultiOutputTransform() })
+}
+
+func TestUnaryMultiOutputTransformErrors(t *testing.T) {
+	inputs := []Type{
+		UnknownType,
+		Absolute,
+		PerSecond,
+		Type(10000),
+	}
+
+	for _, input := range inputs {
+		tf, err := input.UnaryMultiOutputTransform()
+		require.Error(t, err)
+		require.Nil(t, tf)
+		require.Panics(t, func() { input.MustUnaryMultiOutputTransform() })
+	}
+}
+
 func TestTypeString(t *testing.T) {
 	inputs := []struct {
 		typ      Type
@@ -182,6 +246,9 @@ func TestTypeString(t *testing.T) {
 		{typ: UnknownType, expected: "UnknownType"},
 		{typ: Absolute, expected: "Absolute"},
 		{typ: PerSecond, expected: "PerSecond"},
+		{typ: Increase, expected: "Increase"},
+		{typ: Add, expected: "Add"},
+		{typ: Reset, expected: "Reset"},
 		{typ: Type(1000), expected: "Type(1000)"},
 	}
 
@@ -222,6 +289,18 @@ func TestTypeRoundTripProto(t *testing.T) {
 	require.Equal(t, testType, res)
 }
 
+func TestTypeRoundTripProtoAllTypes(t *testing.T) {
+	for _, typ := range []Type{Absolute, PerSecond, Increase, Add, Reset} {
+		var (
+			pb  transformationpb.TransformationType
+			res Type
+		)
+		require.NoError(t, typ.ToProto(&pb))
+		require.NoError(t, res.FromProto(pb))
+		require.Equal(t, typ, res)
+	}
+}
+
 func TestTypeMarshalling(t *testing.T) {
 	cases := []struct {
 		Example      Type
@@ -230,6 +309,12 @@ func TestTypeMarshalling(t *testing.T) {
 	}{{
 		Example: Absolute,
 		Text:    "Absolute",
+	}, {
+		Example: Increase,
+		Text:    "Increase",
+	}, {
+		Example: Reset,
+		Text:    "Reset",
 	}}
 
 	t.Run("roundtrips", func(t *testing.T) {
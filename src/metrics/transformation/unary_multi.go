@@ -0,0 +1,37 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transformation
+
+import "time"
+
+// reset returns the datapoint as is, along with a zero valued datapoint
+// halfway between the datapoint and the next timestamp aligned to the
+// resolution. This allows downstream consumers to observe an explicit reset
+// after each value, without the reset sharing a timestamp or a window with
+// the next value.
+func reset(dp Datapoint, resolution time.Duration) (Datapoint, Datapoint) {
+	if resolution <= 0 {
+		return dp, Datapoint{TimeNanos: dp.TimeNanos, Value: 0}
+	}
+	res := int64(resolution)
+	next := dp.TimeNanos - dp.TimeNanos%res + res
+	return dp, Datapoint{TimeNanos: dp.TimeNanos + (next-dp.TimeNanos)/2, Value: 0}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transformation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReset(t *testing.T) {
+	dp := Datapoint{TimeNanos: time.Unix(1230, 0).UnixNano(), Value: 25}
+	res, other := reset(dp, 10*time.Second)
+	require.Equal(t, dp, res)
+	require.Equal(t, Datapoint{TimeNanos: time.Unix(1235, 0).UnixNano(), Value: 0}, other)
+}
+
+func TestResetUnaligned(t *testing.T) {
+	dp := Datapoint{TimeNanos: time.Unix(1234, 0).UnixNano(), Value: 25}
+	res, other := reset(dp, 10*time.Second)
+	require.Equal(t, dp, res)
+	require.Equal(t, Datapoint{TimeNanos: time.Unix(1237, 0).UnixNano(), Value: 0}, other)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package offset
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultCommitInterval = 10 * time.Second
+)
+
+var (
+	errNoTopicName           = errors.New("no topic name")
+	errNoProducerID          = errors.New("no producer id")
+	errNoOffsetService       = errors.New("no offset service")
+	errInvalidCommitInterval = errors.New("invalid offset commit interval")
+)
+
+type serviceOptions struct {
+	configServiceClient client.Client
+	kvOpts              kv.OverrideOptions
+}
+
+// NewServiceOptions returns new ServiceOptions.
+func NewServiceOptions() ServiceOptions {
+	return &serviceOptions{
+		kvOpts: kv.NewOverrideOptions(),
+	}
+}
+
+func (opts *serviceOptions) ConfigService() client.Client {
+	return opts.configServiceClient
+}
+
+func (opts *serviceOptions) SetConfigService(c client.Client) ServiceOptions {
+	o := *opts
+	o.configServiceClient = c
+	return &o
+}
+
+func (opts *serviceOptions) KVOverrideOptions() kv.OverrideOptions {
+	return opts.kvOpts
+}
+
+func (opts *serviceOptions) SetKVOverrideOptions(value kv.OverrideOptions) ServiceOptions {
+	o := *opts
+	o.kvOpts = value
+	return &o
+}
+
+type trackerOptions struct {
+	topicName      string
+	producerID     string
+	service        Service
+	commitInterval time.Duration
+	iOpts          instrument.Options
+}
+
+// NewTrackerOptions returns new TrackerOptions.
+func NewTrackerOptions() TrackerOptions {
+	return &trackerOptions{
+		commitInterval: defaultCommitInterval,
+		iOpts:          instrument.NewOptions(),
+	}
+}
+
+func (opts *trackerOptions) TopicName() string {
+	return opts.topicName
+}
+
+func (opts *trackerOptions) SetTopicName(value string) TrackerOptions {
+	o := *opts
+	o.topicName = value
+	return &o
+}
+
+func (opts *trackerOptions) ProducerID() string {
+	return opts.producerID
+}
+
+func (opts *trackerOptions) SetProducerID(value string) TrackerOptions {
+	o := *opts
+	o.producerID = value
+	return &o
+}
+
+func (opts *trackerOptions) OffsetService() Service {
+	return opts.service
+}
+
+func (opts *trackerOptions) SetOffsetService(value Service) TrackerOptions {
+	o := *opts
+	o.service = value
+	return &o
+}
+
+func (opts *trackerOptions) CommitInterval() time.Duration {
+	return opts.commitInterval
+}
+
+func (opts *trackerOptions) SetCommitInterval(value time.Duration) TrackerOptions {
+	o := *opts
+	o.commitInterval = value
+	return &o
+}
+
+func (opts *trackerOptions) InstrumentOptions() instrument.Options {
+	return opts.iOpts
+}
+
+func (opts *trackerOptions) SetInstrumentOptions(value instrument.Options) TrackerOptions {
+	o := *opts
+	o.iOpts = value
+	return &o
+}
+
+func (opts *trackerOptions) Validate() error {
+	if opts.TopicName() == "" {
+		return errNoTopicName
+	}
+	if opts.ProducerID() == "" {
+		return errNoProducerID
+	}
+	if opts.OffsetService() == nil {
+		return errNoOffsetService
+	}
+	if opts.CommitInterval() <= 0 {
+		return errInvalidCommitInterval
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package offset
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/msg/generated/proto/offsetpb"
+)
+
+const (
+	// NB: Producers of the same topic commit to the same key concurrently.
+	maxCommitAttempts = 5
+)
+
+var (
+	defaultNamespace     = "/topic_offset"
+	errNoConsumerService = errors.New("no consumer service")
+	errResetNotAvailable = errors.New("offset reset is not available")
+	errCommitNoProgress  = errors.New("could not commit offsets due to concurrent commits")
+)
+
+type service struct {
+	store kv.Store
+}
+
+// NewService creates an offset service.
+func NewService(sOpts ServiceOptions) (Service, error) {
+	kvOpts := sanitizeKVOptions(sOpts.KVOverrideOptions())
+	store, err := sOpts.ConfigService().Store(kvOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &service{
+		store: store,
+	}, nil
+}
+
+func (s *service) Get(topicName, consumerService string) (*offsetpb.ConsumerServiceOffsets, error) {
+	offsets, _, err := s.get(offsetKey(topicName, consumerService))
+	if err != nil {
+		return nil, err
+	}
+	return offsets, nil
+}
+
+func (s *service) get(key string) (*offsetpb.ConsumerServiceOffsets, int, error) {
+	value, err := s.store.Get(key)
+	if err != nil {
+		return nil, 0, err
+	}
+	var offsets offsetpb.ConsumerServiceOffsets
+	if err := value.Unmarshal(&offsets); err != nil {
+		return nil, 0, err
+	}
+	return &offsets, value.Version(), nil
+}
+
+func (s *service) Commit(
+	topicName string,
+	consumerService string,
+	offsets *offsetpb.ProducerOffsets,
+) error {
+	if consumerService == "" {
+		return errNoConsumerService
+	}
+	if offsets.ProducerId == "" {
+		return errNoProducerID
+	}
+	key := offsetKey(topicName, consumerService)
+	for i := 0; i < maxCommitAttempts; i++ {
+		existing, version, err := s.get(key)
+		if err == kv.ErrNotFound {
+			existing, version, err = &offsetpb.ConsumerServiceOffsets{}, kv.UninitializedVersion, nil
+		}
+		if err != nil {
+			return err
+		}
+		_, err = s.store.CheckAndSet(key, version, mergeOffsets(existing, offsets))
+		if err != kv.ErrVersionMismatch {
+			return err
+		}
+	}
+	return errCommitNoProgress
+}
+
+func (s *service) GetReset(topicName, consumerService string) (*offsetpb.Reset, int, error) {
+	value, err := s.store.Get(resetKey(topicName, consumerService))
+	if err != nil {
+		return nil, 0, err
+	}
+	return newResetFromValue(value)
+}
+
+func (s *service) Reset(topicName string, reset *offsetpb.Reset) error {
+	if reset.ConsumerService == "" {
+		return errNoConsumerService
+	}
+	_, err := s.store.Set(resetKey(topicName, reset.ConsumerService), reset)
+	return err
+}
+
+func (s *service) WatchReset(topicName, consumerService string) (ResetWatch, error) {
+	w, err := s.store.Watch(resetKey(topicName, consumerService))
+	if err != nil {
+		return nil, err
+	}
+	return &resetWatch{w}, nil
+}
+
+// mergeOffsets replaces the offsets of the producer in the existing offsets.
+func mergeOffsets(
+	existing *offsetpb.ConsumerServiceOffsets,
+	offsets *offsetpb.ProducerOffsets,
+) *offsetpb.ConsumerServiceOffsets {
+	for i, producerOffsets := range existing.ProducerOffsets {
+		if producerOffsets.ProducerId == offsets.ProducerId {
+			existing.ProducerOffsets[i] = offsets
+			return existing
+		}
+	}
+	existing.ProducerOffsets = append(existing.ProducerOffsets, offsets)
+	return existing
+}
+
+func offsetKey(topicName, consumerService string) string {
+	return fmt.Sprintf("offsets/%s/%s", topicName, consumerService)
+}
+
+// resetKey is per consumer service so that resetting one consumer service
+// never overwrites a reset of another one before its producers applied it.
+func resetKey(topicName, consumerService string) string {
+	return fmt.Sprintf("resets/%s/%s", topicName, consumerService)
+}
+
+func sanitizeKVOptions(opts kv.OverrideOptions) kv.OverrideOptions {
+	if opts.Namespace() == "" {
+		opts = opts.SetNamespace(defaultNamespace)
+	}
+	return opts
+}
+
+func newResetFromValue(value kv.Value) (*offsetpb.Reset, int, error) {
+	var reset offsetpb.Reset
+	if err := value.Unmarshal(&reset); err != nil {
+		return nil, 0, err
+	}
+	return &reset, value.Version(), nil
+}
+
+type resetWatch struct {
+	kv.ValueWatch
+}
+
+func (w *resetWatch) Get() (*offsetpb.Reset, int, error) {
+	value := w.ValueWatch.Get()
+	if value == nil {
+		return nil, 0, errResetNotAvailable
+	}
+	return newResetFromValue(value)
+}
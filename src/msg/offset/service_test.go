@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package offset
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/msg/generated/proto/offsetpb"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffsetServiceCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newTestService(t, ctrl, mem.NewStore())
+
+	_, err := s.Get("topic", "svc")
+	require.Equal(t, kv.ErrNotFound, err)
+
+	require.Equal(t, errNoConsumerService, s.Commit("topic", "", &offsetpb.ProducerOffsets{ProducerId: "p1"}))
+	require.Equal(t, errNoProducerID, s.Commit("topic", "svc", &offsetpb.ProducerOffsets{}))
+
+	p1 := &offsetpb.ProducerOffsets{
+		ProducerId:   "p1",
+		ShardOffsets: []*offsetpb.ShardOffset{{Shard: 0, Offset: 10}},
+	}
+	p2 := &offsetpb.ProducerOffsets{
+		ProducerId:   "p2",
+		ShardOffsets: []*offsetpb.ShardOffset{{Shard: 1, Offset: 20}},
+	}
+	require.NoError(t, s.Commit("topic", "svc", p1))
+	require.NoError(t, s.Commit("topic", "svc", p2))
+
+	// Committing again replaces the offsets of the producer.
+	p1 = &offsetpb.ProducerOffsets{
+		ProducerId:   "p1",
+		ShardOffsets: []*offsetpb.ShardOffset{{Shard: 0, Offset: 15}},
+	}
+	require.NoError(t, s.Commit("topic", "svc", p1))
+
+	offsets, err := s.Get("topic", "svc")
+	require.NoError(t, err)
+	require.Equal(t, &offsetpb.ConsumerServiceOffsets{
+		ProducerOffsets: []*offsetpb.ProducerOffsets{p1, p2},
+	}, offsets)
+
+	_, err = s.Get("topic", "other")
+	require.Equal(t, kv.ErrNotFound, err)
+}
+
+func TestOffsetServiceReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newTestService(t, ctrl, mem.NewStore())
+
+	_, _, err := s.GetReset("topic", "svc")
+	require.Equal(t, kv.ErrNotFound, err)
+	require.Equal(t, errNoConsumerService, s.Reset("topic", &offsetpb.Reset{}))
+
+	w, err := s.WatchReset("topic", "svc")
+	require.NoError(t, err)
+	defer w.Close()
+	require.Equal(t, 0, len(w.C()))
+
+	reset := &offsetpb.Reset{ConsumerService: "svc", Offset: 10}
+	require.NoError(t, s.Reset("topic", reset))
+	// Resets of other consumer services are kept separately.
+	other := &offsetpb.Reset{ConsumerService: "other", Offset: 20}
+	require.NoError(t, s.Reset("topic", other))
+
+	<-w.C()
+	watched, version, err := w.Get()
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+	require.Equal(t, reset, watched)
+
+	latest, version, err := s.GetReset("topic", "svc")
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+	require.Equal(t, reset, latest)
+
+	latest, _, err = s.GetReset("topic", "other")
+	require.NoError(t, err)
+	require.Equal(t, other, latest)
+}
+
+func newTestService(t *testing.T, ctrl *gomock.Controller, store kv.Store) Service {
+	kvOpts := kv.NewOverrideOptions().SetNamespace("foo")
+	cs := client.NewMockClient(ctrl)
+	cs.EXPECT().Store(kvOpts).Return(store, nil)
+
+	s, err := NewService(NewServiceOptions().SetConfigService(cs).SetKVOverrideOptions(kvOpts))
+	require.NoError(t, err)
+	return s
+}
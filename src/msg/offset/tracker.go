@@ -0,0 +1,290 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package offset
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/msg/generated/proto/offsetpb"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+var (
+	errTrackerAlreadyInitialized = errors.New("offset tracker already initialized")
+	errTrackerClosed             = errors.New("offset tracker closed")
+)
+
+type trackerMetrics struct {
+	commitSuccess tally.Counter
+	commitError   tally.Counter
+	resetSuccess  tally.Counter
+	resetError    tally.Counter
+}
+
+func newTrackerMetrics(scope tally.Scope) trackerMetrics {
+	return trackerMetrics{
+		commitSuccess: scope.Counter("commit-success"),
+		commitError:   scope.Counter("commit-error"),
+		resetSuccess:  scope.Counter("reset-success"),
+		resetError:    scope.Counter("reset-error"),
+	}
+}
+
+// tracker keeps the highest offset acknowledged for every shard of every
+// consumer service in memory and commits the ones that changed since the
+// last commit on every tick.
+type tracker struct {
+	sync.Mutex
+
+	opts    TrackerOptions
+	service Service
+	logger  *zap.Logger
+	nowFn   func() time.Time
+
+	offsets     map[string]map[uint32]uint64
+	dirty       map[string]struct{}
+	watches     map[string]ResetWatch
+	resetFn     ResetFn
+	initialized bool
+	closed      bool
+	doneCh      chan struct{}
+	wg          sync.WaitGroup
+	m           trackerMetrics
+}
+
+// NewTracker creates a new offset tracker.
+func NewTracker(opts TrackerOptions) (Tracker, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &tracker{
+		opts:    opts,
+		service: opts.OffsetService(),
+		logger:  opts.InstrumentOptions().Logger(),
+		nowFn:   time.Now,
+		offsets: make(map[string]map[uint32]uint64),
+		dirty:   make(map[string]struct{}),
+		watches: make(map[string]ResetWatch),
+		doneCh:  make(chan struct{}),
+		m: newTrackerMetrics(
+			opts.InstrumentOptions().MetricsScope().SubScope("offset-tracker"),
+		),
+	}, nil
+}
+
+func (t *tracker) Ack(consumerService string, shard uint32, offset uint64) {
+	t.Lock()
+	shards, ok := t.offsets[consumerService]
+	if !ok {
+		shards = make(map[uint32]uint64)
+		t.offsets[consumerService] = shards
+	}
+	if current, ok := shards[shard]; !ok || offset > current {
+		shards[shard] = offset
+		t.dirty[consumerService] = struct{}{}
+	}
+	t.Unlock()
+}
+
+func (t *tracker) Init(fn ResetFn) error {
+	t.Lock()
+	defer t.Unlock()
+	if t.initialized {
+		return errTrackerAlreadyInitialized
+	}
+	if t.closed {
+		return errTrackerClosed
+	}
+
+	t.resetFn = fn
+	for consumerService := range t.watches {
+		if err := t.watchWithLock(consumerService); err != nil {
+			return err
+		}
+	}
+	t.initialized = true
+
+	t.wg.Add(1)
+	go func() {
+		t.commitUntilClose()
+		t.wg.Done()
+	}()
+	return nil
+}
+
+func (t *tracker) Watch(consumerService string) error {
+	t.Lock()
+	defer t.Unlock()
+	if t.closed {
+		return errTrackerClosed
+	}
+	if _, ok := t.watches[consumerService]; ok {
+		return nil
+	}
+	if !t.initialized {
+		// NB: Watched once the reset function is known.
+		t.watches[consumerService] = nil
+		return nil
+	}
+	return t.watchWithLock(consumerService)
+}
+
+func (t *tracker) watchWithLock(consumerService string) error {
+	// Only the resets requested after the consumer service is watched are
+	// applied, the latest reset has been applied by a previous process already.
+	_, initVersion, err := t.service.GetReset(t.opts.TopicName(), consumerService)
+	if err != nil && err != kv.ErrNotFound {
+		return err
+	}
+	w, err := t.service.WatchReset(t.opts.TopicName(), consumerService)
+	if err != nil {
+		return err
+	}
+	t.watches[consumerService] = w
+
+	t.wg.Add(1)
+	go func() {
+		t.resetUntilClose(w, t.resetFn, initVersion)
+		t.wg.Done()
+	}()
+	return nil
+}
+
+func (t *tracker) commitUntilClose() {
+	ticker := time.NewTicker(t.opts.CommitInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.commit()
+		case <-t.doneCh:
+			return
+		}
+	}
+}
+
+func (t *tracker) commit() {
+	t.Lock()
+	var (
+		nowNanos = t.nowFn().UnixNano()
+		toCommit = make(map[string]*offsetpb.ProducerOffsets, len(t.dirty))
+	)
+	for consumerService := range t.dirty {
+		shards := t.offsets[consumerService]
+		offsets := &offsetpb.ProducerOffsets{
+			ProducerId:       t.opts.ProducerID(),
+			ShardOffsets:     make([]*offsetpb.ShardOffset, 0, len(shards)),
+			CommittedAtNanos: nowNanos,
+		}
+		for shard, offset := range shards {
+			offsets.ShardOffsets = append(offsets.ShardOffsets, &offsetpb.ShardOffset{
+				Shard:  shard,
+				Offset: offset,
+			})
+		}
+		sort.Slice(offsets.ShardOffsets, func(i, j int) bool {
+			return offsets.ShardOffsets[i].Shard < offsets.ShardOffsets[j].Shard
+		})
+		toCommit[consumerService] = offsets
+		delete(t.dirty, consumerService)
+	}
+	t.Unlock()
+
+	for consumerService, offsets := range toCommit {
+		if err := t.service.Commit(t.opts.TopicName(), consumerService, offsets); err != nil {
+			t.m.commitError.Inc(1)
+			t.logger.Error("could not commit offsets",
+				zap.String("consumerService", consumerService), zap.Error(err))
+			// Retry on the next tick.
+			t.Lock()
+			t.dirty[consumerService] = struct{}{}
+			t.Unlock()
+			continue
+		}
+		t.m.commitSuccess.Inc(1)
+	}
+}
+
+func (t *tracker) resetUntilClose(w ResetWatch, fn ResetFn, lastVersion int) {
+	for {
+		select {
+		case <-w.C():
+			reset, version, err := w.Get()
+			if err != nil {
+				t.logger.Error("invalid offset reset update", zap.Error(err))
+				continue
+			}
+			if version <= lastVersion {
+				continue
+			}
+			lastVersion = version
+			if reset.ProducerId != "" && reset.ProducerId != t.opts.ProducerID() {
+				continue
+			}
+			t.logger.Info("rewinding consumer service",
+				zap.String("consumerService", reset.ConsumerService),
+				zap.Uint64("offset", reset.Offset),
+				zap.Int64("timestampNanos", reset.TimestampNanos))
+			if err := fn(reset); err != nil {
+				t.m.resetError.Inc(1)
+				t.logger.Error("could not rewind consumer service",
+					zap.String("consumerService", reset.ConsumerService), zap.Error(err))
+				continue
+			}
+			t.m.resetSuccess.Inc(1)
+		case <-t.doneCh:
+			return
+		}
+	}
+}
+
+func (t *tracker) Close() {
+	t.Lock()
+	if t.closed {
+		t.Unlock()
+		return
+	}
+	t.closed = true
+	initialized := t.initialized
+	watches := make([]ResetWatch, 0, len(t.watches))
+	for _, w := range t.watches {
+		if w != nil {
+			watches = append(watches, w)
+		}
+	}
+	t.Unlock()
+
+	close(t.doneCh)
+	for _, w := range watches {
+		w.Close()
+	}
+	t.wg.Wait()
+	if !initialized {
+		return
+	}
+	t.commit()
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package offset
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/msg/generated/proto/offsetpb"
+
+	"github.com/fortytw2/leaktest"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackerOptionsValidation(t *testing.T) {
+	opts := NewTrackerOptions()
+	require.Equal(t, errNoTopicName, opts.Validate())
+
+	opts = opts.SetTopicName("topic")
+	require.Equal(t, errNoProducerID, opts.Validate())
+
+	opts = opts.SetProducerID("p1")
+	require.Equal(t, errNoOffsetService, opts.Validate())
+
+	opts = opts.SetOffsetService(&service{}).SetCommitInterval(0)
+	require.Equal(t, errInvalidCommitInterval, opts.Validate())
+}
+
+func TestTrackerCommit(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newTestService(t, ctrl, mem.NewStore())
+	tr, err := NewTracker(testTrackerOptions(s))
+	require.NoError(t, err)
+	require.NoError(t, tr.Init(func(*offsetpb.Reset) error { return nil }))
+	require.Equal(t, errTrackerAlreadyInitialized, tr.Init(nil))
+
+	tr.Ack("svc", 1, 10)
+	tr.Ack("svc", 1, 5)
+	tr.Ack("svc", 0, 3)
+
+	var offsets *offsetpb.ConsumerServiceOffsets
+	for {
+		offsets, err = s.Get("topic", "svc")
+		if err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Len(t, offsets.ProducerOffsets, 1)
+	require.Equal(t, "p1", offsets.ProducerOffsets[0].ProducerId)
+	require.Equal(t, []*offsetpb.ShardOffset{
+		{Shard: 0, Offset: 3},
+		{Shard: 1, Offset: 10},
+	}, offsets.ProducerOffsets[0].ShardOffsets)
+
+	// Outstanding offsets are committed on close.
+	tr.Ack("svc", 1, 11)
+	tr.Close()
+	offsets, err = s.Get("topic", "svc")
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), offsets.ProducerOffsets[0].ShardOffsets[1].Offset)
+}
+
+func TestTrackerReset(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newTestService(t, ctrl, mem.NewStore())
+	// The reset requested before the tracker is initialized is not applied.
+	require.NoError(t, s.Reset("topic", &offsetpb.Reset{ConsumerService: "svc", Offset: 1}))
+
+	tr, err := NewTracker(testTrackerOptions(s))
+	require.NoError(t, err)
+	// Consumer services watched before init are watched once initialized.
+	require.NoError(t, tr.Watch("svc"))
+	resetCh := make(chan *offsetpb.Reset, 3)
+	require.NoError(t, tr.Init(func(reset *offsetpb.Reset) error {
+		resetCh <- reset
+		if reset.Offset == 3 {
+			return errors.New("rewind error")
+		}
+		return nil
+	}))
+	defer tr.Close()
+	require.NoError(t, tr.Watch("svc"))
+
+	// Resets for other producers and unwatched consumer services are skipped.
+	require.NoError(t, s.Reset("topic", &offsetpb.Reset{ConsumerService: "other", Offset: 2}))
+	require.NoError(t, s.Reset("topic", &offsetpb.Reset{ConsumerService: "svc", ProducerId: "p2", Offset: 2}))
+	require.NoError(t, s.Reset("topic", &offsetpb.Reset{ConsumerService: "svc", Offset: 3}))
+	require.NoError(t, s.Reset("topic", &offsetpb.Reset{ConsumerService: "svc", ProducerId: "p1", Offset: 4}))
+
+	// NB: The watch could coalesce the notifications of consecutive resets.
+	for {
+		var reset *offsetpb.Reset
+		select {
+		case reset = <-resetCh:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for resets")
+		}
+		require.NotEqual(t, uint64(1), reset.Offset)
+		require.NotEqual(t, uint64(2), reset.Offset)
+		if reset.Offset == 4 {
+			break
+		}
+	}
+}
+
+func testTrackerOptions(s Service) TrackerOptions {
+	return NewTrackerOptions().
+		SetTopicName("topic").
+		SetProducerID("p1").
+		SetOffsetService(s).
+		SetCommitInterval(10 * time.Millisecond)
+}
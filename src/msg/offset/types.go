@@ -0,0 +1,138 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package offset
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/msg/generated/proto/offsetpb"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Service persists the offsets committed for the consumer services of the
+// topics and the resets requested for them.
+type Service interface {
+	// Get returns the offsets committed for the consumer service of the topic.
+	Get(topicName, consumerService string) (*offsetpb.ConsumerServiceOffsets, error)
+
+	// Commit commits the offsets of a producer for the consumer service of the
+	// topic, replacing the offsets previously committed by the producer.
+	Commit(topicName, consumerService string, offsets *offsetpb.ProducerOffsets) error
+
+	// GetReset returns the latest reset requested for the consumer service of
+	// the topic and its version.
+	GetReset(topicName, consumerService string) (*offsetpb.Reset, int, error)
+
+	// Reset requests the producers of the topic to rewind a consumer service.
+	Reset(topicName string, reset *offsetpb.Reset) error
+
+	// WatchReset returns a watch on the resets requested for the consumer
+	// service of the topic.
+	WatchReset(topicName, consumerService string) (ResetWatch, error)
+}
+
+// ResetWatch watches the resets requested for a consumer service of a topic.
+type ResetWatch interface {
+	// C returns the notification channel.
+	C() <-chan struct{}
+
+	// Get returns the latest reset and its version.
+	Get() (*offsetpb.Reset, int, error)
+
+	// Close stops watching for resets.
+	Close()
+}
+
+// ServiceOptions configures the offset service.
+type ServiceOptions interface {
+	// ConfigService returns the client of config service.
+	ConfigService() client.Client
+
+	// SetConfigService sets the client of config service.
+	SetConfigService(c client.Client) ServiceOptions
+
+	// KVOverrideOptions returns the override options for KV store.
+	KVOverrideOptions() kv.OverrideOptions
+
+	// SetKVOverrideOptions sets the override options for KV store.
+	SetKVOverrideOptions(value kv.OverrideOptions) ServiceOptions
+}
+
+// ResetFn rewinds a consumer service as requested by the reset.
+type ResetFn func(reset *offsetpb.Reset) error
+
+// Tracker tracks the offsets acknowledged by the consumer services of a
+// producer and commits them periodically.
+type Tracker interface {
+	// Ack records that the consumer service acknowledged the message of the
+	// shard with the offset.
+	Ack(consumerService string, shard uint32, offset uint64)
+
+	// Init starts committing the offsets, the function is called with every
+	// reset requested for the producer after the tracker is initialized.
+	Init(fn ResetFn) error
+
+	// Watch starts applying the resets requested for the consumer service,
+	// the consumer services watched before the tracker is initialized are
+	// applied once it is.
+	Watch(consumerService string) error
+
+	// Close commits the outstanding offsets and stops the tracker.
+	Close()
+}
+
+// TrackerOptions configures the tracker.
+type TrackerOptions interface {
+	// TopicName returns the name of the topic the producer produces to.
+	TopicName() string
+
+	// SetTopicName sets the name of the topic the producer produces to.
+	SetTopicName(value string) TrackerOptions
+
+	// ProducerID returns the id of the producer, it must be unique among the
+	// producers of the topic and stable across restarts.
+	ProducerID() string
+
+	// SetProducerID sets the id of the producer.
+	SetProducerID(value string) TrackerOptions
+
+	// OffsetService returns the offset service.
+	OffsetService() Service
+
+	// SetOffsetService sets the offset service.
+	SetOffsetService(value Service) TrackerOptions
+
+	// CommitInterval returns the interval to commit the offsets.
+	CommitInterval() time.Duration
+
+	// SetCommitInterval sets the interval to commit the offsets.
+	SetCommitInterval(value time.Duration) TrackerOptions
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) TrackerOptions
+
+	// Validate validates the options.
+	Validate() error
+}
@@ -23,6 +23,7 @@ package buffer
 import (
 	"container/list"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 
 	"github.com/uber-go/tally"
 	"go.uber.org/atomic"
+	"go.uber.org/zap"
 )
 
 var (
@@ -41,6 +43,26 @@ var (
 	errBufferClosed      = errors.New("buffer closed")
 	errMessageTooLarge   = errors.New("message size larger than allowed")
 	errCleanupNoProgress = errors.New("buffer cleanup no progress")
+	errNoSegmentLog      = errors.New("buffer has no segment log to rewind")
+)
+
+const rewindBackoff = 100 * time.Millisecond
+
+// addSource is where a message added to the buffer comes from.
+type addSource int
+
+const (
+	// producedSource means the message is newly produced and is persisted to
+	// the segment log before it is buffered.
+	producedSource addSource = iota
+
+	// unackedSource means the message was persisted by a previous process
+	// and was never consumed, it is acked in the segment log once consumed.
+	unackedSource
+
+	// retainedSource means the message has been consumed already and is
+	// rewound from the segment log, it is never acked again.
+	retainedSource
 )
 
 type bufferMetrics struct {
@@ -53,6 +75,8 @@ type bufferMetrics struct {
 	messageBuffered   tally.Gauge
 	byteBuffered      tally.Gauge
 	bufferScanBatch   tally.Timer
+	replayError       tally.Counter
+	messageRewound    tally.Counter
 }
 
 func newBufferMetrics(
@@ -69,6 +93,8 @@ func newBufferMetrics(
 		messageBuffered:   scope.Gauge("message-buffered"),
 		byteBuffered:      scope.Gauge("byte-buffered"),
 		bufferScanBatch:   instrument.MustCreateSampledTimer(scope.Timer("buffer-scan-batch"), samplingRate),
+		replayError:       scope.Counter("replay-error"),
+		messageRewound:    scope.Counter("message-rewound"),
 	}
 }
 
@@ -84,6 +110,7 @@ type buffer struct {
 	maxMessageSize   int
 	onFinalizeFn     producer.OnFinalizeFn
 	retrier          retry.Retrier
+	log              *segmentLog
 	m                bufferMetrics
 
 	size         *atomic.Uint64
@@ -121,10 +148,27 @@ func NewBuffer(opts Options) (producer.Buffer, error) {
 		doneCh:       make(chan struct{}),
 	}
 	b.onFinalizeFn = b.subSize
+	if segmentLogOpts := opts.SegmentLogOptions(); segmentLogOpts != nil {
+		log, err := newSegmentLog(segmentLogOpts, opts.InstrumentOptions())
+		if err != nil {
+			return nil, err
+		}
+		b.log = log
+	}
 	return b, nil
 }
 
 func (b *buffer) Add(m producer.Message) (*producer.RefCountedMessage, error) {
+	return b.add(m, nil, producedSource)
+}
+
+// add adds the message to the buffer, persisting it first if the buffer has
+// a segment log, unless it is being read back from the segment log.
+func (b *buffer) add(
+	m producer.Message,
+	persisted *replayMessage,
+	src addSource,
+) (*producer.RefCountedMessage, error) {
 	s := m.Size()
 	if s > b.maxMessageSize {
 		b.m.messageTooLarge.Inc(1)
@@ -143,7 +187,31 @@ func (b *buffer) Add(m producer.Message) (*producer.RefCountedMessage, error) {
 			return nil, err
 		}
 	}
-	rm := producer.NewRefCountedMessage(m, b.onFinalizeFn)
+	var (
+		onFinalizeFn = b.onFinalizeFn
+		seq          uint64
+	)
+	if b.log != nil {
+		switch src {
+		case producedSource:
+			var err error
+			if seq, err = b.log.Write(m.Shard(), m.Bytes()); err != nil {
+				b.size.Sub(messageSize)
+				b.RUnlock()
+				return nil, err
+			}
+			onFinalizeFn = b.ackOnFinalizeFn(seq)
+		case unackedSource:
+			seq = persisted.seq
+			onFinalizeFn = b.ackOnFinalizeFn(seq)
+		case retainedSource:
+			seq = persisted.seq
+		}
+	}
+	rm := producer.NewRefCountedMessage(m, onFinalizeFn)
+	if b.log != nil {
+		rm.SetOffset(seq)
+	}
 	b.listLock.Lock()
 	b.bufferList.PushBack(rm)
 	b.listLock.Unlock()
@@ -176,6 +244,82 @@ func (b *buffer) produceOnFull(newBufferSize uint64, messageSize uint64) error {
 	return nil
 }
 
+func (b *buffer) Replay(fn func(rm *producer.RefCountedMessage) error) error {
+	if b.log == nil {
+		return nil
+	}
+
+	var (
+		replayed = b.log.Replay()
+		failed   int
+		firstErr error
+	)
+	for i := range replayed {
+		rm, err := b.add(replayedMessage{
+			shard: replayed[i].shard,
+			bytes: replayed[i].bytes,
+		}, &replayed[i], unackedSource)
+		if err == nil {
+			err = fn(rm)
+		}
+		if err != nil {
+			b.m.replayError.Inc(1)
+			if firstErr == nil {
+				firstErr = err
+			}
+			failed++
+		}
+	}
+	if firstErr != nil {
+		return fmt.Errorf("could not replay %d of %d messages: %v",
+			failed, len(replayed), firstErr)
+	}
+	return nil
+}
+
+func (b *buffer) Rewind(
+	fromOffset uint64,
+	fromNanos int64,
+	fn func(rm *producer.RefCountedMessage) error,
+) error {
+	if b.log == nil {
+		return errNoSegmentLog
+	}
+
+	return b.log.Read(fromOffset, fromNanos, func(msg replayMessage) error {
+		// NB: Copy the bytes so the message does not hold on to the whole
+		// segment read from disk.
+		msg.bytes = append([]byte(nil), msg.bytes...)
+		if err := b.waitForRoom(uint64(len(msg.bytes))); err != nil {
+			return err
+		}
+		rm, err := b.add(replayedMessage{
+			shard: msg.shard,
+			bytes: msg.bytes,
+		}, &msg, retainedSource)
+		if err != nil {
+			return err
+		}
+		b.m.messageRewound.Inc(1)
+		return fn(rm)
+	})
+}
+
+// waitForRoom blocks until the message fits in the buffer, so that rewinding
+// never drops newly produced messages or fails them when the buffer is full.
+func (b *buffer) waitForRoom(messageSize uint64) error {
+	for b.size.Load()+messageSize > b.maxBufferSize {
+		b.RLock()
+		isClosed := b.isClosed
+		b.RUnlock()
+		if isClosed {
+			return errBufferClosed
+		}
+		time.Sleep(rewindBackoff)
+	}
+	return nil
+}
+
 func (b *buffer) Init() {
 	b.wg.Add(1)
 	go func() {
@@ -183,6 +327,14 @@ func (b *buffer) Init() {
 		b.wg.Done()
 	}()
 
+	if b.log != nil {
+		b.wg.Add(1)
+		go func() {
+			b.syncUntilClose()
+			b.wg.Done()
+		}()
+	}
+
 	if b.opts.OnFullStrategy() != DropOldest {
 		return
 	}
@@ -220,6 +372,20 @@ func (b *buffer) cleanupUntilClose() {
 	}
 }
 
+func (b *buffer) syncUntilClose() {
+	ticker := time.NewTicker(b.log.opts.SyncInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.log.Sync()
+		case <-b.doneCh:
+			return
+		}
+	}
+}
+
 func (b *buffer) cleanup() error {
 	b.listLock.RLock()
 	e := b.bufferList.Front()
@@ -369,6 +535,11 @@ func (b *buffer) Close(ct producer.CloseType) {
 	close(b.doneCh)
 	close(b.dropOldestCh)
 	b.wg.Wait()
+	if b.log != nil {
+		if err := b.log.Close(); err != nil {
+			b.opts.InstrumentOptions().Logger().Error("could not close segment log", zap.Error(err))
+		}
+	}
 }
 
 func (b *buffer) waitUntilAllDataConsumed() {
@@ -395,3 +566,35 @@ func (b *buffer) bufferLen() int {
 func (b *buffer) subSize(rm *producer.RefCountedMessage) {
 	b.size.Sub(rm.Size())
 }
+
+// ackOnFinalizeFn returns the finalize function of a persisted message, the
+// message is acked in the segment log once consumed. Dropped messages are
+// kept in the segment log so they are replayed on restart until they expire.
+func (b *buffer) ackOnFinalizeFn(seq uint64) producer.OnFinalizeFn {
+	return func(rm *producer.RefCountedMessage) {
+		b.subSize(rm)
+		if rm.FinalizeReason() == producer.Consumed {
+			b.log.Ack(seq)
+		}
+	}
+}
+
+// replayedMessage is a message replayed or rewound from the segment log.
+type replayedMessage struct {
+	shard uint32
+	bytes []byte
+}
+
+func (m replayedMessage) Shard() uint32 {
+	return m.shard
+}
+
+func (m replayedMessage) Bytes() []byte {
+	return m.bytes
+}
+
+func (m replayedMessage) Size() int {
+	return len(m.bytes)
+}
+
+func (m replayedMessage) Finalize(producer.FinalizeReason) {}
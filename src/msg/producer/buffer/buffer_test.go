@@ -21,6 +21,7 @@
 package buffer
 
 import (
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -471,6 +472,127 @@ func TestBufferReturnErrorOnFull(t *testing.T) {
 	require.Equal(t, 300, int(b.size.Load()))
 }
 
+func TestBufferSegmentLogReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := newTestSegmentLogDir(t)
+	defer os.RemoveAll(dir)
+
+	opts := testOptions().SetSegmentLogOptions(NewSegmentLogOptions().SetPath(dir))
+	b := mustNewBuffer(t, opts)
+	b.Init()
+	require.NoError(t, b.Replay(func(*producer.RefCountedMessage) error {
+		require.FailNow(t, "unexpected replayed message")
+		return nil
+	}))
+
+	mm1 := producer.NewMockMessage(ctrl)
+	mm1.EXPECT().Size().Return(3).AnyTimes()
+	mm1.EXPECT().Shard().Return(uint32(1)).AnyTimes()
+	mm1.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	rm1, err := b.Add(mm1)
+	require.NoError(t, err)
+
+	mm2 := producer.NewMockMessage(ctrl)
+	mm2.EXPECT().Size().Return(3).AnyTimes()
+	mm2.EXPECT().Shard().Return(uint32(2)).AnyTimes()
+	mm2.EXPECT().Bytes().Return([]byte("bar")).AnyTimes()
+	_, err = b.Add(mm2)
+	require.NoError(t, err)
+
+	// Only the consumed message is acked, the dropped one is replayed.
+	mm1.EXPECT().Finalize(producer.Consumed)
+	rm1.IncRef()
+	rm1.DecRef()
+	mm2.EXPECT().Finalize(producer.Dropped)
+	b.Close(producer.DropEverything)
+
+	b = mustNewBuffer(t, opts)
+	b.Init()
+	var replayed []*producer.RefCountedMessage
+	require.NoError(t, b.Replay(func(rm *producer.RefCountedMessage) error {
+		replayed = append(replayed, rm)
+		return nil
+	}))
+	require.Len(t, replayed, 1)
+	require.Equal(t, uint32(2), replayed[0].Shard())
+	require.Equal(t, []byte("bar"), replayed[0].Bytes())
+	require.Equal(t, uint64(3), b.size.Load())
+
+	replayed[0].IncRef()
+	replayed[0].DecRef()
+	require.Equal(t, uint64(0), b.size.Load())
+	b.Close(producer.WaitForConsumption)
+
+	b = mustNewBuffer(t, opts)
+	require.NoError(t, b.Replay(func(*producer.RefCountedMessage) error {
+		require.FailNow(t, "unexpected replayed message")
+		return nil
+	}))
+	require.NoError(t, b.log.Close())
+}
+
+func TestBufferRewind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := newTestSegmentLogDir(t)
+	defer os.RemoveAll(dir)
+
+	b := mustNewBuffer(t, testOptions())
+	require.Equal(t, errNoSegmentLog, b.Rewind(0, 0, nil))
+
+	opts := testOptions().SetSegmentLogOptions(NewSegmentLogOptions().
+		SetPath(dir).
+		SetRetentionPeriod(time.Hour))
+	b = mustNewBuffer(t, opts)
+	b.Init()
+	defer b.Close(producer.WaitForConsumption)
+
+	var offsets []uint64
+	for i, value := range []string{"foo", "bar", "baz"} {
+		mm := producer.NewMockMessage(ctrl)
+		mm.EXPECT().Size().Return(3).AnyTimes()
+		mm.EXPECT().Shard().Return(uint32(i)).AnyTimes()
+		mm.EXPECT().Bytes().Return([]byte(value)).AnyTimes()
+		mm.EXPECT().Finalize(producer.Consumed)
+		rm, err := b.Add(mm)
+		require.NoError(t, err)
+		offset, ok := rm.Offset()
+		require.True(t, ok)
+		offsets = append(offsets, offset)
+		rm.IncRef()
+		rm.DecRef()
+	}
+	require.Equal(t, 0, b.log.unacked)
+
+	var rewound []*producer.RefCountedMessage
+	require.NoError(t, b.Rewind(offsets[1], 0, func(rm *producer.RefCountedMessage) error {
+		rewound = append(rewound, rm)
+		return nil
+	}))
+	require.Len(t, rewound, 2)
+	for i, rm := range rewound {
+		offset, ok := rm.Offset()
+		require.True(t, ok)
+		require.Equal(t, offsets[i+1], offset)
+		require.Equal(t, uint32(i+1), rm.Shard())
+	}
+	require.Equal(t, []byte("bar"), rewound[0].Bytes())
+	require.Equal(t, uint64(6), b.size.Load())
+
+	// Consuming rewound messages does not ack them in the segment log again.
+	segments := len(b.log.segments)
+	for _, rm := range rewound {
+		rm.IncRef()
+		rm.DecRef()
+	}
+	require.Equal(t, uint64(0), b.size.Load())
+	require.Equal(t, segments, len(b.log.segments))
+	require.Equal(t, 0, b.log.unacked)
+}
+
 func mustNewBuffer(t testing.TB, opts Options) *buffer {
 	b, err := NewBuffer(opts)
 	require.NoError(t, err)
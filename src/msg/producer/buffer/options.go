@@ -37,6 +37,11 @@ const (
 	defaultCleanupInitialBackoff = 10 * time.Second
 	defaultAllowedSpilloverRatio = 0.2
 	defaultCleanupMaxBackoff     = time.Minute
+
+	defaultMaxSegmentSize         = 64 * 1024 * 1024   // 64MB.
+	defaultMaxLogSize             = 1024 * 1024 * 1024 // 1GB.
+	defaultSegmentLogMaxAge       = 24 * time.Hour
+	defaultSegmentLogSyncInterval = time.Second
 )
 
 var (
@@ -44,6 +49,14 @@ var (
 	errInvalidMaxMessageSize  = errors.New("invalid max message size")
 	errNegativeMaxBufferSize  = errors.New("negative max buffer size")
 	errNegativeMaxMessageSize = errors.New("negative max message size")
+
+	errNoSegmentLogPath              = errors.New("no segment log path")
+	errInvalidMaxSegmentSize         = errors.New("invalid max segment size")
+	errInvalidMaxLogSize             = errors.New("max log size smaller than max segment size")
+	errNegativeSegmentLogMaxAge      = errors.New("negative segment log max age")
+	errInvalidSegmentLogSyncInterval = errors.New("invalid segment log sync interval")
+	errNegativeRetentionPeriod       = errors.New("negative segment log retention period")
+	errInvalidRetentionPeriod        = errors.New("segment log retention period larger than max age")
 )
 
 type bufferOptions struct {
@@ -55,6 +68,7 @@ type bufferOptions struct {
 	scanBatchSize         int
 	allowedSpilloverRatio float64
 	rOpts                 retry.Options
+	segmentLogOpts        SegmentLogOptions
 	iOpts                 instrument.Options
 }
 
@@ -156,6 +170,16 @@ func (opts *bufferOptions) SetCleanupRetryOptions(value retry.Options) Options {
 	return &o
 }
 
+func (opts *bufferOptions) SegmentLogOptions() SegmentLogOptions {
+	return opts.segmentLogOpts
+}
+
+func (opts *bufferOptions) SetSegmentLogOptions(value SegmentLogOptions) Options {
+	o := *opts
+	o.segmentLogOpts = value
+	return &o
+}
+
 func (opts *bufferOptions) InstrumentOptions() instrument.Options {
 	return opts.iOpts
 }
@@ -180,5 +204,112 @@ func (opts *bufferOptions) Validate() error {
 		// Max message size can only be as large as max buffer size.
 		return errInvalidMaxMessageSize
 	}
+	if opts.SegmentLogOptions() != nil {
+		return opts.SegmentLogOptions().Validate()
+	}
+	return nil
+}
+
+type segmentLogOptions struct {
+	path           string
+	maxSegmentSize int
+	maxLogSize     int
+	maxAge         time.Duration
+	syncInterval   time.Duration
+	retention      time.Duration
+}
+
+// NewSegmentLogOptions creates SegmentLogOptions.
+func NewSegmentLogOptions() SegmentLogOptions {
+	return &segmentLogOptions{
+		maxSegmentSize: defaultMaxSegmentSize,
+		maxLogSize:     defaultMaxLogSize,
+		maxAge:         defaultSegmentLogMaxAge,
+		syncInterval:   defaultSegmentLogSyncInterval,
+	}
+}
+
+func (opts *segmentLogOptions) Path() string {
+	return opts.path
+}
+
+func (opts *segmentLogOptions) SetPath(value string) SegmentLogOptions {
+	o := *opts
+	o.path = value
+	return &o
+}
+
+func (opts *segmentLogOptions) MaxSegmentSize() int {
+	return opts.maxSegmentSize
+}
+
+func (opts *segmentLogOptions) SetMaxSegmentSize(value int) SegmentLogOptions {
+	o := *opts
+	o.maxSegmentSize = value
+	return &o
+}
+
+func (opts *segmentLogOptions) MaxLogSize() int {
+	return opts.maxLogSize
+}
+
+func (opts *segmentLogOptions) SetMaxLogSize(value int) SegmentLogOptions {
+	o := *opts
+	o.maxLogSize = value
+	return &o
+}
+
+func (opts *segmentLogOptions) MaxAge() time.Duration {
+	return opts.maxAge
+}
+
+func (opts *segmentLogOptions) SetMaxAge(value time.Duration) SegmentLogOptions {
+	o := *opts
+	o.maxAge = value
+	return &o
+}
+
+func (opts *segmentLogOptions) SyncInterval() time.Duration {
+	return opts.syncInterval
+}
+
+func (opts *segmentLogOptions) SetSyncInterval(value time.Duration) SegmentLogOptions {
+	o := *opts
+	o.syncInterval = value
+	return &o
+}
+
+func (opts *segmentLogOptions) RetentionPeriod() time.Duration {
+	return opts.retention
+}
+
+func (opts *segmentLogOptions) SetRetentionPeriod(value time.Duration) SegmentLogOptions {
+	o := *opts
+	o.retention = value
+	return &o
+}
+
+func (opts *segmentLogOptions) Validate() error {
+	if opts.Path() == "" {
+		return errNoSegmentLogPath
+	}
+	if opts.MaxSegmentSize() <= 0 {
+		return errInvalidMaxSegmentSize
+	}
+	if opts.MaxLogSize() < opts.MaxSegmentSize() {
+		return errInvalidMaxLogSize
+	}
+	if opts.MaxAge() < 0 {
+		return errNegativeSegmentLogMaxAge
+	}
+	if opts.SyncInterval() <= 0 {
+		return errInvalidSegmentLogSyncInterval
+	}
+	if opts.RetentionPeriod() < 0 {
+		return errNegativeRetentionPeriod
+	}
+	if opts.MaxAge() > 0 && opts.RetentionPeriod() > opts.MaxAge() {
+		return errInvalidRetentionPeriod
+	}
 	return nil
 }
@@ -0,0 +1,655 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package buffer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	segmentFilePrefix = "segment-"
+	segmentFileSuffix = ".log"
+
+	// Each record is encoded as the length of the payload, the checksum of
+	// the payload, the record type and the payload itself.
+	recordHeaderLen  = 4 + 4 + 1
+	dataRecordPrefix = 8 + 8 + 4
+	ackRecordLen     = 8
+
+	segmentDirPerm  = 0755
+	segmentFilePerm = 0644
+)
+
+type recordType byte
+
+const (
+	dataRecordType recordType = iota + 1
+	ackRecordType
+)
+
+var (
+	errSegmentLogClosed = errors.New("segment log closed")
+	errCorruptRecord    = errors.New("corrupt segment log record")
+)
+
+type segmentLogMetrics struct {
+	messagePersisted tally.Counter
+	bytePersisted    tally.Counter
+	persistError     tally.Counter
+	ackError         tally.Counter
+	syncError        tally.Counter
+	messageReplayed  tally.Counter
+	corruptSegment   tally.Counter
+	sizeExpired      tally.Counter
+	ageExpired       tally.Counter
+	messageExpired   tally.Counter
+	segments         tally.Gauge
+	bytes            tally.Gauge
+	messageUnacked   tally.Gauge
+}
+
+func newSegmentLogMetrics(scope tally.Scope) segmentLogMetrics {
+	return segmentLogMetrics{
+		messagePersisted: scope.Counter("message-persisted"),
+		bytePersisted:    scope.Counter("byte-persisted"),
+		persistError:     scope.Counter("persist-error"),
+		ackError:         scope.Counter("ack-error"),
+		syncError:        scope.Counter("sync-error"),
+		messageReplayed:  scope.Counter("message-replayed"),
+		corruptSegment:   scope.Counter("corrupt-segment"),
+		sizeExpired: scope.Tagged(map[string]string{
+			"reason": "size",
+		}).Counter("segment-expired"),
+		ageExpired: scope.Tagged(map[string]string{
+			"reason": "age",
+		}).Counter("segment-expired"),
+		messageExpired: scope.Counter("message-expired"),
+		segments:       scope.Gauge("segments"),
+		bytes:          scope.Gauge("bytes"),
+		messageUnacked: scope.Gauge("message-unacked"),
+	}
+}
+
+// logSegment is a single file of the segment log, it holds the records
+// written from the first sequence number until the next segment.
+type logSegment struct {
+	path        string
+	firstSeq    uint64
+	size        int64
+	lastWrite   time.Time
+	outstanding int
+}
+
+// replayMessage is a message read back from the segment log.
+type replayMessage struct {
+	seq   uint64
+	nanos int64
+	shard uint32
+	bytes []byte
+}
+
+// segmentLog is a write-ahead log of the messages added to the buffer. Every
+// message is persisted before it is buffered and is acknowledged once it has
+// been consumed, so that messages that were never consumed can be replayed
+// when the process restarts.
+//
+// The log is split into segments that are removed once every message in them
+// has been acknowledged and they are older than the retention period, or once
+// they exceed the size or age retention. The messages in retained segments
+// can be read again to rewind a consumer service.
+type segmentLog struct {
+	sync.Mutex
+
+	opts    SegmentLogOptions
+	nowFn   func() time.Time
+	logger  *zap.Logger
+	m       segmentLogMetrics
+	scratch []byte
+
+	segments      []*logSegment
+	active        *os.File
+	size          int64
+	unacked       int
+	nextSeq       uint64
+	nextSegmentID uint64
+	pending       []replayMessage
+	isClosed      bool
+}
+
+func newSegmentLog(
+	opts SegmentLogOptions,
+	iOpts instrument.Options,
+) (*segmentLog, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.Path(), segmentDirPerm); err != nil {
+		return nil, err
+	}
+
+	l := &segmentLog{
+		opts:   opts,
+		nowFn:  time.Now,
+		logger: iOpts.Logger(),
+		m:      newSegmentLogMetrics(iOpts.MetricsScope().SubScope("segment-log")),
+	}
+	if err := l.load(); err != nil {
+		return nil, err
+	}
+	if err := l.rotateWithLock(); err != nil {
+		return nil, err
+	}
+	l.removeAckedSegmentsWithLock()
+	l.updateGaugesWithLock()
+	return l, nil
+}
+
+// load reads the segments left behind by a previous segment log and keeps
+// the messages that were never acknowledged so they can be replayed.
+func (l *segmentLog) load() error {
+	paths, err := segmentFilePaths(l.opts.Path())
+	if err != nil {
+		return err
+	}
+
+	pending := make(map[uint64]replayMessage)
+	for _, path := range paths {
+		segment, err := l.loadSegment(path, pending)
+		if err != nil {
+			return err
+		}
+		l.segments = append(l.segments, segment)
+		l.size += segment.size
+	}
+
+	l.pending = make([]replayMessage, 0, len(pending))
+	for _, msg := range pending {
+		l.pending = append(l.pending, msg)
+	}
+	sort.Slice(l.pending, func(i, j int) bool {
+		return l.pending[i].seq < l.pending[j].seq
+	})
+	l.unacked = len(l.pending)
+	return nil
+}
+
+func (l *segmentLog) loadSegment(
+	path string,
+	pending map[uint64]replayMessage,
+) (*logSegment, error) {
+	firstSeq, segmentID, err := parseSegmentFileName(path)
+	if err != nil {
+		return nil, err
+	}
+	if segmentID >= l.nextSegmentID {
+		l.nextSegmentID = segmentID + 1
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	segment := &logSegment{
+		path:      path,
+		firstSeq:  firstSeq,
+		size:      info.Size(),
+		lastWrite: info.ModTime(),
+	}
+	if firstSeq > l.nextSeq {
+		l.nextSeq = firstSeq
+	}
+	for len(data) > 0 {
+		typ, payload, n, err := decodeRecord(data)
+		if err != nil {
+			// NB: A partially written record at the end of the segment is
+			// expected if the process crashed mid write.
+			l.m.corruptSegment.Inc(1)
+			l.logger.Warn("skipping corrupt segment log records",
+				zap.String("path", path), zap.Int("bytes", len(data)), zap.Error(err))
+			break
+		}
+		data = data[n:]
+
+		switch typ {
+		case dataRecordType:
+			if len(payload) < dataRecordPrefix {
+				return nil, errCorruptRecord
+			}
+			msg := decodeDataRecord(payload)
+			pending[msg.seq] = msg
+			segment.outstanding++
+			if msg.seq >= l.nextSeq {
+				l.nextSeq = msg.seq + 1
+			}
+		case ackRecordType:
+			if len(payload) != ackRecordLen {
+				return nil, errCorruptRecord
+			}
+			seq := binary.BigEndian.Uint64(payload)
+			if _, ok := pending[seq]; !ok {
+				continue
+			}
+			delete(pending, seq)
+			if seq >= firstSeq {
+				segment.outstanding--
+			} else if owner := l.segmentForSeqWithLock(seq); owner != nil {
+				owner.outstanding--
+			}
+		}
+	}
+	return segment, nil
+}
+
+// Write persists the message and returns its sequence number.
+func (l *segmentLog) Write(shard uint32, data []byte) (uint64, error) {
+	l.Lock()
+	defer l.Unlock()
+	if l.isClosed {
+		return 0, errSegmentLogClosed
+	}
+
+	seq := l.nextSeq
+	nanos := l.nowFn().UnixNano()
+	l.scratch = encodeRecord(l.scratch[:0], dataRecordType, func(b []byte) []byte {
+		b = appendUint64(b, seq)
+		b = appendUint64(b, uint64(nanos))
+		b = appendUint32(b, shard)
+		return append(b, data...)
+	})
+	if err := l.writeWithLock(l.scratch); err != nil {
+		l.m.persistError.Inc(1)
+		return 0, err
+	}
+	l.nextSeq++
+	l.unacked++
+	l.segments[len(l.segments)-1].outstanding++
+	l.m.messagePersisted.Inc(1)
+	l.m.bytePersisted.Inc(int64(len(data)))
+
+	l.expireBySizeWithLock()
+	return seq, nil
+}
+
+// Ack marks the message with the sequence number as consumed.
+func (l *segmentLog) Ack(seq uint64) {
+	l.Lock()
+	defer l.Unlock()
+	if l.isClosed {
+		return
+	}
+
+	segment := l.segmentForSeqWithLock(seq)
+	if segment == nil {
+		// The segment has already been removed by retention.
+		return
+	}
+
+	l.scratch = encodeRecord(l.scratch[:0], ackRecordType, func(b []byte) []byte {
+		return appendUint64(b, seq)
+	})
+	if err := l.writeWithLock(l.scratch); err != nil {
+		// NB: The message will be replayed again on restart which is fine
+		// given the at least once delivery guarantee.
+		l.m.ackError.Inc(1)
+		return
+	}
+	segment.outstanding--
+	l.unacked--
+	l.removeAckedSegmentsWithLock()
+}
+
+// Replay returns the messages that were persisted by a previous segment log
+// and never acknowledged, it only returns them on the first call.
+func (l *segmentLog) Replay() []replayMessage {
+	l.Lock()
+	defer l.Unlock()
+
+	pending := l.pending
+	l.pending = nil
+
+	// Messages in segments that have since expired are not replayed.
+	firstSeq := l.segments[0].firstSeq
+	for len(pending) > 0 && pending[0].seq < firstSeq {
+		pending = pending[1:]
+	}
+	l.m.messageReplayed.Inc(int64(len(pending)))
+	return pending
+}
+
+// Read calls the function with every message retained by the segment log
+// from the given offset that was written at or after the given time, in
+// offset order. Only the messages written before the call are read.
+func (l *segmentLog) Read(
+	fromSeq uint64,
+	fromNanos int64,
+	fn func(msg replayMessage) error,
+) error {
+	l.Lock()
+	if l.isClosed {
+		l.Unlock()
+		return errSegmentLogClosed
+	}
+	var (
+		segments = make([]logSegment, 0, len(l.segments))
+		endSeq   = l.nextSeq
+	)
+	for _, segment := range l.segments {
+		segments = append(segments, *segment)
+	}
+	l.Unlock()
+
+	for i, segment := range segments {
+		if i+1 < len(segments) && segments[i+1].firstSeq <= fromSeq {
+			// Every message in the segment is before the offset.
+			continue
+		}
+		if segment.lastWrite.UnixNano() < fromNanos {
+			continue
+		}
+		if err := l.readSegment(segment, fromSeq, fromNanos, endSeq, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *segmentLog) readSegment(
+	segment logSegment,
+	fromSeq uint64,
+	fromNanos int64,
+	endSeq uint64,
+	fn func(msg replayMessage) error,
+) error {
+	data, err := ioutil.ReadFile(segment.path)
+	if os.IsNotExist(err) {
+		// The segment has been removed by retention since.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) > segment.size {
+		data = data[:segment.size]
+	}
+	for len(data) > 0 {
+		typ, payload, n, err := decodeRecord(data)
+		if err != nil {
+			l.logger.Warn("skipping corrupt segment log records",
+				zap.String("path", segment.path), zap.Int("bytes", len(data)), zap.Error(err))
+			return nil
+		}
+		data = data[n:]
+		if typ != dataRecordType || len(payload) < dataRecordPrefix {
+			continue
+		}
+		msg := decodeDataRecord(payload)
+		if msg.seq >= endSeq {
+			return nil
+		}
+		if msg.seq < fromSeq || msg.nanos < fromNanos {
+			continue
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sync flushes the active segment to disk, removes the acknowledged segments
+// older than the retention period and the segments older than the max age.
+func (l *segmentLog) Sync() {
+	l.Lock()
+	defer l.Unlock()
+	if l.isClosed {
+		return
+	}
+
+	if err := l.active.Sync(); err != nil {
+		l.m.syncError.Inc(1)
+		l.logger.Error("could not sync segment log", zap.Error(err))
+	}
+	l.removeAckedSegmentsWithLock()
+	l.expireByAgeWithLock()
+	l.updateGaugesWithLock()
+}
+
+// Close syncs and closes the segment log, the segments are kept on disk.
+func (l *segmentLog) Close() error {
+	l.Lock()
+	defer l.Unlock()
+	if l.isClosed {
+		return nil
+	}
+	l.isClosed = true
+	l.pending = nil
+
+	if err := l.active.Sync(); err != nil {
+		l.active.Close()
+		return err
+	}
+	return l.active.Close()
+}
+
+func (l *segmentLog) writeWithLock(record []byte) error {
+	active := l.segments[len(l.segments)-1]
+	if active.size > 0 && active.size+int64(len(record)) > int64(l.opts.MaxSegmentSize()) {
+		if err := l.rotateWithLock(); err != nil {
+			return err
+		}
+		active = l.segments[len(l.segments)-1]
+	}
+
+	n, err := l.active.Write(record)
+	active.size += int64(n)
+	l.size += int64(n)
+	if err != nil {
+		return err
+	}
+	active.lastWrite = l.nowFn()
+	return nil
+}
+
+// rotateWithLock starts a new active segment, the segment is named after the
+// sequence number of the first message written to it followed by a segment ID
+// since segments holding only acks share the sequence number of the next one.
+func (l *segmentLog) rotateWithLock() error {
+	path := filepath.Join(l.opts.Path(), fmt.Sprintf("%s%020d-%020d%s",
+		segmentFilePrefix, l.nextSeq, l.nextSegmentID, segmentFileSuffix))
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, segmentFilePerm)
+	if err != nil {
+		return err
+	}
+
+	if l.active != nil {
+		if err := l.active.Sync(); err != nil {
+			l.m.syncError.Inc(1)
+		}
+		l.active.Close()
+	}
+	l.active = fd
+	l.nextSegmentID++
+	l.segments = append(l.segments, &logSegment{
+		path:      path,
+		firstSeq:  l.nextSeq,
+		lastWrite: l.nowFn(),
+	})
+	return nil
+}
+
+func (l *segmentLog) segmentForSeqWithLock(seq uint64) *logSegment {
+	idx := sort.Search(len(l.segments), func(i int) bool {
+		return l.segments[i].firstSeq > seq
+	})
+	if idx == 0 {
+		return nil
+	}
+	return l.segments[idx-1]
+}
+
+// removeAckedSegmentsWithLock removes the oldest segments once every message
+// in them has been acknowledged and they are older than the retention period.
+// Segments are only ever removed in order so that acks written to later
+// segments remain for the messages they ack.
+func (l *segmentLog) removeAckedSegmentsWithLock() {
+	retainAfter := l.nowFn().Add(-l.opts.RetentionPeriod())
+	for len(l.segments) > 1 && l.segments[0].outstanding <= 0 &&
+		!l.segments[0].lastWrite.After(retainAfter) {
+		l.removeOldestSegmentWithLock()
+	}
+}
+
+func (l *segmentLog) expireBySizeWithLock() {
+	for len(l.segments) > 1 && l.size > int64(l.opts.MaxLogSize()) {
+		l.m.sizeExpired.Inc(1)
+		l.expireOldestSegmentWithLock()
+	}
+}
+
+func (l *segmentLog) expireByAgeWithLock() {
+	maxAge := l.opts.MaxAge()
+	if maxAge <= 0 {
+		return
+	}
+	expireBefore := l.nowFn().Add(-maxAge)
+	for len(l.segments) > 1 && l.segments[0].lastWrite.Before(expireBefore) {
+		l.m.ageExpired.Inc(1)
+		l.expireOldestSegmentWithLock()
+	}
+}
+
+func (l *segmentLog) expireOldestSegmentWithLock() {
+	if outstanding := l.segments[0].outstanding; outstanding > 0 {
+		l.m.messageExpired.Inc(int64(outstanding))
+		l.unacked -= outstanding
+	}
+	l.removeOldestSegmentWithLock()
+}
+
+func (l *segmentLog) removeOldestSegmentWithLock() {
+	oldest := l.segments[0]
+	if err := os.Remove(oldest.path); err != nil && !os.IsNotExist(err) {
+		l.logger.Error("could not remove segment log segment",
+			zap.String("path", oldest.path), zap.Error(err))
+	}
+	l.size -= oldest.size
+	l.segments[0] = nil
+	l.segments = l.segments[1:]
+}
+
+func (l *segmentLog) updateGaugesWithLock() {
+	l.m.segments.Update(float64(len(l.segments)))
+	l.m.bytes.Update(float64(l.size))
+	l.m.messageUnacked.Update(float64(l.unacked))
+}
+
+func segmentFilePaths(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, segmentFilePrefix+"*"+segmentFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	// NB: Segment names are zero padded so they sort in sequence order.
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func parseSegmentFileName(path string) (uint64, uint64, error) {
+	name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), segmentFilePrefix),
+		segmentFileSuffix)
+	parts := strings.Split(name, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid segment log file %s", path)
+	}
+	firstSeq, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid segment log file %s: %v", path, err)
+	}
+	segmentID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid segment log file %s: %v", path, err)
+	}
+	return firstSeq, segmentID, nil
+}
+
+func encodeRecord(b []byte, typ recordType, payloadFn func([]byte) []byte) []byte {
+	b = append(b, make([]byte, recordHeaderLen)...)
+	b = payloadFn(b)
+	payload := b[recordHeaderLen:]
+	binary.BigEndian.PutUint32(b, uint32(len(payload)))
+	binary.BigEndian.PutUint32(b[4:], crc32.ChecksumIEEE(payload))
+	b[8] = byte(typ)
+	return b
+}
+
+func decodeRecord(b []byte) (recordType, []byte, int, error) {
+	if len(b) < recordHeaderLen {
+		return 0, nil, 0, errCorruptRecord
+	}
+	payloadLen := int(binary.BigEndian.Uint32(b))
+	if len(b) < recordHeaderLen+payloadLen {
+		return 0, nil, 0, errCorruptRecord
+	}
+	payload := b[recordHeaderLen : recordHeaderLen+payloadLen]
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(b[4:]) {
+		return 0, nil, 0, errCorruptRecord
+	}
+	return recordType(b[8]), payload, recordHeaderLen + payloadLen, nil
+}
+
+func decodeDataRecord(payload []byte) replayMessage {
+	return replayMessage{
+		seq:   binary.BigEndian.Uint64(payload),
+		nanos: int64(binary.BigEndian.Uint64(payload[8:])),
+		shard: binary.BigEndian.Uint32(payload[16:]),
+		bytes: payload[dataRecordPrefix:],
+	}
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
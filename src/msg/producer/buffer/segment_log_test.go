@@ -0,0 +1,274 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package buffer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentLogOptionsValidation(t *testing.T) {
+	opts := NewSegmentLogOptions()
+	require.Equal(t, errNoSegmentLogPath, opts.Validate())
+
+	opts = opts.SetPath("/tmp")
+	require.NoError(t, opts.Validate())
+
+	opts = opts.SetMaxLogSize(1).SetMaxSegmentSize(2)
+	require.Equal(t, errInvalidMaxLogSize, opts.Validate())
+
+	opts = opts.SetMaxSegmentSize(0)
+	require.Equal(t, errInvalidMaxSegmentSize, opts.Validate())
+
+	opts = opts.SetMaxSegmentSize(1).SetMaxAge(-1)
+	require.Equal(t, errNegativeSegmentLogMaxAge, opts.Validate())
+
+	opts = opts.SetMaxAge(0).SetSyncInterval(0)
+	require.Equal(t, errInvalidSegmentLogSyncInterval, opts.Validate())
+
+	opts = opts.SetSyncInterval(time.Second).SetRetentionPeriod(-1)
+	require.Equal(t, errNegativeRetentionPeriod, opts.Validate())
+
+	opts = opts.SetMaxAge(time.Minute).SetRetentionPeriod(time.Hour)
+	require.Equal(t, errInvalidRetentionPeriod, opts.Validate())
+
+	require.Equal(t, errNoSegmentLogPath, NewOptions().
+		SetSegmentLogOptions(NewSegmentLogOptions()).Validate())
+}
+
+func TestSegmentLogReplayUnacked(t *testing.T) {
+	dir := newTestSegmentLogDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	nowFn := func() time.Time { return now }
+	opts := NewSegmentLogOptions().SetPath(dir)
+	l := mustNewSegmentLog(t, opts)
+	l.nowFn = nowFn
+	require.Empty(t, l.Replay())
+
+	seq1, err := l.Write(1, []byte("a"))
+	require.NoError(t, err)
+	seq2, err := l.Write(2, []byte("b"))
+	require.NoError(t, err)
+	seq3, err := l.Write(3, []byte("c"))
+	require.NoError(t, err)
+	l.Ack(seq2)
+	require.NoError(t, l.Close())
+
+	l = mustNewSegmentLog(t, opts)
+	l.nowFn = nowFn
+	require.Equal(t, []replayMessage{
+		{seq: seq1, nanos: now.UnixNano(), shard: 1, bytes: []byte("a")},
+		{seq: seq3, nanos: now.UnixNano(), shard: 3, bytes: []byte("c")},
+	}, l.Replay())
+	require.Empty(t, l.Replay())
+
+	seq4, err := l.Write(4, []byte("d"))
+	require.NoError(t, err)
+	require.True(t, seq4 > seq3)
+	l.Ack(seq1)
+	require.NoError(t, l.Close())
+
+	l = mustNewSegmentLog(t, opts)
+	require.Equal(t, []replayMessage{
+		{seq: seq3, nanos: now.UnixNano(), shard: 3, bytes: []byte("c")},
+		{seq: seq4, nanos: now.UnixNano(), shard: 4, bytes: []byte("d")},
+	}, l.Replay())
+	require.NoError(t, l.Close())
+}
+
+func TestSegmentLogRemovesAckedSegments(t *testing.T) {
+	dir := newTestSegmentLogDir(t)
+	defer os.RemoveAll(dir)
+
+	// Every record is written to a new segment.
+	l := mustNewSegmentLog(t, NewSegmentLogOptions().SetPath(dir).SetMaxSegmentSize(1))
+	defer l.Close()
+
+	var seqs []uint64
+	for i := 0; i < 3; i++ {
+		seq, err := l.Write(uint32(i), []byte("foo"))
+		require.NoError(t, err)
+		seqs = append(seqs, seq)
+	}
+	require.Len(t, testSegmentFiles(t, dir), 3)
+
+	// Acking a message in the middle can not remove its segment since the
+	// acks of earlier messages would be lost.
+	l.Ack(seqs[1])
+	require.Len(t, testSegmentFiles(t, dir), 4)
+
+	l.Ack(seqs[0])
+	l.Ack(seqs[2])
+	require.Len(t, testSegmentFiles(t, dir), 1)
+	require.Equal(t, 0, l.unacked)
+}
+
+func TestSegmentLogExpireBySize(t *testing.T) {
+	dir := newTestSegmentLogDir(t)
+	defer os.RemoveAll(dir)
+
+	opts := NewSegmentLogOptions().
+		SetPath(dir).
+		SetMaxSegmentSize(1).
+		SetMaxLogSize(64)
+	l := mustNewSegmentLog(t, opts)
+
+	var seqs []uint64
+	for i := 0; i < 10; i++ {
+		seq, err := l.Write(uint32(i), []byte("foo"))
+		require.NoError(t, err)
+		seqs = append(seqs, seq)
+	}
+	require.True(t, l.size <= 64)
+	require.NoError(t, l.Close())
+
+	l = mustNewSegmentLog(t, opts)
+	defer l.Close()
+	replayed := l.Replay()
+	require.NotEmpty(t, replayed)
+	require.True(t, len(replayed) < len(seqs))
+	require.Equal(t, seqs[len(seqs)-1], replayed[len(replayed)-1].seq)
+}
+
+func TestSegmentLogExpireByAge(t *testing.T) {
+	dir := newTestSegmentLogDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	l := mustNewSegmentLog(t, NewSegmentLogOptions().
+		SetPath(dir).
+		SetMaxSegmentSize(1).
+		SetMaxAge(time.Minute))
+	defer l.Close()
+	l.nowFn = func() time.Time { return now }
+
+	_, err := l.Write(1, []byte("foo"))
+	require.NoError(t, err)
+	now = now.Add(time.Hour)
+	_, err = l.Write(2, []byte("bar"))
+	require.NoError(t, err)
+	require.Equal(t, 2, l.unacked)
+
+	l.Sync()
+	require.Equal(t, 1, l.unacked)
+	require.Len(t, l.segments, 1)
+}
+
+func TestSegmentLogRetainsAckedSegments(t *testing.T) {
+	dir := newTestSegmentLogDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	l := mustNewSegmentLog(t, NewSegmentLogOptions().
+		SetPath(dir).
+		SetMaxSegmentSize(1).
+		SetRetentionPeriod(time.Hour))
+	defer l.Close()
+	l.nowFn = func() time.Time { return now }
+
+	var seqs []uint64
+	for i := 0; i < 3; i++ {
+		seq, err := l.Write(uint32(i), []byte("foo"))
+		require.NoError(t, err)
+		seqs = append(seqs, seq)
+		l.Ack(seq)
+		now = now.Add(time.Minute)
+	}
+	require.Equal(t, 0, l.unacked)
+	require.Len(t, testSegmentFiles(t, dir), 6)
+
+	var read []uint64
+	readFn := func(msg replayMessage) error {
+		read = append(read, msg.seq)
+		return nil
+	}
+	require.NoError(t, l.Read(0, 0, readFn))
+	require.Equal(t, seqs, read)
+
+	// Read from an offset.
+	read = nil
+	require.NoError(t, l.Read(seqs[1], 0, readFn))
+	require.Equal(t, seqs[1:], read)
+
+	// Read from a time.
+	read = nil
+	require.NoError(t, l.Read(0, now.Add(-time.Minute).UnixNano(), readFn))
+	require.Equal(t, seqs[2:], read)
+
+	// The acked segments are removed once older than the retention period.
+	now = now.Add(time.Hour - 90*time.Second)
+	l.Sync()
+	read = nil
+	require.NoError(t, l.Read(0, 0, readFn))
+	require.Equal(t, seqs[2:], read)
+}
+
+func TestSegmentLogSkipsCorruptTail(t *testing.T) {
+	dir := newTestSegmentLogDir(t)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	opts := NewSegmentLogOptions().SetPath(dir)
+	l := mustNewSegmentLog(t, opts)
+	l.nowFn = func() time.Time { return now }
+	seq, err := l.Write(1, []byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+
+	files := testSegmentFiles(t, dir)
+	fd, err := os.OpenFile(files[len(files)-1], os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = fd.Write([]byte{0, 0, 0, 10, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	l = mustNewSegmentLog(t, opts)
+	defer l.Close()
+	require.Equal(t, []replayMessage{
+		{seq: seq, nanos: now.UnixNano(), shard: 1, bytes: []byte("foo")},
+	}, l.Replay())
+}
+
+func newTestSegmentLogDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "segment-log")
+	require.NoError(t, err)
+	return dir
+}
+
+func mustNewSegmentLog(t *testing.T, opts SegmentLogOptions) *segmentLog {
+	l, err := newSegmentLog(opts, instrument.NewOptions())
+	require.NoError(t, err)
+	return l
+}
+
+func testSegmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, segmentFilePrefix+"*"))
+	require.NoError(t, err)
+	return files
+}
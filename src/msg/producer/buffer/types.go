@@ -96,6 +96,14 @@ type Options interface {
 	// SetCleanupRetryOptions sets the cleanup retry options.
 	SetCleanupRetryOptions(value retry.Options) Options
 
+	// SegmentLogOptions returns the options of the segment log that persists
+	// messages before they are buffered, the buffer is only held in memory
+	// when not set.
+	SegmentLogOptions() SegmentLogOptions
+
+	// SetSegmentLogOptions sets the options of the segment log.
+	SetSegmentLogOptions(value SegmentLogOptions) Options
+
 	// InstrumentOptions returns the instrument options.
 	InstrumentOptions() instrument.Options
 
@@ -105,3 +113,51 @@ type Options interface {
 	// Validate validates the options.
 	Validate() error
 }
+
+// SegmentLogOptions configs the segment log of the buffer.
+type SegmentLogOptions interface {
+	// Path returns the directory of the segment log.
+	Path() string
+
+	// SetPath sets the directory of the segment log.
+	SetPath(value string) SegmentLogOptions
+
+	// MaxSegmentSize returns the max size of a single segment file.
+	MaxSegmentSize() int
+
+	// SetMaxSegmentSize sets the max size of a single segment file.
+	SetMaxSegmentSize(value int) SegmentLogOptions
+
+	// MaxLogSize returns the max size of all the segment files, the oldest
+	// segments are removed even if not consumed when it is exceeded.
+	MaxLogSize() int
+
+	// SetMaxLogSize sets the max size of all the segment files.
+	SetMaxLogSize(value int) SegmentLogOptions
+
+	// MaxAge returns the max age of a segment since it was last written to,
+	// older segments are removed even if not consumed, zero means no limit.
+	MaxAge() time.Duration
+
+	// SetMaxAge sets the max age of a segment since it was last written to.
+	SetMaxAge(value time.Duration) SegmentLogOptions
+
+	// SyncInterval returns the interval to sync the segment log to disk and
+	// to enforce the max age.
+	SyncInterval() time.Duration
+
+	// SetSyncInterval sets the interval to sync the segment log to disk and
+	// to enforce the max age.
+	SetSyncInterval(value time.Duration) SegmentLogOptions
+
+	// RetentionPeriod returns how long segments are retained once every
+	// message in them has been consumed, so consumer services can be rewound.
+	RetentionPeriod() time.Duration
+
+	// SetRetentionPeriod sets how long segments are retained once every
+	// message in them has been consumed.
+	SetRetentionPeriod(value time.Duration) SegmentLogOptions
+
+	// Validate validates the options.
+	Validate() error
+}
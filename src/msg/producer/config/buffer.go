@@ -30,14 +30,15 @@ import (
 
 // BufferConfiguration configs the buffer.
 type BufferConfiguration struct {
-	OnFullStrategy        *buffer.OnFullStrategy `yaml:"onFullStrategy"`
-	MaxBufferSize         *int                   `yaml:"maxBufferSize"`
-	MaxMessageSize        *int                   `yaml:"maxMessageSize"`
-	CloseCheckInterval    *time.Duration         `yaml:"closeCheckInterval"`
-	DropOldestInterval    *time.Duration         `yaml:"dropOldestInterval"`
-	ScanBatchSize         *int                   `yaml:"scanBatchSize"`
-	AllowedSpilloverRatio *float64               `yaml:"allowedSpilloverRatio"`
-	CleanupRetry          *retry.Configuration   `yaml:"cleanupRetry"`
+	OnFullStrategy        *buffer.OnFullStrategy   `yaml:"onFullStrategy"`
+	MaxBufferSize         *int                     `yaml:"maxBufferSize"`
+	MaxMessageSize        *int                     `yaml:"maxMessageSize"`
+	CloseCheckInterval    *time.Duration           `yaml:"closeCheckInterval"`
+	DropOldestInterval    *time.Duration           `yaml:"dropOldestInterval"`
+	ScanBatchSize         *int                     `yaml:"scanBatchSize"`
+	AllowedSpilloverRatio *float64                 `yaml:"allowedSpilloverRatio"`
+	CleanupRetry          *retry.Configuration     `yaml:"cleanupRetry"`
+	SegmentLog            *SegmentLogConfiguration `yaml:"segmentLog"`
 }
 
 // NewOptions creates new buffer options.
@@ -67,5 +68,39 @@ func (c *BufferConfiguration) NewOptions(iOpts instrument.Options) buffer.Option
 	if c.CleanupRetry != nil {
 		opts = opts.SetCleanupRetryOptions(c.CleanupRetry.NewOptions(iOpts.MetricsScope()))
 	}
+	if c.SegmentLog != nil {
+		opts = opts.SetSegmentLogOptions(c.SegmentLog.NewOptions())
+	}
 	return opts.SetInstrumentOptions(iOpts)
 }
+
+// SegmentLogConfiguration configs the segment log of the buffer.
+type SegmentLogConfiguration struct {
+	Path            string         `yaml:"path" validate:"nonzero"`
+	MaxSegmentSize  *int           `yaml:"maxSegmentSize"`
+	MaxLogSize      *int           `yaml:"maxLogSize"`
+	MaxAge          *time.Duration `yaml:"maxAge"`
+	SyncInterval    *time.Duration `yaml:"syncInterval"`
+	RetentionPeriod *time.Duration `yaml:"retentionPeriod"`
+}
+
+// NewOptions creates new segment log options.
+func (c *SegmentLogConfiguration) NewOptions() buffer.SegmentLogOptions {
+	opts := buffer.NewSegmentLogOptions().SetPath(c.Path)
+	if c.MaxSegmentSize != nil {
+		opts = opts.SetMaxSegmentSize(*c.MaxSegmentSize)
+	}
+	if c.MaxLogSize != nil {
+		opts = opts.SetMaxLogSize(*c.MaxLogSize)
+	}
+	if c.MaxAge != nil {
+		opts = opts.SetMaxAge(*c.MaxAge)
+	}
+	if c.SyncInterval != nil {
+		opts = opts.SetSyncInterval(*c.SyncInterval)
+	}
+	if c.RetentionPeriod != nil {
+		opts = opts.SetRetentionPeriod(*c.RetentionPeriod)
+	}
+	return opts
+}
@@ -43,6 +43,13 @@ dropOldestInterval: 500ms
 allowedSpilloverRatio: 0.1
 cleanupRetry:
   initialBackoff: 2s
+segmentLog:
+  path: /var/lib/m3/producer
+  maxSegmentSize: 1024
+  maxLogSize: 4096
+  maxAge: 1h
+  syncInterval: 100ms
+  retentionPeriod: 30m
 `
 
 	var cfg BufferConfiguration
@@ -57,6 +64,12 @@ cleanupRetry:
 	require.Equal(t, 500*time.Millisecond, bOpts.DropOldestInterval())
 	require.Equal(t, 0.1, bOpts.AllowedSpilloverRatio())
 	require.Equal(t, 2*time.Second, bOpts.CleanupRetryOptions().InitialBackoff())
+	require.Equal(t, "/var/lib/m3/producer", bOpts.SegmentLogOptions().Path())
+	require.Equal(t, 1024, bOpts.SegmentLogOptions().MaxSegmentSize())
+	require.Equal(t, 4096, bOpts.SegmentLogOptions().MaxLogSize())
+	require.Equal(t, time.Hour, bOpts.SegmentLogOptions().MaxAge())
+	require.Equal(t, 100*time.Millisecond, bOpts.SegmentLogOptions().SyncInterval())
+	require.Equal(t, 30*time.Minute, bOpts.SegmentLogOptions().RetentionPeriod())
 }
 
 func TestEmptyBufferConfiguration(t *testing.T) {
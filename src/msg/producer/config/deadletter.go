@@ -0,0 +1,194 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/msg/producer/deadletter"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"go.uber.org/zap"
+)
+
+var (
+	errNoDeadLetterSink             = errors.New("no dead letter file or producer")
+	errBothDeadLetterFileOrProducer = errors.New("dead letter file and producer can not both be set")
+	errDeadLetterListenWithoutFile  = errors.New("dead letter listen address requires a dead letter file")
+	errDeadLetterSinkClosed         = errors.New("dead letter sink is closed")
+)
+
+// DeadLetterConfiguration configs the handling of the messages that are not
+// acknowledged by a consumer service after the max write attempts.
+type DeadLetterConfiguration struct {
+	// MaxWriteAttempts is the max number of times a message is written to a
+	// consumer service before it is dead lettered.
+	MaxWriteAttempts int `yaml:"maxWriteAttempts" validate:"min=1"`
+
+	// File persists the dead lettered messages to a local file.
+	File *DeadLetterFileConfiguration `yaml:"file"`
+
+	// Producer produces the dead lettered messages to a separate topic.
+	Producer *ProducerConfiguration `yaml:"producer"`
+
+	// ProducerCloseTimeout is the max time to wait for the dead lettered
+	// messages to be consumed when the producer is closed.
+	ProducerCloseTimeout time.Duration `yaml:"producerCloseTimeout"`
+
+	// ListenAddress is the address to serve the endpoints to inspect and
+	// replay the messages in the dead letter file.
+	ListenAddress string `yaml:"listenAddress"`
+}
+
+// Validate validates the configuration.
+func (c *DeadLetterConfiguration) Validate() error {
+	if c.File == nil && c.Producer == nil {
+		return errNoDeadLetterSink
+	}
+	if c.File != nil && c.Producer != nil {
+		return errBothDeadLetterFileOrProducer
+	}
+	if c.ListenAddress != "" && c.File == nil {
+		return errDeadLetterListenWithoutFile
+	}
+	return nil
+}
+
+// NewSink creates the dead letter sink.
+func (c *DeadLetterConfiguration) NewSink(
+	cs client.Client,
+	iOpts instrument.Options,
+) (deadletter.Sink, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.File != nil {
+		sink, err := deadletter.NewFileSink(c.File.NewOptions(iOpts))
+		if err != nil {
+			return nil, err
+		}
+		if c.ListenAddress == "" {
+			return sink, nil
+		}
+		return &servingSink{FileSink: sink}, nil
+	}
+	p, err := c.Producer.NewProducer(cs, iOpts)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Init(); err != nil {
+		return nil, err
+	}
+	return deadletter.NewProducerSink(p, c.ProducerCloseTimeout), nil
+}
+
+// serve serves the endpoints to inspect and replay the dead lettered messages
+// in the background if a listen address is configured, the server is shut
+// down when the writer closes the sink.
+func (c *DeadLetterConfiguration) serve(
+	sink deadletter.Sink,
+	fn deadletter.ReplayFn,
+	iOpts instrument.Options,
+) error {
+	s, ok := sink.(*servingSink)
+	if !ok {
+		return nil
+	}
+	return s.serve(c.ListenAddress, fn, iOpts)
+}
+
+// servingSink is a file sink that serves the endpoints to inspect and replay
+// its messages until it is closed.
+type servingSink struct {
+	sync.Mutex
+	deadletter.FileSink
+
+	server *http.Server
+	closed bool
+}
+
+func (s *servingSink) serve(
+	address string,
+	fn deadletter.ReplayFn,
+	iOpts instrument.Options,
+) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.closed {
+		return errDeadLetterSinkClosed
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	deadletter.RegisterHandlers(mux, s.FileSink, fn, iOpts)
+	s.server = &http.Server{Handler: mux}
+
+	logger := iOpts.Logger()
+	logger.Info("dead letter server listening", zap.String("address", listener.Addr().String()))
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logger.Error("dead letter server stopped serving",
+				zap.String("address", address), zap.Error(err))
+		}
+	}(s.server)
+	return nil
+}
+
+func (s *servingSink) Close() error {
+	s.Lock()
+	s.closed = true
+	server := s.server
+	s.server = nil
+	s.Unlock()
+
+	// NB: Stop serving first so no replays are in flight once the file is closed.
+	if server != nil {
+		if err := server.Close(); err != nil {
+			s.FileSink.Close()
+			return err
+		}
+	}
+	return s.FileSink.Close()
+}
+
+// DeadLetterFileConfiguration configs the dead letter file.
+type DeadLetterFileConfiguration struct {
+	Path        string `yaml:"path" validate:"nonzero"`
+	MaxFileSize *int64 `yaml:"maxFileSize"`
+}
+
+// NewOptions creates new dead letter file sink options.
+func (c *DeadLetterFileConfiguration) NewOptions(iOpts instrument.Options) deadletter.FileSinkOptions {
+	opts := deadletter.NewFileSinkOptions().
+		SetPath(c.Path).
+		SetInstrumentOptions(iOpts)
+	if c.MaxFileSize != nil {
+		opts = opts.SetMaxFileSize(*c.MaxFileSize)
+	}
+	return opts
+}
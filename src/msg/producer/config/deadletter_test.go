@@ -0,0 +1,108 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
+	"github.com/m3db/m3/src/msg/producer/deadletter"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestDeadLetterConfiguration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deadletter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	str := `
+maxWriteAttempts: 5
+file:
+  path: ` + filepath.Join(dir, "deadletter") + `
+  maxFileSize: 1024
+`
+
+	var cfg DeadLetterConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte(str), &cfg))
+	require.Equal(t, 5, cfg.MaxWriteAttempts)
+	require.Equal(t, int64(1024), cfg.File.NewOptions(instrument.NewOptions()).MaxFileSize())
+
+	sink, err := cfg.NewSink(nil, instrument.NewOptions())
+	require.NoError(t, err)
+	_, ok := sink.(deadletter.FileSink)
+	require.True(t, ok)
+	require.NoError(t, sink.Close())
+}
+
+func TestDeadLetterConfigurationServeUntilSinkClosed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deadletter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	cfg := DeadLetterConfiguration{
+		MaxWriteAttempts: 5,
+		File:             &DeadLetterFileConfiguration{Path: filepath.Join(dir, "deadletter")},
+		ListenAddress:    address,
+	}
+	iOpts := instrument.NewOptions()
+	sink, err := cfg.NewSink(nil, iOpts)
+	require.NoError(t, err)
+	_, ok := sink.(deadletter.FileSink)
+	require.True(t, ok)
+	require.NoError(t, cfg.serve(sink, func(*deadletterpb.Message) error { return nil }, iOpts))
+
+	resp, err := http.Get("http://" + address + deadletter.MessagesPath)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Closing the sink stops the server.
+	require.NoError(t, sink.Close())
+	_, err = http.Get("http://" + address + deadletter.MessagesPath)
+	require.Error(t, err)
+	require.Equal(t, errDeadLetterSinkClosed, cfg.serve(sink, nil, iOpts))
+}
+
+func TestDeadLetterConfigurationValidation(t *testing.T) {
+	cfg := DeadLetterConfiguration{MaxWriteAttempts: 5}
+	require.Equal(t, errNoDeadLetterSink, cfg.Validate())
+
+	cfg.File = &DeadLetterFileConfiguration{Path: "/tmp/deadletter"}
+	cfg.Producer = &ProducerConfiguration{}
+	require.Equal(t, errBothDeadLetterFileOrProducer, cfg.Validate())
+
+	cfg.File = nil
+	cfg.ListenAddress = "localhost:0"
+	require.Equal(t, errDeadLetterListenWithoutFile, cfg.Validate())
+}
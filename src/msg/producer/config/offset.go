@@ -0,0 +1,66 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package config
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/msg/offset"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// OffsetConfiguration configs the tracking of the offsets consumed by the
+// consumer services, which allows consumer services to be rewound.
+type OffsetConfiguration struct {
+	ProducerID     string                   `yaml:"producerID" validate:"nonzero"`
+	CommitInterval *time.Duration           `yaml:"commitInterval"`
+	KVOverride     kv.OverrideConfiguration `yaml:"kvOverride"`
+}
+
+// NewTracker creates a new offset tracker for the topic.
+func (c *OffsetConfiguration) NewTracker(
+	topicName string,
+	cs client.Client,
+	iOpts instrument.Options,
+) (offset.Tracker, error) {
+	kvOpts, err := c.KVOverride.NewOverrideOptions()
+	if err != nil {
+		return nil, err
+	}
+	service, err := offset.NewService(
+		offset.NewServiceOptions().
+			SetConfigService(cs).
+			SetKVOverrideOptions(kvOpts),
+	)
+	if err != nil {
+		return nil, err
+	}
+	opts := offset.NewTrackerOptions().
+		SetTopicName(topicName).
+		SetProducerID(c.ProducerID).
+		SetOffsetService(service).
+		SetInstrumentOptions(iOpts)
+	if c.CommitInterval != nil {
+		opts = opts.SetCommitInterval(*c.CommitInterval)
+	}
+	return offset.NewTracker(opts)
+}
@@ -24,14 +24,17 @@ import (
 	"github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/msg/producer"
 	"github.com/m3db/m3/src/msg/producer/buffer"
+	"github.com/m3db/m3/src/msg/producer/deadletter"
 	"github.com/m3db/m3/src/msg/producer/writer"
 	"github.com/m3db/m3/src/x/instrument"
 )
 
 // ProducerConfiguration configs the producer.
 type ProducerConfiguration struct {
-	Buffer BufferConfiguration `yaml:"buffer"`
-	Writer WriterConfiguration `yaml:"writer"`
+	Buffer     BufferConfiguration      `yaml:"buffer"`
+	Writer     WriterConfiguration      `yaml:"writer"`
+	Offsets    *OffsetConfiguration     `yaml:"offsets"`
+	DeadLetter *DeadLetterConfiguration `yaml:"deadLetter"`
 }
 
 func (c *ProducerConfiguration) newOptions(
@@ -46,9 +49,34 @@ func (c *ProducerConfiguration) newOptions(
 	if err != nil {
 		return nil, err
 	}
-	return producer.NewOptions().
+	opts := producer.NewOptions()
+	if c.Offsets != nil {
+		tracker, err := c.Offsets.NewTracker(c.Writer.TopicName, cs, iOpts)
+		if err != nil {
+			return nil, err
+		}
+		wOpts = wOpts.SetOffsetTracker(tracker)
+		opts = opts.SetOffsetTracker(tracker)
+	}
+	if c.DeadLetter == nil {
+		return opts.
+			SetBuffer(b).
+			SetWriter(writer.NewWriter(wOpts)), nil
+	}
+	sink, err := c.DeadLetter.NewSink(cs, iOpts.SetMetricsScope(iOpts.MetricsScope().SubScope("dead-letter")))
+	if err != nil {
+		return nil, err
+	}
+	w := writer.NewWriter(wOpts.
+		SetMessageMaxWriteAttempts(c.DeadLetter.MaxWriteAttempts).
+		SetDeadLetterSink(sink))
+	if err := c.DeadLetter.serve(sink, deadletter.NewWriterReplayFn(w), iOpts); err != nil {
+		sink.Close()
+		return nil, err
+	}
+	return opts.
 		SetBuffer(b).
-		SetWriter(writer.NewWriter(wOpts)), nil
+		SetWriter(w), nil
 }
 
 // NewProducer creates new producer.
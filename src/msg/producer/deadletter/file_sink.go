@@ -0,0 +1,397 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// NB: Every record is prefixed with the length and the checksum of the
+	// encoded message.
+	recordHeaderLen = 8
+
+	compactTmpSuffix = ".tmp"
+)
+
+var (
+	errFileSinkClosed = errors.New("dead letter file sink closed")
+	errFileSinkFull   = errors.New("dead letter file sink full")
+	errCorruptRecord  = errors.New("corrupt dead letter record")
+)
+
+type fileSinkMetrics struct {
+	written     tally.Counter
+	writeError  tally.Counter
+	droppedFull tally.Counter
+	corruptTail tally.Counter
+	purged      tally.Counter
+	purgeError  tally.Counter
+	fileSize    tally.Gauge
+	messages    tally.Gauge
+}
+
+func newFileSinkMetrics(scope tally.Scope) fileSinkMetrics {
+	return fileSinkMetrics{
+		written:     scope.Counter("written"),
+		writeError:  scope.Counter("write-error"),
+		droppedFull: scope.Counter("dropped-full"),
+		corruptTail: scope.Counter("corrupt-tail"),
+		purged:      scope.Counter("purged"),
+		purgeError:  scope.Counter("purge-error"),
+		fileSize:    scope.Gauge("file-size"),
+		messages:    scope.Gauge("messages"),
+	}
+}
+
+// recordOffset is the offset of the record of a message in the file.
+type recordOffset struct {
+	id     uint64
+	offset int64
+}
+
+type fileSink struct {
+	sync.Mutex
+
+	path        string
+	maxFileSize int64
+	logger      *zap.Logger
+	m           fileSinkMetrics
+
+	fd       *os.File
+	size     int64
+	nextID   uint64
+	records  []recordOffset
+	scratch  []byte
+	isClosed bool
+}
+
+// NewFileSink creates a file sink, the messages persisted by a previous file
+// sink with the same path are kept.
+func NewFileSink(opts FileSinkOptions) (FileSink, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	iOpts := opts.InstrumentOptions()
+	s := &fileSink{
+		path:        opts.Path(),
+		maxFileSize: opts.MaxFileSize(),
+		logger:      iOpts.Logger(),
+		m:           newFileSinkMetrics(iOpts.MetricsScope().SubScope("file-sink")),
+		nextID:      1,
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSink) load() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	// NB: A leftover compacted file means the process crashed mid purge
+	// before the compacted file replaced the file.
+	if err := os.Remove(s.path + compactTmpSuffix); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	fd, err := os.OpenFile(s.path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := fd.Stat()
+	if err != nil {
+		fd.Close()
+		return err
+	}
+	var validLen int64
+	err = readRecords(fd, info.Size(), func(msg *deadletterpb.Message, record []byte) bool {
+		s.records = append(s.records, recordOffset{id: msg.Id, offset: validLen})
+		validLen += int64(len(record))
+		if msg.Id >= s.nextID {
+			s.nextID = msg.Id + 1
+		}
+		return true
+	})
+	if err != nil {
+		// NB: A partially written record at the end of the file is expected
+		// if the process crashed mid write, it is truncated so new records
+		// can be appended.
+		s.m.corruptTail.Inc(1)
+		s.logger.Warn("truncating corrupt dead letter records",
+			zap.String("path", s.path), zap.Int64("bytes", info.Size()-validLen), zap.Error(err))
+	}
+	if err := fd.Truncate(validLen); err != nil {
+		fd.Close()
+		return err
+	}
+	if _, err := fd.Seek(validLen, 0); err != nil {
+		fd.Close()
+		return err
+	}
+	s.fd = fd
+	s.size = validLen
+	s.updateGaugesWithLock()
+	return nil
+}
+
+func (s *fileSink) Write(msg *deadletterpb.Message) error {
+	s.Lock()
+	defer s.Unlock()
+	if s.isClosed {
+		return errFileSinkClosed
+	}
+
+	msg.Id = s.nextID
+	size := msg.Size()
+	if s.maxFileSize > 0 && s.size+int64(recordHeaderLen+size) > s.maxFileSize {
+		s.m.droppedFull.Inc(1)
+		return errFileSinkFull
+	}
+	if cap(s.scratch) < recordHeaderLen+size {
+		s.scratch = make([]byte, recordHeaderLen+size)
+	}
+	record := s.scratch[:recordHeaderLen+size]
+	if _, err := msg.MarshalTo(record[recordHeaderLen:]); err != nil {
+		s.m.writeError.Inc(1)
+		return err
+	}
+	binary.BigEndian.PutUint32(record, uint32(size))
+	binary.BigEndian.PutUint32(record[4:], crc32.ChecksumIEEE(record[recordHeaderLen:]))
+	if _, err := s.fd.Write(record); err != nil {
+		s.m.writeError.Inc(1)
+		// NB: Drop the partially written record so the records written after
+		// it can still be read.
+		if err := s.fd.Truncate(s.size); err == nil {
+			s.fd.Seek(s.size, 0) // nolint: errcheck
+		}
+		return err
+	}
+	s.records = append(s.records, recordOffset{id: msg.Id, offset: s.size})
+	s.size += int64(len(record))
+	s.nextID++
+	s.m.written.Inc(1)
+	s.updateGaugesWithLock()
+	return nil
+}
+
+func (s *fileSink) Read(fromID uint64, limit int) ([]*deadletterpb.Message, error) {
+	s.Lock()
+	// NB: Only read the records written so far, a record could be in the
+	// middle of being written. The file is opened with the lock held so that
+	// the offsets match the file even if it is compacted concurrently.
+	var (
+		size  = s.size
+		start = sort.Search(len(s.records), func(i int) bool {
+			return s.records[i].id >= fromID
+		})
+		offset = size
+	)
+	if start < len(s.records) {
+		offset = s.records[start].offset
+	}
+	fd, err := os.Open(s.path)
+	s.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	var msgs []*deadletterpb.Message
+	err = readRecords(io.NewSectionReader(fd, offset, size-offset), size-offset,
+		func(msg *deadletterpb.Message, _ []byte) bool {
+			msgs = append(msgs, msg)
+			return limit <= 0 || len(msgs) < limit
+		})
+	return msgs, err
+}
+
+// Purge removes the messages with the ids by compacting the file.
+func (s *fileSink) Purge(ids []uint64) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.isClosed {
+		return 0, errFileSinkClosed
+	}
+
+	purge := make(map[uint64]struct{}, len(ids))
+	for _, id := range ids {
+		purge[id] = struct{}{}
+	}
+	var purged int
+	for _, r := range s.records {
+		if _, ok := purge[r.id]; ok {
+			purged++
+		}
+	}
+	if purged == 0 {
+		return 0, nil
+	}
+
+	if err := s.compactWithLock(purge); err != nil {
+		s.m.purgeError.Inc(1)
+		return 0, err
+	}
+	s.m.purged.Inc(int64(purged))
+	s.updateGaugesWithLock()
+	return purged, nil
+}
+
+// compactWithLock rewrites the file without the records of the purged
+// messages and atomically replaces the file with it.
+func (s *fileSink) compactWithLock(purge map[uint64]struct{}) error {
+	src, err := os.Open(s.path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmpPath := s.path + compactTmpSuffix
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	success := false
+	defer func() {
+		if !success {
+			dst.Close()
+			os.Remove(tmpPath) // nolint: errcheck
+		}
+	}()
+
+	var (
+		w        = bufio.NewWriter(dst)
+		records  = make([]recordOffset, 0, len(s.records))
+		size     int64
+		writeErr error
+	)
+	err = readRecords(io.NewSectionReader(src, 0, s.size), s.size,
+		func(msg *deadletterpb.Message, record []byte) bool {
+			if _, ok := purge[msg.Id]; ok {
+				return true
+			}
+			if _, writeErr = w.Write(record); writeErr != nil {
+				return false
+			}
+			records = append(records, recordOffset{id: msg.Id, offset: size})
+			size += int64(len(record))
+			return true
+		})
+	if err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := dst.Sync(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+
+	// NB: The compacted file remains open for appending new records.
+	success = true
+	s.fd.Close() // nolint: errcheck
+	s.fd = dst
+	s.size = size
+	s.records = records
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.isClosed {
+		return nil
+	}
+	s.isClosed = true
+	return s.fd.Close()
+}
+
+func (s *fileSink) updateGaugesWithLock() {
+	s.m.fileSize.Update(float64(s.size))
+	s.m.messages.Update(float64(len(s.records)))
+}
+
+// readRecords reads the records in the first size bytes of the reader until
+// the function returns false, the function is called with the message and its
+// encoded record which is only valid until the function returns.
+func readRecords(
+	r io.Reader,
+	size int64,
+	fn func(msg *deadletterpb.Message, record []byte) bool,
+) error {
+	var (
+		br  = bufio.NewReader(r)
+		buf = make([]byte, recordHeaderLen)
+	)
+	for size > 0 {
+		if size < recordHeaderLen {
+			return errCorruptRecord
+		}
+		if _, err := io.ReadFull(br, buf[:recordHeaderLen]); err != nil {
+			return err
+		}
+		n := recordHeaderLen + int64(binary.BigEndian.Uint32(buf))
+		if n > size {
+			return errCorruptRecord
+		}
+		if int64(cap(buf)) < n {
+			grown := make([]byte, n)
+			copy(grown, buf[:recordHeaderLen])
+			buf = grown
+		}
+		record := buf[:n]
+		payload := record[recordHeaderLen:]
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return err
+		}
+		if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(record[4:]) {
+			return errCorruptRecord
+		}
+		var msg deadletterpb.Message
+		if err := msg.Unmarshal(payload); err != nil {
+			return err
+		}
+		size -= n
+		if !fn(&msg, record) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSinkWriteRead(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	opts := NewFileSinkOptions().SetPath(filepath.Join(dir, "sub", "deadletter"))
+	s := mustNewFileSink(t, opts)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.Write(testMessage(uint32(i))))
+	}
+
+	msgs, err := s.Read(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3}, testMessageIDs(msgs))
+	require.Equal(t, []byte("foo"), msgs[0].Value)
+	require.Equal(t, uint32(2), msgs[2].Shard)
+
+	msgs, err = s.Read(2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, testMessageIDs(msgs))
+	require.NoError(t, s.Close())
+	require.Error(t, s.Write(testMessage(0)))
+
+	// The ids continue after the messages persisted before.
+	s = mustNewFileSink(t, opts)
+	defer s.Close()
+	require.NoError(t, s.Write(testMessage(3)))
+	msgs, err = s.Read(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3, 4}, testMessageIDs(msgs))
+}
+
+func TestFileSinkTruncatesCorruptTail(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	opts := NewFileSinkOptions().SetPath(filepath.Join(dir, "deadletter"))
+	s := mustNewFileSink(t, opts)
+	require.NoError(t, s.Write(testMessage(1)))
+	require.NoError(t, s.Close())
+
+	fd, err := os.OpenFile(opts.Path(), os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = fd.Write([]byte{0, 0, 0, 10, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	s = mustNewFileSink(t, opts)
+	defer s.Close()
+	require.NoError(t, s.Write(testMessage(2)))
+	msgs, err := s.Read(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, testMessageIDs(msgs))
+}
+
+func TestFileSinkFull(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	// NB: The size includes the id assigned by the sink.
+	msg := testMessage(1)
+	msg.Id = 1
+	opts := NewFileSinkOptions().
+		SetPath(filepath.Join(dir, "deadletter")).
+		SetMaxFileSize(int64(recordHeaderLen + msg.Size() + 1))
+	s := mustNewFileSink(t, opts)
+	defer s.Close()
+
+	require.NoError(t, s.Write(msg))
+	require.Equal(t, errFileSinkFull, s.Write(testMessage(2)))
+	msgs, err := s.Read(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, testMessageIDs(msgs))
+}
+
+func TestFileSinkPurge(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	// NB: The size includes the id assigned by the sink.
+	msg := testMessage(1)
+	msg.Id = 1
+	opts := NewFileSinkOptions().
+		SetPath(filepath.Join(dir, "deadletter")).
+		SetMaxFileSize(int64(3 * (recordHeaderLen + msg.Size())))
+	s := mustNewFileSink(t, opts)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.Write(testMessage(uint32(i))))
+	}
+	require.Equal(t, errFileSinkFull, s.Write(testMessage(3)))
+
+	purged, err := s.Purge([]uint64{2, 5})
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	purged, err = s.Purge([]uint64{2})
+	require.NoError(t, err)
+	require.Equal(t, 0, purged)
+
+	// The purged message frees space for new messages.
+	require.NoError(t, s.Write(testMessage(3)))
+	msgs, err := s.Read(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 3, 4}, testMessageIDs(msgs))
+	msgs, err = s.Read(2, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3}, testMessageIDs(msgs))
+	require.Equal(t, uint32(2), msgs[0].Shard)
+	require.NoError(t, s.Close())
+
+	// The purged messages stay purged after reopening.
+	s = mustNewFileSink(t, opts)
+	defer s.Close()
+	msgs, err = s.Read(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 3, 4}, testMessageIDs(msgs))
+	purged, err = s.Purge([]uint64{1, 3, 4})
+	require.NoError(t, err)
+	require.Equal(t, 3, purged)
+	msgs, err = s.Read(0, 0)
+	require.NoError(t, err)
+	require.Empty(t, msgs)
+}
+
+func TestFileSinkOptionsValidation(t *testing.T) {
+	_, err := NewFileSink(NewFileSinkOptions())
+	require.Equal(t, errNoFileSinkPath, err)
+}
+
+func newTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "deadletter")
+	require.NoError(t, err)
+	return dir
+}
+
+func mustNewFileSink(t *testing.T, opts FileSinkOptions) FileSink {
+	s, err := NewFileSink(opts)
+	require.NoError(t, err)
+	return s
+}
+
+func testMessage(shard uint32) *deadletterpb.Message {
+	return &deadletterpb.Message{
+		TopicName:       "topic",
+		ConsumerService: "cs",
+		Shard:           shard,
+		Value:           []byte("foo"),
+		Reason:          "reason",
+		WriteAttempts:   3,
+	}
+}
+
+func testMessageIDs(msgs []*deadletterpb.Message) []uint64 {
+	ids := make([]uint64, 0, len(msgs))
+	for _, msg := range msgs {
+		ids = append(ids, msg.Id)
+	}
+	return ids
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+// A list of HTTP endpoints.
+const (
+	MessagesPath = "/deadletter/messages"
+	ReplayPath   = "/deadletter/replay"
+	PurgePath    = "/deadletter/purge"
+
+	fromParam  = "from"
+	limitParam = "limit"
+
+	defaultLimit = 100
+)
+
+var (
+	errRequestMustBeGet  = errors.New("request must be GET")
+	errRequestMustBePost = errors.New("request must be POST")
+	errNoIDs             = errors.New("must specify the ids of the messages")
+)
+
+// ReplayRequest is the request to replay dead lettered messages.
+type ReplayRequest struct {
+	IDs []uint64 `json:"ids"`
+}
+
+// ReplayResponse is the response of replaying dead lettered messages.
+type ReplayResponse struct {
+	Replayed   []uint64          `json:"replayed"`
+	Errors     map[uint64]string `json:"errors,omitempty"`
+	PurgeError string            `json:"purgeError,omitempty"`
+}
+
+// PurgeRequest is the request to purge dead lettered messages.
+type PurgeRequest struct {
+	IDs []uint64 `json:"ids"`
+}
+
+// PurgeResponse is the response of purging dead lettered messages.
+type PurgeResponse struct {
+	Purged int `json:"purged"`
+}
+
+// RegisterHandlers registers the handlers to inspect the dead lettered
+// messages read by the reader, to replay them with the function and to purge
+// them, the replayed messages are purged.
+func RegisterHandlers(
+	mux *http.ServeMux,
+	r Reader,
+	fn ReplayFn,
+	iOpts instrument.Options,
+) {
+	logger := iOpts.Logger()
+	mux.HandleFunc(MessagesPath, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			xhttp.Error(w, errRequestMustBeGet, http.StatusMethodNotAllowed)
+			return
+		}
+		fromID, limit, err := parseReadParams(req)
+		if err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		msgs, err := r.Read(fromID, limit)
+		if err != nil {
+			logger.Error("unable to read dead letter messages", zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		xhttp.WriteProtoMsgJSONResponse(w, &deadletterpb.Messages{Messages: msgs}, logger)
+	})
+	mux.HandleFunc(ReplayPath, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			xhttp.Error(w, errRequestMustBePost, http.StatusMethodNotAllowed)
+			return
+		}
+		defer req.Body.Close()
+		var replayReq ReplayRequest
+		if err := json.NewDecoder(req.Body).Decode(&replayReq); err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		if len(replayReq.IDs) == 0 {
+			xhttp.Error(w, errNoIDs, http.StatusBadRequest)
+			return
+		}
+		resp, err := replay(r, fn, replayReq.IDs)
+		if err != nil {
+			logger.Error("unable to read dead letter messages", zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		// NB: Purge the replayed messages so that they are not replayed again,
+		// the messages that failed to replay are kept.
+		if len(resp.Replayed) > 0 {
+			if _, err := r.Purge(resp.Replayed); err != nil {
+				logger.Error("unable to purge replayed dead letter messages", zap.Error(err))
+				resp.PurgeError = err.Error()
+			}
+		}
+		logger.Info("replayed dead letter messages",
+			zap.Int("replayed", len(resp.Replayed)), zap.Int("errors", len(resp.Errors)))
+		xhttp.WriteJSONResponse(w, resp, logger)
+	})
+	mux.HandleFunc(PurgePath, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			xhttp.Error(w, errRequestMustBePost, http.StatusMethodNotAllowed)
+			return
+		}
+		defer req.Body.Close()
+		var purgeReq PurgeRequest
+		if err := json.NewDecoder(req.Body).Decode(&purgeReq); err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		if len(purgeReq.IDs) == 0 {
+			xhttp.Error(w, errNoIDs, http.StatusBadRequest)
+			return
+		}
+		purged, err := r.Purge(purgeReq.IDs)
+		if err != nil {
+			logger.Error("unable to purge dead letter messages", zap.Error(err))
+			xhttp.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+		logger.Info("purged dead letter messages", zap.Int("purged", purged))
+		xhttp.WriteJSONResponse(w, PurgeResponse{Purged: purged}, logger)
+	})
+}
+
+func parseReadParams(req *http.Request) (uint64, int, error) {
+	var (
+		fromID uint64
+		limit  = defaultLimit
+		err    error
+	)
+	if str := req.URL.Query().Get(fromParam); str != "" {
+		if fromID, err = strconv.ParseUint(str, 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("invalid %s: %v", fromParam, err)
+		}
+	}
+	if str := req.URL.Query().Get(limitParam); str != "" {
+		if limit, err = strconv.Atoi(str); err != nil {
+			return 0, 0, fmt.Errorf("invalid %s: %v", limitParam, err)
+		}
+	}
+	return fromID, limit, nil
+}
+
+func replay(r Reader, fn ReplayFn, ids []uint64) (ReplayResponse, error) {
+	var (
+		resp    ReplayResponse
+		pending = make(map[uint64]struct{}, len(ids))
+		minID   = ids[0]
+	)
+	for _, id := range ids {
+		pending[id] = struct{}{}
+		if id < minID {
+			minID = id
+		}
+	}
+	msgs, err := r.Read(minID, 0)
+	if err != nil {
+		return resp, err
+	}
+	for _, msg := range msgs {
+		if _, ok := pending[msg.Id]; !ok {
+			continue
+		}
+		delete(pending, msg.Id)
+		if err := fn(msg); err != nil {
+			resp.addError(msg.Id, err)
+			continue
+		}
+		resp.Replayed = append(resp.Replayed, msg.Id)
+	}
+	for id := range pending {
+		resp.addError(id, errors.New("message not found"))
+	}
+	return resp, nil
+}
+
+func (r *ReplayResponse) addError(id uint64, err error) {
+	if r.Errors == nil {
+		r.Errors = make(map[uint64]string)
+	}
+	r.Errors[id] = err.Error()
+}
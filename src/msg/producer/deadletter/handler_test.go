@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlers(t *testing.T) {
+	dir := newTestDir(t)
+	defer os.RemoveAll(dir)
+
+	s := mustNewFileSink(t, NewFileSinkOptions().SetPath(filepath.Join(dir, "deadletter")))
+	defer s.Close()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.Write(testMessage(uint32(i))))
+	}
+
+	var replayed []uint64
+	replayFn := func(msg *deadletterpb.Message) error {
+		if msg.Id == 3 {
+			return errors.New("boom")
+		}
+		replayed = append(replayed, msg.Id)
+		return nil
+	}
+	mux := http.NewServeMux()
+	RegisterHandlers(mux, s, replayFn, instrument.NewOptions())
+
+	// List the messages.
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, MessagesPath+"?from=2&limit=1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var msgs deadletterpb.Messages
+	require.NoError(t, jsonpb.Unmarshal(w.Body, &msgs))
+	require.Equal(t, []uint64{2}, testMessageIDs(msgs.Messages))
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, MessagesPath+"?from=foo", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Replay the messages.
+	w = httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"ids":[3,1,5]}`)
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, ReplayPath, body))
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp ReplayResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, []uint64{1}, resp.Replayed)
+	require.Equal(t, []uint64{1}, replayed)
+	require.Equal(t, "boom", resp.Errors[3])
+	require.Equal(t, "message not found", resp.Errors[5])
+	require.Empty(t, resp.PurgeError)
+
+	// The replayed messages are purged.
+	remaining, err := s.Read(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 3}, testMessageIDs(remaining))
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, ReplayPath, strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ReplayPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	// Purge the messages.
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, PurgePath, strings.NewReader(`{"ids":[3,4]}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	var purgeResp PurgeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&purgeResp))
+	require.Equal(t, 1, purgeResp.Purged)
+	remaining, err = s.Read(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, testMessageIDs(remaining))
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, PurgePath, strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
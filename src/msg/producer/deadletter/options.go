@@ -0,0 +1,86 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"errors"
+
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultMaxFileSize = 64 * 1024 * 1024
+)
+
+var (
+	errNoFileSinkPath = errors.New("no dead letter file path")
+)
+
+type fileSinkOptions struct {
+	path        string
+	maxFileSize int64
+	iOpts       instrument.Options
+}
+
+// NewFileSinkOptions creates FileSinkOptions.
+func NewFileSinkOptions() FileSinkOptions {
+	return &fileSinkOptions{
+		maxFileSize: defaultMaxFileSize,
+		iOpts:       instrument.NewOptions(),
+	}
+}
+
+func (opts *fileSinkOptions) Path() string {
+	return opts.path
+}
+
+func (opts *fileSinkOptions) SetPath(value string) FileSinkOptions {
+	o := *opts
+	o.path = value
+	return &o
+}
+
+func (opts *fileSinkOptions) MaxFileSize() int64 {
+	return opts.maxFileSize
+}
+
+func (opts *fileSinkOptions) SetMaxFileSize(value int64) FileSinkOptions {
+	o := *opts
+	o.maxFileSize = value
+	return &o
+}
+
+func (opts *fileSinkOptions) InstrumentOptions() instrument.Options {
+	return opts.iOpts
+}
+
+func (opts *fileSinkOptions) SetInstrumentOptions(value instrument.Options) FileSinkOptions {
+	o := *opts
+	o.iOpts = value
+	return &o
+}
+
+func (opts *fileSinkOptions) Validate() error {
+	if opts.path == "" {
+		return errNoFileSinkPath
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
+	"github.com/m3db/m3/src/msg/producer"
+
+	"go.uber.org/atomic"
+)
+
+const (
+	defaultProducerSinkCloseTimeout = 10 * time.Second
+)
+
+type producerSink struct {
+	p            producer.Producer
+	closeTimeout time.Duration
+	pending      sync.WaitGroup
+}
+
+// NewProducerSink creates a sink producing the dead lettered messages to the
+// topic of the producer, the producer must have been initialized. Closing the
+// sink waits at most the close timeout for the messages to be consumed before
+// dropping them, a default timeout is used if the timeout is not positive.
+func NewProducerSink(p producer.Producer, closeTimeout time.Duration) Sink {
+	if closeTimeout <= 0 {
+		closeTimeout = defaultProducerSinkCloseTimeout
+	}
+	return &producerSink{p: p, closeTimeout: closeTimeout}
+}
+
+func (s *producerSink) Write(msg *deadletterpb.Message) error {
+	b, err := msg.Marshal()
+	if err != nil {
+		return err
+	}
+	// NB: The dead letter topic could have a different number of shards.
+	m := &pendingMessage{
+		message: message{shard: msg.Shard % s.p.NumShards(), bytes: b},
+		pending: &s.pending,
+	}
+	s.pending.Add(1)
+	if err := s.p.Produce(m); err != nil {
+		// NB: The message is only finalized if it was buffered.
+		m.done()
+		return err
+	}
+	return nil
+}
+
+func (s *producerSink) Close() error {
+	// NB: The producer is not closed with WaitForConsumption since the close
+	// of the writer dead lettering the messages would hang if the consumers
+	// of the dead letter topic were down.
+	consumed := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(consumed)
+	}()
+	select {
+	case <-consumed:
+		s.p.Close(producer.WaitForConsumption)
+	case <-time.After(s.closeTimeout):
+		s.p.Close(producer.DropEverything)
+	}
+	return nil
+}
+
+// NewWriterReplayFn returns a function replaying the dead lettered messages
+// to their consumer services with the writer.
+func NewWriterReplayFn(w producer.Writer) ReplayFn {
+	return func(msg *deadletterpb.Message) error {
+		rm := producer.NewRefCountedMessage(newMessage(msg.Shard, msg.Value), nil)
+		return w.ReplayTo(msg.ConsumerService, rm)
+	}
+}
+
+type message struct {
+	shard uint32
+	bytes []byte
+}
+
+func newMessage(shard uint32, bytes []byte) producer.Message {
+	return message{shard: shard, bytes: bytes}
+}
+
+func (m message) Shard() uint32 {
+	return m.shard
+}
+
+func (m message) Bytes() []byte {
+	return m.bytes
+}
+
+func (m message) Size() int {
+	return len(m.bytes)
+}
+
+func (m message) Finalize(producer.FinalizeReason) {}
+
+// pendingMessage is a message tracked by the sink until it is finalized.
+type pendingMessage struct {
+	message
+
+	pending *sync.WaitGroup
+	isDone  atomic.Bool
+}
+
+func (m *pendingMessage) Finalize(producer.FinalizeReason) {
+	m.done()
+}
+
+func (m *pendingMessage) done() {
+	if m.isDone.CAS(false, true) {
+		m.pending.Done()
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
+	"github.com/m3db/m3/src/msg/producer"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProducerSink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := producer.NewMockProducer(ctrl)
+	s := NewProducerSink(p, 0)
+
+	msg := testMessage(5)
+	p.EXPECT().NumShards().Return(uint32(4))
+	p.EXPECT().Produce(gomock.Any()).DoAndReturn(func(m producer.Message) error {
+		require.Equal(t, uint32(1), m.Shard())
+		var decoded deadletterpb.Message
+		require.NoError(t, decoded.Unmarshal(m.Bytes()))
+		require.Equal(t, *msg, decoded)
+		m.Finalize(producer.Consumed)
+		return nil
+	})
+	require.NoError(t, s.Write(msg))
+
+	p.EXPECT().Close(producer.WaitForConsumption)
+	require.NoError(t, s.Close())
+}
+
+func TestProducerSinkCloseDropsAfterTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := producer.NewMockProducer(ctrl)
+	s := NewProducerSink(p, 10*time.Millisecond)
+
+	// NB: The message is never consumed.
+	p.EXPECT().NumShards().Return(uint32(4)).Times(2)
+	p.EXPECT().Produce(gomock.Any()).Return(nil)
+	require.NoError(t, s.Write(testMessage(1)))
+
+	// A message that failed to be produced is not waited for.
+	p.EXPECT().Produce(gomock.Any()).Return(errTestProduce)
+	require.Equal(t, errTestProduce, s.Write(testMessage(2)))
+
+	p.EXPECT().Close(producer.DropEverything)
+	require.NoError(t, s.Close())
+}
+
+func TestWriterReplayFn(t *testing.T) {
+	w := &testReplayWriter{}
+	fn := NewWriterReplayFn(w)
+
+	require.NoError(t, fn(testMessage(5)))
+	require.Equal(t, "cs", w.consumerService)
+	require.Equal(t, uint32(5), w.rm.Shard())
+	require.Equal(t, []byte("foo"), w.rm.Bytes())
+}
+
+var errTestProduce = errors.New("test produce error")
+
+type testReplayWriter struct {
+	producer.Writer
+
+	consumerService string
+	rm              *producer.RefCountedMessage
+}
+
+func (w *testReplayWriter) ReplayTo(consumerService string, rm *producer.RefCountedMessage) error {
+	w.consumerService = consumerService
+	w.rm = rm
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package deadletter
+
+import (
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Sink receives the messages that could not be delivered to a consumer
+// service.
+type Sink interface {
+	// Write writes a dead lettered message.
+	Write(msg *deadletterpb.Message) error
+
+	// Close closes the sink.
+	Close() error
+}
+
+// Reader reads and purges the dead lettered messages.
+type Reader interface {
+	// Read returns at most limit messages with ids no less than fromID, all
+	// the messages are returned if limit is not positive.
+	Read(fromID uint64, limit int) ([]*deadletterpb.Message, error)
+
+	// Purge removes the messages with the ids and returns the number of
+	// messages removed.
+	Purge(ids []uint64) (int, error)
+}
+
+// FileSink persists the dead lettered messages to a local file so that they
+// can be inspected and replayed.
+type FileSink interface {
+	Sink
+	Reader
+}
+
+// ReplayFn replays a dead lettered message to its consumer service.
+type ReplayFn func(msg *deadletterpb.Message) error
+
+// FileSinkOptions configs the file sink.
+type FileSinkOptions interface {
+	// Path returns the path of the file.
+	Path() string
+
+	// SetPath sets the path of the file.
+	SetPath(value string) FileSinkOptions
+
+	// MaxFileSize returns the max size of the file in bytes, messages are
+	// dropped once the file is full until messages are replayed or purged,
+	// the size is unlimited if not positive.
+	MaxFileSize() int64
+
+	// SetMaxFileSize sets the max size of the file in bytes.
+	SetMaxFileSize(value int64) FileSinkOptions
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) FileSinkOptions
+
+	// Validate validates the options.
+	Validate() error
+}
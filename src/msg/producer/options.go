@@ -20,9 +20,14 @@
 
 package producer
 
+import (
+	"github.com/m3db/m3/src/msg/offset"
+)
+
 type producerOptions struct {
-	buffer Buffer
-	writer Writer
+	buffer        Buffer
+	writer        Writer
+	offsetTracker offset.Tracker
 }
 
 // NewOptions creates a Options.
@@ -49,3 +54,13 @@ func (opts *producerOptions) SetWriter(value Writer) Options {
 	o.writer = value
 	return &o
 }
+
+func (opts *producerOptions) OffsetTracker() offset.Tracker {
+	return opts.offsetTracker
+}
+
+func (opts *producerOptions) SetOffsetTracker(value offset.Tracker) Options {
+	o := *opts
+	o.offsetTracker = value
+	return &o
+}
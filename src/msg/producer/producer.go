@@ -20,22 +20,48 @@
 
 package producer
 
+import (
+	"github.com/m3db/m3/src/msg/generated/proto/offsetpb"
+	"github.com/m3db/m3/src/msg/offset"
+)
+
 type producer struct {
 	Buffer
 	Writer
+
+	offsetTracker offset.Tracker
 }
 
 // NewProducer returns a new producer.
 func NewProducer(opts Options) Producer {
 	return &producer{
-		Buffer: opts.Buffer(),
-		Writer: opts.Writer(),
+		Buffer:        opts.Buffer(),
+		Writer:        opts.Writer(),
+		offsetTracker: opts.OffsetTracker(),
 	}
 }
 
 func (p *producer) Init() error {
 	p.Buffer.Init()
-	return p.Writer.Init()
+	if err := p.Writer.Init(); err != nil {
+		return err
+	}
+	// NB: Messages can only be replayed once the writer has been initialized.
+	if err := p.Buffer.Replay(p.Writer.Write); err != nil {
+		return err
+	}
+	if p.offsetTracker == nil {
+		return nil
+	}
+	return p.offsetTracker.Init(p.rewind)
+}
+
+// rewind writes the messages retained by the buffer since the reset out to
+// the consumer service of the reset again.
+func (p *producer) rewind(reset *offsetpb.Reset) error {
+	return p.Buffer.Rewind(reset.Offset, reset.TimestampNanos, func(rm *RefCountedMessage) error {
+		return p.Writer.ReplayTo(reset.ConsumerService, rm)
+	})
 }
 
 func (p *producer) Produce(m Message) error {
@@ -54,4 +80,8 @@ func (p *producer) Close(ct CloseType) {
 	p.Buffer.Close(ct)
 	// Then we can close writer to clean up outstanding go routines.
 	p.Writer.Close()
+	// Commit the offsets acknowledged before the writer was closed.
+	if p.offsetTracker != nil {
+		p.offsetTracker.Close()
+	}
 }
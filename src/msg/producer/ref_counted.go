@@ -34,8 +34,11 @@ type RefCountedMessage struct {
 	sync.RWMutex
 	Message
 
-	size         uint64
-	onFinalizeFn OnFinalizeFn
+	size           uint64
+	onFinalizeFn   OnFinalizeFn
+	finalizeReason FinalizeReason
+	offset         uint64
+	hasOffset      bool
 
 	refCount            *atomic.Int32
 	isDroppedOrConsumed *atomic.Bool
@@ -52,6 +55,19 @@ func NewRefCountedMessage(m Message, fn OnFinalizeFn) *RefCountedMessage {
 	}
 }
 
+// SetOffset sets the offset of the message in the log of the buffer, it must
+// be set before the message is written.
+func (rm *RefCountedMessage) SetOffset(value uint64) {
+	rm.offset = value
+	rm.hasOffset = true
+}
+
+// Offset returns the offset of the message in the log of the buffer and
+// whether the message has an offset.
+func (rm *RefCountedMessage) Offset() (uint64, bool) {
+	return rm.offset, rm.hasOffset
+}
+
 // Accept returns true if the message can be accepted by the filter.
 func (rm *RefCountedMessage) Accept(fn FilterFunc) bool {
 	return fn(rm.Message)
@@ -99,6 +115,15 @@ func (rm *RefCountedMessage) IsDroppedOrConsumed() bool {
 	return rm.isDroppedOrConsumed.Load()
 }
 
+// FinalizeReason returns the reason the message was finalized for, it is only
+// meaningful once the message has been dropped or consumed.
+func (rm *RefCountedMessage) FinalizeReason() FinalizeReason {
+	rm.RLock()
+	r := rm.finalizeReason
+	rm.RUnlock()
+	return r
+}
+
 func (rm *RefCountedMessage) finalize(r FinalizeReason) bool {
 	// NB: This lock prevents the message from being finalized when its still
 	// being read.
@@ -107,6 +132,7 @@ func (rm *RefCountedMessage) finalize(r FinalizeReason) bool {
 		rm.Unlock()
 		return false
 	}
+	rm.finalizeReason = r
 	rm.isDroppedOrConsumed.Store(true)
 	rm.Unlock()
 	if rm.onFinalizeFn != nil {
@@ -22,6 +22,7 @@ package producer
 
 import (
 	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/offset"
 )
 
 // FinalizeReason defines the reason why the message is being finalized by Producer.
@@ -101,6 +102,14 @@ type Options interface {
 
 	// SetWriter sets the writer.
 	SetWriter(value Writer) Options
+
+	// OffsetTracker returns the tracker of the offsets consumed by the
+	// consumer services, consumer services can not be rewound when not set.
+	OffsetTracker() offset.Tracker
+
+	// SetOffsetTracker sets the tracker of the offsets consumed by the
+	// consumer services, it should also be set on the writer.
+	SetOffsetTracker(value offset.Tracker) Options
 }
 
 // Buffer buffers all the messages in the producer.
@@ -108,6 +117,16 @@ type Buffer interface {
 	// Add adds message to the buffer and returns a reference counted message.
 	Add(m Message) (*RefCountedMessage, error)
 
+	// Replay adds the messages that were persisted by the buffer but not
+	// consumed before the process restarted back to the buffer, and passes
+	// each of them to the provided function.
+	Replay(fn func(rm *RefCountedMessage) error) error
+
+	// Rewind adds the messages still retained by the buffer from the offset
+	// that were produced at or after the time back to the buffer, and passes
+	// each of them to the provided function.
+	Rewind(fromOffset uint64, fromNanos int64, fn func(rm *RefCountedMessage) error) error
+
 	// Init initializes the buffer.
 	Init()
 
@@ -122,6 +141,10 @@ type Writer interface {
 	// Write writes a reference counted message out.
 	Write(rm *RefCountedMessage) error
 
+	// ReplayTo writes a reference counted message out to the consumer services
+	// with the given name only.
+	ReplayTo(consumerService string, rm *RefCountedMessage) error
+
 	// RegisterFilter registers a filter to a consumer service.
 	RegisterFilter(sid services.ServiceID, fn FilterFunc)
 
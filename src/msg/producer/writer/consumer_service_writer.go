@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/placement"
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
 	"github.com/m3db/m3/src/msg/producer"
 	"github.com/m3db/m3/src/msg/topic"
 	"github.com/m3db/m3/src/x/watch"
@@ -132,11 +133,16 @@ func newConsumerServiceWriter(
 	if ct == topic.Unknown {
 		return nil, errUnknownConsumptionType
 	}
-	router := newAckRouter(int(numShards))
+	var (
+		router = newAckRouter(int(numShards))
+		sws    = initShardWriters(
+			router, ct, numShards, opts, newOffsetAckFn(cs, opts), newDeadLetterFn(cs, opts),
+		)
+	)
 	w := &consumerServiceWriterImpl{
 		cs:              cs,
 		ps:              ps,
-		shardWriters:    initShardWriters(router, ct, numShards, opts),
+		shardWriters:    sws,
 		opts:            opts,
 		logger:          opts.InstrumentOptions().Logger(),
 		dataFilter:      acceptAllFilter,
@@ -156,6 +162,8 @@ func initShardWriters(
 	ct topic.ConsumptionType,
 	numberOfShards uint32,
 	opts Options,
+	fn onAckFn,
+	dlFn deadLetterFn,
 ) []shardWriter {
 	var (
 		sws = make([]shardWriter, numberOfShards)
@@ -172,14 +180,51 @@ func initShardWriters(
 	for i := range sws {
 		switch ct {
 		case topic.Shared:
-			sws[i] = newSharedShardWriter(uint32(i), router, mPool, opts, m)
+			sws[i] = newSharedShardWriter(uint32(i), router, mPool, opts, m, fn, dlFn)
 		case topic.Replicated:
-			sws[i] = newReplicatedShardWriter(uint32(i), numberOfShards, router, mPool, opts, m)
+			sws[i] = newReplicatedShardWriter(uint32(i), numberOfShards, router, mPool, opts, m, fn, dlFn)
 		}
 	}
 	return sws
 }
 
+// newOffsetAckFn returns the function that tracks the offsets of the messages
+// acknowledged by the consumer service, or nil if offsets are not tracked.
+func newOffsetAckFn(cs topic.ConsumerService, opts Options) onAckFn {
+	tracker := opts.OffsetTracker()
+	if tracker == nil {
+		return nil
+	}
+	name := cs.ServiceID().Name()
+	return func(rm *producer.RefCountedMessage) {
+		if offset, ok := rm.Offset(); ok {
+			tracker.Ack(name, rm.Shard(), offset)
+		}
+	}
+}
+
+// newDeadLetterFn returns the function that creates the dead letter records
+// for the messages of the consumer service.
+func newDeadLetterFn(cs topic.ConsumerService, opts Options) deadLetterFn {
+	if opts.DeadLetterSink() == nil {
+		return nil
+	}
+	var (
+		topicName = opts.TopicName()
+		name      = cs.ServiceID().Name()
+	)
+	return func(rm *producer.RefCountedMessage) *deadletterpb.Message {
+		return &deadletterpb.Message{
+			TopicName:       topicName,
+			ConsumerService: name,
+			Shard:           rm.Shard(),
+			// NB: The bytes are copied since the message could be finalized
+			// before the record is written.
+			Value: append([]byte(nil), rm.Bytes()...),
+		}
+	}
+}
+
 func (w *consumerServiceWriterImpl) Write(rm *producer.RefCountedMessage) {
 	if rm.Accept(w.dataFilter) {
 		w.shardWriters[rm.Shard()].Write(rm)
@@ -36,6 +36,7 @@ type message struct {
 	initNanos    int64
 	retryAtNanos int64
 	retried      int
+	written      int
 	// NB(cw) isAcked could be accessed concurrently by the background thread
 	// in message writer and acked by consumer service writers.
 	isAcked *atomic.Bool
@@ -45,6 +46,7 @@ func newMessage() *message {
 	return &message{
 		retryAtNanos: 0,
 		retried:      0,
+		written:      0,
 		isAcked:      atomic.NewBool(false),
 	}
 }
@@ -61,6 +63,7 @@ func (m *message) Set(meta metadata, rm *producer.RefCountedMessage, initNanos i
 func (m *message) Close() {
 	m.retryAtNanos = 0
 	m.retried = 0
+	m.written = 0
 	m.isAcked.Store(false)
 	m.ResetProto(&m.pb)
 }
@@ -90,6 +93,19 @@ func (m *message) IncWriteTimes() {
 	m.retried++
 }
 
+// WriteAttempts returns the times the message has been handed to a consumer
+// writer, unlike the write times it does not count the scheduled writes that
+// failed to reach any consumer writer.
+func (m *message) WriteAttempts() int {
+	return m.written
+}
+
+// IncWriteAttempts increments the times the message has been handed to a
+// consumer writer.
+func (m *message) IncWriteAttempts() {
+	m.written++
+}
+
 // IsAcked returns true if the message has been acked.
 func (m *message) IsAcked() bool {
 	return m.isAcked.Load()
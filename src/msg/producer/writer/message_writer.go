@@ -23,10 +23,12 @@ package writer
 import (
 	"container/list"
 	"errors"
+	"fmt"
 	"math/rand"
 	"sync"
 	"time"
 
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
 	"github.com/m3db/m3/src/msg/producer"
 	"github.com/m3db/m3/src/msg/protocol/proto"
 	"github.com/m3db/m3/src/x/clock"
@@ -41,6 +43,17 @@ var (
 	errNoWriters        = errors.New("no writers")
 )
 
+const (
+	maxWriteAttemptsReasonFmt = "not acknowledged after %d write attempts"
+)
+
+// onAckFn is called with every message acknowledged by a consumer.
+type onAckFn func(rm *producer.RefCountedMessage)
+
+// deadLetterFn returns the dead letter record for a message that exceeded
+// the max write attempts, the record must not reference the message bytes.
+type deadLetterFn func(rm *producer.RefCountedMessage) *deadletterpb.Message
+
 type messageWriter interface {
 	// Write writes a message, messages not acknowledged in time will be retried.
 	// New messages will be written in order, but retries could be out of order.
@@ -98,7 +111,10 @@ type messageWriterMetrics struct {
 	messageClosed            tally.Counter
 	messageDroppedBufferFull tally.Counter
 	messageDroppedTTLExpire  tally.Counter
+	messageDroppedMaxWrites  tally.Counter
 	messageRetry             tally.Counter
+	deadLetterWritten        tally.Counter
+	deadLetterWriteError     tally.Counter
 	messageConsumeLatency    tally.Timer
 	messageWriteDelay        tally.Timer
 	scanBatchLatency         tally.Timer
@@ -132,7 +148,14 @@ func newMessageWriterMetrics(
 		messageDroppedTTLExpire: scope.Tagged(
 			map[string]string{"reason": "ttl-expire"},
 		).Counter("message-dropped"),
-		messageRetry:          scope.Counter("message-retry"),
+		messageDroppedMaxWrites: scope.Tagged(
+			map[string]string{"reason": "max-write-attempts"},
+		).Counter("message-dropped"),
+		messageRetry:      scope.Counter("message-retry"),
+		deadLetterWritten: scope.Counter("dead-letter-written"),
+		deadLetterWriteError: scope.
+			Tagged(map[string]string{"error-type": "dead-letter"}).
+			Counter("write-error"),
 		messageConsumeLatency: instrument.MustCreateSampledTimer(scope.Timer("message-consume-latency"), samplingRate),
 		messageWriteDelay:     instrument.MustCreateSampledTimer(scope.Timer("message-write-delay"), samplingRate),
 		scanBatchLatency:      instrument.MustCreateSampledTimer(scope.Timer("scan-batch-latency"), samplingRate),
@@ -163,6 +186,10 @@ type messageWriterImpl struct {
 	doneCh           chan struct{}
 	wg               sync.WaitGroup
 	m                messageWriterMetrics
+	onAckFn          onAckFn
+	deadLetterFn     deadLetterFn
+	maxWriteAttempts int
+	deadLetters      []*deadletterpb.Message
 	nextFullScan     time.Time
 	lastNewWrite     *list.Element
 
@@ -174,6 +201,8 @@ func newMessageWriter(
 	mPool messagePool,
 	opts Options,
 	m messageWriterMetrics,
+	fn onAckFn,
+	dlFn deadLetterFn,
 ) messageWriter {
 	if opts == nil {
 		opts = NewOptions()
@@ -195,6 +224,9 @@ func newMessageWriter(
 		isClosed:          false,
 		doneCh:            make(chan struct{}),
 		m:                 m,
+		onAckFn:           fn,
+		deadLetterFn:      dlFn,
+		maxWriteAttempts:  opts.MessageMaxWriteAttempts(),
 		nowFn:             nowFn,
 	}
 }
@@ -266,6 +298,7 @@ func (w *messageWriterImpl) write(
 			continue
 		}
 		written = true
+		m.IncWriteAttempts()
 		w.m.writeSuccess.Inc(1)
 		break
 	}
@@ -298,7 +331,7 @@ func (w *messageWriterImpl) nextRetryNanos(writeTimes int, nowNanos int64) int64
 }
 
 func (w *messageWriterImpl) Ack(meta metadata) bool {
-	acked, initNanos := w.acks.ack(meta)
+	acked, initNanos := w.acks.ack(meta, w.onAckFn)
 	if acked {
 		w.m.messageConsumeLatency.Record(time.Duration(w.nowFn().UnixNano() - initNanos))
 		w.m.messageAcked.Inc(1)
@@ -358,7 +391,10 @@ func (w *messageWriterImpl) scanMessageQueue() {
 		e, msgsToWrite = w.scanBatchWithLock(e, beforeBatchNanos, batchSize, fullScan)
 		consumerWriters = w.consumerWriters
 		iterationIndexes = w.iterationIndexes
+		deadLetters := w.deadLetters
+		w.deadLetters = nil
 		w.Unlock()
+		w.writeDeadLetters(deadLetters)
 		if !fullScan && len(msgsToWrite) == 0 {
 			w.m.scanBatchLatency.Record(w.nowFn().Sub(beforeBatch))
 			// If this is not a full scan, abort after the iteration batch
@@ -430,7 +466,7 @@ func (w *messageWriterImpl) scanBatchWithLock(
 			// So that the unacked messages for the unhealthy consumer services
 			// do not stay in memory forever.
 			// NB: The message must be added to the ack map to be acked here.
-			w.acks.ack(m.Metadata())
+			w.acks.ack(m.Metadata(), nil)
 			w.removeFromQueueWithLock(e, m)
 			w.m.messageClosed.Inc(1)
 			continue
@@ -448,7 +484,7 @@ func (w *messageWriterImpl) scanBatchWithLock(
 		if w.messageTTLNanos > 0 && m.InitNanos()+w.messageTTLNanos <= nowNanos {
 			// There is a chance the message was acked right before the ack is
 			// called, in which case just remove it from the queue.
-			if acked, _ := w.acks.ack(m.Metadata()); acked {
+			if acked, _ := w.acks.ack(m.Metadata(), nil); acked {
 				w.m.messageDroppedTTLExpire.Inc(1)
 			}
 			w.removeFromQueueWithLock(e, m)
@@ -471,6 +507,15 @@ func (w *messageWriterImpl) scanBatchWithLock(
 			w.m.messageDroppedBufferFull.Inc(1)
 			continue
 		}
+		if w.maxWriteAttempts > 0 && m.WriteAttempts() >= w.maxWriteAttempts {
+			// Stop retrying the message so a message that the consumers can
+			// never acknowledge is not retried until it expires.
+			// NB: Only the writes that reached a consumer writer are counted so
+			// messages are not dead lettered while no consumer is reachable.
+			w.deadLetterWithLock(m, nowNanos)
+			w.removeFromQueueWithLock(e, m)
+			continue
+		}
 		m.IncWriteTimes()
 		writeTimes := m.WriteTimes()
 		m.SetRetryAtNanos(w.nextRetryNanos(writeTimes, nowNanos))
@@ -482,6 +527,39 @@ func (w *messageWriterImpl) scanBatchWithLock(
 	return next, w.msgsToWrite
 }
 
+func (w *messageWriterImpl) deadLetterWithLock(m *message, nowNanos int64) {
+	var fn onAckFn
+	if w.deadLetterFn != nil {
+		fn = func(rm *producer.RefCountedMessage) {
+			msg := w.deadLetterFn(rm)
+			msg.Reason = fmt.Sprintf(maxWriteAttemptsReasonFmt, m.WriteAttempts())
+			msg.WriteAttempts = uint32(m.WriteAttempts())
+			msg.CreatedAtNanos = m.InitNanos()
+			msg.DeadLetteredAtNanos = nowNanos
+			w.deadLetters = append(w.deadLetters, msg)
+		}
+	}
+	// There is a chance the message was acked right before the ack is
+	// called, in which case just remove it from the queue.
+	if acked, _ := w.acks.ack(m.Metadata(), fn); acked {
+		w.m.messageDroppedMaxWrites.Inc(1)
+	}
+}
+
+func (w *messageWriterImpl) writeDeadLetters(msgs []*deadletterpb.Message) {
+	if len(msgs) == 0 {
+		return
+	}
+	sink := w.opts.DeadLetterSink()
+	for _, msg := range msgs {
+		if err := sink.Write(msg); err != nil {
+			w.m.deadLetterWriteError.Inc(1)
+			continue
+		}
+		w.m.deadLetterWritten.Inc(1)
+	}
+}
+
 func (w *messageWriterImpl) Close() {
 	w.Lock()
 	if w.isClosed {
@@ -642,7 +720,9 @@ func (a *acks) remove(meta metadata) {
 	a.Unlock()
 }
 
-func (a *acks) ack(meta metadata) (bool, int64) {
+// ack acknowledges the message, the function is called with the message
+// before it is acknowledged if provided.
+func (a *acks) ack(meta metadata, fn onAckFn) (bool, int64) {
 	a.Lock()
 	m, ok := a.ackMap[meta]
 	if !ok {
@@ -653,6 +733,9 @@ func (a *acks) ack(meta metadata) (bool, int64) {
 	delete(a.ackMap, meta)
 	a.Unlock()
 	initNanos := m.InitNanos()
+	if fn != nil {
+		fn(m.RefCountedMessage)
+	}
 	m.Ack()
 	return true, initNanos
 }
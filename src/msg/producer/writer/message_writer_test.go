@@ -26,7 +26,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/generated/proto/deadletterpb"
 	"github.com/m3db/m3/src/msg/producer"
+	"github.com/m3db/m3/src/msg/topic"
 	"github.com/m3db/m3/src/x/retry"
 
 	"github.com/fortytw2/leaktest"
@@ -109,7 +112,7 @@ func TestMessageWriterWithPooling(t *testing.T) {
 		wg.Done()
 	}()
 
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 	require.Equal(t, 200, int(w.ReplicatedShardID()))
 	w.Init()
 
@@ -192,7 +195,7 @@ func TestMessageWriterWithoutPooling(t *testing.T) {
 		wg.Done()
 	}()
 
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 	require.Equal(t, 200, int(w.ReplicatedShardID()))
 	w.Init()
 
@@ -265,7 +268,7 @@ func TestMessageWriterRetryWithoutPooling(t *testing.T) {
 
 	addr := lis.Addr().String()
 	opts := testOptions()
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 	w.Init()
 	defer w.Close()
 
@@ -325,7 +328,7 @@ func TestMessageWriterRetryWithPooling(t *testing.T) {
 
 	addr := lis.Addr().String()
 	opts := testOptions()
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 	w.Init()
 	defer w.Close()
 
@@ -385,7 +388,7 @@ func TestMessageWriterCleanupDroppedMessage(t *testing.T) {
 	defer leaktest.Check(t)()
 
 	opts := testOptions()
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics())
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -427,7 +430,7 @@ func TestMessageWriterCleanupAckedMessage(t *testing.T) {
 	defer leaktest.Check(t)()
 
 	opts := testOptions()
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 	w.Init()
 	defer w.Close()
 
@@ -455,7 +458,7 @@ func TestMessageWriterCleanupAckedMessage(t *testing.T) {
 		shard: 200,
 	}
 	// The message will not be finalized because it's still being hold by another message writer.
-	acks.ack(meta)
+	acks.ack(meta, nil)
 	require.True(t, isEmptyWithLock(w.acks))
 
 	// A get will allocate a new message because the old one has not been returned to pool yet.
@@ -478,11 +481,166 @@ func TestMessageWriterCleanupAckedMessage(t *testing.T) {
 	require.Equal(t, meta, m.Metadata())
 }
 
+func TestMessageWriterAckCallsOnAckFn(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var acked []*producer.RefCountedMessage
+	opts := testOptions()
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(),
+		func(rm *producer.RefCountedMessage) {
+			acked = append(acked, rm)
+		}, nil).(*messageWriterImpl)
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Bytes().Return([]byte("foo"))
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Finalize(producer.Consumed)
+
+	rm := producer.NewRefCountedMessage(mm, nil)
+	rm.SetOffset(5)
+	w.Write(rm)
+
+	meta := metadata{
+		id:    1,
+		shard: 200,
+	}
+	require.True(t, w.Ack(meta))
+	require.Equal(t, []*producer.RefCountedMessage{rm}, acked)
+
+	// Duplicated acks do not call the function again.
+	require.False(t, w.Ack(meta))
+	require.Len(t, acked, 1)
+	offset, ok := acked[0].Offset()
+	require.True(t, ok)
+	require.Equal(t, uint64(5), offset)
+}
+
+func TestMessageWriterDeadLetterAfterMaxWriteAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sink := &testDeadLetterSink{}
+	opts := testOptions().SetMessageMaxWriteAttempts(2).SetDeadLetterSink(sink)
+	cs := topic.NewConsumerService().SetServiceID(services.NewServiceID().SetName("s1"))
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil,
+		newDeadLetterFn(cs, opts)).(*messageWriterImpl)
+
+	now := time.Now()
+	initNanos := now.UnixNano()
+	w.nowFn = func() time.Time { return now }
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Shard().Return(uint32(1)).AnyTimes()
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	w.Write(producer.NewRefCountedMessage(mm, nil))
+
+	// The message is never acknowledged by the consumer writer.
+	cw := &testConsumerWriter{}
+	w.AddConsumerWriter(cw)
+	for i := 0; i < 2; i++ {
+		w.scanMessageQueue()
+		require.Equal(t, 1, w.queue.Len())
+		require.Empty(t, sink.msgs)
+		now = now.Add(time.Hour)
+	}
+	require.Equal(t, 2, cw.writes)
+
+	mm.EXPECT().Finalize(producer.Consumed)
+	w.scanMessageQueue()
+	require.Equal(t, 0, w.queue.Len())
+	require.True(t, isEmptyWithLock(w.acks))
+	require.Equal(t, []*deadletterpb.Message{
+		{
+			TopicName:           "topicName",
+			ConsumerService:     "s1",
+			Shard:               1,
+			Value:               []byte("foo"),
+			Reason:              "not acknowledged after 2 write attempts",
+			WriteAttempts:       2,
+			CreatedAtNanos:      initNanos,
+			DeadLetteredAtNanos: now.UnixNano(),
+		},
+	}, sink.msgs)
+}
+
+func TestMessageWriterDropAfterMaxWriteAttemptsWithoutSink(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testOptions().SetMessageMaxWriteAttempts(1)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
+
+	now := time.Now()
+	w.nowFn = func() time.Time { return now }
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	w.Write(producer.NewRefCountedMessage(mm, nil))
+
+	_, toBeRetried := w.scanBatchWithLock(w.queue.Front(), now.UnixNano(), 10, true)
+	require.Equal(t, 1, len(toBeRetried))
+	require.Equal(t, 1, w.queue.Len())
+	require.NoError(t, w.writeBatch([]int{0}, []consumerWriter{&testConsumerWriter{}}, toBeRetried))
+
+	mm.EXPECT().Finalize(producer.Consumed)
+	_, toBeRetried = w.scanBatchWithLock(w.queue.Front(), now.Add(time.Hour).UnixNano(), 10, true)
+	require.Equal(t, 0, len(toBeRetried))
+	require.Equal(t, 0, w.queue.Len())
+	require.Empty(t, w.deadLetters)
+}
+
+func TestMessageWriterNoDeadLetterWithoutConsumerWriters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sink := &testDeadLetterSink{}
+	opts := testOptions().SetMessageMaxWriteAttempts(2).SetDeadLetterSink(sink)
+	cs := topic.NewConsumerService().SetServiceID(services.NewServiceID().SetName("s1"))
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil,
+		newDeadLetterFn(cs, opts)).(*messageWriterImpl)
+
+	now := time.Now()
+	w.nowFn = func() time.Time { return now }
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Size().Return(3)
+	mm.EXPECT().Shard().Return(uint32(1)).AnyTimes()
+	mm.EXPECT().Bytes().Return([]byte("foo")).AnyTimes()
+	w.Write(producer.NewRefCountedMessage(mm, nil))
+
+	// Writes that never reach a consumer writer are not write attempts.
+	for i := 0; i < 5; i++ {
+		w.scanMessageQueue()
+		require.Equal(t, 1, w.queue.Len())
+		require.Empty(t, sink.msgs)
+		now = now.Add(time.Hour)
+	}
+	require.Equal(t, 0, w.queue.Front().Value.(*message).WriteAttempts())
+
+	// The message is dead lettered once written to a consumer writer enough.
+	w.AddConsumerWriter(&testConsumerWriter{})
+	for i := 0; i < 2; i++ {
+		w.scanMessageQueue()
+		require.Equal(t, 1, w.queue.Len())
+		now = now.Add(time.Hour)
+	}
+	mm.EXPECT().Finalize(producer.Consumed)
+	w.scanMessageQueue()
+	require.Equal(t, 0, w.queue.Len())
+	require.Len(t, sink.msgs, 1)
+	require.Equal(t, uint32(2), sink.msgs[0].WriteAttempts)
+}
+
 func TestMessageWriterCutoverCutoff(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	w := newMessageWriter(200, testMessagePool(testOptions()), nil, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(testOptions()), nil, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
 	require.True(t, w.isValidWriteWithLock(now.UnixNano()))
@@ -510,7 +668,7 @@ func TestMessageWriterKeepNewWritesInOrderInFrontOfTheQueue(t *testing.T) {
 	opts := testOptions().SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
 	)
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
@@ -554,7 +712,7 @@ func TestMessageWriterRetryIterateBatchFullScan(t *testing.T) {
 	opts := testOptions().SetMessageQueueScanBatchSize(retryBatchSize).SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
 	)
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
@@ -618,7 +776,7 @@ func TestMessageWriterRetryIterateBatchFullScanWithMessageTTL(t *testing.T) {
 	opts := testOptions().SetMessageQueueScanBatchSize(retryBatchSize).SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
 	)
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
@@ -679,7 +837,7 @@ func TestMessageWriterRetryIterateBatchNotFullScan(t *testing.T) {
 	opts := testOptions().SetMessageQueueScanBatchSize(retryBatchSize).SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(2 * time.Nanosecond).SetMaxBackoff(5 * time.Nanosecond),
 	)
-	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, testMessagePool(opts), opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 
 	now := time.Now()
 	w.nowFn = func() time.Time { return now }
@@ -749,7 +907,7 @@ func TestNextRetryNanos(t *testing.T) {
 	opts := testOptions().SetMessageRetryOptions(
 		retry.NewOptions().SetInitialBackoff(backoffDuration).SetMaxBackoff(2 * backoffDuration).SetJitter(true),
 	)
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 
 	nowNanos := time.Now().UnixNano()
 	m := newMessage()
@@ -772,7 +930,7 @@ func TestMessageWriterCloseCleanupAllMessages(t *testing.T) {
 	defer leaktest.Check(t)()
 
 	opts := testOptions()
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -797,7 +955,7 @@ func TestMessageWriterQueueFullScanOnWriteErrors(t *testing.T) {
 	defer ctrl.Finish()
 
 	opts := testOptions().SetMessageQueueScanBatchSize(1)
-	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics()).(*messageWriterImpl)
+	w := newMessageWriter(200, nil, opts, testMessageWriterMetrics(), nil, nil).(*messageWriterImpl)
 	w.AddConsumerWriter(newConsumerWriter("bad", nil, opts, testConsumerWriterMetrics()))
 
 	mm1 := producer.NewMockMessage(ctrl)
@@ -846,3 +1004,31 @@ func validateMessages(t *testing.T, msgs []*producer.RefCountedMessage, w *messa
 	w.RUnlock()
 	require.Equal(t, idx, len(msgs))
 }
+
+type testDeadLetterSink struct {
+	msgs []*deadletterpb.Message
+}
+
+func (s *testDeadLetterSink) Write(msg *deadletterpb.Message) error {
+	s.msgs = append(s.msgs, msg)
+	return nil
+}
+
+func (s *testDeadLetterSink) Close() error {
+	return nil
+}
+
+type testConsumerWriter struct {
+	writes int
+}
+
+func (w *testConsumerWriter) Address() string { return "test" }
+
+func (w *testConsumerWriter) Write(b []byte) error {
+	w.writes++
+	return nil
+}
+
+func (w *testConsumerWriter) Init() {}
+
+func (w *testConsumerWriter) Close() {}
@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cluster/services"
+	"github.com/m3db/m3/src/msg/offset"
+	"github.com/m3db/m3/src/msg/producer/deadletter"
 	"github.com/m3db/m3/src/msg/protocol/proto"
 	"github.com/m3db/m3/src/msg/topic"
 	"github.com/m3db/m3/src/x/instrument"
@@ -268,6 +270,23 @@ type Options interface {
 	// MessageRetryOptions returns the retry options for message retry.
 	SetMessageRetryOptions(value retry.Options) Options
 
+	// MessageMaxWriteAttempts returns the max number of times a message is
+	// written to a consumer service without being acknowledged before it is
+	// dead lettered, messages are retried until they expire if not positive.
+	MessageMaxWriteAttempts() int
+
+	// SetMessageMaxWriteAttempts sets the max number of times a message is
+	// written to a consumer service without being acknowledged.
+	SetMessageMaxWriteAttempts(value int) Options
+
+	// DeadLetterSink returns the sink for the messages exceeding the max
+	// write attempts, the messages are dropped when not set.
+	DeadLetterSink() deadletter.Sink
+
+	// SetDeadLetterSink sets the sink for the messages exceeding the max
+	// write attempts.
+	SetDeadLetterSink(value deadletter.Sink) Options
+
 	// MessageQueueNewWritesScanInterval returns the interval between scanning
 	// message queue for new writes.
 	MessageQueueNewWritesScanInterval() time.Duration
@@ -326,6 +345,14 @@ type Options interface {
 	// SetConnectionOptions sets the options for connections.
 	SetConnectionOptions(value ConnectionOptions) Options
 
+	// OffsetTracker returns the tracker of the offsets acknowledged by the
+	// consumer services, the offsets are not tracked when not set.
+	OffsetTracker() offset.Tracker
+
+	// SetOffsetTracker sets the tracker of the offsets acknowledged by the
+	// consumer services.
+	SetOffsetTracker(value offset.Tracker) Options
+
 	// InstrumentOptions returns the instrument options.
 	InstrumentOptions() instrument.Options
 
@@ -341,6 +368,8 @@ type writerOptions struct {
 	placementWatchInitTimeout         time.Duration
 	messagePoolOptions                pool.ObjectPoolOptions
 	messageRetryOpts                  retry.Options
+	messageMaxWriteAttempts           int
+	deadLetterSink                    deadletter.Sink
 	messageQueueNewWritesScanInterval time.Duration
 	messageQueueFullScanInterval      time.Duration
 	messageQueueScanBatchSize         int
@@ -350,6 +379,7 @@ type writerOptions struct {
 	encOpts                           proto.Options
 	decOpts                           proto.Options
 	cOpts                             ConnectionOptions
+	offsetTracker                     offset.Tracker
 	iOpts                             instrument.Options
 }
 
@@ -442,6 +472,26 @@ func (opts *writerOptions) SetMessageRetryOptions(value retry.Options) Options {
 	return &o
 }
 
+func (opts *writerOptions) MessageMaxWriteAttempts() int {
+	return opts.messageMaxWriteAttempts
+}
+
+func (opts *writerOptions) SetMessageMaxWriteAttempts(value int) Options {
+	o := *opts
+	o.messageMaxWriteAttempts = value
+	return &o
+}
+
+func (opts *writerOptions) DeadLetterSink() deadletter.Sink {
+	return opts.deadLetterSink
+}
+
+func (opts *writerOptions) SetDeadLetterSink(value deadletter.Sink) Options {
+	o := *opts
+	o.deadLetterSink = value
+	return &o
+}
+
 func (opts *writerOptions) MessageQueueNewWritesScanInterval() time.Duration {
 	return opts.messageQueueNewWritesScanInterval
 }
@@ -532,6 +582,16 @@ func (opts *writerOptions) SetConnectionOptions(value ConnectionOptions) Options
 	return &o
 }
 
+func (opts *writerOptions) OffsetTracker() offset.Tracker {
+	return opts.offsetTracker
+}
+
+func (opts *writerOptions) SetOffsetTracker(value offset.Tracker) Options {
+	o := *opts
+	o.offsetTracker = value
+	return &o
+}
+
 func (opts *writerOptions) InstrumentOptions() instrument.Options {
 	return opts.iOpts
 }
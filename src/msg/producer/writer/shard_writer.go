@@ -62,9 +62,11 @@ func newSharedShardWriter(
 	mPool messagePool,
 	opts Options,
 	m messageWriterMetrics,
+	fn onAckFn,
+	dlFn deadLetterFn,
 ) shardWriter {
 	replicatedShardID := uint64(shard)
-	mw := newMessageWriter(replicatedShardID, mPool, opts, m)
+	mw := newMessageWriter(replicatedShardID, mPool, opts, m, fn, dlFn)
 	mw.Init()
 	router.Register(replicatedShardID, mw)
 	return &sharedShardWriter{
@@ -130,6 +132,8 @@ type replicatedShardWriter struct {
 	opts           Options
 	logger         *zap.Logger
 	m              messageWriterMetrics
+	onAckFn        onAckFn
+	deadLetterFn   deadLetterFn
 
 	messageWriters  map[string]messageWriter
 	messageTTLNanos int64
@@ -143,6 +147,8 @@ func newReplicatedShardWriter(
 	mPool messagePool,
 	opts Options,
 	m messageWriterMetrics,
+	fn onAckFn,
+	dlFn deadLetterFn,
 ) shardWriter {
 	return &replicatedShardWriter{
 		shard:          shard,
@@ -155,6 +161,8 @@ func newReplicatedShardWriter(
 		messageWriters: make(map[string]messageWriter),
 		isClosed:       false,
 		m:              m,
+		onAckFn:        fn,
+		deadLetterFn:   dlFn,
 	}
 }
 
@@ -216,7 +224,7 @@ func (w *replicatedShardWriter) UpdateInstances(
 	for instance, cw := range toBeAdded {
 		replicatedShardID := uint64(w.replicaID*w.numberOfShards + w.shard)
 		w.replicaID++
-		mw := newMessageWriter(replicatedShardID, w.mPool, w.opts, w.m)
+		mw := newMessageWriter(replicatedShardID, w.mPool, w.opts, w.m, w.onAckFn, w.deadLetterFn)
 		mw.AddConsumerWriter(cw)
 		w.updateCutoverCutoffNanos(mw, instance)
 		mw.Init()
@@ -71,6 +71,7 @@ type writer struct {
 	initType               initType
 	numShards              uint32
 	consumerServiceWriters map[string]consumerServiceWriter
+	consumerServiceNames   map[string]string
 	filterRegistry         map[string]producer.FilterFunc
 	isClosed               bool
 	m                      writerMetrics
@@ -87,6 +88,7 @@ func NewWriter(opts Options) producer.Writer {
 		logger:                 opts.InstrumentOptions().Logger(),
 		initType:               failOnError,
 		consumerServiceWriters: make(map[string]consumerServiceWriter),
+		consumerServiceNames:   make(map[string]string),
 		filterRegistry:         make(map[string]producer.FilterFunc),
 		isClosed:               false,
 		m:                      newWriterMetrics(opts.InstrumentOptions().MetricsScope()),
@@ -120,6 +122,39 @@ func (w *writer) Write(rm *producer.RefCountedMessage) error {
 	return nil
 }
 
+func (w *writer) ReplayTo(consumerService string, rm *producer.RefCountedMessage) error {
+	w.RLock()
+	if w.isClosed {
+		rm.Drop()
+		w.RUnlock()
+		return errWriterClosed
+	}
+	if shard := rm.Shard(); shard >= w.numShards {
+		w.m.invalidShard.Inc(1)
+		rm.Drop()
+		w.RUnlock()
+		return fmt.Errorf("could not write message for shard %d which is larger than max shard id %d", shard, w.numShards-1)
+	}
+	var csws []consumerServiceWriter
+	for key, csw := range w.consumerServiceWriters {
+		if w.consumerServiceNames[key] == consumerService {
+			csws = append(csws, csw)
+		}
+	}
+	if len(csws) == 0 {
+		rm.Drop()
+		w.RUnlock()
+		return fmt.Errorf("could not find consumer service %s", consumerService)
+	}
+	rm.IncRef()
+	for _, csw := range csws {
+		csw.Write(rm)
+	}
+	rm.DecRef()
+	w.RUnlock()
+	return nil
+}
+
 func (w *writer) Init() error {
 	newUpdatableFn := func() (watch.Updatable, error) {
 		return w.ts.Watch(w.topic)
@@ -168,11 +203,13 @@ func (w *writer) process(update interface{}) error {
 	var (
 		iOpts                     = w.opts.InstrumentOptions()
 		newConsumerServiceWriters = make(map[string]consumerServiceWriter, len(t.ConsumerServices()))
+		newConsumerServiceNames   = make(map[string]string, len(t.ConsumerServices()))
 		toBeClosed                []consumerServiceWriter
 		multiErr                  xerrors.MultiError
 	)
 	for _, cs := range t.ConsumerServices() {
 		key := cs.ServiceID().String()
+		newConsumerServiceNames[key] = cs.ServiceID().Name()
 		csw, ok := w.consumerServiceWriters[key]
 		if ok {
 			csw.SetMessageTTLNanos(cs.MessageTTLNanos())
@@ -203,6 +240,13 @@ func (w *writer) process(update interface{}) error {
 		csw.SetMessageTTLNanos(cs.MessageTTLNanos())
 		newConsumerServiceWriters[key] = csw
 		w.logger.Info("initialized consumer service writer", zap.String("writer", cs.String()))
+		if tracker := w.opts.OffsetTracker(); tracker != nil {
+			if err := tracker.Watch(cs.ServiceID().Name()); err != nil {
+				w.logger.Error("could not watch offset resets for consumer service",
+					zap.String("writer", cs.String()), zap.Error(err))
+				multiErr = multiErr.Add(err)
+			}
+		}
 	}
 	for key, csw := range w.consumerServiceWriters {
 		if _, ok := newConsumerServiceWriters[key]; !ok {
@@ -226,6 +270,7 @@ func (w *writer) process(update interface{}) error {
 		}
 	}
 	w.consumerServiceWriters = newConsumerServiceWriters
+	w.consumerServiceNames = newConsumerServiceNames
 	w.numShards = t.NumberOfShards()
 	w.Unlock()
 
@@ -257,6 +302,13 @@ func (w *writer) Close() {
 	for _, csw := range w.consumerServiceWriters {
 		csw.Close()
 	}
+	// NB: The sink can only be closed once the message writers stopped
+	// writing dead letters.
+	if sink := w.opts.DeadLetterSink(); sink != nil {
+		if err := sink.Close(); err != nil {
+			w.logger.Error("could not close dead letter sink", zap.Error(err))
+		}
+	}
 }
 
 func (w *writer) RegisterFilter(sid services.ServiceID, filter producer.FilterFunc) {
@@ -121,6 +121,49 @@ func TestWriterWriteWithInvalidShard(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestWriterReplayTo(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := testOptions()
+	w := NewWriter(opts).(*writer)
+	w.numShards = 2
+
+	sid1 := services.NewServiceID().SetName("s1").SetEnvironment("env1")
+	sid2 := services.NewServiceID().SetName("s1").SetEnvironment("env2")
+	sid3 := services.NewServiceID().SetName("s2")
+	csw1 := NewMockconsumerServiceWriter(ctrl)
+	csw2 := NewMockconsumerServiceWriter(ctrl)
+	csw3 := NewMockconsumerServiceWriter(ctrl)
+	for sid, csw := range map[services.ServiceID]consumerServiceWriter{
+		sid1: csw1,
+		sid2: csw2,
+		sid3: csw3,
+	} {
+		w.consumerServiceWriters[sid.String()] = csw
+		w.consumerServiceNames[sid.String()] = sid.Name()
+	}
+
+	mm := producer.NewMockMessage(ctrl)
+	mm.EXPECT().Shard().Return(uint32(1)).AnyTimes()
+	mm.EXPECT().Size().Return(3).AnyTimes()
+	rm := producer.NewRefCountedMessage(mm, nil)
+
+	// Only the consumer services with the name are written to.
+	csw1.EXPECT().Write(rm)
+	csw2.EXPECT().Write(rm)
+	rm.IncRef()
+	require.NoError(t, w.ReplayTo("s1", rm))
+
+	mm.EXPECT().Finalize(producer.Consumed)
+	rm.DecRef()
+
+	mm.EXPECT().Finalize(producer.Dropped)
+	require.Error(t, w.ReplayTo("s3", producer.NewRefCountedMessage(mm, nil)))
+}
+
 func TestWriterInvalidTopicUpdate(t *testing.T) {
 	defer leaktest.Check(t)()
 
@@ -171,7 +171,8 @@ func testLocalType(t *testing.T, providedType string, placementExists bool) {
 							"blockSizeNanos": "3600000000000"
 						},
 						"schemaOptions": null,
-						"coldWritesEnabled": false
+						"coldWritesEnabled": false,
+						"dataFileCompression": "NONE"
 					}
 				}
 			}
@@ -329,7 +330,8 @@ func TestLocalTypeWithNumShards(t *testing.T) {
 							"blockSizeNanos": "3600000000000"
 						},
 						"schemaOptions": null,
-						"coldWritesEnabled": false
+						"coldWritesEnabled": false,
+						"dataFileCompression": "NONE"
 					}
 				}
 			}
@@ -439,7 +441,8 @@ func TestLocalWithBlockSizeNanos(t *testing.T) {
 							"blockSizeNanos": "10800000000000"
 						},
 						"schemaOptions": null,
-						"coldWritesEnabled": false
+						"coldWritesEnabled": false,
+						"dataFileCompression": "NONE"
 					}
 				}
 			}
@@ -553,7 +556,8 @@ func TestLocalWithBlockSizeExpectedSeriesDatapointsPerHour(t *testing.T) {
 							"blockSizeNanos": "%d"
 						},
 						"schemaOptions": null,
-						"coldWritesEnabled": false
+						"coldWritesEnabled": false,
+						"dataFileCompression": "NONE"
 					}
 				}
 			}
@@ -801,7 +805,8 @@ func testClusterTypeHosts(t *testing.T, placementExists bool) {
 							"blockSizeNanos": "3600000000000"
 						},
 						"schemaOptions": null,
-						"coldWritesEnabled": false
+						"coldWritesEnabled": false,
+						"dataFileCompression": "NONE"
 					}
 				}
 			}
@@ -933,7 +938,8 @@ func TestClusterTypeHostsWithIsolationGroup(t *testing.T) {
 							"blockSizeNanos": "3600000000000"
 						},
 						"schemaOptions": null,
-						"coldWritesEnabled": false
+						"coldWritesEnabled": false,
+						"dataFileCompression": "NONE"
 					}
 				}
 			}
@@ -38,6 +38,7 @@ import (
 	graphite "github.com/m3db/m3/src/query/graphite/storage"
 	"github.com/m3db/m3/src/query/graphite/ts"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/tenant"
 	xhttp "github.com/m3db/m3/src/x/net/http"
 )
 
@@ -56,6 +57,7 @@ var (
 type renderHandler struct {
 	engine           *native.Engine
 	queryContextOpts models.QueryContextOptions
+	tenants          tenant.Tenants
 }
 
 type respError struct {
@@ -66,10 +68,11 @@ type respError struct {
 // NewRenderHandler returns a new render handler around the given storage.
 func NewRenderHandler(opts options.HandlerOptions) http.Handler {
 	wrappedStore := graphite.NewM3WrappedStorage(opts.Storage(),
-		opts.Enforcer(), opts.InstrumentOpts())
+		opts.Enforcer(), opts.TagOptions(), opts.InstrumentOpts())
 	return &renderHandler{
 		engine:           native.NewEngine(wrappedStore),
 		queryContextOpts: opts.QueryContextOptions(),
+		tenants:          opts.Tenants(),
 	}
 }
 
@@ -103,6 +106,11 @@ func (h *renderHandler) serveHTTP(
 		return respError{err: err, code: http.StatusBadRequest}
 	}
 
+	t, rErr := handleroptions.ParseTenant(r, h.tenants)
+	if rErr != nil {
+		return respError{err: rErr.Inner(), code: rErr.Code()}
+	}
+
 	var (
 		results = make([]ts.SeriesList, len(p.Targets))
 		errorCh = make(chan error, 1)
@@ -115,6 +123,7 @@ func (h *renderHandler) serveHTTP(
 		End:     p.Until,
 		Timeout: p.Timeout,
 		Limit:   limit,
+		Tenant:  t,
 	})
 
 	// Set the request context.
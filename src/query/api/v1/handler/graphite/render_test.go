@@ -21,6 +21,8 @@
 package graphite
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -35,6 +37,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
 	xtest "github.com/m3db/m3/src/x/test"
 
@@ -174,6 +177,84 @@ func TestParseQueryResults(t *testing.T) {
 	require.Equal(t, expected, string(buf))
 }
 
+func TestParseQueryResultsTenant(t *testing.T) {
+	resolution := 10 * time.Second
+	start := time.Now().Add(-30 * time.Minute).Truncate(resolution)
+	newSeries := func(name, tenant string) *ts.Series {
+		tags := models.NewTags(0, nil).AddTags([]models.Tag{
+			{Name: graphite.TagName(0), Value: []byte("foo")},
+			{Name: graphite.TagName(1), Value: []byte("bar")},
+			{Name: []byte("tenant"), Value: []byte(tenant)},
+		})
+		vals := ts.NewFixedStepValues(resolution, 3, 3, start)
+		return ts.NewSeries([]byte(name), vals, tags)
+	}
+	all := ts.SeriesList{
+		newSeries("series_foo", "foo"),
+		newSeries("series_bar", "bar"),
+	}
+
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	// The store applies the tenant restriction of the fetch options, as the
+	// m3 storage does.
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().FetchBlocks(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ *storage.FetchQuery,
+			opts *storage.FetchOptions,
+		) (block.Result, error) {
+			restrict := opts.RestrictQueryOptions.GetRestrictByTag()
+			require.NotNil(t, restrict)
+			assert.Equal(t, "foo", opts.Tenant)
+
+			var matched ts.SeriesList
+			for _, series := range all {
+				ok := true
+				for _, m := range restrict.Restrict {
+					value, _ := series.Tags.Get(m.Name)
+					ok = ok && m.Type == models.MatchEqual &&
+						bytes.Equal(value, m.Value)
+				}
+				if ok {
+					matched = append(matched, series)
+				}
+			}
+
+			meta := block.NewResultMetadata()
+			meta.Resolutions = []int64{int64(resolution)}
+			return makeBlockResult(ctrl, &storage.FetchResult{
+				SeriesList: matched,
+				Metadata:   meta,
+			}), nil
+		})
+
+	tenants, err := tenant.Configuration{}.NewTenants(tenant.NewOptions())
+	require.NoError(t, err)
+	opts := options.EmptyHandlerOptions().
+		SetStorage(store).
+		SetTenants(tenants).
+		SetQueryContextOptions(models.QueryContextOptions{})
+	handler := NewRenderHandler(opts)
+
+	req := newGraphiteReadHTTPRequest(t)
+	req.Header.Set(handleroptions.TenantHeader, "foo")
+	req.URL.RawQuery = fmt.Sprintf("target=foo.bar&from=%d&until=%d",
+		start.Unix(), start.Unix()+30)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	res := recorder.Result()
+	require.Equal(t, 200, res.StatusCode)
+
+	buf, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(buf), "series_foo")
+	assert.NotContains(t, string(buf), "series_bar")
+}
+
 func TestParseQueryResultsMaxDatapoints(t *testing.T) {
 	startStr := "03/07/14"
 	endStr := "03/07/15"
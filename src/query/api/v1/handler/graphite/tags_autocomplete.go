@@ -0,0 +1,328 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	graphiteStorage "github.com/m3db/m3/src/query/graphite/storage"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// TagsAutoCompleteTagsURL is the url for auto completing graphite tag
+	// names.
+	TagsAutoCompleteTagsURL = handler.RoutePrefixV1 +
+		"/graphite/tags/autoComplete/tags"
+
+	// TagsAutoCompleteValuesURL is the url for auto completing graphite tag
+	// values.
+	TagsAutoCompleteValuesURL = handler.RoutePrefixV1 +
+		"/graphite/tags/autoComplete/values"
+
+	// defaultAutoCompleteLimit matches the default limit used by graphite.
+	defaultAutoCompleteLimit = 100
+
+	// internalTagPrefix prefixes tags such as the graphite path tags which
+	// are not exposed as graphite tags.
+	internalTagPrefix = "__"
+)
+
+var (
+	// TagsAutoCompleteHTTPMethods are the HTTP methods for the tag auto
+	// complete handlers.
+	TagsAutoCompleteHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errNoTag = errors.New("no 'tag' given")
+)
+
+type tagsAutoCompleteHandler struct {
+	storage             storage.Storage
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tagOptions          models.TagOptions
+	nowFn               clock.NowFn
+	instrumentOpts      instrument.Options
+	completeValues      bool
+}
+
+// NewTagsAutoCompleteTagsHandler returns a new instance of a handler which
+// auto completes graphite tag names.
+func NewTagsAutoCompleteTagsHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsAutoCompleteHandler(opts, false)
+}
+
+// NewTagsAutoCompleteValuesHandler returns a new instance of a handler which
+// auto completes graphite tag values.
+func NewTagsAutoCompleteValuesHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsAutoCompleteHandler(opts, true)
+}
+
+func newTagsAutoCompleteHandler(
+	opts options.HandlerOptions,
+	completeValues bool,
+) http.Handler {
+	tagOptions := opts.TagOptions()
+	if tagOptions == nil {
+		tagOptions = models.NewTagOptions()
+	}
+
+	return &tagsAutoCompleteHandler{
+		storage:             opts.Storage(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tagOptions:          tagOptions,
+		nowFn:               opts.NowFn(),
+		instrumentOpts:      opts.InstrumentOpts(),
+		completeValues:      completeValues,
+	}
+}
+
+type tagsAutoCompleteParams struct {
+	exprs  []string
+	tag    string
+	prefix string
+	limit  int
+}
+
+func (h *tagsAutoCompleteHandler) ServeHTTP(
+	w http.ResponseWriter,
+	r *http.Request,
+) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	w.Header().Set("Content-Type", "application/json")
+
+	params, err := h.parseParams(r)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	query, err := h.buildQuery(params)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	opts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	result, err := h.storage.CompleteTags(ctx, query, opts)
+	if err != nil {
+		logger.Error("unable to complete tags", zap.Error(err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var completed []string
+	if h.completeValues {
+		completed = h.tagValues(result, params)
+	} else {
+		completed = h.tagNames(result, params)
+	}
+
+	handleroptions.AddWarningHeaders(w, result.Metadata)
+	if err := autoCompleteResultsJSON(w, completed); err != nil {
+		logger.Error("unable to render auto complete results", zap.Error(err))
+		xhttp.Error(w, err, http.StatusBadRequest)
+	}
+}
+
+func (h *tagsAutoCompleteHandler) parseParams(
+	r *http.Request,
+) (tagsAutoCompleteParams, error) {
+	if err := r.ParseForm(); err != nil {
+		return tagsAutoCompleteParams{}, err
+	}
+
+	params := tagsAutoCompleteParams{
+		exprs: r.Form["expr"],
+		limit: defaultAutoCompleteLimit,
+	}
+
+	if limit := r.Form.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			return tagsAutoCompleteParams{},
+				fmt.Errorf("invalid 'limit': %s", limit)
+		}
+
+		params.limit = n
+	}
+
+	if !h.completeValues {
+		params.prefix = r.Form.Get("tagPrefix")
+		return params, nil
+	}
+
+	params.tag = r.Form.Get("tag")
+	if params.tag == "" {
+		return tagsAutoCompleteParams{}, errNoTag
+	}
+
+	params.prefix = r.Form.Get("valuePrefix")
+	return params, nil
+}
+
+func (h *tagsAutoCompleteHandler) buildQuery(
+	params tagsAutoCompleteParams,
+) (*storage.CompleteTagsQuery, error) {
+	query := &storage.CompleteTagsQuery{
+		CompleteNameOnly: !h.completeValues,
+		// NB: necessarily spans the entire timerange for the index.
+		Start: time.Time{},
+		End:   h.nowFn(),
+	}
+
+	if len(params.exprs) > 0 {
+		matchers, err := graphiteStorage.TranslateTagExpressionsToMatchers(
+			params.exprs, h.tagOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		query.TagMatchers = matchers
+	}
+
+	if !h.completeValues {
+		if len(query.TagMatchers) == 0 {
+			query.TagMatchers = models.Matchers{{Type: models.MatchAll}}
+		}
+
+		return query, nil
+	}
+
+	name := h.tagName(params.tag)
+	matchType, value := models.MatchField, []byte(nil)
+	if params.prefix != "" {
+		matchType = models.MatchRegexp
+		value = []byte(regexp.QuoteMeta(params.prefix) + ".*")
+	}
+
+	m, err := models.NewMatcher(matchType, name, value)
+	if err != nil {
+		return nil, err
+	}
+
+	query.FilterNameTags = [][]byte{name}
+	query.TagMatchers = append(query.TagMatchers, m)
+	return query, nil
+}
+
+// tagName maps a graphite tag name to the stored tag name.
+func (h *tagsAutoCompleteHandler) tagName(tag string) []byte {
+	if tag == graphite.TaggedNameTag {
+		return h.tagOptions.MetricName()
+	}
+
+	return []byte(tag)
+}
+
+func (h *tagsAutoCompleteHandler) tagNames(
+	result *storage.CompleteTagsResult,
+	params tagsAutoCompleteParams,
+) []string {
+	// NB: graphite does not complete tags already used in the expressions.
+	used := make(map[string]struct{}, len(params.exprs))
+	for _, expr := range params.exprs {
+		if parsed, err := graphite.ParseTagExpression(expr); err == nil {
+			used[parsed.Tag] = struct{}{}
+		}
+	}
+
+	metricName := string(h.tagOptions.MetricName())
+	names := make([]string, 0, len(result.CompletedTags))
+	for _, tag := range result.CompletedTags {
+		name := string(tag.Name)
+		if name == metricName {
+			name = graphite.TaggedNameTag
+		} else if strings.HasPrefix(name, internalTagPrefix) {
+			continue
+		}
+
+		if _, ok := used[name]; ok {
+			continue
+		}
+
+		if strings.HasPrefix(name, params.prefix) {
+			names = append(names, name)
+		}
+	}
+
+	return sortAndLimit(names, params.limit)
+}
+
+func (h *tagsAutoCompleteHandler) tagValues(
+	result *storage.CompleteTagsResult,
+	params tagsAutoCompleteParams,
+) []string {
+	var values []string
+	for _, tag := range result.CompletedTags {
+		for _, value := range tag.Values {
+			values = append(values, string(value))
+		}
+	}
+
+	return sortAndLimit(values, params.limit)
+}
+
+func sortAndLimit(values []string, limit int) []string {
+	sort.Strings(values)
+	if len(values) > limit {
+		values = values[:limit]
+	}
+
+	return values
+}
+
+func autoCompleteResultsJSON(w io.Writer, values []string) error {
+	jw := json.NewWriter(w)
+	jw.BeginArray()
+
+	for _, value := range values {
+		jw.WriteString(value)
+	}
+
+	jw.EndArray()
+	return jw.Close()
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAutoCompleteTestOptions(store storage.Storage) options.HandlerOptions {
+	builder := handleroptions.
+		NewFetchOptionsBuilder(handleroptions.FetchOptionsBuilderOptions{})
+	return options.EmptyHandlerOptions().
+		SetFetchOptionsBuilder(builder).
+		SetTagOptions(models.NewTagOptions()).
+		SetNowFn(func() time.Time { return until.t }).
+		SetStorage(store)
+}
+
+func serveAutoComplete(t *testing.T, h http.Handler, url string) []string {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var results []string
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &results))
+	return results
+}
+
+func TestTagsAutoCompleteTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			q *storage.CompleteTagsQuery,
+			_ interface{},
+		) (*storage.CompleteTagsResult, error) {
+			assert.True(t, q.CompleteNameOnly)
+			assert.True(t, q.End.Equal(until.t))
+			require.Len(t, q.TagMatchers, 1)
+			assert.Equal(t, models.MatchEqual, q.TagMatchers[0].Type)
+			assert.Equal(t, b("__name__"), q.TagMatchers[0].Name)
+			assert.Equal(t, b("cpu"), q.TagMatchers[0].Value)
+
+			return &storage.CompleteTagsResult{
+				CompleteNameOnly: true,
+				CompletedTags: []storage.CompletedTag{
+					{Name: b("__name__")},
+					{Name: b("__g0__")},
+					{Name: b("dc")},
+					{Name: b("host")},
+					{Name: b("region")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsAutoCompleteTagsHandler(newAutoCompleteTestOptions(store))
+	results := serveAutoComplete(t, h,
+		TagsAutoCompleteTagsURL+"?expr=name%3Dcpu&tagPrefix=&limit=2")
+	assert.Equal(t, []string{"dc", "host"}, results)
+}
+
+func TestTagsAutoCompleteValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			q *storage.CompleteTagsQuery,
+			_ interface{},
+		) (*storage.CompleteTagsResult, error) {
+			assert.False(t, q.CompleteNameOnly)
+			assert.Equal(t, bs("__name__"), q.FilterNameTags)
+			require.Len(t, q.TagMatchers, 2)
+			assert.Equal(t, models.MatchRegexp, q.TagMatchers[0].Type)
+			assert.Equal(t, b("dc"), q.TagMatchers[0].Name)
+			assert.Equal(t, models.MatchRegexp, q.TagMatchers[1].Type)
+			assert.Equal(t, b("__name__"), q.TagMatchers[1].Name)
+			assert.Equal(t, b(`cpu\..*`), q.TagMatchers[1].Value)
+
+			return &storage.CompleteTagsResult{
+				CompletedTags: []storage.CompletedTag{
+					{Name: b("__name__"), Values: bs("cpu.user", "cpu.idle")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsAutoCompleteValuesHandler(newAutoCompleteTestOptions(store))
+	results := serveAutoComplete(t, h, TagsAutoCompleteValuesURL+
+		"?expr=dc%3D~us-.*&tag=name&valuePrefix=cpu.")
+	assert.Equal(t, []string{"cpu.idle", "cpu.user"}, results)
+}
+
+func TestTagsAutoCompleteValuesNoTag(t *testing.T) {
+	h := NewTagsAutoCompleteValuesHandler(newAutoCompleteTestOptions(nil))
+	req := httptest.NewRequest(http.MethodGet, TagsAutoCompleteValuesURL, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
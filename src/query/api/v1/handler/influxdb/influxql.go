@@ -0,0 +1,792 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+)
+
+var (
+	errInfluxQLEmptyQuery   = errors.New("empty query")
+	errInfluxQLNoLowerBound = errors.New("queries must specify a lower " +
+		"bound on time, e.g. time > now() - 1h")
+	errInfluxQLOrCondition = errors.New("OR conditions are not supported")
+	errInfluxQLRawGroupBy  = errors.New("GROUP BY requires every field " +
+		"to be an aggregate function")
+	errInfluxQLMixedFields = errors.New("mixing aggregate and " +
+		"non-aggregate fields is not supported")
+)
+
+// influxQLAggregations are the supported aggregate functions.
+var influxQLAggregations = map[string]struct{}{
+	"count": struct{}{},
+	"first": struct{}{},
+	"last":  struct{}{},
+	"max":   struct{}{},
+	"mean":  struct{}{},
+	"min":   struct{}{},
+	"sum":   struct{}{},
+}
+
+type influxQLFill int
+
+const (
+	influxQLFillNull influxQLFill = iota
+	influxQLFillNone
+)
+
+// influxQLStatement is a parsed InfluxQL statement. Only SELECT statements
+// are executed, CREATE DATABASE statements are accepted as no-ops since
+// clients such as Telegraf issue them on startup.
+type influxQLStatement struct {
+	sel *influxQLSelect
+}
+
+// influxQLSelect is a parsed InfluxQL SELECT statement of the form:
+// SELECT <fields> FROM <measurement> WHERE <conditions>
+// GROUP BY time(<interval>), <tags> fill(null|none).
+type influxQLSelect struct {
+	measurement string
+	fields      []influxQLField
+	matchers    []influxQLTagMatcher
+	start       time.Time
+	end         time.Time
+	interval    time.Duration
+	groupByTags []string
+	fill        influxQLFill
+}
+
+type influxQLField struct {
+	name        string
+	aggregation string
+	alias       string
+}
+
+// column returns the name of the result column for the field.
+func (f influxQLField) column() string {
+	switch {
+	case f.alias != "":
+		return f.alias
+	case f.aggregation != "":
+		return f.aggregation
+	default:
+		return f.name
+	}
+}
+
+type influxQLTagMatcher struct {
+	matchType models.MatchType
+	name      string
+	value     string
+}
+
+// fetchQueries returns the fetch query for each selected field, fields are
+// stored as series named after the measurement and field the same way they
+// are named when written.
+func (s *influxQLSelect) fetchQueries(
+	rewriter *promRewriter,
+	tagOpts models.TagOptions,
+) ([]*storage.FetchQuery, error) {
+	matchers := make(models.Matchers, 0, 1+len(s.matchers))
+	matchers = append(matchers, models.Matcher{})
+	for _, m := range s.matchers {
+		matcher, err := models.NewMatcher(m.matchType,
+			rewriter.labelName(m.name), []byte(m.value))
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	queries := make([]*storage.FetchQuery, 0, len(s.fields))
+	for _, field := range s.fields {
+		fieldMatchers := make(models.Matchers, len(matchers))
+		copy(fieldMatchers, matchers)
+		name, err := models.NewMatcher(models.MatchEqual, tagOpts.MetricName(),
+			rewriter.metricName(s.measurement, field.name))
+		if err != nil {
+			return nil, err
+		}
+		fieldMatchers[0] = name
+		queries = append(queries, &storage.FetchQuery{
+			Raw:         fmt.Sprintf("%s.%s", s.measurement, field.name),
+			TagMatchers: fieldMatchers,
+			Start:       s.start,
+			End:         s.end,
+			Interval:    s.interval,
+		})
+	}
+
+	return queries, nil
+}
+
+// parseInfluxQL parses a semicolon separated list of InfluxQL statements,
+// relative times are resolved against the given now.
+func parseInfluxQL(query string, now time.Time) ([]influxQLStatement, error) {
+	tokens, err := lexInfluxQL(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &influxQLParser{tokens: tokens, now: now}
+	var statements []influxQLStatement
+	for {
+		for p.acceptPunct(";") {
+		}
+		if p.peek().typ == influxQLEOF {
+			break
+		}
+		stmt, err := p.parseStatement()
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+		if !p.acceptPunct(";") && p.peek().typ != influxQLEOF {
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(statements) == 0 {
+		return nil, errInfluxQLEmptyQuery
+	}
+	return statements, nil
+}
+
+type influxQLParser struct {
+	tokens []influxQLToken
+	pos    int
+	now    time.Time
+}
+
+func (p *influxQLParser) peek() influxQLToken {
+	return p.tokens[p.pos]
+}
+
+func (p *influxQLParser) next() influxQLToken {
+	tok := p.tokens[p.pos]
+	if tok.typ != influxQLEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *influxQLParser) unexpected() error {
+	tok := p.peek()
+	if tok.typ == influxQLEOF {
+		return errors.New("unexpected end of query")
+	}
+	return fmt.Errorf("unexpected %q at position %d", tok.val, tok.pos)
+}
+
+// acceptKeyword consumes the next token if it is the given keyword, keywords
+// are case insensitive and are never quoted.
+func (p *influxQLParser) acceptKeyword(keyword string) bool {
+	tok := p.peek()
+	if tok.typ == influxQLIdent && !tok.quoted &&
+		strings.EqualFold(tok.val, keyword) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *influxQLParser) expectKeyword(keyword string) error {
+	if !p.acceptKeyword(keyword) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *influxQLParser) acceptPunct(punct string) bool {
+	tok := p.peek()
+	if tok.typ == influxQLPunct && tok.val == punct {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *influxQLParser) expectPunct(punct string) error {
+	if !p.acceptPunct(punct) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *influxQLParser) expectIdent() (influxQLToken, error) {
+	tok := p.peek()
+	if tok.typ != influxQLIdent {
+		return tok, p.unexpected()
+	}
+	return p.next(), nil
+}
+
+func (p *influxQLParser) parseStatement() (influxQLStatement, error) {
+	switch {
+	case p.acceptKeyword("select"):
+		sel, err := p.parseSelect()
+		return influxQLStatement{sel: sel}, err
+	case p.acceptKeyword("create"):
+		if err := p.expectKeyword("database"); err != nil {
+			return influxQLStatement{}, err
+		}
+		if _, err := p.expectIdent(); err != nil {
+			return influxQLStatement{}, err
+		}
+		// Skip any retention policy options, they do not apply.
+		for tok := p.peek(); tok.typ != influxQLEOF &&
+			!(tok.typ == influxQLPunct && tok.val == ";"); tok = p.peek() {
+			p.next()
+		}
+		return influxQLStatement{}, nil
+	default:
+		return influxQLStatement{}, p.unexpected()
+	}
+}
+
+func (p *influxQLParser) parseSelect() (*influxQLSelect, error) {
+	sel := &influxQLSelect{end: p.now}
+	for {
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		sel.fields = append(sel.fields, field)
+		if !p.acceptPunct(",") {
+			break
+		}
+	}
+
+	if err := p.expectKeyword("from"); err != nil {
+		return nil, err
+	}
+	measurement, err := p.parseMeasurement()
+	if err != nil {
+		return nil, err
+	}
+	sel.measurement = measurement
+
+	if p.acceptKeyword("where") {
+		if err := p.parseConditions(sel); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("group") {
+		if err := p.expectKeyword("by"); err != nil {
+			return nil, err
+		}
+		if err := p.parseGroupBy(sel); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("fill") {
+		if err := p.parseFill(sel); err != nil {
+			return nil, err
+		}
+	}
+
+	if sel.start.IsZero() {
+		return nil, errInfluxQLNoLowerBound
+	}
+	if !sel.start.Before(sel.end) {
+		return nil, fmt.Errorf("invalid time range: start %v is not before end %v",
+			sel.start, sel.end)
+	}
+
+	aggregations := 0
+	for _, field := range sel.fields {
+		if field.aggregation != "" {
+			aggregations++
+		}
+	}
+	if aggregations > 0 && aggregations < len(sel.fields) {
+		return nil, errInfluxQLMixedFields
+	}
+	if aggregations == 0 && (sel.interval > 0 || len(sel.groupByTags) > 0) {
+		return nil, errInfluxQLRawGroupBy
+	}
+
+	return sel, nil
+}
+
+func (p *influxQLParser) parseField() (influxQLField, error) {
+	if p.acceptPunct("*") {
+		return influxQLField{}, errors.New("wildcard fields are not supported")
+	}
+
+	tok, err := p.expectIdent()
+	if err != nil {
+		return influxQLField{}, err
+	}
+
+	field := influxQLField{name: tok.val}
+	if !tok.quoted && p.acceptPunct("(") {
+		aggregation := strings.ToLower(tok.val)
+		if _, ok := influxQLAggregations[aggregation]; !ok {
+			return influxQLField{}, fmt.Errorf("unsupported function: %s", tok.val)
+		}
+		arg, err := p.expectIdent()
+		if err != nil {
+			return influxQLField{}, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return influxQLField{}, err
+		}
+		field = influxQLField{name: arg.val, aggregation: aggregation}
+	}
+
+	if p.acceptKeyword("as") {
+		alias, err := p.expectIdent()
+		if err != nil {
+			return influxQLField{}, err
+		}
+		field.alias = alias.val
+	}
+
+	return field, nil
+}
+
+// parseMeasurement parses a measurement which may be qualified with a
+// database and retention policy, which are ignored.
+func (p *influxQLParser) parseMeasurement() (string, error) {
+	tok, err := p.expectIdent()
+	if err != nil {
+		return "", err
+	}
+	name := tok.val
+	for p.acceptPunct(".") {
+		// Allow an empty retention policy, i.e. "db".."measurement".
+		if p.peek().typ == influxQLPunct && p.peek().val == "." {
+			continue
+		}
+		tok, err := p.expectIdent()
+		if err != nil {
+			return "", err
+		}
+		name = tok.val
+	}
+	return name, nil
+}
+
+func (p *influxQLParser) parseConditions(sel *influxQLSelect) error {
+	for {
+		if p.acceptPunct("(") {
+			if err := p.parseConditions(sel); err != nil {
+				return err
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return err
+			}
+		} else if err := p.parseCondition(sel); err != nil {
+			return err
+		}
+
+		if p.acceptKeyword("or") {
+			return errInfluxQLOrCondition
+		}
+		if !p.acceptKeyword("and") {
+			return nil
+		}
+	}
+}
+
+func (p *influxQLParser) parseCondition(sel *influxQLSelect) error {
+	tok, err := p.expectIdent()
+	if err != nil {
+		return err
+	}
+
+	op := p.peek()
+	if op.typ != influxQLPunct {
+		return p.unexpected()
+	}
+	p.next()
+
+	if strings.EqualFold(tok.val, "time") {
+		return p.parseTimeCondition(sel, op)
+	}
+
+	value := p.next()
+	var matchType models.MatchType
+	switch {
+	case (op.val == "=" || op.val == "!=" || op.val == "<>") &&
+		value.typ == influxQLString:
+		matchType = models.MatchEqual
+		if op.val != "=" {
+			matchType = models.MatchNotEqual
+		}
+	case (op.val == "=~" || op.val == "!~") && value.typ == influxQLRegex:
+		matchType = models.MatchRegexp
+		if op.val == "!~" {
+			matchType = models.MatchNotRegexp
+		}
+		re := anchorInfluxQLRegex(value.val)
+		if _, err := regexp.Compile(re); err != nil {
+			return err
+		}
+		value.val = re
+	default:
+		return fmt.Errorf("unsupported condition on %s at position %d",
+			tok.val, op.pos)
+	}
+
+	sel.matchers = append(sel.matchers, influxQLTagMatcher{
+		matchType: matchType,
+		name:      tok.val,
+		value:     value.val,
+	})
+	return nil
+}
+
+// anchorInfluxQLRegex converts an InfluxQL regex, which matches anywhere
+// in a tag value unless anchored, to a regex that matches the whole value.
+func anchorInfluxQLRegex(re string) string {
+	if strings.HasPrefix(re, "^") {
+		re = re[1:]
+	} else {
+		re = ".*" + re
+	}
+	if strings.HasSuffix(re, "$") && !strings.HasSuffix(re, `\$`) {
+		re = re[:len(re)-1]
+	} else {
+		re = re + ".*"
+	}
+	return re
+}
+
+func (p *influxQLParser) parseTimeCondition(
+	sel *influxQLSelect,
+	op influxQLToken,
+) error {
+	t, err := p.parseTime()
+	if err != nil {
+		return err
+	}
+
+	// Fetches include the start and exclude the end.
+	var start, end time.Time
+	switch op.val {
+	case ">":
+		start = t.Add(time.Nanosecond)
+	case ">=":
+		start = t
+	case "<":
+		end = t
+	case "<=":
+		end = t.Add(time.Nanosecond)
+	case "=":
+		start, end = t, t.Add(time.Nanosecond)
+	default:
+		return fmt.Errorf("unsupported time condition %q at position %d",
+			op.val, op.pos)
+	}
+
+	if !start.IsZero() && start.After(sel.start) {
+		sel.start = start
+	}
+	if !end.IsZero() && end.Before(sel.end) {
+		sel.end = end
+	}
+	return nil
+}
+
+func (p *influxQLParser) parseTime() (time.Time, error) {
+	tok := p.next()
+	switch tok.typ {
+	case influxQLIdent:
+		if tok.quoted || !strings.EqualFold(tok.val, "now") {
+			break
+		}
+		if err := p.expectPunct("("); err != nil {
+			return time.Time{}, err
+		}
+		if err := p.expectPunct(")"); err != nil {
+			return time.Time{}, err
+		}
+		t := p.now
+		for {
+			var sign time.Duration
+			switch {
+			case p.acceptPunct("+"):
+				sign = 1
+			case p.acceptPunct("-"):
+				sign = -1
+			default:
+				return t, nil
+			}
+			offset := p.next()
+			if offset.typ != influxQLDuration {
+				return time.Time{}, fmt.Errorf("expected duration at position %d",
+					offset.pos)
+			}
+			t = t.Add(sign * offset.duration)
+		}
+	case influxQLString:
+		for _, layout := range []string{
+			time.RFC3339Nano,
+			"2006-01-02 15:04:05.999999999",
+			"2006-01-02",
+		} {
+			if t, err := time.Parse(layout, tok.val); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid time %q", tok.val)
+	case influxQLNumber:
+		// Integer times are nanoseconds since the epoch.
+		nanos, err := strconv.ParseInt(tok.val, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q", tok.val)
+		}
+		return time.Unix(0, nanos), nil
+	case influxQLDuration:
+		// Durations are offsets from the epoch, e.g. 1577836800s.
+		return time.Unix(0, int64(tok.duration)), nil
+	}
+	return time.Time{}, fmt.Errorf("expected time at position %d", tok.pos)
+}
+
+func (p *influxQLParser) parseGroupBy(sel *influxQLSelect) error {
+	for {
+		tok, err := p.expectIdent()
+		if err != nil {
+			return err
+		}
+
+		if !tok.quoted && strings.EqualFold(tok.val, "time") &&
+			p.acceptPunct("(") {
+			interval := p.next()
+			if interval.typ != influxQLDuration || interval.duration <= 0 {
+				return fmt.Errorf("expected interval at position %d", interval.pos)
+			}
+			if err := p.expectPunct(")"); err != nil {
+				return err
+			}
+			sel.interval = interval.duration
+		} else {
+			sel.groupByTags = append(sel.groupByTags, tok.val)
+		}
+
+		if !p.acceptPunct(",") {
+			return nil
+		}
+	}
+}
+
+func (p *influxQLParser) parseFill(sel *influxQLSelect) error {
+	if err := p.expectPunct("("); err != nil {
+		return err
+	}
+	tok, err := p.expectIdent()
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(tok.val) {
+	case "null":
+		sel.fill = influxQLFillNull
+	case "none":
+		sel.fill = influxQLFillNone
+	default:
+		return fmt.Errorf("unsupported fill option: %s", tok.val)
+	}
+	return p.expectPunct(")")
+}
+
+type influxQLTokenType int
+
+const (
+	influxQLEOF influxQLTokenType = iota
+	influxQLIdent
+	influxQLString
+	influxQLRegex
+	influxQLNumber
+	influxQLDuration
+	influxQLPunct
+)
+
+type influxQLToken struct {
+	typ      influxQLTokenType
+	val      string
+	pos      int
+	quoted   bool
+	duration time.Duration
+}
+
+// influxQLDurationUnits are the duration literal units, ordered so that
+// longer units are matched first.
+var influxQLDurationUnits = []struct {
+	unit     string
+	duration time.Duration
+}{
+	{"ns", time.Nanosecond},
+	{"ms", time.Millisecond},
+	{"u", time.Microsecond},
+	{"µ", time.Microsecond},
+	{"s", time.Second},
+	{"m", time.Minute},
+	{"h", time.Hour},
+	{"d", 24 * time.Hour},
+	{"w", 7 * 24 * time.Hour},
+}
+
+var influxQLPuncts = []string{
+	"!=", "!~", "<>", "<=", ">=", "=~",
+	"=", "<", ">", "(", ")", ",", ";", ".", "*", "+", "-",
+}
+
+func lexInfluxQL(query string) ([]influxQLToken, error) {
+	var tokens []influxQLToken
+	for pos := 0; pos < len(query); {
+		r, size := utf8.DecodeRuneInString(query[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case r == '\'' || r == '"' || r == '/':
+			val, n, err := lexInfluxQLQuoted(query[pos:], byte(r))
+			if err != nil {
+				return nil, fmt.Errorf("%v at position %d", err, pos)
+			}
+			tok := influxQLToken{typ: influxQLString, val: val, pos: pos}
+			switch r {
+			case '"':
+				tok.typ = influxQLIdent
+				tok.quoted = true
+			case '/':
+				tok.typ = influxQLRegex
+			}
+			tokens = append(tokens, tok)
+			pos += n
+		case r == '_' || unicode.IsLetter(r):
+			end := pos
+			for end < len(query) {
+				r, size := utf8.DecodeRuneInString(query[end:])
+				if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+					break
+				}
+				end += size
+			}
+			tokens = append(tokens,
+				influxQLToken{typ: influxQLIdent, val: query[pos:end], pos: pos})
+			pos = end
+		case r >= '0' && r <= '9':
+			tok, n, err := lexInfluxQLNumber(query[pos:])
+			if err != nil {
+				return nil, fmt.Errorf("%v at position %d", err, pos)
+			}
+			tok.pos = pos
+			tokens = append(tokens, tok)
+			pos += n
+		default:
+			matched := false
+			for _, punct := range influxQLPuncts {
+				if strings.HasPrefix(query[pos:], punct) {
+					tokens = append(tokens,
+						influxQLToken{typ: influxQLPunct, val: punct, pos: pos})
+					pos += len(punct)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected %q at position %d", r, pos)
+			}
+		}
+	}
+
+	return append(tokens, influxQLToken{typ: influxQLEOF, pos: len(query)}), nil
+}
+
+// lexInfluxQLQuoted returns the unescaped contents of a quoted string,
+// identifier or regex and the number of bytes consumed.
+func lexInfluxQLQuoted(s string, quote byte) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case quote:
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(s) && (s[i+1] == quote || (quote != '/' && s[i+1] == '\\')) {
+				i++
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return "", 0, errors.New("unterminated quoted literal")
+}
+
+// lexInfluxQLNumber lexes a number or duration literal, durations may
+// combine several units, e.g. 1h30m.
+func lexInfluxQLNumber(s string) (influxQLToken, int, error) {
+	var (
+		pos      int
+		duration time.Duration
+		isNumber bool
+	)
+	for pos < len(s) && s[pos] >= '0' && s[pos] <= '9' {
+		start := pos
+		for pos < len(s) && ((s[pos] >= '0' && s[pos] <= '9') || s[pos] == '.') {
+			pos++
+		}
+		digits := s[start:pos]
+
+		var unit *time.Duration
+		for _, u := range influxQLDurationUnits {
+			if strings.HasPrefix(s[pos:], u.unit) {
+				d := u.duration
+				unit = &d
+				pos += len(u.unit)
+				break
+			}
+		}
+		if unit == nil {
+			if duration != 0 || start != 0 {
+				return influxQLToken{}, 0, fmt.Errorf("invalid duration %q", s[:pos])
+			}
+			isNumber = true
+			break
+		}
+
+		n, err := strconv.ParseInt(digits, 10, 64)
+		if err != nil || n > math.MaxInt64/int64(*unit) {
+			return influxQLToken{}, 0, fmt.Errorf("invalid duration %q", s[:pos])
+		}
+		duration += time.Duration(n) * *unit
+	}
+
+	if isNumber {
+		return influxQLToken{typ: influxQLNumber, val: s[:pos]}, pos, nil
+	}
+	return influxQLToken{typ: influxQLDuration, val: s[:pos], duration: duration},
+		pos, nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInfluxQLSelect(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	stmts, err := parseInfluxQL(`SELECT mean("usage_idle") AS idle, max(usage_user) `+
+		`FROM "telegraf"."autogen"."cpu" WHERE "host" = 'a' AND cpu != 'total' `+
+		`AND dc =~ /^us-/ AND time > now() - 1h AND time <= now() - 5m `+
+		`GROUP BY time(1m), "host" fill(none)`, now)
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+
+	assert.Equal(t, &influxQLSelect{
+		measurement: "cpu",
+		fields: []influxQLField{
+			{name: "usage_idle", aggregation: "mean", alias: "idle"},
+			{name: "usage_user", aggregation: "max"},
+		},
+		matchers: []influxQLTagMatcher{
+			{matchType: models.MatchEqual, name: "host", value: "a"},
+			{matchType: models.MatchNotEqual, name: "cpu", value: "total"},
+			{matchType: models.MatchRegexp, name: "dc", value: "us-.*"},
+		},
+		start:       now.Add(-time.Hour).Add(time.Nanosecond),
+		end:         now.Add(-5 * time.Minute).Add(time.Nanosecond),
+		interval:    time.Minute,
+		groupByTags: []string{"host"},
+		fill:        influxQLFillNone,
+	}, stmts[0].sel)
+}
+
+func TestParseInfluxQLTimes(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	for _, tt := range []struct {
+		where string
+		start time.Time
+	}{
+		{"time >= now() - 1h30m", now.Add(-90 * time.Minute)},
+		{"time >= '2020-09-13T12:00:00Z'", time.Date(2020, 9, 13, 12, 0, 0, 0, time.UTC)},
+		{"time >= '2020-09-13 12:00:00'", time.Date(2020, 9, 13, 12, 0, 0, 0, time.UTC)},
+		{"time >= 1599998400000000000", time.Unix(1599998400, 0)},
+		{"time >= 1599998400s", time.Unix(1599998400, 0)},
+		{"time >= 1599998400000ms", time.Unix(1599998400, 0)},
+	} {
+		t.Run(tt.where, func(t *testing.T) {
+			stmts, err := parseInfluxQL("SELECT value FROM m WHERE "+tt.where, now)
+			require.NoError(t, err)
+			require.Len(t, stmts, 1)
+			assert.True(t, tt.start.Equal(stmts[0].sel.start),
+				"expected %v, got %v", tt.start, stmts[0].sel.start)
+			assert.Equal(t, now, stmts[0].sel.end)
+		})
+	}
+}
+
+func TestParseInfluxQLMultipleStatements(t *testing.T) {
+	stmts, err := parseInfluxQL(`CREATE DATABASE "telegraf"; `+
+		`select last(value) from m where time > now() - 1m;`, time.Now())
+	require.NoError(t, err)
+	require.Len(t, stmts, 2)
+	assert.Nil(t, stmts[0].sel)
+	require.NotNil(t, stmts[1].sel)
+	assert.Equal(t, "last", stmts[1].sel.fields[0].aggregation)
+}
+
+func TestParseInfluxQLErrors(t *testing.T) {
+	for _, q := range []string{
+		"",
+		";",
+		"SELECT",
+		"SELECT value FROM m",
+		"SELECT * FROM m WHERE time > now() - 1h",
+		"SELECT median(value) FROM m WHERE time > now() - 1h",
+		"SELECT value FROM m WHERE time > now() - 1h GROUP BY time(1m)",
+		"SELECT value FROM m WHERE time > now() - 1h GROUP BY host",
+		"SELECT value, sum(value) FROM m WHERE time > now() - 1h",
+		"SELECT value FROM m WHERE a = 'b' OR a = 'c' AND time > now() - 1h",
+		"SELECT value FROM m WHERE a = /b/ AND time > now() - 1h",
+		"SELECT value FROM m WHERE a =~ 'b' AND time > now() - 1h",
+		"SELECT value FROM m WHERE a =~ /(/ AND time > now() - 1h",
+		"SELECT value FROM m WHERE time > now() + 1h",
+		"SELECT value FROM m WHERE time > now() - 1h LIMIT 10",
+		"SELECT sum(value) FROM m WHERE time > now() - 1h fill(previous)",
+		"SELECT value FROM m WHERE time > 'yesterday'",
+		"SELECT value FROM 'm' WHERE time > now() - 1h",
+		"SHOW DATABASES",
+	} {
+		t.Run(q, func(t *testing.T) {
+			_, err := parseInfluxQL(q, time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestInfluxQLFetchQueries(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	stmts, err := parseInfluxQL(`SELECT sum(value), sum("bytes.in") FROM "disk.io" `+
+		`WHERE "host-name" !~ /^a$/ AND time > now() - 1h`, now)
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+
+	queries, err := stmts[0].sel.fetchQueries(newPromRewriter(),
+		models.NewTagOptions())
+	require.NoError(t, err)
+	require.Len(t, queries, 2)
+
+	for i, name := range []string{"disk_io_value", "disk_io_bytes_in"} {
+		query := queries[i]
+		assert.Equal(t, now.Add(-time.Hour).Add(time.Nanosecond), query.Start)
+		assert.Equal(t, now, query.End)
+		require.Len(t, query.TagMatchers, 2)
+		assert.Equal(t, models.MatchEqual, query.TagMatchers[0].Type)
+		assert.Equal(t, "__name__", string(query.TagMatchers[0].Name))
+		assert.Equal(t, name, string(query.TagMatchers[0].Value))
+		assert.Equal(t, models.MatchNotRegexp, query.TagMatchers[1].Type)
+		assert.Equal(t, "host_name", string(query.TagMatchers[1].Name))
+		assert.Equal(t, "a", string(query.TagMatchers[1].Value))
+	}
+}
+
+func TestAnchorInfluxQLRegex(t *testing.T) {
+	assert.Equal(t, ".*foo.*", anchorInfluxQLRegex("foo"))
+	assert.Equal(t, "foo", anchorInfluxQLRegex("^foo$"))
+	assert.Equal(t, "foo.*", anchorInfluxQLRegex("^foo"))
+	assert.Equal(t, `.*foo\$.*`, anchorInfluxQLRegex(`foo\$`))
+}
@@ -0,0 +1,400 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// InfluxQueryURL is the Influx DB query handler URL
+	InfluxQueryURL = handler.RoutePrefixV1 + "/influxdb/query"
+
+	// maxInfluxQLBuckets is the maximum number of GROUP BY time() buckets
+	// returned for a single statement.
+	maxInfluxQLBuckets = 100000
+)
+
+var (
+	// InfluxQueryHTTPMethods are the HTTP methods used with this resource
+	InfluxQueryHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errMissingQuery = errors.New("missing required parameter \"q\"")
+	errInvalidEpoch = errors.New("invalid epoch, valid values are: " +
+		"ns, n, u, µ, ms, s, m, h")
+	errTooManyBuckets = fmt.Errorf("query selects more than %d buckets, "+
+		"use a larger GROUP BY time() interval", maxInfluxQLBuckets)
+)
+
+type queryHandler struct {
+	storage             storage.Storage
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	tagOpts             models.TagOptions
+	promRewriter        *promRewriter
+	nowFn               clock.NowFn
+	instrumentOpts      instrument.Options
+}
+
+type influxQLResponse struct {
+	Results []influxQLResult `json:"results"`
+}
+
+type influxQLResult struct {
+	StatementID int              `json:"statement_id"`
+	Series      []influxQLSeries `json:"series,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+type influxQLSeries struct {
+	Name    string            `json:"name"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Columns []string          `json:"columns"`
+	Values  [][]interface{}   `json:"values"`
+}
+
+// NewInfluxQueryHandler returns a new instance of the InfluxQL query handler,
+// which supports a subset of InfluxQL SELECT statements.
+func NewInfluxQueryHandler(opts options.HandlerOptions) http.Handler {
+	return &queryHandler{
+		storage:             opts.Storage(),
+		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
+		tagOpts:             opts.TagOptions(),
+		promRewriter:        newPromRewriter(),
+		nowFn:               opts.NowFn(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+func (h *queryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.FormValue("q")
+	if query == "" {
+		xhttp.Error(w, errMissingQuery, http.StatusBadRequest)
+		return
+	}
+
+	epoch, err := parseEpoch(r.FormValue("epoch"))
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	statements, err := parseInfluxQL(query, h.nowFn())
+	if err != nil {
+		xhttp.Error(w, fmt.Errorf("error parsing query: %v", err),
+			http.StatusBadRequest)
+		return
+	}
+
+	fetchOpts, rErr := h.fetchOptionsBuilder.NewFetchOptions(r)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	results := make([]influxQLResult, 0, len(statements))
+	for i, stmt := range statements {
+		result := influxQLResult{StatementID: i}
+		if stmt.sel != nil {
+			series, err := h.executeSelect(ctx, stmt.sel, fetchOpts, epoch)
+			if err != nil {
+				logger.Error("unable to execute influxql statement",
+					zap.String("query", query), zap.Error(err))
+				result.Error = err.Error()
+			}
+			result.Series = series
+		}
+		results = append(results, result)
+	}
+
+	xhttp.WriteJSONResponse(w, influxQLResponse{Results: results}, logger)
+}
+
+// parseEpoch returns the unit that result times are returned in, or zero
+// if they are returned as RFC3339 strings.
+func parseEpoch(epoch string) (time.Duration, error) {
+	switch epoch {
+	case "":
+		return 0, nil
+	case "n", "ns":
+		return time.Nanosecond, nil
+	case "u", "µ":
+		return time.Microsecond, nil
+	case "ms":
+		return time.Millisecond, nil
+	case "s":
+		return time.Second, nil
+	case "m":
+		return time.Minute, nil
+	case "h":
+		return time.Hour, nil
+	default:
+		return 0, errInvalidEpoch
+	}
+}
+
+type influxQLGroup struct {
+	tags   map[string]string
+	fields []map[int64]*influxQLAggregator
+}
+
+func (h *queryHandler) executeSelect(
+	ctx context.Context,
+	sel *influxQLSelect,
+	fetchOpts *storage.FetchOptions,
+	epoch time.Duration,
+) ([]influxQLSeries, error) {
+	queries, err := sel.fetchQueries(h.promRewriter, h.tagOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	groupByLabels := make([]string, 0, len(sel.groupByTags))
+	for _, tag := range sel.groupByTags {
+		groupByLabels = append(groupByLabels, string(h.promRewriter.labelName(tag)))
+	}
+
+	var (
+		interval = sel.interval.Nanoseconds()
+		start    = sel.start.UnixNano()
+		groups   = make(map[string]*influxQLGroup)
+		labels   = make(map[string]string)
+	)
+	for i, query := range queries {
+		result, err := h.storage.FetchProm(ctx, query, fetchOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, series := range result.PromResult.GetTimeseries() {
+			for k := range labels {
+				delete(labels, k)
+			}
+			for _, label := range series.GetLabels() {
+				labels[string(label.Name)] = string(label.Value)
+			}
+
+			values := make([]string, 0, len(groupByLabels))
+			for _, label := range groupByLabels {
+				values = append(values, labels[label])
+			}
+			key := strings.Join(values, "\xff")
+
+			group, ok := groups[key]
+			if !ok {
+				group = &influxQLGroup{
+					fields: make([]map[int64]*influxQLAggregator, len(queries)),
+				}
+				if len(sel.groupByTags) > 0 {
+					group.tags = make(map[string]string, len(sel.groupByTags))
+					for j, tag := range sel.groupByTags {
+						group.tags[tag] = values[j]
+					}
+				}
+				groups[key] = group
+			}
+			if group.fields[i] == nil {
+				group.fields[i] = make(map[int64]*influxQLAggregator)
+			}
+
+			aggregation := sel.fields[i].aggregation
+			for _, sample := range series.GetSamples() {
+				if math.IsNaN(sample.Value) {
+					continue
+				}
+
+				// Samples are aggregated at their own time so that first and
+				// last do not depend on the order series are returned in, t
+				// is only the bucket the sample is grouped into.
+				sampleTime := sample.Timestamp * int64(time.Millisecond)
+				t := sampleTime
+				switch {
+				case interval > 0:
+					t -= t % interval
+				case aggregation != "":
+					t = start
+				}
+
+				agg, ok := group.fields[i][t]
+				if !ok {
+					agg = &influxQLAggregator{aggregation: aggregation}
+					group.fields[i][t] = agg
+				}
+				agg.add(sampleTime, sample.Value)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	columns := make([]string, 0, 1+len(sel.fields))
+	columns = append(columns, "time")
+	for _, field := range sel.fields {
+		columns = append(columns, field.column())
+	}
+
+	series := make([]influxQLSeries, 0, len(groups))
+	for _, key := range keys {
+		group := groups[key]
+		times, err := sel.times(group)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([][]interface{}, 0, len(times))
+		for _, t := range times {
+			row := make([]interface{}, 0, len(columns))
+			row = append(row, formatInfluxQLTime(t, epoch))
+			for _, field := range group.fields {
+				if agg, ok := field[t]; ok {
+					row = append(row, agg.value())
+				} else {
+					row = append(row, nil)
+				}
+			}
+			values = append(values, row)
+		}
+
+		series = append(series, influxQLSeries{
+			Name:    sel.measurement,
+			Tags:    group.tags,
+			Columns: columns,
+			Values:  values,
+		})
+	}
+
+	return series, nil
+}
+
+// times returns the sorted result times for a group, which are all the
+// buckets in the time range when grouping by time with fill(null).
+func (s *influxQLSelect) times(group *influxQLGroup) ([]int64, error) {
+	var times []int64
+	if s.interval > 0 && s.fill == influxQLFillNull {
+		interval := s.interval.Nanoseconds()
+		start, end := s.start.UnixNano(), s.end.UnixNano()
+		if (end-start)/interval > maxInfluxQLBuckets {
+			return nil, errTooManyBuckets
+		}
+		for t := start - start%interval; t < end; t += interval {
+			times = append(times, t)
+		}
+		return times, nil
+	}
+
+	seen := make(map[int64]struct{})
+	for _, field := range group.fields {
+		for t := range field {
+			if _, ok := seen[t]; !ok {
+				seen[t] = struct{}{}
+				times = append(times, t)
+			}
+		}
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i] < times[j]
+	})
+	return times, nil
+}
+
+func formatInfluxQLTime(t int64, epoch time.Duration) interface{} {
+	if epoch == 0 {
+		return time.Unix(0, t).UTC().Format(time.RFC3339Nano)
+	}
+	return t / epoch.Nanoseconds()
+}
+
+// influxQLAggregator aggregates the values of a single result cell, raw
+// selects without an aggregation keep the last value at a time.
+type influxQLAggregator struct {
+	aggregation string
+	count       int
+	sum         float64
+	min         float64
+	max         float64
+	first       float64
+	firstTime   int64
+	last        float64
+	lastTime    int64
+}
+
+func (a *influxQLAggregator) add(t int64, v float64) {
+	if a.count == 0 {
+		a.min, a.max = v, v
+		a.first, a.firstTime = v, t
+		a.last, a.lastTime = v, t
+	}
+	a.count++
+	a.sum += v
+	a.min = math.Min(a.min, v)
+	a.max = math.Max(a.max, v)
+	if t < a.firstTime {
+		a.first, a.firstTime = v, t
+	}
+	if t >= a.lastTime {
+		a.last, a.lastTime = v, t
+	}
+}
+
+func (a *influxQLAggregator) value() float64 {
+	switch a.aggregation {
+	case "count":
+		return float64(a.count)
+	case "sum":
+		return a.sum
+	case "mean":
+		return a.sum / float64(a.count)
+	case "min":
+		return a.min
+	case "max":
+		return a.max
+	case "first":
+		return a.first
+	default:
+		return a.last
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package influxdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQueryHandler(
+	store storage.Storage,
+	now time.Time,
+) http.Handler {
+	fb := handleroptions.
+		NewFetchOptionsBuilder(handleroptions.FetchOptionsBuilderOptions{})
+	opts := options.EmptyHandlerOptions().
+		SetStorage(store).
+		SetTagOptions(models.NewTagOptions()).
+		SetFetchOptionsBuilder(fb).
+		SetNowFn(func() time.Time { return now })
+	return NewInfluxQueryHandler(opts)
+}
+
+func testPromSeries(host string, samples ...prompb.Sample) *prompb.TimeSeries {
+	return &prompb.TimeSeries{
+		Labels: []prompb.Label{
+			{Name: []byte("__name__"), Value: []byte("ignored")},
+			{Name: []byte("host"), Value: []byte(host)},
+		},
+		Samples: samples,
+	}
+}
+
+func TestInfluxQueryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Unix(1600000020, 0)
+	ms := func(d time.Duration) int64 {
+		return now.Add(d).UnixNano() / int64(time.Millisecond)
+	}
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().
+		FetchProm(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			query *storage.FetchQuery,
+			_ *storage.FetchOptions,
+		) (storage.PromResult, error) {
+			assert.Equal(t, now.Add(-3*time.Minute), query.Start)
+			assert.Equal(t, now, query.End)
+			assert.Equal(t, time.Minute, query.Interval)
+
+			var series []*prompb.TimeSeries
+			switch string(query.TagMatchers[0].Value) {
+			case "cpu_idle":
+				series = []*prompb.TimeSeries{
+					testPromSeries("a",
+						prompb.Sample{Timestamp: ms(-170 * time.Second), Value: 1},
+						prompb.Sample{Timestamp: ms(-160 * time.Second), Value: 3},
+						prompb.Sample{Timestamp: ms(-time.Minute), Value: 5}),
+					testPromSeries("b",
+						prompb.Sample{Timestamp: ms(-3 * time.Minute), Value: 2}),
+				}
+			case "cpu_user":
+				series = []*prompb.TimeSeries{
+					testPromSeries("a",
+						prompb.Sample{Timestamp: ms(-2 * time.Minute), Value: 7}),
+				}
+			default:
+				t.Fatalf("unexpected query: %v", query.TagMatchers)
+			}
+			return storage.PromResult{
+				PromResult: &prompb.QueryResult{Timeseries: series},
+			}, nil
+		}).
+		Times(2)
+
+	handler := newTestQueryHandler(store, now)
+	q := url.Values{}
+	q.Set("q", `CREATE DATABASE telegraf; SELECT mean(idle), max(user) AS user `+
+		`FROM cpu WHERE time >= now() - 3m GROUP BY time(1m), host`)
+	q.Set("epoch", "s")
+	req := httptest.NewRequest(http.MethodGet, InfluxQueryURL+"?"+q.Encode(), nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	expected := xtest.MustPrettyJSON(t, `
+	{
+		"results": [
+			{
+				"statement_id": 0
+			},
+			{
+				"statement_id": 1,
+				"series": [
+					{
+						"name": "cpu",
+						"tags": {"host": "a"},
+						"columns": ["time", "mean", "user"],
+						"values": [
+							[1599999840, 2, null],
+							[1599999900, null, 7],
+							[1599999960, 5, null]
+						]
+					},
+					{
+						"name": "cpu",
+						"tags": {"host": "b"},
+						"columns": ["time", "mean", "user"],
+						"values": [
+							[1599999840, 2, null],
+							[1599999900, null, null],
+							[1599999960, null, null]
+						]
+					}
+				]
+			}
+		]
+	}`)
+	actual := xtest.MustPrettyJSON(t, recorder.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestInfluxQueryHandlerFirstLastInterleavedSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Unix(1600000020, 0)
+	ms := func(d time.Duration) int64 {
+		return now.Add(d).UnixNano() / int64(time.Millisecond)
+	}
+
+	// Samples of both series interleave in time within the same bucket, and
+	// the series holding neither the first nor the last sample is returned
+	// first.
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().
+		FetchProm(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(storage.PromResult{
+			PromResult: &prompb.QueryResult{
+				Timeseries: []*prompb.TimeSeries{
+					testPromSeries("b",
+						prompb.Sample{Timestamp: ms(-165 * time.Second), Value: 2},
+						prompb.Sample{Timestamp: ms(-135 * time.Second), Value: 4}),
+					testPromSeries("a",
+						prompb.Sample{Timestamp: ms(-170 * time.Second), Value: 1},
+						prompb.Sample{Timestamp: ms(-130 * time.Second), Value: 3}),
+				},
+			},
+		}, nil).
+		Times(2)
+
+	handler := newTestQueryHandler(store, now)
+	q := url.Values{}
+	q.Set("q", `SELECT first(value), last(value) FROM m `+
+		`WHERE time >= now() - 3m GROUP BY time(1m) fill(none)`)
+	q.Set("epoch", "s")
+	req := httptest.NewRequest(http.MethodGet, InfluxQueryURL+"?"+q.Encode(), nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	expected := xtest.MustPrettyJSON(t, `
+	{
+		"results": [
+			{
+				"statement_id": 0,
+				"series": [
+					{
+						"name": "m",
+						"columns": ["time", "first", "last"],
+						"values": [
+							[1599999840, 1, 3]
+						]
+					}
+				]
+			}
+		]
+	}`)
+	actual := xtest.MustPrettyJSON(t, recorder.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestInfluxQueryHandlerRawValues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Unix(1600000000, 0)
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().
+		FetchProm(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(storage.PromResult{
+			PromResult: &prompb.QueryResult{
+				Timeseries: []*prompb.TimeSeries{
+					testPromSeries("a", prompb.Sample{
+						Timestamp: now.Add(-time.Minute).UnixNano() / int64(time.Millisecond),
+						Value:     1.5,
+					}),
+				},
+			},
+		}, nil)
+
+	handler := newTestQueryHandler(store, now)
+	q := url.Values{}
+	q.Set("q", `SELECT value FROM m WHERE time > now() - 5m`)
+	req := httptest.NewRequest(http.MethodPost, InfluxQueryURL,
+		nil)
+	req.Form = q
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	expected := xtest.MustPrettyJSON(t, `
+	{
+		"results": [
+			{
+				"statement_id": 0,
+				"series": [
+					{
+						"name": "m",
+						"columns": ["time", "value"],
+						"values": [
+							["2020-09-13T12:25:40Z", 1.5]
+						]
+					}
+				]
+			}
+		]
+	}`)
+	actual := xtest.MustPrettyJSON(t, recorder.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestInfluxQueryHandlerBadRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := newTestQueryHandler(storage.NewMockStorage(ctrl), time.Now())
+	for _, query := range []string{
+		"",
+		"q=SELECT",
+		"q=SELECT+value+FROM+m+WHERE+time+>+now()+-+1h&epoch=d",
+	} {
+		req := httptest.NewRequest(http.MethodGet, InfluxQueryURL+"?"+query, nil)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, query)
+	}
+}
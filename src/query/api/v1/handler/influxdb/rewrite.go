@@ -89,3 +89,23 @@ func (pr *promRewriter) rewriteMetricTail(data []byte) {
 func (pr *promRewriter) rewriteLabel(data []byte) {
 	pr.label.rewrite(data)
 }
+
+// metricName returns the rewritten name of the series that a field of a
+// measurement is written to.
+func (pr *promRewriter) metricName(measurement, field string) []byte {
+	name := make([]byte, 0, len(measurement)+1+len(field))
+	name = append(name, measurement...)
+	name = append(name, byte('_'))
+	pr.rewriteMetric(name)
+	tail := len(name)
+	name = append(name, field...)
+	pr.rewriteMetricTail(name[tail:])
+	return name
+}
+
+// labelName returns the rewritten name of a tag.
+func (pr *promRewriter) labelName(name string) []byte {
+	label := []byte(name)
+	pr.rewriteLabel(label)
+	return label
+}
@@ -29,8 +29,10 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/ts"
@@ -40,6 +42,7 @@ import (
 	xhttp "github.com/m3db/m3/src/x/net/http"
 	xtime "github.com/m3db/m3/src/x/time"
 	imodels "github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/escape"
 	"go.uber.org/zap"
 )
 
@@ -51,10 +54,16 @@ const (
 	InfluxWriteHTTPMethod = http.MethodPost
 )
 
+var (
+	errInvalidPrecision = errors.New("invalid precision, valid values " +
+		"are: n, ns, u, us, µ, ms, s, m, h")
+)
+
 type ingestWriteHandler struct {
 	handlerOpts  options.HandlerOptions
 	tagOpts      models.TagOptions
 	promRewriter *promRewriter
+	influxCfg    config.InfluxDBConfiguration
 }
 
 type ingestField struct {
@@ -67,6 +76,7 @@ type ingestIterator struct {
 	points       []imodels.Point
 	tagOpts      models.TagOptions
 	promRewriter *promRewriter
+	influxCfg    config.InfluxDBConfiguration
 
 	// internal
 	pointIndex int
@@ -75,6 +85,7 @@ type ingestIterator struct {
 	// following entries are within current point, and initialized
 	// when we go to the first entry in the current point
 	fields         []*ingestField
+	stringTags     []models.Tag
 	nextFieldIndex int
 	tags           models.Tags
 }
@@ -84,6 +95,7 @@ func (ii *ingestIterator) populateFields() bool {
 	it := point.FieldIterator()
 	n := 0
 	ii.fields = make([]*ingestField, 0, 10)
+	ii.stringTags = ii.stringTags[:0]
 	bname := make([]byte, 0, len(point.Name())+1)
 	bname = append(bname, point.Name()...)
 	bname = append(bname, byte('_'))
@@ -94,6 +106,9 @@ func (ii *ingestIterator) populateFields() bool {
 		n += 1
 		switch it.Type() {
 		case imodels.Boolean:
+			if ii.influxCfg.BooleanFields == config.DropInfluxDBBooleanFields {
+				continue
+			}
 			v, err := it.BooleanValue()
 			if err != nil {
 				ii.err = ii.err.Add(err)
@@ -123,13 +138,24 @@ func (ii *ingestIterator) populateFields() bool {
 				continue
 			}
 			value = v
+		case imodels.String:
+			// Strings are dropped by default to prevent cardinality
+			// explosion, unless explicitly configured to be tags
+			if ii.influxCfg.StringFields == config.TagInfluxDBStringFields {
+				key := escape.Unescape(it.FieldKey())
+				name := make([]byte, len(key))
+				copy(name, key)
+				ii.promRewriter.rewriteLabel(name)
+				ii.stringTags = append(ii.stringTags, models.Tag{
+					Name:  name,
+					Value: []byte(it.StringValue()),
+				})
+			}
+			continue
 		default:
-			// TBD if we should stick strings as
-			// tags or not; to prevent cardinality
-			// explosion, we drop them for now
 			continue
 		}
-		tail := it.FieldKey()
+		tail := escape.Unescape(it.FieldKey())
 		name := make([]byte, 0, bnamelen+len(tail))
 		name = append(name, bname...)
 		name = append(name, tail...)
@@ -153,6 +179,9 @@ func (ii *ingestIterator) Next() bool {
 					ii.promRewriter.rewriteLabel(name)
 					tags = tags.AddTagWithoutNormalizing(models.Tag{Name: name, Value: tag.Value})
 				}
+				for _, tag := range ii.stringTags {
+					tags = tags.AddTagWithoutNormalizing(tag)
+				}
 				// sanity check no duplicate Name's;
 				// after Normalize, they are sorted so
 				// can just check them sequentially
@@ -243,10 +272,28 @@ func (ii *ingestIterator) Error() error {
 	return ii.err.FinalError()
 }
 
+// NewInfluxWriterHandler returns a new instance of the InfluxDB write handler.
 func NewInfluxWriterHandler(options options.HandlerOptions) http.Handler {
 	return &ingestWriteHandler{handlerOpts: options,
 		tagOpts:      options.TagOptions(),
-		promRewriter: newPromRewriter()}
+		promRewriter: newPromRewriter(),
+		influxCfg:    options.Config().InfluxDB}
+}
+
+// parsePrecision returns the line protocol precision for the precision
+// parameter of a write request, accepting the aliases used by InfluxDB
+// clients.
+func parsePrecision(precision string) (string, error) {
+	switch precision {
+	case "", "n", "ns":
+		return "n", nil
+	case "u", "us", "µ":
+		return "u", nil
+	case "ms", "s", "m", "h":
+		return precision, nil
+	default:
+		return "", errInvalidPrecision
+	}
 }
 
 func (iwh *ingestWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -255,13 +302,24 @@ func (iwh *ingestWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		xhttp.Error(w, err, http.StatusInternalServerError)
 		return
 	}
-	points, err := imodels.ParsePoints(bytes)
+	precision, err := parsePrecision(r.URL.Query().Get("precision"))
 	if err != nil {
-		xhttp.Error(w, err, http.StatusInternalServerError)
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+	points, err := imodels.ParsePointsWithPrecision(bytes, time.Now().UTC(), precision)
+	if err != nil {
+		xhttp.Error(w, err, http.StatusBadRequest)
+		return
+	}
+	t, rErr := handleroptions.ParseTenant(r, iwh.handlerOpts.Tenants())
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
 		return
 	}
-	opts := ingest.WriteOptions{}
-	iter := &ingestIterator{points: points, tagOpts: iwh.tagOpts, promRewriter: iwh.promRewriter}
+	opts := ingest.WriteOptions{Tenant: t}
+	iter := &ingestIterator{points: points, tagOpts: iwh.tagOpts,
+		promRewriter: iwh.promRewriter, influxCfg: iwh.influxCfg}
 	batchErr := iwh.handlerOpts.DownsamplerAndWriter().WriteBatch(r.Context(), iter, opts)
 	if batchErr == nil {
 		w.WriteHeader(http.StatusNoContent)
@@ -21,11 +21,21 @@
 package influxdb
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
 	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
 	imodels "github.com/influxdata/influxdb/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -127,3 +137,105 @@ func TestDetermineTimeUnit(t *testing.T) {
 	assert.Equal(t, determineTimeUnit(zerot.Add(4*time.Nanosecond)), xtime.Nanosecond)
 
 }
+
+func TestIngestIteratorEscaping(t *testing.T) {
+	s := `measure\ 1,tag\ key=val\,ue,t2=a\=b f\ 1=1i,f\,2=2i 1574838670386469800
+`
+	points, err := imodels.ParsePoints([]byte(s))
+	require.NoError(t, err)
+	iter := &ingestIterator{points: points, promRewriter: newPromRewriter()}
+	for _, line := range []string{
+		"__name__: measure_1_f_1, t2: a=b, tag_key: val,ue 1 2019-11-27 07:11:10.3864698 +0000 UTC",
+		"__name__: measure_1_f_2, t2: a=b, tag_key: val,ue 2 2019-11-27 07:11:10.3864698 +0000 UTC",
+		"",
+	} {
+		assert.Equal(t, line, iter.pop(t))
+	}
+	require.NoError(t, iter.Error())
+}
+
+func TestIngestIteratorFieldPolicies(t *testing.T) {
+	s := `measure,host=a value=1,state="o\"k",up=T 1574838670386469800
+`
+	points, err := imodels.ParsePoints([]byte(s))
+	require.NoError(t, err)
+	iter := &ingestIterator{
+		points:       points,
+		promRewriter: newPromRewriter(),
+		influxCfg: config.InfluxDBConfiguration{
+			StringFields:  config.TagInfluxDBStringFields,
+			BooleanFields: config.DropInfluxDBBooleanFields,
+		},
+	}
+	for _, line := range []string{
+		`__name__: measure_value, host: a, state: o"k 1 2019-11-27 07:11:10.3864698 +0000 UTC`,
+		"",
+	} {
+		assert.Equal(t, line, iter.pop(t))
+	}
+	require.NoError(t, iter.Error())
+}
+
+func TestParsePrecision(t *testing.T) {
+	for _, tt := range []struct {
+		precision string
+		expected  string
+	}{
+		{"", "n"},
+		{"ns", "n"},
+		{"us", "u"},
+		{"µ", "u"},
+		{"ms", "ms"},
+		{"s", "s"},
+		{"m", "m"},
+		{"h", "h"},
+	} {
+		actual, err := parsePrecision(tt.precision)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, actual)
+	}
+
+	_, err := parsePrecision("d")
+	assert.Equal(t, errInvalidPrecision, err)
+}
+
+func TestInfluxWriterHandlerPrecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	writer := ingest.NewMockDownsamplerAndWriter(ctrl)
+	opts := options.EmptyHandlerOptions().
+		SetTagOptions(models.NewTagOptions()).
+		SetDownsamplerAndWriter(writer)
+	handler := NewInfluxWriterHandler(opts)
+
+	writer.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			iter ingest.DownsampleAndWriteIter,
+			_ ingest.WriteOptions,
+		) ingest.BatchError {
+			require.True(t, iter.Next())
+			_, dps, unit, _ := iter.Current()
+			require.Len(t, dps, 1)
+			assert.Equal(t, time.Unix(1574838670, 0).UTC(), dps[0].Timestamp)
+			assert.Equal(t, xtime.Second, unit)
+			assert.False(t, iter.Next())
+			return nil
+		})
+
+	req := httptest.NewRequest(InfluxWriteHTTPMethod,
+		InfluxWriteURL+"?precision=s",
+		bytes.NewBufferString("measure value=1 1574838670\n"))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+
+	req = httptest.NewRequest(InfluxWriteHTTPMethod,
+		InfluxWriteURL+"?precision=d",
+		bytes.NewBufferString("measure value=1 1574838670\n"))
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -27,9 +27,11 @@ import (
 	"net/http"
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util"
 	"github.com/m3db/m3/src/query/util/logging"
@@ -51,6 +53,7 @@ const (
 // WriteJSONHandler represents a handler for the write json endpoint
 type WriteJSONHandler struct {
 	store          storage.Storage
+	tenants        tenant.Tenants
 	instrumentOpts instrument.Options
 }
 
@@ -58,6 +61,7 @@ type WriteJSONHandler struct {
 func NewWriteJSONHandler(opts options.HandlerOptions) http.Handler {
 	return &WriteJSONHandler{
 		store:          opts.Storage(),
+		tenants:        opts.Tenants(),
 		instrumentOpts: opts.InstrumentOpts(),
 	}
 }
@@ -79,6 +83,12 @@ func (h *WriteJSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	t, rErr := handleroptions.ParseTenant(r, h.tenants)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
 	writeQuery, err := newStorageWriteQuery(req)
 	if err != nil {
 		logger := logging.WithContext(r.Context(), h.instrumentOpts)
@@ -86,6 +96,16 @@ func (h *WriteJSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			zap.String("remoteAddr", r.RemoteAddr),
 			zap.Error(err))
 		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if t != nil {
+		writeQuery.Tags = t.Tags(writeQuery.Tags)
+		writeQuery.Tenant = t.Name()
+		if err := t.CheckWrite(writeQuery.Tags, len(writeQuery.Datapoints)); err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
 	}
 
 	if err := h.store.Write(r.Context(), writeQuery); err != nil {
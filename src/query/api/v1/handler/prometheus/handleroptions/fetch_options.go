@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 	xhttp "github.com/m3db/m3/src/x/net/http"
 )
 
@@ -54,7 +55,10 @@ type FetchOptionsBuilder interface {
 // FetchOptionsBuilderOptions provides options to use when creating a
 // fetch options builder.
 type FetchOptionsBuilderOptions struct {
-	Limit int
+	Limit             int
+	DocsLimit         int
+	RequireExhaustive bool
+	Tenants           tenant.Tenants
 }
 
 type fetchOptionsBuilder struct {
@@ -95,6 +99,66 @@ func ParseLimit(req *http.Request, defaultLimit int) (int, error) {
 	return defaultLimit, nil
 }
 
+// ParseDocsLimit parses the request docs limit from the docs limit header.
+func ParseDocsLimit(req *http.Request, defaultLimit int) (int, error) {
+	if str := req.Header.Get(LimitMaxDocsHeader); str != "" {
+		n, err := strconv.Atoi(str)
+		if err != nil {
+			err = fmt.Errorf(
+				"could not parse docs limit: input=%s, err=%v", str, err)
+			return 0, err
+		}
+
+		return n, nil
+	}
+
+	return defaultLimit, nil
+}
+
+// ParseRequireExhaustive parses whether the request requires exhaustive
+// results from the require exhaustive header.
+func ParseRequireExhaustive(req *http.Request, defaultValue bool) (bool, error) {
+	if str := req.Header.Get(LimitRequireExhaustiveHeader); str != "" {
+		v, err := strconv.ParseBool(str)
+		if err != nil {
+			err = fmt.Errorf(
+				"could not parse require exhaustive: input=%s, err=%v", str, err)
+			return false, err
+		}
+
+		return v, nil
+	}
+
+	return defaultValue, nil
+}
+
+// ParseTenant parses the tenant of a request from the tenant header, a nil
+// tenant is returned if tenants are not configured or the request does not
+// specify a tenant and tenants are not required.
+func ParseTenant(
+	req *http.Request,
+	tenants tenant.Tenants,
+) (tenant.Tenant, *xhttp.ParseError) {
+	if tenants == nil {
+		return nil, nil
+	}
+
+	name := strings.TrimSpace(req.Header.Get(TenantHeader))
+	t, err := tenants.Resolve(name)
+	switch {
+	case err == tenant.ErrTenantRequired:
+		err = fmt.Errorf("%v: must set %s header", err, TenantHeader)
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	case err == tenant.ErrUnknownTenant:
+		err = fmt.Errorf("%v: %s", err, name)
+		return nil, xhttp.NewParseError(err, http.StatusForbidden)
+	case err != nil:
+		return nil, xhttp.NewParseError(err, http.StatusInternalServerError)
+	}
+
+	return t, nil
+}
+
 // NewFetchOptions parses an http request into fetch options.
 func (b fetchOptionsBuilder) NewFetchOptions(
 	req *http.Request,
@@ -106,6 +170,18 @@ func (b fetchOptionsBuilder) NewFetchOptions(
 	}
 
 	fetchOpts.Limit = limit
+	docsLimit, err := ParseDocsLimit(req, b.opts.DocsLimit)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	fetchOpts.DocsLimit = docsLimit
+	requireExhaustive, err := ParseRequireExhaustive(req, b.opts.RequireExhaustive)
+	if err != nil {
+		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+	}
+
+	fetchOpts.RequireExhaustive = requireExhaustive
 	if str := req.Header.Get(MetricsTypeHeader); str != "" {
 		mt, err := storage.ParseMetricsType(str)
 		if err != nil {
@@ -149,6 +225,14 @@ func (b fetchOptionsBuilder) NewFetchOptions(
 		fetchOpts.RestrictQueryOptions.RestrictByTag = tagOpts
 	}
 
+	t, parseErr := ParseTenant(req, b.opts.Tenants)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	if t != nil {
+		fetchOpts = t.FetchOptions(fetchOpts)
+	}
+
 	if restrict := fetchOpts.RestrictQueryOptions; restrict != nil {
 		if err := restrict.Validate(); err != nil {
 			err = fmt.Errorf(
@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,14 +44,18 @@ func TestFetchOptionsBuilder(t *testing.T) {
 	}
 
 	tests := []struct {
-		name             string
-		defaultLimit     int
-		headers          map[string]string
-		query            string
-		expectedLimit    int
-		expectedRestrict *storage.RestrictQueryOptions
-		expectedLookback *expectedLookback
-		expectedErr      bool
+		name                      string
+		defaultLimit              int
+		defaultDocsLimit          int
+		defaultRequireExhaustive  bool
+		headers                   map[string]string
+		query                     string
+		expectedLimit             int
+		expectedDocsLimit         int
+		expectedRequireExhaustive bool
+		expectedRestrict          *storage.RestrictQueryOptions
+		expectedLookback          *expectedLookback
+		expectedErr               bool
 	}{
 		{
 			name:          "default limit with no headers",
@@ -74,6 +79,38 @@ func TestFetchOptionsBuilder(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name:                      "default docs limit and require exhaustive",
+			defaultDocsLimit:          42,
+			defaultRequireExhaustive:  true,
+			headers:                   map[string]string{},
+			expectedDocsLimit:         42,
+			expectedRequireExhaustive: true,
+		},
+		{
+			name:             "docs limit and require exhaustive with header",
+			defaultDocsLimit: 42,
+			headers: map[string]string{
+				LimitMaxDocsHeader:           "4242",
+				LimitRequireExhaustiveHeader: "true",
+			},
+			expectedDocsLimit:         4242,
+			expectedRequireExhaustive: true,
+		},
+		{
+			name: "bad docs limit header",
+			headers: map[string]string{
+				LimitMaxDocsHeader: "not_a_number",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "bad require exhaustive header",
+			headers: map[string]string{
+				LimitRequireExhaustiveHeader: "not_a_bool",
+			},
+			expectedErr: true,
+		},
 		{
 			name: "unaggregated metrics type",
 			headers: map[string]string{
@@ -154,7 +191,9 @@ func TestFetchOptionsBuilder(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			builder := NewFetchOptionsBuilder(FetchOptionsBuilderOptions{
-				Limit: test.defaultLimit,
+				Limit:             test.defaultLimit,
+				DocsLimit:         test.defaultDocsLimit,
+				RequireExhaustive: test.defaultRequireExhaustive,
 			})
 
 			url := "/foo"
@@ -171,6 +210,8 @@ func TestFetchOptionsBuilder(t *testing.T) {
 			if !test.expectedErr {
 				require.NoError(t, err)
 				require.Equal(t, test.expectedLimit, opts.Limit)
+				require.Equal(t, test.expectedDocsLimit, opts.DocsLimit)
+				require.Equal(t, test.expectedRequireExhaustive, opts.RequireExhaustive)
 				if test.expectedRestrict == nil {
 					require.Nil(t, opts.RestrictQueryOptions)
 				} else {
@@ -325,3 +366,40 @@ func TestFetchOptionsWithHeader(t *testing.T) {
 
 	require.Equal(t, ex, opts.RestrictQueryOptions)
 }
+
+func TestFetchOptionsWithTenant(t *testing.T) {
+	tenants, err := tenant.Configuration{
+		Required:      true,
+		RejectUnknown: true,
+		Tenants:       map[string]tenant.LimitsConfiguration{"foo": {}},
+	}.NewTenants(tenant.NewOptions())
+	require.NoError(t, err)
+
+	builder := NewFetchOptionsBuilder(FetchOptionsBuilderOptions{
+		Limit:   5,
+		Tenants: tenants,
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	_, parseErr := builder.NewFetchOptions(req)
+	require.NotNil(t, parseErr)
+	assert.Equal(t, http.StatusBadRequest, parseErr.Code())
+
+	req.Header.Set(TenantHeader, "bar")
+	_, parseErr = builder.NewFetchOptions(req)
+	require.NotNil(t, parseErr)
+	assert.Equal(t, http.StatusForbidden, parseErr.Code())
+
+	req.Header.Set(TenantHeader, "foo")
+	req.Header.Set(RestrictByTagsJSONHeader,
+		`{"match":[{"name":"tenant", "value":"bar", "type":"EQUAL"}]}`)
+	opts, parseErr := builder.NewFetchOptions(req)
+	require.Nil(t, parseErr)
+	assert.Equal(t, "foo", opts.Tenant)
+
+	restrict := opts.RestrictQueryOptions.GetRestrictByTag()
+	require.NotNil(t, restrict)
+	assert.Equal(t, models.Matchers{
+		mustMatcher("tenant", "foo", models.MatchEqual),
+	}, restrict.Restrict)
+}
@@ -56,10 +56,23 @@ const (
 	// in JSON format. See `handler.stringTagOptions` for definitions.`
 	RestrictByTagsJSONHeader = "M3-Restrict-By-Tags-JSON"
 
+	// TenantHeader specifies the tenant of a write or read request, writes
+	// and reads are isolated to the series of the tenant.
+	TenantHeader = "M3-Tenant"
+
 	// LimitMaxSeriesHeader is the M3 limit timeseries header that limits
 	// the number of time series returned by each storage node.
 	LimitMaxSeriesHeader = "M3-Limit-Max-Series"
 
+	// LimitMaxDocsHeader is the M3 limit docs header that limits
+	// the number of index documents matched by each storage node.
+	LimitMaxDocsHeader = "M3-Limit-Max-Docs"
+
+	// LimitRequireExhaustiveHeader is the M3 limit exhaustive header that will
+	// return an error if the query exceeds the series or docs limit rather
+	// than returning partial results.
+	LimitRequireExhaustiveHeader = "M3-Limit-Require-Exhaustive"
+
 	// UnaggregatedStoragePolicy specifies the unaggregated storage policy.
 	UnaggregatedStoragePolicy = "unaggregated"
 
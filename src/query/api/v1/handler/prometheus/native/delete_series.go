@@ -0,0 +1,181 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromDeleteSeriesURL is the url for the prometheus delete series handler.
+	PromDeleteSeriesURL = handler.RoutePrefixV1 + "/admin/tsdb/delete_series"
+)
+
+var (
+	// PromDeleteSeriesHTTPMethods are the HTTP methods for this handler.
+	PromDeleteSeriesHTTPMethods = []string{http.MethodPost, http.MethodPut}
+
+	errNoClusters = errors.New("delete series requires m3db clusters to be configured")
+)
+
+// DeleteSeriesNamespaceResult is the result of deleting matching series
+// from a single cluster namespace.
+type DeleteSeriesNamespaceResult struct {
+	Namespace string   `json:"namespace"`
+	Deleted   int64    `json:"deleted"`
+	Errors    []string `json:"errors,omitempty"`
+}
+
+// DeleteSeriesResponse is returned when deleting from one or more
+// namespaces fails, and lists the outcome for every namespace.
+type DeleteSeriesResponse struct {
+	Results []DeleteSeriesNamespaceResult `json:"results"`
+}
+
+// PromDeleteSeriesHandler represents a handler for the prometheus delete
+// series endpoint, which deletes the data of matching series from every
+// cluster namespace.
+type PromDeleteSeriesHandler struct {
+	clusters       m3.Clusters
+	tagOptions     models.TagOptions
+	tenants        tenant.Tenants
+	instrumentOpts instrument.Options
+}
+
+// NewPromDeleteSeriesHandler returns a new instance of handler.
+func NewPromDeleteSeriesHandler(opts options.HandlerOptions) http.Handler {
+	return &PromDeleteSeriesHandler{
+		clusters:       opts.Clusters(),
+		tagOptions:     opts.TagOptions(),
+		tenants:        opts.Tenants(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromDeleteSeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.WithValue(r.Context(), handler.HeaderKey, r.Header)
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+
+	if h.clusters == nil {
+		xhttp.Error(w, errNoClusters, http.StatusBadRequest)
+		return
+	}
+
+	queries, rErr := prometheus.ParseSeriesMatchQuery(r, h.tagOptions)
+	if rErr != nil {
+		logger.Error("unable to parse series match values to query", zap.Error(rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	t, rErr := handleroptions.ParseTenant(r, h.tenants)
+	if rErr != nil {
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	// NB: Restrict the deletes of a tenant to the series of the tenant as
+	// the read path does.
+	var fetchOpts *storage.FetchOptions
+	if t != nil {
+		fetchOpts = t.FetchOptions(storage.NewFetchOptions())
+	}
+
+	m3queries := make([]index.Query, 0, len(queries))
+	for _, query := range queries {
+		m3query, err := storage.FetchQueryToM3Query(query, fetchOpts)
+		if err != nil {
+			xhttp.Error(w, err, http.StatusBadRequest)
+			return
+		}
+		m3queries = append(m3queries, m3query)
+	}
+
+	// NB: Keep deleting from the remaining namespaces when one fails so
+	// that a single unavailable namespace does not leave the others
+	// untouched, then report the outcome for every namespace.
+	var (
+		namespaces = h.clusters.ClusterNamespaces()
+		results    = make([]DeleteSeriesNamespaceResult, 0, len(namespaces))
+		deleted    int64
+		failed     bool
+	)
+	for _, ns := range namespaces {
+		results = append(results, DeleteSeriesNamespaceResult{
+			Namespace: ns.NamespaceID().String(),
+		})
+	}
+
+	for i, query := range queries {
+		// NB: Times before the unix epoch cannot be represented by
+		// the delete request, so clamp the default start time.
+		start := query.Start
+		if start.Before(time.Unix(0, 0)) {
+			start = time.Unix(0, 0)
+		}
+
+		for j, ns := range namespaces {
+			n, err := ns.Session().DeleteTagged(ns.NamespaceID(), m3queries[i],
+				start, query.End)
+			if err != nil {
+				logger.Error("unable to delete series",
+					zap.String("namespace", ns.NamespaceID().String()),
+					zap.String("query", query.Raw),
+					zap.Error(err))
+				results[j].Errors = append(results[j].Errors, err.Error())
+				failed = true
+				continue
+			}
+
+			results[j].Deleted += n
+			deleted += n
+		}
+	}
+
+	logger.Info("deleted series", zap.Int64("numSeries", deleted))
+	if !failed {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusInternalServerError)
+	json.NewEncoder(w).Encode(DeleteSeriesResponse{Results: results})
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromDeleteSeriesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetTagOptions(models.NewTagOptions()).
+		SetInstrumentOpts(instrument.NewOptions())
+	handler := NewPromDeleteSeriesHandler(opts)
+
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	session.EXPECT().
+		DeleteTagged(ident.NewIDMatcher("metrics"), gomock.Any(), start, end).
+		Return(int64(3), nil)
+
+	form := url.Values{}
+	form.Set("match[]", `foo{bar="baz"}`)
+	form.Set("start", "1000")
+	form.Set("end", "2000")
+	req := httptest.NewRequest(http.MethodPost, PromDeleteSeriesURL,
+		strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNoContent, recorder.Code, recorder.Body.String())
+}
+
+func TestPromDeleteSeriesHandlerPartialFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	}, m3.AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_agg"),
+		Session:     session,
+		Retention:   48 * time.Hour,
+		Resolution:  time.Minute,
+	})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetTagOptions(models.NewTagOptions()).
+		SetInstrumentOpts(instrument.NewOptions())
+	handler := NewPromDeleteSeriesHandler(opts)
+
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	session.EXPECT().
+		DeleteTagged(ident.NewIDMatcher("metrics"), gomock.Any(), start, end).
+		Return(int64(0), errors.New("unavailable"))
+	session.EXPECT().
+		DeleteTagged(ident.NewIDMatcher("metrics_agg"), gomock.Any(), start, end).
+		Return(int64(2), nil)
+
+	form := url.Values{}
+	form.Set("match[]", `foo{bar="baz"}`)
+	form.Set("start", "1000")
+	form.Set("end", "2000")
+	req := httptest.NewRequest(http.MethodPost, PromDeleteSeriesURL,
+		strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	var resp DeleteSeriesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.ElementsMatch(t, []DeleteSeriesNamespaceResult{
+		{Namespace: "metrics", Errors: []string{"unavailable"}},
+		{Namespace: "metrics_agg", Deleted: 2},
+	}, resp.Results)
+}
+
+func TestPromDeleteSeriesHandlerTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	tenants, err := tenant.Configuration{}.NewTenants(tenant.NewOptions())
+	require.NoError(t, err)
+	opts := options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetTagOptions(models.NewTagOptions()).
+		SetTenants(tenants).
+		SetInstrumentOpts(instrument.NewOptions())
+	handler := NewPromDeleteSeriesHandler(opts)
+
+	session.EXPECT().
+		DeleteTagged(ident.NewIDMatcher("metrics"), gomock.Any(),
+			gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ ident.ID,
+			q index.Query,
+			_, _ time.Time,
+		) (int64, error) {
+			// The delete is restricted to the series of the tenant.
+			expected, err := storage.FetchQueryToM3Query(&storage.FetchQuery{
+				TagMatchers: models.Matchers{
+					{Type: models.MatchEqual, Name: []byte("bar"), Value: []byte("baz")},
+					{Type: models.MatchEqual, Name: []byte("__name__"), Value: []byte("foo")},
+					{Type: models.MatchEqual, Name: []byte("tenant"), Value: []byte("foo")},
+				},
+			}, nil)
+			require.NoError(t, err)
+			assert.Equal(t, expected.String(), q.String())
+			return 1, nil
+		})
+
+	form := url.Values{}
+	form.Set("match[]", `foo{bar="baz"}`)
+	req := httptest.NewRequest(http.MethodPost, PromDeleteSeriesURL,
+		strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(handleroptions.TenantHeader, "foo")
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusNoContent, recorder.Code, recorder.Body.String())
+}
+
+func TestPromDeleteSeriesHandlerNoMatchers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockSession(ctrl)
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics"),
+		Session:     session,
+		Retention:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	opts := options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetTagOptions(models.NewTagOptions()).
+		SetInstrumentOpts(instrument.NewOptions())
+	handler := NewPromDeleteSeriesHandler(opts)
+
+	req := httptest.NewRequest(http.MethodPost, PromDeleteSeriesURL, nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cache"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
@@ -69,11 +70,14 @@ var (
 // PromReadHandler represents a handler for prometheus read endpoint.
 type PromReadHandler struct {
 	keepEmpty           bool
+	parseFn             parseFn
+	formatType          models.FormatType
 	limitsCfg           *config.LimitsConfiguration
 	timeoutOps          *prometheus.TimeoutOpts
 	engine              executor.Engine
 	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
 	tagOpts             models.TagOptions
+	resultsCache        cache.ResultsCache
 	promReadMetrics     promReadMetrics
 	instrumentOpts      instrument.Options
 }
@@ -116,14 +120,27 @@ type RespError struct {
 
 // NewPromReadHandler returns a new instance of handler.
 func NewPromReadHandler(opts options.HandlerOptions) *PromReadHandler {
+	return newReadHandler(opts, "native-read", parsePromQL,
+		models.FormatPromQL)
+}
+
+func newReadHandler(
+	opts options.HandlerOptions,
+	handlerName string,
+	parseFn parseFn,
+	formatType models.FormatType,
+) *PromReadHandler {
 	taggedScope := opts.InstrumentOpts().MetricsScope().
-		Tagged(map[string]string{"handler": "native-read"})
+		Tagged(map[string]string{"handler": handlerName})
 	limits := opts.Config().Limits
 
 	h := &PromReadHandler{
+		parseFn:             parseFn,
+		formatType:          formatType,
 		engine:              opts.Engine(),
 		fetchOptionsBuilder: opts.FetchOptionsBuilder(),
 		tagOpts:             opts.TagOptions(),
+		resultsCache:        opts.QueryResultsCache(),
 		limitsCfg:           &limits,
 		promReadMetrics:     newPromReadMetrics(taggedScope),
 		timeoutOps:          opts.TimeoutOpts(),
@@ -165,7 +182,8 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if params.FormatType == models.FormatM3QL {
+	if params.FormatType == models.FormatM3QL ||
+		h.formatType == models.FormatM3QL {
 		renderM3QLResultsJSON(w, result, params)
 		h.promReadMetrics.fetchSuccess.Inc(1)
 		timer.Stop()
@@ -205,8 +223,7 @@ func (h *PromReadHandler) ServeHTTPWithEngine(
 		return nil, emptyReqParams, &RespError{Err: err, Code: http.StatusBadRequest}
 	}
 
-	result, err := read(ctx, engine, opts, fetchOpts, h.tagOpts,
-		w, params, h.instrumentOpts)
+	result, err := h.readWithCache(ctx, engine, opts, fetchOpts, w, params)
 	if err != nil {
 		sp := xopentracing.SpanFromContextOrNoop(ctx)
 		sp.LogFields(opentracinglog.Error(err))
@@ -225,6 +242,62 @@ func (h *PromReadHandler) ServeHTTPWithEngine(
 	return result.series, params, nil
 }
 
+// readWithCache executes the query, serving as much of the requested range
+// as possible from the results cache if one is set.
+func (h *PromReadHandler) readWithCache(
+	ctx context.Context,
+	engine executor.Engine,
+	opts *executor.QueryOptions,
+	fetchOpts *storage.FetchOptions,
+	w http.ResponseWriter,
+	params models.RequestParams,
+) (readResult, error) {
+	if h.resultsCache == nil {
+		return read(ctx, engine, h.parseFn, opts, fetchOpts, h.tagOpts,
+			w, params, h.instrumentOpts)
+	}
+
+	// NB: Key on the canonical form of the parsed query so that equivalent
+	// queries share results, while whitespace within label values is kept.
+	parser, err := h.parseFn(params.Query, params.Step, h.tagOpts, engine.Options())
+	if err != nil {
+		return readResult{}, err
+	}
+
+	series, meta, err := h.resultsCache.FetchRange(h.cacheKey(opts, fetchOpts, params, parser.String()),
+		params, func(p models.RequestParams) ([]*ts.Series, block.ResultMetadata, error) {
+			result, err := read(ctx, engine, h.parseFn, opts, fetchOpts, h.tagOpts,
+				w, p, h.instrumentOpts)
+			return result.series, result.meta, err
+		})
+	if err != nil {
+		return readResult{meta: meta}, err
+	}
+
+	return readResult{series: series, meta: meta}, nil
+}
+
+// cacheKey returns the key identifying cached results for the request, which
+// includes the canonical query along with every parameter other than the time
+// range that affects results.
+func (h *PromReadHandler) cacheKey(
+	opts *executor.QueryOptions,
+	fetchOpts *storage.FetchOptions,
+	params models.RequestParams,
+	query string,
+) string {
+	var restrict string
+	if r := opts.QueryContextOptions.RestrictFetchType; r != nil {
+		restrict = fmt.Sprintf("%d:%s", r.MetricsType, r.StoragePolicy.String())
+	}
+
+	// NB: The tenant is part of the key so that tenants never share results.
+	return fmt.Sprintf("%d|%s|%v|%v|%d|%s|%s", h.formatType,
+		query, params.Step,
+		params.LookbackDuration, opts.QueryContextOptions.LimitMaxTimeseries,
+		restrict, fetchOpts.Tenant)
+}
+
 func (h *PromReadHandler) validateRequest(params *models.RequestParams) error {
 	// Impose a rough limit on the number of returned time series. This is intended to prevent things like
 	// querying from the beginning of time with a 1s step size.
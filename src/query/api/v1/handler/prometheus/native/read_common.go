@@ -26,12 +26,15 @@ import (
 	"math"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/parser/m3ql"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
@@ -46,9 +49,36 @@ type readResult struct {
 	meta   block.ResultMetadata
 }
 
+// parseFn parses a query string into a parser that can build the query DAG.
+type parseFn func(
+	query string,
+	step time.Duration,
+	tagOpts models.TagOptions,
+	engineOpts executor.EngineOptions,
+) (parser.Parser, error)
+
+func parsePromQL(
+	query string,
+	step time.Duration,
+	tagOpts models.TagOptions,
+	engineOpts executor.EngineOptions,
+) (parser.Parser, error) {
+	return promql.Parse(query, step, tagOpts, engineOpts.ParseOptions())
+}
+
+func parseM3QL(
+	query string,
+	step time.Duration,
+	tagOpts models.TagOptions,
+	_ executor.EngineOptions,
+) (parser.Parser, error) {
+	return m3ql.Parse(query, step, tagOpts)
+}
+
 func read(
 	reqCtx context.Context,
 	engine executor.Engine,
+	parseFn parseFn,
 	opts *executor.QueryOptions,
 	fetchOpts *storage.FetchOptions,
 	tagOpts models.TagOptions,
@@ -73,8 +103,7 @@ func read(
 	emptyResult := readResult{meta: block.NewResultMetadata()}
 
 	// TODO: Capture timing
-	parseOpts := engine.Options().ParseOptions()
-	parser, err := promql.Parse(params.Query, params.Step, tagOpts, parseOpts)
+	parser, err := parseFn(params.Query, params.Step, tagOpts, engine.Options())
 	if err != nil {
 		return emptyResult, err
 	}
@@ -108,7 +108,7 @@ func (h *PromReadInstantHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		queryOpts.QueryContextOptions.RestrictFetchType = restrict
 	}
 
-	result, err := read(ctx, h.engine, queryOpts, fetchOpts,
+	result, err := read(ctx, h.engine, parsePromQL, queryOpts, fetchOpts,
 		h.tagOpts, w, params, h.instrumentOpts)
 	if err != nil {
 		logger.Error("unable to fetch data", zap.Error(err))
@@ -0,0 +1,50 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+)
+
+const (
+	// M3QLReadURL is the url for the native M3QL read handler, this accepts
+	// the same parameters as the query range endpoint but parses the query
+	// as M3QL rather than PromQL.
+	M3QLReadURL = handler.RoutePrefixV1 + "/m3ql/query_range"
+)
+
+var (
+	// M3QLReadHTTPMethods are the HTTP methods for this handler.
+	M3QLReadHTTPMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+	}
+)
+
+// NewM3QLReadHandler returns a new instance of a handler that executes
+// M3QL range queries and renders results in the M3QL format.
+func NewM3QLReadHandler(opts options.HandlerOptions) *PromReadHandler {
+	return newReadHandler(opts, "m3ql-read", parseM3QL, models.FormatM3QL)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestM3QLReadHandlerRead(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+
+	setup := newTestSetup()
+	m3qlRead := setup.Handlers.M3QLRead
+
+	seriesMeta := test.NewSeriesMeta("dummy", len(values))
+	meta := block.Metadata{
+		Bounds:         bounds,
+		Tags:           models.NewTags(0, models.NewTagOptions()),
+		ResultMetadata: block.NewResultMetadata(),
+	}
+
+	b := test.NewBlockFromValuesWithMetaAndSeriesMeta(meta, seriesMeta, values)
+	setup.Storage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	params := defaultParams()
+	params.Set(queryParam, "fetch name:http_requests_total job:prometheus")
+	req, err := http.NewRequest("GET", M3QLReadURL, nil)
+	require.NoError(t, err)
+	req.URL.RawQuery = params.Encode()
+
+	recorder := httptest.NewRecorder()
+	m3qlRead.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var m3qlResp M3QLResp
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &m3qlResp))
+
+	require.Len(t, m3qlResp, 2)
+	assert.Equal(t, "dummy0", m3qlResp[0].Target)
+	assert.Equal(t, 10000, m3qlResp[0].StepSizeMs)
+	assert.Equal(t, "dummy1", m3qlResp[1].Target)
+}
+
+func TestM3QLReadHandlerInvalidQuery(t *testing.T) {
+	setup := newTestSetup()
+	m3qlRead := setup.Handlers.M3QLRead
+
+	params := defaultParams()
+	params.Set(queryParam, "fetch name:foo | unknownFunction")
+	req, err := http.NewRequest("GET", M3QLReadURL, nil)
+	require.NoError(t, err)
+	req.URL.RawQuery = params.Encode()
+
+	recorder := httptest.NewRecorder()
+	m3qlRead.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
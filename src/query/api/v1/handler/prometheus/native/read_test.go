@@ -35,11 +35,13 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/cache"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/x/instrument"
 
 	"github.com/stretchr/testify/assert"
@@ -79,7 +81,7 @@ func testPromReadHandlerRead(
 	r, parseErr := testParseParams(req)
 	require.Nil(t, parseErr)
 	assert.Equal(t, models.FormatPromQL, r.FormatType)
-	result, err := read(context.TODO(), promRead.engine,
+	result, err := read(context.TODO(), promRead.engine, parsePromQL,
 		setup.QueryOpts, setup.FetchOpts, promRead.tagOpts, httptest.NewRecorder(),
 		r, instrument.NewOptions())
 
@@ -153,6 +155,71 @@ func testM3PromReadHandlerRead(
 
 }
 
+type recordingResultsCache struct {
+	keys []string
+}
+
+func (c *recordingResultsCache) FetchRange(
+	key string,
+	params models.RequestParams,
+	fetchFn cache.FetchFn,
+) ([]*ts.Series, block.ResultMetadata, error) {
+	c.keys = append(c.keys, key)
+	return fetchFn(params)
+}
+
+func TestPromReadHandlerReadWithResultsCache(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+
+	setup := newTestSetup()
+	promRead := setup.Handlers.Read
+	resultsCache := &recordingResultsCache{}
+	promRead.resultsCache = resultsCache
+
+	seriesMeta := test.NewSeriesMeta("dummy", len(values))
+	meta := block.Metadata{
+		Bounds:         bounds,
+		Tags:           models.NewTags(0, models.NewTagOptions()),
+		ResultMetadata: block.NewResultMetadata(),
+	}
+
+	b := test.NewBlockFromValuesWithMetaAndSeriesMeta(meta, seriesMeta, values)
+	setup.Storage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	for _, q := range []string{"sum(up)", " sum(up)  "} {
+		params := defaultParams()
+		params.Set(queryParam, q)
+		recorder := httptest.NewRecorder()
+		promRead.ServeHTTP(recorder, newReadRequest(t, params))
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	// Queries that differ only in whitespace share the same cache key.
+	require.Len(t, resultsCache.keys, 2)
+	assert.Equal(t, resultsCache.keys[0], resultsCache.keys[1])
+
+	// Whitespace within label values is significant.
+	for _, q := range []string{`sum(up{job="a  b"})`, `sum(up{job="a b"})`} {
+		params := defaultParams()
+		params.Set(queryParam, q)
+		recorder := httptest.NewRecorder()
+		promRead.ServeHTTP(recorder, newReadRequest(t, params))
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	require.Len(t, resultsCache.keys, 4)
+	assert.NotEqual(t, resultsCache.keys[2], resultsCache.keys[3])
+	resultsCache.keys = resultsCache.keys[:2]
+
+	params := defaultParams()
+	params.Set(queryParam, "sum(up)")
+	params.Set(handleroptions.StepParam, "20s")
+	recorder := httptest.NewRecorder()
+	promRead.ServeHTTP(recorder, newReadRequest(t, params))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	require.Len(t, resultsCache.keys, 3)
+	assert.NotEqual(t, resultsCache.keys[0], resultsCache.keys[2])
+}
+
 func newReadRequest(t *testing.T, params url.Values) *http.Request {
 	req, err := http.NewRequest("GET", PromReadURL, nil)
 	require.NoError(t, err)
@@ -171,6 +238,7 @@ type testSetup struct {
 type testSetupHandlers struct {
 	Read        *PromReadHandler
 	InstantRead *PromReadInstantHandler
+	M3QLRead    *PromReadHandler
 }
 
 func newTestSetup() *testSetup {
@@ -204,12 +272,14 @@ func newTestSetup() *testSetup {
 
 	read := NewPromReadHandler(opts)
 	instantRead := NewPromReadInstantHandler(opts)
+	m3qlRead := NewM3QLReadHandler(opts)
 
 	return &testSetup{
 		Storage: mockStorage,
 		Handlers: testSetupHandlers{
 			Read:        read,
 			InstantRead: instantRead,
+			M3QLRead:    m3qlRead,
 		},
 		QueryOpts:   &executor.QueryOptions{},
 		FetchOpts:   storage.NewFetchOptions(),
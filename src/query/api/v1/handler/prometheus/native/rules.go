@@ -0,0 +1,223 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/ruler"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// PromRulesURL is the url for listing recording and alerting rules, this
+	// matches the rules endpoint found on a Prometheus server.
+	PromRulesURL = handler.RoutePrefixV1 + "/rules"
+
+	// PromAlertsURL is the url for listing active alerts, this matches the
+	// alerts endpoint found on a Prometheus server.
+	PromAlertsURL = handler.RoutePrefixV1 + "/alerts"
+
+	// PromRulesHTTPMethod is the HTTP method for the rules handler.
+	PromRulesHTTPMethod = http.MethodGet
+
+	// PromAlertsHTTPMethod is the HTTP method for the alerts handler.
+	PromAlertsHTTPMethod = http.MethodGet
+)
+
+type rulesResponse struct {
+	Status string    `json:"status"`
+	Data   rulesData `json:"data"`
+}
+
+type rulesData struct {
+	Groups []ruleGroupJSON `json:"groups"`
+}
+
+type ruleGroupJSON struct {
+	Name     string        `json:"name"`
+	File     string        `json:"file"`
+	Rules    []interface{} `json:"rules"`
+	Interval float64       `json:"interval"`
+}
+
+type alertingRuleJSON struct {
+	State       string            `json:"state"`
+	Name        string            `json:"name"`
+	Query       string            `json:"query"`
+	Duration    float64           `json:"duration"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Alerts      []alertJSON       `json:"alerts"`
+	Health      string            `json:"health"`
+	LastError   string            `json:"lastError,omitempty"`
+	Type        string            `json:"type"`
+}
+
+type recordingRuleJSON struct {
+	Name      string            `json:"name"`
+	Query     string            `json:"query"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Health    string            `json:"health"`
+	LastError string            `json:"lastError,omitempty"`
+	Type      string            `json:"type"`
+}
+
+type alertsResponse struct {
+	Status string     `json:"status"`
+	Data   alertsData `json:"data"`
+}
+
+type alertsData struct {
+	Alerts []alertJSON `json:"alerts"`
+}
+
+type alertJSON struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	State       string            `json:"state"`
+	ActiveAt    *time.Time        `json:"activeAt,omitempty"`
+	Value       string            `json:"value"`
+}
+
+// PromRulesHandler represents a handler for the rules endpoint.
+type PromRulesHandler struct {
+	ruleManager    ruler.Manager
+	instrumentOpts instrument.Options
+}
+
+// NewPromRulesHandler returns a new instance of handler.
+func NewPromRulesHandler(opts options.HandlerOptions) http.Handler {
+	return &PromRulesHandler{
+		ruleManager:    opts.RuleManager(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context(), h.instrumentOpts)
+	w.Header().Set("Content-Type", "application/json")
+
+	groups := []ruleGroupJSON{}
+	if h.ruleManager != nil {
+		for _, g := range h.ruleManager.RuleGroups() {
+			groups = append(groups, toRuleGroupJSON(g))
+		}
+	}
+
+	xhttp.WriteJSONResponse(w, rulesResponse{
+		Status: "success",
+		Data:   rulesData{Groups: groups},
+	}, logger)
+}
+
+// PromAlertsHandler represents a handler for the alerts endpoint.
+type PromAlertsHandler struct {
+	ruleManager    ruler.Manager
+	instrumentOpts instrument.Options
+}
+
+// NewPromAlertsHandler returns a new instance of handler.
+func NewPromAlertsHandler(opts options.HandlerOptions) http.Handler {
+	return &PromAlertsHandler{
+		ruleManager:    opts.RuleManager(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *PromAlertsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context(), h.instrumentOpts)
+	w.Header().Set("Content-Type", "application/json")
+
+	var alerts []ruler.Alert
+	if h.ruleManager != nil {
+		alerts = h.ruleManager.Alerts()
+	}
+
+	xhttp.WriteJSONResponse(w, alertsResponse{
+		Status: "success",
+		Data:   alertsData{Alerts: toAlertsJSON(alerts)},
+	}, logger)
+}
+
+func toRuleGroupJSON(g ruler.GroupStatus) ruleGroupJSON {
+	rules := make([]interface{}, 0, len(g.Rules))
+	for _, r := range g.Rules {
+		var lastError string
+		if r.LastError != nil {
+			lastError = r.LastError.Error()
+		}
+
+		if r.Type == ruler.RecordingRuleType {
+			rules = append(rules, recordingRuleJSON{
+				Name:      r.Name,
+				Query:     r.Query,
+				Labels:    r.Labels,
+				Health:    string(r.Health),
+				LastError: lastError,
+				Type:      r.Type.String(),
+			})
+			continue
+		}
+
+		rules = append(rules, alertingRuleJSON{
+			State:       r.State().String(),
+			Name:        r.Name,
+			Query:       r.Query,
+			Duration:    r.For.Seconds(),
+			Labels:      r.Labels,
+			Annotations: r.Annotations,
+			Alerts:      toAlertsJSON(r.Alerts),
+			Health:      string(r.Health),
+			LastError:   lastError,
+			Type:        r.Type.String(),
+		})
+	}
+
+	return ruleGroupJSON{
+		Name:     g.Name,
+		File:     g.File,
+		Rules:    rules,
+		Interval: g.Interval.Seconds(),
+	}
+}
+
+func toAlertsJSON(alerts []ruler.Alert) []alertJSON {
+	result := make([]alertJSON, 0, len(alerts))
+	for _, a := range alerts {
+		activeAt := a.ActiveAt
+		result = append(result, alertJSON{
+			Labels:      a.Labels,
+			Annotations: a.Annotations,
+			State:       a.State.String(),
+			ActiveAt:    &activeAt,
+			Value:       strconv.FormatFloat(a.Value, 'e', -1, 64),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/ruler"
+	xtest "github.com/m3db/m3/src/x/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRuleManager struct {
+	groups []ruler.GroupStatus
+}
+
+func (m *testRuleManager) Start() error { return nil }
+func (m *testRuleManager) Close() error { return nil }
+
+func (m *testRuleManager) RuleGroups() []ruler.GroupStatus { return m.groups }
+
+func (m *testRuleManager) Alerts() []ruler.Alert {
+	var alerts []ruler.Alert
+	for _, g := range m.groups {
+		for _, r := range g.Rules {
+			alerts = append(alerts, r.Alerts...)
+		}
+	}
+
+	return alerts
+}
+
+func newTestRuleManager() *testRuleManager {
+	return &testRuleManager{
+		groups: []ruler.GroupStatus{{
+			Name:     "example",
+			File:     "rules.yml",
+			Interval: time.Minute,
+			Rules: []ruler.RuleStatus{
+				{
+					Type:   ruler.RecordingRuleType,
+					Name:   "job:up:sum",
+					Query:  "sum(up) by (job)",
+					Health: ruler.RuleHealthOK,
+				},
+				{
+					Type:        ruler.AlertingRuleType,
+					Name:        "InstanceDown",
+					Query:       "up == 0",
+					For:         5 * time.Minute,
+					Labels:      map[string]string{"severity": "page"},
+					Annotations: map[string]string{"summary": "down"},
+					Alerts: []ruler.Alert{{
+						Labels:      map[string]string{"alertname": "InstanceDown"},
+						Annotations: map[string]string{"summary": "down"},
+						State:       ruler.AlertStateFiring,
+						ActiveAt:    time.Unix(100, 0).UTC(),
+						Value:       1,
+					}},
+					Health:    ruler.RuleHealthErr,
+					LastError: errors.New("timeout"),
+				},
+			},
+		}},
+	}
+}
+
+func TestPromRulesHandler(t *testing.T) {
+	opts := options.EmptyHandlerOptions().SetRuleManager(newTestRuleManager())
+	h := NewPromRulesHandler(opts)
+
+	req := httptest.NewRequest(PromRulesHTTPMethod, PromRulesURL, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	expected := xtest.MustPrettyJSON(t, `{
+		"status": "success",
+		"data": {
+			"groups": [{
+				"name": "example",
+				"file": "rules.yml",
+				"interval": 60,
+				"rules": [
+					{
+						"name": "job:up:sum",
+						"query": "sum(up) by (job)",
+						"health": "ok",
+						"type": "recording"
+					},
+					{
+						"state": "firing",
+						"name": "InstanceDown",
+						"query": "up == 0",
+						"duration": 300,
+						"labels": {"severity": "page"},
+						"annotations": {"summary": "down"},
+						"alerts": [{
+							"labels": {"alertname": "InstanceDown"},
+							"annotations": {"summary": "down"},
+							"state": "firing",
+							"activeAt": "1970-01-01T00:01:40Z",
+							"value": "1e+00"
+						}],
+						"health": "err",
+						"lastError": "timeout",
+						"type": "alerting"
+					}
+				]
+			}]
+		}
+	}`)
+	actual := xtest.MustPrettyJSON(t, recorder.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestPromAlertsHandler(t *testing.T) {
+	opts := options.EmptyHandlerOptions().SetRuleManager(newTestRuleManager())
+	h := NewPromAlertsHandler(opts)
+
+	req := httptest.NewRequest(PromAlertsHTTPMethod, PromAlertsURL, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	expected := xtest.MustPrettyJSON(t, `{
+		"status": "success",
+		"data": {
+			"alerts": [{
+				"labels": {"alertname": "InstanceDown"},
+				"annotations": {"summary": "down"},
+				"state": "firing",
+				"activeAt": "1970-01-01T00:01:40Z",
+				"value": "1e+00"
+			}]
+		}
+	}`)
+	actual := xtest.MustPrettyJSON(t, recorder.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestPromRulesHandlerWithoutRuleManager(t *testing.T) {
+	h := NewPromRulesHandler(options.EmptyHandlerOptions())
+
+	req := httptest.NewRequest(PromRulesHTTPMethod, PromRulesURL, nil)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"status":"success","data":{"groups":[]}}`,
+		recorder.Body.String())
+}
@@ -23,10 +23,12 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strconv"
 
+	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/storage"
@@ -44,6 +46,10 @@ const (
 	// SearchHTTPMethod is the HTTP method used with this resource.
 	SearchHTTPMethod = http.MethodPost
 
+	// SearchQueryParam is the URL parameter of a Lucene-style query string,
+	// which overrides the query string of the request body.
+	SearchQueryParam = "query"
+
 	defaultLimit = 1000
 )
 
@@ -96,6 +102,18 @@ func (h *SearchHandler) parseBody(r *http.Request) (*storage.FetchQuery, *xhttp.
 		return nil, xhttp.NewParseError(err, http.StatusBadRequest)
 	}
 
+	if str := r.URL.Query().Get(SearchQueryParam); str != "" {
+		fetchQuery.IndexQuery = str
+	}
+
+	if fetchQuery.IndexQuery != "" {
+		// Validate the query string up front to return a descriptive error.
+		if _, err := idx.ParseQuery(fetchQuery.IndexQuery); err != nil {
+			err = fmt.Errorf("could not parse query: %v", err)
+			return nil, xhttp.NewParseError(err, http.StatusBadRequest)
+		}
+	}
+
 	return &fetchQuery, nil
 }
 
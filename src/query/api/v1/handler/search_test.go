@@ -135,3 +135,40 @@ func TestSearchEndpoint(t *testing.T) {
 	defer resp.Body.Close()
 	require.NotNil(t, resp)
 }
+
+func TestSearchEndpointWithQueryString(t *testing.T) {
+	searchHandler := searchServer(t)
+	server := httptest.NewServer(searchHandler)
+	defer server.Close()
+
+	req := generateSearchReq()
+	req.IndexQuery = "service:api AND (env:prod OR env:staging) -host:foo*"
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	resp, err := http.Post(server.URL, "application/json", bytes.NewReader(data))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var results struct {
+		Metrics []struct {
+			ID []byte
+		}
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&results))
+	require.Equal(t, 1, len(results.Metrics))
+	assert.Equal(t, []byte(testID), results.Metrics[0].ID)
+}
+
+func TestSearchEndpointInvalidQueryString(t *testing.T) {
+	searchHandler := searchServer(t)
+	server := httptest.NewServer(searchHandler)
+	defer server.Close()
+
+	url := fmt.Sprintf("%s?%s=%s", server.URL, SearchQueryParam, "service:(api")
+	resp, err := http.Post(url, "application/json", generateSearchBody(t))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -23,6 +23,7 @@ package topic
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	clusterclient "github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/cluster/kv"
@@ -54,8 +55,10 @@ type Handler struct {
 	client clusterclient.Client
 	cfg    config.Configuration
 
-	serviceFn      serviceFn
-	instrumentOpts instrument.Options
+	serviceFn       serviceFn
+	offsetServiceFn offsetServiceFn
+	nowFn           func() time.Time
+	instrumentOpts  instrument.Options
 }
 
 // Service gets a topic service from m3cluster client
@@ -95,6 +98,12 @@ func RegisterRoutes(
 	r.HandleFunc(DeleteURL,
 		wrapped(newDeleteHandler(client, cfg, instrumentOpts)).ServeHTTP).
 		Methods(DeleteHTTPMethod)
+	r.HandleFunc(OffsetGetURL,
+		wrapped(newOffsetGetHandler(client, cfg, instrumentOpts)).ServeHTTP).
+		Methods(OffsetGetHTTPMethod)
+	r.HandleFunc(OffsetResetURL,
+		wrapped(newOffsetResetHandler(client, cfg, instrumentOpts)).ServeHTTP).
+		Methods(OffsetResetHTTPMethod)
 }
 
 func topicName(headers http.Header) string {
@@ -0,0 +1,211 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package topic
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/msg/generated/proto/offsetpb"
+	"github.com/m3db/m3/src/msg/offset"
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	consumerServiceVar = "consumerService"
+
+	// OffsetGetHTTPMethod is the HTTP method used with the offset get resource.
+	OffsetGetHTTPMethod = http.MethodGet
+
+	// OffsetResetURL is the url for the topic offset reset handler (with the POST method).
+	OffsetResetURL = handler.RoutePrefixV1 + "/topic/reset"
+
+	// OffsetResetHTTPMethod is the HTTP method used with the offset reset resource.
+	OffsetResetHTTPMethod = http.MethodPost
+)
+
+var (
+	// OffsetGetURL is the url for the topic offset get handler (with the GET method).
+	OffsetGetURL = fmt.Sprintf("%s/topic/offsets/{%s}", handler.RoutePrefixV1, consumerServiceVar)
+
+	errNoConsumerService = errors.New("must specify consumer service")
+	errNoResetPosition   = errors.New("must specify either has_offset or timestamp_nanos to reset to")
+	errNoHasOffset       = errors.New("must set has_offset to reset to an offset")
+)
+
+type offsetServiceFn func(clusterClient clusterclient.Client, opts handleroptions.ServiceOptions) (offset.Service, error)
+
+// OffsetService gets an offset service from m3cluster client
+func OffsetService(clusterClient clusterclient.Client, opts handleroptions.ServiceOptions) (offset.Service, error) {
+	kvOverride := kv.NewOverrideOptions().
+		SetEnvironment(opts.ServiceEnvironment).
+		SetZone(opts.ServiceZone)
+	offsetOpts := offset.NewServiceOptions().
+		SetConfigService(clusterClient).
+		SetKVOverrideOptions(kvOverride)
+	return offset.NewService(offsetOpts)
+}
+
+// OffsetGetHandler is the handler for getting the offsets committed for a
+// consumer service of a topic, the offset of every shard in the response is
+// the highest sequence number of the shard acknowledged by the consumer
+// service as last committed by each producer.
+type OffsetGetHandler Handler
+
+// newOffsetGetHandler returns a new instance of OffsetGetHandler.
+func newOffsetGetHandler(
+	client clusterclient.Client,
+	cfg config.Configuration,
+	instrumentOpts instrument.Options,
+) http.Handler {
+	return &OffsetGetHandler{
+		client:          client,
+		cfg:             cfg,
+		offsetServiceFn: OffsetService,
+		instrumentOpts:  instrumentOpts,
+	}
+}
+
+func (h *OffsetGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx, h.instrumentOpts)
+	)
+
+	consumerService := strings.TrimSpace(mux.Vars(r)[consumerServiceVar])
+	if consumerService == "" {
+		logger.Error("no consumer service", zap.Error(errNoConsumerService))
+		xhttp.Error(w, errNoConsumerService, http.StatusBadRequest)
+		return
+	}
+
+	serviceCfg := handleroptions.ServiceNameAndDefaults{}
+	svcOpts := handleroptions.NewServiceOptions(serviceCfg, r.Header, nil)
+	service, err := h.offsetServiceFn(h.client, svcOpts)
+	if err != nil {
+		logger.Error("unable to get service", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	offsets, err := service.Get(topicName(r.Header), consumerService)
+	if err == kv.ErrNotFound {
+		// NB: Nothing has been committed for the consumer service yet.
+		offsets, err = &offsetpb.ConsumerServiceOffsets{}, nil
+	}
+	if err != nil {
+		logger.Error("unable to get offsets", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	xhttp.WriteProtoMsgJSONResponse(w, offsets, logger)
+}
+
+// OffsetResetHandler is the handler for resetting a consumer service of a
+// topic to an offset or a timestamp, has_offset must be set to reset to an
+// offset since zero is a valid offset to reset to.
+type OffsetResetHandler Handler
+
+// newOffsetResetHandler returns a new instance of OffsetResetHandler.
+func newOffsetResetHandler(
+	client clusterclient.Client,
+	cfg config.Configuration,
+	instrumentOpts instrument.Options,
+) http.Handler {
+	return &OffsetResetHandler{
+		client:          client,
+		cfg:             cfg,
+		offsetServiceFn: OffsetService,
+		nowFn:           time.Now,
+		instrumentOpts:  instrumentOpts,
+	}
+}
+
+func (h *OffsetResetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx, h.instrumentOpts)
+		req    offsetpb.Reset
+	)
+	rErr := parseRequest(r, &req)
+	if rErr != nil {
+		logger.Error("unable to parse request", zap.Error(rErr))
+		xhttp.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+	if req.ConsumerService == "" {
+		logger.Error("invalid request", zap.Error(errNoConsumerService))
+		xhttp.Error(w, errNoConsumerService, http.StatusBadRequest)
+		return
+	}
+	if req.Offset != 0 && !req.HasOffset {
+		logger.Error("invalid request", zap.Error(errNoHasOffset))
+		xhttp.Error(w, errNoHasOffset, http.StatusBadRequest)
+		return
+	}
+	if !req.HasOffset && req.TimestampNanos == 0 {
+		logger.Error("invalid request", zap.Error(errNoResetPosition))
+		xhttp.Error(w, errNoResetPosition, http.StatusBadRequest)
+		return
+	}
+
+	serviceCfg := handleroptions.ServiceNameAndDefaults{}
+	svcOpts := handleroptions.NewServiceOptions(serviceCfg, r.Header, nil)
+	service, err := h.offsetServiceFn(h.client, svcOpts)
+	if err != nil {
+		logger.Error("unable to get service", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	// NB: The request time makes every reset a new value so that producers
+	// are notified even if the same reset is requested twice.
+	req.RequestedAtNanos = h.nowFn().UnixNano()
+	name := topicName(r.Header)
+	if err := service.Reset(name, &req); err != nil {
+		logger.Error("unable to reset offsets", zap.Error(err))
+		xhttp.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("reset consumer service offsets",
+		zap.String("topic", name),
+		zap.String("consumerService", req.ConsumerService),
+		zap.String("producerID", req.ProducerId),
+		zap.Bool("hasOffset", req.HasOffset),
+		zap.Uint64("offset", req.Offset),
+		zap.Int64("timestampNanos", req.TimestampNanos))
+	xhttp.WriteProtoMsgJSONResponse(w, &req, logger)
+}
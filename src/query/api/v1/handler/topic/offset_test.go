@@ -0,0 +1,176 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package topic
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/msg/generated/proto/offsetpb"
+	"github.com/m3db/m3/src/msg/offset"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/x/instrument"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicOffsetGetHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := newTestOffsetService(t, ctrl)
+	handler := newOffsetGetHandler(nil, config.Configuration{}, instrument.NewOptions())
+	handler.(*OffsetGetHandler).offsetServiceFn = testOffsetServiceFn(service)
+
+	// Nothing committed yet.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/topic/offsets/cs1", nil)
+	req = mux.SetURLVars(req, map[string]string{consumerServiceVar: "cs1"})
+	handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	var respProto offsetpb.ConsumerServiceOffsets
+	require.NoError(t, jsonUnmarshaler.Unmarshal(bytes.NewBuffer(body), &respProto))
+	require.Empty(t, respProto.ProducerOffsets)
+
+	producerOffsets := &offsetpb.ProducerOffsets{
+		ProducerId:       "p1",
+		ShardOffsets:     []*offsetpb.ShardOffset{{Shard: 1, Offset: 10}},
+		CommittedAtNanos: 100,
+	}
+	require.NoError(t, service.Commit(DefaultTopicName, "cs1", producerOffsets))
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/topic/offsets/cs1", nil)
+	req = mux.SetURLVars(req, map[string]string{consumerServiceVar: "cs1"})
+	handler.ServeHTTP(w, req)
+
+	resp = w.Result()
+	body, _ = ioutil.ReadAll(resp.Body)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	require.NoError(t, jsonUnmarshaler.Unmarshal(bytes.NewBuffer(body), &respProto))
+	require.Equal(t, []*offsetpb.ProducerOffsets{producerOffsets}, respProto.ProducerOffsets)
+
+	// Missing consumer service.
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/topic/offsets/", nil)
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestTopicOffsetResetHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Unix(100, 0)
+	service := newTestOffsetService(t, ctrl)
+	handler := newOffsetResetHandler(nil, config.Configuration{}, instrument.NewOptions())
+	handler.(*OffsetResetHandler).offsetServiceFn = testOffsetServiceFn(service)
+	handler.(*OffsetResetHandler).nowFn = func() time.Time { return now }
+
+	reset := &offsetpb.Reset{
+		ConsumerService: "cs1",
+		TimestampNanos:  50,
+	}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/topic/reset", mustMarshalTestReset(t, reset))
+	req.Header.Add(HeaderTopicName, "foo")
+	handler.ServeHTTP(w, req)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	expected := &offsetpb.Reset{
+		ConsumerService:  "cs1",
+		TimestampNanos:   50,
+		RequestedAtNanos: now.UnixNano(),
+	}
+	var respProto offsetpb.Reset
+	require.NoError(t, jsonUnmarshaler.Unmarshal(bytes.NewBuffer(body), &respProto))
+	require.Equal(t, *expected, respProto)
+
+	stored, _, err := service.GetReset("foo", "cs1")
+	require.NoError(t, err)
+	require.Equal(t, expected, stored)
+
+	// Rewinding to offset zero is allowed when the offset is set explicitly.
+	reset = &offsetpb.Reset{
+		ConsumerService: "cs1",
+		HasOffset:       true,
+	}
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/topic/reset", mustMarshalTestReset(t, reset))
+	req.Header.Add(HeaderTopicName, "foo")
+	handler.ServeHTTP(w, req)
+	resp = w.Result()
+	body, _ = ioutil.ReadAll(resp.Body)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	stored, _, err = service.GetReset("foo", "cs1")
+	require.NoError(t, err)
+	require.True(t, stored.HasOffset)
+	require.Equal(t, uint64(0), stored.Offset)
+
+	// Invalid requests.
+	for _, reset := range []*offsetpb.Reset{
+		{TimestampNanos: 50},
+		{ConsumerService: "cs1"},
+		{ConsumerService: "cs1", Offset: 10},
+	} {
+		w = httptest.NewRecorder()
+		req = httptest.NewRequest("POST", "/topic/reset", mustMarshalTestReset(t, reset))
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	}
+}
+
+func testOffsetServiceFn(s offset.Service) offsetServiceFn {
+	return func(clusterClient clusterclient.Client, opts handleroptions.ServiceOptions) (offset.Service, error) {
+		return s, nil
+	}
+}
+
+func newTestOffsetService(t *testing.T, ctrl *gomock.Controller) offset.Service {
+	cs := clusterclient.NewMockClient(ctrl)
+	cs.EXPECT().Store(gomock.Any()).Return(mem.NewStore(), nil)
+	s, err := offset.NewService(offset.NewServiceOptions().SetConfigService(cs))
+	require.NoError(t, err)
+	return s
+}
+
+func mustMarshalTestReset(t *testing.T, reset *offsetpb.Reset) *bytes.Buffer {
+	var buf bytes.Buffer
+	require.NoError(t, jsonMarshaler.Marshal(&buf, reset))
+	return &buf
+}
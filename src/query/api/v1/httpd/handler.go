@@ -170,10 +170,20 @@ func (h *Handler) RegisterRoutes() error {
 		wrapped(native.NewPromReadInstantHandler(h.options)).ServeHTTP,
 	).Methods(native.PromReadInstantHTTPMethods...)
 
+	// M3QL read endpoint.
+	h.router.HandleFunc(native.M3QLReadURL,
+		wrapped(native.NewM3QLReadHandler(nativeSourceOpts)).ServeHTTP,
+	).Methods(native.M3QLReadHTTPMethods...)
+
 	// InfluxDB write endpoint.
 	h.router.HandleFunc(influxdb.InfluxWriteURL,
 		wrapped(influxdb.NewInfluxWriterHandler(h.options)).ServeHTTP).Methods(influxdb.InfluxWriteHTTPMethod)
 
+	// InfluxDB query endpoint.
+	h.router.HandleFunc(influxdb.InfluxQueryURL,
+		wrapped(influxdb.NewInfluxQueryHandler(h.options)).ServeHTTP,
+	).Methods(influxdb.InfluxQueryHTTPMethods...)
+
 	// Native M3 search and write endpoints.
 	h.router.HandleFunc(handler.SearchURL,
 		wrapped(handler.NewSearchHandler(h.options)).ServeHTTP,
@@ -203,11 +213,24 @@ func (h *Handler) RegisterRoutes() error {
 		wrapped(native.NewPromThresholdHandler(h.options)).ServeHTTP,
 	).Methods(native.PromThresholdHTTPMethod)
 
+	// Rule and alert endpoints.
+	h.router.HandleFunc(native.PromRulesURL,
+		wrapped(native.NewPromRulesHandler(h.options)).ServeHTTP,
+	).Methods(native.PromRulesHTTPMethod)
+	h.router.HandleFunc(native.PromAlertsURL,
+		wrapped(native.NewPromAlertsHandler(h.options)).ServeHTTP,
+	).Methods(native.PromAlertsHTTPMethod)
+
 	// Series match endpoints.
 	h.router.HandleFunc(remote.PromSeriesMatchURL,
 		wrapped(remote.NewPromSeriesMatchHandler(h.options)).ServeHTTP,
 	).Methods(remote.PromSeriesMatchHTTPMethods...)
 
+	// Series delete endpoints.
+	h.router.HandleFunc(native.PromDeleteSeriesURL,
+		wrapped(native.NewPromDeleteSeriesHandler(h.options)).ServeHTTP,
+	).Methods(native.PromDeleteSeriesHTTPMethods...)
+
 	// Graphite endpoints.
 	h.router.HandleFunc(graphite.ReadURL,
 		wrapped(graphite.NewRenderHandler(h.options)).ServeHTTP,
@@ -217,6 +240,14 @@ func (h *Handler) RegisterRoutes() error {
 		wrapped(graphite.NewFindHandler(h.options)).ServeHTTP,
 	).Methods(graphite.FindHTTPMethods...)
 
+	h.router.HandleFunc(graphite.TagsAutoCompleteTagsURL,
+		wrapped(graphite.NewTagsAutoCompleteTagsHandler(h.options)).ServeHTTP,
+	).Methods(graphite.TagsAutoCompleteHTTPMethods...)
+
+	h.router.HandleFunc(graphite.TagsAutoCompleteValuesURL,
+		wrapped(graphite.NewTagsAutoCompleteValuesHandler(h.options)).ServeHTTP,
+	).Methods(graphite.TagsAutoCompleteHTTPMethods...)
+
 	placementOpts, err := h.placementOpts()
 	if err != nil {
 		return err
@@ -228,6 +228,19 @@ func TestPromNativeReadPost(t *testing.T) {
 	require.Equal(t, res.Code, http.StatusBadRequest, "Empty request")
 }
 
+func TestM3QLReadGet(t *testing.T) {
+	req := httptest.NewRequest("GET", native.M3QLReadURL, nil)
+	res := httptest.NewRecorder()
+	ctrl := gomock.NewController(t)
+	storage, _ := m3.NewStorageAndSession(t, ctrl)
+
+	h, err := setupHandler(storage)
+	require.NoError(t, err, "unable to setup handler")
+	h.RegisterRoutes()
+	h.Router().ServeHTTP(res, req)
+	require.Equal(t, res.Code, http.StatusBadRequest, "Empty request")
+}
+
 func TestJSONWritePost(t *testing.T) {
 	req := httptest.NewRequest("POST", m3json.WriteJSONURL, nil)
 	res := httptest.NewRecorder()
@@ -31,11 +31,14 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/cache"
 	"github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ruler"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
 )
@@ -117,6 +120,21 @@ type HandlerOptions interface {
 	// SetQueryContextOptions sets the query context options.
 	SetQueryContextOptions(o models.QueryContextOptions) HandlerOptions
 
+	// QueryResultsCache returns the query results cache, if any.
+	QueryResultsCache() cache.ResultsCache
+	// SetQueryResultsCache sets the query results cache.
+	SetQueryResultsCache(c cache.ResultsCache) HandlerOptions
+
+	// Tenants returns the tenants, if any.
+	Tenants() tenant.Tenants
+	// SetTenants sets the tenants.
+	SetTenants(t tenant.Tenants) HandlerOptions
+
+	// RuleManager returns the rule manager, if any.
+	RuleManager() ruler.Manager
+	// SetRuleManager sets the rule manager.
+	SetRuleManager(m ruler.Manager) HandlerOptions
+
 	// CPUProfileDuration returns the cpu profile duration.
 	CPUProfileDuration() time.Duration
 	// SetCPUProfileDuration sets the cpu profile duration.
@@ -158,6 +176,9 @@ type handlerOptions struct {
 	enforcer              cost.ChainedEnforcer
 	fetchOptionsBuilder   handleroptions.FetchOptionsBuilder
 	queryContextOptions   models.QueryContextOptions
+	queryResultsCache     cache.ResultsCache
+	tenants               tenant.Tenants
+	ruleManager           ruler.Manager
 	instrumentOpts        instrument.Options
 	cpuProfileDuration    time.Duration
 	placementServiceNames []string
@@ -354,6 +375,36 @@ func (o *handlerOptions) SetQueryContextOptions(
 	return &opts
 }
 
+func (o *handlerOptions) QueryResultsCache() cache.ResultsCache {
+	return o.queryResultsCache
+}
+
+func (o *handlerOptions) SetQueryResultsCache(c cache.ResultsCache) HandlerOptions {
+	opts := *o
+	opts.queryResultsCache = c
+	return &opts
+}
+
+func (o *handlerOptions) Tenants() tenant.Tenants {
+	return o.tenants
+}
+
+func (o *handlerOptions) SetTenants(t tenant.Tenants) HandlerOptions {
+	opts := *o
+	opts.tenants = t
+	return &opts
+}
+
+func (o *handlerOptions) RuleManager() ruler.Manager {
+	return o.ruleManager
+}
+
+func (o *handlerOptions) SetRuleManager(m ruler.Manager) HandlerOptions {
+	opts := *o
+	opts.ruleManager = m
+	return &opts
+}
+
 func (o *handlerOptions) CPUProfileDuration() time.Duration {
 	return o.cpuProfileDuration
 }
@@ -0,0 +1,265 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+
+	"github.com/uber-go/tally"
+)
+
+type resultsCache struct {
+	backend Backend
+	opts    Options
+	metrics resultsCacheMetrics
+}
+
+type resultsCacheMetrics struct {
+	hits        tally.Counter
+	partialHits tally.Counter
+	misses      tally.Counter
+	bypassed    tally.Counter
+}
+
+func newResultsCacheMetrics(scope tally.Scope) resultsCacheMetrics {
+	return resultsCacheMetrics{
+		hits:        scope.Counter("hits"),
+		partialHits: scope.Counter("partial-hits"),
+		misses:      scope.Counter("misses"),
+		bypassed:    scope.Counter("bypassed"),
+	}
+}
+
+// NewResultsCache returns a new results cache that stores block aligned
+// extents in the given backend.
+func NewResultsCache(backend Backend, opts Options) (ResultsCache, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	scope := opts.InstrumentOptions().MetricsScope().SubScope("results-cache")
+	return &resultsCache{
+		backend: backend,
+		opts:    opts,
+		metrics: newResultsCacheMetrics(scope),
+	}, nil
+}
+
+type timeRange struct {
+	start time.Time
+	end   time.Time
+}
+
+func (c *resultsCache) FetchRange(
+	key string,
+	params models.RequestParams,
+	fetchFn FetchFn,
+) ([]*ts.Series, block.ResultMetadata, error) {
+	var (
+		step  = params.Step
+		start = params.Start
+		end   = params.ExclusiveEnd()
+	)
+
+	// NB: results are only consistent across requests if every step lands on
+	// the same timestamps, so only cache requests with step aligned starts.
+	if step <= 0 || start.UnixNano()%int64(step) != 0 || !start.Before(end) {
+		c.metrics.bypassed.Inc(1)
+		return fetchFn(withRange(params, start, end))
+	}
+
+	var (
+		blockSize    = c.blockSize(step)
+		cacheableEnd = truncate(params.Now.Add(-c.opts.MaxFreshness()), step)
+		extents      []Extent
+		missing      []timeRange
+	)
+
+	for blockStart := truncate(start, blockSize); blockStart.Before(end); blockStart = blockStart.Add(blockSize) {
+		rangeStart := maxTime(blockStart, start)
+		rangeEnd := minTime(blockStart.Add(blockSize), end)
+		extent, ok := c.backend.Get(blockKey(key, blockStart))
+		if !ok || extent.Start.After(rangeStart) || !extent.End.After(rangeStart) {
+			c.metrics.misses.Inc(1)
+			missing = appendRange(missing, timeRange{start: rangeStart, end: rangeEnd})
+			continue
+		}
+
+		coveredEnd := minTime(extent.End, rangeEnd)
+		extents = append(extents, stitch([]Extent{extent}, rangeStart, coveredEnd, step))
+		if coveredEnd.Before(rangeEnd) {
+			c.metrics.partialHits.Inc(1)
+			missing = appendRange(missing, timeRange{start: coveredEnd, end: rangeEnd})
+			continue
+		}
+
+		c.metrics.hits.Inc(1)
+	}
+
+	meta := block.NewResultMetadata()
+	for _, r := range missing {
+		series, fetchedMeta, err := fetchFn(withRange(params, r.start, r.end))
+		if err != nil {
+			return nil, meta, err
+		}
+
+		meta = meta.CombineMetadata(fetchedMeta)
+		fetched := Extent{Start: r.start, End: r.end, Series: series}
+		extents = append(extents, fetched)
+
+		// NB: do not cache results that are incomplete.
+		if fetchedMeta.Exhaustive && len(fetchedMeta.Warnings) == 0 {
+			c.store(key, fetched, blockSize, step, cacheableEnd)
+		}
+	}
+
+	return stitch(extents, start, end, step).Series, meta, nil
+}
+
+// store splits the fetched extent into blocks and merges each block with any
+// existing cached extent that it is contiguous with.
+func (c *resultsCache) store(
+	key string,
+	fetched Extent,
+	blockSize time.Duration,
+	step time.Duration,
+	cacheableEnd time.Time,
+) {
+	for blockStart := truncate(fetched.Start, blockSize); blockStart.Before(fetched.End); blockStart = blockStart.Add(blockSize) {
+		rangeStart := maxTime(blockStart, fetched.Start)
+		rangeEnd := minTime(minTime(blockStart.Add(blockSize), fetched.End), cacheableEnd)
+		if !rangeStart.Before(rangeEnd) {
+			// NB: all remaining blocks are past the cacheable end.
+			return
+		}
+
+		k := blockKey(key, blockStart)
+		extent := stitch([]Extent{fetched}, rangeStart, rangeEnd, step)
+		existing, ok := c.backend.Get(k)
+		if ok && !existing.Start.After(rangeEnd) && !existing.End.Before(rangeStart) {
+			extent = stitch([]Extent{existing, extent},
+				minTime(existing.Start, rangeStart), maxTime(existing.End, rangeEnd), step)
+		}
+
+		c.backend.Set(k, extent)
+	}
+}
+
+// blockSize returns the configured block size rounded up to a multiple of
+// the step, so that block boundaries are always step aligned.
+func (c *resultsCache) blockSize(step time.Duration) time.Duration {
+	blockSize := c.opts.BlockSize()
+	if rem := blockSize % step; rem != 0 {
+		blockSize += step - rem
+	}
+
+	return blockSize
+}
+
+// stitch combines the extents into a single extent covering [start, end),
+// matching series across extents by name and tags.
+func stitch(extents []Extent, start, end time.Time, step time.Duration) Extent {
+	sort.SliceStable(extents, func(i, j int) bool {
+		return extents[i].Start.Before(extents[j].Start)
+	})
+
+	var (
+		numSteps = int(end.Sub(start) / step)
+		indices  = make(map[string]int)
+		series   = make([]*ts.Series, 0)
+		values   = make([]ts.FixedResolutionMutableValues, 0)
+	)
+
+	for _, extent := range extents {
+		for _, s := range extent.Series {
+			id := string(s.Name()) + string(s.Tags.ID())
+			idx, ok := indices[id]
+			if !ok {
+				idx = len(series)
+				indices[id] = idx
+				vals := ts.NewFixedStepValues(step, numSteps, math.NaN(), start)
+				values = append(values, vals)
+				series = append(series, ts.NewSeries(s.Name(), vals, s.Tags))
+			}
+
+			vals := s.Values()
+			for i := 0; i < vals.Len(); i++ {
+				dp := vals.DatapointAt(i)
+				if dp.Timestamp.Before(start) || !dp.Timestamp.Before(end) {
+					continue
+				}
+
+				values[idx].SetValueAt(int(dp.Timestamp.Sub(start)/step), dp.Value)
+			}
+		}
+	}
+
+	return Extent{Start: start, End: end, Series: series}
+}
+
+func appendRange(ranges []timeRange, r timeRange) []timeRange {
+	if n := len(ranges); n > 0 && ranges[n-1].end.Equal(r.start) {
+		ranges[n-1].end = r.end
+		return ranges
+	}
+
+	return append(ranges, r)
+}
+
+func withRange(params models.RequestParams, start, end time.Time) models.RequestParams {
+	params.Start = start
+	params.End = end
+	params.IncludeEnd = false
+	return params
+}
+
+func blockKey(key string, blockStart time.Time) string {
+	return fmt.Sprintf("%s:%d", key, blockStart.UnixNano())
+}
+
+// truncate truncates the time to a multiple of d since the Unix epoch.
+func truncate(t time.Time, d time.Duration) time.Time {
+	nanos := t.UnixNano()
+	return time.Unix(0, nanos-nanos%int64(d))
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
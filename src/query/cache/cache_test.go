@@ -0,0 +1,228 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fetchCall struct {
+	start time.Time
+	end   time.Time
+}
+
+type testFetcher struct {
+	calls []fetchCall
+	meta  block.ResultMetadata
+}
+
+// fetch returns a single series whose value at each step is the Unix time
+// of that step in seconds.
+func (f *testFetcher) fetch(
+	params models.RequestParams,
+) ([]*ts.Series, block.ResultMetadata, error) {
+	f.calls = append(f.calls, fetchCall{start: params.Start, end: params.End})
+	numSteps := int(params.End.Sub(params.Start) / params.Step)
+	vals := ts.NewFixedStepValues(params.Step, numSteps, math.NaN(), params.Start)
+	for i := 0; i < numSteps; i++ {
+		vals.SetValueAt(i, float64(params.Start.Add(time.Duration(i)*params.Step).Unix()))
+	}
+
+	tags := models.NewTags(1, models.NewTagOptions()).
+		AddTag(models.Tag{Name: []byte("foo"), Value: []byte("bar")})
+	return []*ts.Series{ts.NewSeries([]byte("foo"), vals, tags)}, f.meta, nil
+}
+
+func newTestFetcher() *testFetcher {
+	return &testFetcher{meta: block.NewResultMetadata()}
+}
+
+func newTestCache(t *testing.T) ResultsCache {
+	opts := NewOptions().
+		SetBlockSize(time.Hour).
+		SetMaxFreshness(5 * time.Minute)
+	c, err := NewResultsCache(NewMemoryBackend(0, 0), opts)
+	require.NoError(t, err)
+	return c
+}
+
+func testParams(start, end, now time.Time) models.RequestParams {
+	return models.RequestParams{
+		Start: start,
+		End:   end,
+		Now:   now,
+		Step:  time.Minute,
+	}
+}
+
+func requireStepValues(
+	t *testing.T,
+	series []*ts.Series,
+	start, end time.Time,
+	step time.Duration,
+) {
+	require.Len(t, series, 1)
+	vals := series[0].Values()
+	require.Equal(t, int(end.Sub(start)/step), vals.Len())
+	for i := 0; i < vals.Len(); i++ {
+		dp := vals.DatapointAt(i)
+		assert.Equal(t, start.Add(time.Duration(i)*step), dp.Timestamp)
+		assert.Equal(t, float64(dp.Timestamp.Unix()), dp.Value)
+	}
+}
+
+func TestResultsCacheServesRepeatedQuery(t *testing.T) {
+	var (
+		c       = newTestCache(t)
+		fetcher = newTestFetcher()
+		start   = time.Unix(0, 0).Add(100 * time.Hour)
+		end     = start.Add(3 * time.Hour)
+		now     = end.Add(time.Hour)
+		params  = testParams(start, end, now)
+	)
+
+	series, _, err := c.FetchRange("q", params, fetcher.fetch)
+	require.NoError(t, err)
+	requireStepValues(t, series, start, end, time.Minute)
+	require.Len(t, fetcher.calls, 1)
+
+	series, meta, err := c.FetchRange("q", params, fetcher.fetch)
+	require.NoError(t, err)
+	requireStepValues(t, series, start, end, time.Minute)
+	assert.Len(t, fetcher.calls, 1)
+	assert.True(t, meta.Exhaustive)
+
+	// A different key does not share cached results.
+	_, _, err = c.FetchRange("other", params, fetcher.fetch)
+	require.NoError(t, err)
+	assert.Len(t, fetcher.calls, 2)
+}
+
+func TestResultsCacheFetchesOnlyMissingTail(t *testing.T) {
+	var (
+		c       = newTestCache(t)
+		fetcher = newTestFetcher()
+		start   = time.Unix(0, 0).Add(100 * time.Hour)
+		end     = start.Add(90 * time.Minute)
+	)
+
+	_, _, err := c.FetchRange("q", testParams(start, end, end.Add(time.Hour)), fetcher.fetch)
+	require.NoError(t, err)
+	require.Len(t, fetcher.calls, 1)
+
+	// Slide the window forward, only the new tail should be fetched.
+	newStart := start.Add(30 * time.Minute)
+	newEnd := end.Add(45 * time.Minute)
+	series, _, err := c.FetchRange("q",
+		testParams(newStart, newEnd, newEnd.Add(time.Hour)), fetcher.fetch)
+	require.NoError(t, err)
+	requireStepValues(t, series, newStart, newEnd, time.Minute)
+	require.Len(t, fetcher.calls, 2)
+	assert.Equal(t, fetchCall{start: end, end: newEnd}, fetcher.calls[1])
+}
+
+func TestResultsCacheDoesNotCacheFreshResults(t *testing.T) {
+	var (
+		c       = newTestCache(t)
+		fetcher = newTestFetcher()
+		start   = time.Unix(0, 0).Add(100 * time.Hour)
+		end     = start.Add(30 * time.Minute)
+		params  = testParams(start, end, end)
+	)
+
+	_, _, err := c.FetchRange("q", params, fetcher.fetch)
+	require.NoError(t, err)
+
+	// Only the steps older than the max freshness are cached.
+	series, _, err := c.FetchRange("q", params, fetcher.fetch)
+	require.NoError(t, err)
+	requireStepValues(t, series, start, end, time.Minute)
+	require.Len(t, fetcher.calls, 2)
+	assert.Equal(t, fetchCall{start: end.Add(-5 * time.Minute), end: end},
+		fetcher.calls[1])
+}
+
+func TestResultsCacheDoesNotCacheIncompleteResults(t *testing.T) {
+	var (
+		c       = newTestCache(t)
+		fetcher = newTestFetcher()
+		start   = time.Unix(0, 0).Add(100 * time.Hour)
+		end     = start.Add(time.Hour)
+		params  = testParams(start, end, end.Add(time.Hour))
+	)
+
+	fetcher.meta.Exhaustive = false
+	for i := 0; i < 2; i++ {
+		_, meta, err := c.FetchRange("q", params, fetcher.fetch)
+		require.NoError(t, err)
+		assert.False(t, meta.Exhaustive)
+	}
+
+	assert.Len(t, fetcher.calls, 2)
+}
+
+func TestResultsCacheBypassesUnalignedStart(t *testing.T) {
+	var (
+		c       = newTestCache(t)
+		fetcher = newTestFetcher()
+		start   = time.Unix(0, 0).Add(100 * time.Hour).Add(time.Second)
+		end     = start.Add(time.Hour)
+		params  = testParams(start, end, end.Add(time.Hour))
+	)
+
+	for i := 0; i < 2; i++ {
+		series, _, err := c.FetchRange("q", params, fetcher.fetch)
+		require.NoError(t, err)
+		requireStepValues(t, series, start, end, time.Minute)
+	}
+
+	assert.Len(t, fetcher.calls, 2)
+}
+
+func TestResultsCacheReturnsFetchError(t *testing.T) {
+	var (
+		c      = newTestCache(t)
+		start  = time.Unix(0, 0).Add(100 * time.Hour)
+		params = testParams(start, start.Add(time.Hour), start.Add(2*time.Hour))
+		errFoo = errors.New("foo")
+	)
+
+	_, _, err := c.FetchRange("q", params,
+		func(models.RequestParams) ([]*ts.Series, block.ResultMetadata, error) {
+			return nil, block.NewResultMetadata(), errFoo
+		})
+	assert.Equal(t, errFoo, err)
+}
+
+func TestNewResultsCacheInvalidOptions(t *testing.T) {
+	_, err := NewResultsCache(NewMemoryBackend(0, 0), NewOptions().SetBlockSize(0))
+	assert.Equal(t, errInvalidBlockSize, err)
+}
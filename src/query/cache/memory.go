@@ -0,0 +1,125 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+const (
+	defaultMemoryBackendSize     = 1024
+	defaultMemoryBackendMaxBytes = 256 * 1024 * 1024
+
+	// extentOverheadBytes and seriesOverheadBytes approximate the memory used
+	// by the structures holding an extent and each of its series.
+	extentOverheadBytes = 128
+	seriesOverheadBytes = 96
+)
+
+type memoryEntry struct {
+	key    string
+	extent Extent
+	bytes  int
+}
+
+type memoryBackend struct {
+	sync.Mutex
+
+	size     int
+	maxBytes int
+	bytes    int
+	lru      *list.List
+	entries  map[string]*list.Element
+}
+
+// NewMemoryBackend returns an in-process backend that keeps at most size
+// extents using at most roughly maxBytes of memory, evicting the least
+// recently used extents when full. A non-positive size or max bytes uses the
+// default.
+func NewMemoryBackend(size int, maxBytes int) Backend {
+	if size <= 0 {
+		size = defaultMemoryBackendSize
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultMemoryBackendMaxBytes
+	}
+
+	return &memoryBackend{
+		size:     size,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element, size),
+	}
+}
+
+func (b *memoryBackend) Get(key string) (Extent, bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	elem, ok := b.entries[key]
+	if !ok {
+		return Extent{}, false
+	}
+
+	b.lru.MoveToFront(elem)
+	return elem.Value.(*memoryEntry).extent, true
+}
+
+func (b *memoryBackend) Set(key string, extent Extent) {
+	b.Lock()
+	defer b.Unlock()
+
+	if elem, ok := b.entries[key]; ok {
+		b.removeWithLock(elem)
+	}
+
+	entry := &memoryEntry{key: key, extent: extent, bytes: extentBytes(key, extent)}
+	if entry.bytes > b.maxBytes {
+		// Never cache an extent that would evict everything else.
+		return
+	}
+
+	b.entries[key] = b.lru.PushFront(entry)
+	b.bytes += entry.bytes
+	for b.lru.Len() > b.size || b.bytes > b.maxBytes {
+		b.removeWithLock(b.lru.Back())
+	}
+}
+
+func (b *memoryBackend) removeWithLock(elem *list.Element) {
+	entry := elem.Value.(*memoryEntry)
+	b.lru.Remove(elem)
+	delete(b.entries, entry.key)
+	b.bytes -= entry.bytes
+}
+
+// extentBytes returns the approximate number of bytes used by the extent.
+func extentBytes(key string, extent Extent) int {
+	n := extentOverheadBytes + len(key)
+	for _, s := range extent.Series {
+		n += seriesOverheadBytes + len(s.Name()) + 8*s.Len()
+		for _, tag := range s.Tags.Tags {
+			n += len(tag.Name) + len(tag.Value)
+		}
+	}
+	return n
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBackendEvictsLeastRecentlyUsed(t *testing.T) {
+	b := NewMemoryBackend(2, 0)
+	b.Set("a", Extent{End: time.Unix(1, 0)})
+	b.Set("b", Extent{End: time.Unix(2, 0)})
+
+	// Touch a so that b is the least recently used.
+	_, ok := b.Get("a")
+	assert.True(t, ok)
+
+	b.Set("c", Extent{End: time.Unix(3, 0)})
+	_, ok = b.Get("b")
+	assert.False(t, ok)
+
+	extent, ok := b.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(1, 0), extent.End)
+
+	extent, ok = b.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, time.Unix(3, 0), extent.End)
+}
+
+func TestMemoryBackendEvictsOverMaxBytes(t *testing.T) {
+	var (
+		tags   = models.NewTags(0, models.NewTagOptions())
+		series = ts.NewSeries([]byte("foo"), ts.NewFixedStepValues(time.Second, 100, 1, time.Unix(0, 0)), tags)
+		extent = Extent{End: time.Unix(1, 0), Series: []*ts.Series{series}}
+		size   = extentBytes("a", extent)
+	)
+	b := NewMemoryBackend(10, 2*size)
+	b.Set("a", extent)
+	b.Set("b", extent)
+	_, ok := b.Get("a")
+	assert.True(t, ok)
+
+	// Adding a third extent exceeds the max bytes and evicts b.
+	b.Set("c", extent)
+	_, ok = b.Get("b")
+	assert.False(t, ok)
+	_, ok = b.Get("a")
+	assert.True(t, ok)
+	_, ok = b.Get("c")
+	assert.True(t, ok)
+
+	// Extents larger than the max bytes are never cached.
+	b = NewMemoryBackend(10, size-1)
+	b.Set("a", extent)
+	_, ok = b.Get("a")
+	assert.False(t, ok)
+}
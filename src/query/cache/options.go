@@ -0,0 +1,93 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultBlockSize    = 2 * time.Hour
+	defaultMaxFreshness = 10 * time.Minute
+)
+
+var (
+	errInvalidBlockSize    = errors.New("block size must be positive")
+	errInvalidMaxFreshness = errors.New("max freshness must not be negative")
+)
+
+type options struct {
+	blockSize      time.Duration
+	maxFreshness   time.Duration
+	instrumentOpts instrument.Options
+}
+
+// NewOptions creates a new set of results cache options.
+func NewOptions() Options {
+	return &options{
+		blockSize:      defaultBlockSize,
+		maxFreshness:   defaultMaxFreshness,
+		instrumentOpts: instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if o.blockSize <= 0 {
+		return errInvalidBlockSize
+	}
+	if o.maxFreshness < 0 {
+		return errInvalidMaxFreshness
+	}
+	return nil
+}
+
+func (o *options) SetBlockSize(value time.Duration) Options {
+	opts := *o
+	opts.blockSize = value
+	return &opts
+}
+
+func (o *options) BlockSize() time.Duration {
+	return o.blockSize
+}
+
+func (o *options) SetMaxFreshness(value time.Duration) Options {
+	opts := *o
+	opts.maxFreshness = value
+	return &opts
+}
+
+func (o *options) MaxFreshness() time.Duration {
+	return o.maxFreshness
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
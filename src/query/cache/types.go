@@ -0,0 +1,92 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package cache provides a step aligned results cache for range queries.
+package cache
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Extent is a cached query result for a contiguous range of steps, starting
+// at Start (inclusive) and ending at End (exclusive). Each series in the
+// extent has exactly one value per step in the range.
+type Extent struct {
+	Start  time.Time
+	End    time.Time
+	Series []*ts.Series
+}
+
+// Backend is a storage backend for cached extents.
+type Backend interface {
+	// Get returns the extent stored for the given key, if any.
+	Get(key string) (Extent, bool)
+
+	// Set stores the extent for the given key, replacing any existing extent.
+	Set(key string, extent Extent)
+}
+
+// FetchFn executes the query with the given request params, which always
+// have an exclusive end.
+type FetchFn func(params models.RequestParams) ([]*ts.Series, block.ResultMetadata, error)
+
+// ResultsCache is a cache of range query results that serves step aligned
+// requests from cached extents, only executing the query for missing ranges.
+type ResultsCache interface {
+	// FetchRange returns the results for the range query identified by key
+	// and described by params, executing fetchFn for any ranges that are not
+	// present in the cache.
+	FetchRange(
+		key string,
+		params models.RequestParams,
+		fetchFn FetchFn,
+	) ([]*ts.Series, block.ResultMetadata, error)
+}
+
+// Options are the options for the results cache.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetBlockSize sets the block size that cached extents are aligned to.
+	SetBlockSize(value time.Duration) Options
+
+	// BlockSize returns the block size that cached extents are aligned to.
+	BlockSize() time.Duration
+
+	// SetMaxFreshness sets the duration before the request time for which
+	// results are not cached, since they may still change.
+	SetMaxFreshness(value time.Duration) Options
+
+	// MaxFreshness returns the duration before the request time for which
+	// results are not cached, since they may still change.
+	MaxFreshness() time.Duration
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+}
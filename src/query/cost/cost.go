@@ -32,6 +32,8 @@ const (
 	BlockLevel = "block"
 	// QueryLevel identifies per-query enforcers.
 	QueryLevel = "query"
+	// TenantLevel identifies per-tenant enforcers.
+	TenantLevel = "tenant"
 	// GlobalLevel identifies global enforcers.
 	GlobalLevel = "global"
 )
@@ -115,6 +117,34 @@ func NewChainedEnforcer(rootResourceName string, models []cost.Enforcer) (Chaine
 	}, nil
 }
 
+// NewChildChainedEnforcer constructs a chainedEnforcer which rolls up into the
+// provided parent, but creates itself and its children using the provided
+// models rather than the models of the parent.
+// models[0] enforces this instance; models[1] enforces the first level of children, and so on.
+func NewChildChainedEnforcer(
+	parent ChainedEnforcer,
+	resourceName string,
+	models []cost.Enforcer,
+) (ChainedEnforcer, error) {
+	if len(models) == 0 {
+		return nil, errors.New("must provide at least one Enforcer instance for a chainedEnforcer")
+	}
+
+	p, ok := parent.(*chainedEnforcer)
+	if !ok {
+		return nil, fmt.Errorf("parent must be a chainedEnforcer, got %T", parent)
+	}
+
+	local := models[0]
+	return &chainedEnforcer{
+		resourceName: resourceName,
+		parent:       p,
+		local:        local,
+		models:       models[1:],
+		reporter:     upcastReporterOrNoop(local.Reporter()),
+	}, nil
+}
+
 func upcastReporterOrNoop(r cost.EnforcerReporter) ChainedReporter {
 	if r, ok := r.(ChainedReporter); ok {
 		return r
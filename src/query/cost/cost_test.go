@@ -60,6 +60,34 @@ func TestChainedEnforcer_Child(t *testing.T) {
 	})
 }
 
+func TestNewChildChainedEnforcer(t *testing.T) {
+	globalEnforcer := newTestEnforcer(cost.Limit{Threshold: 10.0, Enabled: true})
+	tenantEnforcer := newTestEnforcer(cost.Limit{Threshold: 5.0, Enabled: true})
+	queryEnforcer := newTestEnforcer(cost.Limit{Threshold: 3.0, Enabled: true})
+
+	global, err := NewChainedEnforcer(GlobalLevel, []cost.Enforcer{globalEnforcer})
+	require.NoError(t, err)
+
+	tenant, err := NewChildChainedEnforcer(global, TenantLevel,
+		[]cost.Enforcer{tenantEnforcer, queryEnforcer})
+	require.NoError(t, err)
+
+	query := tenant.Child(QueryLevel)
+	query.Add(2)
+
+	test.AssertCurrentCost(t, 2.0, query)
+	test.AssertCurrentCost(t, 2.0, tenant)
+	test.AssertCurrentCost(t, 2.0, globalEnforcer)
+
+	query.Close()
+	test.AssertCurrentCost(t, 0.0, tenant)
+	test.AssertCurrentCost(t, 0.0, globalEnforcer)
+
+	_, err = NewChildChainedEnforcer(NewMockChainedEnforcer(gomock.NewController(t)),
+		TenantLevel, []cost.Enforcer{tenantEnforcer})
+	require.Error(t, err)
+}
+
 func TestChainedEnforcer_Close(t *testing.T) {
 	t.Run("removes local total from global", func(t *testing.T) {
 		parentIface, err := NewChainedEnforcer(
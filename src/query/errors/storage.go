@@ -88,4 +88,9 @@ var (
 	// request returns an inconsistenent type.
 	ErrInconsistentCompleteTagsType = errors.New("inconsistent complete tags" +
 		" response type")
+
+	// ErrIndexQueryNotSupported is an error returned when a fetch query with
+	// an index query string is sent to a storage that does not support it.
+	ErrIndexQueryNotSupported = errors.New("index query strings are not supported" +
+		" by remote storage")
 )
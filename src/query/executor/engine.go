@@ -125,7 +125,20 @@ func (e *engine) ExecuteExpr(
 	fetchOpts *storage.FetchOptions,
 	params models.RequestParams,
 ) (Result, error) {
-	perQueryEnforcer := e.opts.GlobalEnforcer().Child(qcost.QueryLevel)
+	parentEnforcer := e.opts.GlobalEnforcer()
+	if tenants := e.opts.Tenants(); tenants != nil && fetchOpts.Tenant != "" {
+		t, err := tenants.Resolve(fetchOpts.Tenant)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			// Queries by a tenant roll up to the enforcer of the tenant,
+			// which in turn rolls up to the global enforcer.
+			parentEnforcer = t.Enforcer()
+		}
+	}
+
+	perQueryEnforcer := parentEnforcer.Child(qcost.QueryLevel)
 	defer perQueryEnforcer.Close()
 	req := newRequest(e, params, fetchOpts, e.opts.InstrumentOptions())
 	nodes, edges, err := req.compile(ctx, parser)
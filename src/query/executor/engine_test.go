@@ -27,12 +27,15 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/cost"
 	qcost "github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/tenant"
+	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/m3"
 	"github.com/m3db/m3/src/x/instrument"
 
@@ -95,3 +98,133 @@ func TestEngine_ExecuteExpr(t *testing.T) {
 
 	require.NoError(t, err)
 }
+
+func TestEngine_ExecuteExprWithTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The global enforcer is only used through the enforcer of the tenant.
+	mockParent := cost.NewMockChainedEnforcer(ctrl)
+
+	tenants, err := tenant.Configuration{}.NewTenants(tenant.NewOptions())
+	require.NoError(t, err)
+
+	parser, err := promql.Parse("foo", time.Second,
+		models.NewTagOptions(), promql.NewParseOptions())
+	require.NoError(t, err)
+
+	engineOpts := NewEngineOptions().
+		SetStore(mock.NewMockStorage()).
+		SetLookbackDuration(defaultLookbackDuration).
+		SetGlobalEnforcer(mockParent).
+		SetTenants(tenants).
+		SetInstrumentOptions(instrument.NewOptions())
+
+	fetchOpts := storage.NewFetchOptions()
+	fetchOpts.Tenant = "foo"
+
+	_, err = NewEngine(engineOpts).ExecuteExpr(context.TODO(), parser,
+		&QueryOptions{}, fetchOpts, models.RequestParams{
+			Start: time.Now().Add(-2 * time.Second),
+			End:   time.Now(),
+			Step:  time.Second,
+		})
+
+	require.NoError(t, err)
+}
+
+func TestEngine_ExecuteExprSubquery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// NB: x is a counter sampled every 15s, which increases by 1 per sample
+	// for the first 30 minutes and by 3 per sample afterwards, i.e. the
+	// series `x 0+1x120 123+3x120` in the Prometheus test language.
+	var (
+		base = time.Now().Truncate(24 * time.Hour).Add(-24 * time.Hour)
+		dps  = make([]test.Datapoint, 0, 242)
+	)
+	for i := 0; i <= 120; i++ {
+		dps = append(dps, test.Datapoint{
+			Value:  float64(i),
+			Offset: time.Duration(i) * 15 * time.Second,
+		})
+	}
+	for i := 0; i <= 120; i++ {
+		dps = append(dps, test.Datapoint{
+			Value:  float64(123 + 3*i),
+			Offset: time.Duration(121+i) * 15 * time.Second,
+		})
+	}
+
+	store, session := m3.NewStorageAndSession(t, ctrl)
+	session.EXPECT().IteratorPools().Return(nil, nil).AnyTimes()
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(interface{}, interface{}, interface{}) (
+			encoding.SeriesIterators, client.FetchResponseMetadata, error,
+		) {
+			iter, _, err := test.BuildCustomIterator([][]test.Datapoint{dps},
+				map[string]string{"__name__": "x"}, "x", m3.TestNamespaceID,
+				base, 2*time.Hour, time.Minute)
+			require.NoError(t, err)
+			return encoding.NewSeriesIterators(
+					[]encoding.SeriesIterator{iter}, nil),
+				client.FetchResponseMetadata{Exhaustive: true}, nil
+		})
+
+	parser, err := promql.Parse("avg_over_time(rate(x[5m])[30m:1m])",
+		2*time.Minute, models.NewTagOptions(), promql.NewParseOptions())
+	require.NoError(t, err)
+
+	var (
+		start = base.Add(40 * time.Minute)
+		end   = base.Add(time.Hour)
+	)
+
+	engine := newEngine(store, defaultLookbackDuration,
+		qcost.NoopChainedEnforcer(), instrument.NewOptions())
+	res, err := engine.ExecuteExpr(context.TODO(), parser,
+		&QueryOptions{}, storage.NewFetchOptions(), models.RequestParams{
+			Start:      start,
+			End:        end,
+			Step:       2 * time.Minute,
+			IncludeEnd: true,
+		})
+	require.NoError(t, err)
+
+	var actual []float64
+	for r := range res.ResultChan() {
+		require.NoError(t, r.Err)
+		iter, err := r.Block.StepIter()
+		require.NoError(t, err)
+		for iter.Next() {
+			step := iter.Current()
+			if step.Time().Before(start) {
+				continue
+			}
+
+			require.Equal(t, 1, len(step.Values()))
+			actual = append(actual, step.Values()[0])
+		}
+
+		require.NoError(t, iter.Err())
+		require.NoError(t, r.Block.Close())
+	}
+
+	// NB: expected values are the output of the same range query evaluated
+	// by the Prometheus engine.
+	expected := []float64{
+		0.10107526881720429,
+		0.10967741935483871,
+		0.1182795698924731,
+		0.12688172043010754,
+		0.13548387096774195,
+		0.14408602150537633,
+		0.15268817204301074,
+		0.16129032258064516,
+		0.1698924731182796,
+		0.17849462365591398,
+		0.1870967741935484,
+	}
+	test.EqualsWithNansWithDelta(t, expected, actual, 0.0001)
+}
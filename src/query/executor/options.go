@@ -26,12 +26,14 @@ import (
 	qcost "github.com/m3db/m3/src/query/cost"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/x/instrument"
 )
 
 type engineOptions struct {
 	instrumentOpts   instrument.Options
 	globalEnforcer   qcost.ChainedEnforcer
+	tenants          tenant.Tenants
 	store            storage.Storage
 	parseOptions     promql.ParseOptions
 	lookbackDuration time.Duration
@@ -64,6 +66,16 @@ func (o *engineOptions) SetGlobalEnforcer(v qcost.ChainedEnforcer) EngineOptions
 	return &opts
 }
 
+func (o *engineOptions) Tenants() tenant.Tenants {
+	return o.tenants
+}
+
+func (o *engineOptions) SetTenants(v tenant.Tenants) EngineOptions {
+	opts := *o
+	opts.tenants = v
+	return &opts
+}
+
 func (o *engineOptions) Store() storage.Storage {
 	return o.store
 }
@@ -161,6 +161,12 @@ func (s *ExecutionState) createNode(
 
 	transformNode, controller := CreateTransform(step.ID(),
 		transformParams, options)
+	parentOptions := options
+	if timeSpecOp, ok := step.Transform.Op.(transform.TimeSpecOp); ok {
+		parentOptions = options.WithTimeSpec(
+			timeSpecOp.ParentTimeSpec(options.TimeSpec()))
+	}
+
 	for _, parentID := range step.Parents {
 		parentStep, ok := s.plan.Step(parentID)
 		if !ok {
@@ -168,7 +174,7 @@ func (s *ExecutionState) createNode(
 				"%s, node: %s", parentID, step.ID())
 		}
 
-		parentController, err := s.createNode(parentStep, options)
+		parentController, err := s.createNode(parentStep, parentOptions)
 		if err != nil {
 			return nil, err
 		}
@@ -96,6 +96,12 @@ func (o Options) InstrumentOptions() instrument.Options {
 	return o.instrumentOptions
 }
 
+// WithTimeSpec returns a copy of the options with the given TimeSpec.
+func (o Options) WithTimeSpec(timeSpec TimeSpec) Options {
+	o.timeSpec = timeSpec
+	return o
+}
+
 // OpNode represents an execution node.
 type OpNode interface {
 	Process(
@@ -144,6 +150,16 @@ type MetaNode interface {
 	SeriesMeta(metas []block.SeriesMeta) []block.SeriesMeta
 }
 
+// TimeSpecOp is an operation which evaluates its parents over a different
+// time spec than its own, such as a subquery. The parent time spec accounts
+// for the range of the operation consuming it, so the range of that
+// consumer does not widen the time spec of the query.
+type TimeSpecOp interface {
+	// ParentTimeSpec returns the time spec to evaluate the parents of this
+	// operation with, given the time spec of the operation itself.
+	ParentTimeSpec(timeSpec TimeSpec) TimeSpec
+}
+
 // BoundOp is an operation that is able to yield boundary information.
 type BoundOp interface {
 	Bounds() BoundSpec
@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/parser/promql"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/tenant"
 	"github.com/m3db/m3/src/x/instrument"
 )
 
@@ -73,6 +74,11 @@ type EngineOptions interface {
 	// SetGlobalEnforcer sets the query cost enforcer.
 	SetGlobalEnforcer(qcost.ChainedEnforcer) EngineOptions
 
+	// Tenants returns the tenants whose query cost is enforced separately.
+	Tenants() tenant.Tenants
+	// SetTenants sets the tenants whose query cost is enforced separately.
+	SetTenants(tenant.Tenants) EngineOptions
+
 	// Store returns the storage.
 	Store() storage.Storage
 	// SetStore sets the storage.
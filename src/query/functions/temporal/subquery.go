@@ -0,0 +1,240 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package temporal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/ts"
+)
+
+const (
+	// SubqueryType evaluates its inner expression at the subquery step across
+	// the subquery range, presenting the results as raw datapoints to the
+	// temporal function consuming it.
+	SubqueryType = "subquery"
+)
+
+type subqueryOp struct {
+	rng    time.Duration
+	step   time.Duration
+	offset time.Duration
+}
+
+// NewSubqueryOp creates a new subquery operation, which evaluates its
+// parents every step across the given range.
+func NewSubqueryOp(
+	rng, step, offset time.Duration,
+) (transform.Params, error) {
+	if rng <= 0 {
+		return nil, fmt.Errorf("subquery range must be positive, received: %v",
+			rng)
+	}
+
+	if step <= 0 {
+		return nil, fmt.Errorf("subquery step must be positive, received: %v",
+			step)
+	}
+
+	if offset < 0 {
+		return nil, fmt.Errorf("offset must be positive, received: %v", offset)
+	}
+
+	return subqueryOp{
+		rng:    rng,
+		step:   step,
+		offset: offset,
+	}, nil
+}
+
+func (o subqueryOp) OpType() string {
+	return SubqueryType
+}
+
+func (o subqueryOp) String() string {
+	return fmt.Sprintf("type: %s, range: %v, step: %v, offset: %v",
+		o.OpType(), o.rng, o.step, o.offset)
+}
+
+// ParentTimeSpec returns the time spec the subquery expression is evaluated
+// over; this covers the subquery range before the start of the outer query,
+// with evaluation times aligned to multiples of the subquery step.
+func (o subqueryOp) ParentTimeSpec(
+	timeSpec transform.TimeSpec,
+) transform.TimeSpec {
+	var (
+		step  = int64(o.step)
+		from  = timeSpec.Start.Add(-1 * (o.rng + o.offset)).UnixNano()
+		until = timeSpec.End.Add(-1 * o.offset).UnixNano()
+		start = from - from%step
+	)
+
+	if start < from {
+		start += step
+	}
+
+	// NB: the end is exclusive, so extend it to cover the last step before the
+	// offset end of the outer query.
+	steps := (until - start + step - 1) / step
+	return transform.TimeSpec{
+		Start: time.Unix(0, start),
+		End:   time.Unix(0, start+steps*step),
+		Now:   timeSpec.Now,
+		Step:  o.step,
+	}
+}
+
+// Node creates an execution node.
+func (o subqueryOp) Node(
+	controller *transform.Controller,
+	opts transform.Options,
+) transform.OpNode {
+	return &subqueryNode{
+		op:         o,
+		controller: controller,
+		bounds:     opts.TimeSpec().Bounds(),
+	}
+}
+
+type subqueryNode struct {
+	op         subqueryOp
+	controller controller
+	bounds     models.Bounds
+}
+
+// Process converts the block evaluated at the subquery step to a block of
+// raw datapoints spanning the bounds of the outer query.
+func (n *subqueryNode) Process(
+	queryCtx *models.QueryContext,
+	_ parser.NodeID,
+	b block.Block,
+) error {
+	iter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	var (
+		seriesMetas = iter.SeriesMeta()
+		datapoints  = make([]ts.Datapoints, len(seriesMetas))
+	)
+
+	for iter.Next() {
+		step := iter.Current()
+		t := step.Time().Add(n.op.offset)
+		for i, v := range step.Values() {
+			if math.IsNaN(v) {
+				continue
+			}
+
+			datapoints[i] = append(datapoints[i], ts.Datapoint{
+				Timestamp: t,
+				Value:     v,
+			})
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	meta := b.Meta()
+	meta.Bounds = n.bounds
+	if err := b.Close(); err != nil {
+		return err
+	}
+
+	bl := &subqueryBlock{
+		meta:       meta,
+		seriesMeta: seriesMetas,
+		datapoints: datapoints,
+	}
+
+	return n.controller.Process(queryCtx, bl)
+}
+
+// subqueryBlock is a block of raw datapoints produced by a subquery.
+type subqueryBlock struct {
+	meta       block.Metadata
+	seriesMeta []block.SeriesMeta
+	datapoints []ts.Datapoints
+}
+
+func (b *subqueryBlock) StepIter() (block.StepIter, error) {
+	return nil, errors.New("step iterator undefined for a subquery block")
+}
+
+func (b *subqueryBlock) SeriesIter() (block.SeriesIter, error) {
+	return &subquerySeriesIter{block: b, idx: -1}, nil
+}
+
+func (b *subqueryBlock) MultiSeriesIter(
+	_ int,
+) ([]block.SeriesIterBatch, error) {
+	return nil, errors.New("multi series iterator undefined for a subquery block")
+}
+
+func (b *subqueryBlock) Meta() block.Metadata {
+	return b.meta
+}
+
+func (b *subqueryBlock) Info() block.BlockInfo {
+	return block.NewBlockInfo(block.BlockDecompressed)
+}
+
+func (b *subqueryBlock) Close() error {
+	return nil
+}
+
+type subquerySeriesIter struct {
+	block *subqueryBlock
+	idx   int
+}
+
+func (it *subquerySeriesIter) SeriesMeta() []block.SeriesMeta {
+	return it.block.seriesMeta
+}
+
+func (it *subquerySeriesIter) SeriesCount() int {
+	return len(it.block.seriesMeta)
+}
+
+func (it *subquerySeriesIter) Next() bool {
+	it.idx++
+	return it.idx < it.SeriesCount()
+}
+
+func (it *subquerySeriesIter) Current() block.UnconsolidatedSeries {
+	return block.NewUnconsolidatedSeries(it.block.datapoints[it.idx],
+		it.block.seriesMeta[it.idx], block.UnconsolidatedSeriesStats{})
+}
+
+func (it *subquerySeriesIter) Err() error {
+	return nil
+}
+
+func (it *subquerySeriesIter) Close() {}
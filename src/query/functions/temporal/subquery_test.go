@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package temporal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+	"github.com/m3db/m3/src/query/test/transformtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubqueryParentTimeSpec(t *testing.T) {
+	var (
+		start = time.Unix(7230, 0)
+		now   = time.Unix(9000, 0)
+	)
+
+	op, err := NewSubqueryOp(time.Hour, time.Minute, 5*time.Minute)
+	require.NoError(t, err)
+
+	timeSpecOp, ok := op.(transform.TimeSpecOp)
+	require.True(t, ok)
+
+	spec := timeSpecOp.ParentTimeSpec(transform.TimeSpec{
+		Start: start,
+		End:   start.Add(10*time.Minute + 45*time.Second),
+		Now:   now,
+		Step:  15 * time.Second,
+	})
+
+	// NB: start is aligned up to the next multiple of the subquery step after
+	// (start - range - offset), and the end is extended to cover the last step.
+	assert.Equal(t, time.Unix(3360, 0), spec.Start)
+	assert.Equal(t, time.Unix(7620, 0), spec.End)
+	assert.Equal(t, now, spec.Now)
+	assert.Equal(t, time.Minute, spec.Step)
+}
+
+func TestSubqueryInvalidParams(t *testing.T) {
+	_, err := NewSubqueryOp(0, time.Minute, 0)
+	assert.Error(t, err)
+
+	_, err = NewSubqueryOp(time.Hour, 0, 0)
+	assert.Error(t, err)
+
+	_, err = NewSubqueryOp(time.Hour, time.Minute, -time.Minute)
+	assert.Error(t, err)
+}
+
+func TestSubqueryProcess(t *testing.T) {
+	var (
+		start     = time.Unix(3600, 0)
+		outerSpec = transform.TimeSpec{
+			Start: start,
+			End:   start.Add(3 * time.Minute),
+			Step:  time.Minute,
+		}
+		seriesMetas = []block.SeriesMeta{{
+			Name: []byte("s1"),
+			Tags: models.EmptyTags().AddTags([]models.Tag{{
+				Name:  []byte("t1"),
+				Value: []byte("v1"),
+			}}),
+		}}
+		opts = transformtest.Options(t, transform.OptionsParams{
+			TimeSpec: outerSpec,
+		})
+	)
+
+	op, err := NewSubqueryOp(2*time.Minute, time.Minute, 0)
+	require.NoError(t, err)
+
+	innerSpec := op.(transform.TimeSpecOp).ParentTimeSpec(outerSpec)
+	require.Equal(t, start.Add(-2*time.Minute), innerSpec.Start)
+	require.Equal(t, 5, innerSpec.Bounds().Steps())
+
+	inner := test.NewBlockFromValuesWithSeriesMeta(innerSpec.Bounds(),
+		seriesMetas, [][]float64{{1, 5, 3, nan, 2}})
+
+	aggOp, err := NewAggOp([]interface{}{2 * time.Minute}, MaxType)
+	require.NoError(t, err)
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID("2"))
+	subqueryController := &transform.Controller{ID: parser.NodeID("1")}
+	subqueryController.AddTransform(aggOp.Node(c, opts))
+
+	node := op.Node(subqueryController, opts)
+	err = node.Process(models.NoopQueryContext(), parser.NodeID("0"), inner)
+	require.NoError(t, err)
+
+	require.Len(t, sink.Values, 1)
+	test.EqualsWithNansWithDelta(t, [][]float64{{5, 5, 3}}, sink.Values, 0.0001)
+	assert.Equal(t, outerSpec.Bounds(), sink.Meta.Bounds)
+}
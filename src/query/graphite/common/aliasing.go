@@ -27,6 +27,7 @@ import (
 	"strings"
 
 	"github.com/m3db/m3/src/query/graphite/errors"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/graphite/ts"
 )
 
@@ -90,6 +91,58 @@ func AliasByNode(_ *Context, seriesList ts.SeriesList, nodes ...int) (ts.SeriesL
 	return seriesList, nil
 }
 
+// AliasByTags renames a time series result according to a mix of nodes in
+// its hierarchy and tags of a tagged series; integer arguments select nodes
+// of the metric name, string arguments select tag values.
+func AliasByTags(_ *Context, seriesList ts.SeriesList, nodesOrTags ...interface{}) (ts.SeriesList, error) {
+	renamed := make([]*ts.Series, 0, seriesList.Len())
+	for _, series := range seriesList.Values {
+		var (
+			tags         = graphite.ParseTaggedSeriesName(series.Name())
+			nameParts    = strings.Split(tags[graphite.TaggedNameTag], ".")
+			newNameParts = make([]string, 0, len(nodesOrTags))
+		)
+
+		for _, nodeOrTag := range nodesOrTags {
+			var part string
+			switch v := nodeOrTag.(type) {
+			case string:
+				part = tags[v]
+			case int, float64:
+				node := toNode(v)
+				if node < 0 {
+					node += len(nameParts)
+				}
+				if node < 0 || node >= len(nameParts) {
+					continue
+				}
+				part = nameParts[node]
+			default:
+				err := errors.NewInvalidParamsError(fmt.Errorf(
+					"aliasByTags expects nodes or tags, received %v", nodeOrTag))
+				return ts.NewSeriesList(), err
+			}
+
+			if part != "" {
+				newNameParts = append(newNameParts, part)
+			}
+		}
+
+		newName := strings.Join(newNameParts, ".")
+		renamed = append(renamed, series.RenamedTo(newName))
+	}
+	seriesList.Values = renamed
+	return seriesList, nil
+}
+
+func toNode(v interface{}) int {
+	if f, ok := v.(float64); ok {
+		return int(f)
+	}
+
+	return v.(int)
+}
+
 // AliasSub runs series names through a regex search/replace.
 func AliasSub(_ *Context, input ts.SeriesList, search, replace string) (ts.SeriesList, error) {
 	regex, err := regexp.Compile(search)
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/graphite/context"
+	"github.com/m3db/m3/src/query/tenant"
 )
 
 // contextBase are the real content of a Context, minus the lock so that we
@@ -57,6 +58,10 @@ type contextBase struct {
 	// Limit provides a cap on the number of results returned from the database.
 	Limit int
 
+	// Tenant restricts fetches to the series of the tenant, nil if fetches
+	// are not restricted to a tenant.
+	Tenant tenant.Tenant
+
 	parent         *Context
 	reqCtx         ctx.Context
 	storageContext context.Context
@@ -75,6 +80,7 @@ type ContextOptions struct {
 	Engine  QueryEngine
 	Timeout time.Duration
 	Limit   int
+	Tenant  tenant.Tenant
 }
 
 // TimeRangeAdjustment is an applied time range adjustment.
@@ -97,6 +103,7 @@ func NewContext(options ContextOptions) *Context {
 			storageContext: context.New(),
 			Timeout:        options.Timeout,
 			Limit:          options.Limit,
+			Tenant:         options.Tenant,
 		},
 	}
 }
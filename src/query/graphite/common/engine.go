@@ -32,6 +32,12 @@ type QueryEngine interface {
 		query string,
 		options storage.FetchOptions,
 	) (*storage.FetchResult, error)
+
+	FetchByTags(
+		ctx context.Context,
+		exprs []string,
+		options storage.FetchOptions,
+	) (*storage.FetchResult, error)
 }
 
 // The Engine for running queries
@@ -54,3 +60,12 @@ func (e *Engine) FetchByQuery(
 ) (*storage.FetchResult, error) {
 	return e.storage.FetchByQuery(ctx, query, options)
 }
+
+// FetchByTags retrieves one or more time series matching tag expressions
+func (e *Engine) FetchByTags(
+	ctx context.Context,
+	exprs []string,
+	options storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	return e.storage.FetchByTags(ctx, exprs, options)
+}
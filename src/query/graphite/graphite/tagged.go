@@ -0,0 +1,145 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/m3db/m3/src/query/graphite/errors"
+)
+
+const (
+	// TaggedNameTag is the tag which holds the metric name of a graphite
+	// tagged series, i.e. `name` in `seriesByTag('name=foo.bar')`.
+	TaggedNameTag = "name"
+
+	taggedSeparator = ";"
+)
+
+// TagExpressionOperator is the operator of a seriesByTag tag expression.
+type TagExpressionOperator int
+
+const (
+	// TagEqual matches series where the tag is exactly the given value.
+	TagEqual TagExpressionOperator = iota
+	// TagNotEqual matches series where the tag is not the given value.
+	TagNotEqual
+	// TagMatch matches series where the tag value matches the given regex
+	// from its start.
+	TagMatch
+	// TagNotMatch matches series where the tag value does not match the
+	// given regex from its start.
+	TagNotMatch
+)
+
+// TagExpression is a parsed seriesByTag tag expression, e.g. `dc=~us-.*`.
+type TagExpression struct {
+	Tag      string
+	Operator TagExpressionOperator
+	Value    string
+}
+
+// ParseTagExpression parses a seriesByTag tag expression of the form
+// `tag=value`, `tag!=value`, `tag=~regex` or `tag!=~regex`.
+func ParseTagExpression(expr string) (TagExpression, error) {
+	idx := strings.Index(expr, "=")
+	if idx < 0 {
+		return TagExpression{}, errors.NewInvalidParamsError(
+			fmt.Errorf("invalid tag expression %q: missing operator", expr))
+	}
+
+	var (
+		tag   = expr[:idx]
+		value = expr[idx+1:]
+		op    = TagEqual
+	)
+
+	if strings.HasSuffix(tag, "!") {
+		tag = tag[:len(tag)-1]
+		op = TagNotEqual
+	}
+
+	if strings.HasPrefix(value, "~") {
+		value = value[1:]
+		if op == TagEqual {
+			op = TagMatch
+		} else {
+			op = TagNotMatch
+		}
+	}
+
+	tag = strings.TrimSpace(tag)
+	if len(tag) == 0 {
+		return TagExpression{}, errors.NewInvalidParamsError(
+			fmt.Errorf("invalid tag expression %q: missing tag", expr))
+	}
+
+	return TagExpression{
+		Tag:      tag,
+		Operator: op,
+		Value:    strings.TrimSpace(value),
+	}, nil
+}
+
+// TaggedSeriesName returns the graphite name for a tagged series, of the form
+// `name;tag1=value1;tag2=value2` with tags sorted by tag name.
+func TaggedSeriesName(name string, tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		if k == TaggedNameTag {
+			continue
+		}
+
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		b.WriteString(taggedSeparator)
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(tags[k])
+	}
+
+	return b.String()
+}
+
+// ParseTaggedSeriesName splits a graphite tagged series name into its tags;
+// the metric name is returned under the `name` tag. Untagged series names are
+// returned as just a `name` tag.
+func ParseTaggedSeriesName(seriesName string) map[string]string {
+	parts := strings.Split(seriesName, taggedSeparator)
+	tags := make(map[string]string, len(parts))
+	tags[TaggedNameTag] = parts[0]
+	for _, part := range parts[1:] {
+		idx := strings.Index(part, "=")
+		if idx <= 0 {
+			continue
+		}
+
+		tags[part[:idx]] = part[idx+1:]
+	}
+
+	return tags
+}
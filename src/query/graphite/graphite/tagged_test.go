@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTagExpression(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected TagExpression
+	}{
+		{"name=foo.bar", TagExpression{Tag: "name", Operator: TagEqual, Value: "foo.bar"}},
+		{"dc!=us-east", TagExpression{Tag: "dc", Operator: TagNotEqual, Value: "us-east"}},
+		{"dc=~us-.*", TagExpression{Tag: "dc", Operator: TagMatch, Value: "us-.*"}},
+		{"dc!=~eu", TagExpression{Tag: "dc", Operator: TagNotMatch, Value: "eu"}},
+		{"dc=", TagExpression{Tag: "dc", Operator: TagEqual, Value: ""}},
+		{"a=b=c", TagExpression{Tag: "a", Operator: TagEqual, Value: "b=c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			actual, err := ParseTagExpression(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+
+	for _, expr := range []string{"", "dc", "=foo", "!=foo"} {
+		_, err := ParseTagExpression(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestTaggedSeriesName(t *testing.T) {
+	name := TaggedSeriesName("foo.bar", map[string]string{
+		"name": "ignored",
+		"dc":   "us-east",
+		"az":   "a",
+	})
+
+	assert.Equal(t, "foo.bar;az=a;dc=us-east", name)
+	assert.Equal(t, map[string]string{
+		"name": "foo.bar",
+		"az":   "a",
+		"dc":   "us-east",
+	}, ParseTaggedSeriesName(name))
+	assert.Equal(t, map[string]string{"name": "foo.bar"},
+		ParseTaggedSeriesName("foo.bar"))
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/query/graphite/common"
 	"github.com/m3db/m3/src/query/graphite/errors"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/graphite/ts"
 )
 
@@ -247,6 +248,60 @@ func groupByNode(ctx *common.Context, series singlePathSpec, node int, fname str
 		metaSeries[key] = append(metaSeries[key], s)
 	}
 
+	return applyFnToMetaSeries(ctx, series, metaSeries, fname)
+}
+
+// groupByTags takes a serieslist and maps a callback to subgroups within as
+// defined by a common set of tags
+//
+//    &target=groupByTags(seriesByTag("name=cpu","dc=dc1"),"sum","dc")
+//
+//  Would return multiple series which are each the result of applying the
+//  "sum" function to groups joined on the "dc" tag, named as tagged series
+//  such as sum;dc=dc1. If "name" is one of the given tags the groups are
+//  named after the metric name rather than the callback.
+func groupByTags(ctx *common.Context, series singlePathSpec, fname string, tags ...string) (ts.SeriesList, error) {
+	var (
+		metaSeries  = make(map[string][]*ts.Series)
+		includeName = false
+	)
+
+	for _, tag := range tags {
+		if tag == graphite.TaggedNameTag {
+			includeName = true
+		}
+	}
+
+	for _, s := range series.Values {
+		var (
+			seriesTags = graphite.ParseTaggedSeriesName(s.Name())
+			groupTags  = make(map[string]string, len(tags))
+			name       = fname
+		)
+
+		if includeName {
+			name = seriesTags[graphite.TaggedNameTag]
+		}
+
+		for _, tag := range tags {
+			groupTags[tag] = seriesTags[tag]
+		}
+
+		key := graphite.TaggedSeriesName(name, groupTags)
+		metaSeries[key] = append(metaSeries[key], s)
+	}
+
+	return applyFnToMetaSeries(ctx, series, metaSeries, fname)
+}
+
+// applyFnToMetaSeries combines each group of series using the summarize
+// function with the given name, naming each resulting series by its key.
+func applyFnToMetaSeries(
+	ctx *common.Context,
+	series singlePathSpec,
+	metaSeries map[string][]*ts.Series,
+	fname string,
+) (ts.SeriesList, error) {
 	if fname == "" {
 		fname = "sum"
 	}
@@ -140,6 +140,11 @@ type mockEngine struct {
 		query string,
 		options storage.FetchOptions,
 	) (*storage.FetchResult, error)
+	tagsFn func(
+		ctx context.Context,
+		exprs []string,
+		options storage.FetchOptions,
+	) (*storage.FetchResult, error)
 }
 
 func (e mockEngine) FetchByQuery(
@@ -150,6 +155,14 @@ func (e mockEngine) FetchByQuery(
 	return e.fn(ctx, query, opts)
 }
 
+func (e mockEngine) FetchByTags(
+	ctx context.Context,
+	exprs []string,
+	opts storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	return e.tagsFn(ctx, exprs, opts)
+}
+
 func TestVariadicSumSeries(t *testing.T) {
 	expr, err := compile("sumSeries(foo.bar.*, foo.baz.*)")
 	require.NoError(t, err)
@@ -401,6 +414,70 @@ func TestGroupByNode(t *testing.T) {
 	}
 }
 
+func TestGroupByTags(t *testing.T) {
+	var (
+		start, _ = time.Parse(time.RFC1123, "Mon, 27 Jul 2015 19:41:19 GMT")
+		end, _   = time.Parse(time.RFC1123, "Mon, 27 Jul 2015 19:43:19 GMT")
+		ctx      = common.NewContext(common.ContextOptions{Start: start, End: end})
+		inputs   = []*ts.Series{
+			ts.NewSeries(ctx, "requests;dc=us-east;host=a", start,
+				ts.NewConstantValues(ctx, 2, 12, 10000)),
+			ts.NewSeries(ctx, "requests;dc=us-east;host=b", start,
+				ts.NewConstantValues(ctx, 4, 12, 10000)),
+			ts.NewSeries(ctx, "requests;dc=us-west;host=c", start,
+				ts.NewConstantValues(ctx, 6, 12, 10000)),
+			ts.NewSeries(ctx, "errors;dc=us-west;host=c", start,
+				ts.NewConstantValues(ctx, 8, 12, 10000)),
+		}
+	)
+	defer ctx.Close()
+
+	type result struct {
+		name      string
+		sumOfVals float64
+	}
+
+	tests := []struct {
+		fname           string
+		tags            []string
+		expectedResults []result
+	}{
+		{"sum", []string{"dc"}, []result{
+			{"sum;dc=us-east", (2 + 4) * 12},
+			{"sum;dc=us-west", (6 + 8) * 12},
+		}},
+		{"max", []string{"name", "dc"}, []result{
+			{"errors;dc=us-west", 8 * 12},
+			{"requests;dc=us-east", 4 * 12},
+			{"requests;dc=us-west", 6 * 12},
+		}},
+		{"avg", []string{"rack"}, []result{
+			{"avg;rack=", 5 * 12},
+		}},
+	}
+
+	for _, test := range tests {
+		outSeries, err := groupByTags(ctx, singlePathSpec{
+			Values: inputs,
+		}, test.fname, test.tags...)
+		require.NoError(t, err)
+		require.Equal(t, len(test.expectedResults), len(outSeries.Values))
+
+		outSeries, _ = sortByName(ctx, singlePathSpec(outSeries))
+
+		for i, expected := range test.expectedResults {
+			series := outSeries.Values[i]
+			assert.Equal(t, expected.name, series.Name(),
+				"wrong name for %v %s (%d)", test.tags, test.fname, i)
+			assert.Equal(t, expected.sumOfVals, series.SafeSum(),
+				"wrong result for %v %s (%d)", test.tags, test.fname, i)
+		}
+	}
+
+	_, err := groupByTags(ctx, singlePathSpec{Values: inputs}, "unknown", "dc")
+	require.Error(t, err)
+}
+
 func TestWeightedAverage(t *testing.T) {
 	ctx, _ := newConsolidationTestSeries()
 	defer ctx.Close()
@@ -43,6 +43,17 @@ func aliasByNode(ctx *common.Context, seriesList singlePathSpec, nodes ...int) (
 	return common.AliasByNode(ctx, ts.SeriesList(seriesList), nodes...)
 }
 
+// aliasByTags renames a time series result according to a mix of nodes in
+// its hierarchy and tags of a tagged series.
+func aliasByTags(ctx *common.Context, seriesList singlePathSpec, nodesOrTags ...genericInterface) (ts.SeriesList, error) {
+	args := make([]interface{}, 0, len(nodesOrTags))
+	for _, nodeOrTag := range nodesOrTags {
+		args = append(args, nodeOrTag)
+	}
+
+	return common.AliasByTags(ctx, ts.SeriesList(seriesList), args...)
+}
+
 // aliasSub runs series names through a regex search/replace.
 func aliasSub(ctx *common.Context, input singlePathSpec, search, replace string) (ts.SeriesList, error) {
 	return common.AliasSub(ctx, ts.SeriesList(input), search, replace)
@@ -194,6 +194,43 @@ func TestAliasByNode(t *testing.T) {
 	assert.Equal(t, "P75", results.Values[2].Name())
 }
 
+func TestAliasByTags(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer ctx.Close()
+
+	now := time.Now()
+	values := ts.NewConstantValues(ctx, 10.0, 1000, 10)
+	series := []*ts.Series{
+		ts.NewSeries(ctx, "cpu.load;dc=us-east;host=a", now, values),
+		ts.NewSeries(ctx, "cpu.load;host=b", now, values),
+	}
+
+	results, err := aliasByTags(ctx, singlePathSpec{
+		Values: series,
+	}, "dc", "host")
+	require.NoError(t, err)
+	require.Equal(t, len(series), results.Len())
+	assert.Equal(t, "us-east.a", results.Values[0].Name())
+	assert.Equal(t, "b", results.Values[1].Name())
+
+	results, err = aliasByTags(ctx, singlePathSpec{
+		Values: series,
+	}, "name", "host", float64(-1))
+	require.NoError(t, err)
+	assert.Equal(t, "cpu.load.a.load", results.Values[0].Name())
+	assert.Equal(t, "cpu.load.b.load", results.Values[1].Name())
+}
+
+func TestAliasByTagsCompiled(t *testing.T) {
+	expr, err := compile("aliasByTags(foo.bar, 1, 'host')")
+	require.NoError(t, err)
+
+	call := expr.(*funcExpression).call
+	require.Len(t, call.in, 3)
+	assert.Equal(t, "1", call.in[1].String())
+	assert.Equal(t, "host", call.in[2].String())
+}
+
 func TestAliasByNodeWithComposition(t *testing.T) {
 	ctx := common.NewTestContext()
 	defer ctx.Close()
@@ -32,6 +32,7 @@ import (
 
 	"github.com/m3db/m3/src/query/graphite/common"
 	"github.com/m3db/m3/src/query/graphite/errors"
+	"github.com/m3db/m3/src/query/graphite/storage"
 	"github.com/m3db/m3/src/query/graphite/ts"
 )
 
@@ -185,6 +186,40 @@ func identity(ctx *common.Context, name string) (ts.SeriesList, error) {
 	return common.Identity(ctx, name)
 }
 
+// seriesByTag returns the tagged series matching all of the given tag
+// expressions, e.g. seriesByTag('name=cpu.load', 'dc=~us-.*').
+func seriesByTag(ctx *common.Context, tagExpressions ...string) (ts.SeriesList, error) {
+	opts := storage.FetchOptions{
+		StartTime: ctx.StartTime,
+		EndTime:   ctx.EndTime,
+		DataOptions: storage.DataOptions{
+			Timeout: ctx.Timeout,
+			Limit:   ctx.Limit,
+			Tenant:  ctx.Tenant,
+		},
+	}
+
+	result, err := ctx.Engine.FetchByTags(ctx, tagExpressions, opts)
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+
+	quoted := make([]string, 0, len(tagExpressions))
+	for _, expr := range tagExpressions {
+		quoted = append(quoted, fmt.Sprintf("'%s'", expr))
+	}
+
+	spec := fmt.Sprintf("seriesByTag(%s)", strings.Join(quoted, ","))
+	for _, r := range result.SeriesList {
+		r.Specification = spec
+	}
+
+	return ts.SeriesList{
+		Values:   result.SeriesList,
+		Metadata: result.Metadata,
+	}, nil
+}
+
 // limit takes one metric or a wildcard seriesList followed by an integer N, and draws
 // the first N metrics.
 func limit(_ *common.Context, series singlePathSpec, n int) (ts.SeriesList, error) {
@@ -1835,6 +1870,7 @@ func init() {
 	MustRegisterFunction(alias)
 	MustRegisterFunction(aliasByMetric)
 	MustRegisterFunction(aliasByNode)
+	MustRegisterFunction(aliasByTags)
 	MustRegisterFunction(aliasSub)
 	MustRegisterFunction(asPercent).WithDefaultParams(map[uint8]interface{}{
 		2: []*ts.Series(nil), // total
@@ -1859,6 +1895,7 @@ func init() {
 	MustRegisterFunction(fallbackSeries)
 	MustRegisterFunction(group)
 	MustRegisterFunction(groupByNode)
+	MustRegisterFunction(groupByTags)
 	MustRegisterFunction(highestAverage)
 	MustRegisterFunction(highestCurrent)
 	MustRegisterFunction(highestMax)
@@ -1910,6 +1947,7 @@ func init() {
 	MustRegisterFunction(removeEmptySeries)
 	MustRegisterFunction(scale)
 	MustRegisterFunction(scaleToSeconds)
+	MustRegisterFunction(seriesByTag)
 	MustRegisterFunction(sortByMaxima)
 	MustRegisterFunction(sortByName)
 	MustRegisterFunction(sortByTotal)
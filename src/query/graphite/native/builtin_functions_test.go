@@ -1961,6 +1961,42 @@ func (*mockStorage) FetchByQuery(
 	return storage.NewFetchResult(ctx, nil, block.NewResultMetadata()), nil
 }
 
+func (*mockStorage) FetchByTags(
+	ctx xctx.Context, exprs []string, opts storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	return storage.NewFetchResult(ctx, nil, block.NewResultMetadata()), nil
+}
+
+func TestSeriesByTag(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer ctx.Close()
+
+	var fetched []string
+	ctx.Engine = mockEngine{tagsFn: func(
+		ctx xctx.Context,
+		exprs []string,
+		options storage.FetchOptions,
+	) (*storage.FetchResult, error) {
+		fetched = exprs
+		start := options.StartTime
+		return storage.NewFetchResult(ctx, []*ts.Series{
+			ts.NewSeries(ctx, "cpu;dc=us-east", start, ts.NewConstantValues(ctx, 1, 3, 1000)),
+			ts.NewSeries(ctx, "cpu;dc=us-west", start, ts.NewConstantValues(ctx, 2, 3, 1000)),
+		}, block.NewResultMetadata()), nil
+	}}
+
+	expr, err := compile("aliasByTags(seriesByTag('name=cpu', 'dc=~us-.*'), 'dc')")
+	require.NoError(t, err)
+
+	res, err := expr.Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name=cpu", "dc=~us-.*"}, fetched)
+	require.Len(t, res.Values, 2)
+	assert.Equal(t, "us-east", res.Values[0].Name())
+	assert.Equal(t, "us-west", res.Values[1].Name())
+	assert.Equal(t, "seriesByTag('name=cpu','dc=~us-.*')", res.Values[0].Specification)
+}
+
 func TestHoltWintersForecast(t *testing.T) {
 	ctx := common.NewTestContext()
 	ctx.Engine = NewEngine(
@@ -2783,6 +2819,7 @@ func TestFunctionsRegistered(t *testing.T) {
 		"alias",
 		"aliasByMetric",
 		"aliasByNode",
+		"aliasByTags",
 		"aliasSub",
 		"asPercent",
 		"averageAbove",
@@ -2804,6 +2841,7 @@ func TestFunctionsRegistered(t *testing.T) {
 		"fallbackSeries",
 		"group",
 		"groupByNode",
+		"groupByTags",
 		"highestAverage",
 		"highestCurrent",
 		"highestMax",
@@ -2846,6 +2884,7 @@ func TestFunctionsRegistered(t *testing.T) {
 		"removeEmptySeries",
 		"scale",
 		"scaleToSeconds",
+		"seriesByTag",
 		"sortByMaxima",
 		"sortByName",
 		"sortByTotal",
@@ -48,6 +48,15 @@ func (e *Engine) FetchByQuery(
 	return e.storage.FetchByQuery(ctx, query, options)
 }
 
+// FetchByTags retrieves one or more time series matching tag expressions.
+func (e *Engine) FetchByTags(
+	ctx context.Context,
+	exprs []string,
+	options storage.FetchOptions,
+) (*storage.FetchResult, error) {
+	return e.storage.FetchByTags(ctx, exprs, options)
+}
+
 // Compile compiles an expression from an expression string
 func (e *Engine) Compile(s string) (Expression, error) {
 	return compile(s)
@@ -93,6 +93,7 @@ func (f *fetchExpression) Execute(ctx *common.Context) (ts.SeriesList, error) {
 		DataOptions: storage.DataOptions{
 			Timeout: ctx.Timeout,
 			Limit:   ctx.Limit,
+			Tenant:  ctx.Tenant,
 		},
 	}
 
@@ -21,10 +21,12 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/m3db/m3/src/query/block"
@@ -42,11 +44,14 @@ import (
 
 var (
 	errSeriesNoResolution = errors.New("series has no resolution set")
+	errNoPositiveTagMatch = errors.New("seriesByTag requires at least one " +
+		"expression matching a non-empty tag value")
 )
 
 type m3WrappedStore struct {
 	m3             storage.Storage
 	enforcer       cost.ChainedEnforcer
+	tagOptions     models.TagOptions
 	instrumentOpts instrument.Options
 }
 
@@ -55,15 +60,21 @@ type m3WrappedStore struct {
 func NewM3WrappedStorage(
 	m3storage storage.Storage,
 	enforcer cost.ChainedEnforcer,
+	tagOptions models.TagOptions,
 	instrumentOpts instrument.Options,
 ) Storage {
 	if enforcer == nil {
 		enforcer = cost.NoopChainedEnforcer()
 	}
 
+	if tagOptions == nil {
+		tagOptions = models.NewTagOptions()
+	}
+
 	return &m3WrappedStore{
 		m3:             m3storage,
 		enforcer:       enforcer,
+		tagOptions:     tagOptions,
 		instrumentOpts: instrumentOpts,
 	}
 }
@@ -104,6 +115,79 @@ func GetQueryTerminatorTagName(query string) []byte {
 	return graphite.TagName(metricLength)
 }
 
+// TranslateTagExpressionsToMatchers converts seriesByTag tag expressions to
+// tag matchers, mapping the graphite `name` tag to the metric name tag.
+func TranslateTagExpressionsToMatchers(
+	exprs []string,
+	tagOptions models.TagOptions,
+) (models.Matchers, error) {
+	var (
+		matchers    = make(models.Matchers, 0, len(exprs))
+		hasPositive = false
+	)
+
+	for _, expr := range exprs {
+		parsed, err := graphite.ParseTagExpression(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		name := []byte(parsed.Tag)
+		if parsed.Tag == graphite.TaggedNameTag {
+			name = tagOptions.MetricName()
+		}
+
+		var (
+			value     = []byte(parsed.Value)
+			matchType models.MatchType
+		)
+
+		switch parsed.Operator {
+		case graphite.TagEqual:
+			matchType = models.MatchEqual
+			if len(value) == 0 {
+				// NB: an empty value matches series without the tag.
+				matchType = models.MatchNotField
+			}
+		case graphite.TagNotEqual:
+			matchType = models.MatchNotEqual
+			if len(value) == 0 {
+				matchType = models.MatchField
+			}
+		case graphite.TagMatch:
+			matchType = models.MatchRegexp
+			value = prefixRegexp(parsed.Value)
+		case graphite.TagNotMatch:
+			matchType = models.MatchNotRegexp
+			value = prefixRegexp(parsed.Value)
+		}
+
+		if (matchType == models.MatchEqual) ||
+			(matchType == models.MatchRegexp && len(parsed.Value) > 0) {
+			hasPositive = true
+		}
+
+		m, err := models.NewMatcher(matchType, name, value)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, m)
+	}
+
+	if !hasPositive {
+		return nil, errNoPositiveTagMatch
+	}
+
+	return matchers, nil
+}
+
+// prefixRegexp converts a graphite tag regexp, which matches from the start
+// of the value, to a fully anchored regexp as used by tag matchers.
+func prefixRegexp(re string) []byte {
+	return []byte("(?:" + re + ").*")
+}
+
 func translateQuery(query string, opts FetchOptions) (*storage.FetchQuery, error) {
 	matchers, err := TranslateQueryToMatchersWithTerminator(query)
 	if err != nil {
@@ -143,6 +227,7 @@ func translateTimeseries(
 	ctx xctx.Context,
 	result block.Result,
 	start, end time.Time,
+	seriesName func(meta block.SeriesMeta) string,
 ) ([]*ts.Series, error) {
 	if len(result.Blocks) == 0 {
 		return []*ts.Series{}, nil
@@ -193,7 +278,7 @@ func translateTimeseries(
 			values.SetValueAt(index, datapoint.Value)
 		}
 
-		name := string(seriesMetas[idx].Name)
+		name := seriesName(seriesMetas[idx])
 		series = append(series, ts.NewSeries(ctx, name, start, values))
 	}
 
@@ -204,6 +289,32 @@ func translateTimeseries(
 	return series, nil
 }
 
+// pathSeriesName names a series fetched by a graphite path by its ID.
+func pathSeriesName(meta block.SeriesMeta) string {
+	return string(meta.Name)
+}
+
+// taggedSeriesName names a series fetched by tags in the graphite tagged
+// series format, i.e. `name;tag1=value1;tag2=value2`.
+func (s *m3WrappedStore) taggedSeriesName(meta block.SeriesMeta) string {
+	var (
+		metricName = s.tagOptions.MetricName()
+		name       string
+		tags       = make(map[string]string, meta.Tags.Len())
+	)
+
+	for _, tag := range meta.Tags.Tags {
+		if bytes.Equal(tag.Name, metricName) {
+			name = string(tag.Value)
+			continue
+		}
+
+		tags[string(tag.Name)] = string(tag.Value)
+	}
+
+	return graphite.TaggedSeriesName(name, tags)
+}
+
 func (s *m3WrappedStore) FetchByQuery(
 	ctx xctx.Context, query string, opts FetchOptions,
 ) (*FetchResult, error) {
@@ -220,11 +331,47 @@ func (s *m3WrappedStore) FetchByQuery(
 		}, nil
 	}
 
+	return s.fetch(ctx, m3query, opts, pathSeriesName)
+}
+
+func (s *m3WrappedStore) FetchByTags(
+	ctx xctx.Context, exprs []string, opts FetchOptions,
+) (*FetchResult, error) {
+	matchers, err := TranslateTagExpressionsToMatchers(exprs, s.tagOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	m3query := &storage.FetchQuery{
+		Raw:         strings.Join(exprs, ","),
+		TagMatchers: matchers,
+		Start:       opts.StartTime,
+		End:         opts.EndTime,
+		Interval:    time.Duration(0),
+	}
+
+	return s.fetch(ctx, m3query, opts, s.taggedSeriesName)
+}
+
+func (s *m3WrappedStore) fetch(
+	ctx xctx.Context,
+	m3query *storage.FetchQuery,
+	opts FetchOptions,
+	seriesName func(meta block.SeriesMeta) string,
+) (*FetchResult, error) {
 	m3ctx, cancel := context.WithTimeout(ctx.RequestContext(), opts.Timeout)
 	defer cancel()
 	fetchOptions := storage.NewFetchOptions()
 	fetchOptions.Limit = opts.Limit
-	perQueryEnforcer := s.enforcer.Child(cost.QueryLevel)
+	parentEnforcer := s.enforcer
+	if opts.Tenant != nil {
+		// NB: Restrict the fetch to the series of the tenant and roll up the
+		// cost of the query to the enforcer of the tenant.
+		fetchOptions = opts.Tenant.FetchOptions(fetchOptions)
+		parentEnforcer = opts.Tenant.Enforcer()
+	}
+
+	perQueryEnforcer := parentEnforcer.Child(cost.QueryLevel)
 	defer perQueryEnforcer.Close()
 
 	// NB: ensure single block return.
@@ -246,7 +393,8 @@ func (s *m3WrappedStore) FetchByQuery(
 		return nil, fmt.Errorf("expected at most one block, received %d", blockCount)
 	}
 
-	series, err := translateTimeseries(ctx, res, opts.StartTime, opts.EndTime)
+	series, err := translateTimeseries(ctx, res, opts.StartTime, opts.EndTime,
+		seriesName)
 	if err != nil {
 		return nil, err
 	}
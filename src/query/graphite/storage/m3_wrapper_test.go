@@ -97,6 +97,59 @@ func TestTranslateQueryTrailingDot(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTranslateTagExpressionsToMatchers(t *testing.T) {
+	matchers, err := TranslateTagExpressionsToMatchers([]string{
+		"name=cpu.load",
+		"dc=~us-.*",
+		"host!=a",
+		"az!=~b",
+		"rack=",
+		"pod!=",
+	}, models.NewTagOptions())
+	require.NoError(t, err)
+
+	expected := []struct {
+		matchType models.MatchType
+		name      string
+		value     string
+	}{
+		{models.MatchEqual, "__name__", "cpu.load"},
+		{models.MatchRegexp, "dc", "(?:us-.*).*"},
+		{models.MatchNotEqual, "host", "a"},
+		{models.MatchNotRegexp, "az", "(?:b).*"},
+		{models.MatchNotField, "rack", ""},
+		{models.MatchField, "pod", ""},
+	}
+
+	require.Equal(t, len(expected), len(matchers))
+	for i, ex := range expected {
+		assert.Equal(t, ex.matchType, matchers[i].Type)
+		assert.Equal(t, ex.name, string(matchers[i].Name))
+		assert.Equal(t, ex.value, string(matchers[i].Value))
+	}
+
+	_, err = TranslateTagExpressionsToMatchers([]string{"dc!=us"},
+		models.NewTagOptions())
+	assert.Error(t, err)
+
+	_, err = TranslateTagExpressionsToMatchers([]string{"dc"},
+		models.NewTagOptions())
+	assert.Error(t, err)
+}
+
+func TestTaggedSeriesName(t *testing.T) {
+	store := NewM3WrappedStorage(nil, nil, nil,
+		instrument.NewOptions()).(*m3WrappedStore)
+	tags := models.NewTags(3, models.NewTagOptions()).AddTags([]models.Tag{
+		{Name: []byte("host"), Value: []byte("a")},
+		{Name: []byte("__name__"), Value: []byte("cpu.load")},
+		{Name: []byte("dc"), Value: []byte("us-east")},
+	})
+
+	name := store.taggedSeriesName(block.SeriesMeta{Tags: tags})
+	assert.Equal(t, "cpu.load;dc=us-east;host=a", name)
+}
+
 func buildResult(
 	ctrl *gomock.Controller,
 	resolution time.Duration,
@@ -158,7 +211,7 @@ func TestTranslateTimeseries(t *testing.T) {
 
 	expected := 5
 	result := buildResult(ctrl, resolution, expected, steps, start)
-	translated, err := translateTimeseries(ctx, result, start, end)
+	translated, err := translateTimeseries(ctx, result, start, end, pathSeriesName)
 	require.NoError(t, err)
 
 	require.Equal(t, expected, len(translated))
@@ -200,7 +253,7 @@ func TestFetchByQuery(t *testing.T) {
 	enforcer := cost.NewMockChainedEnforcer(ctrl)
 	enforcer.EXPECT().Child(cost.QueryLevel).Return(childEnforcer).MinTimes(1)
 
-	wrapper := NewM3WrappedStorage(store, enforcer, instrument.NewOptions())
+	wrapper := NewM3WrappedStorage(store, enforcer, nil, instrument.NewOptions())
 	ctx := xctx.New()
 	ctx.SetRequestContext(context.TODO())
 	end := start.Add(time.Duration(steps) * resolution)
@@ -241,7 +294,7 @@ func TestFetchByInvalidQuery(t *testing.T) {
 
 	query := "a."
 	ctx := xctx.New()
-	wrapper := NewM3WrappedStorage(store, nil, instrument.NewOptions())
+	wrapper := NewM3WrappedStorage(store, nil, nil, instrument.NewOptions())
 	result, err := wrapper.FetchByQuery(ctx, query, opts)
 	assert.NoError(t, err)
 	require.Equal(t, 0, len(result.SeriesList))
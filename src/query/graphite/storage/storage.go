@@ -27,6 +27,7 @@ import (
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/graphite/context"
 	"github.com/m3db/m3/src/query/graphite/ts"
+	"github.com/m3db/m3/src/query/tenant"
 )
 
 // FetchOptions provides context to a fetch expression.
@@ -46,6 +47,8 @@ type DataOptions struct {
 	Timeout time.Duration
 	// Limit is the limit for number of datapoints to retrieve.
 	Limit int
+	// Tenant restricts the fetch to the series of the tenant if set.
+	Tenant tenant.Tenant
 }
 
 // Storage provides an interface for retrieving timeseries values or names
@@ -55,6 +58,12 @@ type Storage interface {
 	FetchByQuery(
 		ctx context.Context, query string, opts FetchOptions,
 	) (*FetchResult, error)
+
+	// FetchByTags fetches timeseries data matching the given seriesByTag
+	// tag expressions.
+	FetchByTags(
+		ctx context.Context, exprs []string, opts FetchOptions,
+	) (*FetchResult, error)
 }
 
 // FetchResult provides a fetch result and meta information.
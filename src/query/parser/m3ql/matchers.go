@@ -0,0 +1,122 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3ql
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+// patternToMatcher converts an M3QL tag pattern to a matcher; patterns that
+// contain glob symbols are converted to regexp matchers.
+func patternToMatcher(name []byte, pattern string) (models.Matcher, error) {
+	re, isGlob, err := globToRegexPattern(pattern)
+	if err != nil {
+		return models.Matcher{}, err
+	}
+
+	if !isGlob {
+		return models.NewMatcher(models.MatchEqual, name, []byte(pattern))
+	}
+
+	return models.NewMatcher(models.MatchRegexp, name, re)
+}
+
+// globToRegexPattern converts an M3QL glob into a regex pattern, with a
+// boolean indicating if the glob contained any glob symbols.
+func globToRegexPattern(glob string) ([]byte, bool, error) {
+	var (
+		buff    bytes.Buffer
+		isGlob  bool
+		inGroup bool
+		inClass bool
+		last    = len(glob) - 1
+	)
+
+	for i, r := range glob {
+		if inClass {
+			if r == ']' {
+				inClass = false
+			}
+
+			buff.WriteRune(r)
+			continue
+		}
+
+		switch r {
+		case '*':
+			buff.WriteString(".*")
+			isGlob = true
+		case '?':
+			buff.WriteRune('.')
+			isGlob = true
+		case '{':
+			if inGroup {
+				return nil, false, fmt.Errorf("nested '{' at %d in %s", i, glob)
+			}
+
+			buff.WriteRune('(')
+			inGroup = true
+			isGlob = true
+		case '}':
+			if !inGroup {
+				return nil, false, fmt.Errorf("invalid '}' at %d in %s", i, glob)
+			}
+
+			buff.WriteRune(')')
+			inGroup = false
+		case ',':
+			if inGroup {
+				buff.WriteRune('|')
+			} else {
+				buff.WriteRune(r)
+			}
+		case '[':
+			buff.WriteRune('[')
+			inClass = true
+			isGlob = true
+		case ']':
+			return nil, false, fmt.Errorf("invalid ']' at %d in %s", i, glob)
+		case '^', '$':
+			// NB: matches are always anchored, so leading and trailing anchors
+			// can be safely dropped.
+			isGlob = true
+			if (r == '^' && i != 0) || (r == '$' && i != last) {
+				buff.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		default:
+			buff.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	if inGroup {
+		return nil, false, fmt.Errorf("unbalanced '{' in %s", glob)
+	}
+
+	if inClass {
+		return nil, false, fmt.Errorf("unbalanced '[' in %s", glob)
+	}
+
+	return buff.Bytes(), isGlob, nil
+}
@@ -0,0 +1,457 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3ql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/binary"
+	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/functions/scalar"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	fetchType      = "fetch"
+	perSecondType  = "perSecond"
+	scaleType      = "scale"
+	offsetType     = "offset"
+	divideType     = "divideSeries"
+	asPercentType  = "asPercent"
+	nameKeyword    = "name"
+	defaultPercent = 100.0
+
+	// defaultPerSecondRange is the lookback used by perSecond when no
+	// explicit range is provided.
+	defaultPerSecondRange = 5 * time.Minute
+)
+
+var (
+	errNoPipeline = errors.New("m3ql query must contain a pipeline")
+
+	// aggregationTypes maps M3QL aggregation names to aggregation types.
+	aggregationTypes = map[string]string{
+		"sum":     aggregation.SumType,
+		"min":     aggregation.MinType,
+		"max":     aggregation.MaxType,
+		"avg":     aggregation.AverageType,
+		"average": aggregation.AverageType,
+		"count":   aggregation.CountType,
+		"stdev":   aggregation.StandardDeviationType,
+		"stddev":  aggregation.StandardDeviationType,
+	}
+
+	// mathTypes maps M3QL math function names to linear math types.
+	mathTypes = map[string]string{
+		"abs":      linear.AbsType,
+		"absolute": linear.AbsType,
+		"ceil":     linear.CeilType,
+		"floor":    linear.FloorType,
+		"exp":      linear.ExpType,
+		"ln":       linear.LnType,
+		"log2":     linear.Log2Type,
+		"log10":    linear.Log10Type,
+		"sqrt":     linear.SqrtType,
+	}
+
+	// comparisonTypes are the comparison operators supported by M3QL; they
+	// share their representation with the binary comparison types.
+	comparisonTypes = map[string]struct{}{
+		binary.EqType:        struct{}{},
+		binary.NotEqType:     struct{}{},
+		binary.GreaterType:   struct{}{},
+		binary.LesserType:    struct{}{},
+		binary.GreaterEqType: struct{}{},
+		binary.LesserEqType:  struct{}{},
+	}
+)
+
+type m3qlParser struct {
+	query    string
+	script   script
+	stepSize time.Duration
+	tagOpts  models.TagOptions
+}
+
+// Parse takes an M3QL string and parses it into a DAG.
+func Parse(
+	q string,
+	stepSize time.Duration,
+	tagOpts models.TagOptions,
+) (parser.Parser, error) {
+	builder := newASTBuilder()
+	grammar := &m3ql{
+		Buffer:        q,
+		scriptBuilder: builder,
+	}
+
+	grammar.Init()
+	if err := grammar.Parse(); err != nil {
+		return nil, err
+	}
+
+	grammar.Execute()
+	if builder.err != nil {
+		return nil, builder.err
+	}
+
+	if builder.script.root == nil {
+		return nil, errNoPipeline
+	}
+
+	return &m3qlParser{
+		query:    q,
+		script:   builder.script,
+		stepSize: stepSize,
+		tagOpts:  tagOpts,
+	}, nil
+}
+
+func (p *m3qlParser) DAG() (parser.Nodes, parser.Edges, error) {
+	state := &parseState{
+		stepSize:  p.stepSize,
+		tagOpts:   p.tagOpts,
+		macros:    p.script.macros,
+		expanding: make(map[string]struct{}),
+	}
+
+	if err := state.walkPipeline(p.script.root, false); err != nil {
+		return nil, nil, err
+	}
+
+	return state.transforms, state.edges, nil
+}
+
+func (p *m3qlParser) String() string {
+	return p.query
+}
+
+type parseState struct {
+	stepSize   time.Duration
+	tagOpts    models.TagOptions
+	macros     map[string]*pipeline
+	expanding  map[string]struct{}
+	edges      parser.Edges
+	transforms parser.Nodes
+}
+
+func (p *parseState) lastTransformID() parser.NodeID {
+	if len(p.transforms) == 0 {
+		return parser.NodeID("-1")
+	}
+
+	return p.transforms[len(p.transforms)-1].ID
+}
+
+func (p *parseState) transformLen() int {
+	return len(p.transforms)
+}
+
+// addTransform adds a transform for the given op, with edges from each of
+// the given parents.
+func (p *parseState) addTransform(
+	op parser.Params,
+	parents ...parser.NodeID,
+) parser.NodeID {
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	for _, parent := range parents {
+		p.edges = append(p.edges, parser.Edge{
+			ParentID: parent,
+			ChildID:  opTransform.ID,
+		})
+	}
+
+	p.transforms = append(p.transforms, opTransform)
+	return opTransform.ID
+}
+
+func (p *parseState) walkPipeline(pl *pipeline, hasInput bool) error {
+	for _, e := range pl.expressions {
+		if err := p.walkExpression(e, hasInput); err != nil {
+			return err
+		}
+
+		hasInput = true
+	}
+
+	return nil
+}
+
+func (p *parseState) walkExpression(e *expression, hasInput bool) error {
+	if e.nested != nil {
+		return p.walkPipeline(e.nested, hasInput)
+	}
+
+	if macro, ok := p.macros[e.name]; ok && len(e.arguments) == 0 {
+		if _, ok := p.expanding[e.name]; ok {
+			return fmt.Errorf("macro %s is recursive", e.name)
+		}
+
+		p.expanding[e.name] = struct{}{}
+		defer delete(p.expanding, e.name)
+		return p.walkPipeline(macro, hasInput)
+	}
+
+	if e.name == fetchType {
+		if hasInput {
+			return fmt.Errorf("%s must be the first expression in a pipeline",
+				fetchType)
+		}
+
+		return p.addFetch(e)
+	}
+
+	if !hasInput {
+		return fmt.Errorf("%s requires an input, received none", e.name)
+	}
+
+	if opType, ok := aggregationTypes[e.name]; ok {
+		return p.addAggregation(e, opType)
+	}
+
+	if opType, ok := mathTypes[e.name]; ok {
+		if len(e.arguments) != 0 {
+			return fmt.Errorf("%s takes no arguments, received %d",
+				e.name, len(e.arguments))
+		}
+
+		op, err := linear.NewMathOp(opType)
+		if err != nil {
+			return err
+		}
+
+		p.addTransform(op, p.lastTransformID())
+		return nil
+	}
+
+	if _, ok := comparisonTypes[e.name]; ok {
+		return p.addBinary(e, e.name, nil)
+	}
+
+	switch e.name {
+	case perSecondType:
+		return p.addPerSecond(e)
+	case scaleType:
+		return p.addBinary(e, binary.MultiplyType, nil)
+	case offsetType:
+		return p.addBinary(e, binary.PlusType, nil)
+	case divideType:
+		return p.addBinary(e, binary.DivType, manyToOneMatcherBuilder)
+	case asPercentType:
+		if err := p.addBinary(e, binary.DivType, manyToOneMatcherBuilder); err != nil {
+			return err
+		}
+
+		return p.addScalarBinary(binary.MultiplyType, defaultPercent)
+	}
+
+	return fmt.Errorf("function not supported: %s", e.name)
+}
+
+func (p *parseState) addFetch(e *expression) error {
+	var (
+		name     string
+		matchers = make(models.Matchers, 0, len(e.arguments))
+	)
+
+	for _, arg := range e.arguments {
+		if arg.keyword == "" {
+			return fmt.Errorf("%s arguments must be of the form tag:value, "+
+				"received %s", fetchType, arg)
+		}
+
+		if arg.argType == pipelineArgument {
+			return fmt.Errorf("%s argument %s cannot be a pipeline",
+				fetchType, arg.keyword)
+		}
+
+		tagName := []byte(arg.keyword)
+		if arg.keyword == nameKeyword {
+			name = arg.value
+			tagName = p.tagOpts.MetricName()
+		}
+
+		matcher, err := patternToMatcher(tagName, arg.value)
+		if err != nil {
+			return err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	if len(matchers) == 0 {
+		return fmt.Errorf("%s requires at least one tag:value argument",
+			fetchType)
+	}
+
+	p.addTransform(functions.FetchOp{
+		Name:     name,
+		Matchers: matchers,
+	})
+
+	return nil
+}
+
+func (p *parseState) addAggregation(e *expression, opType string) error {
+	matchingTags := make([][]byte, 0, len(e.arguments))
+	for _, arg := range e.arguments {
+		if arg.keyword != "" || arg.argType != patternArgument {
+			return fmt.Errorf("%s arguments must be tag names, received %s",
+				e.name, arg)
+		}
+
+		matchingTags = append(matchingTags, []byte(arg.value))
+	}
+
+	op, err := aggregation.NewAggregationOp(opType, aggregation.NodeParams{
+		MatchingTags: matchingTags,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.addTransform(op, p.lastTransformID())
+	return nil
+}
+
+func (p *parseState) addPerSecond(e *expression) error {
+	duration := defaultPerSecondRange
+	if len(e.arguments) > 1 {
+		return fmt.Errorf("%s takes at most one argument, received %d",
+			e.name, len(e.arguments))
+	}
+
+	if len(e.arguments) == 1 {
+		d, err := time.ParseDuration(e.arguments[0].value)
+		if err != nil {
+			return fmt.Errorf("invalid range for %s: %v", e.name, err)
+		}
+
+		duration = d
+	}
+
+	if duration < p.stepSize {
+		duration = p.stepSize
+	}
+
+	// NB: temporal functions need the fetch to retrieve the entire range
+	// preceding each step, so extend the range of the input fetch.
+	last := len(p.transforms) - 1
+	fetch, ok := p.transforms[last].Op.(functions.FetchOp)
+	if !ok {
+		return fmt.Errorf("%s must directly follow %s", e.name, fetchType)
+	}
+
+	fetch.Range = duration
+	p.transforms[last].Op = fetch
+
+	op, err := temporal.NewRateOp([]interface{}{duration}, temporal.IRateType)
+	if err != nil {
+		return err
+	}
+
+	p.addTransform(op, p.lastTransformID())
+	return nil
+}
+
+// addBinary adds a binary operation between the current input and either a
+// numeric argument or a nested pipeline argument.
+func (p *parseState) addBinary(
+	e *expression,
+	opType string,
+	matcherBuilder binary.VectorMatcherBuilder,
+) error {
+	if len(e.arguments) != 1 {
+		return fmt.Errorf("%s takes exactly one argument, received %d",
+			e.name, len(e.arguments))
+	}
+
+	arg := e.arguments[0]
+	switch arg.argType {
+	case numericArgument:
+		val, err := strconv.ParseFloat(arg.value, 64)
+		if err != nil {
+			return err
+		}
+
+		return p.addScalarBinary(opType, val)
+
+	case pipelineArgument:
+		lhsID := p.lastTransformID()
+		if err := p.walkPipeline(arg.pipeline, false); err != nil {
+			return err
+		}
+
+		rhsID := p.lastTransformID()
+		op, err := binary.NewOp(opType, binary.NodeParams{
+			LNode:                lhsID,
+			RNode:                rhsID,
+			VectorMatcherBuilder: matcherBuilder,
+		})
+		if err != nil {
+			return err
+		}
+
+		p.addTransform(op, lhsID, rhsID)
+		return nil
+
+	default:
+		return fmt.Errorf("invalid argument for %s: %s", e.name, arg)
+	}
+}
+
+func (p *parseState) addScalarBinary(opType string, val float64) error {
+	lhsID := p.lastTransformID()
+	scalarOp, err := scalar.NewScalarOp(val, p.tagOpts)
+	if err != nil {
+		return err
+	}
+
+	rhsID := p.addTransform(scalarOp)
+	op, err := binary.NewOp(opType, binary.NodeParams{
+		LNode: lhsID,
+		RNode: rhsID,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.addTransform(op, lhsID, rhsID)
+	return nil
+}
+
+// manyToOneMatcherBuilder matches every series on the left hand side with the
+// single series on the right hand side, as M3QL series functions expect.
+func manyToOneMatcherBuilder(_, _ block.Block) binary.VectorMatching {
+	return binary.VectorMatching{
+		Set:  true,
+		Card: binary.CardManyToOne,
+		On:   true,
+	}
+}
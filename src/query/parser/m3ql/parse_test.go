@@ -0,0 +1,182 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3ql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/binary"
+	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/functions/scalar"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseDAG(t *testing.T, q string) (parser.Nodes, parser.Edges) {
+	p, err := Parse(q, time.Second, models.NewTagOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	return transforms, edges
+}
+
+func TestDAGWithFetch(t *testing.T) {
+	transforms, edges := parseDAG(t, "fetch name:foo.bar service:{a,b}*")
+	require.Len(t, transforms, 1)
+	assert.Len(t, edges, 0)
+
+	op, ok := transforms[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	assert.Equal(t, "foo.bar", op.Name)
+	require.Len(t, op.Matchers, 2)
+	assert.Equal(t, models.MatchEqual, op.Matchers[0].Type)
+	assert.Equal(t, []byte("__name__"), op.Matchers[0].Name)
+	assert.Equal(t, []byte("foo.bar"), op.Matchers[0].Value)
+	assert.Equal(t, models.MatchRegexp, op.Matchers[1].Type)
+	assert.Equal(t, []byte("service"), op.Matchers[1].Name)
+	assert.Equal(t, []byte("(a|b).*"), op.Matchers[1].Value)
+}
+
+func TestDAGWithAggregation(t *testing.T) {
+	transforms, edges := parseDAG(t, "fetch name:foo | sum service dc | abs")
+	require.Len(t, transforms, 3)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, aggregation.SumType, transforms[1].Op.OpType())
+	assert.Equal(t, linear.AbsType, transforms[2].Op.OpType())
+	assert.Equal(t, parser.Edges{
+		{ParentID: "0", ChildID: "1"},
+		{ParentID: "1", ChildID: "2"},
+	}, edges)
+}
+
+func TestDAGWithComparison(t *testing.T) {
+	transforms, edges := parseDAG(t, "fetch name:foo | >= 5")
+	require.Len(t, transforms, 3)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, scalar.ScalarType, transforms[1].Op.OpType())
+	assert.Equal(t, binary.GreaterEqType, transforms[2].Op.OpType())
+	assert.Equal(t, parser.Edges{
+		{ParentID: "0", ChildID: "2"},
+		{ParentID: "1", ChildID: "2"},
+	}, edges)
+}
+
+func TestDAGWithPerSecond(t *testing.T) {
+	transforms, edges := parseDAG(t, "fetch name:foo | perSecond 2m")
+	require.Len(t, transforms, 2)
+	op, ok := transforms[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	assert.Equal(t, 2*time.Minute, op.Range)
+	assert.Equal(t, temporal.IRateType, transforms[1].Op.OpType())
+	assert.Len(t, edges, 1)
+}
+
+func TestDAGWithNestedPipeline(t *testing.T) {
+	transforms, edges := parseDAG(t,
+		"fetch name:errors | asPercent (fetch name:requests | sum)")
+	require.Len(t, transforms, 6)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, functions.FetchType, transforms[1].Op.OpType())
+	assert.Equal(t, aggregation.SumType, transforms[2].Op.OpType())
+	assert.Equal(t, binary.DivType, transforms[3].Op.OpType())
+	assert.Equal(t, scalar.ScalarType, transforms[4].Op.OpType())
+	assert.Equal(t, binary.MultiplyType, transforms[5].Op.OpType())
+	assert.Equal(t, parser.Edges{
+		{ParentID: "1", ChildID: "2"},
+		{ParentID: "0", ChildID: "3"},
+		{ParentID: "2", ChildID: "3"},
+		{ParentID: "3", ChildID: "5"},
+		{ParentID: "4", ChildID: "5"},
+	}, edges)
+}
+
+func TestDAGWithMacro(t *testing.T) {
+	transforms, edges := parseDAG(t, "a = fetch name:foo | sum; a | sqrt")
+	require.Len(t, transforms, 3)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, aggregation.SumType, transforms[1].Op.OpType())
+	assert.Equal(t, linear.SqrtType, transforms[2].Op.OpType())
+	assert.Len(t, edges, 2)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, q := range []string{
+		"",
+		"fetch name:foo |",
+		"fetch name:foo | sum (",
+	} {
+		t.Run(q, func(t *testing.T) {
+			_, err := Parse(q, time.Second, models.NewTagOptions())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDAGErrors(t *testing.T) {
+	for _, q := range []string{
+		"sum",
+		"fetch name:foo | fetch name:bar",
+		"fetch name:foo | unknown",
+		"fetch name:foo | sum | perSecond",
+		"fetch name:foo | >= bar",
+		"fetch foo",
+		"fetch name:{foo",
+		"a = a | sum; a",
+	} {
+		t.Run(q, func(t *testing.T) {
+			p, err := Parse(q, time.Second, models.NewTagOptions())
+			require.NoError(t, err)
+			_, _, err = p.DAG()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGlobToRegexPattern(t *testing.T) {
+	tests := []struct {
+		glob   string
+		regex  string
+		isGlob bool
+	}{
+		{"foo.bar", `foo\.bar`, false},
+		{"foo*", "foo.*", true},
+		{"fo?", "fo.", true},
+		{"{a,b}c", "(a|b)c", true},
+		{"[a-z]x", "[a-z]x", true},
+		{"^foo$", "foo", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.glob, func(t *testing.T) {
+			re, isGlob, err := globToRegexPattern(tt.glob)
+			require.NoError(t, err)
+			assert.Equal(t, tt.regex, string(re))
+			assert.Equal(t, tt.isGlob, isGlob)
+		})
+	}
+}
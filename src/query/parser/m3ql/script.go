@@ -0,0 +1,266 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3ql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type argumentType int
+
+const (
+	booleanArgument argumentType = iota
+	numericArgument
+	patternArgument
+	stringLiteralArgument
+	pipelineArgument
+)
+
+// argument is a single argument to an expression.
+type argument struct {
+	argType  argumentType
+	keyword  string
+	value    string
+	pipeline *pipeline
+}
+
+func (a argument) String() string {
+	var value string
+	switch a.argType {
+	case pipelineArgument:
+		value = fmt.Sprintf("(%v)", a.pipeline)
+	case stringLiteralArgument:
+		value = strconv.Quote(a.value)
+	default:
+		value = a.value
+	}
+
+	if a.keyword == "" {
+		return value
+	}
+
+	return fmt.Sprintf("%s:%s", a.keyword, value)
+}
+
+// expression is a function call within a pipeline.
+type expression struct {
+	name      string
+	arguments []argument
+	// nested is set when the expression is a parenthesized pipeline rather
+	// than a function call.
+	nested *pipeline
+}
+
+func (e *expression) String() string {
+	if e.nested != nil {
+		return fmt.Sprintf("(%v)", e.nested)
+	}
+
+	str := e.name
+	for _, arg := range e.arguments {
+		str += " " + arg.String()
+	}
+
+	return str
+}
+
+// pipeline is a list of expressions, each one consuming the output of the
+// previous one.
+type pipeline struct {
+	expressions []*expression
+	// isArgument indicates the pipeline is an argument to an expression.
+	isArgument bool
+	// keyword is the keyword of the argument, if any.
+	keyword string
+}
+
+func (p *pipeline) String() string {
+	str := ""
+	for i, e := range p.expressions {
+		if i > 0 {
+			str += " | "
+		}
+
+		str += e.String()
+	}
+
+	return str
+}
+
+// script is a parsed M3QL query, consisting of any number of macro
+// definitions followed by the pipeline to execute.
+type script struct {
+	macros map[string]*pipeline
+	root   *pipeline
+}
+
+// astBuilder implements scriptBuilder, accumulating the actions emitted by
+// the generated grammar into a script.
+type astBuilder struct {
+	script       script
+	pendingMacro string
+	keyword      string
+	pipelines    []*pipeline
+	expressions  []*expression
+	err          error
+}
+
+var _ scriptBuilder = (*astBuilder)(nil)
+
+func newASTBuilder() *astBuilder {
+	return &astBuilder{
+		script: script{macros: make(map[string]*pipeline)},
+	}
+}
+
+func (b *astBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *astBuilder) newMacro(name string) {
+	if _, ok := b.script.macros[name]; ok {
+		b.setErr(fmt.Errorf("macro %s is defined more than once", name))
+	}
+
+	b.pendingMacro = name
+}
+
+func (b *astBuilder) newPipeline() {
+	// NB: a pipeline starting while one of the enclosing pipeline's
+	// expressions is still open is an argument to that expression.
+	isArgument := false
+	if n := len(b.pipelines); n > 0 {
+		parent := b.pipelines[n-1]
+		if len(parent.expressions) > 0 && len(b.expressions) > 0 {
+			isArgument = b.expressions[len(b.expressions)-1] ==
+				parent.expressions[len(parent.expressions)-1]
+		}
+	}
+
+	p := &pipeline{isArgument: isArgument}
+	if isArgument {
+		// NB: hold on to the keyword so that arguments within the nested
+		// pipeline do not consume it.
+		p.keyword = b.keyword
+		b.keyword = ""
+	}
+
+	b.pipelines = append(b.pipelines, p)
+}
+
+func (b *astBuilder) endPipeline() {
+	n := len(b.pipelines)
+	if n == 0 {
+		b.setErr(fmt.Errorf("unexpected end of pipeline"))
+		return
+	}
+
+	p := b.pipelines[n-1]
+	b.pipelines = b.pipelines[:n-1]
+	if p.isArgument {
+		b.keyword = p.keyword
+		b.addArgument(pipelineArgument, "", p)
+		return
+	}
+
+	if n > 1 {
+		// NB: a parenthesized pipeline used as an expression.
+		parent := b.pipelines[n-2]
+		parent.expressions = append(parent.expressions, &expression{nested: p})
+		return
+	}
+
+	if b.pendingMacro != "" {
+		b.script.macros[b.pendingMacro] = p
+		b.pendingMacro = ""
+		return
+	}
+
+	b.script.root = p
+}
+
+func (b *astBuilder) newExpression(name string) {
+	n := len(b.pipelines)
+	if n == 0 {
+		b.setErr(fmt.Errorf("expression %s outside of pipeline", name))
+		return
+	}
+
+	e := &expression{name: name}
+	p := b.pipelines[n-1]
+	p.expressions = append(p.expressions, e)
+	b.expressions = append(b.expressions, e)
+}
+
+func (b *astBuilder) endExpression() {
+	n := len(b.expressions)
+	if n == 0 {
+		b.setErr(fmt.Errorf("unexpected end of expression"))
+		return
+	}
+
+	b.expressions = b.expressions[:n-1]
+}
+
+func (b *astBuilder) addArgument(
+	argType argumentType,
+	value string,
+	p *pipeline,
+) {
+	n := len(b.expressions)
+	if n == 0 {
+		b.setErr(fmt.Errorf("argument %s outside of expression", value))
+		return
+	}
+
+	e := b.expressions[n-1]
+	e.arguments = append(e.arguments, argument{
+		argType:  argType,
+		keyword:  b.keyword,
+		value:    value,
+		pipeline: p,
+	})
+
+	b.keyword = ""
+}
+
+func (b *astBuilder) newBooleanArgument(value string) {
+	b.addArgument(booleanArgument, value, nil)
+}
+
+func (b *astBuilder) newNumericArgument(value string) {
+	b.addArgument(numericArgument, value, nil)
+}
+
+func (b *astBuilder) newPatternArgument(value string) {
+	b.addArgument(patternArgument, value, nil)
+}
+
+func (b *astBuilder) newStringLiteralArgument(value string) {
+	b.addArgument(stringLiteralArgument, value, nil)
+}
+
+func (b *astBuilder) newKeywordArgument(keyword string) {
+	b.keyword = keyword
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/query/functions/binary"
 	"github.com/m3db/m3/src/query/functions/lazy"
 	"github.com/m3db/m3/src/query/functions/scalar"
+	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
@@ -212,6 +213,32 @@ func (p *parseState) walk(node pql.Node) error {
 		)
 		return p.addLazyOffsetTransform(n.Offset)
 
+	case *pql.SubqueryExpr:
+		err := p.walk(n.Expr)
+		if err != nil {
+			return err
+		}
+
+		// NB: subqueries without an explicit step are evaluated at the step of
+		// the outer query.
+		step := n.Step
+		if step == 0 {
+			step = p.stepSize
+		}
+
+		op, err := temporal.NewSubqueryOp(n.Range, step, n.Offset)
+		if err != nil {
+			return err
+		}
+
+		opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+		p.edges = append(p.edges, parser.Edge{
+			ParentID: p.lastTransformID(),
+			ChildID:  opTransform.ID,
+		})
+		p.transforms = append(p.transforms, opTransform)
+		return nil
+
 	case *pql.VectorSelector:
 		// Align offset to stepSize.
 		n.Offset = adjustOffset(n.Offset, p.stepSize)
@@ -299,6 +326,10 @@ func (p *parseState) walk(node pql.Node) error {
 					argValues = append(argValues, e.Range)
 				}
 
+				if e, ok := expr.(*pql.SubqueryExpr); ok {
+					argValues = append(argValues, e.Range)
+				}
+
 				if err := p.walk(expr); err != nil {
 					return err
 				}
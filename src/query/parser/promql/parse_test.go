@@ -463,6 +463,52 @@ func TestTemporalParses(t *testing.T) {
 	}
 }
 
+func TestSubqueryParses(t *testing.T) {
+	tests := []struct {
+		q             string
+		expectedTypes []string
+		expectedStep  string
+	}{
+		{
+			"max_over_time(rate(up[5m])[1h:1m])",
+			[]string{functions.FetchType, temporal.RateType,
+				temporal.SubqueryType, temporal.MaxType},
+			"step: 1m0s",
+		},
+		{
+			"sum_over_time((up > 1)[10m:] offset 5m)",
+			[]string{functions.FetchType, scalar.ScalarType, binary.GreaterType,
+				temporal.SubqueryType, temporal.SumType},
+			"step: 1s, offset: 5m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			p, err := Parse(tt.q, time.Second, models.NewTagOptions(), NewParseOptions())
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			require.Len(t, transforms, len(tt.expectedTypes))
+			for i, expected := range tt.expectedTypes {
+				assert.Equal(t, expected, transforms[i].Op.OpType())
+			}
+
+			n := len(transforms)
+			subquery := transforms[n-2]
+			assert.Contains(t, subquery.Op.String(), tt.expectedStep)
+			assert.Contains(t, edges, parser.Edge{
+				ParentID: transforms[n-3].ID,
+				ChildID:  subquery.ID,
+			})
+			assert.Contains(t, edges, parser.Edge{
+				ParentID: subquery.ID,
+				ChildID:  transforms[n-1].ID,
+			})
+		})
+	}
+}
+
 var tagParseTests = []struct {
 	q            string
 	expectedType string
@@ -92,7 +92,7 @@ func (p PhysicalPlan) shiftTime() PhysicalPlan {
 	for _, transformID := range p.pipeline {
 		node := p.steps[transformID]
 		boundOp, ok := node.Transform.Op.(transform.BoundOp)
-		if !ok {
+		if !ok || p.consumesTimeSpecOp(node) {
 			continue
 		}
 
@@ -119,6 +119,25 @@ func (p PhysicalPlan) shiftTime() PhysicalPlan {
 	return p
 }
 
+// consumesTimeSpecOp returns true if any parent of the step evaluates its own
+// parents over a different time spec, such as a subquery; the range of the
+// step is then already covered by the time spec of that parent, and so
+// should not widen the query as well.
+func (p PhysicalPlan) consumesTimeSpecOp(step LogicalStep) bool {
+	for _, parentID := range step.Parents {
+		parent, ok := p.steps[parentID]
+		if !ok {
+			continue
+		}
+
+		if _, ok := parent.Transform.Op.(transform.TimeSpecOp); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p PhysicalPlan) createResultNode() (PhysicalPlan, error) {
 	leaf, err := p.leafNode()
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/query/functions"
 	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
@@ -101,3 +102,41 @@ func TestShiftTime(t *testing.T) {
 		Add(-1*(time.Minute+time.Hour+defaultLookbackDuration)), p.TimeSpec.Start,
 		"start time offset by fetch")
 }
+
+func TestShiftTimeSubquery(t *testing.T) {
+	fetchTransform := parser.NewTransformFromOperation(
+		functions.FetchOp{Range: 5 * time.Minute}, 1)
+	subquery, err := temporal.NewSubqueryOp(30*time.Minute, time.Minute, 0)
+	require.NoError(t, err)
+	subqueryTransform := parser.NewTransformFromOperation(subquery, 2)
+	agg, err := temporal.NewAggOp([]interface{}{30 * time.Minute},
+		temporal.AvgType)
+	require.NoError(t, err)
+	aggTransform := parser.NewTransformFromOperation(agg, 3)
+	transforms := parser.Nodes{fetchTransform, subqueryTransform, aggTransform}
+	edges := parser.Edges{
+		parser.Edge{
+			ParentID: fetchTransform.ID,
+			ChildID:  subqueryTransform.ID,
+		},
+		parser.Edge{
+			ParentID: subqueryTransform.ID,
+			ChildID:  aggTransform.ID,
+		},
+	}
+
+	lp, err := NewLogicalPlan(transforms, edges)
+	require.NoError(t, err)
+
+	params := testRequestParams()
+	params.Start = params.Now.Add(-1 * time.Hour)
+
+	p, err := NewPhysicalPlan(lp, params)
+	require.NoError(t, err)
+
+	// NB: the subquery widens the time spec of its parents by its range, so
+	// only the range of the fetch widens the query itself.
+	assert.Equal(t, params.Start.
+		Add(-1*(5*time.Minute+defaultLookbackDuration)), p.TimeSpec.Start,
+		"start time offset by fetch only")
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/errors"
 	"github.com/m3db/m3/src/query/generated/proto/rpcpb"
 	rpc "github.com/m3db/m3/src/query/generated/proto/rpcpb"
 	"github.com/m3db/m3/src/query/models"
@@ -100,6 +101,10 @@ func encodeFetchRequest(
 	query *storage.FetchQuery,
 	options *storage.FetchOptions,
 ) (*rpc.FetchRequest, error) {
+	if query.IndexQuery != "" {
+		return nil, errors.ErrIndexQueryNotSupported
+	}
+
 	matchers, err := encodeTagMatchers(query.TagMatchers)
 	if err != nil {
 		return nil, err
@@ -166,6 +166,10 @@ func encodeSearchRequest(
 	query *storage.FetchQuery,
 	options *storage.FetchOptions,
 ) (*rpc.SearchRequest, error) {
+	if query.IndexQuery != "" {
+		return nil, errors.ErrIndexQueryNotSupported
+	}
+
 	matchers, err := encodeTagMatchers(query.TagMatchers)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,207 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// instantQueryStep is the step used for instant queries, matching the
+// instant query endpoint.
+const instantQueryStep = time.Second
+
+// sample is a single value of a series in an instant query result.
+type sample struct {
+	tags  models.Tags
+	value float64
+}
+
+// evaluator executes instant queries and writes rule results.
+type evaluator struct {
+	engine  executor.Engine
+	writer  ingest.DownsamplerAndWriter
+	tagOpts models.TagOptions
+	timeout time.Duration
+}
+
+func newEvaluator(opts Options) *evaluator {
+	return &evaluator{
+		engine:  opts.Engine(),
+		writer:  opts.DownsamplerAndWriter(),
+		tagOpts: opts.TagOptions(),
+		timeout: opts.QueryTimeout(),
+	}
+}
+
+// query executes the query at the given time, returning a sample for each
+// series that has a value at that time.
+func (e *evaluator) query(
+	ctx context.Context,
+	query string,
+	at time.Time,
+) ([]sample, error) {
+	parser, err := promql.Parse(query, instantQueryStep, e.tagOpts,
+		e.engine.Options().ParseOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	params := models.RequestParams{
+		Start:            at,
+		End:              at,
+		Now:              at,
+		Timeout:          e.timeout,
+		Step:             instantQueryStep,
+		Query:            query,
+		IncludeEnd:       true,
+		BlockType:        models.TypeSingleBlock,
+		FormatType:       models.FormatPromQL,
+		LookbackDuration: e.engine.Options().LookbackDuration(),
+	}
+
+	fetchOpts := storage.NewFetchOptions()
+	result, err := e.engine.ExecuteExpr(ctx, parser,
+		&executor.QueryOptions{}, fetchOpts, params)
+	if err != nil {
+		return nil, err
+	}
+
+	resultChan := result.ResultChan()
+	defer func() {
+		for range resultChan {
+			// NB: drain result channel in case of early termination.
+		}
+	}()
+
+	var (
+		samples []sample
+		indices = make(map[string]int)
+	)
+
+	for r := range resultChan {
+		if r.Err != nil {
+			return nil, r.Err
+		}
+
+		err := appendBlockSamples(r.Block, at, indices, &samples)
+		r.Block.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return samples, nil
+}
+
+// appendBlockSamples appends the last value at or before the given time for
+// each series in the block, replacing any existing samples for the series.
+func appendBlockSamples(
+	b block.Block,
+	at time.Time,
+	indices map[string]int,
+	samples *[]sample,
+) error {
+	iter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	defer iter.Close()
+	var (
+		commonTags = b.Meta().Tags.Tags
+		seriesMeta = iter.SeriesMeta()
+		values     []float64
+	)
+
+	for iter.Next() {
+		step := iter.Current()
+		if step.Time().After(at) {
+			break
+		}
+
+		values = step.Values()
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		tags := seriesMeta[i].Tags.AddTags(commonTags)
+		id := string(tags.ID())
+		if idx, ok := indices[id]; ok {
+			(*samples)[idx].value = v
+			continue
+		}
+
+		indices[id] = len(*samples)
+		*samples = append(*samples, sample{tags: tags, value: v})
+	}
+
+	return nil
+}
+
+// write writes a single datapoint for the series with the given tags.
+func (e *evaluator) write(
+	ctx context.Context,
+	tags models.Tags,
+	at time.Time,
+	value float64,
+) error {
+	datapoints := ts.Datapoints{{Timestamp: at, Value: value}}
+	return e.writer.Write(ctx, tags, datapoints, xtime.Millisecond, nil,
+		ingest.WriteOptions{})
+}
+
+// newTags returns tags built from the given label set.
+func (e *evaluator) newTags(labels map[string]string) models.Tags {
+	tags := models.NewTags(len(labels), e.tagOpts)
+	for name, value := range labels {
+		tags = tags.AddTag(models.Tag{Name: []byte(name), Value: []byte(value)})
+	}
+
+	return tags
+}
+
+// tagsToLabels returns the label set for the given tags.
+func tagsToLabels(tags models.Tags) map[string]string {
+	labels := make(map[string]string, tags.Len())
+	for _, tag := range tags.Tags {
+		labels[string(tag.Name)] = string(tag.Value)
+	}
+
+	return labels
+}
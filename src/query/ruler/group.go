@@ -0,0 +1,114 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+type groupMetrics struct {
+	evaluations        tally.Counter
+	evaluationFailures tally.Counter
+	evaluationLatency  tally.Timer
+}
+
+func newGroupMetrics(scope tally.Scope) groupMetrics {
+	return groupMetrics{
+		evaluations:        scope.Counter("evaluations"),
+		evaluationFailures: scope.Counter("evaluation-failures"),
+		evaluationLatency:  scope.Timer("evaluation-latency"),
+	}
+}
+
+// group is a set of rules that are evaluated sequentially on an interval.
+type group struct {
+	name     string
+	file     string
+	interval time.Duration
+	timeout  time.Duration
+	rules    []rule
+	nowFn    clock.NowFn
+	logger   *zap.Logger
+	metrics  groupMetrics
+}
+
+func (g *group) run(closeCh <-chan struct{}) {
+	for {
+		// NB: evaluations are aligned to the interval so that recorded series
+		// have consistent timestamps across restarts and instances.
+		now := g.nowFn()
+		next := truncate(now, g.interval).Add(g.interval)
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-closeCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		g.eval(next)
+	}
+}
+
+func (g *group) eval(at time.Time) {
+	start := g.nowFn()
+	for _, r := range g.rules {
+		ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+		err := r.eval(ctx, at)
+		cancel()
+		if err != nil {
+			g.metrics.evaluationFailures.Inc(1)
+			g.logger.Error("rule evaluation failed",
+				zap.String("group", g.name),
+				zap.String("rule", r.ruleName()),
+				zap.Error(err))
+		}
+	}
+
+	g.metrics.evaluations.Inc(1)
+	g.metrics.evaluationLatency.Record(g.nowFn().Sub(start))
+}
+
+func (g *group) status() GroupStatus {
+	rules := make([]RuleStatus, 0, len(g.rules))
+	for _, r := range g.rules {
+		rules = append(rules, r.status())
+	}
+
+	return GroupStatus{
+		Name:     g.name,
+		File:     g.file,
+		Interval: g.interval,
+		Rules:    rules,
+	}
+}
+
+// truncate truncates the time to a multiple of d since the Unix epoch.
+func truncate(t time.Time, d time.Duration) time.Time {
+	nanos := t.UnixNano()
+	return time.Unix(0, nanos-nanos%int64(d))
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	xerrors "github.com/m3db/m3/src/x/errors"
+
+	"github.com/prometheus/prometheus/pkg/rulefmt"
+)
+
+var (
+	errManagerAlreadyStarted = errors.New("rule manager already started")
+	errManagerNotStarted     = errors.New("rule manager not started")
+	errManagerClosed         = errors.New("rule manager closed")
+)
+
+type manager struct {
+	sync.Mutex
+
+	groups  []*group
+	started bool
+	closed  bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewManager creates a new rule manager that evaluates the rule groups
+// defined in the given Prometheus rule files.
+func NewManager(files []string, opts Options) (Manager, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		evaluator = newEvaluator(opts)
+		scope     = opts.InstrumentOptions().MetricsScope().SubScope("ruler")
+		logger    = opts.InstrumentOptions().Logger()
+		groups    []*group
+	)
+
+	for _, file := range files {
+		ruleGroups, errs := rulefmt.ParseFile(file)
+		if len(errs) > 0 {
+			multiErr := xerrors.NewMultiError()
+			for _, err := range errs {
+				multiErr = multiErr.Add(err)
+			}
+
+			return nil, fmt.Errorf("unable to load rule file %s: %v",
+				file, multiErr.FinalError())
+		}
+
+		for _, g := range ruleGroups.Groups {
+			interval := time.Duration(g.Interval)
+			if interval == 0 {
+				interval = opts.EvaluationInterval()
+			}
+
+			rules := make([]rule, 0, len(g.Rules))
+			for _, r := range g.Rules {
+				if r.Record != "" {
+					rules = append(rules, newRecordingRule(r.Record, r.Expr,
+						r.Labels, evaluator))
+					continue
+				}
+
+				rules = append(rules, newAlertingRule(r.Alert, r.Expr,
+					time.Duration(r.For), r.Labels, r.Annotations, evaluator))
+			}
+
+			groups = append(groups, &group{
+				name:     g.Name,
+				file:     file,
+				interval: interval,
+				timeout:  opts.QueryTimeout(),
+				rules:    rules,
+				nowFn:    opts.ClockOptions().NowFn(),
+				logger:   logger,
+				metrics: newGroupMetrics(scope.Tagged(map[string]string{
+					"rule-group": g.Name,
+				})),
+			})
+		}
+	}
+
+	return &manager{
+		groups:  groups,
+		closeCh: make(chan struct{}),
+	}, nil
+}
+
+func (m *manager) Start() error {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.closed {
+		return errManagerClosed
+	}
+	if m.started {
+		return errManagerAlreadyStarted
+	}
+
+	m.started = true
+	for _, g := range m.groups {
+		g := g
+		m.wg.Add(1)
+		go func() {
+			defer m.wg.Done()
+			g.run(m.closeCh)
+		}()
+	}
+
+	return nil
+}
+
+func (m *manager) Close() error {
+	m.Lock()
+	if m.closed {
+		m.Unlock()
+		return errManagerClosed
+	}
+	if !m.started {
+		m.Unlock()
+		return errManagerNotStarted
+	}
+
+	m.closed = true
+	close(m.closeCh)
+	m.Unlock()
+
+	m.wg.Wait()
+	return nil
+}
+
+func (m *manager) RuleGroups() []GroupStatus {
+	groups := make([]GroupStatus, 0, len(m.groups))
+	for _, g := range m.groups {
+		groups = append(groups, g.status())
+	}
+
+	return groups
+}
+
+func (m *manager) Alerts() []Alert {
+	var alerts []Alert
+	for _, g := range m.groups {
+		for _, r := range g.rules {
+			if r, ok := r.(*alertingRule); ok {
+				alerts = append(alerts, r.alerts()...)
+			}
+		}
+	}
+
+	return alerts
+}
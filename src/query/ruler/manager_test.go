@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/executor"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRuleFile = `
+groups:
+  - name: recording
+    interval: 30s
+    rules:
+      - record: job:up:sum
+        expr: sum(up) by (job)
+  - name: alerting
+    rules:
+      - alert: InstanceDown
+        expr: up == 0
+        for: 5m
+        labels:
+          severity: page
+        annotations:
+          summary: "{{ $labels.instance }} is down"
+`
+
+func writeRuleFile(t *testing.T, dir, contents string) string {
+	file := filepath.Join(dir, "rules.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(contents), 0644))
+	return file
+}
+
+func newTestManagerOptions(ctrl *gomock.Controller) Options {
+	return NewOptions().
+		SetEngine(executor.NewMockEngine(ctrl)).
+		SetDownsamplerAndWriter(ingest.NewMockDownsamplerAndWriter(ctrl)).
+		SetEvaluationInterval(time.Minute)
+}
+
+func TestManagerLoadsRuleGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "ruler")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := writeRuleFile(t, dir, testRuleFile)
+	m, err := NewManager([]string{file}, newTestManagerOptions(ctrl))
+	require.NoError(t, err)
+
+	groups := m.RuleGroups()
+	require.Len(t, groups, 2)
+
+	assert.Equal(t, "recording", groups[0].Name)
+	assert.Equal(t, file, groups[0].File)
+	assert.Equal(t, 30*time.Second, groups[0].Interval)
+	require.Len(t, groups[0].Rules, 1)
+	assert.Equal(t, RecordingRuleType, groups[0].Rules[0].Type)
+	assert.Equal(t, "job:up:sum", groups[0].Rules[0].Name)
+	assert.Equal(t, RuleHealthUnknown, groups[0].Rules[0].Health)
+
+	assert.Equal(t, "alerting", groups[1].Name)
+	assert.Equal(t, time.Minute, groups[1].Interval)
+	require.Len(t, groups[1].Rules, 1)
+	rule := groups[1].Rules[0]
+	assert.Equal(t, AlertingRuleType, rule.Type)
+	assert.Equal(t, "InstanceDown", rule.Name)
+	assert.Equal(t, "up == 0", rule.Query)
+	assert.Equal(t, 5*time.Minute, rule.For)
+	assert.Equal(t, map[string]string{"severity": "page"}, rule.Labels)
+	assert.Len(t, m.Alerts(), 0)
+
+	require.NoError(t, m.Start())
+	assert.Equal(t, errManagerAlreadyStarted, m.Start())
+	require.NoError(t, m.Close())
+	assert.Equal(t, errManagerClosed, m.Close())
+}
+
+func TestManagerInvalidRuleFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "ruler")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := writeRuleFile(t, dir, `
+groups:
+  - name: invalid
+    rules:
+      - record: foo
+        expr: sum(
+`)
+	_, err = NewManager([]string{file}, newTestManagerOptions(ctrl))
+	assert.Error(t, err)
+
+	_, err = NewManager([]string{filepath.Join(dir, "missing.yml")},
+		newTestManagerOptions(ctrl))
+	assert.Error(t, err)
+}
+
+func TestNewManagerInvalidOptions(t *testing.T) {
+	_, err := NewManager(nil, NewOptions())
+	assert.Equal(t, errNoEngine, err)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultEvaluationInterval = time.Minute
+	defaultQueryTimeout       = 2 * time.Minute
+)
+
+var (
+	errNoEngine                  = errors.New("no engine set")
+	errNoDownsamplerAndWriter    = errors.New("no downsampler and writer set")
+	errInvalidEvaluationInterval = errors.New("evaluation interval must be positive")
+	errInvalidQueryTimeout       = errors.New("query timeout must be positive")
+)
+
+type options struct {
+	engine               executor.Engine
+	downsamplerAndWriter ingest.DownsamplerAndWriter
+	tagOpts              models.TagOptions
+	evaluationInterval   time.Duration
+	queryTimeout         time.Duration
+	clockOpts            clock.Options
+	instrumentOpts       instrument.Options
+}
+
+// NewOptions creates a new set of rule manager options.
+func NewOptions() Options {
+	return &options{
+		tagOpts:            models.NewTagOptions(),
+		evaluationInterval: defaultEvaluationInterval,
+		queryTimeout:       defaultQueryTimeout,
+		clockOpts:          clock.NewOptions(),
+		instrumentOpts:     instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if o.engine == nil {
+		return errNoEngine
+	}
+	if o.downsamplerAndWriter == nil {
+		return errNoDownsamplerAndWriter
+	}
+	if o.evaluationInterval <= 0 {
+		return errInvalidEvaluationInterval
+	}
+	if o.queryTimeout <= 0 {
+		return errInvalidQueryTimeout
+	}
+
+	return o.tagOpts.Validate()
+}
+
+func (o *options) SetEngine(value executor.Engine) Options {
+	opts := *o
+	opts.engine = value
+	return &opts
+}
+
+func (o *options) Engine() executor.Engine {
+	return o.engine
+}
+
+func (o *options) SetDownsamplerAndWriter(value ingest.DownsamplerAndWriter) Options {
+	opts := *o
+	opts.downsamplerAndWriter = value
+	return &opts
+}
+
+func (o *options) DownsamplerAndWriter() ingest.DownsamplerAndWriter {
+	return o.downsamplerAndWriter
+}
+
+func (o *options) SetTagOptions(value models.TagOptions) Options {
+	opts := *o
+	opts.tagOpts = value
+	return &opts
+}
+
+func (o *options) TagOptions() models.TagOptions {
+	return o.tagOpts
+}
+
+func (o *options) SetEvaluationInterval(value time.Duration) Options {
+	opts := *o
+	opts.evaluationInterval = value
+	return &opts
+}
+
+func (o *options) EvaluationInterval() time.Duration {
+	return o.evaluationInterval
+}
+
+func (o *options) SetQueryTimeout(value time.Duration) Options {
+	opts := *o
+	opts.queryTimeout = value
+	return &opts
+}
+
+func (o *options) QueryTimeout() time.Duration {
+	return o.queryTimeout
+}
+
+func (o *options) SetClockOptions(value clock.Options) Options {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *options) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
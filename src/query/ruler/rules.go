@@ -0,0 +1,414 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/template"
+)
+
+const (
+	alertMetricName = "ALERTS"
+	alertNameLabel  = "alertname"
+	alertStateLabel = "alertstate"
+)
+
+var (
+	errDuplicateSeries = errors.New(
+		"vector contains metrics with the same labelset after applying rule labels")
+	errTemplateQueryNotSupported = errors.New(
+		"query function is not supported in rule templates")
+)
+
+type rule interface {
+	// ruleName returns the name of the rule.
+	ruleName() string
+
+	// eval evaluates the rule at the given time.
+	eval(ctx context.Context, at time.Time) error
+
+	// status returns a snapshot of the state of the rule.
+	status() RuleStatus
+}
+
+// ruleState tracks the health of a rule as of its last evaluation.
+type ruleState struct {
+	sync.RWMutex
+
+	health         RuleHealth
+	lastError      error
+	lastEvaluation time.Time
+}
+
+func newRuleState() ruleState {
+	return ruleState{health: RuleHealthUnknown}
+}
+
+func (s *ruleState) setEvaluation(at time.Time, err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.lastEvaluation = at
+	s.lastError = err
+	s.health = RuleHealthOK
+	if err != nil {
+		s.health = RuleHealthErr
+	}
+}
+
+func (s *ruleState) fillStatus(status *RuleStatus) {
+	s.RLock()
+	defer s.RUnlock()
+
+	status.Health = s.health
+	status.LastError = s.lastError
+	status.LastEvaluation = s.lastEvaluation
+}
+
+type recordingRule struct {
+	ruleState
+
+	name      string
+	query     string
+	labels    map[string]string
+	evaluator *evaluator
+}
+
+func newRecordingRule(
+	name string,
+	query string,
+	labels map[string]string,
+	evaluator *evaluator,
+) *recordingRule {
+	return &recordingRule{
+		ruleState: newRuleState(),
+		name:      name,
+		query:     query,
+		labels:    labels,
+		evaluator: evaluator,
+	}
+}
+
+func (r *recordingRule) ruleName() string {
+	return r.name
+}
+
+func (r *recordingRule) eval(ctx context.Context, at time.Time) error {
+	err := r.evalAndWrite(ctx, at)
+	r.setEvaluation(at, err)
+	return err
+}
+
+func (r *recordingRule) evalAndWrite(ctx context.Context, at time.Time) error {
+	samples, err := r.evaluator.query(ctx, r.query, at)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{}, len(samples))
+	for i, s := range samples {
+		tags := s.tags.SetName([]byte(r.name))
+		for name, value := range r.labels {
+			tags = tags.AddOrUpdateTag(models.Tag{
+				Name:  []byte(name),
+				Value: []byte(value),
+			})
+		}
+
+		id := string(tags.ID())
+		if _, ok := seen[id]; ok {
+			return errDuplicateSeries
+		}
+
+		seen[id] = struct{}{}
+		samples[i].tags = tags
+	}
+
+	for _, s := range samples {
+		if err := r.evaluator.write(ctx, s.tags, at, s.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *recordingRule) status() RuleStatus {
+	status := RuleStatus{
+		Type:   RecordingRuleType,
+		Name:   r.name,
+		Query:  r.query,
+		Labels: r.labels,
+	}
+
+	r.fillStatus(&status)
+	return status
+}
+
+type activeAlert struct {
+	labels      map[string]string
+	annotations map[string]string
+	state       AlertState
+	activeAt    time.Time
+	value       float64
+}
+
+type alertingRule struct {
+	ruleState
+
+	name        string
+	query       string
+	holdFor     time.Duration
+	labels      map[string]string
+	annotations map[string]string
+	evaluator   *evaluator
+
+	alertsLock sync.RWMutex
+	active     map[string]*activeAlert
+}
+
+func newAlertingRule(
+	name string,
+	query string,
+	holdFor time.Duration,
+	labels map[string]string,
+	annotations map[string]string,
+	evaluator *evaluator,
+) *alertingRule {
+	return &alertingRule{
+		ruleState:   newRuleState(),
+		name:        name,
+		query:       query,
+		holdFor:     holdFor,
+		labels:      labels,
+		annotations: annotations,
+		evaluator:   evaluator,
+		active:      make(map[string]*activeAlert),
+	}
+}
+
+func (r *alertingRule) ruleName() string {
+	return r.name
+}
+
+func (r *alertingRule) eval(ctx context.Context, at time.Time) error {
+	err := r.evalAndWrite(ctx, at)
+	r.setEvaluation(at, err)
+	return err
+}
+
+func (r *alertingRule) evalAndWrite(ctx context.Context, at time.Time) error {
+	samples, err := r.evaluator.query(ctx, r.query, at)
+	if err != nil {
+		return err
+	}
+
+	metricName := string(r.evaluator.tagOpts.MetricName())
+	results := make(map[string]*activeAlert, len(samples))
+	for _, s := range samples {
+		seriesLabels := tagsToLabels(s.tags)
+		delete(seriesLabels, metricName)
+
+		var (
+			labels      = make(map[string]string, len(seriesLabels)+len(r.labels)+1)
+			annotations = make(map[string]string, len(r.annotations))
+			expandErr   error
+		)
+
+		for name, value := range seriesLabels {
+			labels[name] = value
+		}
+
+		for name, text := range r.labels {
+			labels[name], expandErr = expandTemplate(name, text, seriesLabels, s.value, at)
+			if expandErr != nil {
+				return expandErr
+			}
+		}
+
+		labels[alertNameLabel] = r.name
+		for name, text := range r.annotations {
+			annotations[name], expandErr = expandTemplate(name, text, seriesLabels, s.value, at)
+			if expandErr != nil {
+				return expandErr
+			}
+		}
+
+		id := labelsID(labels)
+		if _, ok := results[id]; ok {
+			return errDuplicateSeries
+		}
+
+		results[id] = &activeAlert{
+			labels:      labels,
+			annotations: annotations,
+			value:       s.value,
+		}
+	}
+
+	r.alertsLock.Lock()
+	for id, result := range results {
+		if alert, ok := r.active[id]; ok {
+			alert.annotations = result.annotations
+			alert.value = result.value
+			continue
+		}
+
+		result.state = AlertStatePending
+		result.activeAt = at
+		r.active[id] = result
+	}
+
+	active := make([]*activeAlert, 0, len(r.active))
+	for id, alert := range r.active {
+		if _, ok := results[id]; !ok {
+			// NB: the alert is resolved, it is not retained since resolved
+			// alerts are not sent anywhere.
+			delete(r.active, id)
+			continue
+		}
+
+		if alert.state == AlertStatePending && at.Sub(alert.activeAt) >= r.holdFor {
+			alert.state = AlertStateFiring
+		}
+
+		active = append(active, &activeAlert{
+			labels: alert.labels,
+			state:  alert.state,
+		})
+	}
+	r.alertsLock.Unlock()
+
+	for _, alert := range active {
+		labels := make(map[string]string, len(alert.labels)+2)
+		for name, value := range alert.labels {
+			labels[name] = value
+		}
+
+		labels[metricName] = alertMetricName
+		labels[alertStateLabel] = alert.state.String()
+		tags := r.evaluator.newTags(labels)
+		if err := r.evaluator.write(ctx, tags, at, 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *alertingRule) alerts() []Alert {
+	r.alertsLock.RLock()
+	defer r.alertsLock.RUnlock()
+
+	alerts := make([]Alert, 0, len(r.active))
+	for _, alert := range r.active {
+		alerts = append(alerts, Alert{
+			Labels:      alert.labels,
+			Annotations: alert.annotations,
+			State:       alert.state,
+			ActiveAt:    alert.activeAt,
+			Value:       alert.value,
+		})
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return labelsID(alerts[i].Labels) < labelsID(alerts[j].Labels)
+	})
+
+	return alerts
+}
+
+func (r *alertingRule) status() RuleStatus {
+	status := RuleStatus{
+		Type:        AlertingRuleType,
+		Name:        r.name,
+		Query:       r.query,
+		For:         r.holdFor,
+		Labels:      r.labels,
+		Annotations: r.annotations,
+		Alerts:      r.alerts(),
+	}
+
+	r.fillStatus(&status)
+	return status
+}
+
+// expandTemplate expands a label or annotation template with the labels and
+// value of the series that raised the alert, matching Prometheus.
+func expandTemplate(
+	name string,
+	text string,
+	labels map[string]string,
+	value float64,
+	at time.Time,
+) (string, error) {
+	const defs = "{{$labels := .Labels}}{{$externalLabels := .ExternalLabels}}{{$value := .Value}}"
+	expander := template.NewTemplateExpander(context.Background(), defs+text,
+		"__alert_"+name, template.AlertTemplateData(labels, nil, value),
+		model.TimeFromUnixNano(at.UnixNano()), unsupportedTemplateQuery,
+		&url.URL{})
+	result, err := expander.Expand()
+	if err != nil {
+		return "", fmt.Errorf("unable to expand template %s: %v", name, err)
+	}
+
+	return result, nil
+}
+
+func unsupportedTemplateQuery(
+	context.Context,
+	string,
+	time.Time,
+) (promql.Vector, error) {
+	return nil, errTemplateQueryNotSupported
+}
+
+// labelsID returns a unique identifier for the label set.
+func labelsID(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(strconv.Quote(name))
+		b.WriteByte('=')
+		b.WriteString(strconv.Quote(labels[name]))
+		b.WriteByte(',')
+	}
+
+	return b.String()
+}
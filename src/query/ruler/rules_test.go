@@ -0,0 +1,217 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package ruler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testWrite struct {
+	labels map[string]string
+	at     time.Time
+	value  float64
+}
+
+type testSetup struct {
+	storage   mock.Storage
+	evaluator *evaluator
+	writes    []testWrite
+}
+
+func newTestSetup(t *testing.T, ctrl *gomock.Controller) *testSetup {
+	setup := &testSetup{storage: mock.NewMockStorage()}
+	writer := ingest.NewMockDownsamplerAndWriter(ctrl)
+	writer.EXPECT().
+		Write(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			tags models.Tags,
+			datapoints ts.Datapoints,
+			_ xtime.Unit,
+			_ []byte,
+			_ ingest.WriteOptions,
+		) error {
+			require.Len(t, datapoints, 1)
+			setup.writes = append(setup.writes, testWrite{
+				labels: tagsToLabels(tags),
+				at:     datapoints[0].Timestamp,
+				value:  datapoints[0].Value,
+			})
+			return nil
+		}).
+		AnyTimes()
+
+	engine := executor.NewEngine(executor.NewEngineOptions().
+		SetStore(setup.storage).
+		SetLookbackDuration(time.Minute).
+		SetInstrumentOptions(instrument.NewOptions()))
+	opts := NewOptions().
+		SetEngine(engine).
+		SetDownsamplerAndWriter(writer)
+	require.NoError(t, opts.Validate())
+	setup.evaluator = newEvaluator(opts)
+	return setup
+}
+
+// setValues sets the storage to return a single step block at the given time
+// with series named up0, up1, ... that have the given values.
+func (s *testSetup) setValues(at time.Time, values ...float64) {
+	seriesValues := make([][]float64, 0, len(values))
+	for _, v := range values {
+		seriesValues = append(seriesValues, []float64{v})
+	}
+
+	meta := block.Metadata{
+		Bounds: models.Bounds{
+			Start:    at,
+			Duration: time.Second,
+			StepSize: time.Second,
+		},
+		Tags:           models.NewTags(0, models.NewTagOptions()),
+		ResultMetadata: block.NewResultMetadata(),
+	}
+
+	b := test.NewBlockFromValuesWithMetaAndSeriesMeta(meta,
+		test.NewSeriesMeta("up", len(values)), seriesValues)
+	s.storage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+}
+
+func TestRecordingRuleEval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setup := newTestSetup(t, ctrl)
+	at := time.Unix(1000, 0)
+	setup.setValues(at, 1, 2)
+
+	r := newRecordingRule("job:up", "up", map[string]string{"team": "a"},
+		setup.evaluator)
+	assert.Equal(t, RuleHealthUnknown, r.status().Health)
+	require.NoError(t, r.eval(context.Background(), at))
+
+	assert.Equal(t, []testWrite{
+		{
+			labels: map[string]string{"__name__": "job:up", "up0": "up0", "team": "a"},
+			at:     at,
+			value:  1,
+		},
+		{
+			labels: map[string]string{"__name__": "job:up", "up1": "up1", "team": "a"},
+			at:     at,
+			value:  2,
+		},
+	}, setup.writes)
+
+	status := r.status()
+	assert.Equal(t, RecordingRuleType, status.Type)
+	assert.Equal(t, RuleHealthOK, status.Health)
+	assert.Equal(t, at, status.LastEvaluation)
+	assert.NoError(t, status.LastError)
+}
+
+func TestRecordingRuleEvalInvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	setup := newTestSetup(t, ctrl)
+	r := newRecordingRule("job:up", "sum(", nil, setup.evaluator)
+	require.Error(t, r.eval(context.Background(), time.Unix(1000, 0)))
+
+	status := r.status()
+	assert.Equal(t, RuleHealthErr, status.Health)
+	assert.Error(t, status.LastError)
+	assert.Len(t, setup.writes, 0)
+}
+
+func TestAlertingRuleEval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		setup = newTestSetup(t, ctrl)
+		start = time.Unix(1000, 0)
+		r     = newAlertingRule("UpHigh", "up", time.Minute,
+			map[string]string{"severity": "page"},
+			map[string]string{"summary": "{{ $labels.up0 }} is {{ $value }}"},
+			setup.evaluator)
+		labels = map[string]string{
+			"alertname": "UpHigh",
+			"severity":  "page",
+			"up0":       "up0",
+		}
+	)
+
+	setup.setValues(start, 5)
+	require.NoError(t, r.eval(context.Background(), start))
+	assert.Equal(t, []Alert{{
+		Labels:      labels,
+		Annotations: map[string]string{"summary": "up0 is 5"},
+		State:       AlertStatePending,
+		ActiveAt:    start,
+		Value:       5,
+	}}, r.alerts())
+	assert.Equal(t, AlertStatePending, r.status().State())
+
+	next := start.Add(time.Minute)
+	setup.setValues(next, 6)
+	require.NoError(t, r.eval(context.Background(), next))
+	alerts := r.alerts()
+	require.Len(t, alerts, 1)
+	assert.Equal(t, AlertStateFiring, alerts[0].State)
+	assert.Equal(t, start, alerts[0].ActiveAt)
+	assert.Equal(t, "up0 is 6", alerts[0].Annotations["summary"])
+
+	require.Len(t, setup.writes, 2)
+	assert.Equal(t, "pending", setup.writes[0].labels["alertstate"])
+	assert.Equal(t, "ALERTS", setup.writes[0].labels["__name__"])
+	assert.Equal(t, "firing", setup.writes[1].labels["alertstate"])
+	assert.Equal(t, next, setup.writes[1].at)
+	assert.Equal(t, float64(1), setup.writes[1].value)
+
+	// Once the series no longer matches the alert is resolved.
+	setup.setValues(next.Add(time.Minute))
+	require.NoError(t, r.eval(context.Background(), next.Add(time.Minute)))
+	assert.Len(t, r.alerts(), 0)
+	assert.Equal(t, AlertStateInactive, r.status().State())
+}
+
+func TestLabelsIDIsUnambiguous(t *testing.T) {
+	assert.NotEqual(t,
+		labelsID(map[string]string{"a": "b,c=d"}),
+		labelsID(map[string]string{"a": "b", "c": "d"}))
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package ruler evaluates Prometheus recording and alerting rules against
+// the query engine on a schedule.
+package ruler
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// AlertState is the state of an alert.
+type AlertState int
+
+const (
+	// AlertStateInactive is the state of an alert that is not active.
+	AlertStateInactive AlertState = iota
+	// AlertStatePending is the state of an active alert that has not yet
+	// been active for the duration of the rule.
+	AlertStatePending
+	// AlertStateFiring is the state of an active alert that has been active
+	// for at least the duration of the rule.
+	AlertStateFiring
+)
+
+func (s AlertState) String() string {
+	switch s {
+	case AlertStatePending:
+		return "pending"
+	case AlertStateFiring:
+		return "firing"
+	default:
+		return "inactive"
+	}
+}
+
+// RuleType is the type of a rule.
+type RuleType int
+
+const (
+	// RecordingRuleType is a rule that records the result of its expression
+	// as a new series.
+	RecordingRuleType RuleType = iota
+	// AlertingRuleType is a rule that raises alerts for each series in the
+	// result of its expression.
+	AlertingRuleType
+)
+
+func (t RuleType) String() string {
+	if t == AlertingRuleType {
+		return "alerting"
+	}
+
+	return "recording"
+}
+
+// RuleHealth describes the health of a rule as of its last evaluation.
+type RuleHealth string
+
+const (
+	// RuleHealthUnknown is the health of a rule that is yet to be evaluated.
+	RuleHealthUnknown RuleHealth = "unknown"
+	// RuleHealthOK is the health of a rule that last evaluated successfully.
+	RuleHealthOK RuleHealth = "ok"
+	// RuleHealthErr is the health of a rule that last failed to evaluate.
+	RuleHealthErr RuleHealth = "err"
+)
+
+// Alert is an active alert raised by an alerting rule.
+type Alert struct {
+	Labels      map[string]string
+	Annotations map[string]string
+	State       AlertState
+	ActiveAt    time.Time
+	Value       float64
+}
+
+// RuleStatus is a snapshot of the state of a rule.
+type RuleStatus struct {
+	Type           RuleType
+	Name           string
+	Query          string
+	For            time.Duration
+	Labels         map[string]string
+	Annotations    map[string]string
+	Alerts         []Alert
+	Health         RuleHealth
+	LastError      error
+	LastEvaluation time.Time
+}
+
+// State returns the most severe state of the alerts raised by the rule.
+func (s RuleStatus) State() AlertState {
+	state := AlertStateInactive
+	for _, a := range s.Alerts {
+		if a.State > state {
+			state = a.State
+		}
+	}
+
+	return state
+}
+
+// GroupStatus is a snapshot of the state of a rule group.
+type GroupStatus struct {
+	Name     string
+	File     string
+	Interval time.Duration
+	Rules    []RuleStatus
+}
+
+// Manager loads rule groups and evaluates them on their intervals.
+type Manager interface {
+	// Start starts evaluating the rule groups.
+	Start() error
+
+	// Close stops evaluating the rule groups, waiting for any in flight
+	// evaluations to complete.
+	Close() error
+
+	// RuleGroups returns a snapshot of the state of all rule groups.
+	RuleGroups() []GroupStatus
+
+	// Alerts returns all active alerts.
+	Alerts() []Alert
+}
+
+// Options are the options for the rule manager.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetEngine sets the engine used to evaluate rule expressions.
+	SetEngine(value executor.Engine) Options
+
+	// Engine returns the engine used to evaluate rule expressions.
+	Engine() executor.Engine
+
+	// SetDownsamplerAndWriter sets the writer used to write the results of
+	// recording rules and the ALERTS series for alerting rules.
+	SetDownsamplerAndWriter(value ingest.DownsamplerAndWriter) Options
+
+	// DownsamplerAndWriter returns the writer used to write the results of
+	// recording rules and the ALERTS series for alerting rules.
+	DownsamplerAndWriter() ingest.DownsamplerAndWriter
+
+	// SetTagOptions sets the tag options.
+	SetTagOptions(value models.TagOptions) Options
+
+	// TagOptions returns the tag options.
+	TagOptions() models.TagOptions
+
+	// SetEvaluationInterval sets the evaluation interval for rule groups
+	// that do not specify an interval.
+	SetEvaluationInterval(value time.Duration) Options
+
+	// EvaluationInterval returns the evaluation interval for rule groups
+	// that do not specify an interval.
+	EvaluationInterval() time.Duration
+
+	// SetQueryTimeout sets the timeout for evaluating a single rule.
+	SetQueryTimeout(value time.Duration) Options
+
+	// QueryTimeout returns the timeout for evaluating a single rule.
+	QueryTimeout() time.Duration
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+}
@@ -56,7 +56,13 @@ const (
 //
 //   cost.per_query.max_datapoints_hist: histogram; represents the
 //     distribution of the maximum datapoints used at any point in each query.
-func newConfiguredChainedEnforcer(cfg *config.Configuration, instrumentOptions instrument.Options) (qcost.ChainedEnforcer, error) {
+//
+// The per-query and per-block enforcers are also returned so that they can be
+// chained below the enforcers of tenants.
+func newConfiguredChainedEnforcer(
+	cfg *config.Configuration,
+	instrumentOptions instrument.Options,
+) (qcost.ChainedEnforcer, []cost.Enforcer, error) {
 	costScope := instrumentOptions.MetricsScope().SubScope("cost")
 	costIops := instrumentOptions.SetMetricsScope(costScope)
 	limitMgr := cost.NewStaticLimitManager(cfg.Limits.Global.AsLimitManagerOptions().SetInstrumentOptions(costIops))
@@ -83,11 +89,14 @@ func newConfiguredChainedEnforcer(cfg *config.Configuration, instrumentOptions i
 		nil,
 	)
 
-	return qcost.NewChainedEnforcer(qcost.GlobalLevel, []cost.Enforcer{
-		globalEnforcer,
-		queryEnforcer,
-		blockEnforcer,
-	})
+	queryEnforcers := []cost.Enforcer{queryEnforcer, blockEnforcer}
+	enforcer, err := qcost.NewChainedEnforcer(qcost.GlobalLevel,
+		append([]cost.Enforcer{globalEnforcer}, queryEnforcers...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return enforcer, queryEnforcers, nil
 }
 
 // globalReporter records ChainedEnforcer statistics for the global enforcer.
@@ -45,7 +45,7 @@ func TestNewConfiguredChainedEnforcer(t *testing.T) {
 		s := tally.NewTestScope("", nil)
 		iopts := instrument.NewOptions().SetMetricsScope(s)
 
-		globalEnforcer, err := newConfiguredChainedEnforcer(&config.Configuration{
+		globalEnforcer, _, err := newConfiguredChainedEnforcer(&config.Configuration{
 			Limits: config.LimitsConfiguration{
 				PerQuery: config.PerQueryLimitsConfiguration{
 					MaxFetchedDatapoints: perQueryLimit,
@@ -51,11 +51,13 @@ import (
 	"github.com/m3db/m3/src/query/policy/filter"
 	"github.com/m3db/m3/src/query/pools"
 	tsdbRemote "github.com/m3db/m3/src/query/remote"
+	"github.com/m3db/m3/src/query/ruler"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/fanout"
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/storage/remote"
 	"github.com/m3db/m3/src/query/stores/m3db"
+	"github.com/m3db/m3/src/query/tenant"
 	tsdb "github.com/m3db/m3/src/query/ts/m3db"
 	"github.com/m3db/m3/src/query/ts/m3db/consolidators"
 	"github.com/m3db/m3/src/x/clock"
@@ -207,7 +209,6 @@ func Run(runOpts RunOptions) {
 		clusterClient       clusterclient.Client
 		downsampler         downsample.Downsampler
 		fetchOptsBuilderCfg = cfg.Limits.PerQuery.AsFetchOptionsBuilderOptions()
-		fetchOptsBuilder    = handleroptions.NewFetchOptionsBuilder(fetchOptsBuilderCfg)
 		queryCtxOpts        = models.QueryContextOptions{
 			LimitMaxTimeseries: fetchOptsBuilderCfg.Limit,
 		}
@@ -294,15 +295,31 @@ func Run(runOpts RunOptions) {
 		defer cleanup()
 	}
 
-	perQueryEnforcer, err := newConfiguredChainedEnforcer(&cfg, instrumentOptions)
+	perQueryEnforcer, queryEnforcers, err := newConfiguredChainedEnforcer(&cfg,
+		instrumentOptions)
 	if err != nil {
 		logger.Fatal("unable to setup perQueryEnforcer", zap.Error(err))
 	}
 
+	var tenants tenant.Tenants
+	if tenantsCfg := cfg.Tenants; tenantsCfg != nil {
+		tenants, err = tenantsCfg.NewTenants(tenant.NewOptions().
+			SetGlobalEnforcer(perQueryEnforcer).
+			SetQueryEnforcers(queryEnforcers).
+			SetInstrumentOptions(instrumentOptions))
+		if err != nil {
+			logger.Fatal("unable to create tenants", zap.Error(err))
+		}
+
+		fetchOptsBuilderCfg.Tenants = tenants
+	}
+
+	fetchOptsBuilder := handleroptions.NewFetchOptionsBuilder(fetchOptsBuilderCfg)
 	engineOpts := executor.NewEngineOptions().
 		SetStore(backendStorage).
 		SetLookbackDuration(*cfg.LookbackDuration).
 		SetGlobalEnforcer(perQueryEnforcer).
+		SetTenants(tenants).
 		SetInstrumentOptions(instrumentOptions.
 			SetMetricsScope(instrumentOptions.MetricsScope().SubScope("engine")))
 	if fn := runOpts.CustomPromQLParseFunction; fn != nil {
@@ -340,6 +357,44 @@ func Run(runOpts RunOptions) {
 		logger.Fatal("unable to set up handler options", zap.Error(err))
 	}
 
+	if tenants != nil {
+		handlerOptions = handlerOptions.SetTenants(tenants)
+	}
+
+	if cacheCfg := cfg.ResultsCache; cacheCfg != nil {
+		resultsCache, err := cacheCfg.NewResultsCache(instrumentOptions)
+		if err != nil {
+			logger.Fatal("unable to create results cache", zap.Error(err))
+		}
+
+		handlerOptions = handlerOptions.SetQueryResultsCache(resultsCache)
+	}
+
+	if rulesCfg := cfg.Rules; rulesCfg != nil {
+		rulerOpts := ruler.NewOptions().
+			SetEngine(engine).
+			SetDownsamplerAndWriter(downsamplerAndWriter).
+			SetTagOptions(tagOptions).
+			SetInstrumentOptions(instrumentOptions)
+		if rulesCfg.EvaluationInterval != 0 {
+			rulerOpts = rulerOpts.SetEvaluationInterval(rulesCfg.EvaluationInterval)
+		}
+		if rulesCfg.QueryTimeout != 0 {
+			rulerOpts = rulerOpts.SetQueryTimeout(rulesCfg.QueryTimeout)
+		}
+
+		ruleManager, err := ruler.NewManager(rulesCfg.RuleFiles, rulerOpts)
+		if err != nil {
+			logger.Fatal("unable to create rule manager", zap.Error(err))
+		}
+		if err := ruleManager.Start(); err != nil {
+			logger.Fatal("unable to start rule manager", zap.Error(err))
+		}
+		defer ruleManager.Close()
+
+		handlerOptions = handlerOptions.SetRuleManager(ruleManager)
+	}
+
 	handler := httpd.NewHandler(handlerOptions, runOpts.CustomHandlers...)
 	if err := handler.RegisterRoutes(); err != nil {
 		logger.Fatal("unable to register routes", zap.Error(err))
@@ -508,10 +563,20 @@ func newM3DBStorage(
 	}
 
 	cleanup := func() error {
-		lastErr := storageCleanup()
+		var lastErr error
+		if downsampler != nil {
+			// NB: close the downsampler first as it flushes pending
+			// aggregated values to storage.
+			if err := downsampler.Close(); err != nil {
+				lastErr = errors.Wrap(err, "unable to close downsampler")
+				logger.Error("error during downsampler cleanup", zap.Error(err))
+			}
+		}
+
 		// Don't want to quit on the first error since the full cleanup is important
-		if lastErr != nil {
-			logger.Error("error during storage cleanup", zap.Error(lastErr))
+		if err := storageCleanup(); err != nil {
+			lastErr = err
+			logger.Error("error during storage cleanup", zap.Error(err))
 		}
 
 		if err := clusters.Close(); err != nil {
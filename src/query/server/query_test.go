@@ -323,7 +323,7 @@ func TestNewPerQueryEnforcer(t *testing.T) {
 			},
 		}
 
-		global, err := newConfiguredChainedEnforcer(cfg, instrument.NewOptions())
+		global, _, err := newConfiguredChainedEnforcer(cfg, instrument.NewOptions())
 		require.NoError(t, err)
 
 		queryLvl := global.Child(cost.QueryLevel)
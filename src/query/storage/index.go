@@ -89,9 +89,11 @@ func TagsToIdentTagIterator(tags models.Tags) ident.TagIterator {
 // FetchOptionsToM3Options converts a set of coordinator options to M3 options.
 func FetchOptionsToM3Options(fetchOptions *FetchOptions, fetchQuery *FetchQuery) index.QueryOptions {
 	return index.QueryOptions{
-		Limit:          fetchOptions.Limit,
-		StartInclusive: fetchQuery.Start,
-		EndExclusive:   fetchQuery.End,
+		Limit:             fetchOptions.Limit,
+		DocsLimit:         fetchOptions.DocsLimit,
+		RequireExhaustive: fetchOptions.RequireExhaustive,
+		StartInclusive:    fetchQuery.Start,
+		EndExclusive:      fetchQuery.End,
 	}
 }
 
@@ -127,15 +129,15 @@ func FetchQueryToM3Query(
 ) (index.Query, error) {
 	fetchQuery = fetchQuery.WithAppliedOptions(options)
 	matchers := fetchQuery.TagMatchers
-	// If no matchers provided, explicitly set this to an AllQuery.
-	if len(matchers) == 0 {
+	// If no matchers or index query provided, explicitly set this to an AllQuery.
+	if len(matchers) == 0 && fetchQuery.IndexQuery == "" {
 		return index.Query{
 			Query: idx.NewAllQuery(),
 		}, nil
 	}
 
 	// Optimization for single matcher case.
-	if len(matchers) == 1 {
+	if len(matchers) == 1 && fetchQuery.IndexQuery == "" {
 		q, err := matcherToQuery(matchers[0])
 		if err != nil {
 			return index.Query{}, err
@@ -144,7 +146,7 @@ func FetchQueryToM3Query(
 		return index.Query{Query: q}, nil
 	}
 
-	idxQueries := make([]idx.Query, len(matchers))
+	idxQueries := make([]idx.Query, len(matchers), len(matchers)+1)
 	var err error
 	for i, matcher := range matchers {
 		idxQueries[i], err = matcherToQuery(matcher)
@@ -153,6 +155,19 @@ func FetchQueryToM3Query(
 		}
 	}
 
+	if fetchQuery.IndexQuery != "" {
+		q, err := idx.ParseQuery(fetchQuery.IndexQuery)
+		if err != nil {
+			return index.Query{}, err
+		}
+
+		if len(idxQueries) == 0 {
+			return index.Query{Query: q}, nil
+		}
+
+		idxQueries = append(idxQueries, q)
+	}
+
 	q := idx.NewConjunctionQuery(idxQueries...)
 
 	return index.Query{Query: q}, nil
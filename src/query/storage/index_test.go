@@ -83,9 +83,10 @@ func TestFromIdentTagIteratorToTags(t *testing.T) {
 
 func TestFetchQueryToM3Query(t *testing.T) {
 	tests := []struct {
-		name     string
-		expected string
-		matchers models.Matchers
+		name       string
+		expected   string
+		matchers   models.Matchers
+		indexQuery string
 	}{
 		{
 			name:     "exact match",
@@ -189,6 +190,24 @@ func TestFetchQueryToM3Query(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:       "index query",
+			expected:   "disjunction(term(t1, v1), term(t1, v2))",
+			matchers:   models.Matchers{},
+			indexQuery: "t1:v1 OR t1:v2",
+		},
+		{
+			name:     "index query with matchers",
+			expected: "conjunction(term(t1, v1), disjunction(term(t2, v1), term(t2, v2)))",
+			matchers: models.Matchers{
+				{
+					Type:  models.MatchEqual,
+					Name:  []byte("t1"),
+					Value: []byte("v1"),
+				},
+			},
+			indexQuery: "t2:(v1 OR v2)",
+		},
 	}
 
 	for _, test := range tests {
@@ -196,6 +215,7 @@ func TestFetchQueryToM3Query(t *testing.T) {
 			fetchQuery := &FetchQuery{
 				Raw:         "up",
 				TagMatchers: test.matchers,
+				IndexQuery:  test.indexQuery,
 				Start:       now.Add(-5 * time.Minute),
 				End:         now,
 				Interval:    15 * time.Second,
@@ -208,6 +228,17 @@ func TestFetchQueryToM3Query(t *testing.T) {
 	}
 }
 
+func TestFetchQueryToM3QueryInvalidIndexQuery(t *testing.T) {
+	fetchQuery := &FetchQuery{
+		IndexQuery: "t1:(v1",
+		Start:      now.Add(-5 * time.Minute),
+		End:        now,
+	}
+
+	_, err := FetchQueryToM3Query(fetchQuery, nil)
+	require.Error(t, err)
+}
+
 func TestFetchOptionsToAggregateOptions(t *testing.T) {
 	fetchOptions := &FetchOptions{
 		Limit: 7,
@@ -135,7 +135,7 @@ func (q *FetchQuery) WithAppliedOptions(
 	// Since must apply matchers will always be small (usually 1)
 	// it's better to not allocate intermediate datastructure and just
 	// perform n^2 matching.
-	existing := result.TagMatchers
+	existing := make(models.Matchers, 0, len(result.TagMatchers)+len(restrict))
 	for _, existingMatcher := range result.TagMatchers {
 		willBeOverridden := false
 		for _, matcher := range restrict {
@@ -97,9 +97,12 @@ func (q *WriteQuery) query() {}
 type FetchQuery struct {
 	Raw         string
 	TagMatchers models.Matchers `json:"matchers"`
-	Start       time.Time       `json:"start"`
-	End         time.Time       `json:"end"`
-	Interval    time.Duration   `json:"interval"`
+	// IndexQuery is an optional Lucene-style query string which is matched
+	// in addition to the tag matchers, see idx.ParseQuery for the syntax.
+	IndexQuery string        `json:"query,omitempty"`
+	Start      time.Time     `json:"start"`
+	End        time.Time     `json:"end"`
+	Interval   time.Duration `json:"interval"`
 }
 
 // FetchOptions represents the options for fetch query.
@@ -108,6 +111,12 @@ type FetchOptions struct {
 	Remote bool
 	// Limit is the maximum number of series to return.
 	Limit int
+	// DocsLimit is the maximum number of index documents to match across
+	// index blocks before the query is aborted.
+	DocsLimit int
+	// RequireExhaustive results in an error if the query exceeds the series
+	// or docs limit rather than returning partial results.
+	RequireExhaustive bool
 	// BlockType is the block type that the fetch function returns.
 	BlockType models.FetchedBlockType
 	// FanoutOptions are the options for the fetch namespace fanout.
@@ -127,6 +136,8 @@ type FetchOptions struct {
 	// IncludeResolution if set, appends resolution information to fetch results.
 	// Currently only used for graphite queries.
 	IncludeResolution bool
+	// Tenant is the name of the tenant that issued the fetch, if any.
+	Tenant string
 }
 
 // FanoutOptions describes which namespaces should be fanned out to for
@@ -294,6 +305,8 @@ type WriteQuery struct {
 	Unit       xtime.Unit
 	Annotation []byte
 	Attributes Attributes
+	// Tenant is the name of the tenant that issued the write, if any.
+	Tenant string
 }
 
 func (q *WriteQuery) String() string {
@@ -181,6 +181,22 @@ func (s *AsyncSession) Aggregate(
 	return s.session.Aggregate(namespace, q, opts)
 }
 
+// DeleteTagged deletes the data within the time range [start, end) of the
+// series matching the query.
+func (s *AsyncSession) DeleteTagged(
+	namespace ident.ID,
+	q index.Query,
+	start, end time.Time,
+) (int64, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.session.DeleteTagged(namespace, q, start, end)
+}
+
 // ShardID returns the given shard for an ID for callers
 // to easily discern what shard is failing when operations
 // for given IDs begin failing.
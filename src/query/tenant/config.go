@@ -0,0 +1,116 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultTagName is the default name of the tag that identifies the
+	// series of a tenant.
+	DefaultTagName = "tenant"
+
+	defaultSeriesWindow = time.Hour
+)
+
+var (
+	errEmptyTenantName = errors.New("tenant name must not be empty")
+)
+
+// Configuration is the configuration for tenants.
+type Configuration struct {
+	// TagName is the name of the tag that is added to every series written
+	// by a tenant and that restricts the queries of a tenant.
+	TagName string `yaml:"tagName"`
+
+	// Required rejects requests that do not specify a tenant.
+	Required bool `yaml:"required"`
+
+	// RejectUnknown rejects requests that specify a tenant that is not
+	// explicitly configured.
+	RejectUnknown bool `yaml:"rejectUnknown"`
+
+	// SeriesWindow is the window over which distinct series written by a
+	// tenant are counted towards the max series limit.
+	SeriesWindow time.Duration `yaml:"seriesWindow"`
+
+	// DefaultLimits are the limits shared by all tenants that are not
+	// explicitly configured.
+	DefaultLimits LimitsConfiguration `yaml:"defaultLimits"`
+
+	// Tenants are the limits of explicitly configured tenants.
+	Tenants map[string]LimitsConfiguration `yaml:"tenants"`
+}
+
+// LimitsConfiguration is the configuration of the limits of a tenant, a zero
+// value disables the corresponding limit.
+type LimitsConfiguration struct {
+	// MaxWriteDatapointsPerSecond is the max number of datapoints the tenant
+	// can write per second.
+	MaxWriteDatapointsPerSecond int64 `yaml:"maxWriteDatapointsPerSecond"`
+
+	// MaxSeries is the max number of distinct series the tenant can write
+	// within the series window.
+	MaxSeries int `yaml:"maxSeries"`
+
+	// MaxFetchedDatapoints is the max number of datapoints that can be
+	// fetched by all in flight queries of the tenant.
+	MaxFetchedDatapoints int64 `yaml:"maxFetchedDatapoints"`
+}
+
+// NewTenants returns new tenants from the configuration.
+func (c Configuration) NewTenants(opts Options) (Tenants, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	for name := range c.Tenants {
+		if name == "" {
+			return nil, errEmptyTenantName
+		}
+	}
+
+	tagName := c.TagName
+	if tagName == "" {
+		tagName = DefaultTagName
+	}
+
+	seriesWindow := c.SeriesWindow
+	if seriesWindow < 0 {
+		return nil, fmt.Errorf("invalid tenant series window: %v", seriesWindow)
+	}
+	if seriesWindow == 0 {
+		seriesWindow = defaultSeriesWindow
+	}
+
+	return newTenants(tenantsOptions{
+		tagName:       []byte(tagName),
+		required:      c.Required,
+		rejectUnknown: c.RejectUnknown,
+		seriesWindow:  seriesWindow,
+		defaultLimits: c.DefaultLimits,
+		limits:        c.Tenants,
+		opts:          opts,
+	}), nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"errors"
+
+	qcost "github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/cost"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+var (
+	errNoGlobalEnforcer = errors.New("global enforcer not set")
+)
+
+type options struct {
+	globalEnforcer qcost.ChainedEnforcer
+	queryEnforcers []cost.Enforcer
+	clockOpts      clock.Options
+	instrumentOpts instrument.Options
+}
+
+// NewOptions returns a new set of tenant options.
+func NewOptions() Options {
+	return &options{
+		globalEnforcer: qcost.NoopChainedEnforcer(),
+		clockOpts:      clock.NewOptions(),
+		instrumentOpts: instrument.NewOptions(),
+	}
+}
+
+func (o *options) Validate() error {
+	if o.globalEnforcer == nil {
+		return errNoGlobalEnforcer
+	}
+	return nil
+}
+
+func (o *options) SetGlobalEnforcer(value qcost.ChainedEnforcer) Options {
+	opts := *o
+	opts.globalEnforcer = value
+	return &opts
+}
+
+func (o *options) GlobalEnforcer() qcost.ChainedEnforcer {
+	return o.globalEnforcer
+}
+
+func (o *options) SetQueryEnforcers(value []cost.Enforcer) Options {
+	opts := *o
+	opts.queryEnforcers = value
+	return &opts
+}
+
+func (o *options) QueryEnforcers() []cost.Enforcer {
+	return o.queryEnforcers
+}
+
+func (o *options) SetClockOptions(value clock.Options) Options {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *options) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
@@ -0,0 +1,382 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/aggregator/rate"
+	qcost "github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/cost"
+	xerrors "github.com/m3db/m3/src/x/errors"
+
+	"github.com/uber-go/tally"
+)
+
+var (
+	// ErrTenantRequired is returned when a request does not specify a tenant
+	// but tenants are required.
+	ErrTenantRequired = errors.New("tenant required")
+
+	// ErrUnknownTenant is returned when a request specifies a tenant that is
+	// not configured and unknown tenants are rejected.
+	ErrUnknownTenant = errors.New("unknown tenant")
+)
+
+// unconfiguredTenantsName is the name the shared limits and metrics of
+// tenants that are not explicitly configured are reported under.
+const unconfiguredTenantsName = "unconfigured"
+
+type tenantsOptions struct {
+	tagName       []byte
+	required      bool
+	rejectUnknown bool
+	seriesWindow  time.Duration
+	defaultLimits LimitsConfiguration
+	limits        map[string]LimitsConfiguration
+	opts          Options
+}
+
+type tenants struct {
+	sync.RWMutex
+
+	tenantsOptions
+	scope        tally.Scope
+	tenants      map[string]*tenant
+	unconfigured *tenantState
+}
+
+func newTenants(opts tenantsOptions) *tenants {
+	return &tenants{
+		tenantsOptions: opts,
+		scope:          opts.opts.InstrumentOptions().MetricsScope().SubScope("tenant"),
+		tenants:        make(map[string]*tenant),
+	}
+}
+
+func (t *tenants) Resolve(name string) (Tenant, error) {
+	if name == "" {
+		if t.required {
+			return nil, ErrTenantRequired
+		}
+		return nil, nil
+	}
+
+	t.RLock()
+	existing, ok := t.tenants[name]
+	t.RUnlock()
+	if ok {
+		return existing, nil
+	}
+
+	limits, ok := t.limits[name]
+	if !ok {
+		if t.rejectUnknown {
+			return nil, ErrUnknownTenant
+		}
+		return t.resolveUnconfigured(name)
+	}
+
+	t.Lock()
+	defer t.Unlock()
+
+	if existing, ok := t.tenants[name]; ok {
+		return existing, nil
+	}
+
+	state, err := newTenantState(name, limits, t.tenantsOptions, t.scope)
+	if err != nil {
+		return nil, err
+	}
+
+	created := newTenant(name, t.tagName, state)
+	t.tenants[name] = created
+	return created, nil
+}
+
+// resolveUnconfigured returns a tenant that is isolated by its own tag but
+// shares its limits and metrics with every other tenant that is not
+// explicitly configured. NB: Unconfigured tenants are not retained so that
+// clients specifying arbitrary tenants cannot grow memory or the cardinality
+// of metrics without bound.
+func (t *tenants) resolveUnconfigured(name string) (Tenant, error) {
+	t.RLock()
+	state := t.unconfigured
+	t.RUnlock()
+
+	if state == nil {
+		t.Lock()
+		if t.unconfigured == nil {
+			created, err := newTenantState(unconfiguredTenantsName,
+				t.defaultLimits, t.tenantsOptions, t.scope)
+			if err != nil {
+				t.Unlock()
+				return nil, err
+			}
+			t.unconfigured = created
+		}
+		state = t.unconfigured
+		t.Unlock()
+	}
+
+	return newTenant(name, t.tagName, state), nil
+}
+
+type tenantMetrics struct {
+	writtenDatapoints tally.Counter
+	rateLimited       tally.Counter
+	seriesLimited     tally.Counter
+	series            tally.Gauge
+	fetchedDatapoints tally.Gauge
+	fetchOverLimit    tally.Counter
+}
+
+func newTenantMetrics(scope tally.Scope) tenantMetrics {
+	return tenantMetrics{
+		writtenDatapoints: scope.Counter("written_datapoints"),
+		rateLimited: scope.Tagged(map[string]string{
+			"reason": "write_rate",
+		}).Counter("write_rejected"),
+		seriesLimited: scope.Tagged(map[string]string{
+			"reason": "max_series",
+		}).Counter("write_rejected"),
+		series:            scope.Gauge("series"),
+		fetchedDatapoints: scope.Gauge("fetched_datapoints"),
+		fetchOverLimit:    scope.Counter("fetch_over_limit"),
+	}
+}
+
+type tenant struct {
+	*tenantState
+
+	name string
+	tag  models.Tag
+}
+
+func newTenant(name string, tagName []byte, state *tenantState) *tenant {
+	return &tenant{
+		tenantState: state,
+		name:        name,
+		tag:         models.Tag{Name: tagName, Value: []byte(name)},
+	}
+}
+
+// tenantState is the limits and metrics of a tenant, which are shared by all
+// tenants that are not explicitly configured.
+type tenantState struct {
+	nowFn        clock.NowFn
+	limits       LimitsConfiguration
+	limiter      *rate.Limiter
+	seriesWindow time.Duration
+	enforcer     qcost.ChainedEnforcer
+	metrics      tenantMetrics
+
+	seriesLock        sync.Mutex
+	seriesWindowStart time.Time
+	series            map[uint64]struct{}
+}
+
+func newTenantState(
+	name string,
+	limits LimitsConfiguration,
+	opts tenantsOptions,
+	scope tally.Scope,
+) (*tenantState, error) {
+	var (
+		nowFn   = opts.opts.ClockOptions().NowFn()
+		metrics = newTenantMetrics(scope.Tagged(map[string]string{
+			"tenant": name,
+		}))
+		limiter *rate.Limiter
+	)
+	if limits.MaxWriteDatapointsPerSecond > 0 {
+		limiter = rate.NewLimiter(limits.MaxWriteDatapointsPerSecond, nowFn)
+	}
+
+	local := cost.NewEnforcer(
+		cost.NewStaticLimitManager(cost.NewLimitManagerOptions().
+			SetDefaultLimit(cost.Limit{
+				Threshold: cost.Cost(limits.MaxFetchedDatapoints),
+				Enabled:   limits.MaxFetchedDatapoints > 0,
+			})),
+		cost.NewTracker(),
+		cost.NewEnforcerOptions().
+			SetReporter(&tenantReporter{metrics: metrics}).
+			SetCostExceededMessage(
+				fmt.Sprintf("tenant %s maxFetchedDatapoints exceeded", name)),
+	)
+
+	enforcers := append([]cost.Enforcer{local}, opts.opts.QueryEnforcers()...)
+	enforcer, err := qcost.NewChildChainedEnforcer(opts.opts.GlobalEnforcer(),
+		qcost.TenantLevel, enforcers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenantState{
+		nowFn:        nowFn,
+		limits:       limits,
+		limiter:      limiter,
+		seriesWindow: opts.seriesWindow,
+		enforcer:     enforcer,
+		metrics:      metrics,
+		series:       make(map[uint64]struct{}),
+	}, nil
+}
+
+func (t *tenant) Name() string {
+	return t.name
+}
+
+func (t *tenant) Tags(tags models.Tags) models.Tags {
+	// NB: Copy the tags since they may be shared with the caller and the
+	// tenant tag replaces the value of an existing tag in place.
+	copied := make([]models.Tag, len(tags.Tags), len(tags.Tags)+1)
+	copy(copied, tags.Tags)
+	tags.Tags = copied
+	return tags.AddOrUpdateTag(t.tag)
+}
+
+func (t *tenant) CheckWrite(tags models.Tags, numDatapoints int) error {
+	if err := t.checkSeries(tags); err != nil {
+		t.metrics.seriesLimited.Inc(1)
+		return err
+	}
+
+	if t.limiter != nil && !t.limiter.IsAllowed(int64(numDatapoints)) {
+		t.metrics.rateLimited.Inc(1)
+		return xerrors.NewInvalidParamsError(fmt.Errorf(
+			"tenant %s exceeded write limit of %d datapoints per second",
+			t.name, t.limits.MaxWriteDatapointsPerSecond))
+	}
+
+	t.metrics.writtenDatapoints.Inc(int64(numDatapoints))
+	return nil
+}
+
+func (t *tenant) checkSeries(tags models.Tags) error {
+	if t.limits.MaxSeries <= 0 {
+		return nil
+	}
+
+	id := tags.HashedID()
+	now := t.nowFn()
+
+	t.seriesLock.Lock()
+	defer t.seriesLock.Unlock()
+
+	if now.Sub(t.seriesWindowStart) >= t.seriesWindow {
+		t.seriesWindowStart = now
+		t.series = make(map[uint64]struct{}, len(t.series))
+	}
+
+	if _, ok := t.series[id]; ok {
+		return nil
+	}
+
+	if len(t.series) >= t.limits.MaxSeries {
+		return xerrors.NewInvalidParamsError(fmt.Errorf(
+			"tenant %s exceeded limit of %d series per %v",
+			t.name, t.limits.MaxSeries, t.seriesWindow))
+	}
+
+	t.series[id] = struct{}{}
+	t.metrics.series.Update(float64(len(t.series)))
+	return nil
+}
+
+func (t *tenant) FetchOptions(opts *storage.FetchOptions) *storage.FetchOptions {
+	result := opts.Clone()
+	result.Tenant = t.name
+
+	restrict := &storage.RestrictQueryOptions{}
+	if opts.RestrictQueryOptions != nil {
+		*restrict = *opts.RestrictQueryOptions
+	}
+
+	restrictByTag := &storage.RestrictByTag{}
+	if restrict.RestrictByTag != nil {
+		*restrictByTag = *restrict.RestrictByTag
+	}
+
+	// NB: The tenant matcher replaces any existing matcher on the tenant tag
+	// so that requests can never query the series of other tenants.
+	matchers := make(models.Matchers, 0, len(restrictByTag.Restrict)+1)
+	for _, m := range restrictByTag.Restrict {
+		if !bytes.Equal(m.Name, t.tag.Name) {
+			matchers = append(matchers, m)
+		}
+	}
+	matchers = append(matchers, models.Matcher{
+		Type:  models.MatchEqual,
+		Name:  t.tag.Name,
+		Value: t.tag.Value,
+	})
+	restrictByTag.Restrict = matchers
+
+	if restrictByTag.Strip != nil {
+		strip := make([][]byte, 0, len(restrictByTag.Strip)+1)
+		for _, name := range restrictByTag.Strip {
+			if !bytes.Equal(name, t.tag.Name) {
+				strip = append(strip, name)
+			}
+		}
+		restrictByTag.Strip = append(strip, t.tag.Name)
+	}
+
+	restrict.RestrictByTag = restrictByTag
+	result.RestrictQueryOptions = restrict
+	return result
+}
+
+func (t *tenant) Enforcer() qcost.ChainedEnforcer {
+	return t.enforcer
+}
+
+// tenantReporter reports the query cost of a tenant.
+type tenantReporter struct {
+	metrics tenantMetrics
+}
+
+var _ qcost.ChainedReporter = (*tenantReporter)(nil)
+
+func (r *tenantReporter) ReportCost(_ cost.Cost) {}
+
+func (r *tenantReporter) ReportCurrent(c cost.Cost) {
+	r.metrics.fetchedDatapoints.Update(float64(c))
+}
+
+func (r *tenantReporter) ReportOverLimit(enabled bool) {
+	if enabled {
+		r.metrics.fetchOverLimit.Inc(1)
+	}
+}
+
+func (r *tenantReporter) OnChildClose(_ cost.Cost) {}
+
+func (r *tenantReporter) OnClose(_ cost.Cost) {}
@@ -0,0 +1,284 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tenant
+
+import (
+	"testing"
+	"time"
+
+	qcost "github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/cost"
+	xerrors "github.com/m3db/m3/src/x/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTenants(t *testing.T, cfg Configuration, nowFn clock.NowFn) Tenants {
+	opts := NewOptions()
+	if nowFn != nil {
+		opts = opts.SetClockOptions(clock.NewOptions().SetNowFn(nowFn))
+	}
+	tenants, err := cfg.NewTenants(opts)
+	require.NoError(t, err)
+	return tenants
+}
+
+func TestTenantsResolve(t *testing.T) {
+	tenants := newTestTenants(t, Configuration{}, nil)
+
+	resolved, err := tenants.Resolve("")
+	require.NoError(t, err)
+	assert.Nil(t, resolved)
+
+	resolved, err = tenants.Resolve("foo")
+	require.NoError(t, err)
+	require.NotNil(t, resolved)
+	assert.Equal(t, "foo", resolved.Name())
+
+	tenants = newTestTenants(t, Configuration{
+		Tenants: map[string]LimitsConfiguration{"foo": {}},
+	}, nil)
+
+	resolved, err = tenants.Resolve("foo")
+	require.NoError(t, err)
+	again, err := tenants.Resolve("foo")
+	require.NoError(t, err)
+	assert.True(t, resolved == again)
+
+	tenants = newTestTenants(t, Configuration{
+		Required:      true,
+		RejectUnknown: true,
+		Tenants:       map[string]LimitsConfiguration{"foo": {}},
+	}, nil)
+
+	_, err = tenants.Resolve("")
+	assert.Equal(t, ErrTenantRequired, err)
+
+	_, err = tenants.Resolve("bar")
+	assert.Equal(t, ErrUnknownTenant, err)
+
+	resolved, err = tenants.Resolve("foo")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", resolved.Name())
+}
+
+func TestTenantsResolveUnconfiguredSharesLimits(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	all := newTestTenants(t, Configuration{
+		DefaultLimits: LimitsConfiguration{MaxWriteDatapointsPerSecond: 10},
+		Tenants: map[string]LimitsConfiguration{
+			"baz": {MaxWriteDatapointsPerSecond: 10},
+		},
+	}, func() time.Time { return now })
+
+	foo, err := all.Resolve("foo")
+	require.NoError(t, err)
+	bar, err := all.Resolve("bar")
+	require.NoError(t, err)
+	baz, err := all.Resolve("baz")
+	require.NoError(t, err)
+
+	// Unconfigured tenants keep their own tag.
+	assert.Equal(t, "bar", bar.Name())
+	value, ok := bar.Tags(models.NewTags(0, nil)).Get([]byte("tenant"))
+	require.True(t, ok)
+	assert.Equal(t, "bar", string(value))
+
+	// But share the default limits.
+	require.NoError(t, foo.CheckWrite(foo.Tags(models.NewTags(0, nil)), 10))
+	require.Error(t, bar.CheckWrite(bar.Tags(models.NewTags(0, nil)), 1))
+
+	// Configured tenants are unaffected.
+	require.NoError(t, baz.CheckWrite(baz.Tags(models.NewTags(0, nil)), 10))
+
+	// Only configured tenants are retained.
+	assert.Equal(t, 1, len(all.(*tenants).tenants))
+}
+
+func TestTenantTags(t *testing.T) {
+	tenants := newTestTenants(t, Configuration{TagName: "team"}, nil)
+	resolved, err := tenants.Resolve("foo")
+	require.NoError(t, err)
+
+	tags := models.NewTags(2, nil).AddTags([]models.Tag{
+		{Name: []byte("__name__"), Value: []byte("up")},
+		{Name: []byte("team"), Value: []byte("bar")},
+	})
+
+	result := resolved.Tags(tags)
+	value, ok := result.Get([]byte("team"))
+	require.True(t, ok)
+	assert.Equal(t, "foo", string(value))
+	assert.Equal(t, 2, result.Len())
+
+	// Ensure the original tags are not mutated.
+	value, ok = tags.Get([]byte("team"))
+	require.True(t, ok)
+	assert.Equal(t, "bar", string(value))
+
+	result = resolved.Tags(models.NewTags(0, nil))
+	value, ok = result.Get([]byte("team"))
+	require.True(t, ok)
+	assert.Equal(t, "foo", string(value))
+}
+
+func TestTenantCheckWriteRateLimit(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	tenants := newTestTenants(t, Configuration{
+		DefaultLimits: LimitsConfiguration{MaxWriteDatapointsPerSecond: 10},
+	}, func() time.Time { return now })
+	resolved, err := tenants.Resolve("foo")
+	require.NoError(t, err)
+
+	tags := resolved.Tags(models.NewTags(0, nil))
+	require.NoError(t, resolved.CheckWrite(tags, 6))
+	require.NoError(t, resolved.CheckWrite(tags, 4))
+
+	err = resolved.CheckWrite(tags, 1)
+	require.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+
+	now = now.Add(time.Second)
+	require.NoError(t, resolved.CheckWrite(tags, 10))
+}
+
+func TestTenantCheckWriteMaxSeries(t *testing.T) {
+	now := time.Now()
+	tenants := newTestTenants(t, Configuration{
+		SeriesWindow:  time.Minute,
+		DefaultLimits: LimitsConfiguration{MaxSeries: 2},
+	}, func() time.Time { return now })
+	resolved, err := tenants.Resolve("foo")
+	require.NoError(t, err)
+
+	series := func(name string) models.Tags {
+		return resolved.Tags(models.NewTags(1, nil).SetName([]byte(name)))
+	}
+
+	require.NoError(t, resolved.CheckWrite(series("a"), 1))
+	require.NoError(t, resolved.CheckWrite(series("b"), 1))
+	require.NoError(t, resolved.CheckWrite(series("a"), 1))
+
+	err = resolved.CheckWrite(series("c"), 1)
+	require.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, resolved.CheckWrite(series("c"), 1))
+}
+
+func TestTenantFetchOptions(t *testing.T) {
+	tenants := newTestTenants(t, Configuration{}, nil)
+	resolved, err := tenants.Resolve("foo")
+	require.NoError(t, err)
+
+	opts := storage.NewFetchOptions()
+	opts.RestrictQueryOptions = &storage.RestrictQueryOptions{
+		RestrictByTag: &storage.RestrictByTag{
+			Restrict: models.Matchers{
+				{Type: models.MatchEqual, Name: []byte("tenant"), Value: []byte("bar")},
+				{Type: models.MatchEqual, Name: []byte("env"), Value: []byte("prod")},
+			},
+			Strip: [][]byte{[]byte("env")},
+		},
+	}
+
+	result := resolved.FetchOptions(opts)
+	assert.Equal(t, "foo", result.Tenant)
+	assert.Equal(t, "", opts.Tenant)
+
+	restrict := result.RestrictQueryOptions.GetRestrictByTag()
+	require.NotNil(t, restrict)
+	assert.Equal(t, models.Matchers{
+		{Type: models.MatchEqual, Name: []byte("env"), Value: []byte("prod")},
+		{Type: models.MatchEqual, Name: []byte("tenant"), Value: []byte("foo")},
+	}, restrict.Restrict)
+	assert.Equal(t, [][]byte{[]byte("env"), []byte("tenant")}, restrict.Strip)
+
+	// Ensure the original options are not mutated.
+	assert.Equal(t, 2, len(opts.RestrictQueryOptions.RestrictByTag.Restrict))
+	assert.Equal(t, "bar",
+		string(opts.RestrictQueryOptions.RestrictByTag.Restrict[0].Value))
+	assert.Equal(t, 1, len(opts.RestrictQueryOptions.RestrictByTag.Strip))
+
+	result = resolved.FetchOptions(storage.NewFetchOptions())
+	restrict = result.RestrictQueryOptions.GetRestrictByTag()
+	require.NotNil(t, restrict)
+	assert.Equal(t, [][]byte{[]byte("tenant")}, restrict.GetFilterByNames())
+}
+
+func TestTenantEnforcer(t *testing.T) {
+	newEnforcer := func(threshold float64) cost.Enforcer {
+		return cost.NewEnforcer(
+			cost.NewStaticLimitManager(cost.NewLimitManagerOptions().
+				SetDefaultLimit(cost.Limit{
+					Threshold: cost.Cost(threshold),
+					Enabled:   true,
+				})),
+			cost.NewTracker(),
+			nil,
+		)
+	}
+
+	global, err := qcost.NewChainedEnforcer(qcost.GlobalLevel,
+		[]cost.Enforcer{newEnforcer(100)})
+	require.NoError(t, err)
+
+	tenants, err := Configuration{
+		Tenants: map[string]LimitsConfiguration{
+			"foo": {MaxFetchedDatapoints: 10},
+			"bar": {MaxFetchedDatapoints: 10},
+		},
+	}.NewTenants(NewOptions().
+		SetGlobalEnforcer(global).
+		SetQueryEnforcers([]cost.Enforcer{newEnforcer(8)}))
+	require.NoError(t, err)
+
+	foo, err := tenants.Resolve("foo")
+	require.NoError(t, err)
+	bar, err := tenants.Resolve("bar")
+	require.NoError(t, err)
+
+	query1 := foo.Enforcer().Child(qcost.QueryLevel)
+	query2 := foo.Enforcer().Child(qcost.QueryLevel)
+	require.NoError(t, query1.Add(6).Error)
+
+	// Exceeds the limit of the tenant but not of the query.
+	require.Error(t, query2.Add(6).Error)
+
+	// Other tenants are unaffected.
+	query3 := bar.Enforcer().Child(qcost.QueryLevel)
+	require.NoError(t, query3.Add(6).Error)
+
+	report, _ := global.State()
+	assert.Equal(t, cost.Cost(18), report.Cost)
+
+	query1.Close()
+	query2.Close()
+	query3.Close()
+
+	report, _ = global.State()
+	assert.Equal(t, cost.Cost(0), report.Cost)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tenant provides isolation and limits for the writes and queries of
+// the tenants sharing a coordinator.
+package tenant
+
+import (
+	qcost "github.com/m3db/m3/src/query/cost"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/cost"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Tenants resolves the tenants of requests.
+type Tenants interface {
+	// Resolve returns the tenant with the given name, or nil if the name is
+	// empty and requests are not required to specify a tenant.
+	Resolve(name string) (Tenant, error)
+}
+
+// Tenant isolates and limits the writes and queries of a single tenant.
+type Tenant interface {
+	// Name returns the name of the tenant.
+	Name() string
+
+	// Tags returns the tags of a series written by the tenant, which always
+	// include the tenant tag.
+	Tags(tags models.Tags) models.Tags
+
+	// CheckWrite returns an error if writing datapoints for the series with
+	// the given tags exceeds the write rate or series limits of the tenant,
+	// the tags must already include the tenant tag.
+	CheckWrite(tags models.Tags, numDatapoints int) error
+
+	// FetchOptions returns a copy of the fetch options that restricts fetches
+	// to the series of the tenant.
+	FetchOptions(opts *storage.FetchOptions) *storage.FetchOptions
+
+	// Enforcer returns the enforcer of the query cost of the tenant, the
+	// enforcers of queries by the tenant must be children of this enforcer.
+	Enforcer() qcost.ChainedEnforcer
+}
+
+// Options are the options for tenants.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetGlobalEnforcer sets the enforcer shared by all queries, the costs of
+	// queries by tenants roll up to this enforcer.
+	SetGlobalEnforcer(value qcost.ChainedEnforcer) Options
+
+	// GlobalEnforcer returns the enforcer shared by all queries.
+	GlobalEnforcer() qcost.ChainedEnforcer
+
+	// SetQueryEnforcers sets the per query and per block enforcers that are
+	// chained to the enforcer of each tenant.
+	SetQueryEnforcers(value []cost.Enforcer) Options
+
+	// QueryEnforcers returns the per query and per block enforcers that are
+	// chained to the enforcer of each tenant.
+	QueryEnforcers() []cost.Enforcer
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+}